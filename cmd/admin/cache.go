@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newCacheCommand builds the cache command and its subcommands.
+func newCacheCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the search cache",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove every cached search result and content",
+		Long: `Remove every cached search result and content, e.g. after editing contents in the
+database directly. Instances with the local cache tier keep serving their local
+entries for up to cache.local.ttl.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := opts.context(cmd)
+			defer cancel()
+
+			b, err := opts.backend()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = b.Close() }()
+
+			if err := b.ClearCache(ctx); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "cache cleared")
+
+			return nil
+		},
+	})
+
+	return cmd
+}
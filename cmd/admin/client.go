@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// apiClient runs operations through the HTTP API of a running service. Unlike the
+// database backend, syncs queue behind the service's own syncs and publish events
+// (cache invalidation, webhooks, sync history) as manual syncs from the API do.
+type apiClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// newAPIClient creates a client of the service at baseURL. apiKey is optional and
+// sent as a bearer token when set.
+func newAPIClient(baseURL, apiKey string) *apiClient {
+	return &apiClient{
+		baseURL: strings.TrimRight(baseURL, "/") + "/api/v2",
+		apiKey:  apiKey,
+		http:    &http.Client{}, // Bounded by the command context; exports can take a while
+	}
+}
+
+// Sync calls POST /admin/sync, or POST /admin/sync/:provider for a single provider.
+func (c *apiClient) Sync(ctx context.Context, provider string) (dto.SyncResponse, error) {
+	if provider == "" {
+		var resp dto.SyncResponse
+		err := c.call(ctx, http.MethodPost, "/admin/sync", nil, func(body io.Reader) error {
+			return json.NewDecoder(body).Decode(&resp)
+		})
+
+		return resp, err
+	}
+
+	var result dto.SyncResultResponse
+	err := c.call(ctx, http.MethodPost, "/admin/sync/"+url.PathEscape(provider), nil, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(&result)
+	})
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	return dto.SyncResponse{
+		Results: []dto.SyncResultResponse{result},
+		Summary: dto.SyncSummary{TotalSynced: result.Count, ProvidersOK: 1},
+	}, nil
+}
+
+// ClearCache calls DELETE /admin/cache.
+func (c *apiClient) ClearCache(ctx context.Context) error {
+	return c.call(ctx, http.MethodDelete, "/admin/cache", nil, nil)
+}
+
+// Export copies the body of GET /contents/export to w.
+func (c *apiClient) Export(ctx context.Context, w io.Writer, req dto.ExportRequest) error {
+	query := url.Values{}
+	for name, value := range map[string]string{"format": req.Format, "q": req.Query, "type": req.Type} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	return c.call(ctx, http.MethodGet, "/contents/export", query, func(body io.Reader) error {
+		_, err := io.Copy(w, body)
+
+		return err
+	})
+}
+
+// Close implements backend; the client holds no connections of its own.
+func (c *apiClient) Close() error {
+	return nil
+}
+
+// call sends a request and hands the body of a successful response to read (which
+// can be nil). Error responses are returned as errors carrying their problem detail.
+func (c *apiClient) call(ctx context.Context, method, path string, query url.Values, read func(body io.Reader) error) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	// Transport errors already name the method and URL
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(method, path, resp)
+	}
+	if read == nil {
+		return nil
+	}
+	if err := read(resp.Body); err != nil {
+		return fmt.Errorf("reading %s %s response: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// responseError describes an error response, preferring its problem detail.
+func responseError(method, path string, resp *http.Response) error {
+	var problem dto.ProblemResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&problem); err != nil || problem.Detail == "" {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+
+	return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, problem.Detail)
+}
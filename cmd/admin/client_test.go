@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runAgainst executes the CLI with args against an API served by handler and
+// returns its output.
+func runAgainst(t *testing.T, handler http.HandlerFunc, args ...string) (string, error) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var out bytes.Buffer
+	cmd := newRootCommand()
+	cmd.SetArgs(append([]string{"--api", server.URL, "--api-key", "token"}, args...))
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func TestSync_ThroughAPI(t *testing.T) {
+	out, err := runAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /api/v2/admin/sync", r.Method+" "+r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"results": [
+				{"provider": "provider_a", "count": 12, "duration": "150ms"},
+				{"provider": "provider_b", "count": 0, "duration": "2s", "error": "circuit breaker is open"}
+			],
+			"summary": {"total_synced": 12, "providers_ok": 1, "providers_fail": 1}
+		}`))
+	}, "sync")
+
+	require.EqualError(t, err, "1 of 2 providers failed", "CI jobs see failed providers in the exit code")
+	assert.Contains(t, out, "provider_a  12     150ms")
+	assert.Contains(t, out, "circuit breaker is open")
+}
+
+func TestSync_OneProviderThroughAPI(t *testing.T) {
+	out, err := runAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/admin/sync/provider_a", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"provider": "provider_a", "count": 12, "duration": "150ms"}`))
+	}, "sync", "provider_a")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "provider_a")
+}
+
+func TestCacheClear_ReportsProblemDetail(t *testing.T) {
+	_, err := runAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE /api/v2/admin/cache", r.Method+" "+r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"type": "about:blank", "title": "Forbidden", "status": 403, "detail": "admin role required"}`))
+	}, "cache", "clear")
+
+	require.EqualError(t, err, "DELETE /admin/cache: 403 Forbidden: admin role required")
+}
+
+func TestExport_ThroughAPI(t *testing.T) {
+	out, err := runAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/contents/export", r.URL.Path)
+		assert.Equal(t, "format=csv&q=golang", r.URL.RawQuery)
+
+		_, _ = w.Write([]byte("id,title\n1,Go\n"))
+	}, "export", "--format", "csv", "-q", "golang")
+
+	require.NoError(t, err)
+	assert.Equal(t, "id,title\n1,Go\n", out)
+}
+
+func TestExport_RejectsUnknownFormat(t *testing.T) {
+	_, err := runAgainst(t, func(http.ResponseWriter, *http.Request) {
+		t.Error("the API isn't called")
+	}, "export", "--format", "xml")
+
+	require.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/internal/infra/provider/registry"
+	rediscache "search-engine-service/internal/infra/redis"
+	"search-engine-service/internal/infra/snapshot"
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// errLocalCache is returned for cache operations when Redis is disabled: each
+// instance then caches in its own memory, out of the CLI's reach.
+var errLocalCache = errors.New("the cache is process-local while redis is disabled; use --api against each instance")
+
+// directBackend runs operations on the database and cache of the configuration,
+// without a running service. Syncs don't wait for the service's own syncs and
+// publish no events; the shared cache is cleared after them instead.
+type directBackend struct {
+	cfg    *config.Config
+	db     *gorm.DB
+	redis  *redis.Client // Nil when Redis is disabled
+	logger *zap.Logger
+}
+
+// openDirect loads the configuration and connects to its database and, if enabled,
+// Redis. Migrations aren't run; see the migrate command.
+func openDirect(configPath string) (*directBackend, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	// Results go to stdout, so logs (warnings only) go to stderr
+	log, err := logger.New(logger.Config{Level: "warn", Format: "console", Output: "stderr"}, logger.SentryConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("initializing logger: %w", err)
+	}
+
+	db, err := postgres.NewConnection(
+		postgres.Config{
+			Host:         cfg.Database.Host,
+			Port:         cfg.Database.Port,
+			Name:         cfg.Database.Name,
+			User:         cfg.Database.User,
+			Password:     cfg.Database.Password,
+			SSLMode:      cfg.Database.SSLMode,
+			MaxOpenConns: cfg.Database.MaxOpenConns,
+			MaxIdleConns: cfg.Database.MaxIdleConns,
+			MaxLifetime:  cfg.Database.MaxLifetime,
+
+			SlowQueryThreshold: cfg.Database.SlowQueryThreshold,
+		},
+		log.Logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	d := &directBackend{cfg: cfg, db: db, logger: log.Logger}
+	if cfg.Redis.Enabled {
+		d.redis = redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+
+			ReadTimeout:           cfg.Redis.ReadTimeout,
+			WriteTimeout:          cfg.Redis.WriteTimeout,
+			ContextTimeoutEnabled: true,
+		})
+	}
+
+	return d, nil
+}
+
+// Sync runs the sync in this process, then clears the shared cache.
+func (d *directBackend) Sync(ctx context.Context, provider string) (dto.SyncResponse, error) {
+	providers, err := registry.NewProviders(d.cfg.Provider, d.cfg.Providers, d.logger)
+	if err != nil {
+		return dto.SyncResponse{}, fmt.Errorf("creating provider clients: %w", err)
+	}
+	futurePublish, err := d.futurePublish()
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	repo := postgres.NewRepository(d.db).
+		WithCopyThreshold(d.cfg.Database.CopyThreshold).
+		WithUpsertWorkers(d.cfg.Database.UpsertWorkers)
+	syncSvc := service.NewSyncService(repo, providers, d.logger,
+		service.WithFuturePublish(futurePublish),
+		service.WithPipeline(service.SyncPipeline{Buffer: d.cfg.Sync.PipelineBuffer, BatchSize: d.cfg.Sync.BatchSize}),
+		service.WithDisabledProviders(d.cfg.SyncDisabledProviders()...),
+	)
+
+	var results []service.SyncResult
+	if provider == "" {
+		results, err = syncSvc.SyncAll(ctx)
+	} else {
+		var result *service.SyncResult
+		if result, err = syncSvc.SyncProvider(ctx, provider); result != nil {
+			results = []service.SyncResult{*result}
+		}
+	}
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	if err := d.clearCacheAfter(ctx); err != nil {
+		d.logger.Warn("failed to clear cache after sync", zap.Error(err))
+	}
+
+	return dto.FromSyncResults(results), nil
+}
+
+// Replay syncs provider from the response recorded at path, then clears the shared
+// cache.
+func (d *directBackend) Replay(ctx context.Context, provider, path string) (dto.SyncResponse, error) {
+	e, ok := d.cfg.Providers[provider]
+	if !ok {
+		return dto.SyncResponse{}, fmt.Errorf("provider %s: %w", provider, domain.ErrNotFound)
+	}
+	e.Replay = path
+	d.cfg.Providers[provider] = e
+
+	return d.Sync(ctx, provider)
+}
+
+// ClearCache clears the shared cache.
+func (d *directBackend) ClearCache(ctx context.Context) error {
+	if !d.cfg.Cache.Enabled {
+		return nil
+	}
+	if d.redis == nil {
+		return errLocalCache
+	}
+
+	return d.searchService().ClearCache(ctx)
+}
+
+// Export encodes the contents matching req to w, as GET /contents/export does.
+func (d *directBackend) Export(ctx context.Context, w io.Writer, req dto.ExportRequest) error {
+	enc, err := dto.NewContentEncoder(req.Format, w)
+	if err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+
+	err = d.searchService().Export(ctx, req.ToSearchParams(), func(batch []*domain.Content) error {
+		if err := enc.Encode(batch); err != nil {
+			return err
+		}
+
+		return enc.Flush()
+	})
+	if err != nil {
+		return fmt.Errorf("exporting contents: %w", err)
+	}
+
+	return nil
+}
+
+// ReindexScores recomputes every content score, then clears the shared cache.
+func (d *directBackend) ReindexScores(ctx context.Context) (int64, error) {
+	return service.NewScoreService(postgres.NewRepository(d.db), d.cache(), d.logger).Reindex(ctx)
+}
+
+// snapshots returns a snapshot service on the database and the configured store,
+// clearing the shared cache after restores.
+func (d *directBackend) snapshots() (*service.SnapshotService, error) {
+	store, err := snapshot.NewStore(d.cfg.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot config: %w", err)
+	}
+
+	return service.NewSnapshotService(postgres.NewSnapshotRepository(d.db), store, d.cache(), nil, d.cfg.Snapshot.BatchSize, d.logger), nil
+}
+
+// Close closes the database and Redis connections.
+func (d *directBackend) Close() error {
+	var redisErr error
+	if d.redis != nil {
+		redisErr = d.redis.Close()
+	}
+
+	return errors.Join(postgres.Close(d.db), redisErr)
+}
+
+// clearCacheAfter clears the shared cache after contents changed, if there is one.
+func (d *directBackend) clearCacheAfter(ctx context.Context) error {
+	if cache := d.cache(); cache != nil {
+		return cache.Clear(ctx)
+	}
+
+	return nil
+}
+
+// cache returns the shared cache, or nil when caching or Redis is disabled.
+func (d *directBackend) cache() domain.Cache {
+	if !d.cfg.Cache.Enabled || d.redis == nil {
+		return nil
+	}
+
+	return rediscache.NewCache(d.redis, d.logger, d.cfg.Cache.VersionedKeyPrefix(), d.cfg.Cache.CompressionThreshold)
+}
+
+// searchService returns a search service reading the database, with the shared cache
+// for ClearCache.
+func (d *directBackend) searchService() *service.SearchService {
+	futurePublish, _ := d.futurePublish()
+
+	return service.NewSearchService(postgres.NewRepository(d.db), d.logger,
+		service.WithCache(d.cache(), service.CacheTTLs{}),
+		service.WithSearchFuturePublish(futurePublish),
+	)
+}
+
+// futurePublish returns the configured policy for contents published in the future.
+func (d *directBackend) futurePublish() (domain.FuturePublishPolicy, error) {
+	policy := domain.FuturePublishPolicy(d.cfg.Sync.FuturePublish)
+	if !policy.IsValid() {
+		return policy, fmt.Errorf("invalid sync.future_publish %q", d.cfg.Sync.FuturePublish)
+	}
+
+	return policy, nil
+}
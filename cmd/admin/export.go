@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// newExportCommand builds the export command.
+func newExportCommand(opts *options) *cobra.Command {
+	var req dto.ExportRequest
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export contents as NDJSON or CSV",
+		Long: `Export the contents matching the query and type filters, in the format of
+GET /contents/export. Blocked contents are left out.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			if req.Format == "" {
+				req.Format = dto.ExportFormatNDJSON
+			}
+			if err := validator.New().Validate(&req); err != nil {
+				return err
+			}
+
+			ctx, cancel := opts.context(cmd)
+			defer cancel()
+
+			b, err := opts.backend()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = b.Close() }()
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("creating output file: %w", err)
+				}
+				defer func() {
+					if closeErr := file.Close(); err == nil {
+						err = closeErr
+					}
+				}()
+				w = file
+			}
+
+			buf := bufio.NewWriter(w)
+			if err := b.Export(ctx, buf, req); err != nil {
+				return err
+			}
+
+			return buf.Flush()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&req.Format, "format", dto.ExportFormatNDJSON, "output format: ndjson or csv")
+	flags.StringVarP(&req.Query, "query", "q", "", "full-text query")
+	flags.StringVar(&req.Type, "type", "", "content type: video, article, podcast or image")
+	flags.StringVarP(&output, "output", "o", "", "write to this file instead of stdout")
+
+	return cmd
+}
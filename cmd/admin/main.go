@@ -0,0 +1,22 @@
+// Package main is the admin CLI of search-engine-service, for operators and CI jobs.
+//
+// Commands work either through the HTTP API of a running service (--api) or directly
+// on the database and cache of the configuration.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		stop()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/infra/postgres/migrations"
+)
+
+// newMigrateCommand builds the migrate command and its subcommands. They always
+// work on the database.
+func newMigrateCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Inspect and apply database migrations",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "List migrations and whether each is applied",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				statuses, err := migrations.Status(d.db)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "MIGRATION\tSTATUS")
+				for _, s := range statuses {
+					status := "pending"
+					if s.Applied {
+						status = "applied"
+					}
+					_, _ = fmt.Fprintf(w, "%s\t%s\n", s.ID, status)
+				}
+
+				return w.Flush()
+			},
+		},
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply pending migrations",
+			Long:  "Apply pending migrations. The API also applies them when it starts.",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				if err := migrations.Run(d.db); err != nil {
+					return fmt.Errorf("running migrations: %w", err)
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "migrations applied")
+
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "down",
+			Short: "Roll back the last applied migration",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				if err := migrations.Rollback(d.db); err != nil {
+					return fmt.Errorf("rolling back migration: %w", err)
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "last migration rolled back")
+
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newReindexScoresCommand builds the reindex-scores command.
+func newReindexScoresCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex-scores",
+		Short: "Recompute the score of every content",
+		Long: `Recompute the score of every content from its stored metrics and publish date,
+keeping click-through rate boosts, then clear the shared cache. Syncs only rescore
+the contents providers still return, so run this after the scoring formula changed
+or to refresh the recency points of older contents. Always works on the database.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := opts.context(cmd)
+			defer cancel()
+
+			d, err := openDirect(opts.config)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = d.Close() }()
+
+			rescored, err := d.ReindexScores(ctx)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "rescored %d contents\n", rescored)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// options holds the global flags.
+type options struct {
+	config  string        // Config file; empty looks for config.yaml in ./config and . like the API
+	api     string        // Base URL of a running service; empty works on the database directly
+	apiKey  string        // Bearer token sent to the API's admin routes
+	timeout time.Duration // Bound of each command
+}
+
+// backend runs the operations available both through the API and on the database.
+type backend interface {
+	// Sync syncs one provider, or all of them if provider is empty.
+	Sync(ctx context.Context, provider string) (dto.SyncResponse, error)
+	// ClearCache removes every cached search result and content.
+	ClearCache(ctx context.Context) error
+	// Export writes the contents matching req to w.
+	Export(ctx context.Context, w io.Writer, req dto.ExportRequest) error
+	// Close releases the connections of the backend.
+	Close() error
+}
+
+// newRootCommand builds the command tree.
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Operate search-engine-service",
+		Long: `Operate search-engine-service from a shell or a CI job.
+
+With --api, commands call the admin API of a running service, authenticated with
+--api-key. Without it, they connect to the database and cache of the configuration
+(config file and APP_* environment variables, as for the API). reindex-scores,
+seed, migrate and snapshot always work on the database.`,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&opts.config, "config", "", "config file (default config.yaml in ./config or .)")
+	flags.StringVar(&opts.api, "api", os.Getenv("ADMIN_API_URL"), "base URL of a running service, e.g. http://localhost:8080 (env ADMIN_API_URL)")
+	flags.StringVar(&opts.apiKey, "api-key", os.Getenv("ADMIN_API_KEY"), "bearer token for the admin API (env ADMIN_API_KEY)")
+	flags.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "give up after this long")
+
+	cmd.AddCommand(
+		newSyncCommand(opts),
+		newReindexScoresCommand(opts),
+		newCacheCommand(opts),
+		newExportCommand(opts),
+		newMigrateCommand(opts),
+		newSeedCommand(opts),
+		newSnapshotCommand(opts),
+	)
+
+	return cmd
+}
+
+// context returns the command context bounded by the timeout flag.
+func (o *options) context(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(cmd.Context(), o.timeout)
+}
+
+// backend returns the API client when --api is set, the database otherwise.
+func (o *options) backend() (backend, error) {
+	if o.api != "" {
+		return newAPIClient(o.api, o.apiKey), nil
+	}
+
+	return openDirect(o.config)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/internal/infra/postgres/migrations"
+	"search-engine-service/internal/infra/provider/provider_a"
+	"search-engine-service/internal/infra/provider/provider_b"
+	"search-engine-service/mock"
+)
+
+// seedBatchSize is the number of contents upserted per transaction while seeding.
+const seedBatchSize = 1000
+
+// seedOptions are the flags of the seed command.
+type seedOptions struct {
+	generate int    // Variations of the mock contents added to them
+	seed     uint64 // Variations are derived from it, so the same seed gives the same corpus
+	days     int    // Variations are published over this many days before today
+}
+
+// newSeedCommand builds the seed command.
+func newSeedCommand(opts *options) *cobra.Command {
+	var seedOpts seedOptions
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Load the mock provider datasets into the database",
+		Long: `Load the contents served by the mock providers into the database, scored as a sync
+would, so search and the dashboard can be worked on without running the mock servers
+and the scheduler. --generate adds variations of them for a larger corpus.
+
+Migrations are applied first, so an empty database works. Contents are upserted by
+provider and external ID: seeding again updates them in place. Always works on the
+database.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if seedOpts.generate < 0 || seedOpts.days < 1 {
+				return fmt.Errorf("--generate must not be negative and --days must be positive")
+			}
+
+			ctx, cancel := opts.context(cmd)
+			defer cancel()
+
+			d, err := openDirect(opts.config)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = d.Close() }()
+
+			if err := migrations.Run(d.db); err != nil {
+				return fmt.Errorf("running migrations: %w", err)
+			}
+
+			base, err := mockContents()
+			if err != nil {
+				return err
+			}
+
+			seeded, err := seedContents(ctx, postgres.NewRepository(d.db), base, seedOpts, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := d.clearCacheAfter(ctx); err != nil {
+				return fmt.Errorf("clearing cache: %w", err)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "seeded %d contents\n", seeded)
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&seedOpts.generate, "generate", 0, "variations of the mock contents to add")
+	flags.Uint64Var(&seedOpts.seed, "seed", 1, "seed of the variations; the same seed gives the same corpus")
+	flags.IntVar(&seedOpts.days, "days", 365, "publish variations over this many days before today")
+
+	return cmd
+}
+
+// mockContents parses the mock provider datasets as the provider clients do, keeping
+// the valid contents.
+func mockContents() ([]*domain.Content, error) {
+	var feedA provider_a.Response
+	if err := json.Unmarshal(mock.ProviderA, &feedA); err != nil {
+		return nil, fmt.Errorf("parsing %s dataset: %w", provider_a.Name, err)
+	}
+	var feedB provider_b.Feed
+	if err := xml.Unmarshal(mock.ProviderB, &feedB); err != nil {
+		return nil, fmt.Errorf("parsing %s dataset: %w", provider_b.Name, err)
+	}
+
+	contents := make([]*domain.Content, 0, len(feedA.Contents)+len(feedB.Items.Items))
+	for _, item := range feedA.Contents {
+		contents = append(contents, item.ToDomain(provider_a.Name))
+	}
+	for _, item := range feedB.Items.Items {
+		contents = append(contents, item.ToDomain(provider_b.Name))
+	}
+
+	return slices.DeleteFunc(contents, func(c *domain.Content) bool {
+		return c.Validate() != nil
+	}), nil
+}
+
+// seedContents upserts base and opts.generate variations of it, scored, and returns
+// how many contents were upserted.
+func seedContents(ctx context.Context, repo domain.ContentRepository, base []*domain.Content, opts seedOptions, now time.Time) (int, error) {
+	total := len(base) + opts.generate
+	batch := make([]*domain.Content, 0, min(seedBatchSize, total))
+
+	for i := range total {
+		var c *domain.Content
+		if i < len(base) {
+			c = base[i]
+		} else {
+			c = variation(base, i-len(base), opts, now)
+		}
+		c.Score = domain.CalculateScore(c)
+
+		if batch = append(batch, c); len(batch) == cap(batch) || i == total-1 {
+			if err := repo.BulkUpsert(ctx, batch); err != nil {
+				return i + 1 - len(batch), fmt.Errorf("seeding contents: %w", err)
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return total, nil
+}
+
+// variation returns the i-th variation of base: a copy of one of its contents with a
+// new external ID, a publish date within opts.days before today, and engagement
+// scaled by a log-normal factor, so most variations are about as popular as their
+// original and a few far more. Each is derived from the seed and i alone.
+func variation(base []*domain.Content, i int, opts seedOptions, now time.Time) *domain.Content {
+	rng := rand.New(rand.NewPCG(opts.seed, uint64(i)))
+	original := base[i%len(base)]
+
+	c := *original
+	c.ID = ""
+	c.ExternalID = fmt.Sprintf("%s-seed-%d", original.ExternalID, i)
+	c.Title = fmt.Sprintf("%s (Part %d)", original.Title, i/len(base)+2)
+	c.Tags = slices.Clone(original.Tags)
+
+	factor := math.Exp(rng.NormFloat64())
+	scale := func(n int) int { return int(float64(n) * factor) }
+	c.Views, c.Likes, c.Listens = scale(c.Views), scale(c.Likes), scale(c.Listens)
+	c.Reactions, c.Comments = scale(c.Reactions), scale(c.Comments)
+
+	// Published before today's start, so seeding again the same day gives the same dates
+	today := now.UTC().Truncate(24 * time.Hour)
+	c.PublishedAt = today.Add(-time.Duration(1 + rng.Int64N(int64(opts.days)*int64(24*time.Hour))))
+
+	return &c
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+// batchRepo records the batches passed to BulkUpsert.
+type batchRepo struct {
+	domain.ContentRepository
+	batches [][]string // External IDs per batch
+}
+
+func (r *batchRepo) BulkUpsert(_ context.Context, contents []*domain.Content) error {
+	ids := make([]string, len(contents))
+	for i, c := range contents {
+		ids[i] = c.ExternalID
+	}
+	r.batches = append(r.batches, ids)
+
+	return nil
+}
+
+func TestMockContents_ParsesBothProviders(t *testing.T) {
+	contents, err := mockContents()
+	require.NoError(t, err)
+
+	providers := map[string]int{}
+	for _, c := range contents {
+		providers[c.ProviderID]++
+		assert.NoError(t, c.Validate())
+	}
+	assert.Equal(t, map[string]int{"provider_a": 5, "provider_b": 5}, providers)
+}
+
+func TestSeedContents_AddsScoredVariations(t *testing.T) {
+	base, err := mockContents()
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	opts := seedOptions{generate: seedBatchSize, seed: 7, days: 30}
+
+	repo := &batchRepo{}
+	seeded, err := seedContents(context.Background(), repo, base, opts, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, len(base)+seedBatchSize, seeded)
+	require.Len(t, repo.batches, 2)
+	assert.Len(t, repo.batches[0], seedBatchSize)
+	assert.Len(t, repo.batches[1], len(base))
+
+	v := variation(base, 3, opts, now)
+	assert.Equal(t, v, variation(base, 3, opts, now), "variations are derived from the seed")
+	assert.Equal(t, base[3].ExternalID+"-seed-3", v.ExternalID)
+	assert.True(t, v.PublishedAt.Before(now.Truncate(24*time.Hour)))
+	assert.False(t, v.PublishedAt.Before(now.AddDate(0, 0, -30)))
+}
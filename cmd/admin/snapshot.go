@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/infra/postgres/migrations"
+)
+
+// newSnapshotCommand builds the snapshot command and its subcommands. They always
+// work on the database, with the store of the snapshot config.
+func newSnapshotCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot the contents to the snapshot store and restore them",
+		Long: `Write every content, curation and CTR boosts included, and the provider sync
+times to a snapshot in the configured store (snapshot.store), or restore one into an
+empty database, for disaster recovery and cloning environments. snapshot.enabled
+only controls the admin API; these commands always work on the database.`,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "create",
+			Short: "Write a snapshot of the contents",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ctx, cancel := opts.context(cmd)
+				defer cancel()
+
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				svc, err := d.snapshots()
+				if err != nil {
+					return err
+				}
+				status, err := svc.Create(ctx)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "wrote %d contents to %s\n", status.Contents, status.Name)
+
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the stored snapshots, newest first",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ctx, cancel := opts.context(cmd)
+				defer cancel()
+
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				svc, err := d.snapshots()
+				if err != nil {
+					return err
+				}
+				snapshots, err := svc.List(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "NAME\tSIZE\tMODIFIED")
+				for _, s := range snapshots {
+					_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", s.Name, s.Size, s.ModifiedAt.Format(time.RFC3339))
+				}
+
+				return w.Flush()
+			},
+		},
+		&cobra.Command{
+			Use:   "restore NAME",
+			Short: "Restore a snapshot into an empty database",
+			Long: `Restore the snapshot NAME into the database, which must hold no contents; migrations
+are applied first, so a new database works. The shared cache is cleared afterwards.
+Running instances with bleve enabled keep their search index until rebuilt: restart
+them or call POST /api/v1/admin/reindex.`,
+			Args: cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx, cancel := opts.context(cmd)
+				defer cancel()
+
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				if err := migrations.Run(d.db); err != nil {
+					return fmt.Errorf("running migrations: %w", err)
+				}
+				svc, err := d.snapshots()
+				if err != nil {
+					return err
+				}
+				status, err := svc.Restore(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "restored %d contents from %s\n", status.Contents, status.Name)
+
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
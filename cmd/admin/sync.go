@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// newSyncCommand builds the sync command.
+func newSyncCommand(opts *options) *cobra.Command {
+	var replay string
+
+	cmd := &cobra.Command{
+		Use:   "sync [provider]",
+		Short: "Sync contents from all providers, or from one",
+		Long: `Sync contents from all providers, or from the named one, and print the result of
+each. Exits with an error if any provider failed.
+
+Through the API, the sync queues behind running ones and is recorded like a manual
+sync from the dashboard. On the database, it runs in this process regardless of the
+service's syncs, and the shared cache is cleared afterwards.
+
+With --replay, the named provider's contents are read from a response recorded by
+the service (see provider.record_dir) instead of calling the provider, e.g. to find
+out why scores changed. Replays always work on the database.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := opts.context(cmd)
+			defer cancel()
+
+			provider := ""
+			if len(args) == 1 {
+				provider = args[0]
+			}
+
+			var resp dto.SyncResponse
+			if replay != "" {
+				if provider == "" {
+					return errors.New("--replay needs the provider the response was recorded from")
+				}
+				if opts.api != "" {
+					return errors.New("--replay works on the database; leave out --api")
+				}
+
+				d, err := openDirect(opts.config)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = d.Close() }()
+
+				if resp, err = d.Replay(ctx, provider, replay); err != nil {
+					return err
+				}
+			} else {
+				b, err := opts.backend()
+				if err != nil {
+					return err
+				}
+				defer func() { _ = b.Close() }()
+
+				if resp, err = b.Sync(ctx, provider); err != nil {
+					return err
+				}
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "PROVIDER\tCOUNT\tDURATION\tERROR")
+			for _, r := range resp.Results {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", r.Provider, r.Count, r.Duration, r.Error)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if resp.Summary.ProvidersFail > 0 {
+				return fmt.Errorf("%d of %d providers failed", resp.Summary.ProvidersFail, len(resp.Results))
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&replay, "replay", "", "sync the provider from this recorded response")
+
+	return cmd
+}
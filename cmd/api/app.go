@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/internal/job"
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver"
+)
+
+// app holds the components of the API that run in the background, wired by newApp.
+// Those of features disabled in the config are nil.
+type app struct {
+	Config *config.Config
+	Logger *logger.Logger
+	Server *httpserver.Server
+
+	Repository    *postgres.Repository
+	FuturePublish domain.FuturePublishPolicy
+
+	Search         *service.SearchService
+	Settings       *service.SettingsService
+	Reindex        *service.ReindexService
+	Snapshots      *service.SnapshotService
+	QueryAnalytics *service.QueryAnalyticsService
+	Webhooks       *service.WebhookService
+	OutboxRelay    *service.OutboxRelay
+	Dashboard      *service.DashboardNotifier
+	CTR            *service.CTRService
+	ContentEvents  domain.ContentEventBus
+
+	Scheduler           *job.SyncScheduler
+	CTRScheduler        *job.CTRScheduler
+	DictionaryScheduler *job.DictionaryScheduler
+}
+
+// hooks registers the start and stop of each background component with lc. They
+// start once everything is wired, the HTTP server last (see main), and stop after
+// draining in the order below.
+func (a *app) hooks(lc *lifecycle) {
+	cfg := a.Config
+
+	// Starts
+	if cfg.Database.WarmUpConns > 0 {
+		// Before anything queries the database; a failed warm-up only costs the first
+		// requests their latency, so the start goes on
+		lc.OnStart("statement warm-up", func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, cfg.Database.WarmUpTimeout)
+			defer cancel()
+
+			start := time.Now()
+			prepared, err := a.Repository.WarmUp(ctx, cfg.Database.WarmUpConns, a.FuturePublish.HidesUnpublished())
+			if err != nil {
+				a.Logger.Warn("failed to warm up search statements", zap.Error(err))
+
+				return nil
+			}
+			a.Logger.Info("search statements warmed up",
+				zap.Int("prepared", prepared),
+				zap.Int("conns", cfg.Database.WarmUpConns),
+				zap.Duration("duration", time.Since(start)),
+			)
+
+			return nil
+		})
+	}
+	if a.Reindex != nil {
+		// The index is built in the background; PostgreSQL answers until then, and
+		// whenever the search_backend setting says so
+		lc.OnStart("reindex", func(context.Context) error {
+			_, err := a.Reindex.Start()
+
+			return err
+		})
+	}
+	lc.OnStart("settings", func(context.Context) error {
+		a.Settings.Start(cfg.Settings.RefreshInterval)
+
+		return nil
+	})
+	if a.QueryAnalytics != nil {
+		lc.OnStart("query analytics", func(context.Context) error {
+			a.QueryAnalytics.Start()
+
+			return nil
+		})
+	}
+	lc.OnStart("webhooks", func(context.Context) error {
+		a.Webhooks.Start()
+
+		return nil
+	})
+	if a.OutboxRelay != nil {
+		lc.OnStart("outbox relay", func(context.Context) error {
+			a.OutboxRelay.Start()
+
+			return nil
+		})
+	}
+	lc.OnStart("dashboard", func(context.Context) error {
+		a.Dashboard.Start()
+
+		return nil
+	})
+	lc.OnStart("scheduler", func(context.Context) error {
+		a.Scheduler.Start(cfg.Sync.OnStartup)
+
+		return nil
+	})
+	if a.CTRScheduler != nil {
+		lc.OnStart("ctr scheduler", func(context.Context) error {
+			a.CTRScheduler.Start()
+
+			return nil
+		})
+	}
+	if a.DictionaryScheduler != nil {
+		lc.OnStart("dictionary scheduler", func(context.Context) error {
+			a.DictionaryScheduler.Start()
+
+			return nil
+		})
+	}
+	if cfg.App.WatchConfig {
+		// Apply tuning changes to the config file without a restart
+		lc.OnStart("config watcher", func(context.Context) error {
+			reloader := config.NewReloader("", cfg, a.Logger.Logger)
+			subscribeReloads(reloader, reloadTargets{
+				logger:    a.Logger,
+				search:    a.Search,
+				settings:  a.Settings,
+				ctr:       a.CTR,
+				scheduler: a.Scheduler,
+			})
+			if err := reloader.Start(); err != nil {
+				a.Logger.Warn("failed to watch config file", zap.Error(err))
+			}
+
+			return nil
+		})
+	}
+
+	// Stops
+	lc.OnShutdown("scheduler", func(ctx context.Context) error {
+		// A running sync gets part of the shutdown timeout, then is interrupted
+		ctx, cancel := context.WithTimeout(ctx, cfg.Sync.ShutdownWait)
+		defer cancel()
+		a.Scheduler.Stop(ctx)
+
+		return nil
+	})
+	if a.CTRScheduler != nil {
+		lc.OnShutdown("ctr scheduler", func(context.Context) error {
+			a.CTRScheduler.Stop()
+
+			return nil
+		})
+	}
+	if a.Snapshots != nil {
+		// Before the reindex, which a restore starts
+		lc.OnShutdown("snapshots", func(context.Context) error {
+			a.Snapshots.Stop()
+
+			return nil
+		})
+	}
+	if a.Reindex != nil {
+		lc.OnShutdown("reindex", func(context.Context) error {
+			a.Reindex.Stop()
+
+			return nil
+		})
+	}
+	if a.DictionaryScheduler != nil {
+		lc.OnShutdown("dictionary scheduler", func(context.Context) error {
+			a.DictionaryScheduler.Stop()
+
+			return nil
+		})
+	}
+	lc.OnShutdown("settings", func(context.Context) error {
+		a.Settings.Stop()
+
+		return nil
+	})
+	// End open event streams and dashboard sockets so they don't hold up the server shutdown
+	lc.OnShutdown("event bus", func(context.Context) error {
+		return a.ContentEvents.Close()
+	})
+	lc.OnShutdown("dashboard", func(context.Context) error {
+		a.Dashboard.Stop()
+
+		return nil
+	})
+	lc.OnShutdown("http server", a.Server.App.ShutdownWithContext)
+	if a.OutboxRelay != nil {
+		// After the server, so the events of the last admin changes are published, and
+		// before the webhooks, so their deliveries are sent
+		lc.OnShutdown("outbox relay", func(ctx context.Context) error {
+			a.OutboxRelay.Stop(ctx)
+
+			return nil
+		})
+	}
+	// Let in-flight webhook deliveries finish
+	lc.OnShutdown("webhooks", func(ctx context.Context) error {
+		a.Webhooks.Stop(ctx)
+
+		return nil
+	})
+	if a.QueryAnalytics != nil {
+		// After the server, so the last searches are written too
+		lc.OnShutdown("query analytics", func(ctx context.Context) error {
+			a.QueryAnalytics.Stop(ctx)
+
+			return nil
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/internal/infra/postgres/migrations"
+	"search-engine-service/internal/infra/provider/registry"
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/middleware"
+)
+
+// selfCheckTimeout bounds each check of --check.
+const selfCheckTimeout = 10 * time.Second
+
+// errSkipped is reported by checks that depend on one that failed.
+var errSkipped = errors.New("skipped")
+
+// selfCheck is a step of --check. run returns details worth printing on success
+// (may be empty).
+type selfCheck struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// selfCheckMain runs --check against the configuration and returns the exit code.
+// Checks are listed on stdout; logs (warnings only) go to stderr.
+func selfCheckMain() int {
+	cfg, err := config.Load("")
+	if err != nil {
+		fmt.Printf("FAIL  config: %v\n", err)
+
+		return 1
+	}
+
+	log, err := logger.New(logger.Config{Level: "warn", Format: "console", Output: "stderr"}, logger.SentryConfig{})
+	if err != nil {
+		fmt.Printf("FAIL  logger: %v\n", err)
+
+		return 1
+	}
+	defer func() { _ = log.Sync() }()
+
+	checks, closeChecks := selfChecks(cfg, log.Logger)
+	defer closeChecks()
+
+	if !runSelfChecks(context.Background(), os.Stdout, checks) {
+		return 1
+	}
+
+	return 0
+}
+
+// runSelfChecks runs checks in order, each within selfCheckTimeout, and writes a
+// line per check to w. Returns whether all of them passed.
+func runSelfChecks(ctx context.Context, w io.Writer, checks []selfCheck) bool {
+	passed := true
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+		details, err := c.run(checkCtx)
+		cancel()
+
+		switch {
+		case err != nil:
+			passed = false
+			_, _ = fmt.Fprintf(w, "FAIL  %s: %v\n", c.name, err)
+		case details != "":
+			_, _ = fmt.Fprintf(w, "ok    %s: %s\n", c.name, details)
+		default:
+			_, _ = fmt.Fprintf(w, "ok    %s\n", c.name)
+		}
+	}
+
+	return passed
+}
+
+// selfChecks returns the checks of --check for cfg: the settings parsed at startup,
+// the Postgres connection, pending migrations, Redis (if enabled) and each provider.
+// Nothing is written; pending migrations are reported, not failed, since the API
+// applies them when it starts. closeFn closes the connections opened by the checks.
+func selfChecks(cfg *config.Config, logger *zap.Logger) (checks []selfCheck, closeFn func()) {
+	var db *gorm.DB
+	var redisClient *redis.Client
+	closeFn = func() {
+		if db != nil {
+			_ = postgres.Close(db)
+		}
+		if redisClient != nil {
+			_ = redisClient.Close()
+		}
+	}
+
+	checks = []selfCheck{
+		{name: "config", run: func(context.Context) (string, error) {
+			return "", validateConfig(cfg)
+		}},
+		{name: "postgres", run: func(ctx context.Context) (string, error) {
+			conn, err := postgres.NewConnection(postgresConfig(cfg), logger)
+			if err != nil {
+				return "", err
+			}
+			db = conn
+
+			return fmt.Sprintf("%s:%d/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.Name), postgres.HealthCheck(ctx, db)
+		}},
+		{name: "migrations", run: func(ctx context.Context) (string, error) {
+			if db == nil {
+				return "", errSkipped
+			}
+			statuses, err := migrations.Status(db.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+
+			var pending []string
+			for _, s := range statuses {
+				if !s.Applied {
+					pending = append(pending, s.ID)
+				}
+			}
+			if len(pending) == 0 {
+				return "up to date", nil
+			}
+
+			return fmt.Sprintf("%d pending, applied on startup (%s)", len(pending), strings.Join(pending, ", ")), nil
+		}},
+	}
+
+	if cfg.Redis.Enabled {
+		checks = append(checks, selfCheck{name: "redis", run: func(ctx context.Context) (string, error) {
+			redisClient = redis.NewClient(&redis.Options{
+				Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+				Password: cfg.Redis.Password,
+				DB:       cfg.Redis.DB,
+
+				ReadTimeout:           cfg.Redis.ReadTimeout,
+				WriteTimeout:          cfg.Redis.WriteTimeout,
+				ContextTimeoutEnabled: true,
+			})
+
+			return redisClient.Options().Addr, redisClient.Ping(ctx).Err()
+		}})
+	}
+
+	providers, err := registry.NewProviders(cfg.Provider, cfg.Providers, logger)
+	if err != nil {
+		return append(checks, selfCheck{name: "providers", run: func(context.Context) (string, error) {
+			return "", err
+		}}), closeFn
+	}
+	for _, p := range providers {
+		checks = append(checks, selfCheck{name: "provider " + p.Name(), run: func(ctx context.Context) (string, error) {
+			return "", p.HealthCheck(ctx)
+		}})
+	}
+
+	return checks, closeFn
+}
+
+// validateConfig checks the settings the API parses while starting, so --check
+// reports them instead of the API failing on them.
+func validateConfig(cfg *config.Config) error {
+	var errs []error
+	if !domain.FuturePublishPolicy(cfg.Sync.FuturePublish).IsValid() {
+		errs = append(errs, fmt.Errorf("invalid sync.future_publish %q", cfg.Sync.FuturePublish))
+	}
+	if _, _, err := cfg.API.V1.Dates(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid api.v1 deprecation config: %w", err))
+	}
+	if cfg.HTTP.Compression.Enabled {
+		if _, err := middleware.ParseCompressLevel(cfg.HTTP.Compression.Level); err != nil {
+			errs = append(errs, fmt.Errorf("invalid http.compression config: %w", err))
+		}
+	}
+	if _, err := newShadowConfig(cfg.Search.Shadow, cfg.Bleve); err != nil {
+		errs = append(errs, err)
+	}
+	switch cfg.Lock.Backend {
+	case config.LockBackendRedis, config.LockBackendPostgres, config.LockBackendEtcd, config.LockBackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("unknown lock.backend %q", cfg.Lock.Backend))
+	}
+	switch cfg.Snapshot.Store {
+	case config.SnapshotStoreFile, config.SnapshotStoreS3:
+	default:
+		errs = append(errs, fmt.Errorf("unknown snapshot.store %q", cfg.Snapshot.Store))
+	}
+
+	return errors.Join(errs...)
+}
+
+// postgresConfig returns the database connection settings of cfg.
+func postgresConfig(cfg *config.Config) postgres.Config {
+	return postgres.Config{
+		Host:         cfg.Database.Host,
+		Port:         cfg.Database.Port,
+		Name:         cfg.Database.Name,
+		User:         cfg.Database.User,
+		Password:     cfg.Database.Password,
+		SSLMode:      cfg.Database.SSLMode,
+		MaxOpenConns: cfg.Database.MaxOpenConns,
+		MaxIdleConns: cfg.Database.MaxIdleConns,
+		MaxLifetime:  cfg.Database.MaxLifetime,
+
+		SlowQueryThreshold: cfg.Database.SlowQueryThreshold,
+		LogQueries:         cfg.Database.LogQueries,
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/config"
+)
+
+func TestRunSelfChecks_ReportsEveryCheck(t *testing.T) {
+	var out bytes.Buffer
+	passed := runSelfChecks(context.Background(), &out, []selfCheck{
+		{name: "config", run: func(context.Context) (string, error) { return "", nil }},
+		{name: "postgres", run: func(context.Context) (string, error) { return "", errors.New("connection refused") }},
+		{name: "migrations", run: func(ctx context.Context) (string, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+
+			return "up to date", nil
+		}},
+	})
+
+	assert.False(t, passed)
+	assert.Equal(t, "ok    config\nFAIL  postgres: connection refused\nok    migrations: up to date\n", out.String(),
+		"a failed check doesn't stop the others")
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+	require.NoError(t, validateConfig(cfg), "the defaults are valid")
+
+	cfg.Sync.FuturePublish = "later"
+	cfg.Lock.Backend = "zookeeper"
+	cfg.Search.Shadow.Backend = "bleve"
+	cfg.Snapshot.Store = "ftp"
+	err = validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sync.future_publish "later"`)
+	assert.Contains(t, err.Error(), `unknown lock.backend "zookeeper"`)
+	assert.Contains(t, err.Error(), "search.shadow.backend bleve requires bleve.enabled")
+	assert.Contains(t, err.Error(), `unknown snapshot.store "ftp"`)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startStep is a named component start function run by Start.
+type startStep struct {
+	name  string
+	start func(ctx context.Context) error
+}
+
+// shutdownStep is a named component stop function run during shutdown.
+type shutdownStep struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// lifecycle starts the background components in order, then coordinates graceful
+// shutdown: on signal it marks the instance as not ready, keeps serving for the
+// drain period so load balancers can stop routing traffic here, then stops the
+// registered components in order.
+type lifecycle struct {
+	drainPeriod     time.Duration
+	shutdownTimeout time.Duration
+	startDraining   func()
+	starts          []startStep
+	steps           []shutdownStep
+	done            chan struct{}
+	logger          *zap.Logger
+}
+
+// newLifecycle creates a lifecycle. startDraining is called first on shutdown
+// and should make readiness probes fail.
+func newLifecycle(drainPeriod, shutdownTimeout time.Duration, startDraining func(), logger *zap.Logger) *lifecycle {
+	return &lifecycle{
+		drainPeriod:     drainPeriod,
+		shutdownTimeout: shutdownTimeout,
+		startDraining:   startDraining,
+		done:            make(chan struct{}),
+		logger:          logger,
+	}
+}
+
+// OnStart registers a component to start with Start.
+// Components are started in registration order.
+func (l *lifecycle) OnStart(name string, start func(ctx context.Context) error) {
+	l.starts = append(l.starts, startStep{name: name, start: start})
+}
+
+// Start starts the registered components in order. It stops at the first one
+// failing and returns its error; the process is expected to exit then.
+func (l *lifecycle) Start(ctx context.Context) error {
+	for _, step := range l.starts {
+		if err := step.start(ctx); err != nil {
+			return fmt.Errorf("starting %s: %w", step.name, err)
+		}
+		l.logger.Debug("start step completed", zap.String("step", step.name))
+	}
+
+	return nil
+}
+
+// OnShutdown registers a component to stop after draining.
+// Components are stopped in registration order.
+func (l *lifecycle) OnShutdown(name string, stop func(ctx context.Context) error) {
+	l.steps = append(l.steps, shutdownStep{name: name, stop: stop})
+}
+
+// HandleSignals runs the shutdown sequence once one of sigs is received.
+// A second signal during the drain period skips the rest of it.
+func (l *lifecycle) HandleSignals(sigs ...os.Signal) {
+	sigChan := make(chan os.Signal, 2)
+	signal.Notify(sigChan, sigs...)
+
+	go func() {
+		sig := <-sigChan
+		l.logger.Info("shutdown signal received", zap.String("signal", sig.String()))
+
+		l.shutdown(sigChan)
+	}()
+}
+
+// Wait blocks until the shutdown sequence has completed.
+func (l *lifecycle) Wait() {
+	<-l.done
+}
+
+// shutdown drains, then stops every component within the shutdown timeout.
+// Failing steps are logged and don't prevent later ones from running.
+func (l *lifecycle) shutdown(interrupt <-chan os.Signal) {
+	defer close(l.done)
+
+	l.startDraining()
+
+	if l.drainPeriod > 0 {
+		l.logger.Info("draining before shutdown", zap.Duration("period", l.drainPeriod))
+
+		timer := time.NewTimer(l.drainPeriod)
+		select {
+		case <-timer.C:
+		case <-interrupt:
+			timer.Stop()
+			l.logger.Warn("drain period cut short by second signal")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+	defer cancel()
+
+	for _, step := range l.steps {
+		if err := step.stop(ctx); err != nil {
+			l.logger.Error("shutdown step failed", zap.String("step", step.name), zap.Error(err))
+
+			continue
+		}
+		l.logger.Debug("shutdown step completed", zap.String("step", step.name))
+	}
+
+	l.logger.Info("shutdown completed")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLifecycle_StartOrder(t *testing.T) {
+	var order []string
+	lc := newLifecycle(0, time.Second, func() {}, zap.NewNop())
+	for _, name := range []string{"settings", "webhooks", "scheduler"} {
+		lc.OnStart(name, func(context.Context) error {
+			order = append(order, name)
+			if name == "webhooks" {
+				return errors.New("queue full")
+			}
+
+			return nil
+		})
+	}
+
+	// A failing step stops the start
+	err := lc.Start(context.Background())
+	assert.EqualError(t, err, "starting webhooks: queue full")
+	assert.Equal(t, []string{"settings", "webhooks"}, order)
+}
+
+func TestLifecycle_ShutdownOrder(t *testing.T) {
+	var order []string
+	lc := newLifecycle(0, time.Second, func() { order = append(order, "drain") }, zap.NewNop())
+	lc.OnShutdown("scheduler", func(context.Context) error {
+		order = append(order, "scheduler")
+
+		return errors.New("already stopped")
+	})
+	lc.OnShutdown("http server", func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		order = append(order, "http server")
+
+		return nil
+	})
+
+	lc.shutdown(make(chan os.Signal))
+	lc.Wait()
+
+	// A failing step doesn't stop later ones
+	assert.Equal(t, []string{"drain", "scheduler", "http server"}, order)
+}
+
+func TestLifecycle_SecondSignalSkipsDrain(t *testing.T) {
+	lc := newLifecycle(time.Hour, time.Second, func() {}, zap.NewNop())
+	interrupt := make(chan os.Signal, 1)
+	interrupt <- syscall.SIGTERM
+
+	done := make(chan struct{})
+	go func() {
+		lc.shutdown(interrupt)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("shutdown waited for the full drain period")
+	}
+}
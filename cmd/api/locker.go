@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/pkg/locker"
+)
+
+// newLocker creates the distributed locker of the configured backend.
+// redisClient is nil when Redis is disabled; the redis backend then falls back to
+// a process-local locker. closeFn releases connections the locker opened itself.
+func newLocker(cfg config.LockConfig, redisClient *redis.Client, db *gorm.DB, logger *zap.Logger) (l locker.DistributedLocker, closeFn func(), err error) {
+	switch cfg.Backend {
+	case config.LockBackendRedis:
+		if redisClient == nil {
+			logger.Warn("redis disabled, using process-local locks; run a single instance only")
+
+			return newMemoryLocker(db)
+		}
+
+		// Tokens stay above the stored fences when Redis loses its counters
+		return locker.NewRedisLocker(redisClient, logger, locker.WithTokenFloor(postgres.FenceFloor(db))), func() {}, nil
+	case config.LockBackendMemory:
+		return newMemoryLocker(db)
+	case config.LockBackendPostgres:
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting database handle: %w", err)
+		}
+
+		return locker.NewPostgresLocker(sqlDB, logger), func() {}, nil
+	case config.LockBackendEtcd:
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   cfg.Etcd.Endpoints,
+			Username:    cfg.Etcd.Username,
+			Password:    cfg.Etcd.Password,
+			DialTimeout: cfg.Etcd.DialTimeout,
+			Logger:      logger.Named("etcd"),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("connecting to etcd: %w", err)
+		}
+
+		return locker.NewEtcdLocker(client, logger), func() { _ = client.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown lock backend %q", cfg.Backend)
+	}
+}
+
+// newMemoryLocker creates a process-local locker drawing its fencing tokens from the
+// database, so fenced writes outlive restarts: lock_fences keeps the highest token
+// written with.
+func newMemoryLocker(db *gorm.DB) (locker.DistributedLocker, func(), error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting database handle: %w", err)
+	}
+
+	return locker.NewMemoryLocker(locker.WithTokenSource(locker.SequenceTokenSource(sqlDB))), func() {}, nil
+}
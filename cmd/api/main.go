@@ -3,30 +3,28 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"os"
-	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
-	"search-engine-service/internal/app/service"
 	"search-engine-service/internal/config"
 	"search-engine-service/internal/domain"
-	"search-engine-service/internal/infra/postgres"
-	"search-engine-service/internal/infra/postgres/migrations"
-	"search-engine-service/internal/infra/provider/registry"
-	rediscache "search-engine-service/internal/infra/redis"
-	"search-engine-service/internal/job"
 	"search-engine-service/internal/logger"
-	"search-engine-service/internal/transport/httpserver"
-	"search-engine-service/internal/validator"
-	"search-engine-service/pkg/locker"
 )
 
+// reindexBatchSize is the number of contents read per query when rebuilding the Bleve index.
+const reindexBatchSize = 1000
+
 func main() {
+	check := flag.Bool("check", false, "check the config, Postgres, migrations, Redis and providers, then exit (non-zero on failure)")
+	flag.Parse()
+
+	if *check {
+		os.Exit(selfCheckMain())
+	}
+
 	// Load configuration
 	cfg, err := config.Load("")
 	if err != nil {
@@ -57,127 +55,34 @@ func main() {
 		zap.Int("port", cfg.App.Port),
 	)
 
-	// Connect to database
-	db, err := postgres.NewConnection(
-		postgres.Config{
-			Host:         cfg.Database.Host,
-			Port:         cfg.Database.Port,
-			Name:         cfg.Database.Name,
-			User:         cfg.Database.User,
-			Password:     cfg.Database.Password,
-			SSLMode:      cfg.Database.SSLMode,
-			MaxOpenConns: cfg.Database.MaxOpenConns,
-			MaxIdleConns: cfg.Database.MaxIdleConns,
-			MaxLifetime:  cfg.Database.MaxLifetime,
-		},
-		log.Logger,
-	)
+	// Wire the components, then start the background ones before the server
+	a, cleanup, err := newApp(cfg, log)
 	if err != nil {
-		log.Fatal("failed to connect to database", zap.Error(err))
+		log.Fatal("failed to initialize", zap.Error(err))
 	}
-	defer func() { _ = postgres.Close(db) }()
+	defer cleanup()
 
-	// Run migrations
-	if err := migrations.Run(db); err != nil {
-		log.Fatal("failed to run migrations", zap.Error(err))
+	// Graceful shutdown: fail readiness, drain, then stop components in order
+	lc := newLifecycle(cfg.App.DrainPeriod, cfg.App.ShutdownTimeout, a.Server.StartDraining, log.Logger)
+	a.hooks(lc)
+	if err := lc.Start(context.Background()); err != nil {
+		log.Fatal("failed to start", zap.Error(err))
 	}
-	log.Info("database migrations completed")
-
-	// Create repository
-	repo := postgres.NewRepository(db)
+	lc.HandleSignals(syscall.SIGINT, syscall.SIGTERM)
 
-	// Create provider clients using factory pattern
-	domainProviders := registry.NewProviders(cfg.Provider, log.Logger)
-
-	// Connect to Redis
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
-
-	// Ping Redis to verify connection
-	ctx := context.Background()
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		log.Fatal("failed to connect to Redis", zap.Error(err))
+	// Start server (returns once the lifecycle shuts it down)
+	if err := a.Server.Start(cfg.App.Port); err != nil {
+		log.Fatal("server error", zap.Error(err))
 	}
-	defer func() { _ = redisClient.Close() }()
-	log.Info("connected to Redis",
-		zap.String("host", cfg.Redis.Host),
-		zap.Int("port", cfg.Redis.Port),
-	)
+	lc.Wait()
+}
 
-	// Create cache implementation (optional, based on config)
-	var cache domain.Cache
-	if cfg.Cache.Enabled {
-		cache = rediscache.NewCache(redisClient, log.Logger, cfg.Cache.KeyPrefix)
-		log.Info("cache enabled",
-			zap.Duration("search_ttl", cfg.Cache.SearchTTL),
-			zap.String("key_prefix", cfg.Cache.KeyPrefix),
-		)
-	} else {
-		log.Info("cache disabled")
+// defaultSearchBackend returns the search backend used until the search_backend
+// runtime setting is changed.
+func defaultSearchBackend(cfg config.BleveConfig) domain.SearchBackend {
+	if cfg.Enabled && cfg.ServeQueries {
+		return domain.SearchBackendBleve
 	}
 
-	// Create services
-	searchSvc := service.NewSearchService(repo, cache, cfg.Cache.SearchTTL, log.Logger)
-	syncSvc := service.NewSyncService(repo, domainProviders, log.Logger)
-
-	// Create distributed locker
-	distLocker := locker.NewRedisLocker(redisClient, log.Logger)
-
-	// Create validator
-	v := validator.New()
-
-	// Create HTTP server
-	server := httpserver.NewServer(
-		httpserver.ServerConfig{
-			Port:      cfg.App.Port,
-			BodyLimit: 1024 * 1024, // 1MB
-			Debug:     cfg.App.Debug,
-		},
-		searchSvc,
-		syncSvc,
-		db,
-		v,
-		log.Logger,
-	)
-
-	// Start sync scheduler with distributed locking
-	scheduler := job.NewSyncScheduler(
-		syncSvc,
-		job.SyncConfig{
-			Interval:  cfg.Sync.Interval,
-			Timeout:   cfg.Sync.Timeout,
-			OnStartup: cfg.Sync.OnStartup,
-		},
-		log.Logger,
-		distLocker,
-	)
-	scheduler.Start(cfg.Sync.OnStartup)
-
-	// Graceful shutdown
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-
-		log.Info("shutdown signal received")
-
-		// Stop scheduler
-		scheduler.Stop()
-
-		// Shutdown server with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		if err := server.App.ShutdownWithContext(ctx); err != nil {
-			log.Error("server shutdown error", zap.Error(err))
-		}
-	}()
-
-	// Start server
-	if err := server.Start(cfg.App.Port); err != nil {
-		log.Fatal("server error", zap.Error(err))
-	}
+	return domain.SearchBackendPostgres
 }
@@ -0,0 +1,804 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/eventbus"
+	"search-engine-service/internal/infra/bleveindex"
+	memcache "search-engine-service/internal/infra/cache"
+	"search-engine-service/internal/infra/postgres"
+	"search-engine-service/internal/infra/postgres/migrations"
+	"search-engine-service/internal/infra/provider/registry"
+	rediscache "search-engine-service/internal/infra/redis"
+	"search-engine-service/internal/infra/snapshot"
+	"search-engine-service/internal/infra/webhook"
+	"search-engine-service/internal/job"
+	"search-engine-service/internal/metrics"
+	"search-engine-service/internal/transport/httpserver"
+	"search-engine-service/internal/transport/httpserver/middleware"
+	"search-engine-service/internal/validator"
+	"search-engine-service/pkg/locker"
+)
+
+// Providers of the components wired by newApp (see wire.go). Components of features
+// disabled in the config are provided as nil. Nothing is started here: app.hooks
+// starts and stops the components that run in the background.
+
+// provideDB connects to the database and applies pending migrations.
+func provideDB(cfg *config.Config, logger *zap.Logger) (*gorm.DB, func(), error) {
+	db, err := postgres.NewConnection(postgresConfig(cfg), logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to database: %w", err)
+	}
+	if err := migrations.Run(db); err != nil {
+		_ = postgres.Close(db)
+
+		return nil, nil, fmt.Errorf("running migrations: %w", err)
+	}
+	logger.Info("database migrations completed")
+
+	return db, func() { _ = postgres.Close(db) }, nil
+}
+
+// provideRepository creates the content repository. Content changes store their
+// events in the outbox (optional, based on config), and large syncs are written
+// with COPY or by parallel workers.
+func provideRepository(cfg *config.Config, db *gorm.DB) *postgres.Repository {
+	repo := postgres.NewRepository(db).
+		WithCopyThreshold(cfg.Database.CopyThreshold).
+		WithUpsertWorkers(cfg.Database.UpsertWorkers)
+	if cfg.Outbox.Enabled {
+		repo = repo.WithOutbox()
+	}
+
+	return repo
+}
+
+// provideSearchIndex opens the embedded Bleve index, which can answer search queries
+// instead of PostgreSQL text search (optional, based on config).
+func provideSearchIndex(cfg *config.Config, repo *postgres.Repository, logger *zap.Logger) (*bleveindex.Repository, func(), error) {
+	if !cfg.Bleve.Enabled {
+		return nil, func() {}, nil
+	}
+	index, err := bleveindex.NewRepository(repo, bleveindex.Config{
+		Dir:        cfg.Bleve.Path,
+		MaxMatches: cfg.Bleve.MaxMatches,
+	}, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating bleve index: %w", err)
+	}
+
+	return index, func() { _ = index.Close() }, nil
+}
+
+// provideContents returns the contents as seen by the services: through the search
+// index when there is one, so it's kept up to date.
+func provideContents(repo *postgres.Repository, index *bleveindex.Repository) domain.ContentRepository {
+	if index == nil {
+		return repo
+	}
+
+	return index
+}
+
+// provideReindex creates the service rebuilding the search index, if there is one.
+func provideReindex(index *bleveindex.Repository, repo *postgres.Repository, logger *zap.Logger) *service.ReindexService {
+	if index == nil {
+		return nil
+	}
+
+	return service.NewReindexService(index, repo, reindexBatchSize, logger)
+}
+
+// provideProviders creates the provider clients of the config.
+func provideProviders(cfg *config.Config, logger *zap.Logger) ([]domain.Provider, error) {
+	providers, err := registry.NewProviders(cfg.Provider, cfg.Providers, logger)
+	if err != nil {
+		return nil, fmt.Errorf("creating provider clients: %w", err)
+	}
+
+	return providers, nil
+}
+
+// provideRedis connects to Redis (optional; without it this must be the only instance).
+func provideRedis(cfg *config.Config, logger *zap.Logger) (*redis.Client, func(), error) {
+	if !cfg.Redis.Enabled {
+		logger.Warn("redis disabled, cache, settings and event streams are process-local; run a single instance only")
+
+		return nil, func() {}, nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+
+		ReadTimeout:  cfg.Redis.ReadTimeout,
+		WriteTimeout: cfg.Redis.WriteTimeout,
+		// Commands give up at the request deadline instead of waiting out the socket timeouts
+		ContextTimeoutEnabled: true,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		_ = client.Close()
+
+		return nil, nil, fmt.Errorf("connecting to Redis: %w", err)
+	}
+	logger.Info("connected to Redis",
+		zap.String("host", cfg.Redis.Host),
+		zap.Int("port", cfg.Redis.Port),
+	)
+
+	return client, func() { _ = client.Close() }, nil
+}
+
+// provideCache creates the cache (optional, based on config): Redis with an optional
+// local tier, or the local tier alone without Redis. It counts its hits and misses.
+func provideCache(cfg *config.Config, redisClient *redis.Client, logger *zap.Logger) domain.Cache {
+	if !cfg.Cache.Enabled {
+		logger.Info("cache disabled")
+
+		return nil
+	}
+
+	var cache domain.Cache
+	if redisClient == nil {
+		// The local tier becomes the only cache
+		cache = memcache.NewMemoryCache(cfg.Cache.Local.MaxEntries)
+	} else {
+		cache = rediscache.NewCache(redisClient, logger, cfg.Cache.VersionedKeyPrefix(), cfg.Cache.CompressionThreshold)
+		if cfg.Cache.Local.Enabled {
+			cache = memcache.NewTieredCache(
+				memcache.NewMemoryCache(cfg.Cache.Local.MaxEntries),
+				cache,
+				cfg.Cache.Local.TTL,
+				logger,
+			)
+		}
+	}
+	logger.Info("cache enabled",
+		zap.Duration("search_ttl", cfg.Cache.SearchTTL),
+		zap.String("key_prefix", cfg.Cache.VersionedKeyPrefix()),
+		zap.Bool("local_tier", cfg.Cache.Local.Enabled),
+	)
+
+	return memcache.NewInstrumentedCache(cache)
+}
+
+// provideCacheStats returns the usage counters of cache, nil without a cache.
+func provideCacheStats(cache domain.Cache) domain.CacheStatsReporter {
+	stats, _ := cache.(domain.CacheStatsReporter)
+
+	return stats
+}
+
+// provideSettings creates the runtime settings, starting from the configuration;
+// admin changes are shared through Redis.
+func provideSettings(cfg *config.Config, redisClient *redis.Client, logger *zap.Logger) *service.SettingsService {
+	var store domain.SettingsStore
+	if redisClient != nil {
+		store = rediscache.NewSettingsStore(redisClient, cfg.Cache.KeyPrefix)
+	}
+
+	return service.NewSettingsService(
+		store,
+		domain.RuntimeSettings{
+			CacheEnabled:    cfg.Cache.Enabled,
+			CacheSearchTTL:  cfg.Cache.SearchTTL,
+			ScoringStrategy: domain.ScoringHybrid,
+			SearchBackend:   defaultSearchBackend(cfg.Bleve),
+		},
+		logger,
+	)
+}
+
+// provideFuturePublish returns the configured policy for contents published in the future.
+func provideFuturePublish(cfg *config.Config) (domain.FuturePublishPolicy, error) {
+	policy := domain.FuturePublishPolicy(cfg.Sync.FuturePublish)
+	if !policy.IsValid() {
+		return "", fmt.Errorf("invalid sync.future_publish %q", cfg.Sync.FuturePublish)
+	}
+
+	return policy, nil
+}
+
+// provideQueryAnalytics creates the service recording executed searches for the top
+// and zero-result query reports (optional, based on config).
+func provideQueryAnalytics(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *service.QueryAnalyticsService {
+	if !cfg.Analytics.Queries.Enabled {
+		return nil
+	}
+
+	return service.NewQueryAnalyticsService(postgres.NewSearchQueryRepository(db), service.QueryAnalyticsConfig{
+		HashQueries:   cfg.Analytics.Queries.Hash,
+		FlushInterval: cfg.Analytics.Queries.FlushInterval,
+		BufferSize:    cfg.Analytics.Queries.BufferSize,
+		Retention:     cfg.Analytics.Queries.Retention,
+	}, logger)
+}
+
+// provideDictionary creates the term dictionary behind autocomplete suggestions
+// (optional, based on config).
+func provideDictionary(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *service.DictionaryService {
+	if !cfg.Dictionary.Enabled {
+		return nil
+	}
+
+	return service.NewDictionaryService(postgres.NewSearchTermRepository(db), logger)
+}
+
+// provideSearchService creates the search service.
+func provideSearchService(
+	cfg *config.Config,
+	contents domain.ContentRepository,
+	cache domain.Cache,
+	settings *service.SettingsService,
+	futurePublish domain.FuturePublishPolicy,
+	queries *service.QueryAnalyticsService,
+	logger *zap.Logger,
+) (*service.SearchService, error) {
+	shadow, err := newShadowConfig(cfg.Search.Shadow, cfg.Bleve)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewSearchService(contents, logger,
+		service.WithCache(cache, newCacheTTLs(cfg.Cache)),
+		service.WithCacheWarming(service.WarmConfig{Queries: cfg.Cache.Warm.Queries, TopN: cfg.Cache.Warm.TopN}),
+		service.WithSettings(settings),
+		service.WithSearchFuturePublish(futurePublish),
+		service.WithQueryAnalytics(queries),
+		service.WithMaxResults(cfg.Search.MaxResults),
+		service.WithLatencyBudget(cfg.Search.LatencyBudget),
+		// The event bus depends on this service, so comparisons go to the metrics directly
+		service.WithShadowTraffic(shadow, metrics.RecordEvent),
+	), nil
+}
+
+// newShadowConfig returns the shadow traffic settings of cfg, checking the ranking and
+// backend it names. Shadow searches on bleve need the index, which bleve configures.
+func newShadowConfig(cfg config.ShadowConfig, bleve config.BleveConfig) (service.ShadowConfig, error) {
+	scoring := domain.ScoringStrategy(cfg.Scoring)
+	if scoring != "" && !scoring.IsValid() {
+		return service.ShadowConfig{}, fmt.Errorf("invalid search.shadow.scoring %q", cfg.Scoring)
+	}
+	backend := domain.SearchBackend(cfg.Backend)
+	if backend != "" && !backend.IsValid() {
+		return service.ShadowConfig{}, fmt.Errorf("invalid search.shadow.backend %q", cfg.Backend)
+	}
+	if backend == domain.SearchBackendBleve && !bleve.Enabled {
+		return service.ShadowConfig{}, errors.New("search.shadow.backend bleve requires bleve.enabled")
+	}
+
+	return service.ShadowConfig{
+		Percent:     cfg.Percent,
+		Scoring:     scoring,
+		Backend:     backend,
+		K:           cfg.K,
+		MaxInFlight: cfg.MaxInFlight,
+		Timeout:     cfg.Timeout,
+	}, nil
+}
+
+// provideContentEventBus returns the bus streaming content events to SSE clients
+// on every instance, through Redis when enabled.
+func provideContentEventBus(cfg *config.Config, redisClient *redis.Client, logger *zap.Logger) domain.ContentEventBus {
+	if redisClient != nil {
+		return rediscache.NewEventBus(redisClient, logger, cfg.Cache.KeyPrefix)
+	}
+
+	return eventbus.NewContentBus(logger)
+}
+
+// provideWebhooks creates the service delivering content events to webhooks.
+func provideWebhooks(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *service.WebhookService {
+	return service.NewWebhookService(
+		postgres.NewWebhookRepository(db),
+		webhook.NewSender(cfg.Webhook.Timeout),
+		service.WebhookConfig{
+			Workers:     cfg.Webhook.Workers,
+			QueueSize:   cfg.Webhook.QueueSize,
+			MaxAttempts: cfg.Webhook.MaxAttempts,
+			Backoff:     cfg.Webhook.Backoff,
+		},
+		logger,
+	)
+}
+
+// provideCollections creates the service of admin-managed collections.
+func provideCollections(db *gorm.DB, logger *zap.Logger) *service.CollectionService {
+	return service.NewCollectionService(postgres.NewCollectionRepository(db), logger)
+}
+
+// provideSyncHistory creates the service recording sync runs.
+func provideSyncHistory(db *gorm.DB, contents domain.ContentRepository, logger *zap.Logger) *service.SyncHistoryService {
+	return service.NewSyncHistoryService(postgres.NewSyncRunRepository(db), contents, logger)
+}
+
+// provideDeadLetters creates the service keeping the items rejected by syncs.
+func provideDeadLetters(db *gorm.DB, logger *zap.Logger) *service.DeadLetterService {
+	return service.NewDeadLetterService(postgres.NewDeadLetterRepository(db), logger)
+}
+
+// provideEvents creates the bus of domain events. Sync publishes them; cache
+// invalidation, event streams, webhooks, metrics, the sync history and dead letters
+// subscribe (the dashboard does in provideDashboardNotifier).
+func provideEvents(
+	cfg *config.Config,
+	cache domain.Cache,
+	search *service.SearchService,
+	contentEvents domain.ContentEventBus,
+	webhooks *service.WebhookService,
+	syncHistory *service.SyncHistoryService,
+	deadLetters *service.DeadLetterService,
+	logger *zap.Logger,
+) *eventbus.Bus {
+	events := eventbus.New(logger)
+	if cache != nil {
+		var warmer service.CacheWarmer
+		if cfg.Cache.Warm.Enabled {
+			warmer = search
+		}
+		events.Subscribe("cache", service.NewCacheInvalidator(cache, warmer, logger).HandleEvent)
+	}
+	events.Subscribe("event stream", service.NewContentEventRelay(contentEvents, logger).HandleEvent)
+	events.Subscribe("webhooks", webhooks.HandleEvent)
+	events.Subscribe("metrics", metrics.RecordEvent)
+	events.Subscribe("sync history", syncHistory.HandleEvent)
+	events.Subscribe("dead letters", deadLetters.HandleEvent)
+
+	return events
+}
+
+// provideLocker creates the distributed locker of the configured backend.
+func provideLocker(cfg *config.Config, redisClient *redis.Client, db *gorm.DB, logger *zap.Logger) (locker.DistributedLocker, func(), error) {
+	l, closeLocker, err := newLocker(cfg.Lock, redisClient, db, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating distributed locker: %w", err)
+	}
+	logger.Info("distributed locking configured", zap.String("backend", cfg.Lock.Backend))
+
+	return l, closeLocker, nil
+}
+
+// provideOutboxRelay creates the relay publishing the content change events stored
+// in the outbox once committed (optional, based on config).
+func provideOutboxRelay(cfg *config.Config, db *gorm.DB, events *eventbus.Bus, logger *zap.Logger) *service.OutboxRelay {
+	if !cfg.Outbox.Enabled {
+		return nil
+	}
+
+	return service.NewOutboxRelay(postgres.NewOutboxRepository(db), events, service.OutboxConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+	}, logger)
+}
+
+// provideSyncEvents returns the publisher of sync events. With the outbox, content
+// change events are published by the relay instead.
+func provideSyncEvents(cfg *config.Config, events *eventbus.Bus) domain.EventPublisher {
+	if cfg.Outbox.Enabled {
+		return service.WithoutOutboxed(events)
+	}
+
+	return events
+}
+
+// provideSyncService creates the sync service. Manual and scheduled syncs queue
+// behind each other across instances; providers are switched on and off through
+// the runtime settings.
+func provideSyncService(
+	cfg *config.Config,
+	contents domain.ContentRepository,
+	providers []domain.Provider,
+	distLocker locker.DistributedLocker,
+	events domain.EventPublisher,
+	futurePublish domain.FuturePublishPolicy,
+	settings *service.SettingsService,
+	logger *zap.Logger,
+) *service.SyncService {
+	return service.NewSyncService(contents, providers, logger,
+		service.WithSyncLock(&service.SyncLock{
+			Locker:  distLocker,
+			TTL:     cfg.Sync.Timeout,
+			MaxWait: cfg.Sync.LockWait,
+		}),
+		service.WithEvents(events),
+		service.WithFuturePublish(futurePublish),
+		service.WithPipeline(service.SyncPipeline{
+			Buffer:    cfg.Sync.PipelineBuffer,
+			BatchSize: cfg.Sync.BatchSize,
+		}),
+		service.WithDisabledProviders(cfg.SyncDisabledProviders()...),
+		service.WithProviderSwitches(settings),
+	)
+}
+
+// providePurge creates the service purging provider items on removal requests. Like
+// syncs, it publishes content deletions unless the outbox does.
+func providePurge(
+	db *gorm.DB,
+	contents domain.ContentRepository,
+	cache domain.Cache,
+	events domain.EventPublisher,
+	logger *zap.Logger,
+) *service.PurgeService {
+	return service.NewPurgeService(contents, postgres.NewDeadLetterRepository(db), cache, events, logger)
+}
+
+// provideDashboardNotifier creates the live updates of dashboard sessions; subscribed
+// here since it reports sync statuses.
+func provideDashboardNotifier(
+	events *eventbus.Bus,
+	search *service.SearchService,
+	sync *service.SyncService,
+	logger *zap.Logger,
+) *service.DashboardNotifier {
+	notifier := service.NewDashboardNotifier(search, sync, logger)
+	events.Subscribe("dashboard", notifier.HandleEvent)
+
+	return notifier
+}
+
+// provideLockService creates the lock inspection for operators, if the backend supports it.
+func provideLockService(distLocker locker.DistributedLocker, logger *zap.Logger) *service.LockService {
+	inspector, ok := distLocker.(locker.Inspector)
+	if !ok {
+		return nil
+	}
+
+	return service.NewLockService(inspector, logger)
+}
+
+// provideAuth creates the JWT auth of admin routes (optional, based on config).
+func provideAuth(cfg *config.Config, logger *zap.Logger) (*middleware.JWTAuth, error) {
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+	auth, err := middleware.NewJWTAuth(context.Background(), middleware.JWTConfig{
+		Secret:          cfg.Auth.JWT.Secret,
+		JWKSURL:         cfg.Auth.JWT.JWKSURL,
+		JWKSRefresh:     cfg.Auth.JWT.JWKSRefresh,
+		Issuer:          cfg.Auth.JWT.Issuer,
+		Audience:        cfg.Auth.JWT.Audience,
+		RolesClaim:      cfg.Auth.JWT.RolesClaim,
+		ClockSkewLeeway: cfg.Auth.JWT.Leeway,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("configuring auth: %w", err)
+	}
+	logger.Info("admin auth enabled", zap.String("admin_role", cfg.Auth.AdminRole))
+
+	return auth, nil
+}
+
+// provideIdempotency creates the store of Idempotency-Key responses. Records share
+// Redis with the cache but not its namespace, so cache clears keep them.
+func provideIdempotency(cfg *config.Config, redisClient *redis.Client, logger *zap.Logger) domain.IdempotencyStore {
+	if !cfg.Idempotency.Enabled {
+		return nil
+	}
+	if redisClient == nil {
+		logger.Warn("idempotency keys need redis, ignoring Idempotency-Key headers")
+
+		return nil
+	}
+
+	return rediscache.NewIdempotencyStore(redisClient, cfg.Cache.KeyPrefix)
+}
+
+// provideQuotas creates the service enforcing the monthly request quotas of API keys
+// (optional, based on config). Requests are counted in Redis, or in Postgres without it.
+func provideQuotas(cfg *config.Config, redisClient *redis.Client, db *gorm.DB, logger *zap.Logger) (*service.QuotaService, error) {
+	if !cfg.Quota.Enabled {
+		return nil, nil
+	}
+
+	var store domain.QuotaStore = postgres.NewQuotaRepository(db)
+	if redisClient != nil {
+		store = rediscache.NewQuotaStore(redisClient, cfg.Cache.KeyPrefix)
+	}
+
+	keys := make([]service.QuotaKey, len(cfg.Quota.Keys))
+	for i, k := range cfg.Quota.Keys {
+		keys[i] = service.QuotaKey{Name: k.Name, Key: k.Key, Limit: k.MonthlyLimit}
+	}
+	quotas, err := service.NewQuotaService(store, keys, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quota config: %w", err)
+	}
+	logger.Info("api key quotas enabled", zap.Int("keys", len(keys)), zap.Bool("require_key", cfg.Quota.RequireKey))
+
+	return quotas, nil
+}
+
+// provideAudit creates the service recording admin API calls for compliance
+// (optional, based on config).
+func provideAudit(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *service.AuditService {
+	if !cfg.Audit.Enabled {
+		return nil
+	}
+
+	return service.NewAuditService(postgres.NewAuditRepository(db), cfg.Audit.Retention, logger)
+}
+
+// provideAnalytics creates the service recording search client events (optional,
+// based on config).
+func provideAnalytics(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *service.AnalyticsService {
+	if !cfg.Analytics.Enabled {
+		return nil
+	}
+
+	return service.NewAnalyticsService(postgres.NewAnalyticsRepository(db), logger)
+}
+
+// provideHealth creates the health report. Postgres is critical (readiness depends
+// on it); without Redis the instance still serves searches.
+func provideHealth(
+	cfg *config.Config,
+	db *gorm.DB,
+	redisClient *redis.Client,
+	providers []domain.Provider,
+	contents domain.ContentRepository,
+	logger *zap.Logger,
+) *service.HealthService {
+	dependencies := []service.DependencyCheck{
+		{Name: "postgres", Critical: true, Check: func(ctx context.Context) error {
+			return postgres.HealthCheck(ctx, db)
+		}},
+	}
+	if redisClient != nil {
+		dependencies = append(dependencies, service.DependencyCheck{Name: "redis", Check: func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}})
+	}
+
+	return service.NewHealthService(dependencies, providers, contents, cfg.Health.Timeout, logger)
+}
+
+// provideSLO creates the recorder of service level objectives (optional, based on
+// config) and registers its metrics.
+func provideSLO(cfg *config.Config) *metrics.SLORecorder {
+	if !cfg.SLO.Enabled {
+		return nil
+	}
+
+	objectives := make([]metrics.SLOObjective, 0, len(cfg.SLO.Objectives))
+	for _, o := range cfg.SLO.Objectives {
+		objectives = append(objectives, metrics.SLOObjective{
+			Name:          o.Name,
+			Routes:        o.Routes,
+			Availability:  o.Availability,
+			Latency:       o.Latency,
+			LatencyTarget: o.LatencyTarget,
+		})
+	}
+	slo := metrics.NewSLORecorder(objectives, cfg.SLO.Windows)
+	prometheus.MustRegister(slo)
+
+	return slo
+}
+
+// serverServices are the services behind the routes of the HTTP server, filled in
+// by wire.
+type serverServices struct {
+	Search         *service.SearchService
+	Sync           *service.SyncService
+	Webhooks       *service.WebhookService
+	Collections    *service.CollectionService
+	SyncHistory    *service.SyncHistoryService
+	Dashboard      *service.DashboardNotifier
+	Settings       *service.SettingsService
+	Locks          *service.LockService
+	Audit          *service.AuditService
+	Health         *service.HealthService
+	Analytics      *service.AnalyticsService
+	QueryAnalytics *service.QueryAnalyticsService
+	Dictionary     *service.DictionaryService
+	Reindex        *service.ReindexService
+	Snapshots      *service.SnapshotService
+	Purge          *service.PurgeService
+	ContentEvents  domain.ContentEventBus
+	CacheStats     domain.CacheStatsReporter
+	Auth           *middleware.JWTAuth
+	Idempotency    domain.IdempotencyStore
+	Quotas         *service.QuotaService
+	SLO            *metrics.SLORecorder
+}
+
+// provideServer creates the HTTP server.
+func provideServer(cfg *config.Config, svc serverServices, db *gorm.DB, logger *zap.Logger) (*httpserver.Server, error) {
+	v1DeprecatedAt, v1Sunset, err := cfg.API.V1.Dates()
+	if err != nil {
+		return nil, fmt.Errorf("invalid api.v1 deprecation config: %w", err)
+	}
+
+	var compression *middleware.CompressConfig
+	if cfg.HTTP.Compression.Enabled {
+		level, err := middleware.ParseCompressLevel(cfg.HTTP.Compression.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http.compression config: %w", err)
+		}
+		compression = &middleware.CompressConfig{
+			Level:   level,
+			MinSize: cfg.HTTP.Compression.MinSize,
+			Brotli:  cfg.HTTP.Compression.Brotli,
+		}
+	}
+
+	var tlsConfig *httpserver.TLSConfig
+	if cfg.HTTP.TLS.Enabled {
+		tlsConfig = &httpserver.TLSConfig{
+			CertFile:         cfg.HTTP.TLS.CertFile,
+			KeyFile:          cfg.HTTP.TLS.KeyFile,
+			AutocertHosts:    cfg.HTTP.TLS.Autocert.Hosts,
+			AutocertCacheDir: cfg.HTTP.TLS.Autocert.CacheDir,
+			AutocertEmail:    cfg.HTTP.TLS.Autocert.Email,
+		}
+	}
+
+	return httpserver.NewServer(
+		httpserver.ServerConfig{
+			Port:      cfg.App.Port,
+			BodyLimit: cfg.HTTP.BodyLimit,
+			Debug:     cfg.App.Debug,
+
+			SearchLimits: httpserver.RouteLimits{Timeout: cfg.HTTP.Search.Timeout, BodyLimit: cfg.HTTP.Search.BodyLimit},
+			AdminLimits:  httpserver.RouteLimits{Timeout: cfg.HTTP.Admin.Timeout, BodyLimit: cfg.HTTP.Admin.BodyLimit},
+			SyncLimits:   httpserver.RouteLimits{Timeout: cfg.HTTP.Sync.Timeout, BodyLimit: cfg.HTTP.Sync.BodyLimit},
+
+			CORS: middleware.CORSConfig{
+				AllowOrigins:     cfg.HTTP.CORS.AllowOrigins,
+				AllowMethods:     cfg.HTTP.CORS.AllowMethods,
+				AllowHeaders:     cfg.HTTP.CORS.AllowHeaders,
+				ExposeHeaders:    cfg.HTTP.CORS.ExposeHeaders,
+				AllowCredentials: cfg.HTTP.CORS.AllowCredentials,
+				MaxAge:           cfg.HTTP.CORS.MaxAge,
+			},
+			SecurityHeaders: middleware.SecurityHeadersConfig{
+				HSTSMaxAge:            cfg.HTTP.Security.HSTSMaxAge,
+				HSTSIncludeSubdomains: cfg.HTTP.Security.HSTSIncludeSubdomains,
+				ContentSecurityPolicy: cfg.HTTP.Security.DashboardCSP,
+			},
+			V1Deprecation: middleware.DeprecationConfig{Date: v1DeprecatedAt, Sunset: v1Sunset, Successor: "/api/v2"},
+
+			ReadinessCacheTTL: cfg.Health.ReadinessCache,
+		},
+		svc.Search,
+		svc.Sync,
+		db,
+		validator.New(),
+		logger,
+		httpserver.WithAuth(svc.Auth, cfg.Auth.AdminRole),
+		httpserver.WithIdempotency(svc.Idempotency, cfg.Idempotency.TTL),
+		httpserver.WithQuotas(svc.Quotas, cfg.Quota.RequireKey),
+		httpserver.WithCache(svc.CacheStats),
+		httpserver.WithMetrics(svc.SLO),
+		httpserver.WithTemplates(cfg.App.AssetsDir),
+		httpserver.WithTLS(tlsConfig),
+		httpserver.WithCompression(compression),
+		httpserver.WithEventStream(svc.ContentEvents),
+		httpserver.WithDashboardUpdates(svc.Dashboard),
+		httpserver.WithHealthDetails(svc.Health),
+		httpserver.WithWebhooks(svc.Webhooks),
+		httpserver.WithCollections(svc.Collections),
+		httpserver.WithSyncHistory(svc.SyncHistory),
+		httpserver.WithSettings(svc.Settings),
+		httpserver.WithLocks(svc.Locks),
+		httpserver.WithAudit(svc.Audit),
+		httpserver.WithAnalytics(svc.Analytics),
+		httpserver.WithQueryAnalytics(svc.QueryAnalytics),
+		httpserver.WithSuggestions(svc.Dictionary),
+		httpserver.WithReindex(svc.Reindex),
+		httpserver.WithSnapshots(svc.Snapshots),
+		httpserver.WithPurge(svc.Purge),
+	), nil
+}
+
+// provideSyncScheduler creates the scheduler of periodic syncs, coordinated across
+// instances by distLocker.
+func provideSyncScheduler(
+	cfg *config.Config,
+	sync *service.SyncService,
+	events *eventbus.Bus,
+	distLocker locker.DistributedLocker,
+	logger *zap.Logger,
+) *job.SyncScheduler {
+	return job.NewSyncScheduler(
+		sync,
+		job.SyncConfig{
+			Interval:  cfg.Sync.Interval,
+			Timeout:   cfg.Sync.Timeout,
+			OnStartup: cfg.Sync.OnStartup,
+
+			FailureAlertThreshold: cfg.Sync.FailureAlertThreshold,
+		},
+		events,
+		logger,
+		distLocker,
+	)
+}
+
+// provideCTR creates the service blending search click-through rates into content
+// scores (optional, based on config).
+func provideCTR(cfg *config.Config, db *gorm.DB, repo *postgres.Repository, cache domain.Cache, logger *zap.Logger) *service.CTRService {
+	if !cfg.CTR.Enabled {
+		return nil
+	}
+
+	return service.NewCTRService(
+		postgres.NewAnalyticsRepository(db),
+		repo,
+		cache,
+		service.CTRConfig{
+			Window:         cfg.CTR.Window,
+			Weight:         cfg.CTR.Weight,
+			MinImpressions: cfg.CTR.MinImpressions,
+		},
+		logger,
+	)
+}
+
+// provideSnapshots creates the service writing content snapshots to the store of the
+// config and restoring them (optional, based on config).
+func provideSnapshots(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache domain.Cache,
+	reindex *service.ReindexService,
+	logger *zap.Logger,
+) (*service.SnapshotService, error) {
+	if !cfg.Snapshot.Enabled {
+		return nil, nil
+	}
+
+	store, err := snapshot.NewStore(cfg.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot config: %w", err)
+	}
+
+	return service.NewSnapshotService(postgres.NewSnapshotRepository(db), store, cache, reindex, cfg.Snapshot.BatchSize, logger), nil
+}
+
+// provideCTRScheduler creates the scheduler of CTR refreshes, if CTR feedback is enabled.
+func provideCTRScheduler(cfg *config.Config, ctr *service.CTRService, distLocker locker.DistributedLocker, logger *zap.Logger) *job.CTRScheduler {
+	if ctr == nil {
+		return nil
+	}
+
+	return job.NewCTRScheduler(
+		ctr,
+		job.CTRSchedulerConfig{Interval: cfg.CTR.Interval, Timeout: cfg.CTR.Timeout},
+		logger,
+		distLocker,
+	)
+}
+
+// provideDictionaryScheduler creates the scheduler rebuilding the term dictionary
+// from the indexed contents, if the dictionary is enabled.
+func provideDictionaryScheduler(
+	cfg *config.Config,
+	dictionary *service.DictionaryService,
+	distLocker locker.DistributedLocker,
+	logger *zap.Logger,
+) *job.DictionaryScheduler {
+	if dictionary == nil {
+		return nil
+	}
+
+	return job.NewDictionaryScheduler(
+		dictionary,
+		job.DictionarySchedulerConfig{Interval: cfg.Dictionary.Interval, Timeout: cfg.Dictionary.Timeout},
+		logger,
+		distLocker,
+	)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/job"
+	"search-engine-service/internal/logger"
+)
+
+// reloadTargets are the components whose settings can change without a restart.
+type reloadTargets struct {
+	logger    *logger.Logger
+	search    *service.SearchService
+	settings  *service.SettingsService
+	ctr       *service.CTRService // nil when CTR feedback is disabled
+	scheduler *job.SyncScheduler
+}
+
+// subscribeReloads applies config file changes of logger.level, the cache TTLs,
+// ctr.weight and sync.interval to t. An invalid value rejects the whole change, so
+// the previous settings stay in effect (see config.Reloader.Subscribe).
+func subscribeReloads(r *config.Reloader, t reloadTargets) {
+	r.Subscribe("logger", func(old, updated *config.Config) error {
+		if updated.Logger.Level == old.Logger.Level {
+			return nil
+		}
+		if err := t.logger.SetLevel(updated.Logger.Level); err != nil {
+			return fmt.Errorf("logger.level: %w", err)
+		}
+		t.logger.Info("log level changed", zap.String("level", updated.Logger.Level))
+
+		return nil
+	})
+
+	r.Subscribe("cache", func(old, updated *config.Config) error {
+		ttls := newCacheTTLs(updated.Cache)
+		if ttls == newCacheTTLs(old.Cache) {
+			return nil
+		}
+		if ttls.Search <= 0 || ttls.Content <= 0 || ttls.NotFound <= 0 || ttls.Stats <= 0 || ttls.Stale < 0 || ttls.Degraded < 0 {
+			return errors.New("cache ttls must be positive")
+		}
+		t.search.SetCacheTTLs(ttls)
+		t.settings.SetDefaultSearchTTL(ttls.Search)
+		t.logger.Info("cache ttls changed",
+			zap.Duration("search_ttl", ttls.Search),
+			zap.Duration("content_ttl", ttls.Content),
+			zap.Duration("stale_ttl", ttls.Stale),
+		)
+
+		return nil
+	})
+
+	if t.ctr != nil {
+		r.Subscribe("ctr", func(old, updated *config.Config) error {
+			if updated.CTR.Weight == old.CTR.Weight {
+				return nil
+			}
+			if updated.CTR.Weight < 0 {
+				return errors.New("ctr.weight must not be negative")
+			}
+			t.ctr.SetWeight(updated.CTR.Weight)
+			t.logger.Info("ctr weight changed, applied on the next refresh", zap.Float64("weight", updated.CTR.Weight))
+
+			return nil
+		})
+	}
+
+	r.Subscribe("sync", func(old, updated *config.Config) error {
+		if updated.Sync.Interval == old.Sync.Interval {
+			return nil
+		}
+		if updated.Sync.Interval <= 0 {
+			return errors.New("sync.interval must be positive")
+		}
+		t.scheduler.SetInterval(updated.Sync.Interval)
+
+		return nil
+	})
+}
+
+// newCacheTTLs returns the TTL per cached value type configured in cfg.
+func newCacheTTLs(cfg config.CacheConfig) service.CacheTTLs {
+	return service.CacheTTLs{
+		Search:   cfg.SearchTTL,
+		Content:  cfg.ContentTTL,
+		NotFound: cfg.NotFoundTTL,
+		Stats:    cfg.StatsTTL,
+		Stale:    cfg.StaleTTL,
+		Degraded: cfg.DegradedTTL,
+	}
+}
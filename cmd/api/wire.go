@@ -0,0 +1,71 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/logger"
+)
+
+// newApp wires the components of the API from the config. The returned cleanup
+// closes the connections it opened, in reverse order.
+func newApp(cfg *config.Config, log *logger.Logger) (*app, func(), error) {
+	wire.Build(
+		wire.FieldsOf(new(*logger.Logger), "Logger"),
+
+		// Storage and providers
+		provideDB,
+		provideRepository,
+		provideSearchIndex,
+		provideContents,
+		provideReindex,
+		provideProviders,
+		provideRedis,
+		provideCache,
+		provideCacheStats,
+
+		// Services
+		provideSettings,
+		provideFuturePublish,
+		provideQueryAnalytics,
+		provideDictionary,
+		provideSearchService,
+		provideContentEventBus,
+		provideWebhooks,
+		provideCollections,
+		provideSyncHistory,
+		provideDeadLetters,
+		provideEvents,
+		provideLocker,
+		provideOutboxRelay,
+		provideSyncEvents,
+		provideSyncService,
+		providePurge,
+		provideDashboardNotifier,
+		provideLockService,
+		provideAudit,
+		provideAnalytics,
+		provideHealth,
+		provideCTR,
+		provideSnapshots,
+
+		// HTTP server
+		provideAuth,
+		provideIdempotency,
+		provideQuotas,
+		provideSLO,
+		wire.Struct(new(serverServices), "*"),
+		provideServer,
+
+		// Schedulers
+		provideSyncScheduler,
+		provideCTRScheduler,
+		provideDictionaryScheduler,
+
+		wire.Struct(new(app), "*"),
+	)
+
+	return nil, nil, nil
+}
@@ -0,0 +1,147 @@
+// Package main is a load test runner for search-engine-service. It runs built-in
+// scenarios against a running service and reports the latency of each operation, so
+// performance changes can be measured before a release.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		stop()
+		os.Exit(1)
+	}
+}
+
+// thresholds fail the run when a measurement exceeds them.
+type thresholds struct {
+	maxP99       time.Duration // Zero disables the check
+	maxErrorRate float64       // Fraction of requests; 1 disables the check
+}
+
+// newRootCommand builds the loadtest command.
+func newRootCommand() *cobra.Command {
+	cfg := config{}
+	var limits thresholds
+	var asJSON bool
+
+	var names []string
+	var list strings.Builder
+	for _, s := range scenarios {
+		names = append(names, s.name)
+		_, _ = fmt.Fprintf(&list, "  %-16s %s\n", s.name, s.description)
+	}
+
+	cmd := &cobra.Command{
+		Use:   "loadtest [scenario...]",
+		Short: "Measure search-engine-service latency under load",
+		Long: `Run load scenarios against a running service, one after the other, and report the
+request rate and latency percentiles of each operation. Without arguments, every
+scenario runs.
+
+Scenarios:
+` + list.String() + `
+Scenarios starting cold clear the cache first, which needs --api-key when auth is
+enabled. Seed a large corpus (admin seed --generate) for meaningful database numbers.`,
+		ValidArgs:    names,
+		Args:         cobra.OnlyValidArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected, err := findScenarios(args)
+			if err != nil {
+				return err
+			}
+			if cfg.concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			cfg.target = strings.TrimRight(cfg.target, "/")
+
+			r := newRunner(cmd.Context(), cfg, cmd.ErrOrStderr())
+			var results []result
+			for _, s := range selected {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "running %s for %s...\n", s.name, cfg.duration)
+				res, err := r.run(s)
+				if err != nil {
+					return err
+				}
+				results = append(results, res...)
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			} else if err := writeReport(cmd.OutOrStdout(), results); err != nil {
+				return err
+			}
+
+			return limits.check(results)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&cfg.target, "target", "http://localhost:8080", "base URL of the service")
+	flags.StringVar(&cfg.apiKey, "api-key", os.Getenv("ADMIN_API_KEY"), "bearer token for admin calls (env ADMIN_API_KEY)")
+	flags.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long each scenario sends requests")
+	flags.IntVar(&cfg.concurrency, "concurrency", 10, "concurrent workers per operation")
+	flags.DurationVar(&cfg.timeout, "request-timeout", 10*time.Second, "bound of each request")
+	flags.StringVar(&cfg.query, "query", "golang", "query of the hot searches")
+	flags.BoolVar(&asJSON, "json", false, "print the results as JSON, e.g. to compare runs in CI")
+	flags.DurationVar(&limits.maxP99, "max-p99", 0, "fail if any operation's p99 latency exceeds this")
+	flags.Float64Var(&limits.maxErrorRate, "max-error-rate", 1, "fail if any operation's error rate exceeds this fraction")
+
+	return cmd
+}
+
+// writeReport prints results as a table.
+func writeReport(w io.Writer, results []result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "SCENARIO\tOPERATION\tREQUESTS\tERRORS\tRPS\tP50\tP90\tP99\tMAX\t")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n",
+			r.Scenario, r.Operation, r.Requests, r.Errors, r.RPS,
+			round(r.P50), round(r.P90), round(r.P99), round(r.Max))
+	}
+
+	return tw.Flush()
+}
+
+// round shortens a latency for display.
+func round(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+
+	return d.Round(100 * time.Microsecond)
+}
+
+// check returns an error naming the first operation beyond a threshold.
+func (t thresholds) check(results []result) error {
+	for _, r := range results {
+		if t.maxP99 > 0 && r.P99 > t.maxP99 {
+			return fmt.Errorf("%s %s: p99 %s exceeds %s", r.Scenario, r.Operation, round(r.P99), t.maxP99)
+		}
+		if r.ErrorRate() > t.maxErrorRate {
+			return fmt.Errorf("%s %s: error rate %.3f exceeds %.3f", r.Scenario, r.Operation, r.ErrorRate(), t.maxErrorRate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// config holds the settings shared by the scenarios.
+type config struct {
+	target      string        // Base URL of the service
+	apiKey      string        // Bearer token for admin calls; optional
+	duration    time.Duration // How long each scenario sends requests
+	concurrency int           // Workers per operation, unless it sets its own
+	timeout     time.Duration // Bound of each request
+	query       string        // Query of the hot searches
+}
+
+// runner runs scenarios against the service.
+type runner struct {
+	ctx    context.Context
+	cfg    config
+	client *http.Client
+	warn   io.Writer
+}
+
+// newRunner creates a runner whose requests are bounded by ctx.
+func newRunner(ctx context.Context, cfg config, warn io.Writer) *runner {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Keep a connection per worker instead of churning through ephemeral ports
+	transport.MaxIdleConnsPerHost = cfg.concurrency + 1
+
+	return &runner{
+		ctx:    ctx,
+		cfg:    cfg,
+		client: &http.Client{Transport: transport, Timeout: cfg.timeout},
+		warn:   warn,
+	}
+}
+
+// result is the measurement of an operation of a scenario.
+type result struct {
+	Scenario  string        `json:"scenario"`
+	Operation string        `json:"operation"`
+	Requests  int           `json:"requests"`
+	Errors    int           `json:"errors"` // Transport errors and 4xx/5xx responses
+	RPS       float64       `json:"rps"`
+	P50       time.Duration `json:"p50_ns"`
+	P90       time.Duration `json:"p90_ns"`
+	P99       time.Duration `json:"p99_ns"`
+	Max       time.Duration `json:"max_ns"`
+}
+
+// ErrorRate returns the fraction of requests that failed.
+func (r result) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+// run sends the requests of s for the configured duration and measures each operation.
+// Requests in flight when the duration ends are waited for and counted.
+func (r *runner) run(s scenario) ([]result, error) {
+	if s.setup != nil {
+		if err := s.setup(r); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.name, err)
+		}
+	}
+
+	ops := s.operations(r.cfg)
+	recorders := make([]*recorder, len(ops))
+	deadline := time.Now().Add(r.cfg.duration)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i, op := range ops {
+		rec := &recorder{}
+		recorders[i] = rec
+
+		var sent atomic.Int64
+		workers := op.workers
+		if workers == 0 {
+			workers = r.cfg.concurrency
+		}
+		for range workers {
+			wg.Go(func() {
+				for time.Now().Before(deadline) && r.ctx.Err() == nil {
+					req := op.request(sent.Add(1) - 1)
+					began := time.Now()
+					status, err := r.send(r.ctx, req)
+					if r.ctx.Err() != nil {
+						return // Interrupted, not a failure of the service
+					}
+					rec.record(time.Since(began), status, err)
+				}
+			})
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]result, len(ops))
+	for i, op := range ops {
+		results[i] = recorders[i].result(s.name, op.name, elapsed)
+	}
+
+	return results, nil
+}
+
+// send sends req and reads the whole response body, so latencies include it.
+func (r *runner) send(ctx context.Context, req request) (int, error) {
+	target := r.cfg.target + "/api/v2" + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	if r.cfg.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.cfg.apiKey)
+	}
+
+	resp, err := r.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, err = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, err
+}
+
+// warnf reports a problem that doesn't stop the run.
+func (r *runner) warnf(format string, args ...any) {
+	_, _ = fmt.Fprintf(r.warn, "warning: "+format+"\n", args...)
+}
+
+// recorder collects the latencies and failures of an operation.
+type recorder struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    int
+}
+
+// record adds a request that took latency and ended with status or err.
+func (r *recorder) record(latency time.Duration, status int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies = append(r.latencies, latency)
+	if err != nil || status >= http.StatusBadRequest {
+		r.errors++
+	}
+}
+
+// result summarizes the recorded requests, sent over elapsed.
+func (r *recorder) result(scenarioName, operationName string, elapsed time.Duration) result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latencies := slices.Clone(r.latencies)
+	slices.Sort(latencies)
+
+	res := result{
+		Scenario:  scenarioName,
+		Operation: operationName,
+		Requests:  len(latencies),
+		Errors:    r.errors,
+		P50:       percentile(latencies, 0.50),
+		P90:       percentile(latencies, 0.90),
+		P99:       percentile(latencies, 0.99),
+	}
+	if len(latencies) > 0 {
+		res.Max = latencies[len(latencies)-1]
+	}
+	if elapsed > 0 {
+		res.RPS = float64(len(latencies)) / elapsed.Seconds()
+	}
+
+	return res
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted latencies (0 if empty).
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+
+	return sorted[max(rank, 1)-1]
+}
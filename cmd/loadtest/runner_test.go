@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile_NearestRank(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 0.50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 0.99))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 0.99))
+	assert.Zero(t, percentile(nil, 0.5))
+}
+
+func TestRunner_ConcurrentSync(t *testing.T) {
+	var searches, syncs atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/contents":
+			assert.Equal(t, "golang", r.URL.Query().Get("q"))
+			searches.Add(1)
+		case "POST /api/v2/admin/sync":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			syncs.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusConflict)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config{target: server.URL, apiKey: "token", duration: 100 * time.Millisecond, concurrency: 2, timeout: time.Second, query: "golang"}
+	selected, err := findScenarios([]string{"concurrent-sync"})
+	require.NoError(t, err)
+	results, err := newRunner(context.Background(), cfg, &bytes.Buffer{}).run(selected[0])
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	search, sync := results[0], results[1]
+	assert.Equal(t, "search", search.Operation)
+	assert.Equal(t, int(searches.Load()), search.Requests, "requests in flight at the end are counted")
+	assert.Zero(t, search.Errors)
+	assert.Equal(t, "sync", sync.Operation)
+	assert.Equal(t, int(syncs.Load()), sync.Requests)
+	assert.Equal(t, sync.Requests, sync.Errors, "error statuses count as errors")
+	assert.GreaterOrEqual(t, sync.P50, 20*time.Millisecond)
+}
+
+func TestRootCommand_FailsBeyondThresholds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	var out, warnings bytes.Buffer
+	cmd := newRootCommand()
+	cmd.SetArgs([]string{"cold-cache", "--target", server.URL, "--duration", "50ms", "--json", "--max-error-rate", "0", "--max-p99", "1ns"})
+	cmd.SetOut(&out)
+	cmd.SetErr(&warnings)
+
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "cold-cache search: p99")
+	assert.Contains(t, warnings.String(), "clearing the cache returned 401")
+
+	var results []result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Positive(t, results[0].Requests)
+}
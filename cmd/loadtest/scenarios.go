@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// scenario is a named load pattern, made of operations sent concurrently.
+type scenario struct {
+	name        string
+	description string
+	// setup runs once before the operations start (can be nil)
+	setup func(r *runner) error
+	// operations returns the operations of the scenario
+	operations func(cfg config) []operation
+}
+
+// operation is a kind of request of a scenario, reported separately.
+type operation struct {
+	name    string
+	workers int                   // Concurrent senders; 0 uses the concurrency flag
+	request func(n int64) request // Builds the n-th request, counting from 0 across workers
+}
+
+// request is an HTTP request relative to the API base URL (e.g. /api/v2).
+type request struct {
+	method string
+	path   string
+	query  url.Values
+}
+
+// Words the cold cache and deep pagination scenarios search for, taken from the mock datasets.
+var searchWords = []string{"go", "golang", "docker", "kubernetes", "tutorial", "programming", "microservices", "api"}
+
+// scenarios lists the built-in scenarios in the order they run by default.
+var scenarios = []scenario{
+	{
+		name:        "hot-query",
+		description: "The same search over and over, served from the cache",
+		operations: func(cfg config) []operation {
+			return []operation{hotSearch(cfg)}
+		},
+	},
+	{
+		name:        "cold-cache",
+		description: "Searches with a distinct cache key each, so every one queries the database",
+		setup:       clearCache,
+		operations: func(config) []operation {
+			return []operation{{
+				name: "search",
+				request: func(n int64) request {
+					// Word, page size and page together never repeat
+					words, sizes := int64(len(searchWords)), int64(100)
+					return request{method: http.MethodGet, path: "/contents", query: url.Values{
+						"q":         {searchWords[n%words]},
+						"page_size": {strconv.FormatInt(1+n/words%sizes, 10)},
+						"page":      {strconv.FormatInt(1+n/(words*sizes), 10)},
+					}}
+				},
+			}}
+		},
+	},
+	{
+		name:        "deep-pagination",
+		description: "Pages 100 and beyond of the newest contents, where OFFSET skips thousands of rows",
+		setup:       clearCache,
+		operations: func(config) []operation {
+			return []operation{{
+				name: "search",
+				request: func(n int64) request {
+					// Page and page size together repeat after 11,000 requests
+					return request{method: http.MethodGet, path: "/contents", query: url.Values{
+						"sort_by":   {"published_at"},
+						"page_size": {strconv.FormatInt(90+n/1000%11, 10)},
+						"page":      {strconv.FormatInt(100+n%1000, 10)},
+					}}
+				},
+			}}
+		},
+	},
+	{
+		name:        "concurrent-sync",
+		description: "The hot query while a sync of all providers runs back to back (needs --api-key with auth)",
+		operations: func(cfg config) []operation {
+			return []operation{
+				hotSearch(cfg),
+				{name: "sync", workers: 1, request: func(int64) request {
+					return request{method: http.MethodPost, path: "/admin/sync"}
+				}},
+			}
+		},
+	},
+}
+
+// hotSearch searches for the configured query.
+func hotSearch(cfg config) operation {
+	return operation{
+		name: "search",
+		request: func(int64) request {
+			return request{method: http.MethodGet, path: "/contents", query: url.Values{"q": {cfg.query}}}
+		},
+	}
+}
+
+// clearCache empties the search cache, so a scenario starts cold. Without an API key
+// the call may be rejected; the scenario then runs with what is cached.
+func clearCache(r *runner) error {
+	status, err := r.send(r.ctx, request{method: http.MethodDelete, path: "/admin/cache"})
+	if err != nil {
+		return fmt.Errorf("clearing cache: %w", err)
+	}
+	if status >= http.StatusBadRequest {
+		r.warnf("clearing the cache returned %d, starting with what is cached", status)
+	}
+
+	return nil
+}
+
+// findScenarios returns the scenarios with the given names, or all of them when
+// names is empty.
+func findScenarios(names []string) ([]scenario, error) {
+	if len(names) == 0 {
+		return scenarios, nil
+	}
+
+	found := make([]scenario, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(scenarios, func(s scenario) bool { return s.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown scenario %q", name)
+		}
+		found = append(found, scenarios[i])
+	}
+
+	return found, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// Bounds on client-reported event times.
+const (
+	analyticsMaxClockSkew = 5 * time.Minute    // How far in the future occurred_at may be
+	analyticsMaxEventAge  = 7 * 24 * time.Hour // How late events may be reported (e.g. from offline clients)
+)
+
+// AnalyticsService records search result impressions and clicks.
+type AnalyticsService struct {
+	repo   domain.AnalyticsEventRepository
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewAnalyticsService creates a new AnalyticsService.
+func NewAnalyticsService(repo domain.AnalyticsEventRepository, logger *zap.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Record validates and stores a batch of events. Events without an occurrence time
+// are stamped with the current time. The batch is rejected as a whole with
+// domain.ErrInvalidParams if any event is invalid.
+func (s *AnalyticsService) Record(ctx context.Context, events []*domain.AnalyticsEvent) error {
+	now := s.now().UTC()
+
+	for i, e := range events {
+		if !e.Type.IsValid() {
+			return fmt.Errorf("%w: events[%d]: unknown type %q", domain.ErrInvalidParams, i, e.Type)
+		}
+		if e.Position < 1 {
+			return fmt.Errorf("%w: events[%d]: position must be at least 1", domain.ErrInvalidParams, i)
+		}
+
+		if e.OccurredAt.IsZero() {
+			e.OccurredAt = now
+		}
+		if e.OccurredAt.After(now.Add(analyticsMaxClockSkew)) {
+			return fmt.Errorf("%w: events[%d]: occurred_at is in the future", domain.ErrInvalidParams, i)
+		}
+		if e.OccurredAt.Before(now.Add(-analyticsMaxEventAge)) {
+			return fmt.Errorf("%w: events[%d]: occurred_at is older than %s", domain.ErrInvalidParams, i, analyticsMaxEventAge)
+		}
+		e.OccurredAt = e.OccurredAt.UTC()
+	}
+
+	if err := s.repo.CreateBatch(ctx, events); err != nil {
+		return fmt.Errorf("recording analytics events: %w", err)
+	}
+
+	return nil
+}
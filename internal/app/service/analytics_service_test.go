@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeAnalyticsRepo is an in-memory AnalyticsEventRepository for tests.
+type fakeAnalyticsRepo struct {
+	batches [][]*domain.AnalyticsEvent
+	ctrs    []domain.ContentCTR
+	since   time.Time
+}
+
+func (r *fakeAnalyticsRepo) CreateBatch(_ context.Context, events []*domain.AnalyticsEvent) error {
+	r.batches = append(r.batches, events)
+
+	return nil
+}
+
+func (r *fakeAnalyticsRepo) AggregateCTR(_ context.Context, since time.Time) ([]domain.ContentCTR, error) {
+	r.since = since
+
+	return r.ctrs, nil
+}
+
+func newTestAnalyticsService(now time.Time) (*AnalyticsService, *fakeAnalyticsRepo) {
+	repo := &fakeAnalyticsRepo{}
+	svc := NewAnalyticsService(repo, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	return svc, repo
+}
+
+func TestAnalyticsService_Record_StampsMissingTimes(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc, repo := newTestAnalyticsService(now)
+	reported := now.Add(-time.Hour)
+
+	events := []*domain.AnalyticsEvent{
+		{Type: domain.AnalyticsEventImpression, ContentID: "c-1", Position: 1},
+		{Type: domain.AnalyticsEventClick, ContentID: "c-1", Position: 1, OccurredAt: reported},
+	}
+	require.NoError(t, svc.Record(context.Background(), events))
+
+	require.Len(t, repo.batches, 1)
+	assert.Equal(t, now, repo.batches[0][0].OccurredAt)
+	assert.Equal(t, reported, repo.batches[0][1].OccurredAt)
+}
+
+func TestAnalyticsService_Record_RejectsInvalidBatch(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	valid := domain.AnalyticsEvent{Type: domain.AnalyticsEventClick, ContentID: "c-1", Position: 2}
+
+	tests := []struct {
+		name   string
+		mutate func(e *domain.AnalyticsEvent)
+	}{
+		{"unknown type", func(e *domain.AnalyticsEvent) { e.Type = "view" }},
+		{"zero position", func(e *domain.AnalyticsEvent) { e.Position = 0 }},
+		{"future time", func(e *domain.AnalyticsEvent) { e.OccurredAt = now.Add(time.Hour) }},
+		{"stale time", func(e *domain.AnalyticsEvent) { e.OccurredAt = now.Add(-8 * 24 * time.Hour) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestAnalyticsService(now)
+			first, second := valid, valid
+			tt.mutate(&second)
+
+			err := svc.Record(context.Background(), []*domain.AnalyticsEvent{&first, &second})
+
+			require.ErrorIs(t, err, domain.ErrInvalidParams)
+			assert.Empty(t, repo.batches, "the whole batch is rejected")
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// auditPruneInterval is how often recording an entry also removes the expired ones.
+const auditPruneInterval = time.Hour
+
+// AuditService records admin API calls and queries the audit log.
+type AuditService struct {
+	repo      domain.AuditLogRepository
+	retention time.Duration // Entries older than this are removed (0 keeps them)
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	prunedAt time.Time // Last removal of expired entries
+}
+
+// NewAuditService creates a new AuditService keeping entries for retention.
+func NewAuditService(repo domain.AuditLogRepository, retention time.Duration, logger *zap.Logger) *AuditService {
+	return &AuditService{
+		repo:      repo,
+		retention: retention,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Record stores an audit entry. Every auditPruneInterval, it also removes the
+// entries older than the retention; failing to do so is only logged.
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("recording audit entry: %w", err)
+	}
+
+	if s.pruneDue() {
+		if _, err := s.repo.DeleteBefore(ctx, s.now().UTC().Add(-s.retention)); err != nil {
+			logger.FromContext(ctx, s.logger).Warn("failed to prune audit entries", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// pruneDue reports whether expired entries should be removed now, and if so
+// counts them as removed, so concurrent calls don't all do it.
+func (s *AuditService) pruneDue() bool {
+	if s.retention <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.prunedAt) < auditPruneInterval {
+		return false
+	}
+	s.prunedAt = now
+
+	return true
+}
+
+// List returns a page of audit entries matching filter, newest first, and the total match count.
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, int64, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, fmt.Errorf("%w: from must be before to", domain.ErrInvalidParams)
+	}
+
+	entries, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeAuditRepo is an in-memory AuditLogRepository for tests.
+type fakeAuditRepo struct {
+	entries []*domain.AuditEntry
+	filters []domain.AuditFilter
+	deletes []time.Time
+}
+
+func (r *fakeAuditRepo) Create(_ context.Context, entry *domain.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+func (r *fakeAuditRepo) List(_ context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, int64, error) {
+	r.filters = append(r.filters, filter)
+
+	return r.entries, int64(len(r.entries)), nil
+}
+
+func (r *fakeAuditRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.deletes = append(r.deletes, before)
+
+	return 0, nil
+}
+
+func TestAuditService_RecordAndList(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	svc := NewAuditService(repo, 0, zap.NewNop())
+
+	require.NoError(t, svc.Record(context.Background(), &domain.AuditEntry{Actor: "user-1", Method: "POST"}))
+
+	filter := domain.AuditFilter{Actor: "user-1", Page: 1, PageSize: 50}
+	entries, total, err := svc.List(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "user-1", entries[0].Actor)
+	assert.Equal(t, []domain.AuditFilter{filter}, repo.filters)
+}
+
+func TestAuditService_List_RejectsInvertedRange(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	svc := NewAuditService(repo, 0, zap.NewNop())
+
+	now := time.Now()
+	_, _, err := svc.List(context.Background(), domain.AuditFilter{From: now, To: now.Add(-time.Hour), Page: 1, PageSize: 50})
+
+	require.ErrorIs(t, err, domain.ErrInvalidParams)
+	assert.Empty(t, repo.filters)
+}
+
+func TestAuditService_PrunesExpiredEntriesHourly(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	svc := NewAuditService(repo, 90*24*time.Hour, zap.NewNop())
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, svc.Record(ctx, &domain.AuditEntry{Actor: "user-1"}))
+	require.NoError(t, svc.Record(ctx, &domain.AuditEntry{Actor: "user-1"}))
+	assert.Equal(t, []time.Time{now.AddDate(0, 0, -90)}, repo.deletes, "once per interval")
+
+	now = now.Add(auditPruneInterval)
+	require.NoError(t, svc.Record(ctx, &domain.AuditEntry{Actor: "user-1"}))
+	assert.Len(t, repo.deletes, 2)
+	assert.Len(t, repo.entries, 3)
+}
+
+func TestAuditService_KeepsEntriesWithoutRetention(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	svc := NewAuditService(repo, 0, zap.NewNop())
+
+	require.NoError(t, svc.Record(context.Background(), &domain.AuditEntry{Actor: "user-1"}))
+	assert.Empty(t, repo.deletes)
+}
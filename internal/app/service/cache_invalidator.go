@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// CacheInvalidator drops cached contents and search results when content changes.
+// Cache errors are logged but never fail the change - stale entries still expire via TTL.
+type CacheInvalidator struct {
+	cache  domain.Cache
+	warmer CacheWarmer // Optional warmer run after search results were cleared (can be nil)
+	logger *zap.Logger
+}
+
+// NewCacheInvalidator creates a new CacheInvalidator.
+// warmer is optional and re-populates hot queries after the cache was cleared.
+func NewCacheInvalidator(cache domain.Cache, warmer CacheWarmer, logger *zap.Logger) *CacheInvalidator {
+	return &CacheInvalidator{
+		cache:  cache,
+		warmer: warmer,
+		logger: logger,
+	}
+}
+
+// HandleEvent is an event bus handler:
+//   - ContentUpserted drops the per-ID entries (including "not found" markers) of the contents
+//   - SyncCompleted clears search results once any provider upserted content, then warms the cache
+//   - ContentDeleted drops the content and all search results, since they may include it
+//   - ContentCurated does the same, since the content's ranking or visibility changed
+func (c *CacheInvalidator) HandleEvent(ctx context.Context, event domain.Event) {
+	switch e := event.(type) {
+	case domain.ContentUpserted:
+		c.invalidateContents(ctx, e.Contents)
+	case domain.SyncCompleted:
+		c.invalidateSearches(ctx, e.Upserted())
+	case domain.ContentDeleted:
+		c.invalidateContent(ctx, e.ID)
+	case domain.ContentCurated:
+		c.invalidateContent(ctx, e.Content.ID)
+	}
+}
+
+// invalidateContents drops per-ID cache entries for freshly upserted contents.
+func (c *CacheInvalidator) invalidateContents(ctx context.Context, contents []*domain.Content) {
+	for _, content := range contents {
+		if content.ID == "" {
+			continue
+		}
+		if err := c.cache.Delete(ctx, contentCacheKey(content.ID)); err != nil {
+			logger.FromContext(ctx, c.logger).Warn("failed to invalidate content cache",
+				zap.String("id", content.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+	}
+}
+
+// invalidateSearches clears cached search results after a sync upserted content.
+func (c *CacheInvalidator) invalidateSearches(ctx context.Context, upserted int) {
+	if upserted == 0 {
+		return
+	}
+
+	if err := c.cache.Clear(ctx); err != nil {
+		logger.FromContext(ctx, c.logger).Warn("failed to invalidate cache after sync",
+			zap.Int("upserted", upserted),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	logger.FromContext(ctx, c.logger).Debug("cache invalidated after sync", zap.Int("upserted", upserted))
+
+	if c.warmer != nil {
+		c.warmer.WarmCache(ctx)
+	}
+}
+
+// invalidateContent drops a deleted or curated content and all search results.
+func (c *CacheInvalidator) invalidateContent(ctx context.Context, id string) {
+	if err := c.cache.Delete(ctx, contentCacheKey(id)); err != nil {
+		logger.FromContext(ctx, c.logger).Warn("failed to invalidate content cache", zap.String("id", id), zap.Error(err))
+	}
+
+	if err := c.cache.Clear(ctx); err != nil {
+		logger.FromContext(ctx, c.logger).Warn("failed to clear cache after content change", zap.String("id", id), zap.Error(err))
+	}
+}
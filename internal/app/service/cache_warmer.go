@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// maxTrackedQueries bounds the memory used by the query frequency tracker.
+const maxTrackedQueries = 1000
+
+// CacheWarmer re-populates hot cache entries, typically right after a sync invalidated them.
+type CacheWarmer interface {
+	// WarmCache executes and caches the configured and most frequent searches.
+	WarmCache(ctx context.Context)
+}
+
+// WarmConfig controls which searches are re-executed after invalidation.
+type WarmConfig struct {
+	Queries []string // Always-warmed queries ("" warms the default listing)
+	TopN    int      // Additionally warm the N most frequent searches seen by this instance
+}
+
+// queryTracker counts how often each distinct search is requested.
+// Only first pages are tracked since deeper pages are rarely hot.
+//
+// Once full, a new search replaces the least frequent one and takes over its count
+// plus one, so a search becoming popular still makes it to the top. Counts are halved
+// after every warm-up (see decay), so searches that were popular once give way to
+// those that are now.
+type queryTracker struct {
+	mu     sync.Mutex
+	counts map[string]*trackedQuery
+}
+
+type trackedQuery struct {
+	params domain.SearchParams
+	count  int
+}
+
+func newQueryTracker() *queryTracker {
+	return &queryTracker{counts: make(map[string]*trackedQuery)}
+}
+
+// record increments the counter for params, evicting the least frequent search when
+// the tracker is full.
+func (t *queryTracker) record(key string, params domain.SearchParams) {
+	if params.Page != 1 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if q, ok := t.counts[key]; ok {
+		q.count++
+
+		return
+	}
+
+	count := 1
+	if len(t.counts) >= maxTrackedQueries {
+		var leastKey string
+		var least *trackedQuery
+		for k, q := range t.counts {
+			if least == nil || q.count < least.count {
+				leastKey, least = k, q
+			}
+		}
+		delete(t.counts, leastKey)
+		count = least.count + 1
+	}
+	t.counts[key] = &trackedQuery{params: params, count: count}
+}
+
+// decay halves every count, forgetting the searches whose count drops to zero.
+func (t *queryTracker) decay() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for k, q := range t.counts {
+		q.count /= 2
+		if q.count == 0 {
+			delete(t.counts, k)
+		}
+	}
+}
+
+// top returns up to n of the most frequently requested searches.
+func (t *queryTracker) top(n int) []domain.SearchParams {
+	if n <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	queries := make([]*trackedQuery, 0, len(t.counts))
+	for _, q := range t.counts {
+		queries = append(queries, q)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(queries, func(i, j int) bool { return queries[i].count > queries[j].count })
+	if len(queries) > n {
+		queries = queries[:n]
+	}
+
+	params := make([]domain.SearchParams, len(queries))
+	for i, q := range queries {
+		params[i] = q.params
+	}
+
+	return params
+}
+
+// WarmCache executes the configured queries and the top-N tracked searches so the
+// first users after an invalidation don't pay the cold-path latency.
+// Failures are logged and never propagated.
+func (s *SearchService) WarmCache(ctx context.Context) {
+	if s.activeCache() == nil {
+		return
+	}
+
+	targets := make([]domain.SearchParams, 0, len(s.warm.Queries)+s.warm.TopN)
+	for _, q := range s.warm.Queries {
+		params := domain.DefaultSearchParams()
+		params.Query = q
+		if q != "" {
+			params.SortBy = domain.SortFieldRelevance
+		}
+		targets = append(targets, params)
+	}
+	targets = append(targets, s.tracker.top(s.warm.TopN)...)
+	s.tracker.decay()
+
+	warmed := 0
+	seen := make(map[string]struct{}, len(targets))
+	for _, params := range targets {
+		params.Validate()
+		key := buildSearchCacheKey(params)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if _, err := s.search(ctx, params); err != nil {
+			logger.FromContext(ctx, s.logger).Warn("cache warm query failed",
+				zap.String("query", params.Query),
+				zap.Error(err),
+			)
+
+			continue
+		}
+		warmed++
+	}
+
+	logger.FromContext(ctx, s.logger).Info("cache warmed", zap.Int("queries", warmed))
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func trackedSearch(query string) domain.SearchParams {
+	params := domain.DefaultSearchParams()
+	params.Query = query
+
+	return params
+}
+
+func TestQueryTracker_FullTrackerLearnsNewHotQueries(t *testing.T) {
+	tracker := newQueryTracker()
+	for i := range maxTrackedQueries {
+		params := trackedSearch(fmt.Sprint("old-", i))
+		for range 5 {
+			tracker.record(params.Query, params)
+		}
+	}
+
+	hot := trackedSearch("new")
+	for range 10 {
+		tracker.record(hot.Query, hot)
+	}
+
+	assert.Len(t, tracker.counts, maxTrackedQueries)
+	top := tracker.top(1)
+	require.Len(t, top, 1)
+	assert.Equal(t, "new", top[0].Query)
+}
+
+func TestQueryTracker_DecayForgetsPastPopularity(t *testing.T) {
+	tracker := newQueryTracker()
+	old, recent := trackedSearch("old"), trackedSearch("recent")
+	for range 8 {
+		tracker.record(old.Query, old)
+	}
+
+	// Warm-ups halve the counts; the old query drops out once it isn't requested
+	for range 4 {
+		tracker.decay()
+		for range 2 {
+			tracker.record(recent.Query, recent)
+		}
+	}
+
+	top := tracker.top(2)
+	require.Len(t, top, 1)
+	assert.Equal(t, "recent", top[0].Query)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// CollectionService manages collections, ordered groups of contents such as a
+// tutorial series.
+type CollectionService struct {
+	repo   domain.CollectionRepository
+	logger *zap.Logger
+}
+
+// NewCollectionService creates a new CollectionService.
+func NewCollectionService(repo domain.CollectionRepository, logger *zap.Logger) *CollectionService {
+	return &CollectionService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Create stores a new collection of the given contents, in order.
+// Returns domain.ErrInvalidParams if a content doesn't exist.
+func (s *CollectionService) Create(ctx context.Context, title, description string, contentIDs []string) (*domain.Collection, error) {
+	collection := &domain.Collection{
+		Title:       title,
+		Description: description,
+		ContentIDs:  nonNil(contentIDs),
+	}
+	if err := s.repo.Create(ctx, collection); err != nil {
+		s.logFailure(ctx, "create collection failed", "", err)
+
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info("collection created",
+		zap.String("id", collection.ID),
+		zap.Int("contents", len(contentIDs)),
+	)
+
+	return collection, nil
+}
+
+// Update replaces the title, description and contents of a collection.
+// Returns domain.ErrNotFound if it doesn't exist and domain.ErrInvalidParams if a content doesn't exist.
+func (s *CollectionService) Update(ctx context.Context, id, title, description string, contentIDs []string) (*domain.Collection, error) {
+	collection := &domain.Collection{
+		ID:          id,
+		Title:       title,
+		Description: description,
+		ContentIDs:  nonNil(contentIDs),
+	}
+	if err := s.repo.Update(ctx, collection); err != nil {
+		s.logFailure(ctx, "update collection failed", id, err)
+
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// Delete removes a collection. Its contents are left alone.
+// Returns domain.ErrNotFound if it didn't exist.
+func (s *CollectionService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// List returns all collections.
+func (s *CollectionService) List(ctx context.Context) ([]*domain.Collection, error) {
+	return s.repo.List(ctx)
+}
+
+// GetWithContents returns a collection along with its contents in collection order.
+// Blocked contents are left out. Returns domain.ErrNotFound if it doesn't exist.
+func (s *CollectionService) GetWithContents(ctx context.Context, id string) (*domain.Collection, []*domain.Content, error) {
+	collection, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents, err := s.repo.Contents(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return collection, contents, nil
+}
+
+// logFailure logs a failed change unless it was caused by the caller's input.
+func (s *CollectionService) logFailure(ctx context.Context, msg, id string, err error) {
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidParams) {
+		return
+	}
+
+	logger.FromContext(ctx, s.logger).Error(msg, zap.String("id", id), zap.Error(err))
+}
+
+// nonNil returns ids, or an empty slice if it's nil, so empty collections list no contents rather than null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+
+	return ids
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// ContentEventRelay forwards upserted contents to the ContentEventBus, which streams
+// them to event stream clients on every instance.
+type ContentEventRelay struct {
+	bus    domain.ContentEventBus
+	logger *zap.Logger
+}
+
+// NewContentEventRelay creates a new ContentEventRelay.
+func NewContentEventRelay(bus domain.ContentEventBus, logger *zap.Logger) *ContentEventRelay {
+	return &ContentEventRelay{bus: bus, logger: logger}
+}
+
+// HandleEvent is an event bus handler publishing a created/updated event per upserted content.
+// Publish errors are logged but never fail the sync.
+func (r *ContentEventRelay) HandleEvent(ctx context.Context, event domain.Event) {
+	e, ok := event.(domain.ContentUpserted)
+	if !ok {
+		return
+	}
+
+	if err := r.bus.Publish(ctx, e.ContentEvents()); err != nil {
+		logger.FromContext(ctx, r.logger).Warn("failed to publish content events",
+			zap.String("provider", e.Provider),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// CTRConfig holds the settings for blending click-through rates into scores.
+type CTRConfig struct {
+	Window         time.Duration // Events older than this are ignored
+	Weight         float64       // Boost of a result clicked on every impression (see domain.CTRBoost)
+	MinImpressions int64         // Contents shown fewer times keep no boost
+}
+
+// CTRService feeds search click-through rates back into content scores.
+type CTRService struct {
+	events domain.AnalyticsEventRepository
+	boosts domain.ContentBoostRepository
+	cache  domain.Cache // Optional cache cleared after boosts change (can be nil)
+	cfg    CTRConfig
+	weight atomic.Uint64 // math.Float64bits of the boost weight; replaces cfg.Weight (see SetWeight)
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewCTRService creates a new CTRService.
+// cache is optional and can be nil; when set, it is cleared after each refresh so
+// search results reflect the new scores.
+func NewCTRService(
+	events domain.AnalyticsEventRepository,
+	boosts domain.ContentBoostRepository,
+	cache domain.Cache,
+	cfg CTRConfig,
+	logger *zap.Logger,
+) *CTRService {
+	s := &CTRService{
+		events: events,
+		boosts: boosts,
+		cache:  cache,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+	s.SetWeight(cfg.Weight)
+
+	return s
+}
+
+// SetWeight changes the boost weight used from the next refresh on, e.g. after a
+// configuration reload.
+func (s *CTRService) SetWeight(weight float64) {
+	s.weight.Store(math.Float64bits(weight))
+}
+
+// Refresh recomputes the CTR boost of every content from the events within the
+// window and replaces the stored boosts. Returns the number of boosted contents.
+func (s *CTRService) Refresh(ctx context.Context) (int, error) {
+	since := s.now().UTC().Add(-s.cfg.Window)
+
+	ctrs, err := s.events.AggregateCTR(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("refreshing ctr boosts: %w", err)
+	}
+
+	weight := math.Float64frombits(s.weight.Load())
+	boosts := make(map[string]float64, len(ctrs))
+	for _, c := range ctrs {
+		if c.Impressions < s.cfg.MinImpressions {
+			continue
+		}
+		if boost := domain.CTRBoost(c.Impressions, c.Clicks, weight); boost > 0 {
+			boosts[c.ContentID] = boost
+		}
+	}
+
+	if err := s.boosts.ReplaceCTRBoosts(ctx, boosts); err != nil {
+		return 0, fmt.Errorf("refreshing ctr boosts: %w", err)
+	}
+
+	log := logger.FromContext(ctx, s.logger)
+	if s.cache != nil {
+		if err := s.cache.Clear(ctx); err != nil {
+			log.Warn("failed to clear cache after ctr refresh", zap.Error(err))
+		}
+	}
+
+	log.Info("ctr boosts refreshed",
+		zap.Int("contents_seen", len(ctrs)),
+		zap.Int("contents_boosted", len(boosts)),
+	)
+
+	return len(boosts), nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	memcache "search-engine-service/internal/infra/cache"
+)
+
+// fakeBoostRepo records the boosts passed to ReplaceCTRBoosts.
+type fakeBoostRepo struct {
+	boosts map[string]float64
+}
+
+func (r *fakeBoostRepo) ReplaceCTRBoosts(_ context.Context, boosts map[string]float64) error {
+	r.boosts = boosts
+
+	return nil
+}
+
+func TestCTRService_Refresh(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	events := &fakeAnalyticsRepo{ctrs: []domain.ContentCTR{
+		{ContentID: "popular", Impressions: 980, Clicks: 299},
+		{ContentID: "ignored", Impressions: 980, Clicks: 0},
+		{ContentID: "new", Impressions: 5, Clicks: 5},
+	}}
+	boosts := &fakeBoostRepo{}
+	cache := memcache.NewMemoryCache(10)
+	require.NoError(t, cache.Set(context.Background(), "search:cached", []byte("{}"), time.Hour))
+
+	svc := NewCTRService(events, boosts, cache, CTRConfig{Window: 24 * time.Hour, Weight: 10, MinImpressions: 100}, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	boosted, err := svc.Refresh(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(-24*time.Hour), events.since)
+	assert.Equal(t, 2, boosted)
+	assert.Equal(t, map[string]float64{"popular": 3, "ignored": 0.01}, boosts.boosts,
+		"contents below MinImpressions lose their boost")
+
+	cached, err := cache.Get(context.Background(), "search:cached")
+	require.NoError(t, err)
+	assert.Nil(t, cached, "cached results are cleared")
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// DashboardUpdateType identifies the kind of update pushed to dashboard sessions.
+type DashboardUpdateType string
+
+const (
+	DashboardSyncStarted   DashboardUpdateType = "sync.started"   // A sync of one or all providers started
+	DashboardSyncProgress  DashboardUpdateType = "sync.progress"  // A provider of the running sync finished
+	DashboardSyncCompleted DashboardUpdateType = "sync.completed" // The running sync finished
+	DashboardStats         DashboardUpdateType = "stats"          // Content count and provider statuses changed
+)
+
+// DashboardUpdate is pushed to connected dashboard sessions.
+type DashboardUpdate struct {
+	Type DashboardUpdateType
+
+	// Sync updates
+	Providers []string     // sync.started: providers being synced
+	Results   []SyncResult // sync.progress: the finished provider; sync.completed: every provider
+	Done      int          // sync.progress: providers finished so far
+	Total     int          // sync.progress: providers of the sync
+
+	// Stats updates
+	ContentCount int64
+	Statuses     []ProviderSyncStatus
+}
+
+const (
+	// dashboardSubscriberBuffer is the per-session channel size. Updates for sessions
+	// that fall further behind are dropped rather than blocking the event bus.
+	dashboardSubscriberBuffer = 16
+
+	// dashboardStatsTimeout bounds computing a stats update.
+	dashboardStatsTimeout = 10 * time.Second
+)
+
+// ContentCounter counts the searchable contents.
+type ContentCounter interface {
+	Count(ctx context.Context) (int64, error)
+}
+
+// ProviderStatusReporter reports the sync status of each provider.
+type ProviderStatusReporter interface {
+	ProviderStatuses(ctx context.Context) []ProviderSyncStatus
+}
+
+// DashboardNotifier turns domain events into updates for the dashboard sessions
+// connected to this instance: sync progress as it happens, and fresh stats after
+// contents changed. Stats are computed in the background so event handling never
+// waits on the database. Call Start to begin computing stats.
+type DashboardNotifier struct {
+	counter  ContentCounter
+	statuses ProviderStatusReporter
+	logger   *zap.Logger
+
+	mu          sync.Mutex
+	subscribers map[chan DashboardUpdate]struct{}
+	done, total int // Progress of the running sync
+
+	refresh  chan struct{} // Pending stats refresh; holds at most one
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewDashboardNotifier creates a new DashboardNotifier.
+func NewDashboardNotifier(counter ContentCounter, statuses ProviderStatusReporter, logger *zap.Logger) *DashboardNotifier {
+	return &DashboardNotifier{
+		counter:     counter,
+		statuses:    statuses,
+		logger:      logger,
+		subscribers: make(map[chan DashboardUpdate]struct{}),
+		refresh:     make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine computing stats updates.
+func (n *DashboardNotifier) Start() {
+	n.wg.Add(1)
+	go n.refreshLoop()
+}
+
+// Stop ends the stats goroutine and closes every subscriber channel.
+func (n *DashboardNotifier) Stop() {
+	n.stopOnce.Do(func() { close(n.stop) })
+	n.wg.Wait()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers {
+		delete(n.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Subscribe registers a dashboard session until ctx is done or the notifier stops.
+func (n *DashboardNotifier) Subscribe(ctx context.Context) <-chan DashboardUpdate {
+	ch := make(chan DashboardUpdate, dashboardSubscriberBuffer)
+
+	n.mu.Lock()
+	select {
+	case <-n.stop:
+		close(ch)
+	default:
+		n.subscribers[ch] = struct{}{}
+	}
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		n.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// HandleEvent is an event bus handler pushing sync progress to dashboard sessions
+// and scheduling a stats update whenever contents changed.
+func (n *DashboardNotifier) HandleEvent(_ context.Context, event domain.Event) {
+	switch e := event.(type) {
+	case domain.SyncStarted:
+		n.mu.Lock()
+		n.done, n.total = 0, len(e.Providers)
+		n.mu.Unlock()
+
+		n.broadcast(DashboardUpdate{Type: DashboardSyncStarted, Providers: e.Providers})
+	case domain.ProviderSynced:
+		n.mu.Lock()
+		n.done++
+		done, total := n.done, n.total
+		n.mu.Unlock()
+
+		n.broadcast(DashboardUpdate{Type: DashboardSyncProgress, Results: []SyncResult{e.Result}, Done: done, Total: total})
+	case domain.SyncCompleted:
+		n.broadcast(DashboardUpdate{Type: DashboardSyncCompleted, Results: e.Results})
+		n.scheduleRefresh()
+	case domain.ContentDeleted, domain.ContentCurated:
+		n.scheduleRefresh()
+	}
+}
+
+// scheduleRefresh asks for a stats update; requests made while one is pending are merged.
+func (n *DashboardNotifier) scheduleRefresh() {
+	select {
+	case n.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// refreshLoop computes and broadcasts a stats update per refresh request until stopped.
+func (n *DashboardNotifier) refreshLoop() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-n.refresh:
+			n.broadcastStats()
+		}
+	}
+}
+
+// broadcastStats pushes the current content count and provider statuses.
+func (n *DashboardNotifier) broadcastStats() {
+	if !n.hasSubscribers() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dashboardStatsTimeout)
+	defer cancel()
+
+	count, err := n.counter.Count(ctx)
+	if err != nil {
+		logger.FromContext(ctx, n.logger).Warn("failed to count contents for dashboard", zap.Error(err))
+
+		return
+	}
+
+	n.broadcast(DashboardUpdate{Type: DashboardStats, ContentCount: count, Statuses: n.statuses.ProviderStatuses(ctx)})
+}
+
+// broadcast hands update to every subscriber with room for it.
+func (n *DashboardNotifier) broadcast(update DashboardUpdate) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers {
+		select {
+		case ch <- update:
+		default:
+			n.logger.Debug("dropping dashboard update for slow subscriber", zap.String("type", string(update.Type)))
+		}
+	}
+}
+
+func (n *DashboardNotifier) hasSubscribers() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return len(n.subscribers) > 0
+}
+
+// unsubscribe removes and closes a subscriber channel.
+func (n *DashboardNotifier) unsubscribe(ch chan DashboardUpdate) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.subscribers[ch]; ok {
+		delete(n.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+type fixedCounter int64
+
+func (c fixedCounter) Count(context.Context) (int64, error) { return int64(c), nil }
+
+type fixedStatuses []ProviderSyncStatus
+
+func (s fixedStatuses) ProviderStatuses(context.Context) []ProviderSyncStatus { return s }
+
+func receiveUpdate(t *testing.T, updates <-chan DashboardUpdate) DashboardUpdate {
+	t.Helper()
+
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("no dashboard update received")
+
+		return DashboardUpdate{}
+	}
+}
+
+func TestDashboardNotifier_PushesSyncProgressAndStats(t *testing.T) {
+	notifier := NewDashboardNotifier(fixedCounter(42), fixedStatuses{{Provider: "provider_a"}}, zap.NewNop())
+	notifier.Start()
+	defer notifier.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := notifier.Subscribe(ctx)
+
+	notifier.HandleEvent(ctx, domain.SyncStarted{Providers: []string{"provider_a", "provider_b"}})
+	notifier.HandleEvent(ctx, domain.ProviderSynced{Result: SyncResult{Provider: "provider_a", Count: 5}})
+	notifier.HandleEvent(ctx, domain.SyncCompleted{Results: []SyncResult{{Provider: "provider_a", Count: 5}}})
+
+	started := receiveUpdate(t, updates)
+	assert.Equal(t, DashboardSyncStarted, started.Type)
+	assert.Equal(t, []string{"provider_a", "provider_b"}, started.Providers)
+
+	progress := receiveUpdate(t, updates)
+	assert.Equal(t, DashboardSyncProgress, progress.Type)
+	assert.Equal(t, 1, progress.Done)
+	assert.Equal(t, 2, progress.Total)
+
+	assert.Equal(t, DashboardSyncCompleted, receiveUpdate(t, updates).Type)
+
+	stats := receiveUpdate(t, updates)
+	assert.Equal(t, DashboardStats, stats.Type)
+	assert.Equal(t, int64(42), stats.ContentCount)
+	require.Len(t, stats.Statuses, 1)
+}
+
+func TestDashboardNotifier_UnsubscribesWhenDone(t *testing.T) {
+	notifier := NewDashboardNotifier(fixedCounter(0), fixedStatuses{}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := notifier.Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-updates:
+		assert.False(t, ok, "expected the channel to be closed")
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed")
+	}
+}
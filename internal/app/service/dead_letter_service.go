@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+const (
+	// DeadLetterRetention is how long dead letters are kept; older ones are removed
+	// whenever new ones are recorded.
+	DeadLetterRetention = 30 * 24 * time.Hour
+
+	// deadLetterWriteTimeout bounds recording a batch of dead letters.
+	deadLetterWriteTimeout = 5 * time.Second
+)
+
+// DeadLetterService records the provider items rejected by schema validation, so
+// they can be looked into once their provider changed its payload.
+type DeadLetterService struct {
+	letters domain.DeadLetterRepository
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// NewDeadLetterService creates a new DeadLetterService.
+func NewDeadLetterService(letters domain.DeadLetterRepository, logger *zap.Logger) *DeadLetterService {
+	return &DeadLetterService{
+		letters: letters,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// HandleEvent is an event bus handler recording the items rejected during syncs.
+func (s *DeadLetterService) HandleEvent(ctx context.Context, event domain.Event) {
+	e, ok := event.(domain.ItemsRejected)
+	if !ok {
+		return
+	}
+
+	// Rejections are kept even when the sync they come from failed or was cancelled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterWriteTimeout)
+	defer cancel()
+
+	if err := s.Record(ctx, e.Letters); err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to record dead letters",
+			zap.String("provider", e.Provider),
+			zap.Int("count", len(e.Letters)),
+			zap.Error(err),
+		)
+	}
+}
+
+// Record stores letters as created now and removes the letters older than
+// DeadLetterRetention.
+func (s *DeadLetterService) Record(ctx context.Context, letters []*domain.DeadLetter) error {
+	now := s.now().UTC()
+	for _, l := range letters {
+		l.CreatedAt = now
+	}
+
+	if err := s.letters.CreateBatch(ctx, letters); err != nil {
+		return fmt.Errorf("recording dead letters: %w", err)
+	}
+	if _, err := s.letters.DeleteBefore(ctx, now.Add(-DeadLetterRetention)); err != nil {
+		return fmt.Errorf("pruning dead letters: %w", err)
+	}
+
+	return nil
+}
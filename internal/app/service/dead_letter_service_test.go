@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeDeadLetterRepo is an in-memory DeadLetterRepository for tests.
+type fakeDeadLetterRepo struct {
+	letters       []*domain.DeadLetter
+	deletedBefore time.Time
+}
+
+func (r *fakeDeadLetterRepo) CreateBatch(_ context.Context, letters []*domain.DeadLetter) error {
+	r.letters = append(r.letters, letters...)
+
+	return nil
+}
+
+func (r *fakeDeadLetterRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.deletedBefore = before
+
+	return 0, nil
+}
+
+func (r *fakeDeadLetterRepo) DeleteByExternalID(_ context.Context, provider, externalID string) (int64, error) {
+	kept := r.letters[:0]
+	for _, l := range r.letters {
+		if l.Provider != provider || l.ExternalID != externalID {
+			kept = append(kept, l)
+		}
+	}
+	deleted := int64(len(r.letters) - len(kept))
+	r.letters = kept
+
+	return deleted, nil
+}
+
+func TestDeadLetterService_RecordsRejectedItems(t *testing.T) {
+	repo := &fakeDeadLetterRepo{}
+	svc := NewDeadLetterService(repo, zap.NewNop())
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	// Other events are ignored
+	svc.HandleEvent(context.Background(), domain.SyncStarted{Providers: []string{"provider_a"}})
+	assert.Empty(t, repo.letters)
+
+	// Letters are recorded even once the sync's context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc.HandleEvent(ctx, domain.ItemsRejected{Provider: "provider_a", Letters: []*domain.DeadLetter{
+		{Provider: "provider_a", ExternalID: "a-1", Payload: `{"id": "a-1"}`},
+	}})
+
+	require.Len(t, repo.letters, 1)
+	assert.Equal(t, now, repo.letters[0].CreatedAt)
+	assert.Equal(t, now.Add(-DeadLetterRetention), repo.deletedBefore, "older letters are pruned")
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// DictionaryService maintains the term dictionary built from the indexed titles and
+// tags, and answers autocomplete suggestions and "did you mean" corrections from it.
+//
+// The dictionary is built in Postgres by one instance (see job.DictionaryScheduler)
+// and loaded into memory by every instance, so lookups never query the database.
+type DictionaryService struct {
+	repo   domain.SearchTermRepository
+	dict   atomic.Pointer[domain.TermDictionary]
+	logger *zap.Logger
+}
+
+// NewDictionaryService creates a new DictionaryService with an empty dictionary
+// until the first Load.
+func NewDictionaryService(repo domain.SearchTermRepository, logger *zap.Logger) *DictionaryService {
+	s := &DictionaryService{
+		repo:   repo,
+		logger: logger,
+	}
+	s.dict.Store(domain.NewTermDictionary(nil))
+
+	return s
+}
+
+// Rebuild rebuilds the stored dictionary from the indexed contents, then loads it.
+// Returns the number of terms.
+func (s *DictionaryService) Rebuild(ctx context.Context) (int64, error) {
+	stored, err := s.repo.RebuildTerms(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("term dictionary rebuilt", zap.Int64("terms", stored))
+
+	return stored, s.Load(ctx)
+}
+
+// Load replaces the in-memory dictionary with the stored one.
+func (s *DictionaryService) Load(ctx context.Context) error {
+	terms, err := s.repo.ListTerms(ctx)
+	if err != nil {
+		return fmt.Errorf("loading term dictionary: %w", err)
+	}
+
+	s.dict.Store(domain.NewTermDictionary(terms))
+
+	return nil
+}
+
+// Size returns the number of terms in memory.
+func (s *DictionaryService) Size() int {
+	return s.dict.Load().Len()
+}
+
+// Suggest returns up to limit completions of query: its last word is completed with
+// the most frequent terms starting with it, the words before it are kept.
+func (s *DictionaryService) Suggest(query string, limit int) []string {
+	words := domain.TermWords(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	last := len(words) - 1
+	head := strings.Join(words[:last], " ")
+
+	completions := s.dict.Load().Complete(words[last], limit)
+	suggestions := make([]string, len(completions))
+	for i, c := range completions {
+		suggestions[i] = strings.TrimSpace(head + " " + c.Term)
+	}
+
+	return suggestions
+}
+
+// DidYouMean returns query with its misspelled words corrected, or "" when no word
+// has a correction.
+func (s *DictionaryService) DidYouMean(query string) string {
+	dict := s.dict.Load()
+	words := domain.TermWords(query)
+
+	corrected := false
+	for i, w := range words {
+		if term, ok := dict.Correct(w); ok {
+			words[i], corrected = term, true
+		}
+	}
+	if !corrected {
+		return ""
+	}
+
+	return strings.Join(words, " ")
+}
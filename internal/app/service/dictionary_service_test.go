@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSearchTermRepo is an in-memory SearchTermRepository for tests.
+type fakeSearchTermRepo struct {
+	terms    []domain.Term
+	rebuilds int
+	err      error
+}
+
+func (r *fakeSearchTermRepo) RebuildTerms(context.Context) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.rebuilds++
+
+	return int64(len(r.terms)), nil
+}
+
+func (r *fakeSearchTermRepo) ListTerms(context.Context) ([]domain.Term, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return append([]domain.Term(nil), r.terms...), nil
+}
+
+func TestDictionaryService_RebuildLoadsTerms(t *testing.T) {
+	repo := &fakeSearchTermRepo{terms: []domain.Term{{Term: "golang", Frequency: 3}, {Term: "docker", Frequency: 1}}}
+	svc := NewDictionaryService(repo, zap.NewNop())
+	assert.Equal(t, 0, svc.Size())
+	assert.Empty(t, svc.Suggest("go", 5))
+
+	stored, err := svc.Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stored)
+	assert.Equal(t, 1, repo.rebuilds)
+	assert.Equal(t, 2, svc.Size())
+
+	// A failed load keeps the dictionary in memory
+	repo.err = errors.New("connection refused")
+	require.Error(t, svc.Load(context.Background()))
+	assert.Equal(t, 2, svc.Size())
+}
+
+func TestDictionaryService_SuggestAndDidYouMean(t *testing.T) {
+	repo := &fakeSearchTermRepo{terms: []domain.Term{
+		{Term: "golang", Frequency: 40},
+		{Term: "google", Frequency: 12},
+		{Term: "docker", Frequency: 30},
+		{Term: "compose", Frequency: 8},
+		{Term: "tutorial", Frequency: 50},
+	}}
+	svc := NewDictionaryService(repo, zap.NewNop())
+	require.NoError(t, svc.Load(context.Background()))
+
+	assert.Equal(t, []string{"golang", "google"}, svc.Suggest("Go", 5))
+	assert.Equal(t, []string{"golang"}, svc.Suggest("go", 1))
+	assert.Equal(t, []string{"docker compose"}, svc.Suggest("Docker  comp", 5))
+	assert.Empty(t, svc.Suggest("rust", 5))
+	assert.Empty(t, svc.Suggest("  ", 5))
+
+	assert.Equal(t, "golang tutorial", svc.DidYouMean("Golnag tutorial"))
+	assert.Equal(t, "docker compose", svc.DidYouMean("dokcer compse"))
+	assert.Empty(t, svc.DidYouMean("golang tutorial"))
+	assert.Empty(t, svc.DidYouMean("javascript"))
+}
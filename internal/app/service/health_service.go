@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// Overall health statuses reported by HealthService.
+const (
+	HealthOK       = "ok"       // Every dependency is up and no breaker is open
+	HealthDegraded = "degraded" // A non-critical dependency is down or a provider breaker isn't closed
+	HealthDown     = "down"     // A critical dependency is down; the instance can't serve requests
+)
+
+// DependencyCheck probes a single dependency.
+type DependencyCheck struct {
+	Name     string
+	Critical bool // Failure marks the instance down rather than degraded
+	Check    func(ctx context.Context) error
+}
+
+// DependencyHealth holds the result of a single DependencyCheck.
+type DependencyHealth struct {
+	Name    string
+	Latency time.Duration
+	Error   error
+}
+
+// ProviderStatus holds a provider's circuit breaker state and when it was last synced.
+type ProviderStatus struct {
+	Provider     string
+	BreakerState string    // "" when the provider has no breaker
+	LastSyncAt   time.Time // Zero when never synced (or unknown)
+}
+
+// HealthReport is a snapshot of the instance's dependencies.
+type HealthReport struct {
+	Status       string
+	Dependencies []DependencyHealth
+	Providers    []ProviderStatus
+	CheckedAt    time.Time
+}
+
+// HealthService reports per-dependency health for status pages and debugging.
+type HealthService struct {
+	checks    []DependencyCheck
+	providers []domain.Provider
+	repo      domain.ContentRepository
+	timeout   time.Duration
+	logger    *zap.Logger
+}
+
+// NewHealthService creates a new HealthService. Each check (and the last sync lookup)
+// is bounded by timeout.
+func NewHealthService(
+	checks []DependencyCheck,
+	providers []domain.Provider,
+	repo domain.ContentRepository,
+	timeout time.Duration,
+	logger *zap.Logger,
+) *HealthService {
+	return &HealthService{
+		checks:    checks,
+		providers: providers,
+		repo:      repo,
+		timeout:   timeout,
+		logger:    logger,
+	}
+}
+
+// Check runs all dependency checks concurrently and collects provider states.
+func (s *HealthService) Check(ctx context.Context) HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	report := HealthReport{
+		Status:       HealthOK,
+		Dependencies: make([]DependencyHealth, len(s.checks)),
+		CheckedAt:    time.Now().UTC(),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(idx int, c DependencyCheck) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := c.Check(ctx)
+			report.Dependencies[idx] = DependencyHealth{Name: c.Name, Latency: time.Since(start), Error: err}
+		}(i, check)
+	}
+
+	lastSyncs, err := s.repo.LastSyncTimes(ctx)
+	if err != nil {
+		// The database check reports the cause; sync times are simply left out
+		logger.FromContext(ctx, s.logger).Warn("failed to load last sync times", zap.Error(err))
+	}
+
+	wg.Wait()
+
+	for i, dep := range report.Dependencies {
+		if dep.Error == nil {
+			continue
+		}
+		logger.FromContext(ctx, s.logger).Warn("dependency health check failed",
+			zap.String("dependency", dep.Name),
+			zap.Error(dep.Error),
+		)
+		if s.checks[i].Critical {
+			report.Status = HealthDown
+		} else if report.Status == HealthOK {
+			report.Status = HealthDegraded
+		}
+	}
+
+	report.Providers = make([]ProviderStatus, len(s.providers))
+	for i, p := range s.providers {
+		status := ProviderStatus{Provider: p.Name(), LastSyncAt: lastSyncs[p.Name()]}
+		if r, ok := p.(domain.BreakerStateReporter); ok {
+			status.BreakerState = r.BreakerState()
+		}
+		if status.BreakerState != "" && status.BreakerState != "closed" && report.Status == HealthOK {
+			report.Status = HealthDegraded
+		}
+		report.Providers[i] = status
+	}
+
+	return report
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSyncTimesRepo is a ContentRepository reporting fixed last sync times.
+type fakeSyncTimesRepo struct {
+	domain.ContentRepository
+	times map[string]time.Time
+}
+
+func (r *fakeSyncTimesRepo) LastSyncTimes(context.Context) (map[string]time.Time, error) {
+	return r.times, nil
+}
+
+// fakeBreakerProvider is a Provider with a fixed circuit breaker state.
+type fakeBreakerProvider struct {
+	domain.Provider
+	name  string
+	state string
+}
+
+func (p *fakeBreakerProvider) Name() string         { return p.name }
+func (p *fakeBreakerProvider) BreakerState() string { return p.state }
+
+func checkReturning(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestHealthService_Check(t *testing.T) {
+	lastSync := time.Now().Add(-time.Hour).UTC()
+	repo := &fakeSyncTimesRepo{times: map[string]time.Time{"provider_a": lastSync}}
+	providers := []domain.Provider{
+		&fakeBreakerProvider{name: "provider_a", state: "closed"},
+		&fakeBreakerProvider{name: "provider_b", state: "closed"},
+	}
+
+	tests := []struct {
+		name         string
+		postgresErr  error
+		redisErr     error
+		breakerState string
+		want         string
+	}{
+		{"all up", nil, nil, "closed", HealthOK},
+		{"breaker open", nil, nil, "open", HealthDegraded},
+		{"redis down", nil, errors.New("connection refused"), "closed", HealthDegraded},
+		{"postgres down", errors.New("connection refused"), nil, "closed", HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers[1].(*fakeBreakerProvider).state = tt.breakerState
+			svc := NewHealthService([]DependencyCheck{
+				{Name: "postgres", Critical: true, Check: checkReturning(tt.postgresErr)},
+				{Name: "redis", Check: checkReturning(tt.redisErr)},
+			}, providers, repo, time.Second, zap.NewNop())
+
+			report := svc.Check(context.Background())
+
+			assert.Equal(t, tt.want, report.Status)
+			require.Len(t, report.Dependencies, 2)
+			assert.Equal(t, "postgres", report.Dependencies[0].Name)
+			assert.Equal(t, tt.postgresErr, report.Dependencies[0].Error)
+			require.Len(t, report.Providers, 2)
+			assert.Equal(t, ProviderStatus{Provider: "provider_a", BreakerState: "closed", LastSyncAt: lastSync}, report.Providers[0])
+			assert.Equal(t, ProviderStatus{Provider: "provider_b", BreakerState: tt.breakerState}, report.Providers[1])
+		})
+	}
+}
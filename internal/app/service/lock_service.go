@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+	"search-engine-service/pkg/locker"
+)
+
+// LockService lets operators inspect the distributed locks and free stuck ones.
+type LockService struct {
+	inspector locker.Inspector
+	logger    *zap.Logger
+}
+
+// NewLockService creates a new LockService.
+func NewLockService(inspector locker.Inspector, logger *zap.Logger) *LockService {
+	return &LockService{
+		inspector: inspector,
+		logger:    logger,
+	}
+}
+
+// List returns the locks currently held by any instance, sorted by key.
+func (s *LockService) List(ctx context.Context) ([]locker.LockInfo, error) {
+	locks, err := s.inspector.Locks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing locks: %w", err)
+	}
+
+	return locks, nil
+}
+
+// ForceRelease releases the lock identified by key whoever holds it. The holder isn't
+// told and may keep working until it next renews, so use it only for stuck locks.
+// Returns domain.ErrNotFound if the lock isn't held.
+func (s *LockService) ForceRelease(ctx context.Context, key string) error {
+	released, err := s.inspector.ForceRelease(ctx, key)
+	if err != nil {
+		return fmt.Errorf("releasing lock: %w", err)
+	}
+	if !released {
+		return fmt.Errorf("%w: lock %s is not held", domain.ErrNotFound, key)
+	}
+
+	logger.FromContext(ctx, s.logger).Warn("lock force-released", zap.String("key", key))
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/pkg/locker"
+)
+
+func TestLockService_ForceRelease(t *testing.T) {
+	l := locker.NewMemoryLocker()
+	svc := NewLockService(l, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, "sync:run:lock", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	locks, err := svc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "sync:run:lock", locks[0].Key)
+
+	require.NoError(t, svc.ForceRelease(ctx, "sync:run:lock"))
+
+	locks, err = svc.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+
+	err = svc.ForceRelease(ctx, "sync:run:lock")
+	assert.ErrorIs(t, err, domain.ErrNotFound, "a free lock can't be released")
+}
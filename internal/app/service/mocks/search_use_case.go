@@ -0,0 +1,188 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "search-engine-service/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchUseCase is an autogenerated mock type for the SearchUseCase type
+type SearchUseCase struct {
+	mock.Mock
+}
+
+// ClearCache provides a mock function with given fields: ctx
+func (_m *SearchUseCase) ClearCache(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Count provides a mock function with given fields: ctx
+func (_m *SearchUseCase) Count(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, params, fn
+func (_m *SearchUseCase) Export(ctx context.Context, params domain.SearchParams, fn func([]*domain.Content) error) error {
+	ret := _m.Called(ctx, params, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchParams, func([]*domain.Content) error) error); ok {
+		r0 = rf(ctx, params, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Facets provides a mock function with given fields: ctx, params
+func (_m *SearchUseCase) Facets(ctx context.Context, params domain.SearchParams) (*domain.SearchFacets, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Facets")
+	}
+
+	var r0 *domain.SearchFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchParams) (*domain.SearchFacets, error)); ok {
+		return rf(ctx, params)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchParams) *domain.SearchFacets); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SearchFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SearchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *SearchUseCase) GetByID(ctx context.Context, id string) (*domain.Content, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Content, error)); ok {
+		return rf(ctx, id)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Content); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, params
+func (_m *SearchUseCase) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *domain.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchParams) (*domain.SearchResult, error)); ok {
+		return rf(ctx, params)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SearchParams) *domain.SearchResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SearchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSearchUseCase creates a new instance of SearchUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchUseCase {
+	mock := &SearchUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
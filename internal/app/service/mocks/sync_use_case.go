@@ -0,0 +1,252 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "search-engine-service/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	service "search-engine-service/internal/app/service"
+)
+
+// SyncUseCase is an autogenerated mock type for the SyncUseCase type
+type SyncUseCase struct {
+	mock.Mock
+}
+
+// CheckProviders provides a mock function with given fields: ctx
+func (_m *SyncUseCase) CheckProviders(ctx context.Context) []service.ProviderHealth {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckProviders")
+	}
+
+	var r0 []service.ProviderHealth
+	if rf, ok := ret.Get(0).(func(context.Context) []service.ProviderHealth); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ProviderHealth)
+		}
+	}
+
+	return r0
+}
+
+// CurateContent provides a mock function with given fields: ctx, id, patch
+func (_m *SyncUseCase) CurateContent(ctx context.Context, id string, patch domain.CurationPatch) (*domain.Content, error) {
+	ret := _m.Called(ctx, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CurateContent")
+	}
+
+	var r0 *domain.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CurationPatch) (*domain.Content, error)); ok {
+		return rf(ctx, id, patch)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CurationPatch) *domain.Content); ok {
+		r0 = rf(ctx, id, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.CurationPatch) error); ok {
+		r1 = rf(ctx, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteContent provides a mock function with given fields: ctx, id
+func (_m *SyncUseCase) DeleteContent(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetProviderNames provides a mock function with given fields:
+func (_m *SyncUseCase) GetProviderNames() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProviderNames")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// ListCurated provides a mock function with given fields: ctx
+func (_m *SyncUseCase) ListCurated(ctx context.Context) ([]*domain.Content, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCurated")
+	}
+
+	var r0 []*domain.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Content, error)); ok {
+		return rf(ctx)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Content); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProviderStatuses provides a mock function with given fields: ctx
+func (_m *SyncUseCase) ProviderStatuses(ctx context.Context) []service.ProviderSyncStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProviderStatuses")
+	}
+
+	var r0 []service.ProviderSyncStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []service.ProviderSyncStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ProviderSyncStatus)
+		}
+	}
+
+	return r0
+}
+
+// SetProviderEnabled provides a mock function with given fields: ctx, providerName, enabled
+func (_m *SyncUseCase) SetProviderEnabled(ctx context.Context, providerName string, enabled bool) error {
+	ret := _m.Called(ctx, providerName, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProviderEnabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, providerName, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncAll provides a mock function with given fields: ctx
+func (_m *SyncUseCase) SyncAll(ctx context.Context) ([]service.SyncResult, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncAll")
+	}
+
+	var r0 []service.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]service.SyncResult, error)); ok {
+		return rf(ctx)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context) []service.SyncResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.SyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncProvider provides a mock function with given fields: ctx, providerName
+func (_m *SyncUseCase) SyncProvider(ctx context.Context, providerName string) (*service.SyncResult, error) {
+	ret := _m.Called(ctx, providerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncProvider")
+	}
+
+	var r0 *service.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*service.SyncResult, error)); ok {
+		return rf(ctx, providerName)
+	}
+
+	if rf, ok := ret.Get(0).(func(context.Context, string) *service.SyncResult); ok {
+		r0 = rf(ctx, providerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.SyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, providerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSyncUseCase creates a new instance of SyncUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSyncUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SyncUseCase {
+	mock := &SyncUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// outboxRelayTimeout bounds each relay of the stored events.
+const outboxRelayTimeout = 10 * time.Second
+
+// OutboxConfig controls how the outbox is relayed.
+type OutboxConfig struct {
+	PollInterval time.Duration // How often stored events are looked for
+	BatchSize    int           // Events removed from the outbox per transaction
+}
+
+// OutboxRelay publishes the events of content changes that the content repository
+// stored in the outbox, in the transaction of each change. Subscribers (cache
+// invalidation, webhooks, event streams) thus only see changes that committed, and
+// don't miss those whose instance stopped before publishing them.
+//
+// Every instance relays; events are published at least once, on the instance that
+// relayed them, in order within a batch. An event is published again when its batch
+// fails to be removed from the outbox.
+type OutboxRelay struct {
+	repo   domain.OutboxRepository
+	events domain.EventPublisher
+	cfg    OutboxConfig
+	logger *zap.Logger
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewOutboxRelay creates a new OutboxRelay publishing to events.
+func NewOutboxRelay(repo domain.OutboxRepository, events domain.EventPublisher, cfg OutboxConfig, logger *zap.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		repo:   repo,
+		events: events,
+		cfg:    cfg,
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start relays the stored events every PollInterval until Stop.
+func (r *OutboxRelay) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.relayWithTimeout(context.Background())
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the periodic relays started by Start and relays the events still
+// stored, bounded by ctx.
+func (r *OutboxRelay) Stop(ctx context.Context) {
+	r.stopOnce.Do(func() { close(r.stop) })
+	r.wg.Wait()
+
+	r.relayWithTimeout(ctx)
+}
+
+// relayWithTimeout runs Relay with a timeout, logging failures.
+func (r *OutboxRelay) relayWithTimeout(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, outboxRelayTimeout)
+	defer cancel()
+
+	if _, err := r.Relay(ctx); err != nil {
+		r.logger.Warn("failed to relay outbox events", zap.Error(err))
+	}
+}
+
+// Relay publishes the stored events, BatchSize at a time, until none is left.
+// Returns the number of events relayed.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		relayed, err := r.repo.Relay(ctx, r.cfg.BatchSize, func(events []*domain.OutboxEvent) error {
+			r.publish(ctx, events)
+
+			return nil
+		})
+		total += relayed
+		if err != nil || relayed < r.cfg.BatchSize {
+			return total, err
+		}
+	}
+}
+
+// publish hands the stored events to the subscribers. Events that can't be decoded
+// are logged and dropped, since they would fail again on every relay.
+func (r *OutboxRelay) publish(ctx context.Context, events []*domain.OutboxEvent) {
+	for _, stored := range events {
+		event, err := stored.Event()
+		if err != nil {
+			r.logger.Error("dropping undecodable outbox event", zap.Int64("id", stored.ID), zap.Error(err))
+
+			continue
+		}
+
+		r.events.Publish(ctx, event)
+	}
+}
+
+// WithoutOutboxed returns a publisher forwarding to next the events that don't go
+// through the outbox (see domain.IsOutboxed). With the outbox enabled, the relay
+// publishes the others once their transaction committed.
+func WithoutOutboxed(next domain.EventPublisher) domain.EventPublisher {
+	return outboxedFilter{next: next}
+}
+
+// outboxedFilter drops the events that go through the outbox.
+type outboxedFilter struct {
+	next domain.EventPublisher
+}
+
+// Publish forwards event unless it goes through the outbox.
+func (f outboxedFilter) Publish(ctx context.Context, event domain.Event) {
+	if !domain.IsOutboxed(event) {
+		f.next.Publish(ctx, event)
+	}
+}
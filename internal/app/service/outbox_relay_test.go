@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeOutboxRepo is an in-memory OutboxRepository for tests.
+type fakeOutboxRepo struct {
+	events  []*domain.OutboxEvent
+	batches []int
+}
+
+func (r *fakeOutboxRepo) Relay(_ context.Context, limit int, fn func(events []*domain.OutboxEvent) error) (int, error) {
+	batch := r.events[:min(limit, len(r.events))]
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	if err := fn(batch); err != nil {
+		return 0, err
+	}
+	r.events = r.events[len(batch):]
+	r.batches = append(r.batches, len(batch))
+
+	return len(batch), nil
+}
+
+// recordingPublisher records the events published to it. Syncs publish from several
+// goroutines, so the events are read with published.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event domain.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+// published returns the events published so far, in order.
+func (p *recordingPublisher) published() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]domain.Event(nil), p.events...)
+}
+
+// reset forgets the events published so far.
+func (p *recordingPublisher) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = nil
+}
+
+func storedEvent(t *testing.T, event domain.Event) *domain.OutboxEvent {
+	t.Helper()
+
+	stored, err := domain.NewOutboxEvent(event)
+	require.NoError(t, err)
+
+	return stored
+}
+
+func TestOutboxRelay_PublishesStoredEventsInBatches(t *testing.T) {
+	repo := &fakeOutboxRepo{events: []*domain.OutboxEvent{
+		storedEvent(t, domain.ContentDeleted{ID: "id-1"}),
+		{ID: 2, Name: "content.unknown", Payload: []byte(`{}`)},
+		storedEvent(t, domain.ContentDeleted{ID: "id-3"}),
+		storedEvent(t, domain.ContentCurated{Content: &domain.Content{ID: "id-4", Pinned: true}}),
+	}}
+	publisher := &recordingPublisher{}
+	relay := NewOutboxRelay(repo, publisher, OutboxConfig{BatchSize: 2}, zap.NewNop())
+
+	relayed, err := relay.Relay(context.Background())
+	require.NoError(t, err)
+
+	// The undecodable event is dropped rather than blocking the outbox
+	assert.Equal(t, 4, relayed)
+	assert.Equal(t, []int{2, 2}, repo.batches)
+	assert.Equal(t, []domain.Event{
+		domain.ContentDeleted{ID: "id-1"},
+		domain.ContentDeleted{ID: "id-3"},
+		domain.ContentCurated{Content: &domain.Content{ID: "id-4", Pinned: true}},
+	}, publisher.published())
+}
+
+func TestWithoutOutboxed_ForwardsOtherEvents(t *testing.T) {
+	publisher := &recordingPublisher{}
+	filtered := WithoutOutboxed(publisher)
+
+	filtered.Publish(context.Background(), domain.ContentUpserted{Provider: "provider_a"})
+	filtered.Publish(context.Background(), domain.SyncCompleted{})
+
+	assert.Equal(t, []domain.Event{domain.SyncCompleted{}}, publisher.published())
+}
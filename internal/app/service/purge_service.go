@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// PurgeResult is what a purge removed.
+type PurgeResult struct {
+	ContentID   string // Empty when no content of the item was stored
+	DeadLetters int64  // Rejected payloads of the item
+}
+
+// PurgeService removes every stored copy of a provider item, for providers asking
+// for specific items to be removed: the content, the payloads rejected by schema
+// validation, and cached contents and search results.
+//
+// Purging an item that isn't stored succeeds, so removal requests can be retried
+// until they report no error.
+type PurgeService struct {
+	contents    domain.ContentRepository
+	deadLetters domain.DeadLetterRepository
+	cache       domain.Cache          // Optional (can be nil)
+	events      domain.EventPublisher // Optional (can be nil)
+	logger      *zap.Logger
+}
+
+// NewPurgeService creates a new PurgeService publishing ContentDeleted to events
+// after removing a content, as SyncService.DeleteContent does.
+func NewPurgeService(
+	contents domain.ContentRepository,
+	deadLetters domain.DeadLetterRepository,
+	cache domain.Cache,
+	events domain.EventPublisher,
+	logger *zap.Logger,
+) *PurgeService {
+	return &PurgeService{
+		contents:    contents,
+		deadLetters: deadLetters,
+		cache:       cache,
+		events:      events,
+		logger:      logger,
+	}
+}
+
+// Purge removes the item externalID of provider. The cache is cleared whether or not
+// anything was stored, since an earlier attempt may have failed after removing the
+// content.
+func (s *PurgeService) Purge(ctx context.Context, provider, externalID string) (PurgeResult, error) {
+	if provider == "" || externalID == "" {
+		return PurgeResult{}, fmt.Errorf("%w: provider and external ID are required", domain.ErrInvalidParams)
+	}
+	log := logger.FromContext(ctx, s.logger).With(zap.String("provider", provider), zap.String("external_id", externalID))
+
+	var result PurgeResult
+	content, err := s.contents.GetByProviderAndExternalID(ctx, provider, externalID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+	case err != nil:
+		log.Error("purge failed", zap.Error(err))
+
+		return result, err
+	default:
+		if err := s.contents.Delete(ctx, content.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			log.Error("purge failed", zap.String("id", content.ID), zap.Error(err))
+
+			return result, err
+		}
+		result.ContentID = content.ID
+		if s.events != nil {
+			s.events.Publish(ctx, domain.ContentDeleted{ID: content.ID})
+		}
+	}
+
+	if result.DeadLetters, err = s.deadLetters.DeleteByExternalID(ctx, provider, externalID); err != nil {
+		log.Error("purge failed", zap.String("id", result.ContentID), zap.Error(err))
+
+		return result, err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Clear(ctx); err != nil {
+			log.Error("purge failed", zap.String("id", result.ContentID), zap.Error(err))
+
+			return result, fmt.Errorf("clearing cache: %w", err)
+		}
+	}
+
+	log.Info("item purged", zap.String("id", result.ContentID), zap.Int64("dead_letters", result.DeadLetters))
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	memcache "search-engine-service/internal/infra/cache"
+)
+
+func TestPurgeService_RemovesEveryCopyOfTheItem(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", ProviderID: "provider_a", ExternalID: "a-1"},
+		"id-2": {ID: "id-2", ProviderID: "provider_b", ExternalID: "a-1"},
+	}}
+	letters := &fakeDeadLetterRepo{letters: []*domain.DeadLetter{
+		{Provider: "provider_a", ExternalID: "a-1"},
+		{Provider: "provider_a", ExternalID: "a-2"},
+	}}
+	cache := memcache.NewMemoryCache(10)
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, contentCacheKey("id-1"), []byte("{}"), 0))
+	events := &recordingPublisher{}
+	svc := NewPurgeService(repo, letters, cache, events, zap.NewNop())
+
+	result, err := svc.Purge(ctx, "provider_a", "a-1")
+	require.NoError(t, err)
+	assert.Equal(t, PurgeResult{ContentID: "id-1", DeadLetters: 1}, result)
+	assert.NotContains(t, repo.contents, "id-1")
+	assert.Contains(t, repo.contents, "id-2", "items of other providers are kept")
+	assert.Len(t, letters.letters, 1)
+	cached, err := cache.Get(ctx, contentCacheKey("id-1"))
+	require.NoError(t, err)
+	assert.Nil(t, cached)
+	assert.Equal(t, []domain.Event{domain.ContentDeleted{ID: "id-1"}}, events.published())
+
+	result, err = svc.Purge(ctx, "provider_a", "a-1")
+	require.NoError(t, err, "purging again succeeds")
+	assert.Equal(t, PurgeResult{}, result)
+
+	_, err = svc.Purge(ctx, "provider_a", "")
+	require.ErrorIs(t, err, domain.ErrInvalidParams)
+}
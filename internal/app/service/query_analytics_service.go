@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// queryAnalyticsFlushTimeout bounds writing the buffered searches.
+const queryAnalyticsFlushTimeout = 10 * time.Second
+
+// QueryAnalyticsConfig controls how executed searches are recorded.
+type QueryAnalyticsConfig struct {
+	HashQueries   bool          // Store the SHA-256 of normalized queries instead of their text
+	FlushInterval time.Duration // How often buffered searches are written
+	BufferSize    int           // Searches buffered between writes; more are dropped
+	Retention     time.Duration // Searches older than this are removed
+}
+
+// QueryAnalyticsService records executed searches and reports the most frequent
+// queries, and those finding nothing, to drive synonyms and content gaps.
+//
+// Searches are buffered in memory and written in batches, so recording never
+// slows down a search; the searches buffered when an instance crashes are lost.
+type QueryAnalyticsService struct {
+	repo   domain.SearchQueryRepository
+	cfg    QueryAnalyticsConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buffer  []*domain.SearchQuery
+	dropped int // Searches dropped since the last write because the buffer was full
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewQueryAnalyticsService creates a new QueryAnalyticsService.
+func NewQueryAnalyticsService(repo domain.SearchQueryRepository, cfg QueryAnalyticsConfig, logger *zap.Logger) *QueryAnalyticsService {
+	return &QueryAnalyticsService{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Record buffers a search that matched total contents and took latency. Only first
+// pages of searches with a query are recorded: further pages would count a query
+// again, and listings without one aren't queries.
+func (s *QueryAnalyticsService) Record(params domain.SearchParams, total int64, latency time.Duration) {
+	query := domain.NormalizeQuery(params.Query)
+	if query == "" || params.Page > 1 {
+		return
+	}
+	if s.cfg.HashQueries {
+		sum := sha256.Sum256([]byte(query))
+		query = hex.EncodeToString(sum[:])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.buffer) >= s.cfg.BufferSize {
+		s.dropped++
+
+		return
+	}
+	s.buffer = append(s.buffer, &domain.SearchQuery{
+		Query:      query,
+		Total:      total,
+		Latency:    latency,
+		OccurredAt: s.now().UTC(),
+	})
+}
+
+// Start writes the buffered searches every FlushInterval until Stop.
+func (s *QueryAnalyticsService) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.flushWithTimeout(context.Background())
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the periodic writes started by Start and writes the searches still
+// buffered, bounded by ctx.
+func (s *QueryAnalyticsService) Stop(ctx context.Context) {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.wg.Wait()
+
+	s.flushWithTimeout(ctx)
+}
+
+// flushWithTimeout runs Flush with a timeout, logging failures.
+func (s *QueryAnalyticsService) flushWithTimeout(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, queryAnalyticsFlushTimeout)
+	defer cancel()
+
+	if err := s.Flush(ctx); err != nil {
+		s.logger.Warn("failed to record search queries", zap.Error(err))
+	}
+}
+
+// Flush writes the buffered searches and removes those older than Retention.
+// Searches that fail to be written are dropped.
+func (s *QueryAnalyticsService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	queries, dropped := s.buffer, s.dropped
+	s.buffer, s.dropped = nil, 0
+	s.mu.Unlock()
+
+	if dropped > 0 {
+		s.logger.Warn("search query buffer full, searches not recorded", zap.Int("dropped", dropped))
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+
+	if err := s.repo.CreateBatch(ctx, queries); err != nil {
+		return fmt.Errorf("recording %d search queries: %w", len(queries), err)
+	}
+
+	if _, err := s.repo.DeleteBefore(ctx, s.now().UTC().Add(-s.cfg.Retention)); err != nil {
+		return fmt.Errorf("pruning search queries: %w", err)
+	}
+
+	return nil
+}
+
+// Report returns up to limit of the most frequent queries, and of those finding
+// nothing, searched in the last days days.
+func (s *QueryAnalyticsService) Report(ctx context.Context, days, limit int) (*domain.SearchAnalytics, error) {
+	since := s.now().UTC().AddDate(0, 0, -days)
+
+	top, err := s.repo.TopQueries(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroResults, err := s.repo.TopZeroResultQueries(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SearchAnalytics{
+		Since:       since,
+		Hashed:      s.cfg.HashQueries,
+		Top:         top,
+		ZeroResults: zeroResults,
+	}, nil
+}
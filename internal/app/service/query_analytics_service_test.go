@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSearchQueryRepo is an in-memory SearchQueryRepository for tests.
+type fakeSearchQueryRepo struct {
+	queries       []*domain.SearchQuery
+	deletedBefore time.Time
+	since         time.Time
+}
+
+func (r *fakeSearchQueryRepo) CreateBatch(_ context.Context, queries []*domain.SearchQuery) error {
+	r.queries = append(r.queries, queries...)
+
+	return nil
+}
+
+func (r *fakeSearchQueryRepo) TopQueries(_ context.Context, since time.Time, _ int) ([]domain.QueryStat, error) {
+	r.since = since
+
+	return []domain.QueryStat{{Query: "golang", Searches: 3}}, nil
+}
+
+func (r *fakeSearchQueryRepo) TopZeroResultQueries(_ context.Context, _ time.Time, _ int) ([]domain.QueryStat, error) {
+	return []domain.QueryStat{{Query: "rust", Searches: 1}}, nil
+}
+
+func (r *fakeSearchQueryRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.deletedBefore = before
+
+	return 0, nil
+}
+
+func newTestQueryAnalytics(repo *fakeSearchQueryRepo, cfg QueryAnalyticsConfig, now time.Time) *QueryAnalyticsService {
+	svc := NewQueryAnalyticsService(repo, cfg, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	return svc
+}
+
+func TestQueryAnalytics_RecordsNormalizedFirstPages(t *testing.T) {
+	repo := &fakeSearchQueryRepo{}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc := newTestQueryAnalytics(repo, QueryAnalyticsConfig{BufferSize: 10, Retention: 24 * time.Hour}, now)
+
+	params := domain.DefaultSearchParams()
+	params.Query = "  Golang   Tutorial "
+	svc.Record(params, 4, 3*time.Millisecond)
+
+	params.Page = 2
+	svc.Record(params, 4, time.Millisecond)
+
+	params.Page, params.Query = 1, ""
+	svc.Record(params, 50, time.Millisecond)
+
+	require.NoError(t, svc.Flush(context.Background()))
+	require.Len(t, repo.queries, 1)
+	assert.Equal(t, &domain.SearchQuery{Query: "golang tutorial", Total: 4, Latency: 3 * time.Millisecond, OccurredAt: now}, repo.queries[0])
+	assert.Equal(t, now.Add(-24*time.Hour), repo.deletedBefore)
+
+	// Nothing buffered, nothing written
+	require.NoError(t, svc.Flush(context.Background()))
+	assert.Len(t, repo.queries, 1)
+}
+
+func TestQueryAnalytics_HashesAndBoundsBuffer(t *testing.T) {
+	repo := &fakeSearchQueryRepo{}
+	svc := newTestQueryAnalytics(repo, QueryAnalyticsConfig{HashQueries: true, BufferSize: 2}, time.Now())
+
+	for _, q := range []string{"Go", "go", "docker"} {
+		params := domain.DefaultSearchParams()
+		params.Query = q
+		svc.Record(params, 1, time.Millisecond)
+	}
+
+	require.NoError(t, svc.Flush(context.Background()))
+	// The third search didn't fit in the buffer
+	require.Len(t, repo.queries, 2)
+	// SHA-256 of "go", for both spellings
+	const goHash = "4cd0e21a9a0795a14ec9aa5f0e7d1abff0492565770e43eafdf1e3e8afed1f33"
+	assert.Equal(t, goHash, repo.queries[0].Query)
+	assert.Equal(t, goHash, repo.queries[1].Query)
+}
+
+func TestQueryAnalytics_Report(t *testing.T) {
+	repo := &fakeSearchQueryRepo{}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc := newTestQueryAnalytics(repo, QueryAnalyticsConfig{HashQueries: true}, now)
+
+	report, err := svc.Report(context.Background(), 7, 20)
+	require.NoError(t, err)
+
+	assert.Equal(t, now.AddDate(0, 0, -7), repo.since)
+	assert.Equal(t, &domain.SearchAnalytics{
+		Since:       now.AddDate(0, 0, -7),
+		Hashed:      true,
+		Top:         []domain.QueryStat{{Query: "golang", Searches: 3}},
+		ZeroResults: []domain.QueryStat{{Query: "rust", Searches: 1}},
+	}, report)
+}
+
+func TestSearch_RecordsQueryAnalytics(t *testing.T) {
+	queries := &fakeSearchQueryRepo{}
+	analytics := newTestQueryAnalytics(queries, QueryAnalyticsConfig{BufferSize: 10}, time.Now())
+	search := NewSearchService(&fakeRepo{}, zap.NewNop(), WithQueryAnalytics(analytics))
+
+	params := domain.DefaultSearchParams()
+	params.Query = "golang"
+	_, err := search.Search(context.Background(), params)
+	require.NoError(t, err)
+
+	require.NoError(t, analytics.Flush(context.Background()))
+	require.Len(t, queries.queries, 1)
+	assert.Equal(t, "golang", queries.queries[0].Query)
+}
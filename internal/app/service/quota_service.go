@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// quotaMonthLayout formats the months requests are counted in, in UTC.
+const quotaMonthLayout = "2006-01"
+
+// QuotaKey is an API key partners call the public routes with.
+type QuotaKey struct {
+	Name  string // Reported in usage and logs instead of the key
+	Key   string
+	Limit int64 // Requests allowed per month, 0 for unlimited
+}
+
+// QuotaService enforces the monthly request quotas of API keys and reports their use.
+// Counts are kept in a shared store so quotas hold across instances; a month's count
+// includes the requests rejected for going over the quota.
+type QuotaService struct {
+	store  domain.QuotaStore
+	keys   map[[sha256.Size]byte]QuotaKey // By hash of the key, so lookups don't compare keys
+	names  []string                       // In configuration order
+	limits map[string]int64               // By name
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewQuotaService creates a new QuotaService for keys. Names and keys must be unique.
+func NewQuotaService(store domain.QuotaStore, keys []QuotaKey, logger *zap.Logger) (*QuotaService, error) {
+	s := &QuotaService{
+		store:  store,
+		keys:   make(map[[sha256.Size]byte]QuotaKey, len(keys)),
+		names:  make([]string, 0, len(keys)),
+		limits: make(map[string]int64, len(keys)),
+		logger: logger,
+		now:    time.Now,
+	}
+	for _, k := range keys {
+		switch {
+		case k.Name == "" || k.Key == "":
+			return nil, errors.New("quota keys need a name and a key")
+		case k.Limit < 0:
+			return nil, fmt.Errorf("quota key %s: limit must not be negative", k.Name)
+		}
+		if _, ok := s.limits[k.Name]; ok {
+			return nil, fmt.Errorf("quota key %s is configured twice", k.Name)
+		}
+		hash := sha256.Sum256([]byte(k.Key))
+		if _, ok := s.keys[hash]; ok {
+			return nil, fmt.Errorf("quota key %s: key is used by another name", k.Name)
+		}
+
+		s.keys[hash] = k
+		s.names = append(s.names, k.Name)
+		s.limits[k.Name] = k.Limit
+	}
+
+	return s, nil
+}
+
+// Lookup returns the name of key, or false for unknown keys.
+func (s *QuotaService) Lookup(key string) (string, bool) {
+	k, ok := s.keys[sha256.Sum256([]byte(key))]
+
+	return k.Name, ok
+}
+
+// Consume counts a request of the API key name in the current month and returns the
+// use of its quota; QuotaUsage.Exceeded reports whether the request went over it.
+func (s *QuotaService) Consume(ctx context.Context, name string) (domain.QuotaUsage, error) {
+	now := s.now().UTC()
+	usage := domain.QuotaUsage{Name: name, Limit: s.limits[name], ResetAt: nextMonth(now)}
+
+	requests, err := s.store.Increment(ctx, name, now.Format(quotaMonthLayout))
+	if err != nil {
+		return usage, err
+	}
+	usage.Requests = requests
+
+	if usage.Limit > 0 && requests == usage.Limit+1 {
+		logger.FromContext(ctx, s.logger).Info("api key quota exceeded",
+			zap.String("api_key", name),
+			zap.Int64("limit", usage.Limit),
+			zap.Time("reset_at", usage.ResetAt),
+		)
+	}
+
+	return usage, nil
+}
+
+// Usage returns the use of the quota of every API key in month (YYYY-MM, empty for
+// the current month), in configuration order.
+func (s *QuotaService) Usage(ctx context.Context, month string) (string, []domain.QuotaUsage, error) {
+	start := s.now().UTC()
+	if month != "" {
+		var err error
+		if start, err = time.Parse(quotaMonthLayout, month); err != nil {
+			return "", nil, fmt.Errorf("%w: month must be formatted YYYY-MM", domain.ErrInvalidParams)
+		}
+	}
+	month = start.Format(quotaMonthLayout)
+
+	counts, err := s.store.Counts(ctx, month, s.names)
+	if err != nil {
+		return "", nil, err
+	}
+
+	usage := make([]domain.QuotaUsage, len(s.names))
+	for i, name := range s.names {
+		usage[i] = domain.QuotaUsage{
+			Name:     name,
+			Limit:    s.limits[name],
+			Requests: counts[name],
+			ResetAt:  nextMonth(start),
+		}
+	}
+
+	return month, usage, nil
+}
+
+// nextMonth returns the start of the month after t's, in UTC.
+func nextMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeQuotaStore is an in-memory QuotaStore for tests.
+type fakeQuotaStore struct {
+	counts map[string]int64 // By month and name
+}
+
+func (s *fakeQuotaStore) Increment(_ context.Context, name, month string) (int64, error) {
+	if s.counts == nil {
+		s.counts = make(map[string]int64)
+	}
+	s.counts[month+"/"+name]++
+
+	return s.counts[month+"/"+name], nil
+}
+
+func (s *fakeQuotaStore) Counts(_ context.Context, month string, names []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, name := range names {
+		if n, ok := s.counts[month+"/"+name]; ok {
+			counts[name] = n
+		}
+	}
+
+	return counts, nil
+}
+
+func TestQuotaService_ConsumeCountsAgainstMonthlyLimit(t *testing.T) {
+	store := &fakeQuotaStore{}
+	svc, err := NewQuotaService(store, []QuotaKey{
+		{Name: "partner-a", Key: "key-a", Limit: 2},
+		{Name: "partner-b", Key: "key-b"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	svc.now = func() time.Time { return time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	name, ok := svc.Lookup("key-a")
+	require.True(t, ok)
+	assert.Equal(t, "partner-a", name)
+	_, ok = svc.Lookup("key-c")
+	assert.False(t, ok)
+
+	var usage domain.QuotaUsage
+	for range 3 {
+		usage, err = svc.Consume(ctx, "partner-a")
+		require.NoError(t, err)
+	}
+	assert.True(t, usage.Exceeded())
+	assert.Equal(t, int64(3), usage.Requests)
+	assert.Zero(t, usage.Remaining())
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), usage.ResetAt)
+
+	usage, err = svc.Consume(ctx, "partner-b")
+	require.NoError(t, err)
+	assert.False(t, usage.Exceeded(), "keys without a limit are unlimited")
+
+	month, report, err := svc.Usage(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-12", month)
+	require.Len(t, report, 2)
+	assert.Equal(t, "partner-a", report[0].Name)
+	assert.Equal(t, int64(1), report[0].Rejected())
+
+	_, report, err = svc.Usage(ctx, "2026-11")
+	require.NoError(t, err)
+	assert.Zero(t, report[0].Requests)
+
+	_, _, err = svc.Usage(ctx, "November")
+	require.ErrorIs(t, err, domain.ErrInvalidParams)
+}
+
+func TestNewQuotaService_RejectsDuplicateKeys(t *testing.T) {
+	_, err := NewQuotaService(&fakeQuotaStore{}, []QuotaKey{
+		{Name: "partner-a", Key: "key"},
+		{Name: "partner-b", Key: "key"},
+	}, zap.NewNop())
+	require.Error(t, err)
+}
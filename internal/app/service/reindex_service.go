@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// ReindexService rebuilds a secondary search index from the content repository in
+// the background, so a backend can be migrated to, or refreshed, without downtime:
+// the previous index keeps answering until the rebuilt one is committed, and the
+// search_backend runtime setting switches queries between PostgreSQL and the index.
+type ReindexService struct {
+	index     domain.SearchIndex
+	repo      domain.ContentRepository
+	batchSize int
+	logger    *zap.Logger
+
+	mu     sync.Mutex
+	status domain.ReindexStatus
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReindexService creates a new ReindexService copying the contents of repo, the
+// source of truth, into index batchSize at a time.
+func NewReindexService(index domain.SearchIndex, repo domain.ContentRepository, batchSize int, logger *zap.Logger) *ReindexService {
+	return &ReindexService{
+		index:     index,
+		repo:      repo,
+		batchSize: batchSize,
+		logger:    logger,
+		status:    domain.ReindexStatus{State: domain.ReindexIdle},
+	}
+}
+
+// Start begins a rebuild in the background and returns its status. Returns ErrBusy
+// while another rebuild is running.
+func (s *ReindexService) Start() (domain.ReindexStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status.State == domain.ReindexRunning {
+		return s.status, fmt.Errorf("%w: search index rebuild in progress", domain.ErrBusy)
+	}
+
+	build, err := s.index.BeginRebuild()
+	if err != nil {
+		return s.status, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.status = domain.ReindexStatus{State: domain.ReindexRunning, StartedAt: time.Now().UTC()}
+
+	s.logger.Info("search index rebuild started")
+
+	s.wg.Add(1)
+	go s.run(ctx, build)
+
+	return s.status, nil
+}
+
+// Status returns the progress of the last rebuild.
+func (s *ReindexService) Status() domain.ReindexStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// Stop cancels the running rebuild, if any, and waits for it to be aborted.
+func (s *ReindexService) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// run copies the contents into build, then commits it, or aborts it on failure.
+func (s *ReindexService) run(ctx context.Context, build domain.IndexBuild) {
+	defer s.wg.Done()
+
+	err := s.copyContents(ctx, build)
+	if err == nil {
+		err = build.Commit()
+	} else if abortErr := build.Abort(); abortErr != nil {
+		s.logger.Warn("failed to remove aborted search index", zap.Error(abortErr))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cancel = nil
+	s.status.FinishedAt = time.Now().UTC()
+	if err != nil {
+		s.status.State, s.status.Error = domain.ReindexFailed, err.Error()
+		s.logger.Error("search index rebuild failed", zap.Int64("indexed", s.status.Indexed), zap.Error(err))
+
+		return
+	}
+
+	s.status.State = domain.ReindexCompleted
+	s.logger.Info("search index rebuilt",
+		zap.Int64("contents", s.status.Indexed),
+		zap.Duration("duration", s.status.FinishedAt.Sub(s.status.StartedAt)),
+	)
+}
+
+// copyContents indexes every content that isn't blocked, recording the progress.
+func (s *ReindexService) copyContents(ctx context.Context, build domain.IndexBuild) error {
+	total, err := s.repo.Count(ctx, domain.SearchParams{})
+	if err != nil {
+		return fmt.Errorf("counting contents: %w", err)
+	}
+	s.mu.Lock()
+	s.status.Total = total
+	s.mu.Unlock()
+
+	return s.repo.Iterate(ctx, domain.SearchParams{}, s.batchSize, func(batch []*domain.Content) error {
+		if err := build.Put(batch); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.status.Indexed += int64(len(batch))
+		s.mu.Unlock()
+
+		return nil
+	})
+}
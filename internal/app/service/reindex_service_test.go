@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSearchIndex records the contents put into its builds and how they ended.
+type fakeSearchIndex struct {
+	builds []*fakeIndexBuild
+}
+
+func (i *fakeSearchIndex) BeginRebuild() (domain.IndexBuild, error) {
+	b := &fakeIndexBuild{}
+	i.builds = append(i.builds, b)
+
+	return b, nil
+}
+
+type fakeIndexBuild struct {
+	indexed   int
+	committed bool
+	aborted   bool
+}
+
+func (b *fakeIndexBuild) Put(contents []*domain.Content) error {
+	b.indexed += len(contents)
+
+	return nil
+}
+
+func (b *fakeIndexBuild) Commit() error {
+	b.committed = true
+
+	return nil
+}
+
+func (b *fakeIndexBuild) Abort() error {
+	b.aborted = true
+
+	return nil
+}
+
+// fakeIterateRepo serves contents batch by batch, each batch waiting for release when set.
+type fakeIterateRepo struct {
+	domain.ContentRepository
+	contents []*domain.Content
+	release  chan struct{}
+	err      error
+}
+
+func (r *fakeIterateRepo) Count(context.Context, domain.SearchParams) (int64, error) {
+	return int64(len(r.contents)), nil
+}
+
+func (r *fakeIterateRepo) Iterate(ctx context.Context, _ domain.SearchParams, batchSize int, fn func(batch []*domain.Content) error) error {
+	for start := 0; start < len(r.contents); start += batchSize {
+		if r.release != nil {
+			select {
+			case <-r.release:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := fn(r.contents[start:min(start+batchSize, len(r.contents))]); err != nil {
+			return err
+		}
+	}
+
+	return r.err
+}
+
+// waitReindex waits for the rebuild of svc to finish.
+func waitReindex(t *testing.T, svc *ReindexService) domain.ReindexStatus {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return svc.Status().State != domain.ReindexRunning
+	}, time.Second, 5*time.Millisecond)
+
+	return svc.Status()
+}
+
+func TestReindexService_RebuildsInBackground(t *testing.T) {
+	repo := &fakeIterateRepo{
+		contents: []*domain.Content{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+		release:  make(chan struct{}),
+	}
+	index := &fakeSearchIndex{}
+	svc := NewReindexService(index, repo, 2, zap.NewNop())
+	assert.Equal(t, domain.ReindexIdle, svc.Status().State)
+
+	status, err := svc.Start()
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReindexRunning, status.State)
+
+	// One rebuild at a time
+	_, err = svc.Start()
+	require.ErrorIs(t, err, domain.ErrBusy)
+
+	// Progress is reported batch by batch
+	repo.release <- struct{}{}
+	require.Eventually(t, func() bool { return svc.Status().Indexed == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(3), svc.Status().Total)
+	assert.InDelta(t, 66.7, svc.Status().Progress(), 0.1)
+
+	repo.release <- struct{}{}
+	status = waitReindex(t, svc)
+	assert.Equal(t, domain.ReindexCompleted, status.State)
+	assert.Equal(t, int64(3), status.Indexed)
+	assert.InDelta(t, 100, status.Progress(), 0)
+	assert.False(t, status.FinishedAt.IsZero())
+	require.Len(t, index.builds, 1)
+	assert.Equal(t, 3, index.builds[0].indexed)
+	assert.True(t, index.builds[0].committed)
+}
+
+func TestReindexService_FailureAbortsBuild(t *testing.T) {
+	repo := &fakeIterateRepo{contents: []*domain.Content{{ID: "1"}}, err: errors.New("connection reset")}
+	index := &fakeSearchIndex{}
+	svc := NewReindexService(index, repo, 10, zap.NewNop())
+
+	_, err := svc.Start()
+	require.NoError(t, err)
+
+	status := waitReindex(t, svc)
+	assert.Equal(t, domain.ReindexFailed, status.State)
+	assert.Equal(t, "connection reset", status.Error)
+	assert.True(t, index.builds[0].aborted)
+	assert.False(t, index.builds[0].committed)
+
+	// A failed rebuild can be retried
+	repo.err = nil
+	_, err = svc.Start()
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReindexCompleted, waitReindex(t, svc).State)
+}
+
+func TestReindexService_StopAbortsBuild(t *testing.T) {
+	repo := &fakeIterateRepo{contents: []*domain.Content{{ID: "1"}}, release: make(chan struct{})}
+	index := &fakeSearchIndex{}
+	svc := NewReindexService(index, repo, 10, zap.NewNop())
+
+	_, err := svc.Start()
+	require.NoError(t, err)
+	svc.Stop()
+
+	assert.Equal(t, domain.ReindexFailed, svc.Status().State)
+	assert.True(t, index.builds[0].aborted)
+}
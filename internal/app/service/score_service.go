@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// rescoreBatchSize is the number of contents rescored per statement.
+const rescoreBatchSize = 1000
+
+// ScoreService recomputes stored content scores outside of provider syncs, e.g.
+// after the scoring formula changed or to refresh recency points of contents no
+// provider returns anymore.
+type ScoreService struct {
+	repo   domain.ContentScoreRepository
+	cache  domain.Cache // Optional cache cleared after scores change (can be nil)
+	logger *zap.Logger
+}
+
+// NewScoreService creates a new ScoreService.
+// cache is optional and can be nil; when set, it is cleared after a reindex so
+// search results reflect the new scores.
+func NewScoreService(repo domain.ContentScoreRepository, cache domain.Cache, logger *zap.Logger) *ScoreService {
+	return &ScoreService{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Reindex recomputes the score of every content with domain.CalculateScore, keeping
+// CTR boosts. Returns the number of contents rescored.
+func (s *ScoreService) Reindex(ctx context.Context) (int64, error) {
+	rescored, err := s.repo.Rescore(ctx, rescoreBatchSize, domain.CalculateScore)
+	if err != nil {
+		return rescored, fmt.Errorf("reindexing scores: %w", err)
+	}
+
+	log := logger.FromContext(ctx, s.logger)
+	if s.cache != nil {
+		if err := s.cache.Clear(ctx); err != nil {
+			log.Warn("failed to clear cache after score reindex", zap.Error(err))
+		}
+	}
+
+	log.Info("content scores reindexed", zap.Int64("contents", rescored))
+
+	return rescored, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	memcache "search-engine-service/internal/infra/cache"
+)
+
+// fakeScoreRepo rescores its contents with the function passed to Rescore.
+type fakeScoreRepo struct {
+	contents []*domain.Content
+	scores   map[string]float64
+}
+
+func (r *fakeScoreRepo) Rescore(_ context.Context, _ int, score func(c *domain.Content) float64) (int64, error) {
+	r.scores = make(map[string]float64, len(r.contents))
+	for _, c := range r.contents {
+		r.scores[c.ID] = score(c)
+	}
+
+	return int64(len(r.contents)), nil
+}
+
+func TestScoreService_Reindex(t *testing.T) {
+	article := &domain.Content{
+		ID:          "article",
+		Type:        domain.ContentTypeArticle,
+		ReadingTime: 10,
+		PublishedAt: time.Now().AddDate(-1, 0, 0),
+	}
+	repo := &fakeScoreRepo{contents: []*domain.Content{article}}
+	cache := memcache.NewMemoryCache(10)
+	require.NoError(t, cache.Set(context.Background(), "search:cached", []byte("{}"), time.Hour))
+
+	rescored, err := NewScoreService(repo, cache, zap.NewNop()).Reindex(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), rescored)
+	assert.Equal(t, map[string]float64{"article": domain.CalculateScore(article)}, repo.scores)
+
+	cached, err := cache.Get(context.Background(), "search:cached")
+	require.NoError(t, err)
+	assert.Nil(t, cached, "cached results are cleared")
+}
@@ -2,140 +2,648 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha1" //nolint:gosec // Used for cache key derivation only
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
 )
 
+// CacheTTLs holds the expiration used for each kind of cached value.
+// Volatile aggregates (stats) can expire quickly while stable lookups live longer.
+type CacheTTLs struct {
+	Search   time.Duration // Search result pages
+	Content  time.Duration // Single content by ID
+	NotFound time.Duration // Negative-cache markers for missing IDs
+	Stats    time.Duration // Counts and dashboard stats
+
+	// Stale is how long search results may be served after Search expires while
+	// being refreshed in the background (stale-while-revalidate). 0 disables it.
+	Stale time.Duration
+
+	// Degraded is how long search results are kept past their stale window, only to
+	// be served while the database misses the latency budget (see WithLatencyBudget).
+	// 0 disables it.
+	Degraded time.Duration
+}
+
+// revalidateTimeout bounds a background stale-while-revalidate refresh.
+const revalidateTimeout = 10 * time.Second
+
 // SearchService handles content search operations.
 type SearchService struct {
-	repo     domain.ContentRepository
-	cache    domain.Cache  // Optional cache (can be nil)
-	cacheTTL time.Duration // TTL for cached search results
-	logger   *zap.Logger
+	repo          domain.ContentRepository
+	cache         domain.Cache               // Optional cache (can be nil)
+	ttls          atomic.Pointer[CacheTTLs]  // TTL per cached value type
+	warm          WarmConfig                 // Searches re-executed by WarmCache
+	settings      *SettingsService           // Optional runtime overrides (can be nil)
+	futurePublish domain.FuturePublishPolicy // Whether searches hide contents published in the future
+	queries       *QueryAnalyticsService     // Optional query analytics (can be nil)
+	maxResults    int                        // Deepest result pages may reach (0 is unlimited)
+	latencyBudget time.Duration              // Time database searches get before degrading (0 is unbounded)
+	shadow        ShadowConfig               // Searches executed again to compare rankings
+	shadowSlots   chan struct{}              // Shadow searches running, up to its capacity
+	recordShadow  func(context.Context, domain.Event)
+	tracker       *queryTracker
+	logger        *zap.Logger
+
+	revalidating sync.Map // Cache keys with a background refresh in flight
+}
+
+// SearchOption configures a SearchService.
+type SearchOption func(*SearchService)
+
+// WithCache caches searches and lookups in cache, expiring them after ttls.
+// Without it, every call reaches the repository.
+func WithCache(cache domain.Cache, ttls CacheTTLs) SearchOption {
+	return func(s *SearchService) {
+		s.cache = cache
+		s.ttls.Store(&ttls)
+	}
+}
+
+// WithCacheWarming makes WarmCache re-execute the searches of warm. Only used
+// with WithCache.
+func WithCacheWarming(warm WarmConfig) SearchOption {
+	return func(s *SearchService) {
+		s.warm = warm
+	}
+}
+
+// WithSettings makes the runtime overrides of settings (cache switch, search TTL
+// and scoring strategy) take precedence over the configured ones.
+func WithSettings(settings *SettingsService) SearchOption {
+	return func(s *SearchService) {
+		s.settings = settings
+	}
+}
+
+// WithSearchFuturePublish hides contents published in the future from searches
+// if policy does (see domain.FuturePublishPolicy.HidesUnpublished).
+func WithSearchFuturePublish(policy domain.FuturePublishPolicy) SearchOption {
+	return func(s *SearchService) {
+		s.futurePublish = policy
+	}
+}
+
+// WithQueryAnalytics records the executed searches in queries.
+func WithQueryAnalytics(queries *QueryAnalyticsService) SearchOption {
+	return func(s *SearchService) {
+		s.queries = queries
+	}
+}
+
+// WithMaxResults rejects searches for pages past the first maxResults results (see
+// domain.SearchParams.CheckDepth). 0 leaves the depth unlimited.
+func WithMaxResults(maxResults int) SearchOption {
+	return func(s *SearchService) {
+		s.maxResults = maxResults
+	}
+}
+
+// WithLatencyBudget degrades searches whose database query fails or takes longer than
+// budget instead of failing them: they are answered with an outdated cached result,
+// if one is kept (see CacheTTLs.Degraded), or else with the page sorted without text
+// ranking and uncounted, marked domain.SearchResult.Degraded. 0 leaves them unbounded.
+func WithLatencyBudget(budget time.Duration) SearchOption {
+	return func(s *SearchService) {
+		s.latencyBudget = budget
+	}
+}
+
+// NewSearchService creates a new SearchService searching repo, configured by opts.
+// Without options, it doesn't cache and searches every stored content.
+func NewSearchService(repo domain.ContentRepository, logger *zap.Logger, opts ...SearchOption) *SearchService {
+	s := &SearchService{
+		repo:          repo,
+		futurePublish: domain.FuturePublishClamp,
+		tracker:       newQueryTracker(),
+		logger:        logger,
+	}
+	s.ttls.Store(&CacheTTLs{})
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SetCacheTTLs replaces the cache TTLs, e.g. after a configuration reload. Entries
+// already cached keep their TTL. A search TTL changed at runtime (see
+// SettingsService) still takes precedence.
+func (s *SearchService) SetCacheTTLs(ttls CacheTTLs) {
+	s.ttls.Store(&ttls)
+}
+
+// activeCache returns the cache, or nil when there is none or it is disabled at runtime.
+func (s *SearchService) activeCache() domain.Cache {
+	if s.settings != nil && !s.settings.Current().CacheEnabled {
+		return nil
+	}
+
+	return s.cache
 }
 
-// NewSearchService creates a new SearchService.
-// cache is optional and can be nil to disable caching.
-// cacheTTL is only used if cache is not nil.
-func NewSearchService(
-	repo domain.ContentRepository,
-	cache domain.Cache,
-	cacheTTL time.Duration,
-	logger *zap.Logger,
-) *SearchService {
-	return &SearchService{
-		repo:     repo,
-		cache:    cache,
-		cacheTTL: cacheTTL,
-		logger:   logger,
+// searchTTL returns how long search results stay fresh.
+func (s *SearchService) searchTTL() time.Duration {
+	if s.settings != nil {
+		if ttl := s.settings.Current().CacheSearchTTL; ttl > 0 {
+			return ttl
+		}
 	}
+
+	return s.ttls.Load().Search
+}
+
+// scoringStrategy returns the ranking used for relevance sorts.
+func (s *SearchService) scoringStrategy() domain.ScoringStrategy {
+	if s.settings != nil {
+		return s.settings.Current().ScoringStrategy
+	}
+
+	return domain.ScoringHybrid
+}
+
+// searchBackend returns the backend matching search queries.
+func (s *SearchService) searchBackend() domain.SearchBackend {
+	if s.settings != nil {
+		return s.settings.Current().SearchBackend
+	}
+
+	return domain.SearchBackendPostgres
 }
 
 // Search searches for contents based on the given parameters.
 // Implements cache-aside pattern with TTL-based expiration.
+//
+// When a stale window is configured (stale-while-revalidate), entries past their
+// freshness deadline are still served immediately while a background goroutine
+// refreshes them from the database. With a latency budget, searches the database
+// can't answer in time are degraded rather than failed (see WithLatencyBudget).
 func (s *SearchService) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
 	params.Validate()
+	if err := params.CheckDepth(s.maxResults); err != nil {
+		return nil, err
+	}
+
+	if s.activeCache() != nil {
+		s.tracker.record(buildSearchCacheKey(params), params)
+	}
 
-	s.logger.Debug("searching contents",
+	start := time.Now()
+	result, err := s.search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if s.queries != nil {
+		s.queries.Record(params, result.Total, time.Since(start))
+	}
+	s.shadowAsync(ctx, params, result)
+
+	return result, nil
+}
+
+// search runs a validated search through the cache. Used by Search and WarmCache.
+func (s *SearchService) search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	logger.FromContext(ctx, s.logger).Debug("searching contents",
 		zap.String("query", params.Query),
 		zap.String("type", string(params.Type)),
 		zap.Int("page", params.Page),
 		zap.Int("page_size", params.PageSize),
 	)
 
-	// Try cache if available
-	if s.cache != nil {
-		cacheKey := buildSearchCacheKey(params)
-		if data, err := s.cache.Get(ctx, cacheKey); err == nil && data != nil {
-			var result domain.SearchResult
-			if err := json.Unmarshal(data, &result); err == nil {
-				s.logger.Debug("cache hit",
-					zap.String("key", cacheKey),
-					zap.String("query", params.Query),
-				)
-
-				return &result, nil
-			}
-			// Unmarshal failed - continue to DB query
-			s.logger.Warn("cache unmarshal failed",
+	// Strategies and backends rank differently, so they are part of the cache key
+	params.Scoring = s.scoringStrategy()
+	params.Backend = s.searchBackend()
+	params.HideUnpublished = s.futurePublish.HidesUnpublished()
+
+	cache := s.activeCache()
+	if cache == nil {
+		return s.searchWithinBudget(ctx, params, nil)
+	}
+
+	// Try cache first, unless the caller forces a refresh
+	cacheKey := buildSearchCacheKey(params)
+	var entry *cachedSearch
+	if !params.BypassCache {
+		entry = s.getCachedSearch(ctx, cache, cacheKey)
+	}
+	if entry != nil {
+		now := time.Now()
+		if now.Before(entry.FreshUntil) {
+			logger.FromContext(ctx, s.logger).Debug("cache hit",
 				zap.String("key", cacheKey),
-				zap.Error(err),
+				zap.String("query", params.Query),
 			)
+
+			return entry.Result, nil
+		}
+
+		if now.Before(entry.FreshUntil.Add(s.ttls.Load().Stale)) {
+			logger.FromContext(ctx, s.logger).Debug("serving stale cache entry",
+				zap.String("key", cacheKey),
+				zap.Time("fresh_until", entry.FreshUntil),
+			)
+			s.revalidateAsync(ctx, cache, cacheKey, params)
+
+			return entry.Result, nil
 		}
+		// Past its stale window, only kept for degraded searches
+	}
+
+	// Query database on cache miss
+	result, err := s.searchWithinBudget(ctx, params, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Degraded {
+		s.setCachedSearch(ctx, cache, cacheKey, result)
 	}
 
-	// Query database on cache miss or cache disabled
+	return result, nil
+}
+
+// searchWithinBudget queries the repository within the latency budget, if one is set.
+// Should the query fail while ctx is still live, the search degrades to the result of
+// fallback, kept past its stale window (can be nil), or else to an uncounted query
+// ranking relevance sorts by score, within another budget. The error of the first
+// query is returned if that fails too.
+func (s *SearchService) searchWithinBudget(ctx context.Context, params domain.SearchParams, fallback *cachedSearch) (*domain.SearchResult, error) {
+	if s.latencyBudget <= 0 {
+		return s.searchDB(ctx, params)
+	}
+
+	budgetCtx, cancel := context.WithTimeout(ctx, s.latencyBudget)
+	result, err := s.searchDB(budgetCtx, params)
+	cancel()
+	if err == nil || ctx.Err() != nil {
+		return result, err
+	}
+
+	if fallback != nil {
+		logger.FromContext(ctx, s.logger).Warn("search degraded to an outdated cached result",
+			zap.Time("fresh_until", fallback.FreshUntil),
+			zap.Error(err),
+		)
+		degraded := *fallback.Result
+		degraded.Degraded = true
+
+		return &degraded, nil
+	}
+
+	uncounted := params
+	uncounted.SkipCount = true
+	if uncounted.SortBy == domain.SortFieldRelevance {
+		// Text ranking is the costly part of relevance sorts
+		uncounted.SortBy = domain.SortFieldScore
+	}
+	budgetCtx, cancel = context.WithTimeout(ctx, s.latencyBudget)
+	defer cancel()
+	degraded, degradedErr := s.repo.Search(budgetCtx, uncounted)
+	if degradedErr != nil {
+		logger.FromContext(ctx, s.logger).Warn("degraded search failed", zap.Error(degradedErr))
+
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Warn("search degraded to uncounted results", zap.Error(err))
+	degraded.Degraded = true
+
+	return degraded, nil
+}
+
+// searchDB queries the repository directly.
+func (s *SearchService) searchDB(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
 	result, err := s.repo.Search(ctx, params)
 	if err != nil {
-		s.logger.Error("search failed", zap.Error(err))
+		logger.FromContext(ctx, s.logger).Error("search failed", zap.Error(err))
 
 		return nil, err
 	}
 
-	s.logger.Debug("search completed",
+	logger.FromContext(ctx, s.logger).Debug("search completed",
 		zap.Int64("total", result.Total),
 		zap.Int("count", len(result.Contents)),
 	)
 
-	// Store in cache with TTL if cache is available
-	if s.cache != nil {
-		cacheKey := buildSearchCacheKey(params)
-		if data, err := json.Marshal(result); err == nil {
-			if err := s.cache.Set(ctx, cacheKey, data, s.cacheTTL); err != nil {
-				// Don't fail the request on cache errors - log and continue
-				s.logger.Warn("failed to cache search result",
-					zap.Error(err),
-					zap.String("key", cacheKey),
-				)
-			} else {
-				s.logger.Debug("cached search result",
-					zap.String("key", cacheKey),
-					zap.Duration("ttl", s.cacheTTL),
-				)
-			}
-		} else {
-			s.logger.Warn("failed to marshal search result for caching",
-				zap.Error(err),
-				zap.String("key", cacheKey),
-			)
-		}
+	return result, nil
+}
+
+// cachedSearch is the envelope stored for search results.
+// FreshUntil marks the end of the fresh period; the entry itself lives
+// for an additional stale window when stale-while-revalidate is enabled, and
+// a degraded window after it when configured.
+type cachedSearch struct {
+	Result     *domain.SearchResult `json:"result"`
+	FreshUntil time.Time            `json:"fresh_until"`
+}
+
+// getCachedSearch returns the cached entry for key, or nil on miss or decode failure.
+func (s *SearchService) getCachedSearch(ctx context.Context, cache domain.Cache, key string) *cachedSearch {
+	data, err := cache.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil
 	}
 
-	return result, nil
+	var entry cachedSearch
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Result == nil {
+		// Unmarshal failed - treat as miss and continue to DB query
+		logger.FromContext(ctx, s.logger).Warn("cache unmarshal failed",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	return &entry
+}
+
+// setCachedSearch stores a search result. Cache errors are logged, never returned.
+func (s *SearchService) setCachedSearch(ctx context.Context, cache domain.Cache, key string, result *domain.SearchResult) {
+	searchTTL := s.searchTTL()
+	entry := cachedSearch{
+		Result:     result,
+		FreshUntil: time.Now().Add(searchTTL),
+	}
+	ttls := s.ttls.Load()
+	ttl := searchTTL + ttls.Stale + ttls.Degraded
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to marshal search result for caching",
+			zap.Error(err),
+			zap.String("key", key),
+		)
+
+		return
+	}
+
+	if err := cache.Set(ctx, key, data, ttl); err != nil {
+		// Don't fail the request on cache errors - log and continue
+		logger.FromContext(ctx, s.logger).Warn("failed to cache search result",
+			zap.Error(err),
+			zap.String("key", key),
+		)
+
+		return
+	}
+
+	logger.FromContext(ctx, s.logger).Debug("cached search result",
+		zap.String("key", key),
+		zap.Duration("ttl", ttl),
+	)
+}
+
+// revalidateAsync refreshes a stale entry in the background.
+// At most one refresh per key runs at a time on this instance. The refresh outlives
+// the triggering request but keeps its context values (e.g. the request ID for logs).
+func (s *SearchService) revalidateAsync(ctx context.Context, cache domain.Cache, key string, params domain.SearchParams) {
+	if _, running := s.revalidating.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer s.revalidating.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), revalidateTimeout)
+		defer cancel()
+
+		result, err := s.searchDB(ctx, params)
+		if err != nil {
+			logger.FromContext(ctx, s.logger).Warn("background revalidation failed", zap.String("key", key), zap.Error(err))
+
+			return
+		}
+		s.setCachedSearch(ctx, cache, key, result)
+	}()
 }
 
 // GetByID retrieves a single content by its internal ID.
+// Returns domain.ErrNotFound if it doesn't exist.
+//
+// Found contents are cached with the content TTL. Misses are negatively cached
+// with a short TTL so clients probing random IDs don't reach the database on
+// every request. Both are invalidated when content with that ID is upserted or
+// deleted (see SyncService).
 func (s *SearchService) GetByID(ctx context.Context, id string) (*domain.Content, error) {
+	cacheKey := contentCacheKey(id)
+	cache := s.activeCache()
+
+	if cache != nil {
+		if data, err := cache.Get(ctx, cacheKey); err == nil && data != nil {
+			if isNotFoundMarker(data) {
+				logger.FromContext(ctx, s.logger).Debug("negative cache hit", zap.String("id", id))
+
+				return nil, fmt.Errorf("content %s: %w", id, domain.ErrNotFound)
+			}
+
+			var content domain.Content
+			if err := json.Unmarshal(data, &content); err == nil {
+				logger.FromContext(ctx, s.logger).Debug("content cache hit", zap.String("id", id))
+
+				return &content, nil
+			}
+		}
+	}
+
 	content, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		if cache != nil {
+			s.setCachedContent(ctx, cache, id, nil)
+		}
+
+		return nil, err
+	}
 	if err != nil {
-		s.logger.Error("get by id failed", zap.String("id", id), zap.Error(err))
+		logger.FromContext(ctx, s.logger).Error("get by id failed", zap.String("id", id), zap.Error(err))
 
 		return nil, err
 	}
 
+	if cache != nil {
+		s.setCachedContent(ctx, cache, id, content)
+	}
+
 	return content, nil
 }
 
+// setCachedContent stores a content, or a not-found marker when content is nil.
+func (s *SearchService) setCachedContent(ctx context.Context, cache domain.Cache, id string, content *domain.Content) {
+	cacheKey := contentCacheKey(id)
+
+	if content == nil {
+		if err := cache.Set(ctx, cacheKey, notFoundMarker, s.ttls.Load().NotFound); err != nil {
+			logger.FromContext(ctx, s.logger).Warn("failed to cache not-found marker", zap.String("id", id), zap.Error(err))
+		}
+
+		return
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to marshal content for cache", zap.String("id", id), zap.Error(err))
+
+		return
+	}
+
+	if err := cache.Set(ctx, cacheKey, data, s.ttls.Load().Content); err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to cache content", zap.String("id", id), zap.Error(err))
+	}
+}
+
 // Count returns the total number of contents.
+// The total is cached with the stats TTL since the dashboard requests it on every render.
 func (s *SearchService) Count(ctx context.Context) (int64, error) {
-	return s.repo.Count(ctx, domain.SearchParams{})
+	const cacheKey = "stats:count"
+	cache := s.activeCache()
+
+	if cache != nil {
+		if data, err := cache.Get(ctx, cacheKey); err == nil && data != nil {
+			if count, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+				return count, nil
+			}
+		}
+	}
+
+	count, err := s.repo.Count(ctx, domain.SearchParams{})
+	if err != nil {
+		return 0, err
+	}
+
+	if cache != nil {
+		if err := cache.Set(ctx, cacheKey, []byte(strconv.FormatInt(count, 10)), s.ttls.Load().Stats); err != nil {
+			logger.FromContext(ctx, s.logger).Warn("failed to cache content count", zap.Error(err))
+		}
+	}
+
+	return count, nil
+}
+
+// facetTagLimit is the number of most frequent tags returned in facets.
+const facetTagLimit = 20
+
+// Facets returns the number of contents matching the query and filters of params
+// per type, provider and tag. Pagination and sorting are ignored. Facets are cached
+// with the stats TTL.
+func (s *SearchService) Facets(ctx context.Context, params domain.SearchParams) (*domain.SearchFacets, error) {
+	filters := domain.SearchParams{
+		Query:           params.Query,
+		Mode:            params.Mode,
+		Type:            params.Type,
+		Provider:        params.Provider,
+		Tag:             params.Tag,
+		Duration:        params.Duration,
+		Backend:         s.searchBackend(),
+		HideUnpublished: s.futurePublish.HidesUnpublished(),
+	}
+	filters.Normalize()
+	cacheKey := "facets:" + hashParams(filters)
+	cache := s.activeCache()
+
+	if cache != nil {
+		if data, err := cache.Get(ctx, cacheKey); err == nil && data != nil {
+			var facets domain.SearchFacets
+			if err := json.Unmarshal(data, &facets); err == nil {
+				return &facets, nil
+			}
+		}
+	}
+
+	facets, err := s.repo.Facets(ctx, filters, facetTagLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Error("facets failed", zap.String("query", params.Query), zap.Error(err))
+
+		return nil, err
+	}
+
+	if cache != nil {
+		if data, err := json.Marshal(facets); err == nil {
+			if err := cache.Set(ctx, cacheKey, data, s.ttls.Load().Stats); err != nil {
+				logger.FromContext(ctx, s.logger).Warn("failed to cache facets", zap.Error(err))
+			}
+		}
+	}
+
+	return facets, nil
+}
+
+// ClearCache removes every cached search result and content. It does nothing when
+// there is no cache, and clears it even while caching is disabled at runtime so
+// stale entries aren't served once it's enabled again.
+func (s *SearchService) ClearCache(ctx context.Context) error {
+	if s.cache == nil {
+		return nil
+	}
+
+	if err := s.cache.Clear(ctx); err != nil {
+		return fmt.Errorf("clearing cache: %w", err)
+	}
+	logger.FromContext(ctx, s.logger).Info("cache cleared")
+
+	return nil
+}
+
+// exportBatchSize is the number of rows fetched per query while exporting.
+const exportBatchSize = 500
+
+// Export streams all contents matching the query and type filters of params to fn
+// in batches. Results bypass the cache and ignore pagination.
+func (s *SearchService) Export(ctx context.Context, params domain.SearchParams, fn func(batch []*domain.Content) error) error {
+	params.Scoring = s.scoringStrategy()
+	params.Backend = s.searchBackend()
+	params.HideUnpublished = s.futurePublish.HidesUnpublished()
+
+	if err := s.repo.Iterate(ctx, params, exportBatchSize, fn); err != nil {
+		logger.FromContext(ctx, s.logger).Error("export failed", zap.String("query", params.Query), zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// notFoundMarker is the cached value recorded for IDs that don't exist.
+var notFoundMarker = []byte("\x00not_found")
+
+// isNotFoundMarker reports whether cached data is a negative-cache marker.
+func isNotFoundMarker(data []byte) bool {
+	return bytes.Equal(data, notFoundMarker)
+}
+
+// contentCacheKey creates the cache key for a single content lookup.
+// Format: content:{id}
+func contentCacheKey(id string) string {
+	return "content:" + id
 }
 
 // buildSearchCacheKey creates a deterministic cache key from search parameters.
-// Format: search:query:type:page:pagesize:sortby:sortorder
+// Format: search:{sha1 of canonical JSON params}
+//
+// Hashing bounds key length regardless of query size and avoids delimiter
+// collisions for queries containing ':'. The global cache version is part of
+// the Redis key prefix (see cache.version).
 func buildSearchCacheKey(params domain.SearchParams) string {
-	return fmt.Sprintf("search:%s:%s:%d:%d:%s:%s",
-		params.Query,
-		params.Type,
-		params.Page,
-		params.PageSize,
-		params.SortBy,
-		params.SortOrder,
-	)
+	return "search:" + hashParams(params)
+}
+
+// hashParams returns the hex SHA-1 of the canonical JSON encoding of params.
+func hashParams(params domain.SearchParams) string {
+	// Struct fields marshal in declaration order, so the encoding is canonical
+	canonical, _ := json.Marshal(params)
+	sum := sha1.Sum(canonical) //nolint:gosec // Used for key derivation, not security
+
+	return hex.EncodeToString(sum[:])
 }
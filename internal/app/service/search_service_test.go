@@ -0,0 +1,526 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/eventbus"
+	memcache "search-engine-service/internal/infra/cache"
+)
+
+func TestBuildSearchCacheKey_Deterministic(t *testing.T) {
+	params := domain.DefaultSearchParams()
+	params.Query = "golang"
+
+	assert.Equal(t, buildSearchCacheKey(params), buildSearchCacheKey(params))
+	assert.True(t, strings.HasPrefix(buildSearchCacheKey(params), "search:"))
+}
+
+func TestBuildSearchCacheKey_NoDelimiterCollision(t *testing.T) {
+	// With a plain "search:{query}:{type}" layout these two would collide
+	a := domain.DefaultSearchParams()
+	a.Query = "go:video"
+
+	b := domain.DefaultSearchParams()
+	b.Query = "go"
+	b.Type = domain.ContentTypeVideo
+
+	assert.NotEqual(t, buildSearchCacheKey(a), buildSearchCacheKey(b))
+}
+
+func TestBuildSearchCacheKey_BoundedLength(t *testing.T) {
+	params := domain.DefaultSearchParams()
+	params.Query = strings.Repeat("long query ", 20)
+
+	assert.Len(t, buildSearchCacheKey(params), len("search:")+40)
+}
+
+// fakeRepo is an in-memory ContentRepository that counts GetByID calls.
+type fakeRepo struct {
+	domain.ContentRepository
+	contents  map[string]*domain.Content
+	getCalls  int
+	searches  []domain.SearchParams
+	lastSyncs map[string]time.Time
+	facets    []domain.SearchParams
+}
+
+func (r *fakeRepo) Search(_ context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	r.searches = append(r.searches, params)
+
+	return &domain.SearchResult{Page: params.Page, PageSize: params.PageSize}, nil
+}
+
+func (r *fakeRepo) Facets(_ context.Context, params domain.SearchParams, _ int) (*domain.SearchFacets, error) {
+	r.facets = append(r.facets, params)
+
+	return &domain.SearchFacets{Types: []domain.FacetCount{{Value: "video", Count: 2}}}, nil
+}
+
+func (r *fakeRepo) GetByID(_ context.Context, id string) (*domain.Content, error) {
+	r.getCalls++
+
+	content, ok := r.contents[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return content, nil
+}
+
+func (r *fakeRepo) GetByProviderAndExternalID(_ context.Context, providerID, externalID string) (*domain.Content, error) {
+	for _, c := range r.contents {
+		if c.ProviderID == providerID && c.ExternalID == externalID {
+			return c, nil
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeRepo) Delete(_ context.Context, id string) error {
+	if _, ok := r.contents[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.contents, id)
+
+	return nil
+}
+
+func (r *fakeRepo) LastSyncTimes(_ context.Context) (map[string]time.Time, error) {
+	return r.lastSyncs, nil
+}
+
+func (r *fakeRepo) BulkUpsert(_ context.Context, contents []*domain.Content) error {
+	for _, c := range contents {
+		r.contents[c.ExternalID] = c
+	}
+
+	return nil
+}
+
+func (r *fakeRepo) UpdateCuration(_ context.Context, id string, patch domain.CurationPatch) (*domain.Content, error) {
+	content, ok := r.contents[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	updated := *content
+	if patch.Pinned != nil {
+		updated.Pinned = *patch.Pinned
+	}
+	if patch.Blocked != nil {
+		updated.Blocked = *patch.Blocked
+	}
+	if patch.Boost != nil {
+		updated.Boost = *patch.Boost
+	}
+	r.contents[id] = &updated
+
+	return &updated, nil
+}
+
+func newTestServices(repo *fakeRepo) (*SearchService, *SyncService) {
+	c := memcache.NewMemoryCache(100)
+	ttls := CacheTTLs{Search: time.Minute, Content: time.Minute, NotFound: time.Minute}
+
+	events := eventbus.New(zap.NewNop())
+	events.Subscribe("cache", NewCacheInvalidator(c, nil, zap.NewNop()).HandleEvent)
+
+	return NewSearchService(repo, zap.NewNop(), WithCache(c, ttls)),
+		NewSyncService(repo, nil, zap.NewNop(), WithEvents(events))
+}
+
+func TestGetByID_CachesContent(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Go Concurrency", Type: domain.ContentTypeVideo},
+	}}
+	search, _ := newTestServices(repo)
+
+	first, err := search.GetByID(context.Background(), "id-1")
+	require.NoError(t, err)
+	second, err := search.GetByID(context.Background(), "id-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, repo.getCalls)
+}
+
+func TestDeleteContent_InvalidatesCachedContent(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Go Concurrency", Type: domain.ContentTypeVideo},
+	}}
+	search, sync := newTestServices(repo)
+
+	_, err := search.GetByID(context.Background(), "id-1")
+	require.NoError(t, err)
+
+	require.NoError(t, sync.DeleteContent(context.Background(), "id-1"))
+
+	content, err := search.GetByID(context.Background(), "id-1")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, content)
+	assert.Equal(t, 2, repo.getCalls)
+}
+
+func TestCurateContent_InvalidatesCache(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Go Concurrency", Type: domain.ContentTypeVideo, Boost: 1},
+	}}
+	search, sync := newTestServices(repo)
+	ctx := context.Background()
+	params := domain.DefaultSearchParams()
+
+	_, err := search.GetByID(ctx, "id-1")
+	require.NoError(t, err)
+	_, err = search.Search(ctx, params)
+	require.NoError(t, err)
+
+	blocked := true
+	curated, err := sync.CurateContent(ctx, "id-1", domain.CurationPatch{Blocked: &blocked})
+	require.NoError(t, err)
+	assert.True(t, curated.Blocked)
+	assert.Equal(t, 1.0, curated.Boost, "unset fields are left unchanged")
+
+	content, err := search.GetByID(ctx, "id-1")
+	require.NoError(t, err)
+	assert.True(t, content.Blocked)
+	_, err = search.Search(ctx, params)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, repo.getCalls)
+	assert.Len(t, repo.searches, 2, "cached search results are dropped")
+
+	_, err = sync.CurateContent(ctx, "missing", domain.CurationPatch{Blocked: &blocked})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetByID_NegativelyCachesMissingContent(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{}}
+	search, _ := newTestServices(repo)
+
+	_, err := search.GetByID(context.Background(), "missing")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = search.GetByID(context.Background(), "missing")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.Equal(t, 1, repo.getCalls)
+}
+
+func TestDeleteContent_NotFound(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{}}
+	_, sync := newTestServices(repo)
+
+	err := sync.DeleteContent(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSearchService_RuntimeSettings(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Go Concurrency", Type: domain.ContentTypeVideo},
+	}}
+	settings := NewSettingsService(nil, domain.RuntimeSettings{
+		CacheEnabled:    true,
+		CacheSearchTTL:  time.Minute,
+		ScoringStrategy: domain.ScoringHybrid,
+	}, zap.NewNop())
+	ttls := CacheTTLs{Search: time.Minute, Content: time.Minute, NotFound: time.Minute}
+	search := NewSearchService(repo, zap.NewNop(), WithCache(memcache.NewMemoryCache(100), ttls), WithSettings(settings))
+	ctx := context.Background()
+
+	disabled, text, bleve := false, domain.ScoringText, domain.SearchBackendBleve
+	_, err := settings.Update(ctx, domain.SettingsPatch{CacheEnabled: &disabled, ScoringStrategy: &text, SearchBackend: &bleve})
+	require.NoError(t, err)
+
+	// Cache bypassed: every lookup reaches the repository
+	for range 2 {
+		_, err := search.GetByID(ctx, "id-1")
+		require.NoError(t, err)
+		_, err = search.Search(ctx, domain.SearchParams{Query: "go"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, repo.getCalls)
+	require.Len(t, repo.searches, 2)
+	assert.Equal(t, domain.ScoringText, repo.searches[0].Scoring)
+	assert.Equal(t, domain.SearchBackendBleve, repo.searches[0].Backend)
+}
+
+func TestSearch_HidesUnpublishedUnderEmbargo(t *testing.T) {
+	repo := &fakeRepo{}
+	search := NewSearchService(repo, zap.NewNop(), WithSearchFuturePublish(domain.FuturePublishEmbargo))
+
+	_, err := search.Search(context.Background(), domain.DefaultSearchParams())
+	require.NoError(t, err)
+
+	require.Len(t, repo.searches, 1)
+	assert.True(t, repo.searches[0].HideUnpublished)
+}
+
+func TestFacets_CachedPerFilters(t *testing.T) {
+	repo := &fakeRepo{}
+	search, _ := newTestServices(repo)
+
+	params := domain.DefaultSearchParams()
+	params.Query = "go"
+	params.Tag = "tutorial"
+
+	first, err := search.Facets(context.Background(), params)
+	require.NoError(t, err)
+
+	// Pagination doesn't change the facets, so the cached ones are served
+	params.Page = 3
+	second, err := search.Facets(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	require.Len(t, repo.facets, 1)
+	assert.Equal(t, "tutorial", repo.facets[0].Tag)
+
+	params.Tag = "golang"
+	_, err = search.Facets(context.Background(), params)
+	require.NoError(t, err)
+	assert.Len(t, repo.facets, 2)
+}
+
+func TestSearch_CachedPerNormalizedQuery(t *testing.T) {
+	repo := &fakeRepo{}
+	search, _ := newTestServices(repo)
+
+	for _, q := range []string{"Golang  tutorial", " golang tutorial", "GOLANG\ttutorial "} {
+		params := domain.DefaultSearchParams()
+		params.Query = q
+		_, err := search.Search(context.Background(), params)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, repo.searches, 1, "equivalent queries share a cache entry")
+	assert.Equal(t, "golang tutorial", repo.searches[0].Query)
+}
+
+func TestSearch_BypassCacheRepopulatesIt(t *testing.T) {
+	repo := &fakeRepo{}
+	search, _ := newTestServices(repo)
+	ctx := context.Background()
+
+	params := domain.DefaultSearchParams()
+	_, err := search.Search(ctx, params)
+	require.NoError(t, err)
+
+	refresh := params
+	refresh.BypassCache = true
+	_, err = search.Search(ctx, refresh)
+	require.NoError(t, err)
+	require.Len(t, repo.searches, 2, "refreshes read the database")
+
+	_, err = search.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, repo.searches, 2, "and share the cache entry of the search")
+}
+
+func TestSearch_RejectsDeepPages(t *testing.T) {
+	repo := &fakeRepo{}
+	search := NewSearchService(repo, zap.NewNop(), WithMaxResults(1000))
+
+	params := domain.DefaultSearchParams()
+	params.Page, params.PageSize = 10, 100
+	_, err := search.Search(context.Background(), params)
+	require.NoError(t, err, "the last page within the limit")
+
+	params.Page = 11
+	_, err = search.Search(context.Background(), params)
+	require.ErrorIs(t, err, domain.ErrInvalidParams)
+	assert.Contains(t, err.Error(), "/contents/export")
+	assert.Len(t, repo.searches, 1, "deep pages don't reach the repository")
+}
+
+// slowRepo is a fakeRepo whose counted searches outlast any deadline while slow is set.
+type slowRepo struct {
+	fakeRepo
+	slow bool
+}
+
+func (r *slowRepo) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	if r.slow && !params.SkipCount {
+		<-ctx.Done()
+
+		return nil, fmt.Errorf("%w: %w", domain.ErrTimeout, ctx.Err())
+	}
+	r.searches = append(r.searches, params)
+
+	return &domain.SearchResult{Total: 3, Page: params.Page, PageSize: params.PageSize}, nil
+}
+
+func TestSearch_DegradesPastLatencyBudget(t *testing.T) {
+	repo := &slowRepo{slow: true}
+	search := NewSearchService(repo, zap.NewNop(), WithLatencyBudget(10*time.Millisecond))
+
+	params := domain.DefaultSearchParams()
+	params.Query = "go"
+	params.SortBy = domain.SortFieldRelevance
+	result, err := search.Search(context.Background(), params)
+	require.NoError(t, err)
+
+	assert.True(t, result.Degraded)
+	require.Len(t, repo.searches, 1)
+	assert.True(t, repo.searches[0].SkipCount, "degraded searches aren't counted")
+	assert.Equal(t, domain.SortFieldScore, repo.searches[0].SortBy, "nor ranked by text")
+}
+
+func TestSearch_DegradesToOutdatedCachedResult(t *testing.T) {
+	repo := &slowRepo{}
+	ttls := CacheTTLs{Search: time.Millisecond, Degraded: time.Minute}
+	search := NewSearchService(repo, zap.NewNop(),
+		WithCache(memcache.NewMemoryCache(100), ttls),
+		WithLatencyBudget(10*time.Millisecond),
+	)
+	ctx := context.Background()
+	params := domain.DefaultSearchParams()
+
+	result, err := search.Search(ctx, params)
+	require.NoError(t, err)
+	assert.False(t, result.Degraded)
+
+	time.Sleep(5 * time.Millisecond)
+	repo.slow = true
+	result, err = search.Search(ctx, params)
+	require.NoError(t, err)
+
+	assert.True(t, result.Degraded)
+	assert.Equal(t, int64(3), result.Total, "the cached result is served")
+	assert.Len(t, repo.searches, 1, "no uncounted search with a cached result")
+
+	repo.slow = false
+	result, err = search.Search(ctx, params)
+	require.NoError(t, err)
+	assert.False(t, result.Degraded, "outdated results aren't served once the database keeps up")
+}
+
+// revalidatingRepo counts searches, answering each with its count as total. While
+// gate is open (non-nil), searches wait for it to be closed.
+type revalidatingRepo struct {
+	fakeRepo
+	mu    sync.Mutex
+	calls int
+	gate  chan struct{}
+}
+
+func (r *revalidatingRepo) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	r.mu.Lock()
+	r.calls++
+	calls, gate := r.calls, r.gate
+	r.mu.Unlock()
+
+	if gate != nil {
+		select {
+		case <-gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return &domain.SearchResult{Total: int64(calls), Page: params.Page, PageSize: params.PageSize}, nil
+}
+
+func (r *revalidatingRepo) hold() chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gate = make(chan struct{})
+
+	return r.gate
+}
+
+func (r *revalidatingRepo) searches() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.calls
+}
+
+func TestSearch_ServesStaleEntryWhileRevalidating(t *testing.T) {
+	repo := &revalidatingRepo{}
+	ttls := CacheTTLs{Search: 50 * time.Millisecond, Stale: time.Minute}
+	search := NewSearchService(repo, zap.NewNop(), WithCache(memcache.NewMemoryCache(100), ttls))
+	ctx := context.Background()
+	params := domain.DefaultSearchParams()
+
+	result, err := search.Search(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Total)
+
+	time.Sleep(60 * time.Millisecond)
+	release := repo.hold()
+	result, err = search.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total, "the stale entry is served without waiting for the database")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		result, err := search.Search(ctx, params)
+
+		return err == nil && result.Total == 2
+	}, time.Second, time.Millisecond, "the background refresh replaces the cached entry")
+	assert.Equal(t, 2, repo.searches())
+}
+
+func TestSearch_EntryPastStaleWindowIsAMiss(t *testing.T) {
+	repo := &revalidatingRepo{}
+	// Kept for degraded searches past the stale window, but not served
+	ttls := CacheTTLs{Search: time.Millisecond, Stale: time.Millisecond, Degraded: time.Minute}
+	search := NewSearchService(repo, zap.NewNop(), WithCache(memcache.NewMemoryCache(100), ttls))
+	ctx := context.Background()
+	params := domain.DefaultSearchParams()
+
+	_, err := search.Search(ctx, params)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	result, err := search.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total, "the database answers the search")
+	assert.Equal(t, 2, repo.searches(), "without a background refresh")
+}
+
+func TestSearch_ConcurrentStaleHitsRevalidateOnce(t *testing.T) {
+	repo := &revalidatingRepo{}
+	ttls := CacheTTLs{Search: time.Millisecond, Stale: time.Minute}
+	search := NewSearchService(repo, zap.NewNop(), WithCache(memcache.NewMemoryCache(100), ttls))
+	ctx := context.Background()
+	params := domain.DefaultSearchParams()
+
+	_, err := search.Search(ctx, params)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	release := repo.hold()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := search.Search(ctx, params)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), result.Total)
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool { return repo.searches() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 2, repo.searches(), "a single refresh runs while one is in flight")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		result, err := search.Search(ctx, params)
+
+		return err == nil && result.Total == 2
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// settingsLoadTimeout bounds a single reload of the stored settings.
+const settingsLoadTimeout = 5 * time.Second
+
+// SettingsService holds the runtime settings consulted on every search.
+// Changes are saved to the store and picked up by other instances on their
+// next reload (see Start).
+type SettingsService struct {
+	store    domain.SettingsStore // Optional (nil keeps changes local to this instance)
+	defaults domain.RuntimeSettings
+	current  atomic.Pointer[domain.RuntimeSettings]
+	logger   *zap.Logger
+
+	mu       sync.Mutex // Serializes updates and reloads on this instance
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewSettingsService creates a new SettingsService starting from defaults (the
+// configured values). Caching can only be enabled at runtime if defaults enable it,
+// since no cache exists otherwise.
+func NewSettingsService(store domain.SettingsStore, defaults domain.RuntimeSettings, logger *zap.Logger) *SettingsService {
+	s := &SettingsService{
+		store:    store,
+		defaults: defaults,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+	s.current.Store(&defaults)
+
+	return s
+}
+
+// SetDefaultSearchTTL changes the configured search cache TTL, e.g. after a
+// configuration reload. It only takes effect while no settings were saved through
+// Update, since saved settings include the TTL.
+func (s *SettingsService) SetDefaultSearchTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaults.CacheSearchTTL = ttl
+
+	current := s.Current()
+	if current.UpdatedAt.IsZero() {
+		current.CacheSearchTTL = ttl
+		s.current.Store(&current)
+	}
+}
+
+// Current returns the settings in effect.
+func (s *SettingsService) Current() domain.RuntimeSettings {
+	return *s.current.Load()
+}
+
+// Update applies patch on top of the latest stored settings and saves the result.
+// Returns domain.ErrInvalidParams for invalid values.
+func (s *SettingsService) Update(ctx context.Context, patch domain.SettingsPatch) (domain.RuntimeSettings, error) {
+	if err := s.validate(patch); err != nil {
+		return domain.RuntimeSettings{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Start from the stored settings so changes made through other instances aren't reverted
+	if err := s.load(ctx); err != nil {
+		return domain.RuntimeSettings{}, err
+	}
+
+	updated := s.Current().Apply(patch)
+	updated.UpdatedAt = time.Now().UTC()
+
+	if s.store != nil {
+		if err := s.store.Save(ctx, &updated); err != nil {
+			return domain.RuntimeSettings{}, fmt.Errorf("saving settings: %w", err)
+		}
+	}
+	s.current.Store(&updated)
+
+	logger.FromContext(ctx, s.logger).Info("runtime settings updated",
+		zap.Bool("cache_enabled", updated.CacheEnabled),
+		zap.Duration("cache_search_ttl", updated.CacheSearchTTL),
+		zap.String("scoring_strategy", string(updated.ScoringStrategy)),
+		zap.String("search_backend", string(updated.SearchBackend)),
+		zap.Any("provider_syncs", updated.ProviderSyncs),
+	)
+
+	return updated, nil
+}
+
+// validate rejects patches the service can't apply.
+func (s *SettingsService) validate(patch domain.SettingsPatch) error {
+	if patch.CacheEnabled != nil && *patch.CacheEnabled && !s.defaults.CacheEnabled {
+		return fmt.Errorf("%w: cache is disabled in the configuration", domain.ErrInvalidParams)
+	}
+	if patch.CacheSearchTTL != nil && *patch.CacheSearchTTL <= 0 {
+		return fmt.Errorf("%w: cache_search_ttl must be positive", domain.ErrInvalidParams)
+	}
+	if patch.ScoringStrategy != nil && !patch.ScoringStrategy.IsValid() {
+		return fmt.Errorf("%w: unknown scoring strategy %q", domain.ErrInvalidParams, *patch.ScoringStrategy)
+	}
+	if patch.SearchBackend != nil && !patch.SearchBackend.IsValid() {
+		return fmt.Errorf("%w: unknown search backend %q", domain.ErrInvalidParams, *patch.SearchBackend)
+	}
+
+	return nil
+}
+
+// load replaces the current settings with the stored ones. Without stored settings
+// (or a store) the current settings are kept. Callers must hold s.mu.
+func (s *SettingsService) load(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+
+	stored, err := s.store.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("loading settings: %w", err)
+	}
+	if stored == nil {
+		return nil
+	}
+
+	settings := *stored
+	if !s.defaults.CacheEnabled {
+		// Saved while the cache was configured; there is nothing to enable now
+		settings.CacheEnabled = false
+	}
+	if !settings.ScoringStrategy.IsValid() {
+		settings.ScoringStrategy = s.defaults.ScoringStrategy
+	}
+	if !settings.SearchBackend.IsValid() {
+		// Saved before the setting existed
+		settings.SearchBackend = s.defaults.SearchBackend
+	}
+	if settings.CacheSearchTTL <= 0 {
+		settings.CacheSearchTTL = s.defaults.CacheSearchTTL
+	}
+	s.current.Store(&settings)
+
+	return nil
+}
+
+// Start loads the stored settings, then reloads them every interval until Stop
+// so changes made through other instances take effect here. Load errors are
+// logged and the previous settings stay in effect.
+func (s *SettingsService) Start(interval time.Duration) {
+	s.reload()
+
+	if s.store == nil || interval <= 0 {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.reload()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the periodic reload started by Start.
+func (s *SettingsService) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.wg.Wait()
+}
+
+// reload loads the stored settings with a timeout, logging failures.
+func (s *SettingsService) reload() {
+	ctx, cancel := context.WithTimeout(context.Background(), settingsLoadTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(ctx); err != nil {
+		s.logger.Warn("failed to reload runtime settings", zap.Error(err))
+	}
+}
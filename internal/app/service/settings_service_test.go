@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSettingsStore is an in-memory SettingsStore shared by "instances" in tests.
+type fakeSettingsStore struct {
+	settings *domain.RuntimeSettings
+}
+
+func (s *fakeSettingsStore) Get(context.Context) (*domain.RuntimeSettings, error) {
+	if s.settings == nil {
+		return nil, nil
+	}
+	settings := *s.settings
+
+	return &settings, nil
+}
+
+func (s *fakeSettingsStore) Save(_ context.Context, settings *domain.RuntimeSettings) error {
+	saved := *settings
+	s.settings = &saved
+
+	return nil
+}
+
+var testSettingsDefaults = domain.RuntimeSettings{
+	CacheEnabled:    true,
+	CacheSearchTTL:  15 * time.Minute,
+	ScoringStrategy: domain.ScoringHybrid,
+	SearchBackend:   domain.SearchBackendPostgres,
+}
+
+func TestSettingsService_UpdateIsSharedThroughStore(t *testing.T) {
+	store := &fakeSettingsStore{}
+	first := NewSettingsService(store, testSettingsDefaults, zap.NewNop())
+	second := NewSettingsService(store, testSettingsDefaults, zap.NewNop())
+	ctx := context.Background()
+
+	ttl := 5 * time.Minute
+	updated, err := first.Update(ctx, domain.SettingsPatch{CacheSearchTTL: &ttl})
+	require.NoError(t, err)
+	assert.Equal(t, ttl, updated.CacheSearchTTL)
+	assert.True(t, updated.CacheEnabled, "unpatched fields are kept")
+	assert.False(t, updated.UpdatedAt.IsZero())
+
+	// A change on the second instance starts from the first one's change
+	text := domain.ScoringText
+	updated, err = second.Update(ctx, domain.SettingsPatch{ScoringStrategy: &text})
+	require.NoError(t, err)
+	assert.Equal(t, ttl, updated.CacheSearchTTL)
+	assert.Equal(t, domain.ScoringText, updated.ScoringStrategy)
+
+	first.Start(0)
+	assert.Equal(t, domain.ScoringText, first.Current().ScoringStrategy)
+}
+
+func TestSettingsService_UpdateRejectsInvalidValues(t *testing.T) {
+	enabled := true
+	zero := time.Duration(0)
+	unknown := domain.ScoringStrategy("random")
+	opensearch := domain.SearchBackend("opensearch")
+
+	tests := []struct {
+		name     string
+		defaults domain.RuntimeSettings
+		patch    domain.SettingsPatch
+	}{
+		{"cache not configured", domain.RuntimeSettings{ScoringStrategy: domain.ScoringHybrid}, domain.SettingsPatch{CacheEnabled: &enabled}},
+		{"non-positive ttl", testSettingsDefaults, domain.SettingsPatch{CacheSearchTTL: &zero}},
+		{"unknown strategy", testSettingsDefaults, domain.SettingsPatch{ScoringStrategy: &unknown}},
+		{"unknown backend", testSettingsDefaults, domain.SettingsPatch{SearchBackend: &opensearch}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSettingsStore{}
+			svc := NewSettingsService(store, tt.defaults, zap.NewNop())
+
+			_, err := svc.Update(context.Background(), tt.patch)
+
+			require.ErrorIs(t, err, domain.ErrInvalidParams)
+			assert.Nil(t, store.settings)
+			assert.Equal(t, tt.defaults, svc.Current())
+		})
+	}
+}
+
+func TestSettingsService_StoredCacheSwitchNeedsConfiguredCache(t *testing.T) {
+	store := &fakeSettingsStore{settings: &domain.RuntimeSettings{
+		CacheEnabled:    true,
+		CacheSearchTTL:  time.Minute,
+		ScoringStrategy: domain.ScoringText,
+	}}
+	svc := NewSettingsService(store, domain.RuntimeSettings{ScoringStrategy: domain.ScoringHybrid, SearchBackend: domain.SearchBackendPostgres}, zap.NewNop())
+
+	svc.Start(0)
+
+	assert.False(t, svc.Current().CacheEnabled)
+	assert.Equal(t, domain.ScoringText, svc.Current().ScoringStrategy)
+	assert.Equal(t, domain.SearchBackendPostgres, svc.Current().SearchBackend, "saved before the setting existed")
+}
+
+func TestSettingsService_SetDefaultSearchTTL(t *testing.T) {
+	store := &fakeSettingsStore{}
+	svc := NewSettingsService(store, testSettingsDefaults, zap.NewNop())
+
+	svc.SetDefaultSearchTTL(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, svc.Current().CacheSearchTTL, "applies while nothing was saved")
+
+	text := domain.ScoringText
+	_, err := svc.Update(context.Background(), domain.SettingsPatch{ScoringStrategy: &text})
+	require.NoError(t, err)
+
+	svc.SetDefaultSearchTTL(time.Minute)
+	assert.Equal(t, 5*time.Minute, svc.Current().CacheSearchTTL, "saved settings take precedence")
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// ShadowConfig controls shadow traffic: searches executed again in the background
+// with an alternate ranking or backend, to see how switching the runtime settings
+// would change results before doing it.
+type ShadowConfig struct {
+	Percent     float64                // Share of searches with a query shadowed, 0 to 100
+	Scoring     domain.ScoringStrategy // Ranking of shadow searches; empty keeps the served one
+	Backend     domain.SearchBackend   // Backend of shadow searches; empty keeps the served one
+	K           int                    // Top results compared; 0 compares whole pages
+	MaxInFlight int                    // Shadow searches running at once; more are skipped
+	Timeout     time.Duration          // Bounds a shadow search; 0 is unbounded
+}
+
+// WithShadowTraffic executes cfg.Percent of the searches with a query again, in the
+// background, with the ranking and backend of cfg, and hands how their top results
+// compare with the served ones to record as domain.ShadowSearchCompared events.
+// Served results are neither changed nor delayed; degraded ones aren't compared.
+func WithShadowTraffic(cfg ShadowConfig, record func(context.Context, domain.Event)) SearchOption {
+	return func(s *SearchService) {
+		if cfg.Percent <= 0 || record == nil {
+			return
+		}
+		s.shadow = cfg
+		s.shadowSlots = make(chan struct{}, max(cfg.MaxInFlight, 1))
+		s.recordShadow = record
+	}
+}
+
+// shadowAsync executes params again with the shadow ranking and backend in the
+// background, if sampled, and records how the results compare with served. Like a
+// revalidation, the shadow search outlives the request but keeps its context values.
+func (s *SearchService) shadowAsync(ctx context.Context, params domain.SearchParams, served *domain.SearchResult) {
+	if s.recordShadow == nil || params.Query == "" || served.Degraded || rand.Float64()*100 >= s.shadow.Percent { //nolint:gosec // Sampling only
+		return
+	}
+
+	params.Scoring = s.scoringStrategy()
+	params.Backend = s.searchBackend()
+	params.HideUnpublished = s.futurePublish.HidesUnpublished()
+	shadow := params
+	if s.shadow.Scoring != "" {
+		shadow.Scoring = s.shadow.Scoring
+	}
+	if s.shadow.Backend != "" {
+		shadow.Backend = s.shadow.Backend
+	}
+	if shadow.Scoring == params.Scoring && shadow.Backend == params.Backend {
+		// The settings already serve the shadow variant
+		return
+	}
+	variant := string(shadow.Scoring) + "/" + string(shadow.Backend)
+
+	select {
+	case s.shadowSlots <- struct{}{}:
+	default:
+		s.recordShadow(ctx, domain.ShadowSearchCompared{Variant: variant, Skipped: true})
+
+		return
+	}
+
+	go func() {
+		defer func() { <-s.shadowSlots }()
+
+		ctx := context.WithoutCancel(ctx)
+		if s.shadow.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.shadow.Timeout)
+			defer cancel()
+		}
+
+		log := logger.FromContext(ctx, s.logger)
+		result, err := s.repo.Search(ctx, shadow)
+		if err != nil {
+			log.Warn("shadow search failed", zap.String("variant", variant), zap.Error(err))
+			s.recordShadow(ctx, domain.ShadowSearchCompared{Variant: variant, Error: err})
+
+			return
+		}
+
+		overlap, correlation, correlated := compareRankings(contentIDs(served.Contents), contentIDs(result.Contents), s.shadow.K)
+		log.Info("shadow search compared",
+			zap.String("query", params.Query),
+			zap.String("variant", variant),
+			zap.Float64("overlap", overlap),
+			zap.Float64("rank_correlation", correlation),
+			zap.Int64("total", served.Total),
+			zap.Int64("shadow_total", result.Total),
+		)
+		s.recordShadow(ctx, domain.ShadowSearchCompared{
+			Variant:         variant,
+			Overlap:         overlap,
+			RankCorrelation: correlation,
+			Correlated:      correlated,
+		})
+	}()
+}
+
+// contentIDs returns the IDs of contents, in order.
+func contentIDs(contents []*domain.Content) []string {
+	ids := make([]string, len(contents))
+	for i, c := range contents {
+		ids[i] = c.ID
+	}
+
+	return ids
+}
+
+// compareRankings compares the top k IDs of two rankings (all of them when k is 0).
+// overlap is the share of the longer top found in both, 1 when both are empty, and
+// correlation the Kendall rank correlation of the common IDs; correlated is false
+// when fewer than two are common, leaving correlation unset.
+func compareRankings(a, b []string, k int) (overlap, correlation float64, correlated bool) {
+	if k > 0 {
+		a, b = a[:min(k, len(a))], b[:min(k, len(b))]
+	}
+	n := max(len(a), len(b))
+	if n == 0 {
+		return 1, 0, false
+	}
+
+	rankB := make(map[string]int, len(b))
+	for i, id := range b {
+		rankB[id] = i
+	}
+	// Ranks in b of the common IDs, in the order of a
+	var ranks []int
+	for _, id := range a {
+		if r, ok := rankB[id]; ok {
+			ranks = append(ranks, r)
+		}
+	}
+	overlap = float64(len(ranks)) / float64(n)
+	if len(ranks) < 2 {
+		return overlap, 0, false
+	}
+
+	var concordant, discordant int
+	for i := range ranks {
+		for j := i + 1; j < len(ranks); j++ {
+			if ranks[i] < ranks[j] {
+				concordant++
+			} else {
+				discordant++
+			}
+		}
+	}
+	pairs := len(ranks) * (len(ranks) - 1) / 2
+
+	return overlap, float64(concordant-discordant) / float64(pairs), true
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestCompareRankings(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        []string
+		k           int
+		overlap     float64
+		correlation float64
+		correlated  bool
+	}{
+		{name: "same ranking", a: []string{"1", "2", "3"}, b: []string{"1", "2", "3"}, overlap: 1, correlation: 1, correlated: true},
+		{name: "reversed", a: []string{"1", "2", "3"}, b: []string{"3", "2", "1"}, overlap: 1, correlation: -1, correlated: true},
+		{name: "partial overlap", a: []string{"1", "2", "3", "4"}, b: []string{"2", "1", "5", "6"}, overlap: 0.5, correlation: -1, correlated: true},
+		{name: "top k only", a: []string{"1", "2", "3"}, b: []string{"1", "2", "4"}, k: 2, overlap: 1, correlation: 1, correlated: true},
+		{name: "one in common", a: []string{"1", "2"}, b: []string{"1", "3"}, overlap: 0.5},
+		{name: "shorter shadow", a: []string{"1", "2", "3", "4"}, b: []string{"1", "2"}, overlap: 0.5, correlation: 1, correlated: true},
+		{name: "both empty", overlap: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlap, correlation, correlated := compareRankings(tt.a, tt.b, tt.k)
+			assert.InDelta(t, tt.overlap, overlap, 1e-9)
+			assert.InDelta(t, tt.correlation, correlation, 1e-9)
+			assert.Equal(t, tt.correlated, correlated)
+		})
+	}
+}
+
+func TestSearch_ShadowsWithAlternateRanking(t *testing.T) {
+	repo := &fakeRepo{}
+	compared := make(chan domain.ShadowSearchCompared, 1)
+	search := NewSearchService(repo, zap.NewNop(), WithShadowTraffic(
+		ShadowConfig{Percent: 100, Scoring: domain.ScoringText, K: 10},
+		func(_ context.Context, event domain.Event) { compared <- event.(domain.ShadowSearchCompared) },
+	))
+
+	_, err := search.Search(context.Background(), domain.DefaultSearchParams())
+	require.NoError(t, err)
+	params := domain.DefaultSearchParams()
+	params.Query = "go"
+	_, err = search.Search(context.Background(), params)
+	require.NoError(t, err)
+
+	event := <-compared
+	assert.Equal(t, "text/postgres", event.Variant)
+	assert.NoError(t, event.Error)
+	assert.InDelta(t, 1, event.Overlap, 1e-9, "both found nothing")
+	require.Len(t, repo.searches, 3, "only the search with a query is shadowed")
+	assert.Equal(t, domain.ScoringHybrid, repo.searches[1].Scoring, "the served search keeps its ranking")
+	assert.Equal(t, domain.ScoringText, repo.searches[2].Scoring)
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// SnapshotService writes the content corpus to snapshots kept in a store, and restores
+// them into an empty database, for disaster recovery and cloning environments.
+//
+// Snapshots are gzip-compressed NDJSON: a header line with the format version and the
+// provider sync times, a line per content, and an end line counting them, so a
+// truncated snapshot is never restored.
+//
+// The admin API runs jobs in the background, one at a time per instance, like search
+// index rebuilds; the CLI runs them in the foreground.
+type SnapshotService struct {
+	repo      domain.SnapshotRepository
+	store     domain.SnapshotStore
+	cache     domain.Cache    // Optional, cleared after a restore (can be nil)
+	reindex   *ReindexService // Optional, started after a restore (can be nil)
+	batchSize int
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu     sync.Mutex
+	status domain.SnapshotStatus
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSnapshotService creates a new SnapshotService reading and writing repo batchSize
+// contents at a time. After a restore, it clears cache and rebuilds the search index
+// with reindex, when set.
+func NewSnapshotService(
+	repo domain.SnapshotRepository,
+	store domain.SnapshotStore,
+	cache domain.Cache,
+	reindex *ReindexService,
+	batchSize int,
+	logger *zap.Logger,
+) *SnapshotService {
+	return &SnapshotService{
+		repo:      repo,
+		store:     store,
+		cache:     cache,
+		reindex:   reindex,
+		batchSize: batchSize,
+		logger:    logger,
+		now:       time.Now,
+		status:    domain.SnapshotStatus{State: domain.SnapshotIdle},
+	}
+}
+
+// List returns the stored snapshots, newest first.
+func (s *SnapshotService) List(ctx context.Context) ([]domain.SnapshotInfo, error) {
+	return s.store.List(ctx)
+}
+
+// Status returns the progress of the last job.
+func (s *SnapshotService) Status() domain.SnapshotStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// StartCreate begins writing a snapshot in the background and returns the job status.
+// Returns ErrBusy while another job is running.
+func (s *SnapshotService) StartCreate() (domain.SnapshotStatus, error) {
+	name := s.newName()
+	ctx, err := s.begin(context.Background(), domain.SnapshotCreate, name)
+	if err != nil {
+		return s.Status(), err
+	}
+
+	go func() {
+		defer s.wg.Done()
+		s.finish(s.create(ctx, name))
+	}()
+
+	return s.Status(), nil
+}
+
+// StartRestore begins restoring the snapshot name in the background and returns the
+// job status. Returns ErrNotFound if there is no such snapshot, and ErrBusy while
+// another job is running.
+func (s *SnapshotService) StartRestore(ctx context.Context, name string) (domain.SnapshotStatus, error) {
+	// The snapshot is read after the request ends; Stop ends the job by canceling the
+	// restore, which closes src
+	src, err := s.store.Get(context.WithoutCancel(ctx), name)
+	if err != nil {
+		return s.Status(), err
+	}
+	jobCtx, err := s.begin(context.Background(), domain.SnapshotRestore, name)
+	if err != nil {
+		_ = src.Close()
+
+		return s.Status(), err
+	}
+
+	go func() {
+		defer s.wg.Done()
+		s.finish(s.restore(jobCtx, src))
+	}()
+
+	return s.Status(), nil
+}
+
+// Create writes a snapshot of the corpus and returns its status once stored.
+func (s *SnapshotService) Create(ctx context.Context) (domain.SnapshotStatus, error) {
+	name := s.newName()
+	ctx, err := s.begin(ctx, domain.SnapshotCreate, name)
+	if err != nil {
+		return s.Status(), err
+	}
+	defer s.wg.Done()
+	err = s.create(ctx, name)
+	s.finish(err)
+
+	return s.Status(), err
+}
+
+// Restore restores the snapshot name into the empty database and returns its status
+// once committed.
+func (s *SnapshotService) Restore(ctx context.Context, name string) (domain.SnapshotStatus, error) {
+	src, err := s.store.Get(ctx, name)
+	if err != nil {
+		return s.Status(), err
+	}
+	ctx, err = s.begin(ctx, domain.SnapshotRestore, name)
+	if err != nil {
+		_ = src.Close()
+
+		return s.Status(), err
+	}
+	defer s.wg.Done()
+	err = s.restore(ctx, src)
+	s.finish(err)
+
+	return s.Status(), err
+}
+
+// Stop cancels the running job, if any, and waits for it to stop.
+func (s *SnapshotService) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// newName returns the name of a snapshot created now.
+func (s *SnapshotService) newName() string {
+	return "contents-" + s.now().UTC().Format("20060102T150405Z") + ".ndjson.gz"
+}
+
+// begin marks a job on the snapshot name as running, unless one already is, and
+// returns its context: ctx, also canceled by Stop.
+func (s *SnapshotService) begin(ctx context.Context, op domain.SnapshotOperation, name string) (context.Context, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status.State == domain.SnapshotRunning {
+		return nil, fmt.Errorf("%w: snapshot %s of %s in progress", domain.ErrBusy, s.status.Operation, s.status.Name)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.status = domain.SnapshotStatus{Operation: op, State: domain.SnapshotRunning, Name: name, StartedAt: s.now().UTC()}
+	s.wg.Add(1)
+
+	s.logger.Info("snapshot job started", zap.String("operation", string(op)), zap.String("snapshot", name))
+
+	return ctx, nil
+}
+
+// finish records the outcome of the running job.
+func (s *SnapshotService) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cancel()
+	s.cancel = nil
+	s.status.FinishedAt = s.now().UTC()
+	log := s.logger.With(zap.String("operation", string(s.status.Operation)), zap.String("snapshot", s.status.Name))
+	if err != nil {
+		s.status.State, s.status.Error = domain.SnapshotFailed, err.Error()
+		log.Error("snapshot job failed", zap.Int64("contents", s.status.Contents), zap.Error(err))
+
+		return
+	}
+
+	s.status.State = domain.SnapshotCompleted
+	log.Info("snapshot job completed",
+		zap.Int64("contents", s.status.Contents),
+		zap.Duration("duration", s.status.FinishedAt.Sub(s.status.StartedAt)),
+	)
+}
+
+// progress adds n contents to those written or restored by the running job.
+func (s *SnapshotService) progress(n int) {
+	s.mu.Lock()
+	s.status.Contents += int64(n)
+	s.mu.Unlock()
+}
+
+// create dumps the corpus into the snapshot name, streamed to the store.
+func (s *SnapshotService) create(ctx context.Context, name string) error {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pw.CloseWithError(s.dump(ctx, pw))
+	}()
+
+	err := s.store.Put(ctx, name, pr)
+	// Unblocks the dump if the store gave up reading
+	_ = pr.CloseWithError(errors.New("snapshot store stopped reading"))
+	<-done
+
+	return err
+}
+
+// dump writes the snapshot of the corpus to w.
+func (s *SnapshotService) dump(ctx context.Context, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	sw := &snapshotWriter{enc: json.NewEncoder(gz), createdAt: s.now().UTC(), progress: s.progress}
+	if err := s.repo.DumpSnapshot(ctx, s.batchSize, sw); err != nil {
+		return err
+	}
+	if err := sw.enc.Encode(snapshotLine{End: &snapshotEnd{Contents: sw.count}}); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// restore writes the snapshot read from src into the database, then clears the cache
+// and rebuilds the search index.
+func (s *SnapshotService) restore(ctx context.Context, src io.ReadCloser) error {
+	defer func() { _ = src.Close() }()
+
+	gz, err := gzip.NewReader(src)
+	if err != nil {
+		return fmt.Errorf("reading snapshot: %w", err)
+	}
+	sr := &snapshotReader{dec: json.NewDecoder(gz), batchSize: s.batchSize, progress: s.progress}
+	if _, err := s.repo.RestoreSnapshot(ctx, sr); err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Clear(ctx); err != nil {
+			s.logger.Warn("failed to clear the cache after a snapshot restore", zap.Error(err))
+		}
+	}
+	if s.reindex != nil {
+		if _, err := s.reindex.Start(); err != nil {
+			s.logger.Warn("failed to rebuild the search index after a snapshot restore", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// snapshotLine is a line of a snapshot; exactly one field is set.
+type snapshotLine struct {
+	Header  *snapshotHeader         `json:"header,omitempty"`
+	Content *domain.SnapshotContent `json:"content,omitempty"`
+	End     *snapshotEnd            `json:"end,omitempty"`
+}
+
+// snapshotHeader is the first line of a snapshot.
+type snapshotHeader struct {
+	Version       int                  `json:"version"`
+	CreatedAt     time.Time            `json:"created_at"`
+	ProviderSyncs map[string]time.Time `json:"provider_syncs"`
+}
+
+// snapshotEnd is the last line of a snapshot.
+type snapshotEnd struct {
+	Contents int64 `json:"contents"`
+}
+
+// snapshotWriter implements domain.SnapshotWriter, encoding the corpus as snapshot lines.
+type snapshotWriter struct {
+	enc       *json.Encoder
+	createdAt time.Time
+	count     int64
+	progress  func(n int)
+}
+
+// WriteSyncTimes writes the header.
+func (w *snapshotWriter) WriteSyncTimes(times map[string]time.Time) error {
+	return w.enc.Encode(snapshotLine{Header: &snapshotHeader{
+		Version:       domain.SnapshotFormatVersion,
+		CreatedAt:     w.createdAt,
+		ProviderSyncs: times,
+	}})
+}
+
+// WriteContents writes a line per content.
+func (w *snapshotWriter) WriteContents(batch []*domain.SnapshotContent) error {
+	for _, c := range batch {
+		if err := w.enc.Encode(snapshotLine{Content: c}); err != nil {
+			return err
+		}
+	}
+	w.count += int64(len(batch))
+	w.progress(len(batch))
+
+	return nil
+}
+
+// snapshotReader implements domain.SnapshotReader, decoding snapshot lines.
+type snapshotReader struct {
+	dec       *json.Decoder
+	batchSize int
+	count     int64
+	ended     bool
+	progress  func(n int)
+}
+
+// ReadSyncTimes reads the header, checking the format version.
+func (r *snapshotReader) ReadSyncTimes() (map[string]time.Time, error) {
+	var line snapshotLine
+	if err := r.dec.Decode(&line); err != nil {
+		return nil, fmt.Errorf("reading snapshot header: %w", err)
+	}
+	if line.Header == nil {
+		return nil, errors.New("reading snapshot header: not a snapshot")
+	}
+	if line.Header.Version != domain.SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (want %d)", line.Header.Version, domain.SnapshotFormatVersion)
+	}
+
+	return line.Header.ProviderSyncs, nil
+}
+
+// ReadContents reads up to batchSize contents. The end line must count every content
+// read before it.
+func (r *snapshotReader) ReadContents() ([]*domain.SnapshotContent, error) {
+	var batch []*domain.SnapshotContent
+	for !r.ended && len(batch) < r.batchSize {
+		var line snapshotLine
+		err := r.dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("snapshot is truncated: no end line")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading snapshot: %w", err)
+		}
+
+		switch {
+		case line.Content != nil:
+			batch = append(batch, line.Content)
+		case line.End != nil:
+			r.ended = true
+			if total := r.count + int64(len(batch)); line.End.Contents != total {
+				return nil, fmt.Errorf("snapshot is corrupt: %d contents read, %d written", total, line.End.Contents)
+			}
+		default:
+			return nil, errors.New("snapshot is corrupt: unexpected line")
+		}
+	}
+	r.count += int64(len(batch))
+	if len(batch) > 0 {
+		r.progress(len(batch))
+	}
+
+	return batch, nil
+}
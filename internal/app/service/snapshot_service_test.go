@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/snapshot"
+)
+
+// fakeSnapshotRepo keeps the corpus in memory; restores only keep it if they complete.
+type fakeSnapshotRepo struct {
+	times    map[string]time.Time
+	contents []*domain.SnapshotContent
+}
+
+func (r *fakeSnapshotRepo) DumpSnapshot(_ context.Context, batchSize int, w domain.SnapshotWriter) error {
+	if err := w.WriteSyncTimes(r.times); err != nil {
+		return err
+	}
+	for start := 0; start < len(r.contents); start += batchSize {
+		if err := w.WriteContents(r.contents[start:min(start+batchSize, len(r.contents))]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *fakeSnapshotRepo) RestoreSnapshot(_ context.Context, rd domain.SnapshotReader) (int64, error) {
+	if len(r.contents) > 0 {
+		return 0, domain.ErrConflict
+	}
+	times, err := rd.ReadSyncTimes()
+	if err != nil {
+		return 0, err
+	}
+	var contents []*domain.SnapshotContent
+	for {
+		batch, err := rd.ReadContents()
+		if err != nil {
+			return 0, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		contents = append(contents, batch...)
+	}
+	r.times, r.contents = times, contents
+
+	return int64(len(contents)), nil
+}
+
+func TestSnapshotService_CreateAndRestore(t *testing.T) {
+	synced := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakeSnapshotRepo{times: map[string]time.Time{"provider_a": synced}}
+	for _, id := range []string{"id-1", "id-2", "id-3"} {
+		source.contents = append(source.contents, &domain.SnapshotContent{
+			Content:  domain.Content{ID: id, ProviderID: "provider_a", ExternalID: id, Title: "Go", Score: 12.5, Blocked: id == "id-2"},
+			CTRBoost: 2.5,
+		})
+	}
+	dir := t.TempDir()
+	store, err := snapshot.NewFileStore(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, err := NewSnapshotService(source, store, nil, nil, 2, zap.NewNop()).Create(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SnapshotCompleted, created.State)
+	assert.Equal(t, int64(3), created.Contents)
+
+	snapshots, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, created.Name, snapshots[0].Name)
+
+	target := &fakeSnapshotRepo{}
+	restore := NewSnapshotService(target, store, nil, nil, 2, zap.NewNop())
+	restored, err := restore.Restore(ctx, created.Name)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), restored.Contents)
+	assert.Equal(t, source.times, target.times)
+	assert.Equal(t, source.contents, target.contents)
+
+	_, err = restore.Restore(ctx, created.Name)
+	require.ErrorIs(t, err, domain.ErrConflict, "only empty databases are restored into")
+	_, err = restore.Restore(ctx, "missing.ndjson.gz")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSnapshotService_RejectsTruncatedSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	store, err := snapshot.NewFileStore(dir)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = io.WriteString(gz, `{"header":{"version":1,"created_at":"2026-03-01T12:00:00Z","provider_syncs":{}}}`+"\n"+
+		`{"content":{"id":"id-1","title":"Go"}}`+"\n")
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "truncated.ndjson.gz"), buf.Bytes(), 0o600))
+
+	target := &fakeSnapshotRepo{}
+	status, err := NewSnapshotService(target, store, nil, nil, 10, zap.NewNop()).Restore(context.Background(), "truncated.ndjson.gz")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "truncated")
+	assert.Equal(t, domain.SnapshotFailed, status.State)
+	assert.Empty(t, target.contents, "nothing is restored")
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+const (
+	// SyncHistoryRetention is how long sync runs are kept; older runs are removed
+	// whenever new ones are recorded.
+	SyncHistoryRetention = 90 * 24 * time.Hour
+
+	// syncHistoryWriteTimeout bounds recording the runs of a sync.
+	syncHistoryWriteTimeout = 5 * time.Second
+)
+
+// SyncHistoryService records provider syncs and serves the time series charted on
+// the dashboard: content growth, contents synced per run and sync failures.
+type SyncHistoryService struct {
+	runs     domain.SyncRunRepository
+	contents domain.ContentRepository
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewSyncHistoryService creates a new SyncHistoryService.
+func NewSyncHistoryService(runs domain.SyncRunRepository, contents domain.ContentRepository, logger *zap.Logger) *SyncHistoryService {
+	return &SyncHistoryService{
+		runs:     runs,
+		contents: contents,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// HandleEvent is an event bus handler recording the runs of each completed sync.
+func (s *SyncHistoryService) HandleEvent(ctx context.Context, event domain.Event) {
+	e, ok := event.(domain.SyncCompleted)
+	if !ok {
+		return
+	}
+
+	// The sync's context may be cancelled once it returns; recording shouldn't be
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), syncHistoryWriteTimeout)
+	defer cancel()
+
+	if err := s.Record(ctx, e.Results); err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to record sync history", zap.Error(err))
+	}
+}
+
+// Record stores the results of a sync finished now and removes the runs older than
+// SyncHistoryRetention. Failed runs count no content, except those interrupted by
+// shutdown (domain.ErrInterrupted), which count the contents stored until then.
+func (s *SyncHistoryService) Record(ctx context.Context, results []SyncResult) error {
+	now := s.now().UTC()
+
+	runs := make([]*domain.SyncRun, len(results))
+	for i, r := range results {
+		run := &domain.SyncRun{Provider: r.Provider, Count: r.Count, Duration: r.Duration, FinishedAt: now}
+		if r.Error != nil {
+			// A run interrupted by shutdown keeps what it stored
+			if !errors.Is(r.Error, domain.ErrInterrupted) {
+				run.Count = 0
+			}
+			run.Error = r.Error.Error()
+		}
+		runs[i] = run
+	}
+
+	if err := s.runs.CreateBatch(ctx, runs); err != nil {
+		return fmt.Errorf("recording sync runs: %w", err)
+	}
+
+	if _, err := s.runs.DeleteBefore(ctx, now.Add(-SyncHistoryRetention)); err != nil {
+		return fmt.Errorf("pruning sync runs: %w", err)
+	}
+
+	return nil
+}
+
+// ContentGrowth returns the number of contents at the end of each of the last days
+// UTC days, today included. Contents are counted by creation date, so deleted ones
+// don't show in past days either.
+func (s *SyncHistoryService) ContentGrowth(ctx context.Context, days int) ([]domain.DailyCount, error) {
+	from, to := s.dayRange(days)
+
+	before, daily, err := s.contents.CreatedPerDay(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CumulativeDaily(before, daily, from, to), nil
+}
+
+// SyncRuns returns the runs of the last days UTC days, today included, oldest first.
+func (s *SyncHistoryService) SyncRuns(ctx context.Context, days int) ([]*domain.SyncRun, error) {
+	from, _ := s.dayRange(days)
+
+	return s.runs.ListSince(ctx, from)
+}
+
+// SyncFailures returns the number of failed runs per provider on each of the last
+// days UTC days, today included. Providers without failures are left out.
+func (s *SyncHistoryService) SyncFailures(ctx context.Context, days int) ([]time.Time, []domain.ProviderSeries, error) {
+	from, to := s.dayRange(days)
+
+	counts, err := s.runs.FailuresPerDay(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	dayList, series := domain.DailySeries(counts, from, to)
+
+	return dayList, series, nil
+}
+
+// dayRange returns midnight UTC of the first of the last days days and of today.
+func (s *SyncHistoryService) dayRange(days int) (time.Time, time.Time) {
+	today := domain.TruncateDay(s.now())
+
+	return today.AddDate(0, 0, 1-days), today
+}
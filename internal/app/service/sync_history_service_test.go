@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeSyncRunRepo is an in-memory SyncRunRepository for tests.
+type fakeSyncRunRepo struct {
+	runs          []*domain.SyncRun
+	deletedBefore time.Time
+}
+
+func (r *fakeSyncRunRepo) CreateBatch(_ context.Context, runs []*domain.SyncRun) error {
+	r.runs = append(r.runs, runs...)
+
+	return nil
+}
+
+func (r *fakeSyncRunRepo) ListSince(_ context.Context, since time.Time) ([]*domain.SyncRun, error) {
+	var runs []*domain.SyncRun
+	for _, run := range r.runs {
+		if !run.FinishedAt.Before(since) {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+func (r *fakeSyncRunRepo) FailuresPerDay(_ context.Context, _ time.Time) ([]domain.ProviderDailyCount, error) {
+	return nil, nil
+}
+
+func (r *fakeSyncRunRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.deletedBefore = before
+
+	return 0, nil
+}
+
+// fakeGrowthRepo is a ContentRepository with fixed creation counts.
+type fakeGrowthRepo struct {
+	domain.ContentRepository
+	before int64
+	daily  []domain.DailyCount
+	since  time.Time
+}
+
+func (r *fakeGrowthRepo) CreatedPerDay(_ context.Context, since time.Time) (int64, []domain.DailyCount, error) {
+	r.since = since
+
+	return r.before, r.daily, nil
+}
+
+func TestSyncHistoryService_RecordsCompletedSyncs(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	runs := &fakeSyncRunRepo{}
+	svc := NewSyncHistoryService(runs, nil, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	svc.HandleEvent(context.Background(), domain.SyncCompleted{Results: []SyncResult{
+		{Provider: "provider_a", Count: 12, Duration: time.Second},
+		{Provider: "provider_b", Count: 3, Error: errors.New("timeout")},
+	}})
+
+	require.Len(t, runs.runs, 2)
+	assert.Equal(t, 12, runs.runs[0].Count)
+	assert.Equal(t, now, runs.runs[0].FinishedAt)
+	assert.Equal(t, 0, runs.runs[1].Count, "failed runs synced nothing")
+	assert.Equal(t, "timeout", runs.runs[1].Error)
+	assert.Equal(t, now.Add(-SyncHistoryRetention), runs.deletedBefore)
+
+	// Runs finished today are charted
+	recent, err := svc.SyncRuns(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+}
+
+func TestSyncHistoryService_ContentGrowth(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	contents := &fakeGrowthRepo{before: 100, daily: []domain.DailyCount{
+		{Day: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), Count: 5},
+	}}
+	svc := NewSyncHistoryService(&fakeSyncRunRepo{}, contents, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	points, err := svc.ContentGrowth(context.Background(), 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), contents.since)
+	require.Len(t, points, 3)
+	assert.Equal(t, []int64{100, 105, 105}, []int64{points[0].Count, points[1].Count, points[2].Count})
+}
+
+func TestSyncHistoryService_RecordsInterruptedSyncsWithTheirCount(t *testing.T) {
+	runs := &fakeSyncRunRepo{}
+	svc := NewSyncHistoryService(runs, nil, zap.NewNop())
+
+	interrupted := fmt.Errorf("%w: %w", domain.ErrInterrupted, context.Canceled)
+	require.NoError(t, svc.Record(context.Background(), []SyncResult{{Provider: "provider_a", Count: 2000, Error: interrupted}}))
+
+	require.Len(t, runs.runs, 1)
+	assert.Equal(t, 2000, runs.runs[0].Count, "contents stored before the interruption are kept")
+	assert.Equal(t, "interrupted by shutdown: context canceled", runs.runs[0].Error)
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// defaultPipelineBatchSize is the number of contents upserted at a time when
+// SyncPipeline.BatchSize isn't set.
+const defaultPipelineBatchSize = 1000
+
+// SyncPipeline bounds the pipeline syncing a streaming provider (see
+// domain.ProviderStreamer).
+type SyncPipeline struct {
+	Buffer    int // Items buffered between two stages
+	BatchSize int // Contents upserted at a time (0 uses 1000)
+}
+
+// Stages of the sync pipeline, in order.
+const (
+	stageFetch    = "fetch"
+	stageConvert  = "convert"
+	stageValidate = "validate"
+	stageUpsert   = "upsert"
+)
+
+// streamAndStore syncs a streaming provider through a pipeline of stages connected by
+// buffered channels: fetch decodes the feed, convert turns its items into contents
+// (rejecting those breaking the provider's schema), validate drops invalid contents and scores the others, and upsert stores them
+// BatchSize at a time. A slow stage fills the buffer ahead of it, which blocks the
+// stages before it down to the decoding of the response, so a sync holds a few
+// buffers and a batch of items whatever the size of the feed.
+//
+// Batches are stored, and their created or changed contents announced with
+// ContentUpserted, as they fill: the batches stored before a failure stay stored,
+// and are counted in the result.
+func (s *SyncService) streamAndStore(ctx context.Context, provider domain.Provider, streamer domain.ProviderStreamer) SyncResult {
+	start := time.Now()
+	result := SyncResult{Provider: provider.Name()}
+	log := logger.FromContext(ctx, s.logger).With(zap.String("provider", provider.Name()))
+	log.Debug("syncing provider")
+
+	// Failures are classified against the caller's context, which the first failure
+	// doesn't cancel
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make(chan domain.ProviderItem, s.pipeline.Buffer)
+	converted := make(chan *domain.Content, s.pipeline.Buffer)
+	valid := make(chan *domain.Content, s.pipeline.Buffer)
+
+	// The first stage to fail stops the others; their errors follow from it
+	var (
+		mu         sync.Mutex
+		failed     string
+		firstError error
+	)
+	stages := make([]domain.SyncStage, 4)
+	var wg sync.WaitGroup
+	run := func(i int, fn func() (domain.SyncStage, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			stage, err := fn()
+			stages[i] = stage
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			if firstError == nil {
+				failed, firstError = stage.Name, err
+			}
+			mu.Unlock()
+			cancel()
+		}()
+	}
+
+	run(0, func() (domain.SyncStage, error) { return fetchStage(ctx, streamer, items) })
+	run(1, func() (domain.SyncStage, error) {
+		return s.convertStage(ctx, log, provider.Name(), items, converted, &result.Rejected)
+	})
+	run(2, func() (domain.SyncStage, error) { return s.validateStage(ctx, log, converted, valid) })
+	run(3, func() (domain.SyncStage, error) {
+		return s.upsertStage(ctx, log, provider.Name(), valid, &result.Changed)
+	})
+	wg.Wait()
+
+	result.Stages = stages
+	result.Count = stages[3].Items
+	result.Duration = time.Since(start)
+	if result.Rejected > 0 {
+		log.Warn("rejected items breaking the provider's schema, kept as dead letters",
+			zap.Int("rejected", result.Rejected))
+	}
+	for _, stage := range stages {
+		log.Debug("sync stage finished",
+			zap.String("stage", stage.Name),
+			zap.Int("items", stage.Items),
+			zap.Duration("busy", stage.Busy),
+			zap.Duration("blocked", stage.Blocked),
+		)
+	}
+
+	if firstError != nil {
+		result.Error = firstError
+		result.ErrorKind = syncErrorKind(parent, domain.SyncErrorProvider)
+		if failed == stageUpsert {
+			result.ErrorKind = syncErrorKind(parent, domain.SyncErrorDatabase)
+		}
+		log.Error("provider sync failed",
+			zap.String("stage", failed),
+			zap.String("error_kind", string(result.ErrorKind)),
+			zap.Int("stored", result.Count),
+			zap.Error(firstError),
+		)
+
+		return result
+	}
+
+	log.Info("provider sync completed",
+		zap.Int("count", result.Count),
+		zap.Int("changed", result.Changed),
+		zap.Duration("duration", result.Duration),
+	)
+
+	return result
+}
+
+// fetchStage decodes the feed of streamer into out.
+func fetchStage(ctx context.Context, streamer domain.ProviderStreamer, out chan<- domain.ProviderItem) (domain.SyncStage, error) {
+	defer close(out)
+	clock := newStageClock(stageFetch)
+
+	err := streamer.Stream(ctx, func(item domain.ProviderItem) error {
+		return send(ctx, clock, out, item)
+	})
+
+	return clock.stop(), err
+}
+
+// convertStage converts the items of in to contents of the provider. Items breaking
+// the provider's schema (see domain.SchemaCheckedItem) are counted in rejected and
+// published with ItemsRejected instead, BatchSize at a time.
+func (s *SyncService) convertStage(
+	ctx context.Context,
+	log *zap.Logger,
+	provider string,
+	in <-chan domain.ProviderItem,
+	out chan<- *domain.Content,
+	rejected *int,
+) (domain.SyncStage, error) {
+	defer close(out)
+	clock := newStageClock(stageConvert)
+	batchSize := s.pipelineBatchSize()
+
+	var letters []*domain.DeadLetter
+	flush := func() {
+		if len(letters) > 0 {
+			s.publish(ctx, domain.ItemsRejected{Provider: provider, Letters: letters})
+			letters = nil
+		}
+	}
+	// Items rejected before a failure are kept too
+	defer flush()
+
+	for {
+		item, ok := receive(clock, in)
+		if !ok {
+			return clock.stop(), nil
+		}
+		content := item.ToDomain(provider)
+		if checked, ok := item.(domain.SchemaCheckedItem); ok {
+			if errs := checked.SchemaErrors(); len(errs) > 0 {
+				log.Debug("rejecting item breaking the provider's schema",
+					zap.String("external_id", content.ExternalID), zap.Error(errs))
+				*rejected++
+				letters = append(letters, &domain.DeadLetter{
+					Provider:   provider,
+					ExternalID: content.ExternalID,
+					Payload:    string(checked.Payload()),
+					Errors:     errs,
+				})
+				if len(letters) >= batchSize {
+					flush()
+				}
+
+				continue
+			}
+		}
+		if err := send(ctx, clock, out, content); err != nil {
+			return clock.stop(), err
+		}
+	}
+}
+
+// validateStage passes on the contents of in that satisfy Content.Validate, scored,
+// and logs the others.
+func (s *SyncService) validateStage(ctx context.Context, log *zap.Logger, in <-chan *domain.Content, out chan<- *domain.Content) (domain.SyncStage, error) {
+	defer close(out)
+	clock := newStageClock(stageValidate)
+
+	for {
+		content, ok := receive(clock, in)
+		if !ok {
+			return clock.stop(), nil
+		}
+		if err := content.Validate(); err != nil {
+			log.Warn("skipping invalid content", zap.String("external_id", content.ExternalID), zap.Error(err))
+
+			continue
+		}
+		content.Score = domain.CalculateScore(content)
+		if err := send(ctx, clock, out, content); err != nil {
+			return clock.stop(), err
+		}
+	}
+}
+
+// upsertStage applies the future publish policy to the contents of in and stores them
+// BatchSize at a time, counting those created or changed in changed.
+func (s *SyncService) upsertStage(
+	ctx context.Context,
+	log *zap.Logger,
+	provider string,
+	in <-chan *domain.Content,
+	changed *int,
+) (domain.SyncStage, error) {
+	clock := newStageClock(stageUpsert)
+	batchSize := s.pipelineBatchSize()
+
+	skipped := 0
+	batch := make([]*domain.Content, 0, batchSize)
+	flush := func() error {
+		received := len(batch)
+		contents := s.futurePublish.Apply(batch, time.Now().UTC())
+		skipped += received - len(contents)
+		// The published event keeps the batch
+		batch = make([]*domain.Content, 0, batchSize)
+		if len(contents) == 0 {
+			return nil
+		}
+
+		written, err := s.upsert(ctx, contents)
+		if err != nil {
+			return err
+		}
+		clock.stage.Items += len(contents)
+		*changed += len(written)
+		if len(written) > 0 {
+			s.publish(ctx, domain.ContentUpserted{Provider: provider, Contents: written})
+		}
+
+		return nil
+	}
+
+	for {
+		content, ok := receive(clock, in)
+		if !ok {
+			break
+		}
+		batch = append(batch, content)
+		if len(batch) < batchSize {
+			continue
+		}
+		if err := flush(); err != nil {
+			return clock.stop(), err
+		}
+	}
+
+	// Input ends early when another stage failed
+	if err := ctx.Err(); err != nil {
+		return clock.stop(), err
+	}
+	if err := flush(); err != nil {
+		return clock.stop(), err
+	}
+	if skipped > 0 {
+		log.Info("skipped contents published in the future", zap.Int("count", skipped))
+	}
+
+	return clock.stop(), nil
+}
+
+// pipelineBatchSize returns the number of contents upserted at a time.
+func (s *SyncService) pipelineBatchSize() int {
+	if s.pipeline.BatchSize <= 0 {
+		return defaultPipelineBatchSize
+	}
+
+	return s.pipeline.BatchSize
+}
+
+// stageClock measures the work of a pipeline stage: the time it ran, minus the time
+// it waited for input and for the next stage to take its output.
+type stageClock struct {
+	stage  domain.SyncStage
+	start  time.Time
+	waited time.Duration
+}
+
+// newStageClock starts measuring the stage name.
+func newStageClock(name string) *stageClock {
+	return &stageClock{stage: domain.SyncStage{Name: name}, start: time.Now()}
+}
+
+// stop returns the work of the stage so far.
+func (c *stageClock) stop() domain.SyncStage {
+	c.stage.Busy = time.Since(c.start) - c.waited - c.stage.Blocked
+
+	return c.stage
+}
+
+// receive takes the next input of the stage from in; false once in is closed.
+func receive[T any](clock *stageClock, in <-chan T) (T, bool) {
+	start := time.Now()
+	v, ok := <-in
+	clock.waited += time.Since(start)
+
+	return v, ok
+}
+
+// send hands v to the next stage through out, unless ctx ends first.
+func send[T any](ctx context.Context, clock *stageClock, out chan<- T, v T) error {
+	start := time.Now()
+	defer func() { clock.stage.Blocked += time.Since(start) }()
+
+	select {
+	case out <- v:
+		clock.stage.Items++
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeItem is a domain.SchemaCheckedItem converted to a content with its external ID.
+type fakeItem struct {
+	id       string
+	invalid  bool
+	rejected bool // Breaks the provider's schema
+}
+
+func (i fakeItem) SchemaErrors() domain.ValidationErrors {
+	if !i.rejected {
+		return nil
+	}
+
+	return domain.ValidationErrors{{Field: "views", Tag: "unknown", Message: "views is not in the schema"}}
+}
+
+func (i fakeItem) Payload() []byte {
+	if !i.rejected {
+		return nil
+	}
+
+	return []byte(`{"id": "` + i.id + `", "views": 1}`)
+}
+
+func (i fakeItem) ToDomain(providerID string) *domain.Content {
+	c := &domain.Content{
+		ProviderID:  providerID,
+		ExternalID:  i.id,
+		Title:       "Title " + i.id,
+		Type:        domain.ContentTypeArticle,
+		PublishedAt: time.Now().Add(-time.Hour),
+	}
+	if i.invalid {
+		c.Type = "unknown"
+	}
+
+	return c
+}
+
+// fakeStreamer streams items, or endless items when items is nil, counting those emitted.
+type fakeStreamer struct {
+	fakeProvider
+	items   []fakeItem
+	emitted atomic.Int64
+}
+
+func (p *fakeStreamer) Stream(_ context.Context, emit func(domain.ProviderItem) error) error {
+	for i := 0; p.items == nil || i < len(p.items); i++ {
+		item := fakeItem{id: fmt.Sprintf("item-%d", i)}
+		if p.items != nil {
+			item = p.items[i]
+		}
+		if err := emit(item); err != nil {
+			return err
+		}
+		p.emitted.Add(1)
+	}
+
+	return nil
+}
+
+// batchRepo records the batches upserted, waiting for release when set, or failing with err.
+type batchRepo struct {
+	domain.ContentRepository
+	batches [][]string
+	release chan struct{}
+	err     error
+}
+
+func (r *batchRepo) BulkUpsert(ctx context.Context, contents []*domain.Content) error {
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	ids := make([]string, len(contents))
+	for i, c := range contents {
+		ids[i] = c.ExternalID
+	}
+	r.batches = append(r.batches, ids)
+
+	return nil
+}
+
+func TestSyncService_StreamsInBatches(t *testing.T) {
+	provider := &fakeStreamer{items: []fakeItem{{id: "a"}, {id: "b", invalid: true}, {id: "c"}, {id: "d"}, {id: "e"}}}
+	repo := &batchRepo{}
+	events := &recordingPublisher{}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithEvents(events), WithPipeline(SyncPipeline{Buffer: 1, BatchSize: 2}))
+
+	result, err := svc.SyncProvider(context.Background(), "fake")
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"a", "c"}, {"d", "e"}}, repo.batches, "invalid contents are skipped")
+	assert.Equal(t, 4, result.Count)
+
+	names := make([]string, len(result.Stages))
+	for i, stage := range result.Stages {
+		names[i] = stage.Name
+	}
+	assert.Equal(t, []string{"fetch", "convert", "validate", "upsert"}, names)
+	assert.Equal(t, 5, result.Stages[0].Items)
+	assert.Equal(t, 5, result.Stages[1].Items)
+	assert.Equal(t, 4, result.Stages[2].Items)
+	assert.Equal(t, 4, result.Stages[3].Items)
+
+	var upserted []domain.ContentUpserted
+	for _, e := range events.published() {
+		if u, ok := e.(domain.ContentUpserted); ok {
+			upserted = append(upserted, u)
+		}
+	}
+	require.Len(t, upserted, 2, "each batch is announced once stored")
+	assert.Equal(t, "a", upserted[0].Contents[0].ExternalID)
+	assert.Positive(t, upserted[0].Contents[0].Score)
+}
+
+func TestSyncService_KeepsRejectedItemsAsDeadLetters(t *testing.T) {
+	provider := &fakeStreamer{items: []fakeItem{{id: "a"}, {id: "b", rejected: true}, {id: "c", rejected: true}, {id: "d"}, {id: "e", rejected: true}}}
+	repo := &batchRepo{}
+	events := &recordingPublisher{}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithEvents(events), WithPipeline(SyncPipeline{Buffer: 1, BatchSize: 2}))
+
+	result, err := svc.SyncProvider(context.Background(), "fake")
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"a", "d"}}, repo.batches, "rejected items aren't stored")
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, 3, result.Rejected)
+	assert.Equal(t, 2, result.Stages[1].Items, "convert passes on the conforming items only")
+
+	var rejected []*domain.DeadLetter
+	for _, e := range events.published() {
+		if r, ok := e.(domain.ItemsRejected); ok {
+			assert.Equal(t, "fake", r.Provider)
+			assert.LessOrEqual(t, len(r.Letters), 2, "published a batch at a time")
+			rejected = append(rejected, r.Letters...)
+		}
+	}
+	require.Len(t, rejected, 3)
+	assert.Equal(t, "b", rejected[0].ExternalID)
+	assert.JSONEq(t, `{"id": "b", "views": 1}`, rejected[0].Payload)
+	assert.Equal(t, "views", rejected[0].Errors[0].Field)
+	assert.Equal(t, "e", rejected[2].ExternalID)
+}
+
+func TestSyncService_StreamHoldsBoundedItems(t *testing.T) {
+	provider := &fakeStreamer{}
+	repo := &batchRepo{release: make(chan struct{})}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithPipeline(SyncPipeline{Buffer: 4, BatchSize: 10}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan SyncResult)
+	go func() {
+		results, _ := svc.SyncAll(ctx)
+		done <- results[0]
+	}()
+
+	// While the first upsert waits, the endless feed fills the batch and the buffers,
+	// then stops being read
+	require.Eventually(t, func() bool { return provider.emitted.Load() >= 10 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	// A batch, three buffers and an item held by each stage
+	assert.LessOrEqual(t, provider.emitted.Load(), int64(10+3*4+3))
+
+	repo.release <- struct{}{}
+	require.Eventually(t, func() bool { return provider.emitted.Load() >= 20 }, time.Second, time.Millisecond)
+
+	cancel()
+	result := <-done
+	assert.ErrorIs(t, result.Error, context.Canceled)
+	assert.Equal(t, 10, result.Count, "the stored batch is counted")
+}
+
+func TestSyncService_StreamStopsOnUpsertFailure(t *testing.T) {
+	provider := &fakeStreamer{}
+	repo := &batchRepo{err: errors.New("connection reset")}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithPipeline(SyncPipeline{Buffer: 1, BatchSize: 5}))
+
+	_, err := svc.SyncProvider(context.Background(), "fake")
+	require.EqualError(t, err, "connection reset", "the failing stage's error, not the cancellation of the others")
+	assert.Empty(t, repo.batches)
+}
+
+func TestSyncService_ClassifiesFailures(t *testing.T) {
+	run := func(ctx context.Context, provider domain.Provider, repo domain.ContentRepository) SyncResult {
+		svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithPipeline(SyncPipeline{Buffer: 1, BatchSize: 5}))
+		results, err := svc.SyncAll(ctx)
+		require.NoError(t, err)
+
+		return results[0]
+	}
+
+	result := run(context.Background(), &fakeProvider{err: domain.ErrProviderUnavailable}, &batchRepo{})
+	assert.Equal(t, domain.SyncErrorProvider, result.ErrorKind)
+
+	result = run(context.Background(), &fakeStreamer{}, &batchRepo{err: errors.New("connection reset")})
+	assert.Equal(t, domain.SyncErrorDatabase, result.ErrorKind)
+
+	// The upsert fails from the deadline, not from the database
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result = run(ctx, &fakeStreamer{}, &batchRepo{release: make(chan struct{})})
+	assert.Equal(t, domain.SyncErrorTimeout, result.ErrorKind)
+	assert.ErrorIs(t, result.Error, context.DeadlineExceeded)
+
+	result = run(context.Background(), &fakeStreamer{items: []fakeItem{{id: "a"}}}, &batchRepo{})
+	require.NoError(t, result.Error)
+	assert.Empty(t, result.ErrorKind)
+}
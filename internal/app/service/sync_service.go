@@ -2,49 +2,184 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+	"search-engine-service/pkg/locker"
 )
 
 // SyncService handles content synchronization from providers.
+// Cross-cutting work (cache invalidation, webhooks, event streams, metrics) subscribes
+// to the domain events it publishes.
 type SyncService struct {
-	repo      domain.ContentRepository
-	providers []domain.Provider
-	logger    *zap.Logger
+	repo          domain.ContentRepository
+	providers     []domain.Provider
+	lock          *SyncLock             // Optional lock serializing syncs across instances (can be nil)
+	events        domain.EventPublisher // Optional publisher of domain events (can be nil)
+	futurePublish domain.FuturePublishPolicy
+	pipeline      SyncPipeline
+	settings      *SettingsService // Optional runtime switches of provider syncs (can be nil)
+	disabled      map[string]bool  // Providers configured to be left out of SyncAll
+	logger        *zap.Logger
+
+	mu       sync.Mutex
+	lastRuns map[string]providerRun // Latest sync of each provider run by this instance
+}
+
+// providerRun is a provider sync run by this instance.
+type providerRun struct {
+	result SyncResult
+	at     time.Time
+}
+
+// syncRunLockKey is held while a sync runs. Unlike the scheduler's cooldown lock,
+// it's released as soon as the sync finishes.
+const syncRunLockKey = "sync:run:lock"
+
+// SyncLock serializes syncs across instances, so a manual sync queues behind a
+// running one instead of fetching and upserting the same contents concurrently.
+type SyncLock struct {
+	Locker  locker.DistributedLocker
+	TTL     time.Duration // Expiry should the holder die; renewed while the sync runs if supported
+	MaxWait time.Duration // How long a sync waits for a running one before failing with domain.ErrBusy
+}
+
+type forceSyncKey struct{}
+
+// ForceSync returns a copy of ctx whose syncs don't queue behind a running one: they
+// take the run lock if it's free and otherwise run alongside its holder, e.g. a sync
+// stuck on a hanging provider. Upserts of both then contend for the same rows.
+func ForceSync(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceSyncKey{}, true)
 }
 
-// NewSyncService creates a new SyncService.
-func NewSyncService(repo domain.ContentRepository, providers []domain.Provider, logger *zap.Logger) *SyncService {
-	return &SyncService{
-		repo:      repo,
-		providers: providers,
-		logger:    logger,
+// forced reports whether ctx is from ForceSync.
+func forced(ctx context.Context) bool {
+	force, _ := ctx.Value(forceSyncKey{}).(bool)
+
+	return force
+}
+
+// SyncOption configures a SyncService.
+type SyncOption func(*SyncService)
+
+// WithSyncLock makes syncs wait for each other across instances.
+func WithSyncLock(lock *SyncLock) SyncOption {
+	return func(s *SyncService) {
+		s.lock = lock
 	}
 }
 
-// SyncResult holds the result of a sync operation.
-type SyncResult struct {
-	Provider string
-	Count    int
-	Duration time.Duration
-	Error    error
+// WithEvents publishes to events SyncStarted when a sync starts, ContentUpserted
+// with the contents each provider's upsert created or changed, ProviderSynced after
+// each provider's sync, SyncCompleted after each sync, ContentDeleted after deletes
+// and ContentCurated after editorial changes.
+func WithEvents(events domain.EventPublisher) SyncOption {
+	return func(s *SyncService) {
+		s.events = events
+	}
+}
+
+// WithFuturePublish decides what happens to fetched contents with a publish date
+// in the future. Without it, their publish date is clamped to the ingest time.
+func WithFuturePublish(policy domain.FuturePublishPolicy) SyncOption {
+	return func(s *SyncService) {
+		s.futurePublish = policy
+	}
+}
+
+// WithPipeline bounds the syncs of providers that stream their feed (see
+// domain.ProviderStreamer).
+func WithPipeline(pipeline SyncPipeline) SyncOption {
+	return func(s *SyncService) {
+		s.pipeline = pipeline
+	}
+}
+
+// WithDisabledProviders leaves the named providers out of SyncAll, and so out of
+// scheduled syncs, until switched on with SetProviderEnabled. Their contents are kept.
+func WithDisabledProviders(names ...string) SyncOption {
+	return func(s *SyncService) {
+		s.disabled = make(map[string]bool, len(names))
+		for _, name := range names {
+			s.disabled[name] = true
+		}
+	}
 }
 
+// WithProviderSwitches lets SetProviderEnabled switch provider syncs on and off
+// through the runtime settings, so the switch applies to every instance.
+func WithProviderSwitches(settings *SettingsService) SyncOption {
+	return func(s *SyncService) {
+		s.settings = settings
+	}
+}
+
+// NewSyncService creates a new SyncService storing the contents of providers in
+// repo, configured by opts.
+func NewSyncService(repo domain.ContentRepository, providers []domain.Provider, logger *zap.Logger, opts ...SyncOption) *SyncService {
+	s := &SyncService{
+		repo:          repo,
+		providers:     providers,
+		futurePublish: domain.FuturePublishClamp,
+		logger:        logger,
+		lastRuns:      make(map[string]providerRun),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SyncResult holds the result of a sync operation.
+type SyncResult = domain.SyncResult
+
 // SyncAll synchronizes content from all providers concurrently.
 // Returns results for each provider. Partial failures are allowed.
-func (s *SyncService) SyncAll(ctx context.Context) []SyncResult {
-	results := make([]SyncResult, len(s.providers))
+// Returns domain.ErrBusy if another sync kept running for the lock's MaxWait, unless
+// ctx is from ForceSync.
+func (s *SyncService) SyncAll(ctx context.Context) ([]SyncResult, error) {
+	var results []SyncResult
+	err := s.exclusive(ctx, func(ctx context.Context) error {
+		results = s.syncAll(ctx)
+
+		return nil
+	})
+
+	return results, err
+}
+
+// syncAll runs SyncAll while holding the run lock.
+func (s *SyncService) syncAll(ctx context.Context) []SyncResult {
+	var providers []domain.Provider
+	var names, skipped []string
+	for _, p := range s.providers {
+		if s.providerEnabled(p.Name()) {
+			providers = append(providers, p)
+			names = append(names, p.Name())
+		} else {
+			skipped = append(skipped, p.Name())
+		}
+	}
+	results := make([]SyncResult, len(providers))
 	var wg sync.WaitGroup
 
-	s.logger.Info("starting sync from all providers",
-		zap.Int("provider_count", len(s.providers)),
+	logger.FromContext(ctx, s.logger).Info("starting sync from all providers",
+		zap.Int("provider_count", len(providers)),
+		zap.Strings("disabled", skipped),
 	)
 
-	for i, provider := range s.providers {
+	s.publish(ctx, domain.SyncStarted{Providers: names})
+
+	for i, provider := range providers {
 		wg.Add(1)
 		go func(idx int, p domain.Provider) {
 			defer wg.Done()
@@ -54,6 +189,9 @@ func (s *SyncService) SyncAll(ctx context.Context) []SyncResult {
 
 	wg.Wait()
 
+	// Interrupted syncs are recorded too, with what they stored
+	s.publish(context.WithoutCancel(ctx), domain.SyncCompleted{Results: results})
+
 	// Log summary
 	totalSynced := 0
 	totalErrors := 0
@@ -65,7 +203,7 @@ func (s *SyncService) SyncAll(ctx context.Context) []SyncResult {
 		}
 	}
 
-	s.logger.Info("sync completed",
+	logger.FromContext(ctx, s.logger).Info("sync completed",
 		zap.Int("total_synced", totalSynced),
 		zap.Int("providers_failed", totalErrors),
 	)
@@ -73,65 +211,306 @@ func (s *SyncService) SyncAll(ctx context.Context) []SyncResult {
 	return results
 }
 
-// syncProvider fetches and upserts content from a single provider.
+// syncProvider fetches and upserts content from a single provider and records the result.
 func (s *SyncService) syncProvider(ctx context.Context, provider domain.Provider) SyncResult {
+	start := time.Now()
+	var result SyncResult
+	if streamer, ok := provider.(domain.ProviderStreamer); ok {
+		result = s.streamAndStore(ctx, provider, streamer)
+	} else {
+		result = s.fetchAndStore(ctx, provider)
+	}
+	if result.ErrorKind == domain.SyncErrorInterrupted {
+		result.Error = fmt.Errorf("%w: %w", domain.ErrInterrupted, result.Error)
+	}
+
+	s.mu.Lock()
+	s.lastRuns[result.Provider] = providerRun{result: result, at: start.UTC()}
+	s.mu.Unlock()
+
+	s.publish(context.WithoutCancel(ctx), domain.ProviderSynced{Result: result})
+
+	return result
+}
+
+// fetchAndStore fetches and upserts content from a single provider, all at once.
+func (s *SyncService) fetchAndStore(ctx context.Context, provider domain.Provider) SyncResult {
 	start := time.Now()
 	result := SyncResult{
 		Provider: provider.Name(),
 	}
 
-	s.logger.Debug("syncing provider", zap.String("provider", provider.Name()))
+	logger.FromContext(ctx, s.logger).Debug("syncing provider", zap.String("provider", provider.Name()))
 
 	// Fetch from provider
 	contents, err := provider.Fetch(ctx)
 	if err != nil {
 		result.Error = err
+		result.ErrorKind = syncErrorKind(ctx, domain.SyncErrorProvider)
 		result.Duration = time.Since(start)
-		s.logger.Warn("provider fetch failed",
+		logger.FromContext(ctx, s.logger).Warn("provider fetch failed",
 			zap.String("provider", provider.Name()),
+			zap.String("error_kind", string(result.ErrorKind)),
 			zap.Error(err),
 		)
 
 		return result
 	}
 
+	fetched := len(contents)
+	contents = s.futurePublish.Apply(contents, time.Now().UTC())
+	if dropped := fetched - len(contents); dropped > 0 {
+		logger.FromContext(ctx, s.logger).Info("skipped contents published in the future",
+			zap.String("provider", provider.Name()),
+			zap.Int("count", dropped),
+		)
+	}
+
 	// Bulk upsert to database
 	if len(contents) > 0 {
-		if err := s.repo.BulkUpsert(ctx, contents); err != nil {
+		changed, err := s.upsert(ctx, contents)
+		if err != nil {
 			result.Error = err
+			result.ErrorKind = syncErrorKind(ctx, domain.SyncErrorDatabase)
 			result.Duration = time.Since(start)
-			s.logger.Error("bulk upsert failed",
+			logger.FromContext(ctx, s.logger).Error("bulk upsert failed",
 				zap.String("provider", provider.Name()),
+				zap.String("error_kind", string(result.ErrorKind)),
 				zap.Error(err),
 			)
 
 			return result
 		}
+
+		if len(changed) > 0 {
+			s.publish(ctx, domain.ContentUpserted{Provider: provider.Name(), Contents: changed})
+		}
+		result.Changed = len(changed)
 	}
 
 	result.Count = len(contents)
 	result.Duration = time.Since(start)
 
-	s.logger.Info("provider sync completed",
+	logger.FromContext(ctx, s.logger).Info("provider sync completed",
 		zap.String("provider", provider.Name()),
 		zap.Int("count", result.Count),
+		zap.Int("changed", result.Changed),
 		zap.Duration("duration", result.Duration),
 	)
 
 	return result
 }
 
-// SyncProvider synchronizes content from a specific provider.
+// upsert stores contents and returns those created or changed: all of them, unless
+// the repository tells unchanged contents apart (see domain.ChangedContentUpserter).
+func (s *SyncService) upsert(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
+	if upserter, ok := s.repo.(domain.ChangedContentUpserter); ok {
+		return upserter.UpsertChanged(ctx, contents)
+	}
+	if err := s.repo.BulkUpsert(ctx, contents); err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+// syncErrorKind classifies the error of a provider sync that failed at step: when
+// ctx ended, by shutdown or at its deadline, the step failed from it, so the end of
+// ctx is reported instead.
+func syncErrorKind(ctx context.Context, step domain.SyncErrorKind) domain.SyncErrorKind {
+	switch {
+	case errors.Is(context.Cause(ctx), domain.ErrInterrupted):
+		return domain.SyncErrorInterrupted
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return domain.SyncErrorTimeout
+	default:
+		return step
+	}
+}
+
+// SyncProvider synchronizes content from a specific provider, even one left out of
+// SyncAll. Returns domain.ErrNotFound if no provider has that name, domain.ErrBusy if another
+// sync kept running for the lock's MaxWait, unless ctx is from ForceSync.
 func (s *SyncService) SyncProvider(ctx context.Context, providerName string) (*SyncResult, error) {
 	for _, p := range s.providers {
 		if p.Name() == providerName {
-			result := s.syncProvider(ctx, p)
+			var result SyncResult
+			err := s.exclusive(ctx, func(ctx context.Context) error {
+				s.publish(ctx, domain.SyncStarted{Providers: []string{p.Name()}})
+				result = s.syncProvider(ctx, p)
+				s.publish(context.WithoutCancel(ctx), domain.SyncCompleted{Results: []SyncResult{result}})
+
+				return result.Error
+			})
+			if err != nil {
+				return nil, err
+			}
 
-			return &result, result.Error
+			return &result, nil
 		}
 	}
 
-	return nil, nil // Provider not found
+	return nil, fmt.Errorf("provider %s: %w", providerName, domain.ErrNotFound)
+}
+
+// DeleteContent removes a content by its internal ID.
+// Returns domain.ErrNotFound if it didn't exist.
+func (s *SyncService) DeleteContent(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.FromContext(ctx, s.logger).Error("delete content failed", zap.String("id", id), zap.Error(err))
+		}
+
+		return err
+	}
+
+	s.publish(ctx, domain.ContentDeleted{ID: id})
+
+	return nil
+}
+
+// CurateContent changes the editorial flags of a content and returns the updated content.
+// Returns domain.ErrNotFound if it doesn't exist.
+func (s *SyncService) CurateContent(ctx context.Context, id string, patch domain.CurationPatch) (*domain.Content, error) {
+	content, err := s.repo.UpdateCuration(ctx, id, patch)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.FromContext(ctx, s.logger).Error("curate content failed", zap.String("id", id), zap.Error(err))
+		}
+
+		return nil, err
+	}
+
+	s.publish(ctx, domain.ContentCurated{Content: content})
+
+	return content, nil
+}
+
+// providerEnabled reports whether SyncAll syncs the named provider.
+func (s *SyncService) providerEnabled(name string) bool {
+	if s.settings == nil {
+		return !s.disabled[name]
+	}
+
+	return s.settings.Current().ProviderSyncEnabled(name, !s.disabled[name])
+}
+
+// SetProviderEnabled switches the syncs of a provider on or off, taking it in or out
+// of SyncAll on every instance until switched again. Its contents are kept either way.
+// Returns domain.ErrNotFound if no provider has that name.
+func (s *SyncService) SetProviderEnabled(ctx context.Context, providerName string, enabled bool) error {
+	if !slices.Contains(s.GetProviderNames(), providerName) {
+		return fmt.Errorf("provider %s: %w", providerName, domain.ErrNotFound)
+	}
+	if s.settings == nil {
+		return fmt.Errorf("%w: provider syncs can't be switched without runtime settings", domain.ErrInvalidParams)
+	}
+
+	if _, err := s.settings.Update(ctx, domain.SettingsPatch{ProviderSyncs: map[string]bool{providerName: enabled}}); err != nil {
+		return err
+	}
+	logger.FromContext(ctx, s.logger).Info("provider sync switched", zap.String("provider", providerName), zap.Bool("enabled", enabled))
+
+	return nil
+}
+
+// ListCurated returns all pinned, blocked or boosted contents.
+func (s *SyncService) ListCurated(ctx context.Context) ([]*domain.Content, error) {
+	return s.repo.ListCurated(ctx)
+}
+
+// ProviderSyncStatus holds a provider's circuit breaker state and its latest syncs.
+type ProviderSyncStatus struct {
+	Provider     string
+	Enabled      bool        // Synced by SyncAll (see SetProviderEnabled)
+	BreakerState string      // "" when the provider has no breaker
+	LastSyncAt   time.Time   // Last upsert of its contents by any instance, changed or not; zero if never (or unknown)
+	LastRun      *SyncResult // Latest sync run by this instance, nil if none since it started
+	LastRunAt    time.Time   // When LastRun started
+}
+
+// ProviderStatuses returns the status of each provider, in registration order.
+// Last sync times are left out if they can't be loaded.
+func (s *SyncService) ProviderStatuses(ctx context.Context) []ProviderSyncStatus {
+	lastSyncs, err := s.repo.LastSyncTimes(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to load last sync times", zap.Error(err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]ProviderSyncStatus, len(s.providers))
+	for i, p := range s.providers {
+		status := ProviderSyncStatus{Provider: p.Name(), Enabled: s.providerEnabled(p.Name()), LastSyncAt: lastSyncs[p.Name()]}
+		if r, ok := p.(domain.BreakerStateReporter); ok {
+			status.BreakerState = r.BreakerState()
+		}
+		if run, ok := s.lastRuns[p.Name()]; ok {
+			result := run.result
+			status.LastRun = &result
+			status.LastRunAt = run.at
+		}
+		statuses[i] = status
+	}
+
+	return statuses
+}
+
+// ProviderHealth holds the health check result of a single provider.
+type ProviderHealth struct {
+	Provider string
+	Error    error
+}
+
+// CheckProviders runs HealthCheck on all providers concurrently.
+func (s *SyncService) CheckProviders(ctx context.Context) []ProviderHealth {
+	results := make([]ProviderHealth, len(s.providers))
+	var wg sync.WaitGroup
+
+	for i, provider := range s.providers {
+		wg.Add(1)
+		go func(idx int, p domain.Provider) {
+			defer wg.Done()
+			results[idx] = ProviderHealth{Provider: p.Name(), Error: p.HealthCheck(ctx)}
+		}(i, provider)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// exclusive runs fn while holding the sync run lock, if one is configured. Forced
+// syncs (see ForceSync) don't wait for the lock and run without it while it's held.
+func (s *SyncService) exclusive(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.lock == nil {
+		return fn(ctx)
+	}
+
+	var opts []locker.Option
+	if !forced(ctx) {
+		opts = append(opts, locker.Wait(s.lock.MaxWait))
+	}
+	err := locker.WithLock(ctx, s.lock.Locker, syncRunLockKey, s.lock.TTL, fn, opts...)
+	if errors.Is(err, locker.ErrLockHeld) {
+		if forced(ctx) {
+			logger.FromContext(ctx, s.logger).Warn("forced sync running alongside the sync holding the run lock")
+
+			return fn(ctx)
+		}
+
+		return fmt.Errorf("%w: another sync is still running", domain.ErrBusy)
+	}
+
+	return err
+}
+
+// publish hands event to the subscribers, if a publisher is set.
+func (s *SyncService) publish(ctx context.Context, event domain.Event) {
+	if s.events != nil {
+		s.events.Publish(ctx, event)
+	}
 }
 
 // GetProviderNames returns the names of all registered providers.
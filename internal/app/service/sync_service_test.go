@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeLocker is a locker.DistributedLocker whose lock is either free or held elsewhere.
+type fakeLocker struct {
+	heldElsewhere bool
+	held          map[string]bool
+	waits         []time.Duration
+}
+
+func (l *fakeLocker) Acquire(_ context.Context, key string, _ time.Duration) (bool, error) {
+	if l.heldElsewhere || l.held[key] {
+		return false, nil
+	}
+	l.held[key] = true
+
+	return true, nil
+}
+
+func (l *fakeLocker) AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error) {
+	l.waits = append(l.waits, maxWait)
+
+	return l.Acquire(ctx, key, ttl)
+}
+
+func (l *fakeLocker) Release(_ context.Context, key string) error {
+	delete(l.held, key)
+
+	return nil
+}
+
+func TestSyncService_WaitsForRunLock(t *testing.T) {
+	locker := &fakeLocker{held: make(map[string]bool)}
+	svc := NewSyncService(&fakeRepo{}, nil, zap.NewNop(), WithSyncLock(&SyncLock{Locker: locker, TTL: time.Minute, MaxWait: 5 * time.Second}))
+
+	_, err := svc.SyncAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []time.Duration{5 * time.Second}, locker.waits)
+	assert.Empty(t, locker.held, "the lock is released once the sync finished")
+}
+
+func TestSyncService_BusyWhileAnotherSyncRuns(t *testing.T) {
+	locker := &fakeLocker{heldElsewhere: true, held: make(map[string]bool)}
+	svc := NewSyncService(&fakeRepo{}, nil, zap.NewNop(), WithSyncLock(&SyncLock{Locker: locker, TTL: time.Minute, MaxWait: time.Second}))
+
+	_, err := svc.SyncAll(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrBusy)
+}
+
+func TestSyncService_ForcedSyncDoesNotQueue(t *testing.T) {
+	locker := &fakeLocker{heldElsewhere: true, held: make(map[string]bool)}
+	svc := NewSyncService(&fakeRepo{}, []domain.Provider{&fakeProvider{}}, zap.NewNop(),
+		WithSyncLock(&SyncLock{Locker: locker, TTL: time.Minute, MaxWait: time.Second}))
+
+	results, err := svc.SyncAll(ForceSync(context.Background()))
+	require.NoError(t, err, "a forced sync runs alongside the lock holder")
+	assert.Len(t, results, 1)
+	assert.Empty(t, locker.waits, "a forced sync doesn't wait for the lock")
+
+	locker.heldElsewhere = false
+	_, err = svc.SyncProvider(ForceSync(context.Background()), "fake")
+	require.NoError(t, err)
+	assert.Empty(t, locker.held, "a free lock is taken and released as usual")
+}
+
+// fakeProvider is a domain.Provider serving fixed contents, or failing with err.
+type fakeProvider struct {
+	contents []*domain.Content
+	err      error
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Fetch(_ context.Context) ([]*domain.Content, error) {
+	return p.contents, p.err
+}
+
+func (p *fakeProvider) HealthCheck(_ context.Context) error { return nil }
+
+func TestSyncService_AppliesFuturePublishPolicy(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour)
+	fetch := func() *fakeProvider {
+		return &fakeProvider{contents: []*domain.Content{
+			{ExternalID: "published", PublishedAt: time.Now().Add(-time.Hour)},
+			{ExternalID: "upcoming", PublishedAt: future},
+		}}
+	}
+
+	repo := &fakeRepo{contents: map[string]*domain.Content{}}
+	results, err := NewSyncService(repo, []domain.Provider{fetch()}, zap.NewNop(), WithFuturePublish(domain.FuturePublishExclude)).
+		SyncAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[0].Count)
+	assert.NotContains(t, repo.contents, "upcoming")
+
+	repo = &fakeRepo{contents: map[string]*domain.Content{}}
+	_, err = NewSyncService(repo, []domain.Provider{fetch()}, zap.NewNop()).
+		SyncAll(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, repo.contents, "upcoming")
+	assert.False(t, repo.contents["upcoming"].PublishedAt.After(time.Now()), "clamped to the sync time")
+}
+
+func TestSyncService_ProviderStatuses(t *testing.T) {
+	lastSync := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	repo := &fakeRepo{contents: map[string]*domain.Content{}, lastSyncs: map[string]time.Time{"fake": lastSync}}
+	provider := &fakeProvider{}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop())
+
+	statuses := svc.ProviderStatuses(context.Background())
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "fake", statuses[0].Provider)
+	assert.Equal(t, lastSync, statuses[0].LastSyncAt)
+	assert.Nil(t, statuses[0].LastRun, "no sync has run on this instance yet")
+
+	provider.err = domain.ErrProviderUnavailable
+	_, err := svc.SyncProvider(context.Background(), "fake")
+	require.ErrorIs(t, err, domain.ErrProviderUnavailable)
+
+	statuses = svc.ProviderStatuses(context.Background())
+	require.NotNil(t, statuses[0].LastRun)
+	assert.ErrorIs(t, statuses[0].LastRun.Error, domain.ErrProviderUnavailable)
+	assert.False(t, statuses[0].LastRunAt.IsZero())
+}
+
+func TestSyncService_DisabledProviders(t *testing.T) {
+	repo := &fakeRepo{contents: map[string]*domain.Content{}}
+	provider := &fakeProvider{contents: []*domain.Content{{ExternalID: "v1", PublishedAt: time.Now().Add(-time.Hour)}}}
+	settings := NewSettingsService(nil, domain.RuntimeSettings{}, zap.NewNop())
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(),
+		WithDisabledProviders("fake"),
+		WithProviderSwitches(settings),
+	)
+
+	results, err := svc.SyncAll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, results, "configured out of syncs")
+	assert.False(t, svc.ProviderStatuses(context.Background())[0].Enabled)
+
+	_, err = svc.SyncProvider(context.Background(), "fake")
+	require.NoError(t, err, "still synced on request")
+
+	require.NoError(t, svc.SetProviderEnabled(context.Background(), "fake", true))
+	results, err = svc.SyncAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1, "switched on at runtime")
+	assert.True(t, svc.ProviderStatuses(context.Background())[0].Enabled)
+	assert.Equal(t, map[string]bool{"fake": true}, settings.Current().ProviderSyncs)
+
+	assert.ErrorIs(t, svc.SetProviderEnabled(context.Background(), "unknown", false), domain.ErrNotFound)
+}
+
+// hashingRepo is a fakeRepo telling the contents upserted unchanged apart by their hash.
+type hashingRepo struct {
+	*fakeRepo
+}
+
+func (r hashingRepo) UpsertChanged(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
+	var changed []*domain.Content
+	for _, c := range contents {
+		c.Hash = c.SourceHash()
+		if stored, ok := r.contents[c.ExternalID]; !ok || stored.Hash != c.Hash {
+			changed = append(changed, c)
+		}
+	}
+
+	return changed, r.BulkUpsert(ctx, contents)
+}
+
+func TestSyncService_AnnouncesChangedContents(t *testing.T) {
+	published := time.Now().Add(-time.Hour).UTC()
+	provider := &fakeProvider{}
+	fetch := func(titles ...string) {
+		provider.contents = nil
+		for i, title := range titles {
+			provider.contents = append(provider.contents,
+				&domain.Content{ExternalID: fmt.Sprint(i), Title: title, PublishedAt: published})
+		}
+	}
+
+	events := &recordingPublisher{}
+	repo := hashingRepo{&fakeRepo{contents: map[string]*domain.Content{}}}
+	svc := NewSyncService(repo, []domain.Provider{provider}, zap.NewNop(), WithEvents(events))
+	sync := func() (*SyncResult, []string) {
+		events.reset()
+		result, err := svc.SyncProvider(context.Background(), "fake")
+		require.NoError(t, err)
+
+		var announced []string
+		for _, e := range events.published() {
+			if u, ok := e.(domain.ContentUpserted); ok {
+				for _, c := range u.Contents {
+					announced = append(announced, c.Title)
+				}
+			}
+		}
+
+		return result, announced
+	}
+
+	fetch("Go", "Rust")
+	result, announced := sync()
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, 2, result.Changed)
+	assert.Equal(t, []string{"Go", "Rust"}, announced)
+
+	fetch("Go", "Zig")
+	result, announced = sync()
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, 1, result.Changed)
+	assert.Equal(t, []string{"Zig"}, announced, "unchanged contents aren't announced")
+
+	result, announced = sync()
+	assert.Equal(t, 0, result.Changed)
+	assert.Empty(t, announced, "nothing is announced when nothing changed")
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"search-engine-service/internal/domain"
+)
+
+//go:generate mockery --name=SearchUseCase --filename=search_use_case.go --with-expecter=false
+//go:generate mockery --name=SyncUseCase --filename=sync_use_case.go --with-expecter=false
+
+// SearchUseCase is what the transport layer needs of content search. SearchService
+// implements it; handlers depend on it so they can be tested with the mock in
+// ./mocks (regenerated by `make generate`) or backed by another implementation.
+type SearchUseCase interface {
+	Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error)
+	Facets(ctx context.Context, params domain.SearchParams) (*domain.SearchFacets, error)
+	GetByID(ctx context.Context, id string) (*domain.Content, error)
+	Count(ctx context.Context) (int64, error)
+	Export(ctx context.Context, params domain.SearchParams, fn func(batch []*domain.Content) error) error
+	ClearCache(ctx context.Context) error
+}
+
+// SyncUseCase is what the transport layer needs of provider syncs and content
+// administration. SyncService implements it.
+type SyncUseCase interface {
+	SyncAll(ctx context.Context) ([]SyncResult, error)
+	SyncProvider(ctx context.Context, providerName string) (*SyncResult, error)
+	DeleteContent(ctx context.Context, id string) error
+	CurateContent(ctx context.Context, id string, patch domain.CurationPatch) (*domain.Content, error)
+	ListCurated(ctx context.Context) ([]*domain.Content, error)
+	ProviderStatuses(ctx context.Context) []ProviderSyncStatus
+	SetProviderEnabled(ctx context.Context, providerName string, enabled bool) error
+	CheckProviders(ctx context.Context) []ProviderHealth
+	GetProviderNames() []string
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/metrics"
+)
+
+// webhookBatchSize caps the contents delivered per content event, so a large sync
+// doesn't build a single huge request.
+const webhookBatchSize = 500
+
+// WebhookConfig holds webhook delivery settings.
+type WebhookConfig struct {
+	Workers     int           // Concurrent deliveries
+	QueueSize   int           // Pending deliveries buffered before new ones are dropped
+	MaxAttempts int           // Attempts per delivery, including the first
+	Backoff     time.Duration // Initial retry delay, doubled after every failed attempt
+}
+
+// WebhookService manages webhook subscriptions and delivers events to them.
+// Deliveries are queued in memory and processed by a worker pool, so notifying
+// never blocks the caller (e.g. a running sync). Deliveries that don't fit in the
+// queue, or that are still queued when Stop gives up, are dropped and counted in
+// metrics.WebhookDeliveriesDropped.
+type WebhookService struct {
+	repo   domain.WebhookRepository
+	sender domain.WebhookSender
+	cfg    WebhookConfig
+	logger *zap.Logger
+
+	queue    chan webhookDelivery
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	// ctx is canceled when Stop gives up, aborting the deliveries in flight.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// webhookDelivery is a single event queued for a single webhook.
+type webhookDelivery struct {
+	webhook *domain.Webhook
+	event   domain.WebhookEvent
+}
+
+// NewWebhookService creates a new WebhookService. Call Start to begin delivering.
+func NewWebhookService(
+	repo domain.WebhookRepository,
+	sender domain.WebhookSender,
+	cfg WebhookConfig,
+	logger *zap.Logger,
+) *WebhookService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		cfg:    cfg,
+		logger: logger,
+		queue:  make(chan webhookDelivery, cfg.QueueSize),
+		stop:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start launches the delivery workers.
+func (s *WebhookService) Start() {
+	for range s.cfg.Workers {
+		s.wg.Add(1)
+		go s.worker()
+	}
+
+	s.logger.Info("webhook dispatcher started", zap.Int("workers", s.cfg.Workers))
+}
+
+// Stop has the workers deliver the queued events, with a single attempt each, and
+// waits for them until ctx is done. Deliveries still queued then are dropped.
+func (s *WebhookService) Stop(ctx context.Context) {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.FromContext(ctx, s.logger).Warn("webhook dispatcher stop timed out")
+	}
+	s.cancel()
+
+	if pending := len(s.queue); pending > 0 {
+		metrics.WebhookDeliveriesDropped.WithLabelValues("shutdown").Add(float64(pending))
+		logger.FromContext(ctx, s.logger).Warn("dropping queued webhook deliveries", zap.Int("pending", pending))
+	}
+}
+
+// Create registers a new active webhook. A random secret is generated when none is given.
+func (s *WebhookService) Create(ctx context.Context, url, secret string, eventTypes []string) (*domain.Webhook, error) {
+	if secret == "" {
+		secret = randomHex(32)
+	}
+
+	webhook := &domain.Webhook{
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		logger.FromContext(ctx, s.logger).Error("create webhook failed", zap.String("url", url), zap.Error(err))
+
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info("webhook created",
+		zap.String("id", webhook.ID),
+		zap.String("url", url),
+		zap.Strings("event_types", eventTypes),
+	)
+
+	return webhook, nil
+}
+
+// List returns all webhooks.
+func (s *WebhookService) List(ctx context.Context) ([]*domain.Webhook, error) {
+	return s.repo.List(ctx)
+}
+
+// Delete removes a webhook. Returns domain.ErrNotFound if it didn't exist.
+func (s *WebhookService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// HandleEvent is an event bus handler queueing deliveries for upserted contents and finished syncs.
+func (s *WebhookService) HandleEvent(ctx context.Context, event domain.Event) {
+	switch e := event.(type) {
+	case domain.ContentUpserted:
+		s.NotifyContentEvents(ctx, e.ContentEvents())
+	case domain.SyncCompleted:
+		s.NotifySyncCompleted(ctx, e.Results)
+	}
+}
+
+// NotifyContentEvents queues content.created / content.updated deliveries: one per
+// event type carrying the contents of events, up to webhookBatchSize each. A batch
+// occurred when its first content changed.
+func (s *WebhookService) NotifyContentEvents(ctx context.Context, events []domain.ContentEvent) {
+	var webhookEvents []domain.WebhookEvent
+	batches := make(map[domain.ContentEventType]*contentEventsData) // Open batch per event type
+	for _, e := range events {
+		batch, ok := batches[e.Type]
+		if !ok || len(batch.Contents) == webhookBatchSize {
+			batch = &contentEventsData{}
+			batches[e.Type] = batch
+			webhookEvents = append(webhookEvents, domain.WebhookEvent{
+				ID:         randomHex(16),
+				Type:       string(e.Type),
+				OccurredAt: e.OccurredAt,
+				Data:       batch,
+			})
+		}
+		batch.Contents = append(batch.Contents, e.Content)
+	}
+
+	s.dispatch(ctx, webhookEvents)
+}
+
+// contentEventsData is the payload of content.created and content.updated events.
+type contentEventsData struct {
+	Contents []*domain.Content `json:"contents"`
+}
+
+// NotifySyncCompleted queues sync.completed deliveries.
+func (s *WebhookService) NotifySyncCompleted(ctx context.Context, results []SyncResult) {
+	data := syncCompletedData{Results: make([]syncCompletedResult, len(results))}
+	for i, r := range results {
+		data.Results[i] = syncCompletedResult{
+			Provider:   r.Provider,
+			Count:      r.Count,
+			DurationMS: r.Duration.Milliseconds(),
+		}
+		if r.Error != nil {
+			data.Results[i].Error = r.Error.Error()
+		}
+	}
+
+	s.dispatch(ctx, []domain.WebhookEvent{{
+		ID:         randomHex(16),
+		Type:       domain.EventSyncCompleted,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}})
+}
+
+// syncCompletedData is the payload of sync.completed events.
+type syncCompletedData struct {
+	Results []syncCompletedResult `json:"results"`
+}
+
+// syncCompletedResult describes the outcome for a single provider.
+type syncCompletedResult struct {
+	Provider   string `json:"provider"`
+	Count      int    `json:"count"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// dispatch queues every event for every active webhook subscribed to its type.
+// Deliveries are dropped with a warning, and counted, when the queue is full.
+func (s *WebhookService) dispatch(ctx context.Context, events []domain.WebhookEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	webhooks, err := s.repo.ListActive(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("failed to load webhooks", zap.Error(err))
+
+		return
+	}
+
+	dropped := 0
+	for _, webhook := range webhooks {
+		for _, event := range events {
+			if !webhook.Subscribes(event.Type) {
+				continue
+			}
+
+			select {
+			case s.queue <- webhookDelivery{webhook: webhook, event: event}:
+			default:
+				dropped++
+			}
+		}
+	}
+
+	if dropped > 0 {
+		metrics.WebhookDeliveriesDropped.WithLabelValues("queue_full").Add(float64(dropped))
+		logger.FromContext(ctx, s.logger).Warn("webhook queue full, deliveries dropped", zap.Int("dropped", dropped))
+	}
+}
+
+// worker delivers queued events until Stop is called, then the events still queued.
+func (s *WebhookService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.stop:
+			s.drain()
+
+			return
+		case d := <-s.queue:
+			s.deliver(d)
+		}
+	}
+}
+
+// drain delivers the queued events until the queue is empty or Stop gives up.
+func (s *WebhookService) drain() {
+	for s.ctx.Err() == nil {
+		select {
+		case d := <-s.queue:
+			s.deliver(d)
+		default:
+			return
+		}
+	}
+}
+
+// deliver sends a single event with exponential backoff between attempts. Once Stop
+// was called, failed deliveries aren't retried.
+func (s *WebhookService) deliver(d webhookDelivery) {
+	backoff := s.cfg.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := s.sender.Send(s.ctx, d.webhook, d.event)
+		if err == nil {
+			s.logger.Debug("webhook delivered",
+				zap.String("webhook_id", d.webhook.ID),
+				zap.String("event_id", d.event.ID),
+				zap.Int("attempt", attempt),
+			)
+
+			return
+		}
+
+		if attempt >= s.cfg.MaxAttempts {
+			s.logger.Warn("webhook delivery failed",
+				zap.String("webhook_id", d.webhook.ID),
+				zap.String("event_id", d.event.ID),
+				zap.String("event_type", d.event.Type),
+				zap.Int("attempts", attempt),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+// randomHex returns n random bytes hex-encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+type fakeWebhookRepo struct {
+	domain.WebhookRepository
+	webhooks []*domain.Webhook
+}
+
+func (r *fakeWebhookRepo) ListActive(context.Context) ([]*domain.Webhook, error) {
+	return r.webhooks, nil
+}
+
+// recordingSender fails the first failures calls, then records deliveries.
+type recordingSender struct {
+	mu        sync.Mutex
+	failures  int
+	attempts  int
+	delivered []domain.WebhookEvent
+	done      chan struct{}
+}
+
+func (s *recordingSender) Send(_ context.Context, _ *domain.Webhook, event domain.WebhookEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("endpoint down")
+	}
+
+	s.delivered = append(s.delivered, event)
+	s.done <- struct{}{}
+
+	return nil
+}
+
+func newTestWebhookService(repo *fakeWebhookRepo, sender *recordingSender) *WebhookService {
+	svc := NewWebhookService(repo, sender, WebhookConfig{
+		Workers:     1,
+		QueueSize:   10,
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}, zap.NewNop())
+	svc.Start()
+
+	return svc
+}
+
+func waitDelivered(t *testing.T, sender *recordingSender) {
+	t.Helper()
+
+	select {
+	case <-sender.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestWebhookService_DeliversSubscribedEventsOnly(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: []*domain.Webhook{
+		{ID: "w1", Active: true, EventTypes: []string{domain.EventSyncCompleted}},
+	}}
+	sender := &recordingSender{done: make(chan struct{}, 10)}
+	svc := newTestWebhookService(repo, sender)
+	defer svc.Stop(context.Background())
+
+	svc.NotifyContentEvents(context.Background(), []domain.ContentEvent{
+		{Type: domain.EventContentCreated, Content: &domain.Content{ID: "c1"}},
+	})
+	svc.NotifySyncCompleted(context.Background(), []SyncResult{{Provider: "provider_a", Count: 2}})
+
+	waitDelivered(t, sender)
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	require.Len(t, sender.delivered, 1)
+	assert.Equal(t, domain.EventSyncCompleted, sender.delivered[0].Type)
+	assert.NotEmpty(t, sender.delivered[0].ID)
+}
+
+func TestWebhookService_RetriesFailedDeliveries(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: []*domain.Webhook{
+		{ID: "w1", Active: true, EventTypes: []string{string(domain.EventContentUpdated)}},
+	}}
+	sender := &recordingSender{failures: 2, done: make(chan struct{}, 10)}
+	svc := newTestWebhookService(repo, sender)
+	defer svc.Stop(context.Background())
+
+	svc.NotifyContentEvents(context.Background(), []domain.ContentEvent{
+		{Type: domain.EventContentUpdated, Content: &domain.Content{ID: "c1"}},
+	})
+
+	waitDelivered(t, sender)
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	assert.Equal(t, 3, sender.attempts)
+	assert.Len(t, sender.delivered, 1)
+}
+
+func TestWebhookService_BatchesContentEventsPerType(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: []*domain.Webhook{
+		{ID: "w1", Active: true, EventTypes: []string{string(domain.EventContentCreated), string(domain.EventContentUpdated)}},
+	}}
+	sender := &recordingSender{done: make(chan struct{}, 10)}
+	svc := newTestWebhookService(repo, sender)
+	defer svc.Stop(context.Background())
+
+	svc.NotifyContentEvents(context.Background(), []domain.ContentEvent{
+		{Type: domain.EventContentCreated, Content: &domain.Content{ID: "c1"}},
+		{Type: domain.EventContentUpdated, Content: &domain.Content{ID: "c2"}},
+		{Type: domain.EventContentCreated, Content: &domain.Content{ID: "c3"}},
+	})
+
+	waitDelivered(t, sender)
+	waitDelivered(t, sender)
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	require.Len(t, sender.delivered, 2, "one delivery per event type")
+	contents := make(map[string][]string)
+	for _, event := range sender.delivered {
+		for _, c := range event.Data.(*contentEventsData).Contents {
+			contents[event.Type] = append(contents[event.Type], c.ID)
+		}
+	}
+	assert.Equal(t, []string{"c1", "c3"}, contents[string(domain.EventContentCreated)])
+	assert.Equal(t, []string{"c2"}, contents[string(domain.EventContentUpdated)])
+}
+
+// blockingSender holds every delivery until release is closed.
+type blockingSender struct {
+	release   chan struct{}
+	mu        sync.Mutex
+	delivered int
+}
+
+func (s *blockingSender) Send(ctx context.Context, _ *domain.Webhook, _ domain.WebhookEvent) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delivered++
+
+	return nil
+}
+
+func TestWebhookService_StopDeliversQueuedEvents(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: []*domain.Webhook{
+		{ID: "w1", Active: true, EventTypes: []string{domain.EventSyncCompleted}},
+	}}
+	sender := &blockingSender{release: make(chan struct{})}
+	svc := NewWebhookService(repo, sender, WebhookConfig{Workers: 1, QueueSize: 10, MaxAttempts: 3, Backoff: time.Millisecond}, zap.NewNop())
+	svc.Start()
+
+	for range 3 {
+		svc.NotifySyncCompleted(context.Background(), []SyncResult{{Provider: "provider_a"}})
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		svc.Stop(context.Background())
+		close(stopped)
+	}()
+	close(sender.release)
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stop")
+	}
+	assert.Equal(t, 3, sender.delivered, "queued deliveries are sent before stopping")
+}
+
+func TestWebhookService_StopGivesUpWhenContextDone(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: []*domain.Webhook{
+		{ID: "w1", Active: true, EventTypes: []string{domain.EventSyncCompleted}},
+	}}
+	sender := &blockingSender{release: make(chan struct{})}
+	svc := NewWebhookService(repo, sender, WebhookConfig{Workers: 1, QueueSize: 10, MaxAttempts: 3, Backoff: time.Millisecond}, zap.NewNop())
+	svc.Start()
+
+	for range 3 {
+		svc.NotifySyncCompleted(context.Background(), []SyncResult{{Provider: "provider_a"}})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	svc.Stop(ctx)
+
+	svc.wg.Wait() // Deliveries in flight are aborted
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	assert.Zero(t, sender.delivered)
+}
@@ -3,7 +3,9 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,14 +14,34 @@ import (
 
 // Config holds all application configuration.
 type Config struct {
-	App      AppConfig      `mapstructure:"app"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Provider ProviderConfig `mapstructure:"provider"`
-	Sync     SyncConfig     `mapstructure:"sync"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
-	Sentry   SentryConfig   `mapstructure:"sentry"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	Cache    CacheConfig    `mapstructure:"cache"`
+	App       AppConfig                   `mapstructure:"app"`
+	Database  DatabaseConfig              `mapstructure:"database"`
+	Provider  ProviderConfig              `mapstructure:"provider"`
+	Providers map[string]ProviderEndpoint `mapstructure:"providers"` // Keyed by provider name
+	Sync      SyncConfig                  `mapstructure:"sync"`
+	Logger    LoggerConfig                `mapstructure:"logger"`
+	Sentry    SentryConfig                `mapstructure:"sentry"`
+	Redis     RedisConfig                 `mapstructure:"redis"`
+	Lock      LockConfig                  `mapstructure:"lock"`
+	Cache     CacheConfig                 `mapstructure:"cache"`
+	Auth      AuthConfig                  `mapstructure:"auth"`
+	Webhook   WebhookConfig               `mapstructure:"webhook"`
+
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	Quota       QuotaConfig       `mapstructure:"quota"`
+	HTTP        HTTPConfig        `mapstructure:"http"`
+	Search      SearchConfig      `mapstructure:"search"`
+	API         APIConfig         `mapstructure:"api"`
+	Settings    SettingsConfig    `mapstructure:"settings"`
+	Audit       AuditConfig       `mapstructure:"audit"`
+	Analytics   AnalyticsConfig   `mapstructure:"analytics"`
+	CTR         CTRConfig         `mapstructure:"ctr"`
+	Dictionary  DictionaryConfig  `mapstructure:"dictionary"`
+	Bleve       BleveConfig       `mapstructure:"bleve"`
+	Outbox      OutboxConfig      `mapstructure:"outbox"`
+	Health      HealthConfig      `mapstructure:"health"`
+	SLO         SLOConfig         `mapstructure:"slo"`
+	Snapshot    SnapshotConfig    `mapstructure:"snapshot"`
 }
 
 // AppConfig holds application-level settings.
@@ -28,6 +50,19 @@ type AppConfig struct {
 	Env   string `mapstructure:"env"` // development, staging, production
 	Port  int    `mapstructure:"port"`
 	Debug bool   `mapstructure:"debug"`
+
+	// AssetsDir, in debug mode, serves the dashboard's templates and static files from
+	// this directory instead of the copies embedded in the binary, reloading templates
+	// on every request. Empty always uses the embedded copies.
+	AssetsDir string `mapstructure:"assets_dir"`
+
+	// Shutdown: /readyz fails for DrainPeriod before components are stopped,
+	// which together may take up to ShutdownTimeout
+	DrainPeriod     time.Duration `mapstructure:"drain_period"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// WatchConfig applies changes to the config file at runtime (see Reloader)
+	WatchConfig bool `mapstructure:"watch_config"`
 }
 
 // DatabaseConfig holds database connection settings.
@@ -41,6 +76,13 @@ type DatabaseConfig struct {
 	MaxOpenConns int           `mapstructure:"max_open_conns"`
 	MaxIdleConns int           `mapstructure:"max_idle_conns"`
 	MaxLifetime  time.Duration `mapstructure:"max_lifetime"`
+
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // Queries slower than this are logged at WARN (0 disables)
+	LogQueries         bool          `mapstructure:"log_queries"`          // Log every query at DEBUG
+	CopyThreshold      int           `mapstructure:"copy_threshold"`       // Bulk upserts of at least this many rows use COPY (0 disables)
+	UpsertWorkers      int           `mapstructure:"upsert_workers"`       // Parallel transactions of a bulk upsert (1 upserts in one transaction)
+	WarmUpConns        int           `mapstructure:"warm_up_conns"`        // Connections the hot search statements are prepared on at startup (0 disables)
+	WarmUpTimeout      time.Duration `mapstructure:"warm_up_timeout"`      // Time the startup warm-up gets before the service starts without it
 }
 
 // DSN returns the PostgreSQL connection string.
@@ -51,18 +93,89 @@ func (c *DatabaseConfig) DSN() string {
 	)
 }
 
-// ProviderConfig holds external provider settings.
+// ProviderConfig holds settings shared by all external providers.
 type ProviderConfig struct {
-	A ProviderEndpoint `mapstructure:"a"`
-	B ProviderEndpoint `mapstructure:"b"`
+	HealthCacheTTL time.Duration `mapstructure:"health_cache_ttl"` // Reuse health check results for this long (0 disables)
+	RecordDir      string        `mapstructure:"record_dir"`       // Record provider responses under this directory for replays (empty disables)
+	RecordKeep     int           `mapstructure:"record_keep"`      // Recordings kept per provider, latest first (0 keeps all)
 }
 
-// ProviderEndpoint holds a single provider's configuration.
+// ProviderEndpoint holds a single provider's configuration. The provider's name,
+// its key in Config.Providers, is stored with its contents.
 type ProviderEndpoint struct {
-	BaseURL string        `mapstructure:"base_url"`
-	Timeout time.Duration `mapstructure:"timeout"`
-	Retry   RetryConfig   `mapstructure:"retry"`
-	CB      CBConfig      `mapstructure:"circuit_breaker"`
+	Type     string        `mapstructure:"type"`     // Client decoding the provider's API, e.g. provider_a (JSON) or provider_b (XML)
+	Disabled bool          `mapstructure:"disabled"` // Skip the provider, e.g. one of the defaults
+	Enabled  bool          `mapstructure:"enabled"`  // Sync the provider with the others; false keeps its contents but leaves it out of scheduled syncs
+	BaseURL  string        `mapstructure:"base_url"`
+	Endpoint string        `mapstructure:"endpoint"` // Content path; empty uses the type's default
+	APIKey   string        `mapstructure:"api_key"`  // Sent as a bearer token when set
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Retry    RetryConfig   `mapstructure:"retry"`
+	CB       CBConfig      `mapstructure:"circuit_breaker"`
+
+	MaxBodySize int64 `mapstructure:"max_body_size"` // Largest response read from the provider (bytes, -1 disables)
+	Strict      bool  `mapstructure:"strict"`        // Check items against the type's schema, keeping those breaking it as dead letters
+
+	Replay string `mapstructure:"replay"` // Read the response recorded in this file instead of calling the provider
+}
+
+// SyncDisabledProviders returns the names of the providers configured with
+// enabled: false, sorted. Skipped providers aren't included.
+func (c *Config) SyncDisabledProviders() []string {
+	var names []string
+	for name, p := range c.Providers {
+		if !p.Enabled && !p.Disabled {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Provider endpoint defaults, applied to unset settings of every provider.
+var defaultProviderEndpoint = ProviderEndpoint{
+	Timeout: 10 * time.Second,
+	Retry: RetryConfig{
+		MaxAttempts: 3,
+		WaitTime:    time.Second,
+		MaxWaitTime: 5 * time.Second,
+	},
+	CB: CBConfig{
+		MaxRequests:  3,
+		Interval:     60 * time.Second,
+		Timeout:      30 * time.Second,
+		FailureRatio: 0.5,
+	},
+	MaxBodySize: 64 << 20,
+}
+
+// withDefaults returns e with unset settings taken from defaultProviderEndpoint.
+func (e ProviderEndpoint) withDefaults() ProviderEndpoint {
+	d := defaultProviderEndpoint
+	if e.Timeout == 0 {
+		e.Timeout = d.Timeout
+	}
+	if e.Retry == (RetryConfig{}) {
+		e.Retry = d.Retry
+	}
+	if e.CB.MaxRequests == 0 {
+		e.CB.MaxRequests = d.CB.MaxRequests
+	}
+	if e.CB.Interval == 0 {
+		e.CB.Interval = d.CB.Interval
+	}
+	if e.CB.Timeout == 0 {
+		e.CB.Timeout = d.CB.Timeout
+	}
+	if e.CB.FailureRatio == 0 {
+		e.CB.FailureRatio = d.CB.FailureRatio
+	}
+	if e.MaxBodySize == 0 {
+		e.MaxBodySize = d.MaxBodySize
+	}
+
+	return e
 }
 
 // RetryConfig holds retry settings.
@@ -85,7 +198,16 @@ type SyncConfig struct {
 	Interval  time.Duration `mapstructure:"interval"`
 	OnStartup bool          `mapstructure:"on_startup"`
 	Timeout   time.Duration `mapstructure:"timeout"`
-	BatchSize int           `mapstructure:"batch_size"`
+	BatchSize int           `mapstructure:"batch_size"` // Contents upserted at a time by streamed syncs
+	LockWait  time.Duration `mapstructure:"lock_wait"`  // How long a sync waits for a running one before failing
+
+	ShutdownWait time.Duration `mapstructure:"shutdown_wait"` // How long shutdown waits for a running sync before interrupting it
+
+	PipelineBuffer int `mapstructure:"pipeline_buffer"` // Items buffered between stages of a streamed sync
+
+	FailureAlertThreshold int `mapstructure:"failure_alert_threshold"` // Consecutive failed syncs of a provider before alerting (0 disables)
+
+	FuturePublish string `mapstructure:"future_publish"` // clamp, exclude or embargo contents published in the future
 }
 
 // LoggerConfig holds logging settings.
@@ -104,23 +226,370 @@ type SentryConfig struct {
 }
 
 // RedisConfig holds Redis connection settings for distributed locking.
+// Without Redis, caching, locks, runtime settings and event streams are process-local,
+// so only a single instance may run.
 type RedisConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
+
+	// Socket timeouts per command; a shorter request deadline takes precedence
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+}
+
+// Lock backends.
+const (
+	LockBackendRedis    = "redis"
+	LockBackendPostgres = "postgres"
+	LockBackendEtcd     = "etcd"
+	LockBackendMemory   = "memory" // Single instance only; also used for redis when Redis is disabled
+)
+
+// LockConfig selects the distributed lock backend coordinating background jobs across instances.
+type LockConfig struct {
+	Backend string     `mapstructure:"backend"` // redis, postgres, etcd or memory
+	Etcd    EtcdConfig `mapstructure:"etcd"`
+}
+
+// EtcdConfig holds etcd connection settings for the etcd lock backend.
+type EtcdConfig struct {
+	Endpoints   []string      `mapstructure:"endpoints"`
+	Username    string        `mapstructure:"username"`
+	Password    string        `mapstructure:"password"`
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
 }
 
 // CacheConfig holds caching settings.
 type CacheConfig struct {
-	Enabled   bool          `mapstructure:"enabled"`
-	SearchTTL time.Duration `mapstructure:"search_ttl"`
-	KeyPrefix string        `mapstructure:"key_prefix"`
+	Enabled     bool             `mapstructure:"enabled"`
+	SearchTTL   time.Duration    `mapstructure:"search_ttl"`    // Search result pages
+	ContentTTL  time.Duration    `mapstructure:"content_ttl"`   // Single content by ID
+	NotFoundTTL time.Duration    `mapstructure:"not_found_ttl"` // "Not found" markers for missing IDs
+	StatsTTL    time.Duration    `mapstructure:"stats_ttl"`     // Counts and dashboard stats
+	StaleTTL    time.Duration    `mapstructure:"stale_ttl"`     // Stale-while-revalidate window for search results (0 disables)
+	DegradedTTL time.Duration    `mapstructure:"degraded_ttl"`  // Search results kept past stale_ttl for degraded searches (0 disables)
+	KeyPrefix   string           `mapstructure:"key_prefix"`
+	Version     int              `mapstructure:"version"` // Bump to invalidate every cached entry
+	Local       LocalCacheConfig `mapstructure:"local"`
+	Warm        CacheWarmConfig  `mapstructure:"warm"`
+
+	// CompressionThreshold is the minimum value size in bytes gzip-compressed in Redis (0 disables)
+	CompressionThreshold int `mapstructure:"compression_threshold"`
+}
+
+// VersionedKeyPrefix returns the key prefix including the global cache version.
+// Bumping the version makes all previously cached entries unreachable; they expire via TTL.
+func (c *CacheConfig) VersionedKeyPrefix() string {
+	return fmt.Sprintf("%s:v%d", c.KeyPrefix, c.Version)
+}
+
+// LocalCacheConfig holds settings for the optional in-process LRU tier in front of Redis.
+type LocalCacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	MaxEntries int           `mapstructure:"max_entries"`
+	TTL        time.Duration `mapstructure:"ttl"` // Upper bound for local entries
+}
+
+// CacheWarmConfig holds settings for re-populating hot searches after a sync invalidates the cache.
+type CacheWarmConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Queries []string `mapstructure:"queries"` // "" warms the default dashboard listing
+	TopN    int      `mapstructure:"top_n"`   // Also warm the N most frequent searches
+}
+
+// AuthConfig holds API authentication settings.
+// When enabled, admin endpoints require a JWT carrying AdminRole; search endpoints stay public.
+type AuthConfig struct {
+	Enabled   bool      `mapstructure:"enabled"`
+	AdminRole string    `mapstructure:"admin_role"`
+	JWT       JWTConfig `mapstructure:"jwt"`
+}
+
+// JWTConfig holds JWT validation settings. Set either Secret (HMAC) or JWKSURL.
+type JWTConfig struct {
+	Secret      string        `mapstructure:"secret"`       // HMAC shared secret (HS256/384/512)
+	JWKSURL     string        `mapstructure:"jwks_url"`     // JWKS endpoint for RSA/ECDSA keys
+	JWKSRefresh time.Duration `mapstructure:"jwks_refresh"` // How often the key set is refetched
+	Issuer      string        `mapstructure:"issuer"`       // Expected "iss" claim (empty skips the check)
+	Audience    string        `mapstructure:"audience"`     // Expected "aud" claim (empty skips the check)
+	RolesClaim  string        `mapstructure:"roles_claim"`  // Claim holding the role list
+	Leeway      time.Duration `mapstructure:"leeway"`       // Allowed clock skew for exp/nbf/iat
+}
+
+// WebhookConfig holds outbound webhook delivery settings.
+type WebhookConfig struct {
+	Workers     int           `mapstructure:"workers"`      // Concurrent deliveries
+	QueueSize   int           `mapstructure:"queue_size"`   // Pending deliveries before new ones are dropped
+	MaxAttempts int           `mapstructure:"max_attempts"` // Attempts per delivery, including the first
+	Backoff     time.Duration `mapstructure:"backoff"`      // Initial retry delay (doubles per attempt)
+	Timeout     time.Duration `mapstructure:"timeout"`      // Per-request timeout
+}
+
+// IdempotencyConfig holds settings for Idempotency-Key handling on admin mutations.
+type IdempotencyConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl"` // How long responses are replayed for a key
+}
+
+// QuotaConfig holds the monthly request quotas of the API keys partners call the
+// public routes with. Requests are counted in Redis, or in Postgres without it.
+type QuotaConfig struct {
+	Enabled    bool           `mapstructure:"enabled"`
+	RequireKey bool           `mapstructure:"require_key"` // Reject public requests without an API key; otherwise they aren't counted
+	Keys       []APIKeyConfig `mapstructure:"keys"`
+}
+
+// APIKeyConfig holds an API key and its quota.
+type APIKeyConfig struct {
+	Name         string `mapstructure:"name"`          // Reported in usage and logs instead of the key
+	Key          string `mapstructure:"key"`           // Sent in the X-API-Key header
+	MonthlyLimit int64  `mapstructure:"monthly_limit"` // Requests per calendar month (UTC), 0 for unlimited
+}
+
+// HTTPConfig holds request limits for the HTTP server.
+type HTTPConfig struct {
+	BodyLimit int               `mapstructure:"body_limit"` // Server-wide maximum request body (bytes)
+	Search    RouteLimitsConfig `mapstructure:"search"`     // /api/v1/contents
+	Admin     RouteLimitsConfig `mapstructure:"admin"`      // /api/v1/admin (except sync)
+	Sync      RouteLimitsConfig `mapstructure:"sync"`       // /api/v1/admin/sync
+
+	Compression CompressionConfig `mapstructure:"compression"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	Security    SecurityConfig    `mapstructure:"security"`
+	TLS         TLSConfig         `mapstructure:"tls"`
+}
+
+// TLSConfig holds HTTPS settings for running without a TLS-terminating proxy.
+// Certificates come from CertFile/KeyFile or, when those are empty, from Let's Encrypt.
+type TLSConfig struct {
+	Enabled  bool              `mapstructure:"enabled"`
+	CertFile string            `mapstructure:"cert_file"`
+	KeyFile  string            `mapstructure:"key_file"`
+	Autocert TLSAutocertConfig `mapstructure:"autocert"`
+}
+
+// TLSAutocertConfig holds settings for certificates obtained through ACME.
+type TLSAutocertConfig struct {
+	Hosts    []string `mapstructure:"hosts"`     // Hosts certificates may be requested for
+	CacheDir string   `mapstructure:"cache_dir"` // Certificate storage; empty keeps them in memory
+	Email    string   `mapstructure:"email"`
+}
+
+// CORSConfig holds the cross-origin policy for browser clients.
+type CORSConfig struct {
+	AllowOrigins     []string      `mapstructure:"allow_origins"` // "*" allows any origin
+	AllowMethods     []string      `mapstructure:"allow_methods"`
+	AllowHeaders     []string      `mapstructure:"allow_headers"`
+	ExposeHeaders    []string      `mapstructure:"expose_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"` // Requires explicit origins
+	MaxAge           time.Duration `mapstructure:"max_age"`           // Preflight cache lifetime
+}
+
+// SecurityConfig holds the security headers sent with responses.
+type SecurityConfig struct {
+	HSTSMaxAge            time.Duration `mapstructure:"hsts_max_age"` // Sent over HTTPS only; 0 disables HSTS
+	HSTSIncludeSubdomains bool          `mapstructure:"hsts_include_subdomains"`
+	DashboardCSP          string        `mapstructure:"dashboard_csp"` // Content-Security-Policy of HTML pages
+}
+
+// CompressionConfig holds response compression settings.
+type CompressionConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Level   string `mapstructure:"level"`    // default, best_speed or best_compression
+	MinSize int    `mapstructure:"min_size"` // Smaller responses are sent uncompressed (bytes)
+	Brotli  bool   `mapstructure:"brotli"`   // Prefer br over gzip for clients accepting it
+}
+
+// RouteLimitsConfig bounds handling time and body size for a route group.
+// Zero disables the limit (the body is still capped by HTTPConfig.BodyLimit).
+type RouteLimitsConfig struct {
+	Timeout   time.Duration `mapstructure:"timeout"`
+	BodyLimit int           `mapstructure:"body_limit"`
+}
+
+// SearchConfig holds settings for content searches.
+type SearchConfig struct {
+	// MaxResults bounds how deep result pages may reach (page × page_size); deeper
+	// pages are rejected with 400 rather than scanned past with OFFSET. 0 disables it.
+	MaxResults int `mapstructure:"max_results"`
+
+	// LatencyBudget bounds the database query of a search; searches missing it are
+	// answered degraded instead of failing (see service.WithLatencyBudget). 0 disables it.
+	LatencyBudget time.Duration `mapstructure:"latency_budget"`
+
+	Shadow ShadowConfig `mapstructure:"shadow"`
+}
+
+// ShadowConfig holds settings for shadow traffic: a share of searches executed again
+// in the background with an alternate ranking or backend, comparing their results
+// with the served ones in metrics and logs (see service.WithShadowTraffic).
+type ShadowConfig struct {
+	Percent     float64       `mapstructure:"percent"`       // Share of searches with a query shadowed, 0 to 100; 0 disables it
+	Scoring     string        `mapstructure:"scoring"`       // Ranking of shadow searches (hybrid, text); empty keeps the served one
+	Backend     string        `mapstructure:"backend"`       // Backend of shadow searches (postgres, bleve); empty keeps the served one
+	K           int           `mapstructure:"k"`             // Top results compared; 0 compares whole pages
+	MaxInFlight int           `mapstructure:"max_in_flight"` // Shadow searches running at once per instance; more are skipped
+	Timeout     time.Duration `mapstructure:"timeout"`       // Bounds a shadow search
+}
+
+// SettingsConfig holds settings for runtime settings changed through the admin API.
+type SettingsConfig struct {
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // How often changes made on other instances are loaded
+}
+
+// AuditConfig holds settings for the admin API audit log.
+type AuditConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`   // Record every admin API call in the audit_logs table
+	Retention time.Duration `mapstructure:"retention"` // Entries older than this are removed (0 keeps them)
+}
+
+// AnalyticsConfig holds settings for search analytics ingestion.
+type AnalyticsConfig struct {
+	Enabled bool                 `mapstructure:"enabled"` // Accept impressions and clicks at POST /api/v1/analytics/events
+	Queries QueryAnalyticsConfig `mapstructure:"queries"`
+}
+
+// QueryAnalyticsConfig holds settings for recording executed searches, reported at
+// GET /api/v1/admin/search-analytics.
+type QueryAnalyticsConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Hash          bool          `mapstructure:"hash"`           // Store the SHA-256 of queries instead of their text
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // How often buffered searches are written
+	BufferSize    int           `mapstructure:"buffer_size"`    // Searches buffered between writes; more are dropped
+	Retention     time.Duration `mapstructure:"retention"`      // Searches older than this are removed
+}
+
+// CTRConfig holds settings for the job blending search click-through rates into content scores.
+type CTRConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Interval       time.Duration `mapstructure:"interval"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	Window         time.Duration `mapstructure:"window"`          // Impressions and clicks older than this are ignored
+	Weight         float64       `mapstructure:"weight"`          // Boost of a result clicked on every impression
+	MinImpressions int64         `mapstructure:"min_impressions"` // Contents shown fewer times get no boost
+}
+
+// DictionaryConfig holds settings for the job building the term dictionary behind
+// autocomplete suggestions and "did you mean" corrections.
+type DictionaryConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"` // How often the dictionary is rebuilt, and loaded by the other instances
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// BleveConfig holds settings for the embedded Bleve index answering search queries
+// instead of PostgreSQL full-text search. The search_backend runtime setting switches
+// queries between the two.
+type BleveConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`       // Build the index and keep it up to date
+	ServeQueries bool   `mapstructure:"serve_queries"` // Default of the search_backend setting: bleve when set, postgres otherwise
+	Path         string `mapstructure:"path"`          // Directory of the indexes, recreated on start; empty keeps them in memory
+	MaxMatches   int    `mapstructure:"max_matches"`   // Contents a search query matches at most
+}
+
+// Snapshot stores.
+const (
+	SnapshotStoreFile = "file"
+	SnapshotStoreS3   = "s3"
+)
+
+// SnapshotConfig holds settings for snapshots of the content corpus, written and
+// restored through the admin API and CLI.
+type SnapshotConfig struct {
+	Enabled   bool             `mapstructure:"enabled"`    // Serve the snapshot admin routes
+	Store     string           `mapstructure:"store"`      // file or s3
+	Dir       string           `mapstructure:"dir"`        // Directory of the file store
+	BatchSize int              `mapstructure:"batch_size"` // Contents read or restored per query
+	S3        SnapshotS3Config `mapstructure:"s3"`
+}
+
+// SnapshotS3Config locates the bucket of the s3 snapshot store. Credentials come from
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+type SnapshotS3Config struct {
+	Bucket   string `mapstructure:"bucket"`
+	Prefix   string `mapstructure:"prefix"`   // Prepended to snapshot names, e.g. snapshots/
+	Region   string `mapstructure:"region"`   // Defaults to AWS_REGION
+	Endpoint string `mapstructure:"endpoint"` // For S3-compatible stores, e.g. http://minio:9000
+}
+
+// OutboxConfig holds settings for the outbox of content change events.
+type OutboxConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`       // Store content change events in the outbox with the change
+	PollInterval time.Duration `mapstructure:"poll_interval"` // How often each instance relays stored events
+	BatchSize    int           `mapstructure:"batch_size"`    // Events relayed per transaction
+}
+
+// HealthConfig holds settings for the detailed health endpoint.
+type HealthConfig struct {
+	Timeout        time.Duration `mapstructure:"timeout"`         // Bounds each dependency check of /healthz/details
+	ReadinessCache time.Duration `mapstructure:"readiness_cache"` // Reuse the /readyz database ping for this long (0 disables)
+}
+
+// SLOConfig holds the service level objectives recorded as Prometheus metrics.
+type SLOConfig struct {
+	Enabled    bool                 `mapstructure:"enabled"`
+	Windows    []time.Duration      `mapstructure:"windows"` // Burn rates are reported over each window
+	Objectives []SLOObjectiveConfig `mapstructure:"objectives"`
+}
+
+// SLOObjectiveConfig is a service level objective for a set of routes.
+// A request counts towards the first objective with a matching route prefix.
+type SLOObjectiveConfig struct {
+	Name          string        `mapstructure:"name"`
+	Routes        []string      `mapstructure:"routes"`         // Route prefixes, e.g. /api/v1/contents
+	Availability  float64       `mapstructure:"availability"`   // Target share of non-5xx responses (0 disables)
+	Latency       time.Duration `mapstructure:"latency"`        // Latency threshold (0 disables the latency SLI)
+	LatencyTarget float64       `mapstructure:"latency_target"` // Target share of requests faster than latency
+}
+
+// APIConfig holds API versioning settings.
+type APIConfig struct {
+	V1 APIDeprecationConfig `mapstructure:"v1"` // /api/v1, superseded by /api/v2
+}
+
+// APIDeprecationConfig announces the retirement of an API version.
+// Dates use the YYYY-MM-DD format; an empty date omits its header.
+type APIDeprecationConfig struct {
+	DeprecatedAt string `mapstructure:"deprecated_at"` // Sent as the Deprecation header
+	Sunset       string `mapstructure:"sunset"`        // Sent as the Sunset header; the version may be removed after it
+}
+
+// Dates parses DeprecatedAt and Sunset, returning zero times for empty values.
+func (c *APIDeprecationConfig) Dates() (deprecatedAt, sunset time.Time, err error) {
+	if c.DeprecatedAt != "" {
+		if deprecatedAt, err = time.Parse(time.DateOnly, c.DeprecatedAt); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parsing deprecated_at: %w", err)
+		}
+	}
+	if c.Sunset != "" {
+		if sunset, err = time.Parse(time.DateOnly, c.Sunset); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parsing sunset: %w", err)
+		}
+	}
+
+	return deprecatedAt, sunset, nil
 }
 
 // Load reads configuration from file and environment variables.
 // Priority: env vars > config file > defaults
+//
+// Sensitive settings may hold file:// or secret:// references instead of
+// values, which are resolved here (see secretFields).
 func Load(configPath string) (*Config, error) {
+	v, err := newViper(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshal(v)
+}
+
+// newViper returns a viper instance with the defaults, the config file (if found)
+// and the environment variables.
+func newViper(configPath string) (*viper.Viper, error) {
 	v := viper.New()
 
 	// Set defaults
@@ -149,15 +618,36 @@ func Load(configPath string) (*Config, error) {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 
-	// Unmarshal config
+	return v, nil
+}
+
+// unmarshal decodes the configuration held by v and resolves its secret references.
+func unmarshal(v *viper.Viper) (*Config, error) {
+	// Providers added under providers.<name> are synced unless configured otherwise
+	for name := range v.GetStringMap("providers") {
+		v.SetDefault("providers."+name+".enabled", true)
+	}
+
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
+	for name, p := range cfg.Providers {
+		cfg.Providers[name] = p.withDefaults()
+	}
+	if err := resolveSecrets(context.Background(), &cfg); err != nil {
+		return nil, fmt.Errorf("resolving secrets: %w", err)
+	}
 
 	return &cfg, nil
 }
 
+// defaultDashboardCSP allows the dashboard's own assets and Vue from unpkg. Vue compiles
+// the in-page template at runtime, which needs 'unsafe-eval'.
+const defaultDashboardCSP = "default-src 'self'; script-src 'self' https://unpkg.com 'unsafe-eval'; " +
+	"style-src 'self'; img-src 'self' data:; connect-src 'self'; object-src 'none'; " +
+	"frame-ancestors 'none'; base-uri 'self'; form-action 'self'"
+
 // setDefaults sets default configuration values.
 func setDefaults(v *viper.Viper) {
 	// App defaults
@@ -165,6 +655,10 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("app.env", "development")
 	v.SetDefault("app.port", 8080)
 	v.SetDefault("app.debug", true)
+	v.SetDefault("app.assets_dir", "")
+	v.SetDefault("app.drain_period", "5s")
+	v.SetDefault("app.shutdown_timeout", "10s")
+	v.SetDefault("app.watch_config", true)
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -176,34 +670,55 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 5)
 	v.SetDefault("database.max_lifetime", "5m")
+	v.SetDefault("database.slow_query_threshold", "200ms")
+	v.SetDefault("database.log_queries", false)
+	v.SetDefault("database.copy_threshold", 5000)
+	v.SetDefault("database.upsert_workers", 1)
+	v.SetDefault("database.warm_up_conns", 5)
+	v.SetDefault("database.warm_up_timeout", "10s")
 
-	// Provider A defaults
-	v.SetDefault("provider.a.base_url", "http://localhost:8081")
-	v.SetDefault("provider.a.timeout", "10s")
-	v.SetDefault("provider.a.retry.max_attempts", 3)
-	v.SetDefault("provider.a.retry.wait_time", "1s")
-	v.SetDefault("provider.a.retry.max_wait_time", "5s")
-	v.SetDefault("provider.a.circuit_breaker.max_requests", 3)
-	v.SetDefault("provider.a.circuit_breaker.interval", "60s")
-	v.SetDefault("provider.a.circuit_breaker.timeout", "30s")
-	v.SetDefault("provider.a.circuit_breaker.failure_ratio", 0.5)
-
-	// Provider B defaults
-	v.SetDefault("provider.b.base_url", "http://localhost:8082")
-	v.SetDefault("provider.b.timeout", "10s")
-	v.SetDefault("provider.b.retry.max_attempts", 3)
-	v.SetDefault("provider.b.retry.wait_time", "1s")
-	v.SetDefault("provider.b.retry.max_wait_time", "5s")
-	v.SetDefault("provider.b.circuit_breaker.max_requests", 3)
-	v.SetDefault("provider.b.circuit_breaker.interval", "60s")
-	v.SetDefault("provider.b.circuit_breaker.timeout", "30s")
-	v.SetDefault("provider.b.circuit_breaker.failure_ratio", 0.5)
+	// Provider defaults
+	v.SetDefault("provider.health_cache_ttl", "5s")
+	v.SetDefault("provider.record_dir", "")
+	v.SetDefault("provider.record_keep", 20)
+
+	// Provider defaults; more providers are added under providers.<name>, and
+	// unset settings of any provider default to defaultProviderEndpoint
+	for name, p := range map[string]struct{ baseURL string }{
+		"provider_a": {"http://localhost:8081"},
+		"provider_b": {"http://localhost:8082"},
+	} {
+		key := "providers." + name + "."
+		d := defaultProviderEndpoint
+		v.SetDefault(key+"type", name)
+		v.SetDefault(key+"disabled", false)
+		v.SetDefault(key+"enabled", true)
+		v.SetDefault(key+"base_url", p.baseURL)
+		v.SetDefault(key+"endpoint", "")
+		v.SetDefault(key+"api_key", "")
+		v.SetDefault(key+"timeout", d.Timeout)
+		v.SetDefault(key+"retry.max_attempts", d.Retry.MaxAttempts)
+		v.SetDefault(key+"retry.wait_time", d.Retry.WaitTime)
+		v.SetDefault(key+"retry.max_wait_time", d.Retry.MaxWaitTime)
+		v.SetDefault(key+"circuit_breaker.max_requests", d.CB.MaxRequests)
+		v.SetDefault(key+"circuit_breaker.interval", d.CB.Interval)
+		v.SetDefault(key+"circuit_breaker.timeout", d.CB.Timeout)
+		v.SetDefault(key+"circuit_breaker.failure_ratio", d.CB.FailureRatio)
+		v.SetDefault(key+"max_body_size", d.MaxBodySize)
+		v.SetDefault(key+"strict", false)
+		v.SetDefault(key+"replay", "")
+	}
 
 	// Sync defaults
 	v.SetDefault("sync.interval", "5m")
 	v.SetDefault("sync.on_startup", true)
 	v.SetDefault("sync.timeout", "30s")
-	v.SetDefault("sync.batch_size", 100)
+	v.SetDefault("sync.batch_size", 1000)
+	v.SetDefault("sync.pipeline_buffer", 256)
+	v.SetDefault("sync.lock_wait", "30s")
+	v.SetDefault("sync.shutdown_wait", "5s")
+	v.SetDefault("sync.failure_alert_threshold", 3)
+	v.SetDefault("sync.future_publish", "clamp")
 
 	// Logger defaults
 	v.SetDefault("logger.level", "info")
@@ -217,13 +732,174 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("sentry.sample_rate", 1.0)
 
 	// Redis defaults
+	v.SetDefault("redis.enabled", true)
 	v.SetDefault("redis.host", "localhost")
 	v.SetDefault("redis.port", 6379)
 	v.SetDefault("redis.password", "")
 	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.read_timeout", "3s")
+	v.SetDefault("redis.write_timeout", "3s")
+
+	// Lock defaults
+	v.SetDefault("lock.backend", LockBackendRedis)
+	v.SetDefault("lock.etcd.endpoints", []string{"localhost:2379"})
+	v.SetDefault("lock.etcd.username", "")
+	v.SetDefault("lock.etcd.password", "")
+	v.SetDefault("lock.etcd.dial_timeout", "5s")
 
 	// Cache defaults
 	v.SetDefault("cache.enabled", false)
 	v.SetDefault("cache.search_ttl", "15m")
+	v.SetDefault("cache.content_ttl", "30m")
+	v.SetDefault("cache.not_found_ttl", "30s")
+	v.SetDefault("cache.stats_ttl", "1m")
+	v.SetDefault("cache.stale_ttl", "0s")
+	v.SetDefault("cache.degraded_ttl", "0s")
 	v.SetDefault("cache.key_prefix", "search-engine")
+	v.SetDefault("cache.version", 1)
+	v.SetDefault("cache.compression_threshold", 1024)
+	v.SetDefault("cache.warm.enabled", false)
+	v.SetDefault("cache.warm.queries", []string{""})
+	v.SetDefault("cache.warm.top_n", 10)
+	v.SetDefault("cache.local.enabled", false)
+	v.SetDefault("cache.local.max_entries", 1000)
+	v.SetDefault("cache.local.ttl", "30s")
+
+	// Auth defaults
+	v.SetDefault("auth.enabled", false)
+	v.SetDefault("auth.admin_role", "admin")
+	v.SetDefault("auth.jwt.secret", "")
+	v.SetDefault("auth.jwt.jwks_url", "")
+	v.SetDefault("auth.jwt.jwks_refresh", "1h")
+	v.SetDefault("auth.jwt.issuer", "")
+	v.SetDefault("auth.jwt.audience", "")
+	v.SetDefault("auth.jwt.roles_claim", "roles")
+	v.SetDefault("auth.jwt.leeway", "30s")
+
+	// Webhook defaults
+	v.SetDefault("webhook.workers", 4)
+	v.SetDefault("webhook.queue_size", 10000)
+	v.SetDefault("webhook.max_attempts", 5)
+	v.SetDefault("webhook.backoff", "1s")
+	v.SetDefault("webhook.timeout", "10s")
+
+	// Idempotency defaults
+	v.SetDefault("idempotency.enabled", true)
+	v.SetDefault("idempotency.ttl", "24h")
+
+	// Quota defaults
+	v.SetDefault("quota.enabled", false)
+	v.SetDefault("quota.require_key", false)
+
+	// HTTP defaults
+	v.SetDefault("http.body_limit", 1024*1024)
+	v.SetDefault("http.search.timeout", "2s")
+	v.SetDefault("http.search.body_limit", 0)
+	v.SetDefault("http.admin.timeout", "10s")
+	v.SetDefault("http.admin.body_limit", 64*1024)
+	v.SetDefault("http.sync.timeout", "60s")
+	v.SetDefault("http.sync.body_limit", 0)
+	v.SetDefault("http.compression.enabled", true)
+	v.SetDefault("http.compression.level", "default")
+	v.SetDefault("http.compression.min_size", 1024)
+	v.SetDefault("http.compression.brotli", true)
+	v.SetDefault("http.cors.allow_origins", []string{"*"})
+	v.SetDefault("http.cors.allow_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	v.SetDefault("http.cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization", "Idempotency-Key", "X-API-Key", "X-Refresh", "X-Request-ID", "traceparent"})
+	v.SetDefault("http.cors.expose_headers", []string{
+		"X-Request-ID", "Idempotent-Replayed", "Deprecation", "Sunset", "Link",
+		"X-Quota-Limit", "X-Quota-Remaining", "X-Quota-Reset", "Retry-After",
+	})
+	v.SetDefault("http.cors.allow_credentials", false)
+	v.SetDefault("http.cors.max_age", "24h")
+	v.SetDefault("http.security.hsts_max_age", "8760h")
+	v.SetDefault("http.security.hsts_include_subdomains", false)
+	v.SetDefault("http.security.dashboard_csp", defaultDashboardCSP)
+	v.SetDefault("slo.enabled", true)
+	v.SetDefault("slo.windows", []string{"5m", "30m", "1h", "6h"})
+	v.SetDefault("slo.objectives", []map[string]any{
+		{
+			"name":           "search",
+			"routes":         []string{"/api/v1/contents", "/api/v2/contents", "/dashboard"},
+			"availability":   0.999,
+			"latency":        "300ms",
+			"latency_target": 0.99,
+		},
+		{
+			"name":         "admin",
+			"routes":       []string{"/api/v1/admin", "/api/v2/admin"},
+			"availability": 0.99,
+		},
+	})
+	v.SetDefault("http.tls.enabled", false)
+	v.SetDefault("http.tls.cert_file", "")
+	v.SetDefault("http.tls.key_file", "")
+	v.SetDefault("http.tls.autocert.hosts", []string{})
+	v.SetDefault("http.tls.autocert.cache_dir", "")
+	v.SetDefault("http.tls.autocert.email", "")
+
+	// API versioning defaults
+	v.SetDefault("api.v1.deprecated_at", "2026-10-16")
+	v.SetDefault("api.v1.sunset", "2027-04-30")
+
+	// Runtime settings defaults
+	v.SetDefault("settings.refresh_interval", "10s")
+
+	// Audit log defaults
+	v.SetDefault("audit.enabled", true)
+	v.SetDefault("audit.retention", "2160h")
+	v.SetDefault("analytics.enabled", true)
+	v.SetDefault("analytics.queries.enabled", true)
+	v.SetDefault("analytics.queries.hash", false)
+	v.SetDefault("analytics.queries.flush_interval", "10s")
+	v.SetDefault("analytics.queries.buffer_size", 10000)
+	v.SetDefault("analytics.queries.retention", "720h")
+
+	// CTR feedback defaults
+	v.SetDefault("ctr.enabled", true)
+	v.SetDefault("ctr.interval", "1h")
+	v.SetDefault("ctr.timeout", "2m")
+	v.SetDefault("ctr.window", "720h")
+	v.SetDefault("ctr.weight", 20)
+	v.SetDefault("ctr.min_impressions", 100)
+
+	// Search defaults
+	v.SetDefault("search.max_results", 10000)
+	v.SetDefault("search.latency_budget", "0s")
+	v.SetDefault("search.shadow.percent", 0)
+	v.SetDefault("search.shadow.scoring", "")
+	v.SetDefault("search.shadow.backend", "")
+	v.SetDefault("search.shadow.k", 10)
+	v.SetDefault("search.shadow.max_in_flight", 4)
+	v.SetDefault("search.shadow.timeout", "5s")
+
+	// Term dictionary defaults
+	v.SetDefault("dictionary.enabled", true)
+	v.SetDefault("dictionary.interval", "1h")
+	v.SetDefault("dictionary.timeout", "2m")
+
+	// Bleve index defaults
+	v.SetDefault("bleve.enabled", false)
+	v.SetDefault("bleve.serve_queries", true)
+	v.SetDefault("bleve.path", "")
+	v.SetDefault("bleve.max_matches", 10000)
+
+	// Snapshot defaults
+	v.SetDefault("snapshot.enabled", false)
+	v.SetDefault("snapshot.store", SnapshotStoreFile)
+	v.SetDefault("snapshot.dir", "./snapshots")
+	v.SetDefault("snapshot.batch_size", 1000)
+	v.SetDefault("snapshot.s3.bucket", "")
+	v.SetDefault("snapshot.s3.prefix", "snapshots/")
+	v.SetDefault("snapshot.s3.region", "")
+	v.SetDefault("snapshot.s3.endpoint", "")
+
+	// Outbox defaults
+	v.SetDefault("outbox.enabled", true)
+	v.SetDefault("outbox.poll_interval", "1s")
+	v.SetDefault("outbox.batch_size", 100)
+
+	// Health defaults
+	v.SetDefault("health.timeout", "2s")
+	v.SetDefault("health.readiness_cache", "1s")
 }
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Providers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `providers:
+  provider_a:
+    enabled: false
+  provider_b:
+    disabled: true
+  partner_news:
+    type: provider_b
+    base_url: https://news.example.com
+    endpoint: /rss
+    timeout: 3s
+    circuit_breaker:
+      failure_ratio: 0.8
+    max_body_size: 1048576
+`)
+	t.Setenv("APP_PROVIDERS_PROVIDER_A_BASE_URL", "http://provider-a:8081")
+	t.Setenv("APP_PROVIDERS_PROVIDER_A_REPLAY", "/recordings/provider_a.http")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Providers, 3, "the default providers are kept")
+
+	a := cfg.Providers["provider_a"]
+	assert.Equal(t, "provider_a", a.Type)
+	assert.Equal(t, "http://provider-a:8081", a.BaseURL, "env vars override defaults")
+	assert.Equal(t, defaultProviderEndpoint.Retry, a.Retry)
+	assert.Equal(t, defaultProviderEndpoint.MaxBodySize, a.MaxBodySize)
+	assert.Equal(t, "/recordings/provider_a.http", a.Replay)
+	assert.False(t, a.Enabled)
+	assert.Empty(t, cfg.Provider.RecordDir, "recording is off by default")
+	assert.Equal(t, 20, cfg.Provider.RecordKeep)
+
+	assert.True(t, cfg.Providers["provider_b"].Disabled)
+
+	news := cfg.Providers["partner_news"]
+	assert.Equal(t, "provider_b", news.Type)
+	assert.Equal(t, "/rss", news.Endpoint)
+	assert.Equal(t, 3*time.Second, news.Timeout)
+	assert.Equal(t, defaultProviderEndpoint.Retry, news.Retry, "unset settings take the defaults")
+	assert.Equal(t, CBConfig{MaxRequests: 3, Interval: time.Minute, Timeout: 30 * time.Second, FailureRatio: 0.8}, news.CB)
+	assert.Equal(t, int64(1<<20), news.MaxBodySize)
+	assert.True(t, news.Enabled, "added providers are synced unless configured otherwise")
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Reloader watches the config file and hands every change to its subscribers, which
+// apply the settings that are safe to change at runtime (e.g. the log level). Other
+// changes take effect on the next restart.
+//
+// A reloaded configuration is decoded with the same defaults and environment
+// variables as Load, so env overrides keep their precedence.
+type Reloader struct {
+	configPath string
+	logger     *zap.Logger
+
+	mu          sync.Mutex // Serializes reloads and subscriptions
+	current     *Config
+	subscribers []reloadSubscriber
+}
+
+// reloadSubscriber applies part of a reloaded configuration.
+type reloadSubscriber struct {
+	name  string
+	apply func(old, updated *Config) error
+}
+
+// NewReloader creates a Reloader for the configuration loaded from configPath
+// (see Load). Call Start to begin watching.
+func NewReloader(configPath string, current *Config, logger *zap.Logger) *Reloader {
+	return &Reloader{
+		configPath: configPath,
+		current:    current,
+		logger:     logger,
+	}
+}
+
+// Subscribe registers apply to be called with the previous and the reloaded
+// configuration after every change of the config file. apply should compare the
+// settings it handles and only act on changes. An error rejects the whole change:
+// the subscribers that applied it already are called again with the configurations
+// swapped, so apply must also be able to undo what it did.
+func (r *Reloader) Subscribe(name string, apply func(old, updated *Config) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, reloadSubscriber{name: name, apply: apply})
+}
+
+// Start begins watching the config file. Without a config file there is nothing
+// to watch and Start only logs that.
+func (r *Reloader) Start() error {
+	v, err := newViper(r.configPath)
+	if err != nil {
+		return err
+	}
+	if v.ConfigFileUsed() == "" {
+		r.logger.Info("no config file found, configuration reload disabled")
+
+		return nil
+	}
+
+	v.OnConfigChange(func(fsnotify.Event) { r.reload(v) })
+	v.WatchConfig()
+
+	r.logger.Info("watching config file for changes", zap.String("file", v.ConfigFileUsed()))
+
+	return nil
+}
+
+// reload decodes the configuration re-read by v and passes it to the subscribers.
+// Invalid files are ignored, keeping the current configuration.
+func (r *Reloader) reload(v *viper.Viper) {
+	updated, err := unmarshal(v)
+	if err != nil {
+		r.logger.Warn("ignoring invalid config file change", zap.Error(err))
+
+		return
+	}
+
+	r.apply(updated)
+}
+
+// apply passes updated to every subscriber and makes it the current configuration.
+// Should a subscriber reject it, the subscribers that applied it are rolled back and
+// the current configuration is kept, so the next change is compared with the
+// settings in effect.
+func (r *Reloader) apply(updated *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, s := range r.subscribers {
+		if err := applySafely(s, r.current, updated); err != nil {
+			r.logger.Warn("configuration change rejected, keeping the current configuration",
+				zap.String("subscriber", s.name),
+				zap.Error(err),
+			)
+			r.rollback(r.subscribers[:i], updated)
+
+			return
+		}
+	}
+	r.current = updated
+
+	r.logger.Info("configuration reloaded")
+}
+
+// rollback undoes updated in the subscribers that applied it, last first.
+func (r *Reloader) rollback(applied []reloadSubscriber, updated *Config) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := applySafely(applied[i], updated, r.current); err != nil {
+			r.logger.Error("failed to roll back configuration change",
+				zap.String("subscriber", applied[i].name),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// applySafely calls the subscriber, turning a panic into an error so a broken
+// subscriber can't take down the watcher.
+func applySafely(s reloadSubscriber, old, updated *Config) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return s.apply(old, updated)
+}
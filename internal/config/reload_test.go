@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReloader_AppliesConfigFileChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "logger:\n  level: info\nsync:\n  interval: 1h\n")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "info", cfg.Logger.Level)
+
+	var mu sync.Mutex
+	var levels []string
+	r := NewReloader(path, cfg, zap.NewNop())
+	r.Subscribe("logger", func(old, updated *Config) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if updated.Logger.Level != old.Logger.Level {
+			levels = append(levels, updated.Logger.Level)
+		}
+
+		return nil
+	})
+	require.NoError(t, r.Start())
+
+	writeConfigFile(t, path, "logger:\n  level: debug\nsync:\n  interval: 1h\n")
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(levels) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"debug"}, levels)
+}
+
+func TestReloader_RejectedChangesAreRolledBack(t *testing.T) {
+	current := &Config{Logger: LoggerConfig{Level: "info"}}
+	r := NewReloader("", current, zap.NewNop())
+
+	var levels []string
+	r.Subscribe("logger", func(old, updated *Config) error {
+		if updated.Logger.Level != old.Logger.Level {
+			levels = append(levels, updated.Logger.Level)
+		}
+
+		return nil
+	})
+	reject := true
+	r.Subscribe("sync", func(_, updated *Config) error {
+		if reject && updated.Sync.Interval <= 0 {
+			return errors.New("sync.interval must be positive")
+		}
+
+		return nil
+	})
+	var later int
+	r.Subscribe("later", func(*Config, *Config) error {
+		later++
+
+		return nil
+	})
+
+	r.apply(&Config{Logger: LoggerConfig{Level: "warn"}})
+
+	assert.Equal(t, []string{"warn", "info"}, levels, "the applied level is rolled back")
+	assert.Zero(t, later, "subscribers after the rejecting one aren't called")
+	assert.Same(t, current, r.current, "the rejected configuration doesn't become current")
+
+	reject = false
+	updated := &Config{Logger: LoggerConfig{Level: "warn"}}
+	r.apply(updated)
+
+	assert.Equal(t, []string{"warn", "info", "warn"}, levels, "the next change is compared with the settings in effect")
+	assert.Equal(t, 1, later)
+	assert.Same(t, updated, r.current)
+}
+
+func TestReloader_PanickingSubscriberRejectsTheChange(t *testing.T) {
+	current := &Config{}
+	r := NewReloader("", current, zap.NewNop())
+	r.Subscribe("panicking", func(*Config, *Config) error { panic("boom") })
+
+	assert.NotPanics(t, func() { r.apply(&Config{Logger: LoggerConfig{Level: "warn"}}) })
+	assert.Same(t, current, r.current)
+}
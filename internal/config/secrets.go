@@ -0,0 +1,349 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Secret references. A sensitive setting (see secretFields) may hold a reference
+// instead of the value, which is resolved when the configuration is loaded:
+//
+//	file:///run/secrets/db_password            file content, without the trailing newline
+//	secret://vault/<mount>/<path>#<key>        field of a Vault KV v2 secret
+//	secret://aws/<secret-id>[#<key>]           AWS Secrets Manager secret, or a field of its JSON
+const (
+	fileRefPrefix   = "file://"
+	secretRefPrefix = "secret://"
+)
+
+// secretResolveTimeout bounds resolving all references of a configuration.
+const secretResolveTimeout = 30 * time.Second
+
+// secretHTTPClient fetches secrets from Vault and AWS.
+var secretHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// secretStores resolve secret:// references by store name, given the rest of the
+// reference (e.g. "kv/search-engine#db_password" for secret://vault/kv/search-engine#db_password).
+var secretStores = map[string]func(ctx context.Context, ref string) (string, error){
+	"vault": resolveVaultSecret,
+	"aws":   resolveAWSSecret,
+}
+
+// secretField is a setting that may hold a secret reference.
+type secretField struct {
+	key   string
+	value *string
+}
+
+// secretFields returns the settings that may hold secret references, except the
+// providers' API keys and the quotas' API keys.
+func (c *Config) secretFields() []secretField {
+	return []secretField{
+		{"database.password", &c.Database.Password},
+		{"redis.password", &c.Redis.Password},
+		{"sentry.dsn", &c.Sentry.DSN},
+		{"lock.etcd.password", &c.Lock.Etcd.Password},
+		{"auth.jwt.secret", &c.Auth.JWT.Secret},
+	}
+}
+
+// resolveSecrets replaces the secret references in cfg with the values they point to.
+// A reference used by several settings is resolved once.
+func resolveSecrets(ctx context.Context, cfg *Config) error {
+	ctx, cancel := context.WithTimeout(ctx, secretResolveTimeout)
+	defer cancel()
+
+	resolved := make(map[string]string)
+	resolve := func(f secretField) error {
+		ref := *f.value
+		if !isSecretRef(ref) {
+			return nil
+		}
+
+		value, ok := resolved[ref]
+		if !ok {
+			var err error
+			if value, err = resolveSecret(ctx, ref); err != nil {
+				return fmt.Errorf("resolving %s: %w", f.key, err)
+			}
+			resolved[ref] = value
+		}
+		*f.value = value
+
+		return nil
+	}
+
+	for _, f := range cfg.secretFields() {
+		if err := resolve(f); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Providers)) {
+		p := cfg.Providers[name]
+		if err := resolve(secretField{"providers." + name + ".api_key", &p.APIKey}); err != nil {
+			return err
+		}
+		cfg.Providers[name] = p
+	}
+	for i := range cfg.Quota.Keys {
+		k := &cfg.Quota.Keys[i]
+		if err := resolve(secretField{"quota.keys." + k.Name + ".key", &k.Key}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isSecretRef reports whether value is a secret reference rather than a literal value.
+func isSecretRef(value string) bool {
+	return strings.HasPrefix(value, fileRefPrefix) || strings.HasPrefix(value, secretRefPrefix)
+}
+
+// resolveSecret returns the value ref points to. Errors don't include the value.
+func resolveSecret(ctx context.Context, ref string) (string, error) {
+	if path, ok := strings.CutPrefix(ref, fileRefPrefix); ok {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading secret file: %w", err)
+		}
+
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	store, rest, _ := strings.Cut(strings.TrimPrefix(ref, secretRefPrefix), "/")
+	resolve, ok := secretStores[store]
+	if !ok {
+		return "", fmt.Errorf("unknown secret store %q (want vault or aws)", store)
+	}
+
+	return resolve(ctx, rest)
+}
+
+// resolveVaultSecret reads a field of a KV v2 secret, ref being "<mount>/<path>#<key>".
+// The server and token come from VAULT_ADDR and VAULT_TOKEN; VAULT_NAMESPACE is
+// sent when set (Vault Enterprise).
+func resolveVaultSecret(ctx context.Context, ref string) (string, error) {
+	path, key, _ := strings.Cut(ref, "#")
+	mount, secretPath, _ := strings.Cut(path, "/")
+	if mount == "" || secretPath == "" || key == "" {
+		return "", fmt.Errorf("invalid vault reference %q (want secret://vault/<mount>/<path>#<key>)", ref)
+	}
+
+	addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("vault secret %s: VAULT_ADDR and VAULT_TOKEN must be set", path)
+	}
+
+	endpoint, err := url.JoinPath(addr, "v1", mount, "data", secretPath)
+	if err != nil {
+		return "", fmt.Errorf("vault secret %s: %w", path, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("vault secret %s: %w", path, err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := fetchSecret(req, &secret); err != nil {
+		return "", fmt.Errorf("vault secret %s: %w", path, err)
+	}
+
+	value, ok := secret.Data.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no string field %q", path, key)
+	}
+
+	return value, nil
+}
+
+// resolveAWSSecret reads an AWS Secrets Manager secret, ref being "<secret-id>[#<key>]"
+// where the secret ID is a name or an ARN. With a key, the secret must be a JSON
+// object and the key's value is returned.
+//
+// Credentials come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN,
+// the region from AWS_REGION or AWS_DEFAULT_REGION. AWS_ENDPOINT_URL_SECRETS_MANAGER
+// overrides the endpoint (e.g. for LocalStack).
+func resolveAWSSecret(ctx context.Context, ref string) (string, error) {
+	secretID, key, _ := strings.Cut(ref, "#")
+	if secretID == "" {
+		return "", fmt.Errorf("invalid aws reference %q (want secret://aws/<secret-id>[#<key>])", ref)
+	}
+
+	creds := awsCredentials{
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.accessKeyID == "" || creds.secretAccessKey == "" {
+		return "", fmt.Errorf("aws secret %s: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set", secretID)
+	}
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		return "", fmt.Errorf("aws secret %s: AWS_REGION must be set", secretID)
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_SECRETS_MANAGER")
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + region + ".amazonaws.com"
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", fmt.Errorf("aws secret %s: %w", secretID, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("aws secret %s: %w", secretID, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signAWSRequest(req, body, creds, region, "secretsmanager", time.Now())
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := fetchSecret(req, &secret); err != nil {
+		return "", fmt.Errorf("aws secret %s: %w", secretID, err)
+	}
+	if secret.SecretString == nil {
+		return "", fmt.Errorf("aws secret %s is binary, only string secrets are supported", secretID)
+	}
+	if key == "" {
+		return *secret.SecretString, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(*secret.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("aws secret %s is not a JSON object", secretID)
+	}
+	value, ok := fields[key].(string)
+	if !ok {
+		return "", fmt.Errorf("aws secret %s has no string field %q", secretID, key)
+	}
+
+	return value, nil
+}
+
+// fetchSecret sends req and decodes the JSON response into out. The response body
+// isn't included in errors since it may contain secrets.
+func fetchSecret(req *http.Request, out any) error {
+	resp, err := secretHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// awsCredentials are static AWS credentials.
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// signAWSRequest adds an AWS Signature Version 4 Authorization header to req, whose
+// path has no query string and whose body is body.
+func signAWSRequest(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	// Headers are signed in lexical order of their lower-cased names
+	headers := []string{"content-type", "host", "x-amz-date"}
+	if creds.sessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	headers = append(headers, "x-amz-target")
+
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		value := req.Header.Get(h)
+		if h == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(h + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"", // Query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(creds.secretAccessKey, date, region, service), stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// awsSigningKey derives the Signature Version 4 key for a day, region and service.
+func awsSigningKey(secretAccessKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
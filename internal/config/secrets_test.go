@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ResolvesFileReferences(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "db_password")
+	writeConfigFile(t, secretPath, "s3cret\n")
+
+	path := filepath.Join(dir, "config.yaml")
+	writeConfigFile(t, path, "database:\n  password: file://"+secretPath+"\n")
+	t.Setenv("APP_REDIS_PASSWORD", "file://"+secretPath)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.Database.Password)
+	assert.Equal(t, "s3cret", cfg.Redis.Password, "env vars may hold references too")
+}
+
+func TestLoad_FailsOnUnresolvableReference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "sentry:\n  dsn: file:///nonexistent/sentry_dsn\n")
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sentry.dsn")
+}
+
+func TestResolveSecrets_LeavesLiteralValues(t *testing.T) {
+	cfg := &Config{Database: DatabaseConfig{Password: "plain"}}
+
+	require.NoError(t, resolveSecrets(context.Background(), cfg))
+	assert.Equal(t, "plain", cfg.Database.Password)
+}
+
+func TestResolveSecrets_UnknownStore(t *testing.T) {
+	cfg := &Config{Redis: RedisConfig{Password: "secret://gcp/projects/p/secrets/redis"}}
+
+	err := resolveSecrets(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown secret store")
+}
+
+func TestResolveSecrets_Vault(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "vault-token" || r.URL.Path != "/v1/kv/data/search-engine/prod" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"data":{"db_password":"from-vault","redis_password":"r"},"metadata":{"version":3}}}`)
+	}))
+	defer srv.Close()
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	cfg := &Config{
+		Database: DatabaseConfig{Password: "secret://vault/kv/search-engine/prod#db_password"},
+		Lock:     LockConfig{Etcd: EtcdConfig{Password: "secret://vault/kv/search-engine/prod#db_password"}},
+	}
+	require.NoError(t, resolveSecrets(context.Background(), cfg))
+	assert.Equal(t, "from-vault", cfg.Database.Password)
+	assert.Equal(t, "from-vault", cfg.Lock.Etcd.Password)
+	assert.Equal(t, 1, requests, "a reference is resolved once")
+
+	cfg = &Config{Database: DatabaseConfig{Password: "secret://vault/kv/search-engine/prod#missing"}}
+	assert.Error(t, resolveSecrets(context.Background(), cfg), "missing field")
+
+	cfg = &Config{Database: DatabaseConfig{Password: "secret://vault/kv/search-engine/prod"}}
+	assert.Error(t, resolveSecrets(context.Background(), cfg), "missing key")
+}
+
+func TestResolveSecrets_AWS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(auth, "/eu-west-1/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		var body struct{ SecretId string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body.SecretId {
+		case "prod/sentry":
+			_, _ = io.WriteString(w, `{"SecretString":"https://key@sentry.example.com/1"}`)
+		case "prod/db":
+			_, _ = io.WriteString(w, `{"SecretString":"{\"password\":\"from-aws\"}"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL_SECRETS_MANAGER", srv.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	cfg := &Config{
+		Sentry:   SentryConfig{DSN: "secret://aws/prod/sentry"},
+		Database: DatabaseConfig{Password: "secret://aws/prod/db#password"},
+	}
+	require.NoError(t, resolveSecrets(context.Background(), cfg))
+	assert.Equal(t, "https://key@sentry.example.com/1", cfg.Sentry.DSN)
+	assert.Equal(t, "from-aws", cfg.Database.Password)
+
+	cfg = &Config{Database: DatabaseConfig{Password: "secret://aws/prod/unknown"}}
+	err := resolveSecrets(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 400")
+}
+
+func TestAWSSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AnalyticsEventType is the kind of interaction recorded for a search result.
+type AnalyticsEventType string
+
+const (
+	AnalyticsEventImpression AnalyticsEventType = "impression" // The result was shown
+	AnalyticsEventClick      AnalyticsEventType = "click"      // The result was opened
+)
+
+// IsValid checks if the event type is known.
+func (t AnalyticsEventType) IsValid() bool {
+	return t == AnalyticsEventImpression || t == AnalyticsEventClick
+}
+
+// AnalyticsEvent records a search result being shown or clicked. Impressions and
+// clicks sharing a query and content ID give the result's click-through rate.
+type AnalyticsEvent struct {
+	ID         string             `json:"id"`
+	Type       AnalyticsEventType `json:"type"`
+	Query      string             `json:"query"` // Search query the result was listed for ("" for the default listing)
+	ContentID  string             `json:"content_id"`
+	Position   int                `json:"position"`   // 1-based rank within the result list
+	SessionID  string             `json:"session_id"` // Opaque client session, optional
+	RequestID  string             `json:"request_id"` // Request that reported the event
+	OccurredAt time.Time          `json:"occurred_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// ContentCTR is the number of impressions and clicks recorded for a content.
+type ContentCTR struct {
+	ContentID   string
+	Impressions int64
+	Clicks      int64
+}
+
+// SearchQuery is an executed search, recorded for query analytics.
+type SearchQuery struct {
+	Query      string        // Normalized query, or its SHA-256 when queries are stored hashed
+	Total      int64         // Contents matching the search
+	Latency    time.Duration // Time to serve the search, from the cache or the database
+	OccurredAt time.Time
+}
+
+// QueryStat summarizes the recorded searches for a query.
+type QueryStat struct {
+	Query          string
+	Searches       int64
+	AvgResults     float64
+	AvgLatency     time.Duration
+	LastSearchedAt time.Time
+}
+
+// SearchAnalytics reports the most frequent queries searched since a time, e.g. to
+// find missing synonyms (frequent queries finding nothing) and content gaps.
+type SearchAnalytics struct {
+	Since       time.Time
+	Hashed      bool        // Queries are SHA-256 hashes of the normalized queries
+	Top         []QueryStat // Most searched queries, most frequent first
+	ZeroResults []QueryStat // Most searched queries matching no content, most frequent first
+}
+
+// NormalizeQuery lowercases q and collapses its whitespace, so searches differing
+// only in case or spacing count as the same query.
+func NormalizeQuery(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), " ")
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+)
+
+// AuditEntry records a single admin API call.
+type AuditEntry struct {
+	ID         string         `json:"id"`
+	Actor      string         `json:"actor"` // JWT subject, "anonymous" when auth is disabled or failed
+	Method     string         `json:"method"`
+	Path       string         `json:"path"`
+	Route      string         `json:"route"`  // Matched route pattern, e.g. /api/v1/admin/webhooks/:id
+	Params     map[string]any `json:"params"` // Query and body parameters with secrets redacted, and the result of some calls
+	Status     int            `json:"status"`
+	DurationMs int64          `json:"duration_ms"`
+	IP         string         `json:"ip"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// AuditFilter narrows an audit log query. Zero values don't filter.
+type AuditFilter struct {
+	Actor    string
+	Method   string
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	Page     int       // 1-indexed
+	PageSize int
+}
+
+// Offset calculates the database offset for pagination.
+func (f *AuditFilter) Offset() int {
+	return (f.Page - 1) * f.PageSize
+}
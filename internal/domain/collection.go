@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// Collection is an admin-managed, ordered group of contents, e.g. the parts of a
+// tutorial series or a playlist.
+type Collection struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	ContentIDs  []string  `json:"content_ids"` // In collection order
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
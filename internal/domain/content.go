@@ -3,6 +3,11 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"time"
 )
 
@@ -12,6 +17,8 @@ type ContentType string
 const (
 	ContentTypeVideo   ContentType = "video"
 	ContentTypeArticle ContentType = "article"
+	ContentTypePodcast ContentType = "podcast"
+	ContentTypeImage   ContentType = "image"
 )
 
 // Content represents a unified content entity from any provider.
@@ -23,25 +30,38 @@ type Content struct {
 	ExternalID string `json:"external_id"` // ID from the provider (unique per provider)
 
 	// Content metadata
-	Title string      `json:"title"`
-	Type  ContentType `json:"type"` // video, article
-	Tags  []string    `json:"tags,omitempty"`
+	Title        string      `json:"title"`
+	Type         ContentType `json:"type"` // video, article, podcast, image
+	Tags         []string    `json:"tags,omitempty"`
+	URL          string      `json:"url,omitempty"`           // Link to the item at the provider
+	ThumbnailURL string      `json:"thumbnail_url,omitempty"` // Preview image
+	Author       string      `json:"author,omitempty"`
 
 	// Metrics (varies by content type)
-	Views       int    `json:"views,omitempty"`        // Video: view count
-	Likes       int    `json:"likes,omitempty"`        // Video: like count
-	Duration    string `json:"duration,omitempty"`     // Video: duration (e.g., "15:30")
-	ReadingTime int    `json:"reading_time,omitempty"` // Article: reading time in minutes
-	Reactions   int    `json:"reactions,omitempty"`    // Article: reaction count
-	Comments    int    `json:"comments,omitempty"`     // Article: comment count
+	Views           int    `json:"views,omitempty"`            // Video, image: view count
+	Likes           int    `json:"likes,omitempty"`            // Video, podcast, image: like count
+	Duration        string `json:"duration,omitempty"`         // Video, podcast: duration as sent by the provider (e.g., "15:30")
+	DurationSeconds int    `json:"duration_seconds,omitempty"` // Video, podcast: parsed duration, 0 if unknown (see SetDuration)
+	Listens         int    `json:"listens,omitempty"`          // Podcast: listen count
+	ReadingTime     int    `json:"reading_time,omitempty"`     // Article: reading time in minutes
+	Reactions       int    `json:"reactions,omitempty"`        // Article: reaction count
+	Comments        int    `json:"comments,omitempty"`         // Article: comment count
 
 	// Calculated scores
 	Score float64 `json:"score"` // Calculated relevance/popularity score
 
+	// Editorial curation, set by admins and left alone by syncs (see CurationPatch)
+	Pinned  bool    `json:"pinned,omitempty"`  // Listed above all other matches of a search
+	Blocked bool    `json:"blocked,omitempty"` // Excluded from search results and exports
+	Boost   float64 `json:"boost,omitempty"`   // Ranking multiplier; 1 is neutral
+
 	// Timestamps
 	PublishedAt time.Time `json:"published_at"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Hash is the SourceHash stored with the content; set by upserts and reads
+	Hash string `json:"content_hash,omitempty"`
 }
 
 // NewContent creates a new Content with generated ID and timestamps.
@@ -70,6 +90,16 @@ func (c *Content) IsArticle() bool {
 	return c.Type == ContentTypeArticle
 }
 
+// IsPodcast returns true if content is a podcast.
+func (c *Content) IsPodcast() bool {
+	return c.Type == ContentTypePodcast
+}
+
+// IsImage returns true if content is an image.
+func (c *Content) IsImage() bool {
+	return c.Type == ContentTypeImage
+}
+
 // EngagementRate calculates the engagement rate for videos.
 // Returns 0 for non-video content or if views is 0.
 func (c *Content) EngagementRate() float64 {
@@ -89,3 +119,37 @@ func (c *Content) DaysSincePublished() int {
 
 	return int(days)
 }
+
+// SourceHash returns a hash of the fields c takes from its provider, so a sync can
+// tell the contents it returns unchanged without comparing them field by field. The
+// score, curation and timestamps the service sets are left out.
+func (c *Content) SourceHash() string {
+	tags := c.Tags
+	if len(tags) == 0 {
+		tags = nil
+	}
+
+	// Field order is part of the hash: changing it marks every content as changed
+	source, _ := json.Marshal([]any{
+		c.ProviderID, c.ExternalID, c.Title, c.Type, tags, c.URL, c.ThumbnailURL, c.Author,
+		c.Views, c.Likes, c.Duration, c.DurationSeconds, c.Listens, c.ReadingTime, c.Reactions, c.Comments,
+		c.PublishedAt.UTC().Truncate(time.Microsecond),
+	})
+	sum := sha256.Sum256(source)
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// ETag returns a weak HTTP entity tag of c, changing with its provider data, score
+// and curation. Empty when c has no Hash, e.g. it wasn't synced since hashes were
+// stored.
+func (c *Content) ETag() string {
+	if c.Hash == "" {
+		return ""
+	}
+
+	h := fnv.New32a()
+	_, _ = fmt.Fprint(h, c.Score, c.Pinned, c.Blocked, c.Boost)
+
+	return fmt.Sprintf(`W/"%s-%08x"`, c.Hash, h.Sum32())
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -127,3 +128,76 @@ func TestContent_DaysSincePublished(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_SourceHash(t *testing.T) {
+	published := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	newContent := func() *Content {
+		c := NewContent("provider_a", "v1", "Go Basics", ContentTypeVideo)
+		c.Tags = []string{"go"}
+		c.Views = 100
+		c.PublishedAt = published
+
+		return c
+	}
+	hash := newContent().SourceHash()
+
+	synced := newContent()
+	synced.ID = "id-1"
+	synced.Score = 42
+	synced.Pinned = true
+	synced.CreatedAt = published.Add(time.Hour)
+	if got := synced.SourceHash(); got != hash {
+		t.Errorf("fields set by the service changed the hash: %q, want %q", got, hash)
+	}
+
+	local := newContent()
+	local.PublishedAt = published.In(time.FixedZone("UTC+3", 3*3600))
+	if got := local.SourceHash(); got != hash {
+		t.Errorf("the time zone of the publish date changed the hash: %q, want %q", got, hash)
+	}
+
+	noTags := newContent()
+	noTags.Tags = nil
+	emptyTags := newContent()
+	emptyTags.Tags = []string{}
+	if noTags.SourceHash() != emptyTags.SourceHash() {
+		t.Error("nil and empty tags hash differently")
+	}
+
+	changes := map[string]func(c *Content){
+		"title":   func(c *Content) { c.Title = "Go Advanced" },
+		"views":   func(c *Content) { c.Views++ },
+		"tags":    func(c *Content) { c.Tags = []string{"golang"} },
+		"publish": func(c *Content) { c.PublishedAt = c.PublishedAt.Add(time.Second) },
+	}
+	for name, change := range changes {
+		c := newContent()
+		change(c)
+		if c.SourceHash() == hash {
+			t.Errorf("changing the %s kept the hash", name)
+		}
+	}
+}
+
+func TestContent_ETag(t *testing.T) {
+	c := &Content{Score: 10, Boost: 1}
+	if etag := c.ETag(); etag != "" {
+		t.Errorf("expected no ETag without a hash, got %q", etag)
+	}
+
+	c.Hash = "abc"
+	etag := c.ETag()
+	if !strings.HasPrefix(etag, `W/"abc-`) {
+		t.Errorf("expected a weak ETag of the hash, got %q", etag)
+	}
+
+	c.Pinned = true
+	if c.ETag() == etag {
+		t.Error("curation changes kept the ETag")
+	}
+	c.Pinned = false
+	c.Score = 11
+	if c.ETag() == etag {
+		t.Error("score changes kept the ETag")
+	}
+}
@@ -0,0 +1,8 @@
+package domain
+
+// CurationPatch holds changes to a content's editorial flags; nil fields are left unchanged.
+type CurationPatch struct {
+	Pinned  *bool
+	Blocked *bool
+	Boost   *float64 // Ranking multiplier, 1 is neutral
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// DeadLetter is a provider item rejected by its client's schema validation, kept with
+// the fields that broke the schema so an upstream change (e.g. a renamed field) can
+// be looked into rather than stored as zeros.
+type DeadLetter struct {
+	ID         string           `json:"id"`
+	Provider   string           `json:"provider"`
+	ExternalID string           `json:"external_id,omitempty"` // Empty when the item had no usable ID
+	Payload    string           `json:"payload"`               // The item as sent by the provider
+	Errors     ValidationErrors `json:"errors"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
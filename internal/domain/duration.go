@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationBucket classifies videos by length, for filtering and faceting searches.
+type DurationBucket string
+
+const (
+	DurationShort  DurationBucket = "short"  // Under 4 minutes
+	DurationMedium DurationBucket = "medium" // 4 to 20 minutes
+	DurationLong   DurationBucket = "long"   // Over 20 minutes
+)
+
+// Bounds of the duration buckets, in seconds. The duration_bucket column of the
+// contents table classifies with the same bounds.
+const (
+	shortDurationMax  = 4 * 60  // Shortest medium video
+	mediumDurationMax = 20 * 60 // Longest medium video
+)
+
+// isoDuration matches ISO 8601 durations without date parts, e.g. "PT1H2M3S".
+var isoDuration = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// ParseDuration parses a provider duration into whole seconds. Accepted formats are
+// clock notation ("15:30", "1:02:03"), Go durations ("5m30s"), ISO 8601 durations
+// ("PT5M30S") and plain seconds ("330").
+func ParseDuration(raw string) (int, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration: %w", ErrInvalidParams)
+	}
+
+	if strings.Contains(s, ":") {
+		return parseClockDuration(s)
+	}
+
+	if m := isoDuration.FindStringSubmatch(strings.ToUpper(s)); m != nil && s != "PT" {
+		seconds := 0
+		for i, unit := range []int{3600, 60, 1} {
+			if m[i+1] != "" {
+				n, _ := strconv.Atoi(m[i+1])
+				seconds += n * unit
+			}
+		}
+
+		return seconds, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return int(d.Seconds()), nil
+	}
+
+	return 0, fmt.Errorf("unrecognized duration %q: %w", raw, ErrInvalidParams)
+}
+
+// parseClockDuration parses "mm:ss" or "h:mm:ss".
+func parseClockDuration(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("unrecognized duration %q: %w", s, ErrInvalidParams)
+	}
+
+	seconds := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && n >= 60) {
+			return 0, fmt.Errorf("unrecognized duration %q: %w", s, ErrInvalidParams)
+		}
+		seconds = seconds*60 + n
+	}
+
+	return seconds, nil
+}
+
+// FormatDuration formats seconds in clock notation: "m:ss", or "h:mm:ss" from an hour up.
+func FormatDuration(seconds int) string {
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// SetDuration stores the provider's raw duration along with its length in seconds.
+// Durations in an unrecognized format are kept raw, without seconds.
+func (c *Content) SetDuration(raw string) {
+	c.Duration = raw
+	c.DurationSeconds = 0
+	if seconds, err := ParseDuration(raw); err == nil {
+		c.DurationSeconds = seconds
+	}
+}
+
+// DurationBucket returns the duration bucket of videos, or "" for other types and
+// videos of unknown duration.
+func (c *Content) DurationBucket() DurationBucket {
+	switch {
+	case c.Type != ContentTypeVideo || c.DurationSeconds <= 0:
+		return ""
+	case c.DurationSeconds < shortDurationMax:
+		return DurationShort
+	case c.DurationSeconds <= mediumDurationMax:
+		return DurationMedium
+	default:
+		return DurationLong
+	}
+}
+
+// FormattedDuration returns the duration in clock notation, or the raw provider value
+// if it couldn't be parsed.
+func (c *Content) FormattedDuration() string {
+	if c.DurationSeconds > 0 {
+		return FormatDuration(c.DurationSeconds)
+	}
+
+	return c.Duration
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{"15:30", 930},
+		{"1:02:03", 3723},
+		{"0:45", 45},
+		{"5m30s", 330},
+		{"45m", 2700},
+		{"1h2m3s", 3723},
+		{"PT5M30S", 330},
+		{"PT1H", 3600},
+		{"pt2m", 120},
+		{"330", 330},
+		{" 15:30 ", 930},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.raw)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) returned error: %v", tt.raw, err)
+
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "PT", "abc", "15:75", "1:2:3:4", "-5m", "-30", "1:-5"} {
+		if _, err := ParseDuration(raw); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("ParseDuration(%q) error = %v, want ErrInvalidParams", raw, err)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[int]string{
+		0:    "0:00",
+		45:   "0:45",
+		930:  "15:30",
+		3600: "1:00:00",
+		3723: "1:02:03",
+	}
+
+	for seconds, want := range tests {
+		if got := FormatDuration(seconds); got != want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestContent_SetDuration(t *testing.T) {
+	c := &Content{}
+
+	c.SetDuration("5m30s")
+	if c.Duration != "5m30s" || c.DurationSeconds != 330 {
+		t.Errorf("expected raw 5m30s and 330 seconds, got %q and %d", c.Duration, c.DurationSeconds)
+	}
+	if got := c.FormattedDuration(); got != "5:30" {
+		t.Errorf("expected formatted duration 5:30, got %q", got)
+	}
+
+	c.SetDuration("about an hour")
+	if c.Duration != "about an hour" || c.DurationSeconds != 0 {
+		t.Errorf("expected unparsable duration kept raw without seconds, got %q and %d", c.Duration, c.DurationSeconds)
+	}
+	if got := c.FormattedDuration(); got != "about an hour" {
+		t.Errorf("expected raw duration as fallback, got %q", got)
+	}
+}
+
+func TestContent_DurationBucket(t *testing.T) {
+	tests := []struct {
+		contentType ContentType
+		seconds     int
+		want        DurationBucket
+	}{
+		{ContentTypeVideo, 0, ""},
+		{ContentTypeVideo, 239, DurationShort},
+		{ContentTypeVideo, 240, DurationMedium},
+		{ContentTypeVideo, 1200, DurationMedium},
+		{ContentTypeVideo, 1201, DurationLong},
+		{ContentTypePodcast, 300, ""},
+	}
+
+	for _, tt := range tests {
+		c := &Content{Type: tt.contentType, DurationSeconds: tt.seconds}
+		if got := c.DurationBucket(); got != tt.want {
+			t.Errorf("DurationBucket() of %s of %ds = %q, want %q", tt.contentType, tt.seconds, got, tt.want)
+		}
+	}
+}
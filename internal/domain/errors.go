@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+// Sentinel errors returned (usually wrapped) by services and repositories.
+// The HTTP layer maps them to status codes; check them with errors.Is.
+var (
+	// ErrNotFound means the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidParams means the caller supplied invalid input.
+	ErrInvalidParams = errors.New("invalid parameters")
+
+	// ErrProviderUnavailable means an external provider failed or its circuit breaker is open.
+	ErrProviderUnavailable = errors.New("provider unavailable")
+
+	// ErrTimeout means an operation exceeded its deadline.
+	ErrTimeout = errors.New("operation timed out")
+
+	// ErrBusy means a conflicting operation (e.g. another sync) is in progress; retry later.
+	ErrBusy = errors.New("busy")
+
+	// ErrConflict means the operation doesn't apply to the current state (e.g.
+	// restoring a snapshot into a database that has contents); retrying won't help.
+	ErrConflict = errors.New("conflict")
+
+	// ErrLockLost means a write was rejected because the lock guarding it expired
+	// and another instance has written under it since (stale fencing token).
+	ErrLockLost = errors.New("lock lost")
+
+	// ErrInterrupted means an operation was stopped before it finished because the
+	// service is shutting down; what it did so far is kept.
+	ErrInterrupted = errors.New("interrupted by shutdown")
+)
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ContentEventType identifies the kind of content change.
+type ContentEventType string
+
+const (
+	EventContentCreated ContentEventType = "content.created"
+	EventContentUpdated ContentEventType = "content.updated"
+)
+
+// ContentEvent describes a content item that was created or updated.
+type ContentEvent struct {
+	Type       ContentEventType `json:"type"`
+	Content    *Content         `json:"content"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+// NewContentEvent builds an event for content returned from an upsert.
+// Freshly inserted rows have CreatedAt equal to UpdatedAt; updated rows keep
+// their original, earlier CreatedAt.
+func NewContentEvent(c *Content) ContentEvent {
+	eventType := EventContentUpdated
+	if !c.CreatedAt.Before(c.UpdatedAt) {
+		eventType = EventContentCreated
+	}
+
+	return ContentEvent{
+		Type:       eventType,
+		Content:    c,
+		OccurredAt: c.UpdatedAt,
+	}
+}
+
+// ContentEventFilter selects events by content type and tags.
+// Zero values match everything.
+type ContentEventFilter struct {
+	Type ContentType
+	Tags []string // Matches when the content has any of these tags
+}
+
+// Matches reports whether the event passes the filter.
+func (f ContentEventFilter) Matches(e ContentEvent) bool {
+	if e.Content == nil {
+		return false
+	}
+	if f.Type != "" && e.Content.Type != f.Type {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	for _, tag := range f.Tags {
+		if slices.Contains(e.Content.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is a domain event published on the in-process event bus. Subscribers
+// type-switch on the concrete event types they handle.
+type Event interface {
+	// EventName identifies the event in logs and metrics (e.g. content.upserted).
+	EventName() string
+}
+
+// ContentUpserted is published after a provider's contents were stored.
+type ContentUpserted struct {
+	Provider string     `json:"provider"`
+	Contents []*Content `json:"contents"` // As returned from the upsert, with IDs and timestamps set
+}
+
+// EventName implements Event.
+func (ContentUpserted) EventName() string { return "content.upserted" }
+
+// ContentEvents returns a created or updated ContentEvent per content.
+func (e ContentUpserted) ContentEvents() []ContentEvent {
+	events := make([]ContentEvent, len(e.Contents))
+	for i, c := range e.Contents {
+		events[i] = NewContentEvent(c)
+	}
+
+	return events
+}
+
+// ContentDeleted is published after a content was removed.
+type ContentDeleted struct {
+	ID string `json:"id"`
+}
+
+// EventName implements Event.
+func (ContentDeleted) EventName() string { return "content.deleted" }
+
+// ContentCurated is published after an admin changed a content's editorial flags.
+type ContentCurated struct {
+	Content *Content `json:"content"`
+}
+
+// EventName implements Event.
+func (ContentCurated) EventName() string { return "content.curated" }
+
+// ItemsRejected is published during a sync with the items of a provider its client
+// rejected for breaking the provider's schema, a batch at a time.
+type ItemsRejected struct {
+	Provider string
+	Letters  []*DeadLetter
+}
+
+// EventName implements Event.
+func (ItemsRejected) EventName() string { return "items.rejected" }
+
+// SyncResult holds the outcome of syncing a single provider.
+type SyncResult struct {
+	Provider  string
+	Count     int // Contents stored; for streamed syncs, including those stored before an error
+	Changed   int // Contents of Count created or changed; the others were stored already
+	Rejected  int // Items breaking the provider's schema, kept as dead letters (strict clients)
+	Duration  time.Duration
+	Error     error
+	ErrorKind SyncErrorKind // Why the sync failed; set with Error
+	Stages    []SyncStage   // Work of each pipeline stage, in order; nil unless the provider streams
+}
+
+// SyncErrorKind classifies why a provider sync failed.
+type SyncErrorKind string
+
+// Kinds of sync failures.
+const (
+	SyncErrorTimeout     SyncErrorKind = "timeout"     // The sync's deadline passed; what was stored stays
+	SyncErrorInterrupted SyncErrorKind = "interrupted" // Stopped by shutdown (ErrInterrupted)
+	SyncErrorProvider    SyncErrorKind = "provider"    // Fetching or decoding the provider's feed failed
+	SyncErrorDatabase    SyncErrorKind = "database"    // Storing the contents failed
+)
+
+// SyncStage is the work of a stage of the pipeline syncing a streaming provider
+// (see ProviderStreamer): fetch, convert, validate or upsert.
+type SyncStage struct {
+	Name    string
+	Items   int           // Items passed to the next stage; contents stored for upsert
+	Busy    time.Duration // Time spent working, without waiting for input or output
+	Blocked time.Duration // Time spent waiting for the next stage to take its output
+}
+
+// SyncStarted is published when a sync of one or all providers starts, once it
+// holds the sync lock.
+type SyncStarted struct {
+	Providers []string
+}
+
+// EventName implements Event.
+func (SyncStarted) EventName() string { return "sync.started" }
+
+// ProviderSynced is published when a provider's part of a sync finished, before the
+// sync completes.
+type ProviderSynced struct {
+	Result SyncResult
+}
+
+// EventName implements Event.
+func (ProviderSynced) EventName() string { return "provider.synced" }
+
+// SyncCompleted is published after a sync of one or all providers finished.
+type SyncCompleted struct {
+	Results []SyncResult
+}
+
+// EventName implements Event.
+func (SyncCompleted) EventName() string { return "sync.completed" }
+
+// Upserted returns the number of contents stored by successful providers.
+func (e SyncCompleted) Upserted() int {
+	upserted := 0
+	for _, r := range e.Results {
+		if r.Error == nil {
+			upserted += r.Count
+		}
+	}
+
+	return upserted
+}
+
+// ProviderDown is published when a provider's syncs failed the configured number of times in a row.
+type ProviderDown struct {
+	Provider            string
+	ConsecutiveFailures int
+	Error               error // Error of the latest failed sync
+}
+
+// EventName implements Event.
+func (ProviderDown) EventName() string { return "provider.down" }
+
+// ShadowSearchCompared is recorded after a shadow search, which executed a served
+// search again with an alternate ranking or backend, with how their top results compare.
+type ShadowSearchCompared struct {
+	Variant         string  // Ranking and backend of the shadow search, e.g. text/bleve
+	Skipped         bool    // Whether the shadow search was skipped, too many running already
+	Error           error   // Why the shadow search failed
+	Overlap         float64 // Share of the top results found by both searches, 0 to 1
+	RankCorrelation float64 // Kendall rank correlation of the common results, -1 to 1
+	Correlated      bool    // Whether RankCorrelation is set: it takes two common results
+}
+
+// EventName implements Event.
+func (ShadowSearchCompared) EventName() string { return "search.shadow_compared" }
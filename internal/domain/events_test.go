@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContentEvent_Type(t *testing.T) {
+	now := time.Now()
+
+	created := NewContentEvent(&Content{CreatedAt: now, UpdatedAt: now})
+	assert.Equal(t, EventContentCreated, created.Type)
+
+	updated := NewContentEvent(&Content{CreatedAt: now.Add(-time.Hour), UpdatedAt: now})
+	assert.Equal(t, EventContentUpdated, updated.Type)
+	assert.Equal(t, now, updated.OccurredAt)
+}
+
+func TestContentEventFilter_Matches(t *testing.T) {
+	event := ContentEvent{Content: &Content{Type: ContentTypeVideo, Tags: []string{"go", "api"}}}
+
+	tests := []struct {
+		name   string
+		filter ContentEventFilter
+		want   bool
+	}{
+		{name: "empty filter", filter: ContentEventFilter{}, want: true},
+		{name: "type match", filter: ContentEventFilter{Type: ContentTypeVideo}, want: true},
+		{name: "type mismatch", filter: ContentEventFilter{Type: ContentTypeArticle}, want: false},
+		{name: "any tag match", filter: ContentEventFilter{Tags: []string{"rust", "api"}}, want: true},
+		{name: "no tag match", filter: ContentEventFilter{Tags: []string{"rust"}}, want: false},
+		{name: "type and tag", filter: ContentEventFilter{Type: ContentTypeVideo, Tags: []string{"go"}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(event))
+		})
+	}
+
+	assert.False(t, ContentEventFilter{}.Matches(ContentEvent{}))
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxEvent is a domain event stored in the outbox, in the transaction of the
+// content change it describes, until the outbox relay publishes it. Only the events
+// of content changes (see IsOutboxed) go through the outbox.
+type OutboxEvent struct {
+	ID        int64
+	Name      string // EventName of the event
+	Payload   []byte // JSON encoding of the event
+	CreatedAt time.Time
+}
+
+// IsOutboxed reports whether event is published through the outbox when it's enabled.
+func IsOutboxed(event Event) bool {
+	switch event.(type) {
+	case ContentUpserted, ContentDeleted, ContentCurated:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewOutboxEvent encodes event for the outbox.
+func NewOutboxEvent(event Event) (*OutboxEvent, error) {
+	if !IsOutboxed(event) {
+		return nil, fmt.Errorf("event %s doesn't go through the outbox", event.EventName())
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encoding event %s: %w", event.EventName(), err)
+	}
+
+	return &OutboxEvent{Name: event.EventName(), Payload: payload}, nil
+}
+
+// Event decodes the stored event.
+func (e OutboxEvent) Event() (Event, error) {
+	switch e.Name {
+	case ContentUpserted{}.EventName():
+		return decodeOutboxEvent[ContentUpserted](e)
+	case ContentDeleted{}.EventName():
+		return decodeOutboxEvent[ContentDeleted](e)
+	case ContentCurated{}.EventName():
+		return decodeOutboxEvent[ContentCurated](e)
+	default:
+		return nil, fmt.Errorf("outbox event %d: unknown event %q", e.ID, e.Name)
+	}
+}
+
+// decodeOutboxEvent decodes the payload of e as a T.
+func decodeOutboxEvent[T Event](e OutboxEvent) (Event, error) {
+	var event T
+	if err := json.Unmarshal(e.Payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding outbox event %d (%s): %w", e.ID, e.Name, err)
+	}
+
+	return event, nil
+}
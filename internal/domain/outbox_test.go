@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxEvent_RoundTrip(t *testing.T) {
+	events := []Event{
+		ContentUpserted{Provider: "provider_a", Contents: []*Content{{ID: "id-1", Title: "Go", Tags: []string{"golang"}}}},
+		ContentDeleted{ID: "id-2"},
+		ContentCurated{Content: &Content{ID: "id-3", Pinned: true, Boost: 2}},
+	}
+
+	for _, event := range events {
+		t.Run(event.EventName(), func(t *testing.T) {
+			stored, err := NewOutboxEvent(event)
+			require.NoError(t, err)
+			assert.Equal(t, event.EventName(), stored.Name)
+
+			decoded, err := stored.Event()
+			require.NoError(t, err)
+			assert.Equal(t, event, decoded)
+		})
+	}
+}
+
+func TestOutboxEvent_OnlyContentChanges(t *testing.T) {
+	assert.True(t, IsOutboxed(ContentDeleted{}))
+	assert.False(t, IsOutboxed(SyncCompleted{}))
+
+	_, err := NewOutboxEvent(SyncStarted{Providers: []string{"provider_a"}})
+	require.Error(t, err)
+
+	_, err = OutboxEvent{ID: 7, Name: "sync.started", Payload: []byte(`{}`)}.Event()
+	require.Error(t, err)
+	_, err = OutboxEvent{ID: 8, Name: "content.deleted", Payload: []byte(`not json`)}.Event()
+	require.Error(t, err)
+}
@@ -2,19 +2,25 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
 // ContentRepository defines the interface for content persistence operations.
 // Implementations: internal/infra/postgres/repository.go
 type ContentRepository interface {
-	// Search finds contents matching the given search parameters.
+	// Search finds contents matching the given search parameters. Blocked contents are
+	// excluded, as are future ones if params.HideUnpublished is set; pinned ones come
+	// first, and boosts scale the ranking. With params.SkipCount set, matches aren't
+	// counted (see NewUncountedSearchResult).
 	Search(ctx context.Context, params SearchParams) (*SearchResult, error)
 
 	// GetByID retrieves a single content by its internal ID.
+	// Returns ErrNotFound if it doesn't exist.
 	GetByID(ctx context.Context, id string) (*Content, error)
 
 	// GetByProviderAndExternalID retrieves content by provider and external ID.
+	// Returns ErrNotFound if it doesn't exist.
 	GetByProviderAndExternalID(ctx context.Context, providerID, externalID string) (*Content, error)
 
 	// Upsert creates or updates a single content.
@@ -24,11 +30,36 @@ type ContentRepository interface {
 	// BulkUpsert creates or updates multiple contents in a batch.
 	BulkUpsert(ctx context.Context, contents []*Content) error
 
-	// Delete removes a content by its internal ID.
+	// Delete removes a content by its internal ID. Returns ErrNotFound if it didn't exist.
 	Delete(ctx context.Context, id string) error
 
+	// UpdateCuration changes the editorial flags of a content and returns the updated content.
+	// Returns ErrNotFound if it doesn't exist.
+	UpdateCuration(ctx context.Context, id string, patch CurationPatch) (*Content, error)
+
+	// ListCurated returns all pinned, blocked or boosted contents.
+	ListCurated(ctx context.Context) ([]*Content, error)
+
+	// Facets counts the contents matching the filters of params per type, provider and
+	// tag (pagination and sorting are ignored). Only the tagLimit most frequent tags are
+	// returned.
+	Facets(ctx context.Context, params SearchParams, tagLimit int) (*SearchFacets, error)
+
 	// Count returns the total number of contents matching optional filters.
 	Count(ctx context.Context, params SearchParams) (int64, error)
+
+	// Iterate calls fn with successive batches of all contents matching the query and
+	// type filters of params (pagination and sorting are ignored). Blocked and, if
+	// params.HideUnpublished is set, future contents are skipped, as in Search. Iteration stops at the first error returned by fn.
+	Iterate(ctx context.Context, params SearchParams, batchSize int, fn func(batch []*Content) error) error
+
+	// LastSyncTimes returns, per provider ID, when its contents were last upserted by a
+	// sync, changed or not.
+	LastSyncTimes(ctx context.Context) (map[string]time.Time, error)
+
+	// CreatedPerDay returns the number of contents created before since, and the number
+	// created on each UTC day from since on (days without contents are left out).
+	CreatedPerDay(ctx context.Context, since time.Time) (int64, []DailyCount, error)
 }
 
 // Provider defines the interface for external content providers.
@@ -45,8 +76,56 @@ type Provider interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// BreakerStateReporter is implemented by providers whose calls go through a circuit breaker.
+// Implementations: internal/infra/provider/provider_a/, internal/infra/provider/provider_b/
+type BreakerStateReporter interface {
+	// BreakerState returns "closed", "half-open" or "open", or "" when unknown.
+	BreakerState() string
+}
+
+// ProviderStreamer is implemented by providers that can decode their feed as it
+// arrives. Syncs then convert, validate and store it in a pipeline holding a bounded
+// number of items, rather than the whole feed.
+// Implementations: internal/infra/provider/provider_a/, internal/infra/provider/provider_b/
+type ProviderStreamer interface {
+	// Stream decodes the feed and hands each item to emit, in feed order, until the
+	// feed ends or emit returns an error, which Stream then returns.
+	Stream(ctx context.Context, emit func(ProviderItem) error) error
+}
+
+// ProviderItem is a decoded item of a provider feed, not yet converted to a Content.
+// Implementations: internal/infra/provider/provider_a/, internal/infra/provider/provider_b/
+type ProviderItem interface {
+	// ToDomain converts the item to a content of the provider providerID.
+	ToDomain(providerID string) *Content
+}
+
+// SchemaCheckedItem is implemented by provider items whose client can validate them
+// against the provider's schema (strict clients). Syncs don't convert items breaking
+// the schema; they keep them as dead letters.
+// Implementations: internal/infra/provider/provider_a/, internal/infra/provider/provider_b/
+type SchemaCheckedItem interface {
+	ProviderItem
+
+	// SchemaErrors returns the fields of the item breaking the schema; nil when it
+	// conforms or wasn't checked.
+	SchemaErrors() ValidationErrors
+
+	// Payload returns the item as sent by the provider; nil when it conforms.
+	Payload() []byte
+}
+
+// ChangedContentUpserter is implemented by content repositories that store the
+// SourceHash of each content, so upserts skip the contents a sync returns unchanged.
+type ChangedContentUpserter interface {
+	// UpsertChanged upserts contents like BulkUpsert and returns those it wrote: new
+	// ones, and stored ones whose provider data or score changed. The others are left
+	// as stored; their database-generated fields are set all the same.
+	UpsertChanged(ctx context.Context, contents []*Content) ([]*Content, error)
+}
+
 // Cache defines the interface for caching operations.
-// Implementations: internal/infra/cache/memory.go (optional)
+// Implementations: internal/infra/redis/cache.go, internal/infra/cache/ (in-process LRU, tiered)
 type Cache interface {
 	// Get retrieves a value by key. Returns nil if not found.
 	Get(ctx context.Context, key string) ([]byte, error)
@@ -60,3 +139,291 @@ type Cache interface {
 	// Clear removes all cached values.
 	Clear(ctx context.Context) error
 }
+
+// CacheStats is a snapshot of cache usage counters since process start.
+type CacheStats struct {
+	Hits     uint64  `json:"hits"`
+	Misses   uint64  `json:"misses"`
+	Errors   uint64  `json:"errors"`
+	Sets     uint64  `json:"sets"`
+	Deletes  uint64  `json:"deletes"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// CacheStatsReporter is implemented by caches that track usage counters.
+// Implementations: internal/infra/cache/instrumented.go
+type CacheStatsReporter interface {
+	// Stats returns the current counters.
+	Stats() CacheStats
+}
+
+// EventPublisher delivers domain events to the subscribers within this process.
+// Implementations: internal/eventbus/bus.go
+type EventPublisher interface {
+	// Publish hands event to every subscriber before returning.
+	Publish(ctx context.Context, event Event)
+}
+
+// ContentEventBus distributes content change events across service instances.
+// Implementations: internal/infra/redis/events.go, internal/eventbus/content.go (single instance)
+type ContentEventBus interface {
+	// Publish broadcasts events to all subscribers on every instance.
+	Publish(ctx context.Context, events []ContentEvent) error
+
+	// Subscribe returns a channel receiving published events.
+	// The channel is closed when ctx is done or the bus is closed.
+	Subscribe(ctx context.Context) (<-chan ContentEvent, error)
+
+	// Close closes every subscription; later Subscribe calls fail.
+	Close() error
+}
+
+// WebhookRepository defines persistence operations for webhook subscriptions.
+// Implementations: internal/infra/postgres/webhook_repository.go
+type WebhookRepository interface {
+	// Create stores a new webhook and sets its ID and timestamps.
+	Create(ctx context.Context, webhook *Webhook) error
+
+	// List returns all webhooks ordered by creation time.
+	List(ctx context.Context) ([]*Webhook, error)
+
+	// ListActive returns webhooks that are active.
+	ListActive(ctx context.Context) ([]*Webhook, error)
+
+	// Delete removes a webhook by ID. Returns ErrNotFound if it didn't exist.
+	Delete(ctx context.Context, id string) error
+}
+
+// CollectionRepository defines persistence operations for content collections.
+// Implementations: internal/infra/postgres/collection_repository.go
+type CollectionRepository interface {
+	// Create stores a new collection and sets its ID and timestamps.
+	// Returns ErrInvalidParams if a content ID doesn't exist.
+	Create(ctx context.Context, collection *Collection) error
+
+	// Update replaces the title, description and contents of a collection and sets its timestamps.
+	// Returns ErrNotFound if it doesn't exist and ErrInvalidParams if a content ID doesn't exist.
+	Update(ctx context.Context, collection *Collection) error
+
+	// Delete removes a collection by ID. Returns ErrNotFound if it didn't exist.
+	Delete(ctx context.Context, id string) error
+
+	// GetByID retrieves a collection by ID. Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id string) (*Collection, error)
+
+	// List returns all collections ordered by creation time.
+	List(ctx context.Context) ([]*Collection, error)
+
+	// Contents returns the contents of a collection in collection order. Blocked
+	// contents are skipped; deleted ones drop out of their collections.
+	Contents(ctx context.Context, id string) ([]*Content, error)
+}
+
+// WebhookSender delivers a single event to a webhook endpoint.
+// Implementations: internal/infra/webhook/sender.go
+type WebhookSender interface {
+	// Send posts the signed event. Errors indicate the delivery should be retried.
+	Send(ctx context.Context, webhook *Webhook, event WebhookEvent) error
+}
+
+// IdempotencyRecord tracks a request made with an Idempotency-Key.
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"` // Hash of method, path and body
+	Completed   bool   `json:"completed"`   // False while the first request is still running
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyStore persists idempotency records shared by all instances.
+// Implementations: internal/infra/redis/idempotency.go
+type IdempotencyStore interface {
+	// Begin atomically claims key for a new request. If the key is already taken,
+	// it returns the existing record and false.
+	Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, bool, error)
+
+	// Complete stores the response for a claimed key.
+	Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+
+	// Release frees a claimed key so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// SettingsStore persists runtime settings shared by all instances.
+// Implementations: internal/infra/redis/settings.go
+type SettingsStore interface {
+	// Get returns the stored settings, or nil if they were never changed.
+	Get(ctx context.Context) (*RuntimeSettings, error)
+
+	// Save replaces the stored settings.
+	Save(ctx context.Context, settings *RuntimeSettings) error
+}
+
+// AuditLogRepository persists the admin audit log.
+// Implementations: internal/infra/postgres/audit_repository.go
+type AuditLogRepository interface {
+	// Create stores an entry and sets its ID and CreatedAt.
+	Create(ctx context.Context, entry *AuditEntry) error
+
+	// List returns a page of entries matching filter, newest first, and the total match count.
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEntry, int64, error)
+
+	// DeleteBefore removes the entries created before before and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AnalyticsEventRepository persists search result impressions and clicks.
+// Implementations: internal/infra/postgres/analytics_repository.go
+type AnalyticsEventRepository interface {
+	// CreateBatch stores events in one statement and sets their IDs and CreatedAt.
+	CreateBatch(ctx context.Context, events []*AnalyticsEvent) error
+
+	// AggregateCTR counts impressions and clicks per content for events that occurred at or after since.
+	AggregateCTR(ctx context.Context, since time.Time) ([]ContentCTR, error)
+}
+
+// SearchQueryRepository persists executed searches for query analytics.
+// Implementations: internal/infra/postgres/search_query_repository.go
+type SearchQueryRepository interface {
+	// CreateBatch stores searches in one statement.
+	CreateBatch(ctx context.Context, queries []*SearchQuery) error
+
+	// TopQueries returns up to limit queries searched at or after since, most frequent first.
+	TopQueries(ctx context.Context, since time.Time, limit int) ([]QueryStat, error)
+
+	// TopZeroResultQueries is TopQueries restricted to searches matching no content.
+	TopZeroResultQueries(ctx context.Context, since time.Time, limit int) ([]QueryStat, error)
+
+	// DeleteBefore removes the searches that occurred before before and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SyncRunRepository persists the history of provider syncs.
+// Implementations: internal/infra/postgres/sync_run_repository.go
+type SyncRunRepository interface {
+	// CreateBatch stores runs in one statement and sets their IDs.
+	CreateBatch(ctx context.Context, runs []*SyncRun) error
+
+	// ListSince returns the runs finished at or after since, oldest first.
+	ListSince(ctx context.Context, since time.Time) ([]*SyncRun, error)
+
+	// FailuresPerDay counts the failed runs per provider and UTC day for runs finished
+	// at or after since, ordered by day and provider.
+	FailuresPerDay(ctx context.Context, since time.Time) ([]ProviderDailyCount, error)
+
+	// DeleteBefore removes the runs finished before before and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DeadLetterRepository persists provider items rejected by schema validation.
+// Implementations: internal/infra/postgres/dead_letter_repository.go
+type DeadLetterRepository interface {
+	// CreateBatch stores letters in one statement and sets their IDs.
+	CreateBatch(ctx context.Context, letters []*DeadLetter) error
+
+	// DeleteBefore removes the letters created before before and returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteByExternalID removes the letters of an item of provider and returns how
+	// many were removed.
+	DeleteByExternalID(ctx context.Context, provider, externalID string) (int64, error)
+}
+
+// QuotaStore counts the requests of API keys per month, shared by all instances.
+// Implementations: internal/infra/redis/quota.go, internal/infra/postgres/quota_repository.go
+type QuotaStore interface {
+	// Increment counts a request of the API key name in month (YYYY-MM) and returns
+	// the month's count so far.
+	Increment(ctx context.Context, name, month string) (int64, error)
+
+	// Counts returns the counts of month of the API keys names. Keys without requests
+	// are left out.
+	Counts(ctx context.Context, month string, names []string) (map[string]int64, error)
+}
+
+// SearchTermRepository persists the term dictionary behind autocomplete and spelling
+// corrections.
+// Implementations: internal/infra/postgres/search_term_repository.go
+type SearchTermRepository interface {
+	// RebuildTerms replaces the stored terms with the words of the titles and tags of
+	// the contents that aren't blocked, counting the contents having each, in one
+	// transaction. Returns the number of terms stored.
+	RebuildTerms(ctx context.Context) (int64, error)
+
+	// ListTerms returns every stored term.
+	ListTerms(ctx context.Context) ([]Term, error)
+}
+
+// OutboxRepository reads the events the content repository stored in the outbox.
+// Implementations: internal/infra/postgres/outbox_repository.go
+type OutboxRepository interface {
+	// Relay removes up to limit stored events, oldest first, and passes them to fn, in
+	// one transaction: they stay stored if fn fails. Events being relayed by another
+	// instance are skipped. Returns the number of events relayed.
+	Relay(ctx context.Context, limit int, fn func(events []*OutboxEvent) error) (int, error)
+}
+
+// SearchIndex is a secondary search backend rebuilt from the content repository.
+// Implementations: internal/infra/bleveindex/repository.go
+type SearchIndex interface {
+	// BeginRebuild starts an empty index next to the one answering queries, which
+	// receives the content writes made meanwhile. Returns ErrBusy while another
+	// rebuild is in progress.
+	BeginRebuild() (IndexBuild, error)
+}
+
+// IndexBuild is a search index being rebuilt.
+type IndexBuild interface {
+	// Put indexes contents read from the repository, except those written since the
+	// rebuild began: the index already has their newer version.
+	Put(contents []*Content) error
+
+	// Commit switches queries to the rebuilt index and removes the previous one.
+	Commit() error
+
+	// Abort removes the rebuilt index; the previous one keeps answering queries.
+	Abort() error
+}
+
+// ContentBoostRepository stores the click-through rate boosts added to content scores.
+// Implementations: internal/infra/postgres/repository.go
+type ContentBoostRepository interface {
+	// ReplaceCTRBoosts sets the CTR boost of each content in boosts (keyed by content ID)
+	// and removes the boost of every other content, in one transaction.
+	ReplaceCTRBoosts(ctx context.Context, boosts map[string]float64) error
+}
+
+// ContentScoreRepository recomputes the stored scores of contents.
+// Implementations: internal/infra/postgres/repository.go
+type ContentScoreRepository interface {
+	// Rescore sets the score of every content, blocked ones included, to score(content)
+	// plus its CTR boost, batchSize contents at a time. Returns the number of contents rescored.
+	Rescore(ctx context.Context, batchSize int, score func(c *Content) float64) (int64, error)
+}
+
+// SnapshotRepository dumps and restores the content corpus as stored: every content,
+// blocked ones included, with its ID, CTR boost and timestamps, and the provider sync times.
+// Implementations: internal/infra/postgres/snapshot_repository.go
+type SnapshotRepository interface {
+	// DumpSnapshot writes the corpus to w, batchSize contents at a time, as of a
+	// single point in time.
+	DumpSnapshot(ctx context.Context, batchSize int, w SnapshotWriter) error
+
+	// RestoreSnapshot writes the corpus read from r in one transaction, keeping the
+	// content IDs, so nothing is written if r fails. Returns the number of contents
+	// restored, or ErrConflict if there are contents already.
+	RestoreSnapshot(ctx context.Context, r SnapshotReader) (int64, error)
+}
+
+// SnapshotStore keeps snapshots, e.g. in object storage.
+// Implementations: internal/infra/snapshot/file.go, internal/infra/snapshot/s3.go
+type SnapshotStore interface {
+	// Put stores the snapshot read from r as name. Nothing is stored if reading r fails.
+	Put(ctx context.Context, name string, r io.Reader) error
+
+	// Get opens the snapshot name. Returns ErrNotFound if there is none.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// List returns the stored snapshots, newest first.
+	List(ctx context.Context) ([]SnapshotInfo, error)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// FuturePublishPolicy decides how contents are handled when a provider sends a
+// publish date in the future. Left alone, they would get the full recency bonus
+// and rank at the top before they're out.
+type FuturePublishPolicy string
+
+const (
+	// FuturePublishClamp sets the publish date to the ingest time (default).
+	FuturePublishClamp FuturePublishPolicy = "clamp"
+	// FuturePublishExclude drops the contents at ingest; a later sync picks them up once published.
+	FuturePublishExclude FuturePublishPolicy = "exclude"
+	// FuturePublishEmbargo stores the contents as sent and hides them from searches until published.
+	FuturePublishEmbargo FuturePublishPolicy = "embargo"
+)
+
+// IsValid reports whether p is a known policy.
+func (p FuturePublishPolicy) IsValid() bool {
+	return p == FuturePublishClamp || p == FuturePublishExclude || p == FuturePublishEmbargo
+}
+
+// HidesUnpublished reports whether searches leave out contents published after the
+// time of the search. Excluded contents may have been stored before the policy was set.
+func (p FuturePublishPolicy) HidesUnpublished() bool {
+	return p == FuturePublishExclude || p == FuturePublishEmbargo
+}
+
+// Apply returns the contents to store after a fetch at now: future publish dates are
+// clamped to now, or their contents dropped, depending on p. Embargoed contents are
+// kept as sent. The slice is filtered in place.
+func (p FuturePublishPolicy) Apply(contents []*Content, now time.Time) []*Content {
+	if p == FuturePublishEmbargo {
+		return contents
+	}
+
+	kept := contents[:0]
+	for _, c := range contents {
+		if c.PublishedAt.After(now) {
+			if p == FuturePublishExclude {
+				continue
+			}
+			// The recency score already treats future dates as published today
+			c.PublishedAt = now
+		}
+		kept = append(kept, c)
+	}
+
+	return kept
+}
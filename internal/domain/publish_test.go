@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFuturePublishPolicy_Apply(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	past := now.AddDate(0, 0, -3)
+	future := now.AddDate(0, 0, 3)
+
+	fetch := func() []*Content {
+		return []*Content{
+			{ExternalID: "past", PublishedAt: past},
+			{ExternalID: "future", PublishedAt: future},
+		}
+	}
+
+	tests := []struct {
+		policy   FuturePublishPolicy
+		expected map[string]time.Time
+	}{
+		{FuturePublishClamp, map[string]time.Time{"past": past, "future": now}},
+		{FuturePublishExclude, map[string]time.Time{"past": past}},
+		{FuturePublishEmbargo, map[string]time.Time{"past": past, "future": future}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			got := tt.policy.Apply(fetch(), now)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d contents, got %d", len(tt.expected), len(got))
+			}
+			for _, c := range got {
+				if want, ok := tt.expected[c.ExternalID]; !ok || !c.PublishedAt.Equal(want) {
+					t.Errorf("%s: expected published_at %v, got %v", c.ExternalID, want, c.PublishedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestFuturePublishPolicy_HidesUnpublished(t *testing.T) {
+	if FuturePublishClamp.HidesUnpublished() {
+		t.Error("clamp leaves no future contents to hide")
+	}
+	if !FuturePublishExclude.HidesUnpublished() || !FuturePublishEmbargo.HidesUnpublished() {
+		t.Error("exclude and embargo hide future contents from searches")
+	}
+	if FuturePublishPolicy("allow").IsValid() {
+		t.Error("expected unknown policy to be invalid")
+	}
+}
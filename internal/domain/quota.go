@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// QuotaUsage is the use of an API key's monthly request quota.
+type QuotaUsage struct {
+	Name     string    // Name of the API key, e.g. the partner using it
+	Limit    int64     // Requests allowed in the month, 0 for unlimited
+	Requests int64     // Requests counted in the month, rejected ones included
+	ResetAt  time.Time // Start of the next month, when counting starts over
+}
+
+// Exceeded reports whether the last counted request went over the quota.
+func (u QuotaUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Requests > u.Limit
+}
+
+// Remaining returns the requests left in the month; 0 for unlimited keys.
+func (u QuotaUsage) Remaining() int64 {
+	if u.Limit == 0 {
+		return 0
+	}
+
+	return max(0, u.Limit-u.Requests)
+}
+
+// Rejected returns the requests of the month rejected for going over the quota.
+func (u QuotaUsage) Rejected() int64 {
+	if u.Limit == 0 {
+		return 0
+	}
+
+	return max(0, u.Requests-u.Limit)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ReindexState is the stage of a search index rebuild.
+type ReindexState string
+
+const (
+	// ReindexIdle means no rebuild ran since the start.
+	ReindexIdle ReindexState = "idle"
+	// ReindexRunning means a rebuild is copying contents into a new index.
+	ReindexRunning ReindexState = "running"
+	// ReindexCompleted means the last rebuild finished and its index answers queries.
+	ReindexCompleted ReindexState = "completed"
+	// ReindexFailed means the last rebuild stopped; the previous index still answers queries.
+	ReindexFailed ReindexState = "failed"
+)
+
+// ReindexStatus reports the progress of the last search index rebuild.
+type ReindexStatus struct {
+	State ReindexState
+	// Contents copied into the new index so far, out of Total
+	Indexed int64
+	Total   int64
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Why the rebuild failed
+	Error string
+}
+
+// Progress returns the share of contents indexed, from 0 to 100.
+func (s ReindexStatus) Progress() float64 {
+	switch {
+	case s.State == ReindexCompleted:
+		return 100
+	case s.Total <= 0:
+		return 0
+	}
+
+	return min(100, float64(s.Indexed)*100/float64(s.Total))
+}
@@ -2,13 +2,17 @@
 package domain
 
 // ContentTypeCoefficient returns the scoring coefficient for content type.
-// Video content is weighted higher than articles.
+// Video content is weighted highest, images lowest.
 func ContentTypeCoefficient(contentType ContentType) float64 {
 	switch contentType {
 	case ContentTypeVideo:
 		return 1.5
+	case ContentTypePodcast:
+		return 1.2
 	case ContentTypeArticle:
 		return 1.0
+	case ContentTypeImage:
+		return 0.8
 	default:
 		return 1.0
 	}
@@ -23,10 +27,14 @@ func ContentTypeCoefficient(contentType ContentType) float64 {
 // Base Score:
 //   - Video: views/1000 + likes/100
 //   - Article: reading_time + reactions/50
+//   - Podcast: listens/1000 + likes/100
+//   - Image: views/1000 + likes/100
 //
 // Content Type Coefficient:
 //   - Video: 1.5
+//   - Podcast: 1.2
 //   - Article: 1.0
+//   - Image: 0.8
 //
 // Recency Score:
 //   - Within 1 week: +5
@@ -35,8 +43,9 @@ func ContentTypeCoefficient(contentType ContentType) float64 {
 //   - Older: +0
 //
 // Engagement Score:
-//   - Video: (likes/views) * 10
+//   - Video, image: (likes/views) * 10
 //   - Article: (reactions/reading_time) * 5
+//   - Podcast: (likes/listens) * 10
 func CalculateScore(c *Content) float64 {
 	if c == nil {
 		return 0
@@ -55,14 +64,17 @@ func CalculateScore(c *Content) float64 {
 
 // calculateBaseScore computes the base score based on content type.
 //
-// Video: views/1000 + likes/100
+// Video, image: views/1000 + likes/100
 // Article: reading_time + reactions/50
+// Podcast: listens/1000 + likes/100
 func calculateBaseScore(c *Content) float64 {
 	switch c.Type {
-	case ContentTypeVideo:
+	case ContentTypeVideo, ContentTypeImage:
 		return float64(c.Views)/1000 + float64(c.Likes)/100
 	case ContentTypeArticle:
 		return float64(c.ReadingTime) + float64(c.Reactions)/50
+	case ContentTypePodcast:
+		return float64(c.Listens)/1000 + float64(c.Likes)/100
 	default:
 		return 0
 	}
@@ -91,11 +103,12 @@ func calculateRecencyScore(c *Content) float64 {
 
 // calculateEngagementScore computes engagement bonus based on content type.
 //
-// Video: (likes/views) * 10
+// Video, image: (likes/views) * 10
 // Article: (reactions/reading_time) * 5
+// Podcast: (likes/listens) * 10
 func calculateEngagementScore(c *Content) float64 {
 	switch c.Type {
-	case ContentTypeVideo:
+	case ContentTypeVideo, ContentTypeImage:
 		if c.Views == 0 {
 			return 0
 		}
@@ -107,11 +120,43 @@ func calculateEngagementScore(c *Content) float64 {
 		}
 
 		return (float64(c.Reactions) / float64(c.ReadingTime)) * 5
+	case ContentTypePodcast:
+		if c.Listens == 0 {
+			return 0
+		}
+
+		return (float64(c.Likes) / float64(c.Listens)) * 10
 	default:
 		return 0
 	}
 }
 
+// CTR smoothing prior: every content starts from 1 click in 20 impressions, so a
+// handful of early clicks can't outrank content with a long, steady record.
+const (
+	ctrPriorClicks      = 1
+	ctrPriorImpressions = 20
+)
+
+// CTRBoost computes the score bonus earned by a content's search click-through rate.
+//
+// Formula:
+//
+//	CTR Boost = weight * (clicks + 1) / (impressions + 20)
+//
+// Clicks are capped at impressions, since clients may report clicks on results
+// whose impressions were never sent.
+func CTRBoost(impressions, clicks int64, weight float64) float64 {
+	if impressions <= 0 || weight <= 0 {
+		return 0
+	}
+	clicks = min(max(clicks, 0), impressions)
+
+	ctr := float64(clicks+ctrPriorClicks) / float64(impressions+ctrPriorImpressions)
+
+	return roundTo2Decimals(ctr * weight)
+}
+
 // roundTo2Decimals rounds a float to 2 decimal places.
 func roundTo2Decimals(value float64) float64 {
 	return float64(int(value*100+0.5)) / 100
@@ -128,6 +128,66 @@ func TestCalculateScore_Article(t *testing.T) {
 	}
 }
 
+func TestCalculateScore_Podcast(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		content  *Content
+		expected float64
+	}{
+		{
+			name: "popular recent podcast",
+			content: &Content{
+				Type:        ContentTypePodcast,
+				Listens:     20000, // 20000/1000 = 20
+				Likes:       1000,  // 1000/100 = 10
+				PublishedAt: now,   // +5 recency
+				// Base: 20 + 10 = 30
+				// TypeCoeff: 1.2 → 30 * 1.2 = 36
+				// Engagement: (1000/20000) * 10 = 0.5
+				// Final: 36 + 5 + 0.5 = 41.5
+			},
+			expected: 41.5,
+		},
+		{
+			name: "podcast with views instead of listens (views ignored)",
+			content: &Content{
+				Type:        ContentTypePodcast,
+				Views:       10000,
+				PublishedAt: now,
+			},
+			expected: 5.0, // Base 0, engagement 0, recency 5
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := CalculateScore(tt.content)
+			if score != tt.expected {
+				t.Errorf("CalculateScore() = %v, want %v", score, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateScore_Image(t *testing.T) {
+	content := &Content{
+		Type:        ContentTypeImage,
+		Views:       5000,       // 5000/1000 = 5
+		Likes:       500,        // 500/100 = 5
+		PublishedAt: time.Now(), // +5 recency
+		// Base: 5 + 5 = 10
+		// TypeCoeff: 0.8 → 10 * 0.8 = 8
+		// Engagement: (500/5000) * 10 = 1
+		// Final: 8 + 5 + 1 = 14
+	}
+
+	if score := CalculateScore(content); score != 14.0 {
+		t.Errorf("CalculateScore() = %v, want 14", score)
+	}
+}
+
 func TestCalculateScore_NilContent(t *testing.T) {
 	score := CalculateScore(nil)
 	if score != 0 {
@@ -141,7 +201,9 @@ func TestContentTypeCoefficient(t *testing.T) {
 		expected    float64
 	}{
 		{ContentTypeVideo, 1.5},
+		{ContentTypePodcast, 1.2},
 		{ContentTypeArticle, 1.0},
+		{ContentTypeImage, 0.8},
 		{"unknown", 1.0},
 	}
 
@@ -655,3 +717,29 @@ func TestCalculateScore_IntegrationEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestCTRBoost(t *testing.T) {
+	tests := []struct {
+		name        string
+		impressions int64
+		clicks      int64
+		weight      float64
+		expected    float64
+	}{
+		{"30% ctr", 980, 299, 10, 3},                       // 10 * 300/1000
+		{"no clicks keeps the prior", 980, 0, 10, 0.01},    // 10 * 1/1000
+		{"few impressions are smoothed", 5, 5, 10, 2.4},    // 10 * 6/25
+		{"clicks capped at impressions", 80, 200, 10, 8.1}, // 10 * 81/100
+		{"no impressions", 0, 3, 10, 0},
+		{"zero weight", 980, 299, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boost := CTRBoost(tt.impressions, tt.clicks, tt.weight)
+			if boost != tt.expected {
+				t.Errorf("CTRBoost(%d, %d, %v) = %v, want %v", tt.impressions, tt.clicks, tt.weight, boost, tt.expected)
+			}
+		})
+	}
+}
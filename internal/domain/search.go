@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 // SortOrder represents the sort direction.
 type SortOrder string
 
@@ -17,13 +22,34 @@ const (
 	SortFieldPublishedAt SortField = "published_at"
 )
 
+// SearchMode selects how the search query matches contents.
+type SearchMode string
+
+const (
+	// SearchModeWebsearch matches whole (stemmed) words, with quotes, OR and -word (default).
+	SearchModeWebsearch SearchMode = "websearch"
+	// SearchModePrefix matches contents having a word starting with each query word,
+	// for search-as-you-type.
+	SearchModePrefix SearchMode = "prefix"
+)
+
+// Page sizes of searches.
+const (
+	DefaultPageSize = 20  // When the page size is unset
+	MaxPageSize     = 100 // Larger page sizes are corrected or rejected
+)
+
 // SearchParams holds search and filter parameters for content queries.
 type SearchParams struct {
 	// Text search
-	Query string // Full-text search query
+	Query string     // Full-text search query
+	Mode  SearchMode // How Query matches (default: websearch)
 
 	// Filters
-	Type ContentType // Filter by content type (video, article)
+	Type     ContentType    // Filter by content type (video, article, podcast, image)
+	Provider string         // Filter by provider ID
+	Tag      string         // Filter by tag; matches contents having it
+	Duration DurationBucket // Filter by duration bucket; matches videos only
 
 	// Sorting
 	SortBy    SortField // Field to sort by (default: score)
@@ -32,6 +58,34 @@ type SearchParams struct {
 	// Pagination
 	Page     int // Page number (1-indexed)
 	PageSize int // Items per page
+
+	// Ranking for relevance sorts, set from the runtime settings (empty means hybrid)
+	Scoring ScoringStrategy
+
+	// Backend matching Query, set from the runtime settings (empty means postgres)
+	Backend SearchBackend
+
+	// Leave out contents published in the future, set from the FuturePublishPolicy
+	HideUnpublished bool
+
+	// Leave out the count of matching contents, for degraded searches (see
+	// NewUncountedSearchResult). Their results aren't cached, so left out of its keys.
+	SkipCount bool `json:"-"`
+
+	// Read from the database even when the search is cached, re-populating the cache
+	// with the result, for callers forcing a refresh. Left out of its keys.
+	BypassCache bool `json:"-"`
+
+	// Contents matching Query, found by a text index answering it instead of the
+	// database (nil when the database matches Query). Set below the search cache, so
+	// left out of its keys.
+	TextMatches []TextMatch `json:"-"`
+}
+
+// TextMatch is a content matching a search query, with its text relevance.
+type TextMatch struct {
+	ContentID string
+	Relevance float64 // Higher is more relevant; ranks matches like ts_rank
 }
 
 // DefaultSearchParams returns search params with sensible defaults.
@@ -44,16 +98,31 @@ func DefaultSearchParams() SearchParams {
 	}
 }
 
-// Validate ensures search params are within acceptable bounds. This is bound correction, not validation.
+// Normalize puts the query and filters in canonical form: the query is lowercased
+// with its whitespace collapsed (see NormalizeQuery) and the filters are trimmed, so
+// searches differing only in case or spacing share their cache entries. Text
+// search is case-insensitive, so they match the same contents.
+func (p *SearchParams) Normalize() {
+	p.Query = NormalizeQuery(p.Query)
+	p.Provider = strings.TrimSpace(p.Provider)
+	p.Tag = strings.TrimSpace(p.Tag)
+}
+
+// Validate normalizes search params and ensures they are within acceptable bounds. This is bound correction,
+// not validation; ValidateStrict rejects the values corrected here instead.
 func (p *SearchParams) Validate() {
+	p.Normalize()
 	if p.Page < 1 {
 		p.Page = 1
 	}
 	if p.PageSize < 1 {
-		p.PageSize = 20
+		p.PageSize = DefaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
 	}
-	if p.PageSize > 100 {
-		p.PageSize = 100
+	if p.Mode == "" {
+		p.Mode = SearchModeWebsearch
 	}
 	if p.SortBy == "" {
 		p.SortBy = SortFieldScore
@@ -63,6 +132,71 @@ func (p *SearchParams) Validate() {
 	}
 }
 
+// ValidateStrict checks the search params, returning ValidationErrors naming each
+// offending field: out-of-range pages and page sizes, and unknown modes, types and
+// sorts. Unset fields are then given their defaults as by Validate.
+func (p *SearchParams) ValidateStrict() error {
+	var errs ValidationErrors
+	add := errs.add
+
+	if p.Page < 0 {
+		add("page", "min", p.Page, "must be at least 1")
+	}
+	switch {
+	case p.PageSize < 0:
+		add("page_size", "min", p.PageSize, "must be at least 1")
+	case p.PageSize > MaxPageSize:
+		add("page_size", "max", p.PageSize, "must be at most %d", MaxPageSize)
+	}
+
+	switch p.Mode {
+	case "", SearchModeWebsearch, SearchModePrefix:
+	default:
+		add("mode", "oneof", p.Mode, "must be one of: %s %s", SearchModeWebsearch, SearchModePrefix)
+	}
+	switch p.Type {
+	case "", ContentTypeVideo, ContentTypeArticle, ContentTypePodcast, ContentTypeImage:
+	default:
+		add("type", "oneof", p.Type, "must be one of: %s %s %s %s",
+			ContentTypeVideo, ContentTypeArticle, ContentTypePodcast, ContentTypeImage)
+	}
+	switch p.Duration {
+	case "", DurationShort, DurationMedium, DurationLong:
+	default:
+		add("duration", "oneof", p.Duration, "must be one of: %s %s %s", DurationShort, DurationMedium, DurationLong)
+	}
+	switch p.SortBy {
+	case "", SortFieldRelevance, SortFieldScore, SortFieldPublishedAt:
+	default:
+		add("sort_by", "oneof", p.SortBy, "must be one of: %s %s %s", SortFieldRelevance, SortFieldScore, SortFieldPublishedAt)
+	}
+	switch p.SortOrder {
+	case "", SortOrderAsc, SortOrderDesc:
+	default:
+		add("sort_order", "oneof", p.SortOrder, "must be one of: %s %s", SortOrderAsc, SortOrderDesc)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	p.Validate()
+
+	return nil
+}
+
+// CheckDepth returns ErrInvalidParams if the page reaches past the first maxResults
+// results, whose OFFSET would make the database scan and skip all the rows before
+// it. 0 disables the check.
+func (p *SearchParams) CheckDepth(maxResults int) error {
+	if maxResults <= 0 || p.Page*p.PageSize <= maxResults {
+		return nil
+	}
+
+	return fmt.Errorf("%w: page %d of %d results reaches past the first %d results; "+
+		"narrow the search, or read all of its results with GET /contents/export",
+		ErrInvalidParams, p.Page, p.PageSize, maxResults)
+}
+
 // Offset calculates the database offset for pagination.
 func (p *SearchParams) Offset() int {
 	return (p.Page - 1) * p.PageSize
@@ -80,6 +214,10 @@ type SearchResult struct {
 	Page       int        `json:"page"`        // Current page (1-indexed)
 	PageSize   int        `json:"page_size"`   // Items per page
 	TotalPages int        `json:"total_pages"` // Total number of pages
+
+	// Degraded marks results served while the database was too slow: outdated ones
+	// from the cache, or uncounted ones (see NewUncountedSearchResult)
+	Degraded bool `json:"degraded,omitempty"`
 }
 
 // NewSearchResult creates a new SearchResult with calculated pagination.
@@ -97,3 +235,35 @@ func NewSearchResult(contents []*Content, total int64, params SearchParams) *Sea
 		TotalPages: totalPages,
 	}
 }
+
+// NewUncountedSearchResult creates a SearchResult of a search run without counting
+// its matches, from the contents of its page and, if more follow, the first of the
+// next page. Total is then a lower bound: the contents up to this page, plus one when
+// more follow, so there is a next page.
+func NewUncountedSearchResult(contents []*Content, params SearchParams) *SearchResult {
+	total := int64(params.Offset() + len(contents))
+	if len(contents) > params.PageSize {
+		contents = contents[:params.PageSize]
+	}
+	result := NewSearchResult(contents, total, params)
+	result.Degraded = true
+
+	return result
+}
+
+// FacetCount is the number of matching contents having a facet value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacets holds the number of matching contents per type, provider, tag and
+// duration bucket, most frequent first. Each facet ignores its own filter, so it also
+// counts the alternatives to a selected value. Durations only count videos of known
+// duration.
+type SearchFacets struct {
+	Types     []FacetCount `json:"types"`
+	Providers []FacetCount `json:"providers"`
+	Tags      []FacetCount `json:"tags"`
+	Durations []FacetCount `json:"durations"`
+}
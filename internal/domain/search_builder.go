@@ -0,0 +1,93 @@
+package domain
+
+// SearchParamsBuilder builds SearchParams for programmatic searches:
+//
+//	params, err := domain.NewSearchParams().
+//		Query("distributed systems").
+//		Type(domain.ContentTypeArticle).
+//		Page(2, 20).
+//		Build()
+//
+// It starts from DefaultSearchParams and, like API requests, sorts by relevance
+// when a query is set and no sort is.
+type SearchParamsBuilder struct {
+	params SearchParams
+	sorted bool
+}
+
+// NewSearchParams returns a builder of search params.
+func NewSearchParams() *SearchParamsBuilder {
+	return &SearchParamsBuilder{params: DefaultSearchParams()}
+}
+
+// Query sets the full-text search query.
+func (b *SearchParamsBuilder) Query(query string) *SearchParamsBuilder {
+	b.params.Query = query
+
+	return b
+}
+
+// Mode sets how the query matches contents.
+func (b *SearchParamsBuilder) Mode(mode SearchMode) *SearchParamsBuilder {
+	b.params.Mode = mode
+
+	return b
+}
+
+// Type filters the contents by type.
+func (b *SearchParamsBuilder) Type(contentType ContentType) *SearchParamsBuilder {
+	b.params.Type = contentType
+
+	return b
+}
+
+// Provider filters the contents by provider ID.
+func (b *SearchParamsBuilder) Provider(provider string) *SearchParamsBuilder {
+	b.params.Provider = provider
+
+	return b
+}
+
+// Tag filters the contents having tag.
+func (b *SearchParamsBuilder) Tag(tag string) *SearchParamsBuilder {
+	b.params.Tag = tag
+
+	return b
+}
+
+// Duration filters the videos in duration bucket.
+func (b *SearchParamsBuilder) Duration(bucket DurationBucket) *SearchParamsBuilder {
+	b.params.Duration = bucket
+
+	return b
+}
+
+// Sort sets the field and direction results are sorted by.
+func (b *SearchParamsBuilder) Sort(field SortField, order SortOrder) *SearchParamsBuilder {
+	b.params.SortBy = field
+	b.params.SortOrder = order
+	b.sorted = true
+
+	return b
+}
+
+// Page selects the page of results, 1-indexed, and its size.
+func (b *SearchParamsBuilder) Page(page, pageSize int) *SearchParamsBuilder {
+	b.params.Page = page
+	b.params.PageSize = pageSize
+
+	return b
+}
+
+// Build returns the search params, or the ValidationErrors of ValidateStrict.
+func (b *SearchParamsBuilder) Build() (SearchParams, error) {
+	params := b.params
+	if !b.sorted && params.Query != "" {
+		params.SortBy = SortFieldRelevance
+	}
+	if err := params.ValidateStrict(); err != nil {
+		return SearchParams{}, err
+	}
+
+	return params, nil
+}
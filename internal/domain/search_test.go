@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSearchParams_ValidateStrict(t *testing.T) {
+	tests := []struct {
+		name   string
+		params SearchParams
+		fields []string // Expected offending fields, in order
+	}{
+		{"unset", SearchParams{}, nil},
+		{"in range", SearchParams{Page: 3, PageSize: MaxPageSize, Mode: SearchModePrefix, SortBy: SortFieldPublishedAt}, nil},
+		{"negative page", SearchParams{Page: -1}, []string{"page"}},
+		{"page size too large", SearchParams{PageSize: MaxPageSize + 1}, []string{"page_size"}},
+		{"negative page size", SearchParams{PageSize: -5}, []string{"page_size"}},
+		{"unknown values", SearchParams{Mode: "fuzzy", Type: "livestream", Duration: "epic", SortBy: "views", SortOrder: "up"}, []string{"mode", "type", "duration", "sort_by", "sort_order"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			err := params.ValidateStrict()
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if params.Page < 1 || params.PageSize < 1 || params.Mode == "" || params.SortBy == "" || params.SortOrder == "" {
+					t.Errorf("expected defaults for unset fields, got %+v", params)
+				}
+
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Error("expected error to match ErrInvalidParams")
+			}
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("expected %d field errors, got %v", len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs[i].Field != field {
+					t.Errorf("error %d: expected field %s, got %s", i, field, errs[i].Field)
+				}
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("expected params left as they are, got %+v", params)
+			}
+		})
+	}
+}
+
+func TestSearchParams_ValidateCorrects(t *testing.T) {
+	params := SearchParams{Page: -1, PageSize: MaxPageSize + 1}
+	params.Validate()
+
+	if params.Page != 1 || params.PageSize != MaxPageSize {
+		t.Errorf("expected page 1 of %d, got page %d of %d", MaxPageSize, params.Page, params.PageSize)
+	}
+}
+
+func TestSearchParamsBuilder(t *testing.T) {
+	params, err := NewSearchParams().Query("golang").Type(ContentTypeVideo).Tag("go").Page(2, 10).Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.Query != "golang" || params.Type != ContentTypeVideo || params.Tag != "go" || params.Page != 2 || params.PageSize != 10 {
+		t.Errorf("unexpected params %+v", params)
+	}
+	if params.SortBy != SortFieldRelevance || params.SortOrder != SortOrderDesc {
+		t.Errorf("expected relevance sort with a query, got %s %s", params.SortBy, params.SortOrder)
+	}
+
+	params, err = NewSearchParams().Query("golang").Sort(SortFieldPublishedAt, SortOrderAsc).Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params.SortBy != SortFieldPublishedAt || params.SortOrder != SortOrderAsc {
+		t.Errorf("expected the explicit sort, got %s %s", params.SortBy, params.SortOrder)
+	}
+
+	_, err = NewSearchParams().Page(1, MaxPageSize+1).Build()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "page_size" {
+		t.Errorf("expected a page_size error, got %v", err)
+	}
+}
+
+func TestNewUncountedSearchResult(t *testing.T) {
+	params := SearchParams{Page: 2, PageSize: 2}
+
+	result := NewUncountedSearchResult([]*Content{{}, {}, {}}, params)
+	if len(result.Contents) != 2 || result.Total != 5 || result.TotalPages != 3 || !result.Degraded {
+		t.Errorf("expected a full page followed by another, got %+v", result)
+	}
+
+	result = NewUncountedSearchResult([]*Content{{}}, params)
+	if len(result.Contents) != 1 || result.Total != 3 || result.TotalPages != 2 {
+		t.Errorf("expected the last page, got %+v", result)
+	}
+}
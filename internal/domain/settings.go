@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"maps"
+	"time"
+)
+
+// ScoringStrategy selects how relevance-sorted searches rank their matches.
+type ScoringStrategy string
+
+const (
+	// ScoringHybrid ranks by ts_rank × LOG(score + 10), balancing text relevance and popularity (default).
+	ScoringHybrid ScoringStrategy = "hybrid"
+	// ScoringText ranks by ts_rank only, e.g. while popularity scores are suspect.
+	ScoringText ScoringStrategy = "text"
+)
+
+// IsValid reports whether s is a known scoring strategy.
+func (s ScoringStrategy) IsValid() bool {
+	return s == ScoringHybrid || s == ScoringText
+}
+
+// SearchBackend selects what answers the text query of searches.
+type SearchBackend string
+
+const (
+	// SearchBackendPostgres matches queries with PostgreSQL full-text search (default).
+	SearchBackendPostgres SearchBackend = "postgres"
+	// SearchBackendBleve matches queries with the embedded Bleve index, once built;
+	// PostgreSQL answers until then.
+	SearchBackendBleve SearchBackend = "bleve"
+)
+
+// IsValid reports whether b is a known search backend.
+func (b SearchBackend) IsValid() bool {
+	return b == SearchBackendPostgres || b == SearchBackendBleve
+}
+
+// RuntimeSettings are operational switches changed at runtime through the admin API,
+// e.g. to mitigate an incident without a redeploy.
+type RuntimeSettings struct {
+	CacheEnabled    bool            `json:"cache_enabled"`
+	CacheSearchTTL  time.Duration   `json:"cache_search_ttl"`
+	ScoringStrategy ScoringStrategy `json:"scoring_strategy"`
+	SearchBackend   SearchBackend   `json:"search_backend"`
+	UpdatedAt       time.Time       `json:"updated_at"` // Zero until first changed
+
+	// Providers whose syncs were switched on (true) or off (false), overriding their
+	// configuration; unlisted providers are synced as configured
+	ProviderSyncs map[string]bool `json:"provider_syncs,omitempty"`
+}
+
+// ProviderSyncEnabled reports whether provider is synced with the others, as switched
+// at runtime or else as configured.
+func (s RuntimeSettings) ProviderSyncEnabled(provider string, configured bool) bool {
+	if enabled, ok := s.ProviderSyncs[provider]; ok {
+		return enabled
+	}
+
+	return configured
+}
+
+// SettingsPatch holds changes to RuntimeSettings; nil fields are left unchanged.
+type SettingsPatch struct {
+	CacheEnabled    *bool
+	CacheSearchTTL  *time.Duration
+	ScoringStrategy *ScoringStrategy
+	SearchBackend   *SearchBackend
+	ProviderSyncs   map[string]bool // Merged into the switched providers
+}
+
+// Apply returns s with the changes of p.
+func (s RuntimeSettings) Apply(p SettingsPatch) RuntimeSettings {
+	if p.CacheEnabled != nil {
+		s.CacheEnabled = *p.CacheEnabled
+	}
+	if p.CacheSearchTTL != nil {
+		s.CacheSearchTTL = *p.CacheSearchTTL
+	}
+	if p.ScoringStrategy != nil {
+		s.ScoringStrategy = *p.ScoringStrategy
+	}
+	if p.SearchBackend != nil {
+		s.SearchBackend = *p.SearchBackend
+	}
+	if len(p.ProviderSyncs) > 0 {
+		syncs := maps.Clone(s.ProviderSyncs)
+		if syncs == nil {
+			syncs = make(map[string]bool, len(p.ProviderSyncs))
+		}
+		maps.Copy(syncs, p.ProviderSyncs)
+		s.ProviderSyncs = syncs
+	}
+
+	return s
+}
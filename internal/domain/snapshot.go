@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// SnapshotFormatVersion is the version of the snapshot format written. Restores
+// reject snapshots of any other version.
+const SnapshotFormatVersion = 1
+
+// SnapshotContent is a content as stored, for snapshots: unlike Content, it carries
+// the CTR boost included in Score.
+type SnapshotContent struct {
+	Content
+	CTRBoost float64 `json:"ctr_boost,omitempty"`
+}
+
+// SnapshotWriter receives the corpus read for a snapshot: the provider sync times
+// first, then the contents, a batch at a time.
+type SnapshotWriter interface {
+	WriteSyncTimes(times map[string]time.Time) error
+	WriteContents(batch []*SnapshotContent) error
+}
+
+// SnapshotReader hands over the corpus of a snapshot being restored: the provider
+// sync times first, then the contents, a batch at a time.
+type SnapshotReader interface {
+	ReadSyncTimes() (map[string]time.Time, error)
+	// ReadContents returns the next batch of contents, or none at the end.
+	ReadContents() ([]*SnapshotContent, error)
+}
+
+// SnapshotInfo describes a snapshot kept in a SnapshotStore.
+type SnapshotInfo struct {
+	Name       string
+	Size       int64 // Bytes stored
+	ModifiedAt time.Time
+}
+
+// SnapshotOperation is what a snapshot job does.
+type SnapshotOperation string
+
+const (
+	// SnapshotCreate writes the corpus to a new snapshot.
+	SnapshotCreate SnapshotOperation = "create"
+	// SnapshotRestore writes a snapshot into an empty database.
+	SnapshotRestore SnapshotOperation = "restore"
+)
+
+// SnapshotState is the stage of a snapshot job.
+type SnapshotState string
+
+const (
+	// SnapshotIdle means no job ran since the start.
+	SnapshotIdle SnapshotState = "idle"
+	// SnapshotRunning means a job is copying contents.
+	SnapshotRunning SnapshotState = "running"
+	// SnapshotCompleted means the last job finished.
+	SnapshotCompleted SnapshotState = "completed"
+	// SnapshotFailed means the last job stopped; a failed restore wrote nothing, and
+	// a failed create stored no snapshot.
+	SnapshotFailed SnapshotState = "failed"
+)
+
+// SnapshotStatus reports the progress of the last snapshot job.
+type SnapshotStatus struct {
+	Operation SnapshotOperation
+	State     SnapshotState
+	Name      string // Snapshot written or restored
+	// Contents written or restored so far
+	Contents int64
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Why the job failed
+	Error string
+}
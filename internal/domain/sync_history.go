@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// SyncRun is a recorded provider sync, kept for the dashboard's sync history charts.
+type SyncRun struct {
+	ID         string        `json:"id"`
+	Provider   string        `json:"provider"`
+	Count      int           `json:"count"` // Contents upserted; 0 if the sync failed
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+	FinishedAt time.Time     `json:"finished_at"`
+}
+
+// DailyCount is a count for a UTC day, e.g. a point of a time-series chart.
+type DailyCount struct {
+	Day   time.Time `json:"day"` // Midnight UTC
+	Count int64     `json:"count"`
+}
+
+// ProviderDailyCount is a count for a provider on a UTC day.
+type ProviderDailyCount struct {
+	Provider string    `json:"provider"`
+	Day      time.Time `json:"day"` // Midnight UTC
+	Count    int64     `json:"count"`
+}
+
+// CumulativeDaily returns one point per UTC day from from to to (inclusive) with
+// the running total of base plus the daily counts. Days missing from daily keep the
+// previous total; counts outside the range are ignored.
+func CumulativeDaily(base int64, daily []DailyCount, from, to time.Time) []DailyCount {
+	perDay := make(map[time.Time]int64, len(daily))
+	for _, d := range daily {
+		perDay[TruncateDay(d.Day)] += d.Count
+	}
+
+	var points []DailyCount
+	total := base
+	for day := TruncateDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		total += perDay[day]
+		points = append(points, DailyCount{Day: day, Count: total})
+	}
+
+	return points
+}
+
+// TruncateDay returns midnight UTC of the day of t.
+func TruncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ProviderSeries is a daily count for a provider over consecutive days.
+type ProviderSeries struct {
+	Provider string
+	Counts   []int64 // One per day of the range
+}
+
+// DailySeries spreads counts over one point per UTC day from from to to (inclusive),
+// returning the days and a series per provider ordered by provider. Days without a
+// count are 0; counts outside the range are ignored.
+func DailySeries(counts []ProviderDailyCount, from, to time.Time) ([]time.Time, []ProviderSeries) {
+	var days []time.Time
+	index := make(map[time.Time]int)
+	for day := TruncateDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		index[day] = len(days)
+		days = append(days, day)
+	}
+
+	byProvider := make(map[string][]int64)
+	var providers []string
+	for _, c := range counts {
+		i, ok := index[TruncateDay(c.Day)]
+		if !ok {
+			continue
+		}
+		if _, seen := byProvider[c.Provider]; !seen {
+			byProvider[c.Provider] = make([]int64, len(days))
+			providers = append(providers, c.Provider)
+		}
+		byProvider[c.Provider][i] += c.Count
+	}
+	sort.Strings(providers)
+
+	series := make([]ProviderSeries, len(providers))
+	for i, p := range providers {
+		series[i] = ProviderSeries{Provider: p, Counts: byProvider[p]}
+	}
+
+	return days, series
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCumulativeDaily(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	points := CumulativeDaily(10, []DailyCount{
+		{Day: day(12), Count: 3},
+		{Day: day(14).Add(5 * time.Hour), Count: 2},
+		{Day: day(20), Count: 100}, // After the range
+	}, day(12).Add(9*time.Hour), day(15))
+
+	expected := []int64{13, 13, 15, 15}
+	if len(points) != len(expected) {
+		t.Fatalf("expected %d points, got %d", len(expected), len(points))
+	}
+	for i, p := range points {
+		if !p.Day.Equal(day(12 + i)) {
+			t.Errorf("point %d: expected day %v, got %v", i, day(12+i), p.Day)
+		}
+		if p.Count != expected[i] {
+			t.Errorf("point %d: expected total %d, got %d", i, expected[i], p.Count)
+		}
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	days, series := DailySeries([]ProviderDailyCount{
+		{Provider: "provider_b", Day: day(13), Count: 2},
+		{Provider: "provider_a", Day: day(12), Count: 1},
+		{Provider: "provider_a", Day: day(1), Count: 9}, // Before the range
+	}, day(12), day(14))
+
+	if len(days) != 3 || !days[0].Equal(day(12)) || !days[2].Equal(day(14)) {
+		t.Fatalf("expected days 12 to 14, got %v", days)
+	}
+	if len(series) != 2 || series[0].Provider != "provider_a" || series[1].Provider != "provider_b" {
+		t.Fatalf("expected series of provider_a and provider_b, got %+v", series)
+	}
+	for i, want := range [][]int64{{1, 0, 0}, {0, 2, 0}} {
+		for j, count := range series[i].Counts {
+			if count != want[j] {
+				t.Errorf("%s day %d: expected %d, got %d", series[i].Provider, j, want[j], count)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Term is a word of the indexed titles and tags, with the number of contents having it.
+type Term struct {
+	Term      string
+	Frequency int64
+}
+
+// TermDictionary is an immutable set of terms answering autocomplete and spelling
+// correction lookups in memory.
+type TermDictionary struct {
+	terms []Term // Sorted by term, so the completions of a prefix are adjacent
+}
+
+// NewTermDictionary creates a TermDictionary of terms, which it takes ownership of.
+func NewTermDictionary(terms []Term) *TermDictionary {
+	slices.SortFunc(terms, func(a, b Term) int { return strings.Compare(a.Term, b.Term) })
+
+	return &TermDictionary{terms: terms}
+}
+
+// Len returns the number of terms.
+func (d *TermDictionary) Len() int {
+	return len(d.terms)
+}
+
+// Contains reports whether word is a term.
+func (d *TermDictionary) Contains(word string) bool {
+	_, found := slices.BinarySearchFunc(d.terms, word, func(t Term, w string) int { return strings.Compare(t.Term, w) })
+
+	return found
+}
+
+// Complete returns up to limit terms starting with prefix, most frequent first.
+func (d *TermDictionary) Complete(prefix string, limit int) []Term {
+	if prefix == "" || limit <= 0 {
+		return nil
+	}
+
+	start, _ := slices.BinarySearchFunc(d.terms, prefix, func(t Term, p string) int { return strings.Compare(t.Term, p) })
+	end := start
+	for end < len(d.terms) && strings.HasPrefix(d.terms[end].Term, prefix) {
+		end++
+	}
+
+	matches := slices.Clone(d.terms[start:end])
+	slices.SortStableFunc(matches, func(a, b Term) int {
+		switch {
+		case a.Frequency > b.Frequency:
+			return -1
+		case a.Frequency < b.Frequency:
+			return 1
+		}
+
+		return 0
+	})
+
+	return matches[:min(limit, len(matches))]
+}
+
+// Correct returns the term closest to word, the most frequent of those at the
+// smallest edit distance, and whether one was found. Words that are terms, shorter
+// than 3 letters or containing no letter aren't corrected. The allowed distance is 1
+// for words up to 4 letters and 2 beyond.
+//
+// Every term of a similar length is compared, which takes a few milliseconds for a
+// dictionary of 100,000 terms.
+func (d *TermDictionary) Correct(word string) (string, bool) {
+	runes := []rune(word)
+	if len(runes) < 3 || !slices.ContainsFunc(runes, unicode.IsLetter) || d.Contains(word) {
+		return "", false
+	}
+
+	maxDistance := 2
+	if len(runes) <= 4 {
+		maxDistance = 1
+	}
+
+	var best Term
+	bestDistance := maxDistance + 1
+	for _, t := range d.terms {
+		candidate := []rune(t.Term)
+		if abs(len(candidate)-len(runes)) > maxDistance {
+			continue
+		}
+
+		distance := editDistance(runes, candidate, maxDistance)
+		if distance < bestDistance || (distance == bestDistance && t.Frequency > best.Frequency) {
+			best, bestDistance = t, distance
+		}
+	}
+
+	if bestDistance > maxDistance {
+		return "", false
+	}
+
+	return best.Term, true
+}
+
+// TermWords splits text into the words a TermDictionary holds: lowercased runs of
+// letters and digits, as the 'simple' text search configuration indexes them.
+func TermWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and transpositions of adjacent runes each
+// count as one edit. Distances beyond maxDistance are reported as maxDistance + 1.
+func editDistance(a, b []rune, maxDistance int) int {
+	// Three rows of the distance matrix: two rows back, the previous one and the current one
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		// Distances only grow from one row to the next
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return min(prev[len(b)], maxDistance+1)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
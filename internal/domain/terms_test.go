@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDictionary() *TermDictionary {
+	return NewTermDictionary([]Term{
+		{Term: "golang", Frequency: 40},
+		{Term: "go", Frequency: 25},
+		{Term: "docker", Frequency: 30},
+		{Term: "gopher", Frequency: 5},
+		{Term: "google", Frequency: 12},
+		{Term: "kubernetes", Frequency: 18},
+		{Term: "cubernetes", Frequency: 1},
+		{Term: "tutorial", Frequency: 50},
+	})
+}
+
+func TestTermDictionary_Complete(t *testing.T) {
+	dict := newTestDictionary()
+
+	assert.Equal(t, []Term{{"golang", 40}, {"go", 25}, {"google", 12}}, dict.Complete("go", 3))
+	assert.Equal(t, []Term{{"google", 12}}, dict.Complete("goo", 5))
+	assert.Empty(t, dict.Complete("rust", 5))
+	assert.Empty(t, dict.Complete("", 5))
+	assert.Empty(t, dict.Complete("go", 0))
+}
+
+func TestTermDictionary_Correct(t *testing.T) {
+	dict := newTestDictionary()
+
+	tests := []struct {
+		word string
+		want string
+		ok   bool
+	}{
+		{"golnag", "golang", true},        // Transposition
+		{"dokcer", "docker", true},        // Transposition
+		{"kubernets", "kubernetes", true}, // Deletion; the most frequent at the same distance
+		{"tutoral", "tutorial", true},     // Deletion
+		{"gopehr", "gopher", true},        // Transposition
+		{"tutorial", "", false},           // A term
+		{"javascript", "", false},         // Too far from every term
+		{"goo", "go", true},               // Distance 1 for short words
+		{"dkr", "", false},                // Short words allow a single edit
+		{"gg", "", false},                 // Too short
+		{"2024", "", false},               // Not a word
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, ok := dict.Correct(tt.word)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTermWords(t *testing.T) {
+	assert.Equal(t, []string{"docker", "compose", "v2"}, TermWords("  Docker-Compose v2!"))
+	assert.Empty(t, TermWords("?!"))
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError represents a single field validation error.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors is a collection of validation errors. It matches ErrInvalidParams
+// with errors.Is.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface.
+func (ve ValidationErrors) Error() string {
+	if len(ve) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for i, e := range ve {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(e.Message)
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns ErrInvalidParams.
+func (ve ValidationErrors) Unwrap() error {
+	return ErrInvalidParams
+}
+
+// add appends the error of field, its message formatted after the field name.
+func (ve *ValidationErrors) add(field, tag string, value any, format string, args ...any) {
+	*ve = append(*ve, ValidationError{
+		Field:   field,
+		Tag:     tag,
+		Value:   fmt.Sprint(value),
+		Message: field + " " + fmt.Sprintf(format, args...),
+	})
+}
+
+// maxFuturePublish bounds how far ahead a publish date may be; later dates are
+// taken for provider errors rather than scheduled releases.
+const maxFuturePublish = 365 * 24 * time.Hour
+
+// Validate checks the invariants every stored content must satisfy: provider and
+// external IDs are set, the type is known, metrics aren't negative and the publish
+// date is plausible. Returns ValidationErrors naming each offending field.
+func (c *Content) Validate() error {
+	var errs ValidationErrors
+	add := errs.add
+
+	if strings.TrimSpace(c.ProviderID) == "" {
+		add("provider_id", "required", "", "is required")
+	}
+	if strings.TrimSpace(c.ExternalID) == "" {
+		add("external_id", "required", "", "is required")
+	}
+
+	switch c.Type {
+	case ContentTypeVideo, ContentTypeArticle, ContentTypePodcast, ContentTypeImage:
+	default:
+		add("type", "oneof", c.Type, "must be one of: %s %s %s %s",
+			ContentTypeVideo, ContentTypeArticle, ContentTypePodcast, ContentTypeImage)
+	}
+
+	for _, m := range []struct {
+		field string
+		value int
+	}{
+		{"views", c.Views},
+		{"likes", c.Likes},
+		{"duration_seconds", c.DurationSeconds},
+		{"listens", c.Listens},
+		{"reading_time", c.ReadingTime},
+		{"reactions", c.Reactions},
+		{"comments", c.Comments},
+	} {
+		if m.value < 0 {
+			add(m.field, "min", m.value, "must be at least 0")
+		}
+	}
+
+	switch {
+	case c.PublishedAt.IsZero():
+		add("published_at", "required", "", "is required")
+	case c.PublishedAt.Before(time.Unix(0, 0)):
+		add("published_at", "min", c.PublishedAt.Format(time.RFC3339), "must be after 1970-01-01")
+	case time.Until(c.PublishedAt) > maxFuturePublish:
+		add("published_at", "max", c.PublishedAt.Format(time.RFC3339), "must be within a year from now")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
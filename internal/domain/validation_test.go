@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func validContent() *Content {
+	return &Content{
+		ProviderID:  "provider_a",
+		ExternalID:  "v1",
+		Title:       "Go Basics",
+		Type:        ContentTypeVideo,
+		Views:       100,
+		PublishedAt: time.Now().AddDate(0, 0, -1),
+	}
+}
+
+func TestContent_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Content)
+		fields []string // Expected offending fields, in order
+	}{
+		{"valid", func(*Content) {}, nil},
+		{"missing ids", func(c *Content) { c.ProviderID, c.ExternalID = "", " " }, []string{"provider_id", "external_id"}},
+		{"unknown type", func(c *Content) { c.Type = "livestream" }, []string{"type"}},
+		{"negative metrics", func(c *Content) { c.Views, c.Comments = -1, -2 }, []string{"views", "comments"}},
+		{"missing publish date", func(c *Content) { c.PublishedAt = time.Time{} }, []string{"published_at"}},
+		{"ancient publish date", func(c *Content) { c.PublishedAt = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC) }, []string{"published_at"}},
+		{"far future publish date", func(c *Content) { c.PublishedAt = time.Now().AddDate(5, 0, 0) }, []string{"published_at"}},
+		{"near future publish date", func(c *Content) { c.PublishedAt = time.Now().AddDate(0, 1, 0) }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validContent()
+			tt.modify(c)
+
+			err := c.Validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Error("expected error to match ErrInvalidParams")
+			}
+			if len(errs) != len(tt.fields) {
+				t.Fatalf("expected %d field errors, got %v", len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs[i].Field != field {
+					t.Errorf("expected error %d on %q, got %q", i, field, errs[i].Field)
+				}
+			}
+		})
+	}
+}
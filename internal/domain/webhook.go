@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// EventSyncCompleted is emitted after a sync run (all providers or a single one) finishes.
+const EventSyncCompleted = "sync.completed"
+
+// WebhookEventTypes lists the event types webhooks can subscribe to.
+var WebhookEventTypes = []string{
+	string(EventContentCreated),
+	string(EventContentUpdated),
+	EventSyncCompleted,
+}
+
+// Webhook is an admin-managed subscription delivering events to an external URL.
+type Webhook struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"` // HMAC key for payload signatures, never serialized
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook wants events of the given type.
+func (w *Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookEvent is the payload delivered to webhook endpoints.
+type WebhookEvent struct {
+	ID         string    `json:"id"` // Unique per event; receivers can use it for deduplication
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Subscribes(t *testing.T) {
+	hook := &Webhook{EventTypes: []string{EventSyncCompleted}}
+	assert.False(t, hook.Subscribes(EventSyncCompleted), "inactive webhooks receive nothing")
+
+	hook.Active = true
+	assert.True(t, hook.Subscribes(EventSyncCompleted))
+	assert.False(t, hook.Subscribes(string(EventContentCreated)))
+}
@@ -0,0 +1,76 @@
+// Package eventbus provides an in-process publish/subscribe bus for domain events.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/metrics"
+)
+
+// Handler receives every published event and ignores the types it doesn't handle.
+type Handler func(ctx context.Context, event domain.Event)
+
+// Bus implements domain.EventPublisher. Events are delivered synchronously, to
+// subscribers in subscription order, so a publisher can rely on them being handled
+// when Publish returns. Handlers must not block; slow work (e.g. webhook delivery)
+// belongs on a queue. A panicking handler is logged and skipped.
+//
+// Unlike domain.ContentEventBus, events don't leave the process.
+type Bus struct {
+	logger *zap.Logger
+
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	name   string // Identifies the subscriber in logs
+	handle Handler
+}
+
+// New creates a new Bus without subscribers.
+func New(logger *zap.Logger) *Bus {
+	return &Bus{logger: logger}
+}
+
+// Subscribe adds handler, identified by name in logs, to the subscribers.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, subscriber{name: name, handle: handler})
+}
+
+// Publish hands event to every subscriber before returning.
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	metrics.EventsPublished.WithLabelValues(event.EventName()).Inc()
+
+	for _, s := range subscribers {
+		b.deliver(ctx, s, event)
+	}
+}
+
+// deliver calls a single subscriber, recovering from panics.
+func (b *Bus) deliver(ctx context.Context, s subscriber, event domain.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx, b.logger).Error("event handler panicked",
+				zap.String("subscriber", s.name),
+				zap.String("event", event.EventName()),
+				zap.String("panic", fmt.Sprint(r)),
+			)
+		}
+	}()
+
+	s.handle(ctx, event)
+}
@@ -0,0 +1,47 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestBus_DeliversInSubscriptionOrder(t *testing.T) {
+	bus := New(zap.NewNop())
+
+	var calls []string
+	bus.Subscribe("first", func(_ context.Context, event domain.Event) {
+		calls = append(calls, "first:"+event.EventName())
+	})
+	bus.Subscribe("second", func(_ context.Context, event domain.Event) {
+		if e, ok := event.(domain.ContentDeleted); ok {
+			calls = append(calls, "second:"+e.ID)
+		}
+	})
+
+	bus.Publish(context.Background(), domain.ContentDeleted{ID: "id-1"})
+	bus.Publish(context.Background(), domain.SyncCompleted{})
+
+	assert.Equal(t, []string{"first:content.deleted", "second:id-1", "first:sync.completed"}, calls)
+}
+
+func TestBus_RecoversFromPanickingHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	bus := New(zap.New(core))
+
+	delivered := false
+	bus.Subscribe("broken", func(context.Context, domain.Event) { panic("boom") })
+	bus.Subscribe("healthy", func(context.Context, domain.Event) { delivered = true })
+
+	assert.NotPanics(t, func() {
+		bus.Publish(context.Background(), domain.ContentDeleted{ID: "id-1"})
+	})
+	assert.True(t, delivered, "later subscribers still receive the event")
+	assert.Equal(t, 1, logs.FilterMessage("event handler panicked").FilterField(zap.String("subscriber", "broken")).Len())
+}
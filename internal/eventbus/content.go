@@ -0,0 +1,100 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// contentSubscriberBuffer is the per-subscriber channel size. Events for subscribers
+// that fall further behind are dropped rather than blocking the publisher.
+const contentSubscriberBuffer = 64
+
+// ContentBus implements domain.ContentEventBus within a single process, for running
+// without Redis. Events reach only the stream clients connected to this instance.
+type ContentBus struct {
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	subscribers map[chan domain.ContentEvent]struct{}
+	closed      bool
+}
+
+// NewContentBus creates a new in-process content event bus.
+func NewContentBus(logger *zap.Logger) *ContentBus {
+	return &ContentBus{
+		logger:      logger,
+		subscribers: make(map[chan domain.ContentEvent]struct{}),
+	}
+}
+
+// Publish delivers events to every subscriber.
+func (b *ContentBus) Publish(_ context.Context, events []domain.ContentEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		for ch := range b.subscribers {
+			select {
+			case ch <- event:
+			default:
+				b.logger.Debug("dropping content event for slow subscriber")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done.
+func (b *ContentBus) Subscribe(ctx context.Context) (<-chan domain.ContentEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, fmt.Errorf("event bus closed")
+	}
+
+	ch := make(chan domain.ContentEvent, contentSubscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+
+	return ch, nil
+}
+
+// Close closes all subscriber channels; later subscriptions fail.
+func (b *ContentBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+
+	return nil
+}
+
+// unsubscribe removes and closes a subscriber channel.
+func (b *ContentBus) unsubscribe(ch chan domain.ContentEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,71 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestContentBus_PublishFansOutToSubscribers(t *testing.T) {
+	bus := NewContentBus(zap.NewNop())
+	t.Cleanup(func() { _ = bus.Close() })
+	ctx := context.Background()
+
+	first, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+	second, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+
+	event := domain.ContentEvent{
+		Type:    domain.EventContentCreated,
+		Content: &domain.Content{ID: "id-1", Title: "Go Generics", Type: domain.ContentTypeArticle},
+	}
+	require.NoError(t, bus.Publish(ctx, []domain.ContentEvent{event}))
+
+	for _, ch := range []<-chan domain.ContentEvent{first, second} {
+		select {
+		case got := <-ch:
+			assert.Equal(t, "id-1", got.Content.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+}
+
+func TestContentBus_SubscriptionClosedWithContext(t *testing.T) {
+	bus := NewContentBus(zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription not closed")
+	}
+}
+
+func TestContentBus_CloseEndsSubscriptions(t *testing.T) {
+	bus := NewContentBus(zap.NewNop())
+
+	ch, err := bus.Subscribe(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Close())
+
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	_, err = bus.Subscribe(context.Background())
+	assert.Error(t, err)
+}
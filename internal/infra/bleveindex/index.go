@@ -0,0 +1,334 @@
+// Package bleveindex provides an embedded Bleve full-text index of contents, answering
+// search queries in-process for deployments that don't rely on PostgreSQL text search.
+package bleveindex
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/simple"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"search-engine-service/internal/domain"
+)
+
+// Field boosts, mirroring the weights of search_vector: titles (A) weigh 1, tags (B) 0.4.
+const (
+	titleBoost = 1.0
+	tagsBoost  = 0.4
+)
+
+// document is what the index stores of a content: the English-analyzed title and
+// tags answer websearch queries; the same text, only lowercased, answers prefix
+// queries, like prefix_vector.
+type document struct {
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags"`
+	TitleWords string   `json:"title_words"`
+	TagWords   []string `json:"tag_words"`
+}
+
+// Index is an embedded Bleve index of the contents' titles and tags.
+type Index struct {
+	index      bleve.Index
+	path       string
+	mapping    mapping.IndexMapping
+	maxMatches int
+}
+
+// Open creates an empty index. An empty path keeps the index in memory; otherwise
+// any index at path is removed first, since the index is rebuilt from the database
+// on every start. Searches return at most maxMatches contents.
+func Open(path string, maxMatches int) (*Index, error) {
+	m := newMapping()
+
+	var idx bleve.Index
+	var err error
+	if path == "" {
+		idx, err = bleve.NewMemOnly(m)
+	} else {
+		if err := os.RemoveAll(path); err != nil {
+			return nil, fmt.Errorf("removing previous index: %w", err)
+		}
+		idx, err = bleve.New(path, m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating bleve index: %w", err)
+	}
+
+	return &Index{index: idx, path: path, mapping: m, maxMatches: maxMatches}, nil
+}
+
+// newMapping maps documents to English-analyzed title and tags fields and to
+// lowercased word fields.
+func newMapping() *mapping.IndexMappingImpl {
+	english := bleve.NewTextFieldMapping()
+	english.Analyzer = en.AnalyzerName
+	english.Store = false
+	english.IncludeInAll = false
+
+	words := bleve.NewTextFieldMapping()
+	words.Analyzer = simple.Name
+	words.Store = false
+	words.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("title", english)
+	doc.AddFieldMappingsAt("tags", english)
+	doc.AddFieldMappingsAt("title_words", words)
+	doc.AddFieldMappingsAt("tag_words", words)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.StoreDynamic = false
+	m.IndexDynamic = false
+
+	return m
+}
+
+// Close closes the index.
+func (i *Index) Close() error {
+	return i.index.Close()
+}
+
+// Remove closes the index and removes its files.
+func (i *Index) Remove() error {
+	if err := i.Close(); err != nil {
+		return err
+	}
+	if i.path == "" {
+		return nil
+	}
+
+	if err := os.RemoveAll(i.path); err != nil {
+		return fmt.Errorf("removing index: %w", err)
+	}
+
+	return nil
+}
+
+// Count returns the number of indexed contents.
+func (i *Index) Count() (uint64, error) {
+	return i.index.DocCount()
+}
+
+// Put indexes contents, replacing their previous version. Contents need their ID.
+func (i *Index) Put(contents []*domain.Content) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	batch := i.index.NewBatch()
+	for _, c := range contents {
+		err := batch.Index(c.ID, document{
+			Title:      c.Title,
+			Tags:       c.Tags,
+			TitleWords: c.Title,
+			TagWords:   c.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf("indexing content %s: %w", c.ID, err)
+		}
+	}
+
+	if err := i.index.Batch(batch); err != nil {
+		return fmt.Errorf("indexing %d contents: %w", len(contents), err)
+	}
+
+	return nil
+}
+
+// Delete removes a content from the index.
+func (i *Index) Delete(id string) error {
+	if err := i.index.Delete(id); err != nil {
+		return fmt.Errorf("removing content %s from index: %w", id, err)
+	}
+
+	return nil
+}
+
+// Match returns the contents matching the query of params in its search mode, most
+// relevant first, at most maxMatches of them. Filters, pagination and sorting are
+// left to the caller.
+func (i *Index) Match(ctx context.Context, params domain.SearchParams) ([]domain.TextMatch, error) {
+	q := i.buildQuery(params)
+	if q == nil {
+		return []domain.TextMatch{}, nil
+	}
+
+	req := bleve.NewSearchRequestOptions(q, i.maxMatches, 0, false)
+	res, err := i.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("searching index: %w", err)
+	}
+
+	matches := make([]domain.TextMatch, len(res.Hits))
+	for n, hit := range res.Hits {
+		matches[n] = domain.TextMatch{ContentID: hit.ID, Relevance: hit.Score}
+	}
+
+	return matches, nil
+}
+
+// buildQuery translates the query of params, or returns nil when it can match nothing.
+func (i *Index) buildQuery(params domain.SearchParams) query.Query {
+	if params.Mode == domain.SearchModePrefix {
+		return prefixQuery(params.Query)
+	}
+
+	return i.websearchQuery(params.Query)
+}
+
+// prefixQuery matches contents having a word starting with each word of q, in their
+// title or tags, like the prefix search mode of the database.
+func prefixQuery(q string) query.Query {
+	words := domain.TermWords(q)
+	if len(words) == 0 {
+		return nil
+	}
+
+	clauses := make([]query.Query, len(words))
+	for n, w := range words {
+		clauses[n] = bleve.NewDisjunctionQuery(
+			boosted(bleve.NewPrefixQuery(w), "title_words", titleBoost),
+			boosted(bleve.NewPrefixQuery(w), "tag_words", tagsBoost),
+		)
+	}
+
+	return bleve.NewConjunctionQuery(clauses...)
+}
+
+// websearchQuery translates q with the syntax of websearch_to_tsquery: words and
+// "quoted phrases" must all match, OR between two of them matches either, and -word
+// excludes contents. Words the English analyzer drops (stop words) are ignored, as
+// PostgreSQL does.
+func (i *Index) websearchQuery(q string) query.Query {
+	var must, mustNot []query.Query
+
+	terms := splitWebsearch(q)
+	for n := 0; n < len(terms); n++ {
+		t := terms[n]
+		if !i.meaningful(t.text) {
+			continue
+		}
+		if t.negated {
+			mustNot = append(mustNot, textQuery(t))
+
+			continue
+		}
+
+		// Fold "a OR b OR c" into one disjunction
+		alternatives := []query.Query{textQuery(t)}
+		for n+2 < len(terms) && terms[n+1].or && !terms[n+2].negated && i.meaningful(terms[n+2].text) {
+			alternatives = append(alternatives, textQuery(terms[n+2]))
+			n += 2
+		}
+		if len(alternatives) == 1 {
+			must = append(must, alternatives[0])
+		} else {
+			must = append(must, bleve.NewDisjunctionQuery(alternatives...))
+		}
+	}
+
+	if len(must) == 0 && len(mustNot) == 0 {
+		return nil
+	}
+
+	b := bleve.NewBooleanQuery()
+	if len(must) == 0 {
+		// Only exclusions: everything else matches
+		b.AddMust(bleve.NewMatchAllQuery())
+	} else {
+		b.AddMust(must...)
+	}
+	b.AddMustNot(mustNot...)
+
+	return b
+}
+
+// meaningful reports whether the English analyzer keeps any token of text.
+func (i *Index) meaningful(text string) bool {
+	analyzer := i.mapping.AnalyzerNamed(en.AnalyzerName)
+
+	return analyzer != nil && len(analyzer.Analyze([]byte(text))) > 0
+}
+
+// textQuery matches a word or phrase in the title or tags.
+func textQuery(t websearchTerm) query.Query {
+	field := func(name string, boost float64) query.Query {
+		if t.phrase {
+			return boosted(bleve.NewMatchPhraseQuery(t.text), name, boost)
+		}
+
+		return boosted(bleve.NewMatchQuery(t.text), name, boost)
+	}
+
+	return bleve.NewDisjunctionQuery(field("title", titleBoost), field("tags", tagsBoost))
+}
+
+// fieldQuery is a query that can be restricted to a field and boosted.
+type fieldQuery interface {
+	query.Query
+	query.FieldableQuery
+	query.BoostableQuery
+}
+
+// boosted restricts q to field with boost.
+func boosted(q fieldQuery, field string, boost float64) query.Query {
+	q.SetField(field)
+	q.SetBoost(boost)
+
+	return q
+}
+
+// websearchTerm is a word, a quoted phrase or the OR operator of a websearch query.
+type websearchTerm struct {
+	text    string
+	phrase  bool
+	negated bool
+	or      bool
+}
+
+// splitWebsearch splits q into its terms.
+func splitWebsearch(q string) []websearchTerm {
+	var terms []websearchTerm
+
+	rest := strings.TrimSpace(q)
+	for rest != "" {
+		t := websearchTerm{}
+		if strings.HasPrefix(rest, "-") {
+			t.negated = true
+			rest = rest[1:]
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				// An unclosed quote runs to the end, as in PostgreSQL
+				end = len(rest) - 1
+			}
+			t.text, t.phrase = rest[1:1+end], true
+			rest = rest[min(len(rest), end+2):]
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			t.text, rest = rest[:end], rest[end:]
+			t.or = !t.negated && strings.EqualFold(t.text, "or")
+		}
+
+		if t.text != "" {
+			terms = append(terms, t)
+		}
+		rest = strings.TrimSpace(rest)
+	}
+
+	return terms
+}
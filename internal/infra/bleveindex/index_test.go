@@ -0,0 +1,108 @@
+package bleveindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func newTestIndex(t *testing.T, contents ...*domain.Content) *Index {
+	t.Helper()
+
+	index, err := Open("", 100)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = index.Close() })
+	require.NoError(t, index.Put(contents))
+
+	return index
+}
+
+// matchIDs returns the IDs of the contents matching q in mode, most relevant first.
+func matchIDs(t *testing.T, index *Index, q string, mode domain.SearchMode) []string {
+	t.Helper()
+
+	params := domain.DefaultSearchParams()
+	params.Query, params.Mode = q, mode
+	matches, err := index.Match(context.Background(), params)
+	require.NoError(t, err)
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ContentID
+	}
+
+	return ids
+}
+
+func testContents() []*domain.Content {
+	return []*domain.Content{
+		{ID: "programming", Title: "Programming Kubernetes", Tags: []string{"devops"}},
+		{ID: "docker", Title: "Docker Basics", Tags: []string{"programming", "containers"}},
+		{ID: "golang", Title: "The Go Programming Language", Tags: []string{"golang"}},
+	}
+}
+
+func TestIndex_WebsearchQueries(t *testing.T) {
+	index := newTestIndex(t, testContents()...)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"kubernetes", []string{"programming"}},
+		{"programs", []string{"programming", "golang", "docker"}}, // Stemmed
+		{"the kubernetes", []string{"programming"}},               // Stop words are ignored
+		{"programming -docker", []string{"programming", "golang"}},
+		{"docker OR golang", []string{"docker", "golang"}},
+		{`"programming language"`, []string{"golang"}},
+		{`"language programming"`, []string{}},
+		{"rust", []string{}},
+		{"the", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, matchIDs(t, index, tt.query, domain.SearchModeWebsearch))
+		})
+	}
+
+	// The tag match ranks below the title matches
+	assert.Equal(t, "docker", matchIDs(t, index, "programming", domain.SearchModeWebsearch)[2])
+}
+
+func TestIndex_PrefixQueries(t *testing.T) {
+	index := newTestIndex(t, testContents()...)
+
+	assert.ElementsMatch(t, []string{"programming", "golang", "docker"}, matchIDs(t, index, "programm", domain.SearchModePrefix))
+	assert.Equal(t, []string{"programming"}, matchIDs(t, index, "Prog KUB", domain.SearchModePrefix))
+	assert.Equal(t, []string{"docker"}, matchIDs(t, index, "cont", domain.SearchModePrefix))
+	assert.Empty(t, matchIDs(t, index, "!?", domain.SearchModePrefix))
+}
+
+func TestIndex_PutReplacesAndDeletes(t *testing.T) {
+	index := newTestIndex(t, testContents()...)
+
+	require.NoError(t, index.Put([]*domain.Content{{ID: "docker", Title: "Podman Basics"}}))
+	assert.Empty(t, matchIDs(t, index, "docker", domain.SearchModeWebsearch))
+	assert.Equal(t, []string{"docker"}, matchIDs(t, index, "podman", domain.SearchModeWebsearch))
+
+	require.NoError(t, index.Delete("docker"))
+	assert.Empty(t, matchIDs(t, index, "podman", domain.SearchModeWebsearch))
+
+	count, err := index.Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func TestSplitWebsearch(t *testing.T) {
+	assert.Equal(t, []websearchTerm{
+		{text: "go"},
+		{text: "OR", or: true},
+		{text: "docker compose", phrase: true},
+		{text: "rust", negated: true},
+		{text: "unclosed quote", phrase: true},
+	}, splitWebsearch(`  go OR "docker compose" -rust "unclosed quote`))
+}
@@ -0,0 +1,359 @@
+package bleveindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// errBuildFinished is returned when a build is committed or aborted twice.
+var errBuildFinished = errors.New("index build already finished")
+
+// Config configures the indexes of a Repository.
+type Config struct {
+	// Dir holds the index files, one directory per build; empty keeps them in memory
+	Dir string
+	// Contents a search query matches at most
+	MaxMatches int
+}
+
+// Repository decorates a domain.ContentRepository so an Index answers search queries:
+// the contents it matches are handed to the decorated repository, which still applies
+// the filters, curation, sorting and pagination in SQL. Writes go to the decorated
+// repository first, then to the index.
+//
+// The index is rebuilt from the database in the background (see BeginRebuild) while
+// the live one, if any, keeps answering: writes go to both, and the rebuilt index
+// replaces the live one on commit. Until the first rebuild commits, and for searches
+// whose Backend isn't bleve, the decorated repository matches the query itself.
+//
+// The index lives in the process, so only writes through this Repository reach it:
+// it suits single-instance deployments, where syncs and admin changes run in the
+// instance serving searches.
+type Repository struct {
+	domain.ContentRepository
+	cfg    Config
+	logger *zap.Logger
+
+	// Searches and writes hold mu for reading while they use the indexes, so a
+	// commit never removes an index in use
+	mu       sync.RWMutex
+	live     *Index
+	building *build
+}
+
+// NewRepository wraps next with an index built by BeginRebuild. Any index left in
+// cfg.Dir by a previous run is removed.
+func NewRepository(next domain.ContentRepository, cfg Config, logger *zap.Logger) (*Repository, error) {
+	if cfg.Dir != "" {
+		if err := os.RemoveAll(cfg.Dir); err != nil {
+			return nil, fmt.Errorf("removing previous indexes: %w", err)
+		}
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			return nil, fmt.Errorf("creating index directory: %w", err)
+		}
+	}
+
+	return &Repository{ContentRepository: next, cfg: cfg, logger: logger}, nil
+}
+
+// Close closes the live index and removes the one being built.
+func (r *Repository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.building != nil {
+		if err := r.building.index.Remove(); err != nil {
+			r.logger.Warn("failed to remove unfinished search index", zap.Error(err))
+		}
+		r.building = nil
+	}
+	if r.live == nil {
+		return nil
+	}
+
+	return r.live.Close()
+}
+
+// BeginRebuild starts an empty index in its own directory of cfg.Dir.
+func (r *Repository) BeginRebuild() (domain.IndexBuild, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.building != nil {
+		return nil, fmt.Errorf("%w: search index rebuild in progress", domain.ErrBusy)
+	}
+
+	path := ""
+	if r.cfg.Dir != "" {
+		path = filepath.Join(r.cfg.Dir, fmt.Sprintf("gen-%d", time.Now().UnixNano()))
+	}
+	index, err := Open(path, r.cfg.MaxMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	r.building = &build{repo: r, index: index, written: make(map[string]struct{})}
+
+	return r.building, nil
+}
+
+// Search finds contents matching params, the query matched by the index.
+func (r *Repository) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	params, err := r.match(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ContentRepository.Search(ctx, params)
+}
+
+// Facets counts the contents matching params per type, provider and tag, the query
+// matched by the index.
+func (r *Repository) Facets(ctx context.Context, params domain.SearchParams, tagLimit int) (*domain.SearchFacets, error) {
+	params, err := r.match(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ContentRepository.Facets(ctx, params, tagLimit)
+}
+
+// Count returns the number of contents matching params, the query matched by the index.
+func (r *Repository) Count(ctx context.Context, params domain.SearchParams) (int64, error) {
+	params, err := r.match(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.ContentRepository.Count(ctx, params)
+}
+
+// Iterate calls fn with batches of the contents matching params, the query matched
+// by the index.
+func (r *Repository) Iterate(ctx context.Context, params domain.SearchParams, batchSize int, fn func(batch []*domain.Content) error) error {
+	params, err := r.match(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return r.ContentRepository.Iterate(ctx, params, batchSize, fn)
+}
+
+// match sets the text matches of params from the live index when it has a query to
+// be matched by Bleve.
+func (r *Repository) match(ctx context.Context, params domain.SearchParams) (domain.SearchParams, error) {
+	if params.Query == "" || params.Backend != domain.SearchBackendBleve {
+		return params, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.live == nil {
+		// Not built yet
+		return params, nil
+	}
+
+	matches, err := r.live.Match(ctx, params)
+	if err != nil {
+		return params, err
+	}
+	params.TextMatches = matches
+
+	return params, nil
+}
+
+// Upsert creates or updates a content, then indexes it.
+func (r *Repository) Upsert(ctx context.Context, content *domain.Content) error {
+	if err := r.ContentRepository.Upsert(ctx, content); err != nil {
+		return err
+	}
+
+	r.put(content)
+
+	return nil
+}
+
+// BulkUpsert creates or updates contents, then indexes those that changed.
+func (r *Repository) BulkUpsert(ctx context.Context, contents []*domain.Content) error {
+	_, err := r.UpsertChanged(ctx, contents)
+
+	return err
+}
+
+// UpsertChanged creates or updates contents, then indexes and returns those created
+// or changed: all of them unless the wrapped repository tells unchanged contents
+// apart (see domain.ChangedContentUpserter).
+func (r *Repository) UpsertChanged(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
+	changed := contents
+	if upserter, ok := r.ContentRepository.(domain.ChangedContentUpserter); ok {
+		var err error
+		if changed, err = upserter.UpsertChanged(ctx, contents); err != nil {
+			return nil, err
+		}
+	} else if err := r.ContentRepository.BulkUpsert(ctx, contents); err != nil {
+		return nil, err
+	}
+
+	r.put(changed...)
+
+	return changed, nil
+}
+
+// UpdateCuration changes the editorial flags of a content, then indexes it again: a
+// content unblocked for the first time since the index was built isn't in it yet.
+func (r *Repository) UpdateCuration(ctx context.Context, id string, patch domain.CurationPatch) (*domain.Content, error) {
+	content, err := r.ContentRepository.UpdateCuration(ctx, id, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	r.put(content)
+
+	return content, nil
+}
+
+// Delete removes a content, then removes it from the indexes.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	err := r.ContentRepository.Delete(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.live != nil {
+		if indexErr := r.live.Delete(id); indexErr != nil {
+			r.logger.Warn("failed to remove content from search index", zap.String("id", id), zap.Error(indexErr))
+		}
+	}
+	if r.building != nil {
+		if indexErr := r.building.delete(id); indexErr != nil {
+			r.logger.Warn("failed to remove content from rebuilt search index", zap.String("id", id), zap.Error(indexErr))
+		}
+	}
+
+	return err
+}
+
+// put indexes contents already stored in the live index and the one being built.
+// Failures are logged rather than returned: the database is the source of truth,
+// and a rebuild catches up.
+func (r *Repository) put(contents ...*domain.Content) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.live != nil {
+		if err := r.live.Put(contents); err != nil {
+			r.logger.Warn("failed to index contents", zap.Int("contents", len(contents)), zap.Error(err))
+		}
+	}
+	if r.building != nil {
+		if err := r.building.write(contents); err != nil {
+			r.logger.Warn("failed to index contents in rebuilt search index", zap.Int("contents", len(contents)), zap.Error(err))
+		}
+	}
+}
+
+// finish ends b, making its index live when commit is set, and returns the index
+// to remove.
+func (r *Repository) finish(b *build, commit bool) (*Index, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.building != b {
+		return nil, errBuildFinished
+	}
+	r.building = nil
+
+	if !commit {
+		return b.index, nil
+	}
+	previous := r.live
+	r.live = b.index
+
+	return previous, nil
+}
+
+// build is an index being rebuilt. It remembers the contents written through the
+// Repository since it began, so the older copies the rebuild reads from the database
+// don't overwrite them.
+type build struct {
+	repo  *Repository
+	index *Index
+
+	// mu orders the rebuild's puts with the writes, so a content is never indexed
+	// after its newer version
+	mu      sync.Mutex
+	written map[string]struct{}
+}
+
+// Put indexes the contents not written since the build began.
+func (b *build) Put(contents []*domain.Content) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	fresh := make([]*domain.Content, 0, len(contents))
+	for _, c := range contents {
+		if _, ok := b.written[c.ID]; !ok {
+			fresh = append(fresh, c)
+		}
+	}
+
+	return b.index.Put(fresh)
+}
+
+// Commit makes the index live and removes the previous one.
+func (b *build) Commit() error {
+	previous, err := b.repo.finish(b, true)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return nil
+	}
+
+	return previous.Remove()
+}
+
+// Abort removes the index.
+func (b *build) Abort() error {
+	index, err := b.repo.finish(b, false)
+	if err != nil {
+		return err
+	}
+
+	return index.Remove()
+}
+
+// write indexes contents written through the Repository.
+func (b *build) write(contents []*domain.Content) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, c := range contents {
+		b.written[c.ID] = struct{}{}
+	}
+
+	return b.index.Put(contents)
+}
+
+// delete removes a content deleted through the Repository.
+func (b *build) delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.written[id] = struct{}{}
+
+	return b.index.Delete(id)
+}
@@ -0,0 +1,184 @@
+package bleveindex
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeContentRepo records the params it is searched with and stores upserts in memory.
+type fakeContentRepo struct {
+	domain.ContentRepository
+	contents map[string]*domain.Content
+	params   domain.SearchParams
+}
+
+func (r *fakeContentRepo) Search(_ context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	r.params = params
+
+	return domain.NewSearchResult(nil, 0, params), nil
+}
+
+func (r *fakeContentRepo) BulkUpsert(_ context.Context, contents []*domain.Content) error {
+	for _, c := range contents {
+		c.ID = "id-" + c.ExternalID
+		r.contents[c.ID] = c
+	}
+
+	return nil
+}
+
+func (r *fakeContentRepo) Delete(_ context.Context, id string) error {
+	if _, ok := r.contents[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.contents, id)
+
+	return nil
+}
+
+func (r *fakeContentRepo) Iterate(_ context.Context, _ domain.SearchParams, _ int, fn func(batch []*domain.Content) error) error {
+	batch := make([]*domain.Content, 0, len(r.contents))
+	for _, c := range r.contents {
+		batch = append(batch, c)
+	}
+
+	return fn(batch)
+}
+
+// rebuild builds the index of repo from the contents of next and commits it.
+func rebuild(t *testing.T, repo *Repository, next *fakeContentRepo) {
+	t.Helper()
+
+	build, err := repo.BeginRebuild()
+	require.NoError(t, err)
+	require.NoError(t, next.Iterate(context.Background(), domain.SearchParams{}, 10, build.Put))
+	require.NoError(t, build.Commit())
+}
+
+// bleveParams returns search params matching q with the Bleve index.
+func bleveParams(q string) domain.SearchParams {
+	params := domain.DefaultSearchParams()
+	params.Query, params.Backend = q, domain.SearchBackendBleve
+
+	return params
+}
+
+func TestRepository_SearchesThroughIndex(t *testing.T) {
+	next := &fakeContentRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Golang Basics"},
+	}}
+	repo, err := NewRepository(next, Config{MaxMatches: 100}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+
+	// Until the index is built, the database matches queries
+	params := bleveParams("golang")
+	_, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Nil(t, next.params.TextMatches)
+
+	rebuild(t, repo, next)
+
+	// Upserted contents are indexed with their stored ID
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{{ExternalID: "2", Title: "Docker for Golang"}}))
+
+	_, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, next.params.TextMatches, 2)
+	assert.ElementsMatch(t, []string{"id-1", "id-2"}, []string{next.params.TextMatches[0].ContentID, next.params.TextMatches[1].ContentID})
+
+	// Deleted contents leave the index
+	require.NoError(t, repo.Delete(ctx, "id-2"))
+	_, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TextMatch{{ContentID: "id-1", Relevance: next.params.TextMatches[0].Relevance}}, next.params.TextMatches)
+
+	// Without a query the database lists contents
+	_, err = repo.Search(ctx, domain.DefaultSearchParams())
+	require.NoError(t, err)
+	assert.Nil(t, next.params.TextMatches)
+
+	// Searches on the postgres backend don't use the index
+	params.Backend = domain.SearchBackendPostgres
+	_, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Nil(t, next.params.TextMatches)
+
+	// A query matching nothing matches no content, rather than every content
+	_, err = repo.Search(ctx, bleveParams("rust"))
+	require.NoError(t, err)
+	assert.NotNil(t, next.params.TextMatches)
+	assert.Empty(t, next.params.TextMatches)
+}
+
+func TestRepository_RebuildKeepsWritesMadeMeanwhile(t *testing.T) {
+	next := &fakeContentRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Golang Basics"},
+		"id-2": {ID: "id-2", Title: "Rust Basics"},
+	}}
+	repo, err := NewRepository(next, Config{Dir: t.TempDir(), MaxMatches: 100}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+	rebuild(t, repo, next)
+
+	build, err := repo.BeginRebuild()
+	require.NoError(t, err)
+	_, err = repo.BeginRebuild()
+	require.ErrorIs(t, err, domain.ErrBusy)
+
+	// The rebuild read the contents before they were changed
+	stale := []*domain.Content{{ID: "id-1", Title: "Golang Basics"}, {ID: "id-2", Title: "Rust Basics"}}
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{{ExternalID: "1", Title: "Zig Basics"}}))
+	require.NoError(t, repo.Delete(ctx, "id-2"))
+
+	// The live index answers until the commit, with the writes applied
+	_, err = repo.Search(ctx, bleveParams("zig"))
+	require.NoError(t, err)
+	assert.Len(t, next.params.TextMatches, 1)
+
+	require.NoError(t, build.Put(stale))
+	require.NoError(t, build.Commit())
+	require.ErrorIs(t, build.Abort(), errBuildFinished)
+
+	for q, want := range map[string]int{"zig": 1, "golang": 0, "rust": 0} {
+		_, err = repo.Search(ctx, bleveParams(q))
+		require.NoError(t, err)
+		assert.Len(t, next.params.TextMatches, want, q)
+	}
+
+	// The previous index was removed
+	entries, err := os.ReadDir(repo.cfg.Dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRepository_AbortKeepsLiveIndex(t *testing.T) {
+	next := &fakeContentRepo{contents: map[string]*domain.Content{
+		"id-1": {ID: "id-1", Title: "Golang Basics"},
+	}}
+	repo, err := NewRepository(next, Config{MaxMatches: 100}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	rebuild(t, repo, next)
+
+	build, err := repo.BeginRebuild()
+	require.NoError(t, err)
+	require.NoError(t, build.Abort())
+
+	_, err = repo.Search(context.Background(), bleveParams("golang"))
+	require.NoError(t, err)
+	assert.Len(t, next.params.TextMatches, 1)
+
+	// Another rebuild can begin
+	_, err = repo.BeginRebuild()
+	require.NoError(t, err)
+}
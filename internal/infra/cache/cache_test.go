@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+
+	data, err := c.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), time.Minute))
+	data, err = c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v"), data)
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	c := NewMemoryCache(10)
+	ctx := context.Background()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), time.Second))
+
+	now = now.Add(2 * time.Second)
+	data, err := c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Nil(t, data, "expired entry should not be returned")
+	assert.Equal(t, 0, c.Len(), "expired entry should be removed on read")
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+
+	// Touch "a" so "b" becomes the LRU entry
+	_, _ = c.Get(ctx, "a")
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+	b, _ := c.Get(ctx, "b")
+	assert.Nil(t, b, "LRU entry should be evicted")
+
+	a, _ := c.Get(ctx, "a")
+	assert.Equal(t, []byte("1"), a)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestTieredCache_BackfillsLocalTier(t *testing.T) {
+	local := NewMemoryCache(10)
+	remote := NewMemoryCache(10)
+	c := NewTieredCache(local, remote, time.Second, zap.NewNop())
+	ctx := context.Background()
+
+	require.NoError(t, remote.Set(ctx, "k", []byte("v"), time.Minute))
+
+	data, err := c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v"), data)
+
+	localData, _ := local.Get(ctx, "k")
+	assert.Equal(t, []byte("v"), localData, "remote hit should back-fill local tier")
+}
+
+func TestTieredCache_ClearBothTiers(t *testing.T) {
+	local := NewMemoryCache(10)
+	remote := NewMemoryCache(10)
+	c := NewTieredCache(local, remote, time.Second, zap.NewNop())
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), time.Minute))
+	require.NoError(t, c.Clear(ctx))
+
+	assert.Equal(t, 0, local.Len())
+	assert.Equal(t, 0, remote.Len())
+}
+
+func TestInstrumentedCache_Stats(t *testing.T) {
+	c := NewInstrumentedCache(NewMemoryCache(10))
+	ctx := context.Background()
+
+	_, _ = c.Get(ctx, "k") // miss
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), time.Minute))
+	_, _ = c.Get(ctx, "k") // hit
+	_, _ = c.Get(ctx, "k") // hit
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Sets)
+	assert.InDelta(t, 2.0/3.0, stats.HitRatio, 1e-9)
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/metrics"
+)
+
+// InstrumentedCache decorates a domain.Cache with hit/miss/error accounting.
+// Counters are kept in-process for the admin stats endpoint and mirrored to Prometheus.
+type InstrumentedCache struct {
+	next domain.Cache
+
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	errors  atomic.Uint64
+	sets    atomic.Uint64
+	deletes atomic.Uint64
+}
+
+// NewInstrumentedCache wraps next with metrics collection.
+func NewInstrumentedCache(next domain.Cache) *InstrumentedCache {
+	return &InstrumentedCache{next: next}
+}
+
+// Get retrieves a value and records a hit, miss, or error.
+func (c *InstrumentedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.next.Get(ctx, key)
+
+	switch {
+	case err != nil:
+		c.errors.Add(1)
+		metrics.CacheOperations.WithLabelValues("get", "error").Inc()
+	case data == nil:
+		c.misses.Add(1)
+		metrics.CacheOperations.WithLabelValues("get", "miss").Inc()
+	default:
+		c.hits.Add(1)
+		metrics.CacheOperations.WithLabelValues("get", "hit").Inc()
+	}
+
+	return data, err
+}
+
+// Set stores a value and records the outcome.
+func (c *InstrumentedCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := c.next.Set(ctx, key, value, ttl)
+	c.sets.Add(1)
+	c.record("set", err)
+
+	return err
+}
+
+// Delete removes a value and records the outcome.
+func (c *InstrumentedCache) Delete(ctx context.Context, key string) error {
+	err := c.next.Delete(ctx, key)
+	c.deletes.Add(1)
+	c.record("delete", err)
+
+	return err
+}
+
+// Clear removes all values and records the outcome.
+func (c *InstrumentedCache) Clear(ctx context.Context) error {
+	err := c.next.Clear(ctx)
+	c.record("clear", err)
+
+	return err
+}
+
+// Stats returns a snapshot of the in-process counters.
+func (c *InstrumentedCache) Stats() domain.CacheStats {
+	stats := domain.CacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Errors:  c.errors.Load(),
+		Sets:    c.sets.Load(),
+		Deletes: c.deletes.Load(),
+	}
+
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+
+	return stats
+}
+
+// record updates the error counter and Prometheus for non-get operations.
+func (c *InstrumentedCache) record(operation string, err error) {
+	if err != nil {
+		c.errors.Add(1)
+		metrics.CacheOperations.WithLabelValues(operation, "error").Inc()
+
+		return
+	}
+	metrics.CacheOperations.WithLabelValues(operation, "ok").Inc()
+}
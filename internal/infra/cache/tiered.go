@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
+)
+
+// TieredCache implements domain.Cache by layering a fast local cache in front of a shared remote one.
+//
+// Reads hit the local tier first and fall back to the remote tier, back-filling the local tier
+// on a remote hit. Writes go to both tiers. Local entries use a short TTL (capped by localTTL)
+// so that invalidations performed on other instances become visible within that window.
+type TieredCache struct {
+	local    domain.Cache
+	remote   domain.Cache
+	localTTL time.Duration
+	logger   *zap.Logger
+}
+
+// NewTieredCache creates a new two-tier cache.
+// localTTL is the maximum lifetime of an entry in the local tier.
+func NewTieredCache(local, remote domain.Cache, localTTL time.Duration, logger *zap.Logger) *TieredCache {
+	return &TieredCache{
+		local:    local,
+		remote:   remote,
+		localTTL: localTTL,
+		logger:   logger,
+	}
+}
+
+// Get retrieves a value from the local tier, falling back to the remote tier.
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if data, err := c.local.Get(ctx, key); err == nil && data != nil {
+		return data, nil
+	}
+
+	data, err := c.remote.Get(ctx, key)
+	if err != nil || data == nil {
+		return data, err
+	}
+
+	// Back-fill the local tier so subsequent reads skip the network round-trip
+	if err := c.local.Set(ctx, key, data, c.localTTL); err != nil {
+		logger.FromContext(ctx, c.logger).Debug("local cache back-fill failed", zap.String("key", key), zap.Error(err))
+	}
+
+	return data, nil
+}
+
+// Set stores a value in both tiers. The local copy never outlives localTTL.
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+
+	if err := c.local.Set(ctx, key, value, c.localEntryTTL(ttl)); err != nil {
+		logger.FromContext(ctx, c.logger).Debug("local cache set failed", zap.String("key", key), zap.Error(err))
+	}
+
+	return nil
+}
+
+// Delete removes a value from both tiers.
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	_ = c.local.Delete(ctx, key)
+
+	return c.remote.Delete(ctx, key)
+}
+
+// Clear removes all values from both tiers.
+func (c *TieredCache) Clear(ctx context.Context) error {
+	_ = c.local.Clear(ctx)
+
+	return c.remote.Clear(ctx)
+}
+
+// localEntryTTL caps the requested TTL at the configured local TTL.
+func (c *TieredCache) localEntryTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > c.localTTL {
+		return c.localTTL
+	}
+
+	return ttl
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// AnalyticsEventModel is the GORM model for the analytics_events table.
+type AnalyticsEventModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Type       string    `gorm:"type:varchar(20);not null"`
+	Query      string    `gorm:"type:varchar(200);not null"`
+	ContentID  string    `gorm:"type:uuid;not null"`
+	Position   int       `gorm:"not null"`
+	SessionID  string    `gorm:"type:varchar(64);not null"`
+	RequestID  string    `gorm:"type:varchar(64);not null"`
+	OccurredAt time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for AnalyticsEventModel.
+func (AnalyticsEventModel) TableName() string {
+	return "analytics_events"
+}
+
+// AnalyticsRepository implements domain.AnalyticsEventRepository using PostgreSQL.
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new PostgreSQL analytics event repository.
+func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// CreateBatch stores events in one statement and sets their IDs and CreatedAt.
+func (r *AnalyticsRepository) CreateBatch(ctx context.Context, events []*domain.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	models := make([]AnalyticsEventModel, len(events))
+	for i, e := range events {
+		models[i] = AnalyticsEventModel{
+			Type:       string(e.Type),
+			Query:      e.Query,
+			ContentID:  e.ContentID,
+			Position:   e.Position,
+			SessionID:  e.SessionID,
+			RequestID:  e.RequestID,
+			OccurredAt: e.OccurredAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("creating analytics events: %w", wrapTimeout(err))
+	}
+
+	for i, e := range events {
+		e.ID = models[i].ID
+		e.CreatedAt = models[i].CreatedAt
+	}
+
+	return nil
+}
+
+// AggregateCTR counts impressions and clicks per content for events that occurred at or after since.
+func (r *AnalyticsRepository) AggregateCTR(ctx context.Context, since time.Time) ([]domain.ContentCTR, error) {
+	var rows []struct {
+		ContentID   string
+		Impressions int64
+		Clicks      int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&AnalyticsEventModel{}).
+		Select("content_id, COUNT(*) FILTER (WHERE type = ?) AS impressions, COUNT(*) FILTER (WHERE type = ?) AS clicks",
+			string(domain.AnalyticsEventImpression), string(domain.AnalyticsEventClick)).
+		Where("occurred_at >= ?", since).
+		Group("content_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("aggregating ctr: %w", wrapTimeout(err))
+	}
+
+	ctrs := make([]domain.ContentCTR, len(rows))
+	for i, row := range rows {
+		ctrs[i] = domain.ContentCTR{ContentID: row.ContentID, Impressions: row.Impressions, Clicks: row.Clicks}
+	}
+
+	return ctrs, nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// AuditLogModel is the GORM model for the audit_logs table.
+type AuditLogModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Actor      string    `gorm:"type:varchar(255);not null"`
+	Method     string    `gorm:"type:varchar(10);not null"`
+	Path       string    `gorm:"type:varchar(2048);not null"`
+	Route      string    `gorm:"type:varchar(255);not null"`
+	Params     string    `gorm:"type:jsonb;not null"`
+	Status     int       `gorm:"not null"`
+	DurationMs int64     `gorm:"not null"`
+	IP         string    `gorm:"type:varchar(64);not null"`
+	RequestID  string    `gorm:"type:varchar(64);not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for AuditLogModel.
+func (AuditLogModel) TableName() string {
+	return "audit_logs"
+}
+
+// ToDomain converts AuditLogModel to domain.AuditEntry.
+func (m *AuditLogModel) ToDomain() *domain.AuditEntry {
+	var params map[string]any
+	_ = json.Unmarshal([]byte(m.Params), &params) // Written by Create, always valid JSON
+
+	return &domain.AuditEntry{
+		ID:         m.ID,
+		Actor:      m.Actor,
+		Method:     m.Method,
+		Path:       m.Path,
+		Route:      m.Route,
+		Params:     params,
+		Status:     m.Status,
+		DurationMs: m.DurationMs,
+		IP:         m.IP,
+		RequestID:  m.RequestID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// AuditRepository implements domain.AuditLogRepository using PostgreSQL.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new PostgreSQL audit log repository.
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create stores an entry and sets its ID and CreatedAt.
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	params := entry.Params
+	if params == nil {
+		params = map[string]any{}
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("encoding audit params: %w", err)
+	}
+
+	model := &AuditLogModel{
+		Actor:      entry.Actor,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Route:      entry.Route,
+		Params:     string(encoded),
+		Status:     entry.Status,
+		DurationMs: entry.DurationMs,
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("creating audit entry: %w", wrapTimeout(err))
+	}
+
+	entry.ID = model.ID
+	entry.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+// List returns a page of entries matching filter, newest first, and the total match count.
+func (r *AuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&AuditLogModel{})
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("counting audit entries: %w", wrapTimeout(err))
+	}
+
+	var models []AuditLogModel
+	err := query.Order("created_at DESC").Offset(filter.Offset()).Limit(filter.PageSize).Find(&models).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing audit entries: %w", wrapTimeout(err))
+	}
+
+	entries := make([]*domain.AuditEntry, len(models))
+	for i := range models {
+		entries[i] = models[i].ToDomain()
+	}
+
+	return entries, total, nil
+}
+
+// DeleteBefore removes the entries created before before.
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&AuditLogModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("deleting audit entries: %w", wrapTimeout(result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
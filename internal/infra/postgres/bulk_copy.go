@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// stagingTable receives the rows of BulkUpsertFast before they're merged into
+// contents. It only lives until the end of the transaction.
+const stagingTable = "contents_staging"
+
+// stagingColumns are the columns copied into stagingTable: ord, the position of
+// the row in the batch, then the columns inserted into contents.
+var stagingColumns = []string{
+	"ord", "provider_id", "external_id", "title", "type", "tags", "url", "thumbnail_url", "author",
+	"views", "likes", "duration", "duration_seconds", "listens", "reading_time", "reactions", "comments",
+	"score", "published_at", "created_at", "updated_at", "content_hash",
+}
+
+// createStagingTable creates stagingTable with the types of the contents columns.
+const createStagingTable = `
+	CREATE TEMP TABLE ` + stagingTable + ` (
+		ord INTEGER NOT NULL,
+		provider_id VARCHAR(50) NOT NULL,
+		external_id VARCHAR(100) NOT NULL,
+		title VARCHAR(500) NOT NULL,
+		type VARCHAR(20) NOT NULL,
+		tags TEXT[],
+		url VARCHAR(2048),
+		thumbnail_url VARCHAR(2048),
+		author VARCHAR(200),
+		views INTEGER,
+		likes INTEGER,
+		duration VARCHAR(20),
+		duration_seconds INTEGER,
+		listens INTEGER,
+		reading_time INTEGER,
+		reactions INTEGER,
+		comments INTEGER,
+		score DECIMAL(10,2),
+		published_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP,
+		updated_at TIMESTAMP,
+		content_hash VARCHAR(64)
+	) ON COMMIT DROP`
+
+// dropUnchangedStaging removes the staged keys whose hash and score didn't change from
+// the staging table, returning their stored rows, like findUnchanged: a key staged
+// more than once is compared by its last row.
+const dropUnchangedStaging = `
+	WITH unchanged AS (
+		SELECT c.id, c.provider_id, c.external_id, c.created_at, c.updated_at, c.score
+		FROM (
+			SELECT DISTINCT ON (provider_id, external_id) provider_id, external_id, content_hash, score
+			FROM ` + stagingTable + `
+			ORDER BY provider_id, external_id, ord DESC
+		) s
+		JOIN contents c ON c.provider_id = s.provider_id AND c.external_id = s.external_id
+		WHERE c.content_hash = s.content_hash AND c.score = s.score + c.ctr_boost
+	), dropped AS (
+		DELETE FROM ` + stagingTable + ` s USING unchanged u
+		WHERE s.provider_id = u.provider_id AND s.external_id = u.external_id
+	)
+	SELECT * FROM unchanged`
+
+// mergeStaging upserts the staged rows into contents like upsertOnConflict. A key
+// staged more than once keeps its last row, as if the rows were upserted in order.
+var mergeStaging = func() string {
+	columns := strings.Join(stagingColumns[1:], ", ")
+	updates := make([]string, 0, len(upsertColumns)+1)
+	for _, c := range upsertColumns {
+		updates = append(updates, c+" = excluded."+c)
+	}
+	updates = append(updates, "score = excluded.score + contents.ctr_boost")
+
+	return `
+		INSERT INTO contents (` + columns + `)
+		SELECT DISTINCT ON (provider_id, external_id) ` + columns + `
+		FROM ` + stagingTable + `
+		ORDER BY provider_id, external_id, ord DESC
+		ON CONFLICT (provider_id, external_id) DO UPDATE SET ` + strings.Join(updates, ", ") + `
+		RETURNING id, provider_id, external_id, created_at, updated_at, score`
+}()
+
+// mergedRow is the stored row of a content, returned by mergeStaging for the
+// contents it wrote, and by findUnchanged and dropUnchangedStaging for the others.
+type mergedRow struct {
+	ID         string
+	ProviderID string
+	ExternalID string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Score      float64
+}
+
+// set sets the database-generated fields of m from the row.
+func (row mergedRow) set(m *ContentModel) {
+	m.ID = row.ID
+	m.CreatedAt = row.CreatedAt
+	m.UpdatedAt = row.UpdatedAt
+	m.Score = row.Score
+}
+
+// BulkUpsertFast creates or updates multiple contents like BulkUpsert, for large
+// batches: the rows are streamed with COPY into a temporary staging table, then
+// merged into contents with a single INSERT ... ON CONFLICT, instead of one
+// statement per 100 rows. BulkUpsert uses it above the copy threshold.
+// Returns domain.ErrLockLost without writing if ctx carries a stale fencing token
+// (see locker.FenceFromContext).
+func (r *Repository) BulkUpsertFast(ctx context.Context, contents []*domain.Content) error {
+	_, err := r.bulkUpsertFast(ctx, contents)
+
+	return err
+}
+
+// bulkUpsertFast runs BulkUpsertFast, returning the contents created or changed.
+// Unchanged keys are dropped from the staging table before the merge.
+func (r *Repository) bulkUpsertFast(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	now := upsertTimestamp()
+	models := FromDomainSlice(contents)
+	rows := make([][]any, len(models))
+	for i, m := range models {
+		m.CreatedAt = now
+		m.UpdatedAt = now
+		rows[i] = []any{
+			i, m.ProviderID, m.ExternalID, m.Title, m.Type, []string(m.Tags), m.URL, m.ThumbnailURL, m.Author,
+			m.Views, m.Likes, m.Duration, m.DurationSeconds, m.Listens, m.ReadingTime, m.Reactions, m.Comments,
+			m.Score, m.PublishedAt, m.CreatedAt, m.UpdatedAt, m.ContentHash,
+		}
+	}
+
+	// COPY needs the pgx connection under database/sql, so the transaction is held
+	// on a dedicated connection the COPY also goes through
+	var changed []*ContentModel
+	err := r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		sqlConn, ok := conn.Statement.ConnPool.(*sql.Conn)
+		if !ok {
+			return fmt.Errorf("bulk upserting contents: unexpected connection %T", conn.Statement.ConnPool)
+		}
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := checkFenceAndTouch(contents)(ctx, tx); err != nil {
+				return err
+			}
+			if err := tx.Exec(createStagingTable).Error; err != nil {
+				return fmt.Errorf("creating staging table: %w", wrapTimeout(err))
+			}
+			if err := copyRows(ctx, sqlConn, stagingTable, stagingColumns, rows); err != nil {
+				return fmt.Errorf("copying contents: %w", wrapTimeout(err))
+			}
+
+			var unchanged []mergedRow
+			if err := tx.Raw(dropUnchangedStaging).Scan(&unchanged).Error; err != nil {
+				return fmt.Errorf("finding unchanged contents: %w", wrapTimeout(err))
+			}
+			changed = skipUnchanged(models, unchanged)
+			if len(changed) == 0 {
+				return nil
+			}
+
+			var merged []mergedRow
+			if err := tx.Raw(mergeStaging).Scan(&merged).Error; err != nil {
+				return fmt.Errorf("merging staged contents: %w", wrapTimeout(err))
+			}
+			setMerged(changed, merged)
+
+			return r.writeUpserted(tx, changed)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return setUpserted(contents, models, changed), nil
+}
+
+// copyRows streams rows of columns into table with COPY, on the connection of the
+// open transaction.
+func copyRows(ctx context.Context, conn *sql.Conn, table string, columns []string, rows [][]any) error {
+	return conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+
+		_, err := pgxConn.Conn().CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+
+		return err
+	})
+}
+
+// setMerged sets the database-generated fields of models from the rows merged for
+// their key.
+func setMerged(models []*ContentModel, merged []mergedRow) {
+	byKey := make(map[[2]string]mergedRow, len(merged))
+	for _, m := range merged {
+		byKey[[2]string{m.ProviderID, m.ExternalID}] = m
+	}
+
+	for _, m := range models {
+		byKey[[2]string{m.ProviderID, m.ExternalID}].set(m)
+	}
+}
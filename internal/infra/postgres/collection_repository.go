@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// CollectionModel is the GORM model for the collections table.
+type CollectionModel struct {
+	ID          string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Title       string    `gorm:"type:varchar(500);not null"`
+	Description string    `gorm:"type:text;not null;default:''"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for CollectionModel.
+func (CollectionModel) TableName() string {
+	return "collections"
+}
+
+// ToDomain converts CollectionModel to domain.Collection with the given content IDs.
+func (m *CollectionModel) ToDomain(contentIDs []string) *domain.Collection {
+	if contentIDs == nil {
+		contentIDs = []string{}
+	}
+
+	return &domain.Collection{
+		ID:          m.ID,
+		Title:       m.Title,
+		Description: m.Description,
+		ContentIDs:  contentIDs,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// CollectionItemModel is the GORM model for the collection_items table.
+type CollectionItemModel struct {
+	CollectionID string `gorm:"type:uuid;primaryKey"`
+	ContentID    string `gorm:"type:uuid;primaryKey"`
+	Position     int    `gorm:"not null"`
+}
+
+// TableName returns the table name for CollectionItemModel.
+func (CollectionItemModel) TableName() string {
+	return "collection_items"
+}
+
+// CollectionRepository implements domain.CollectionRepository using PostgreSQL.
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository creates a new PostgreSQL collection repository.
+func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+// Create stores a new collection and sets its ID and timestamps.
+func (r *CollectionRepository) Create(ctx context.Context, collection *domain.Collection) error {
+	model := &CollectionModel{
+		Title:       collection.Title,
+		Description: collection.Description,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("creating collection: %w", wrapTimeout(err))
+		}
+
+		return replaceCollectionItems(tx, model.ID, collection.ContentIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	collection.ID = model.ID
+	collection.CreatedAt = model.CreatedAt
+	collection.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+// Update replaces the title, description and contents of a collection.
+func (r *CollectionRepository) Update(ctx context.Context, collection *domain.Collection) error {
+	var model CollectionModel
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&CollectionModel{}).Where("id = ?", collection.ID).Updates(map[string]any{
+			"title":       collection.Title,
+			"description": collection.Description,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("updating collection: %w", wrapTimeout(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("collection %s: %w", collection.ID, domain.ErrNotFound)
+		}
+
+		if err := replaceCollectionItems(tx, collection.ID, collection.ContentIDs); err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ?", collection.ID).First(&model).Error; err != nil {
+			return fmt.Errorf("reading updated collection: %w", wrapTimeout(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	collection.CreatedAt = model.CreatedAt
+	collection.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+// replaceCollectionItems sets the contents of a collection, in order.
+// Returns domain.ErrInvalidParams if a content doesn't exist.
+func replaceCollectionItems(tx *gorm.DB, collectionID string, contentIDs []string) error {
+	if err := tx.Where("collection_id = ?", collectionID).Delete(&CollectionItemModel{}).Error; err != nil {
+		return fmt.Errorf("clearing collection items: %w", wrapTimeout(err))
+	}
+	if len(contentIDs) == 0 {
+		return nil
+	}
+
+	var found int64
+	if err := tx.Model(&ContentModel{}).Where("id IN ?", contentIDs).Count(&found).Error; err != nil {
+		return fmt.Errorf("checking collection contents: %w", wrapTimeout(err))
+	}
+	if int(found) != len(contentIDs) {
+		return fmt.Errorf("%d of %d collection contents don't exist: %w",
+			len(contentIDs)-int(found), len(contentIDs), domain.ErrInvalidParams)
+	}
+
+	items := make([]CollectionItemModel, len(contentIDs))
+	for i, id := range contentIDs {
+		items[i] = CollectionItemModel{CollectionID: collectionID, ContentID: id, Position: i}
+	}
+	if err := tx.Create(&items).Error; err != nil {
+		return fmt.Errorf("storing collection items: %w", wrapTimeout(err))
+	}
+
+	return nil
+}
+
+// Delete removes a collection by ID along with its items.
+func (r *CollectionRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&CollectionModel{})
+	if result.Error != nil {
+		return fmt.Errorf("deleting collection: %w", wrapTimeout(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("collection %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a collection by ID.
+func (r *CollectionRepository) GetByID(ctx context.Context, id string) (*domain.Collection, error) {
+	var model CollectionModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("collection %s: %w", id, domain.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("getting collection by id: %w", wrapTimeout(err))
+	}
+
+	contentIDs, err := r.contentIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(contentIDs[id]), nil
+}
+
+// List returns all collections ordered by creation time.
+func (r *CollectionRepository) List(ctx context.Context) ([]*domain.Collection, error) {
+	var models []CollectionModel
+	if err := r.db.WithContext(ctx).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("listing collections: %w", wrapTimeout(err))
+	}
+
+	ids := make([]string, len(models))
+	for i := range models {
+		ids[i] = models[i].ID
+	}
+	contentIDs, err := r.contentIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]*domain.Collection, len(models))
+	for i := range models {
+		collections[i] = models[i].ToDomain(contentIDs[models[i].ID])
+	}
+
+	return collections, nil
+}
+
+// contentIDs returns the ordered content IDs of each of the given collections.
+func (r *CollectionRepository) contentIDs(ctx context.Context, collectionIDs []string) (map[string][]string, error) {
+	contentIDs := make(map[string][]string, len(collectionIDs))
+	if len(collectionIDs) == 0 {
+		return contentIDs, nil
+	}
+
+	var items []CollectionItemModel
+	err := r.db.WithContext(ctx).
+		Where("collection_id IN ?", collectionIDs).
+		Order("collection_id, position").
+		Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("listing collection items: %w", wrapTimeout(err))
+	}
+
+	for _, item := range items {
+		contentIDs[item.CollectionID] = append(contentIDs[item.CollectionID], item.ContentID)
+	}
+
+	return contentIDs, nil
+}
+
+// Contents returns the contents of a collection in collection order, without blocked ones.
+func (r *CollectionRepository) Contents(ctx context.Context, id string) ([]*domain.Content, error) {
+	var models []ContentModel
+	err := r.db.WithContext(ctx).
+		Joins("JOIN collection_items ON collection_items.content_id = contents.id").
+		Where("collection_items.collection_id = ? AND NOT contents.blocked", id).
+		Order("collection_items.position").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("getting collection contents: %w", wrapTimeout(err))
+	}
+
+	contents := make([]*domain.Content, len(models))
+	for i := range models {
+		contents[i] = models[i].ToDomain()
+	}
+
+	return contents, nil
+}
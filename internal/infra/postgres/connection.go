@@ -2,6 +2,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -22,6 +23,9 @@ type Config struct {
 	MaxOpenConns int
 	MaxIdleConns int
 	MaxLifetime  time.Duration
+
+	SlowQueryThreshold time.Duration // Queries slower than this are logged at WARN (0 disables)
+	LogQueries         bool          // Log every query at DEBUG
 }
 
 // DSN returns the PostgreSQL connection string.
@@ -35,13 +39,17 @@ func (c *Config) DSN() string {
 // NewConnection creates a new GORM database connection.
 func NewConnection(cfg Config, logger *zap.Logger) (*gorm.DB, error) {
 	// Configure GORM logger
-	gormLogLevel := gormlogger.Silent
+	gormLog := newGormLogger(zap.NewNop(), gormlogger.Silent, 0)
 	if logger != nil {
-		gormLogLevel = gormlogger.Info
+		level := gormlogger.Warn
+		if cfg.LogQueries {
+			level = gormlogger.Info
+		}
+		gormLog = newGormLogger(logger, level, cfg.SlowQueryThreshold)
 	}
 
 	gormConfig := &gorm.Config{
-		Logger: gormlogger.Default.LogMode(gormLogLevel),
+		Logger: gormLog,
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -92,11 +100,11 @@ func Close(db *gorm.DB) error {
 }
 
 // HealthCheck verifies the database connection is alive.
-func HealthCheck(db *gorm.DB) error {
+func HealthCheck(ctx context.Context, db *gorm.DB) error {
 	sqlDB, err := db.DB()
 	if err != nil {
 		return err
 	}
 
-	return sqlDB.Ping()
+	return sqlDB.PingContext(ctx)
 }
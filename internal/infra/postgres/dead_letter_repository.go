@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// DeadLetterModel is the GORM model for the dead_letters table.
+type DeadLetterModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Provider   string    `gorm:"type:varchar(100);not null"`
+	ExternalID string    `gorm:"type:varchar(255);not null;default:''"`
+	Payload    string    `gorm:"type:text;not null"`
+	Errors     string    `gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time `gorm:"not null;index:idx_dead_letters_created_at"`
+}
+
+// TableName returns the table name for DeadLetterModel.
+func (DeadLetterModel) TableName() string {
+	return "dead_letters"
+}
+
+// DeadLetterRepository implements domain.DeadLetterRepository using PostgreSQL.
+type DeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewDeadLetterRepository creates a new PostgreSQL dead letter repository.
+func NewDeadLetterRepository(db *gorm.DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+// CreateBatch stores letters in one statement and sets their IDs.
+func (r *DeadLetterRepository) CreateBatch(ctx context.Context, letters []*domain.DeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+
+	models := make([]DeadLetterModel, len(letters))
+	for i, l := range letters {
+		errs := l.Errors
+		if errs == nil {
+			errs = domain.ValidationErrors{}
+		}
+		encoded, err := json.Marshal(errs)
+		if err != nil {
+			return fmt.Errorf("encoding dead letter errors: %w", err)
+		}
+		models[i] = DeadLetterModel{
+			Provider:   l.Provider,
+			ExternalID: l.ExternalID,
+			Payload:    l.Payload,
+			Errors:     string(encoded),
+			CreatedAt:  l.CreatedAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("creating dead letters: %w", wrapTimeout(err))
+	}
+
+	for i, l := range letters {
+		l.ID = models[i].ID
+	}
+
+	return nil
+}
+
+// DeleteBefore removes the letters created before before.
+func (r *DeadLetterRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&DeadLetterModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("deleting dead letters: %w", wrapTimeout(result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteByExternalID removes the letters of the item externalID of provider.
+func (r *DeadLetterRepository) DeleteByExternalID(ctx context.Context, provider, externalID string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("provider = ? AND external_id = ?", provider, externalID).
+		Delete(&DeadLetterModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("deleting dead letters: %w", wrapTimeout(result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
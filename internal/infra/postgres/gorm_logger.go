@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"search-engine-service/internal/logger"
+)
+
+// gormLogger writes GORM's logs through zap, tagged with the request_id and trace_id
+// carried by the query's context. Queries are logged at DEBUG; failed queries and
+// queries slower than slowThreshold at WARN (callers return errors, so they're
+// reported again where they're handled). Logged SQL keeps its $n placeholders
+// instead of the bound values, so search terms and IDs stay out of the logs.
+type gormLogger struct {
+	logger        *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration // 0 disables slow query logging
+}
+
+// newGormLogger creates a GORM logger writing to logger at the given GORM level.
+// Warn logs failed and slow queries only; Info adds every query at DEBUG.
+func newGormLogger(logger *zap.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) gormlogger.Interface {
+	return &gormLogger{logger: logger, level: level, slowThreshold: slowThreshold}
+}
+
+// LogMode returns a copy of the logger with the given level.
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+
+	return &clone
+}
+
+// Info logs GORM informational messages.
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logger.FromContext(ctx, l.logger).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn logs GORM warnings.
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logger.FromContext(ctx, l.logger).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error logs GORM errors.
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logger.FromContext(ctx, l.logger).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+// ParamsFilter drops the bound values from logged SQL (implements gorm.ParamsFilter).
+func (l *gormLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// Trace logs a finished query. Not-found lookups aren't failures.
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.level >= gormlogger.Warn && l.slowThreshold > 0 && elapsed >= l.slowThreshold
+	if !failed && !slow && l.level < gormlogger.Info {
+		return
+	}
+
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+	}
+
+	log := logger.FromContext(ctx, l.logger)
+	switch {
+	case failed:
+		log.Warn("sql query failed", append(fields, zap.Error(err))...)
+	case slow:
+		log.Warn("slow sql query", append(fields, zap.Duration("threshold", l.slowThreshold))...)
+	default:
+		log.Debug("sql query", fields...)
+	}
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestGormLogger_LogsSlowQueriesAtWarn(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newGormLogger(zap.New(core), gormlogger.Warn, 100*time.Millisecond)
+	query := func() (string, int64) { return "SELECT * FROM contents WHERE id = $1", 1 }
+
+	l.Trace(context.Background(), time.Now().Add(-10*time.Millisecond), query, nil)
+	l.Trace(context.Background(), time.Now().Add(-time.Second), query, nil)
+
+	require.Equal(t, 1, logs.Len(), "fast queries aren't logged at Warn")
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	assert.Equal(t, "slow sql query", entry.Message)
+	assert.Equal(t, "SELECT * FROM contents WHERE id = $1", entry.ContextMap()["sql"])
+	assert.Equal(t, int64(1), entry.ContextMap()["rows"])
+}
+
+func TestGormLogger_InfoLogsEveryQuery(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newGormLogger(zap.New(core), gormlogger.Info, 0)
+
+	l.Trace(context.Background(), time.Now().Add(-time.Second), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zapcore.DebugLevel, logs.All()[0].Level, "a zero threshold disables slow query logging")
+}
+
+func TestGormLogger_ParamsFilterDropsValues(t *testing.T) {
+	l := &gormLogger{}
+
+	sql, vars := l.ParamsFilter(context.Background(), "SELECT * FROM contents WHERE title = $1", "secret")
+
+	assert.Equal(t, "SELECT * FROM contents WHERE title = $1", sql)
+	assert.Empty(t, vars)
+}
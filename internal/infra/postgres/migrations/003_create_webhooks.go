@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createWebhooksTable creates the webhooks table for outbound event subscriptions.
+func createWebhooksTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "003_create_webhooks",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS webhooks (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					url VARCHAR(2048) NOT NULL,
+					secret VARCHAR(256) NOT NULL,
+					event_types TEXT[] NOT NULL,
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS webhooks;").Error
+		},
+	}
+}
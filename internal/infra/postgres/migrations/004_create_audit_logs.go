@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createAuditLogsTable creates the audit_logs table recording admin API calls.
+func createAuditLogsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "004_create_audit_logs",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS audit_logs (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					actor VARCHAR(255) NOT NULL,
+					method VARCHAR(10) NOT NULL,
+					path VARCHAR(2048) NOT NULL,
+					route VARCHAR(255) NOT NULL,
+					params JSONB NOT NULL DEFAULT '{}',
+					status INTEGER NOT NULL,
+					duration_ms BIGINT NOT NULL,
+					ip VARCHAR(64) NOT NULL,
+					request_id VARCHAR(64) NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_created_at ON audit_logs (actor, created_at DESC);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS audit_logs;").Error
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createAnalyticsEventsTable creates the analytics_events table recording search
+// result impressions and clicks.
+func createAnalyticsEventsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "005_create_analytics_events",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS analytics_events (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					type VARCHAR(20) NOT NULL,
+					query VARCHAR(200) NOT NULL DEFAULT '',
+					content_id UUID NOT NULL,
+					position INTEGER NOT NULL,
+					session_id VARCHAR(64) NOT NULL DEFAULT '',
+					request_id VARCHAR(64) NOT NULL DEFAULT '',
+					occurred_at TIMESTAMP NOT NULL,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+
+				-- No foreign key: events outlive deleted content and must not slow down ingestion
+				CREATE INDEX IF NOT EXISTS idx_analytics_events_content_type ON analytics_events (content_id, type, occurred_at);
+				CREATE INDEX IF NOT EXISTS idx_analytics_events_occurred_at ON analytics_events (occurred_at);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS analytics_events;").Error
+		},
+	}
+}
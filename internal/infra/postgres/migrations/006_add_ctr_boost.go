@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addCTRBoost adds the ctr_boost column holding the part of score earned by
+// search click-through rate. Syncs recompute score from provider data and add
+// the stored boost back, so the boost survives until the CTR job replaces it.
+func addCTRBoost() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "006_add_ctr_boost",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				ALTER TABLE contents
+				ADD COLUMN IF NOT EXISTS ctr_boost DECIMAL(10,2) NOT NULL DEFAULT 0
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("UPDATE contents SET score = score - ctr_boost WHERE ctr_boost <> 0").Error; err != nil {
+					return err
+				}
+
+				return tx.Exec("ALTER TABLE contents DROP COLUMN IF EXISTS ctr_boost").Error
+			})
+		},
+	}
+}
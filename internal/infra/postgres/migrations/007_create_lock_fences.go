@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createLockFencesTable creates the lock_fences table holding the highest fencing
+// token that has written under each distributed lock.
+func createLockFencesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "007_create_lock_fences",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS lock_fences (
+					lock_key VARCHAR(255) PRIMARY KEY,
+					token BIGINT NOT NULL,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS lock_fences;").Error
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createLockFencingTokensSequence creates the sequence the PostgreSQL lock backend
+// draws fencing tokens from.
+func createLockFencingTokensSequence() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "008_create_lock_fencing_tokens",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE SEQUENCE IF NOT EXISTS lock_fencing_tokens").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP SEQUENCE IF EXISTS lock_fencing_tokens").Error
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createLockHoldersTable creates the lock_holders table in which the PostgreSQL lock
+// backend records who holds each advisory lock. A row only counts while the session
+// with its pid still holds the lock (see pg_locks).
+func createLockHoldersTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "009_create_lock_holders",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS lock_holders (
+					lock_key VARCHAR(255) PRIMARY KEY,
+					holder VARCHAR(255) NOT NULL,
+					token BIGINT NOT NULL,
+					pid INTEGER NOT NULL,
+					acquired_at TIMESTAMPTZ NOT NULL,
+					expires_at TIMESTAMPTZ NOT NULL
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS lock_holders;").Error
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addListens adds the listens column holding the listen count of podcasts.
+func addListens() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "010_add_listens",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE contents ADD COLUMN IF NOT EXISTS listens INTEGER DEFAULT 0").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE contents DROP COLUMN IF EXISTS listens").Error
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addSourceMetadata adds the columns linking a content back to its source: the
+// item's URL at the provider, a thumbnail and the author.
+func addSourceMetadata() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "011_add_source_metadata",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				ALTER TABLE contents
+				ADD COLUMN IF NOT EXISTS url VARCHAR(2048),
+				ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(2048),
+				ADD COLUMN IF NOT EXISTS author VARCHAR(200)
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				ALTER TABLE contents
+				DROP COLUMN IF EXISTS url,
+				DROP COLUMN IF EXISTS thumbnail_url,
+				DROP COLUMN IF EXISTS author
+			`).Error
+		},
+	}
+}
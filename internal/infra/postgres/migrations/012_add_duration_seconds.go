@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addDurationSeconds adds the duration_seconds column holding the parsed length of
+// videos and podcasts. Existing rows get it on their next sync; until then responses
+// fall back to the raw duration.
+func addDurationSeconds() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "012_add_duration_seconds",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE contents ADD COLUMN IF NOT EXISTS duration_seconds INTEGER DEFAULT 0").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE contents DROP COLUMN IF EXISTS duration_seconds").Error
+		},
+	}
+}
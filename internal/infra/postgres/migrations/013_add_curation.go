@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addCuration adds the editorial flags admins set on contents: pinned contents
+// are listed first, blocked ones are hidden from searches and boost scales the
+// ranking. Syncs never write them.
+func addCuration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "013_add_curation",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				ALTER TABLE contents
+				ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT false,
+				ADD COLUMN IF NOT EXISTS blocked BOOLEAN NOT NULL DEFAULT false,
+				ADD COLUMN IF NOT EXISTS boost DECIMAL(5,2) NOT NULL DEFAULT 1 CHECK (boost > 0)
+			`).Error; err != nil {
+				return err
+			}
+
+			// Few contents are curated; the partial index keeps listing them cheap
+			return tx.Exec(`
+				CREATE INDEX IF NOT EXISTS idx_contents_curated
+				ON contents (id) WHERE pinned OR blocked OR boost <> 1
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_contents_curated").Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(`
+				ALTER TABLE contents
+				DROP COLUMN IF EXISTS pinned,
+				DROP COLUMN IF EXISTS blocked,
+				DROP COLUMN IF EXISTS boost
+			`).Error
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createCollectionsTables creates the collections table and collection_items,
+// which holds the ordered contents of each collection. Deleting a collection or
+// a content removes its items.
+func createCollectionsTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "014_create_collections",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS collections (
+						id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+						title VARCHAR(500) NOT NULL,
+						description TEXT NOT NULL DEFAULT '',
+						created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+						updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS collection_items (
+						collection_id UUID NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+						content_id UUID NOT NULL REFERENCES contents (id) ON DELETE CASCADE,
+						position INTEGER NOT NULL,
+						PRIMARY KEY (collection_id, content_id)
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				// Content deletes look up the items to remove by content
+				return tx.Exec(`
+					CREATE INDEX IF NOT EXISTS idx_collection_items_content_id ON collection_items (content_id);
+				`).Error
+			})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP TABLE IF EXISTS collection_items;").Error; err != nil {
+				return err
+			}
+
+			return tx.Exec("DROP TABLE IF EXISTS collections;").Error
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addTagsIndex indexes tags for the tag filter of searches (tags @> ARRAY[tag]).
+func addTagsIndex() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "015_add_tags_index",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_contents_tags ON contents USING GIN (tags)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS idx_contents_tags").Error
+		},
+	}
+}
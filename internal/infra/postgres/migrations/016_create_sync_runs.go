@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createSyncRunsTable creates the sync_runs table, the history of provider syncs
+// charted on the dashboard.
+func createSyncRunsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "016_create_sync_runs",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS sync_runs (
+						id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+						provider VARCHAR(100) NOT NULL,
+						count INTEGER NOT NULL DEFAULT 0,
+						duration_ms BIGINT NOT NULL DEFAULT 0,
+						error TEXT NOT NULL DEFAULT '',
+						finished_at TIMESTAMP NOT NULL
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				// Charts and pruning select runs by finish time
+				return tx.Exec(`
+					CREATE INDEX IF NOT EXISTS idx_sync_runs_finished_at ON sync_runs (finished_at);
+				`).Error
+			})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS sync_runs;").Error
+		},
+	}
+}
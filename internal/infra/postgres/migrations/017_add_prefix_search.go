@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addPrefixSearch adds prefix_vector, the tsvector matched by searches in prefix mode
+// (search-as-you-type).
+//
+// search_vector holds English stems, which a partly typed word doesn't match:
+// "programming" is stored as "program", so "programm:*" finds nothing. prefix_vector
+// holds the words as written, lowercased ('simple' configuration), so "programm:*"
+// matches "programming". Title and tags are weighted as in search_vector, and the GIN
+// index serves prefix matches too.
+func addPrefixSearch() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "017_add_prefix_search",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				ALTER TABLE contents
+				ADD COLUMN IF NOT EXISTS prefix_vector tsvector
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				CREATE INDEX IF NOT EXISTS idx_contents_prefix_vector
+				ON contents USING GIN (prefix_vector)
+			`).Error; err != nil {
+				return err
+			}
+
+			// A trigger rather than a generated column, like search_vector:
+			// array_to_string isn't immutable
+			if err := tx.Exec(`
+				CREATE OR REPLACE FUNCTION contents_prefix_vector_update()
+				RETURNS trigger AS $$
+				BEGIN
+					NEW.prefix_vector :=
+						setweight(to_tsvector('simple', coalesce(NEW.title, '')), 'A') ||
+						setweight(to_tsvector('simple', coalesce(array_to_string(NEW.tags, ' '), '')), 'B');
+					RETURN NEW;
+				END
+				$$ LANGUAGE plpgsql
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TRIGGER IF EXISTS trg_contents_prefix_vector ON contents`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				CREATE TRIGGER trg_contents_prefix_vector
+				BEFORE INSERT OR UPDATE OF title, tags
+				ON contents
+				FOR EACH ROW
+				EXECUTE FUNCTION contents_prefix_vector_update()
+			`).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(`
+				UPDATE contents SET prefix_vector =
+					setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+					setweight(to_tsvector('simple', coalesce(array_to_string(tags, ' '), '')), 'B')
+				WHERE prefix_vector IS NULL
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			_ = tx.Exec(`DROP TRIGGER IF EXISTS trg_contents_prefix_vector ON contents`).Error
+			_ = tx.Exec(`DROP FUNCTION IF EXISTS contents_prefix_vector_update()`).Error
+			_ = tx.Exec(`DROP INDEX IF EXISTS idx_contents_prefix_vector`).Error
+			_ = tx.Exec(`ALTER TABLE contents DROP COLUMN IF EXISTS prefix_vector`).Error
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createSearchQueriesTable creates the search_queries table, the executed searches
+// behind the top and zero-result query reports.
+func createSearchQueriesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "018_create_search_queries",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS search_queries (
+						id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+						query VARCHAR(200) NOT NULL,
+						total BIGINT NOT NULL DEFAULT 0,
+						latency_us BIGINT NOT NULL DEFAULT 0,
+						occurred_at TIMESTAMP NOT NULL
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				// Reports and pruning select searches by occurrence time
+				return tx.Exec(`
+					CREATE INDEX IF NOT EXISTS idx_search_queries_occurred_at ON search_queries (occurred_at);
+				`).Error
+			})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS search_queries;").Error
+		},
+	}
+}
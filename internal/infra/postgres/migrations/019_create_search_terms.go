@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createSearchTermsTable creates the search_terms table, the term dictionary behind
+// autocomplete suggestions and "did you mean" corrections. The dictionary job
+// replaces its rows on each build.
+func createSearchTermsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "019_create_search_terms",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS search_terms (
+					term VARCHAR(100) PRIMARY KEY,
+					frequency BIGINT NOT NULL DEFAULT 0,
+					updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS search_terms;").Error
+		},
+	}
+}
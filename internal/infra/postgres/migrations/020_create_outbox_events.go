@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createOutboxEventsTable creates the outbox_events table, holding the domain events
+// written in the transaction of the content changes they describe until the outbox
+// relay publishes them.
+func createOutboxEventsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "020_create_outbox_events",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS outbox_events (
+					id BIGSERIAL PRIMARY KEY,
+					name VARCHAR(50) NOT NULL,
+					payload JSONB NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT NOW()
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS outbox_events;").Error
+		},
+	}
+}
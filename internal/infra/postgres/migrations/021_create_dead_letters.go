@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createDeadLettersTable creates the dead_letters table, holding the provider items
+// rejected by schema validation with the fields that broke it.
+func createDeadLettersTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "021_create_dead_letters",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS dead_letters (
+						id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+						provider VARCHAR(100) NOT NULL,
+						external_id VARCHAR(255) NOT NULL DEFAULT '',
+						payload TEXT NOT NULL,
+						errors JSONB NOT NULL,
+						created_at TIMESTAMP NOT NULL
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				// Pruning selects letters by creation time
+				return tx.Exec(`
+					CREATE INDEX IF NOT EXISTS idx_dead_letters_created_at ON dead_letters (created_at);
+				`).Error
+			})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS dead_letters;").Error
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addContentHash adds the content_hash column, the hash of the provider data last
+// stored, so upserts skip the contents a sync returns unchanged. Existing rows get
+// it on their next sync, which writes them all once.
+//
+// Unchanged contents keep their updated_at, so the time of each provider's last sync
+// moves to the provider_syncs table, filled from the contents for a start.
+func addContentHash() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "022_add_content_hash",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("ALTER TABLE contents ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64)").Error; err != nil {
+					return err
+				}
+				if err := tx.Exec(`
+					CREATE TABLE IF NOT EXISTS provider_syncs (
+						provider_id VARCHAR(50) PRIMARY KEY,
+						synced_at TIMESTAMP NOT NULL
+					);
+				`).Error; err != nil {
+					return err
+				}
+
+				return tx.Exec(`
+					INSERT INTO provider_syncs (provider_id, synced_at)
+					SELECT provider_id, MAX(updated_at) FROM contents GROUP BY provider_id
+					ON CONFLICT (provider_id) DO NOTHING;
+				`).Error
+			})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("DROP TABLE IF EXISTS provider_syncs").Error; err != nil {
+					return err
+				}
+
+				return tx.Exec("ALTER TABLE contents DROP COLUMN IF EXISTS content_hash").Error
+			})
+		},
+	}
+}
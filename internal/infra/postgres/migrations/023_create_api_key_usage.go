@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createAPIKeyUsageTable creates the api_key_usage table, counting the requests of
+// each API key per month for quotas when Redis isn't available.
+func createAPIKeyUsageTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "023_create_api_key_usage",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				CREATE TABLE IF NOT EXISTS api_key_usage (
+					name VARCHAR(100) NOT NULL,
+					month CHAR(7) NOT NULL,
+					requests BIGINT NOT NULL DEFAULT 0,
+					updated_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (name, month)
+				);
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE IF EXISTS api_key_usage;").Error
+		},
+	}
+}
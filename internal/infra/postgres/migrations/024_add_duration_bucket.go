@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addDurationBucket adds the duration_bucket column classifying videos by length for
+// the duration filter and facet of searches, with the bounds of
+// domain.Content.DurationBucket. It's generated from duration_seconds, so every write
+// classifies the row and existing rows are classified right away; other types and
+// videos of unknown duration get NULL. Each bucket holds a large share of the videos,
+// so it isn't indexed.
+func addDurationBucket() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "024_add_duration_bucket",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(`
+				ALTER TABLE contents
+				ADD COLUMN IF NOT EXISTS duration_bucket varchar(10)
+					GENERATED ALWAYS AS (
+						CASE
+							WHEN type <> 'video' OR duration_seconds IS NULL OR duration_seconds <= 0 THEN NULL
+							WHEN duration_seconds < 240 THEN 'short'
+							WHEN duration_seconds <= 1200 THEN 'medium'
+							ELSE 'long'
+						END
+					) STORED
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE contents DROP COLUMN IF EXISTS duration_bucket").Error
+		},
+	}
+}
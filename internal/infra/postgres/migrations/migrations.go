@@ -2,6 +2,9 @@
 package migrations
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
 )
@@ -11,6 +14,28 @@ func Migrations() []*gormigrate.Migration {
 	return []*gormigrate.Migration{
 		createContentsTable(),
 		addFTSSupport(),
+		createWebhooksTable(),
+		createAuditLogsTable(),
+		createAnalyticsEventsTable(),
+		addCTRBoost(),
+		createLockFencesTable(),
+		createLockFencingTokensSequence(),
+		createLockHoldersTable(),
+		addListens(),
+		addSourceMetadata(),
+		addDurationSeconds(),
+		addCuration(),
+		createCollectionsTables(),
+		addTagsIndex(),
+		createSyncRunsTable(),
+		addPrefixSearch(),
+		createSearchQueriesTable(),
+		createSearchTermsTable(),
+		createOutboxEventsTable(),
+		createDeadLettersTable(),
+		addContentHash(),
+		createAPIKeyUsageTable(),
+		addDurationBucket(),
 	}
 }
 
@@ -27,3 +52,29 @@ func Rollback(db *gorm.DB) error {
 
 	return m.RollbackLast()
 }
+
+// MigrationStatus tells whether a migration has been applied.
+type MigrationStatus struct {
+	ID      string
+	Applied bool
+}
+
+// Status returns every migration in order and whether it has been applied.
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	opts := gormigrate.DefaultOptions
+
+	var applied []string
+	if db.Migrator().HasTable(opts.TableName) {
+		if err := db.Table(opts.TableName).Pluck(opts.IDColumnName, &applied).Error; err != nil {
+			return nil, fmt.Errorf("reading applied migrations: %w", err)
+		}
+	}
+
+	all := Migrations()
+	statuses := make([]MigrationStatus, len(all))
+	for i, m := range all {
+		statuses[i] = MigrationStatus{ID: m.ID, Applied: slices.Contains(applied, m.ID)}
+	}
+
+	return statuses, nil
+}
@@ -17,26 +17,51 @@ type ContentModel struct {
 	Type       string         `gorm:"type:varchar(20);not null;index"`
 	Tags       pq.StringArray `gorm:"type:text[]"`
 
+	// Source metadata
+	URL          string `gorm:"column:url;type:varchar(2048)"`
+	ThumbnailURL string `gorm:"column:thumbnail_url;type:varchar(2048)"`
+	Author       string `gorm:"type:varchar(200)"`
+
 	// Metrics
-	Views       int    `gorm:"default:0"`
-	Likes       int    `gorm:"default:0"`
-	Duration    string `gorm:"type:varchar(20)"`
-	ReadingTime int    `gorm:"default:0"`
-	Reactions   int    `gorm:"default:0"`
-	Comments    int    `gorm:"default:0"`
-
-	// Score
+	Views           int    `gorm:"default:0"`
+	Likes           int    `gorm:"default:0"`
+	Duration        string `gorm:"type:varchar(20)"`
+	DurationSeconds int    `gorm:"default:0"`
+	Listens         int    `gorm:"default:0"`
+	ReadingTime     int    `gorm:"default:0"`
+	Reactions       int    `gorm:"default:0"`
+	Comments        int    `gorm:"default:0"`
+
+	// Score includes CTRBoost
 	Score float64 `gorm:"type:decimal(10,2);default:0;index"`
 
+	// CTRBoost is the part of Score earned by search click-through rate.
+	// Read-only here: only Repository.ReplaceCTRBoosts changes it.
+	CTRBoost float64 `gorm:"column:ctr_boost;type:decimal(10,2);not null;default:0;<-:false"`
+
+	// Editorial curation. Read-only here: only Repository.UpdateCuration changes it,
+	// so syncs keep what admins set.
+	Pinned  bool    `gorm:"not null;default:false;<-:false"`
+	Blocked bool    `gorm:"not null;default:false;<-:false"`
+	Boost   float64 `gorm:"type:decimal(5,2);not null;default:1;<-:false"`
+
 	// LogScoreCached is a stored computed column: LOG(score + 10)
 	// Used for efficient relevance ranking in full-text search.
 	// The "-" tag excludes this from INSERT/UPDATE - PostgreSQL computes it automatically.
 	LogScoreCached float64 `gorm:"type:float8;generated;stored;-"`
 
+	// DurationBucket is a stored computed column classifying videos by duration
+	// (see domain.Content.DurationBucket), NULL for other types. Excluded like
+	// LogScoreCached; searches filter and facet on it.
+	DurationBucket string `gorm:"type:varchar(10);generated;stored;-"`
+
 	// Timestamps
 	PublishedAt time.Time `gorm:"not null;index"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+
+	// ContentHash is the domain.Content SourceHash of the provider data last stored
+	ContentHash string `gorm:"type:varchar(64)"`
 }
 
 // TableName returns the table name for ContentModel.
@@ -44,47 +69,75 @@ func (ContentModel) TableName() string {
 	return "contents"
 }
 
+// ProviderSyncModel is the GORM model for the provider_syncs table, holding when
+// each provider's contents were last upserted, since unchanged rows keep their
+// updated_at.
+type ProviderSyncModel struct {
+	ProviderID string    `gorm:"type:varchar(50);primaryKey"`
+	SyncedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for ProviderSyncModel.
+func (ProviderSyncModel) TableName() string {
+	return "provider_syncs"
+}
+
 // ToDomain converts ContentModel to domain.Content.
 func (m *ContentModel) ToDomain() *domain.Content {
 	return &domain.Content{
-		ID:          m.ID,
-		ProviderID:  m.ProviderID,
-		ExternalID:  m.ExternalID,
-		Title:       m.Title,
-		Type:        domain.ContentType(m.Type),
-		Tags:        m.Tags,
-		Views:       m.Views,
-		Likes:       m.Likes,
-		Duration:    m.Duration,
-		ReadingTime: m.ReadingTime,
-		Reactions:   m.Reactions,
-		Comments:    m.Comments,
-		Score:       m.Score,
-		PublishedAt: m.PublishedAt,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
+		ID:              m.ID,
+		ProviderID:      m.ProviderID,
+		ExternalID:      m.ExternalID,
+		Title:           m.Title,
+		Type:            domain.ContentType(m.Type),
+		Tags:            m.Tags,
+		URL:             m.URL,
+		ThumbnailURL:    m.ThumbnailURL,
+		Author:          m.Author,
+		Views:           m.Views,
+		Likes:           m.Likes,
+		Duration:        m.Duration,
+		DurationSeconds: m.DurationSeconds,
+		Listens:         m.Listens,
+		ReadingTime:     m.ReadingTime,
+		Reactions:       m.Reactions,
+		Comments:        m.Comments,
+		Score:           m.Score,
+		Pinned:          m.Pinned,
+		Blocked:         m.Blocked,
+		Boost:           m.Boost,
+		PublishedAt:     m.PublishedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+		Hash:            m.ContentHash,
 	}
 }
 
-// FromDomain creates a ContentModel from domain.Content.
+// FromDomain creates a ContentModel from domain.Content, hashing its provider data.
 func FromDomain(c *domain.Content) *ContentModel {
 	return &ContentModel{
-		ID:          c.ID,
-		ProviderID:  c.ProviderID,
-		ExternalID:  c.ExternalID,
-		Title:       c.Title,
-		Type:        string(c.Type),
-		Tags:        c.Tags,
-		Views:       c.Views,
-		Likes:       c.Likes,
-		Duration:    c.Duration,
-		ReadingTime: c.ReadingTime,
-		Reactions:   c.Reactions,
-		Comments:    c.Comments,
-		Score:       c.Score,
-		PublishedAt: c.PublishedAt,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
+		ID:              c.ID,
+		ProviderID:      c.ProviderID,
+		ExternalID:      c.ExternalID,
+		Title:           c.Title,
+		Type:            string(c.Type),
+		Tags:            c.Tags,
+		URL:             c.URL,
+		ThumbnailURL:    c.ThumbnailURL,
+		Author:          c.Author,
+		Views:           c.Views,
+		Likes:           c.Likes,
+		Duration:        c.Duration,
+		DurationSeconds: c.DurationSeconds,
+		Listens:         c.Listens,
+		ReadingTime:     c.ReadingTime,
+		Reactions:       c.Reactions,
+		Comments:        c.Comments,
+		Score:           c.Score,
+		PublishedAt:     c.PublishedAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+		ContentHash:     c.SourceHash(),
 	}
 }
 
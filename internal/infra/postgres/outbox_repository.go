@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// OutboxEventModel is the GORM model for the outbox_events table.
+type OutboxEventModel struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"type:varchar(50);not null"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for OutboxEventModel.
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+// OutboxRepository implements domain.OutboxRepository using PostgreSQL. The events
+// are written by Repository, once created with WithOutbox.
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository.
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Relay removes up to limit stored events, oldest first, and passes them to fn in
+// one transaction. Events locked by another instance's relay are skipped.
+func (r *OutboxRepository) Relay(ctx context.Context, limit int, fn func(events []*domain.OutboxEvent) error) (int, error) {
+	var relayed int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var models []OutboxEventModel
+		err := tx.Raw(`
+			DELETE FROM outbox_events
+			WHERE id IN (
+				SELECT id FROM outbox_events ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, name, payload, created_at
+		`, limit).Scan(&models).Error
+		if err != nil {
+			return fmt.Errorf("claiming outbox events: %w", wrapTimeout(err))
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		// RETURNING doesn't keep the subquery's order
+		events := make([]*domain.OutboxEvent, len(models))
+		for i := range models {
+			events[i] = models[i].toDomain()
+		}
+		slices.SortFunc(events, func(a, b *domain.OutboxEvent) int { return cmp.Compare(a.ID, b.ID) })
+
+		if err := fn(events); err != nil {
+			return err
+		}
+		relayed = len(events)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return relayed, nil
+}
+
+// toDomain converts the model to a domain.OutboxEvent.
+func (m *OutboxEventModel) toDomain() *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		ID:        m.ID,
+		Name:      m.Name,
+		Payload:   []byte(m.Payload),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// writeOutbox stores events in the outbox within tx.
+func writeOutbox(tx *gorm.DB, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	models := make([]OutboxEventModel, len(events))
+	for i, e := range events {
+		stored, err := domain.NewOutboxEvent(e)
+		if err != nil {
+			return err
+		}
+		models[i] = OutboxEventModel{Name: stored.Name, Payload: string(stored.Payload), CreatedAt: now}
+	}
+
+	if err := tx.Create(&models).Error; err != nil {
+		return fmt.Errorf("writing outbox events: %w", wrapTimeout(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyUsageModel is the GORM model for the api_key_usage table.
+type APIKeyUsageModel struct {
+	Name      string    `gorm:"type:varchar(100);primaryKey"`
+	Month     string    `gorm:"type:char(7);primaryKey"`
+	Requests  int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for APIKeyUsageModel.
+func (APIKeyUsageModel) TableName() string {
+	return "api_key_usage"
+}
+
+// QuotaRepository implements domain.QuotaStore using PostgreSQL, a row per API key
+// and month. Every counted request is a write, so Redis is preferred when available.
+type QuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotaRepository creates a new PostgreSQL quota repository.
+func NewQuotaRepository(db *gorm.DB) *QuotaRepository {
+	return &QuotaRepository{db: db}
+}
+
+// Increment counts a request with an upsert, returning the updated count.
+func (r *QuotaRepository) Increment(ctx context.Context, name, month string) (int64, error) {
+	var requests int64
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO api_key_usage (name, month, requests, updated_at)
+		VALUES (?, ?, 1, NOW())
+		ON CONFLICT (name, month) DO UPDATE
+		SET requests = api_key_usage.requests + 1, updated_at = NOW()
+		RETURNING requests
+	`, name, month).Scan(&requests).Error
+	if err != nil {
+		return 0, fmt.Errorf("counting quota request: %w", wrapTimeout(err))
+	}
+
+	return requests, nil
+}
+
+// Counts reads the counts of names in month.
+func (r *QuotaRepository) Counts(ctx context.Context, month string, names []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(names))
+	if len(names) == 0 {
+		return counts, nil
+	}
+
+	var models []APIKeyUsageModel
+	err := r.db.WithContext(ctx).
+		Where("month = ? AND name IN ?", month, names).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("reading quota counts: %w", wrapTimeout(err))
+	}
+
+	for _, m := range models {
+		counts[m.Name] = m.Requests
+	}
+
+	return counts, nil
+}
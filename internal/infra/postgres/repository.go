@@ -4,8 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -14,7 +20,10 @@ import (
 
 // Repository implements domain.ContentRepository using PostgreSQL.
 type Repository struct {
-	db *gorm.DB
+	db            *gorm.DB
+	outbox        bool // Write the events of content changes to the outbox (see WithOutbox)
+	copyThreshold int  // BulkUpsert batches of at least this many rows use COPY (see WithCopyThreshold)
+	upsertWorkers int  // Transactions BulkUpsert runs in parallel (see WithUpsertWorkers)
 }
 
 // NewRepository creates a new PostgreSQL repository.
@@ -22,6 +31,37 @@ func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// WithOutbox returns a copy of the repository that stores a ContentUpserted,
+// ContentDeleted or ContentCurated event in the outbox, in the transaction of each
+// upsert, deletion and curation change, for the outbox relay to publish.
+func (r *Repository) WithOutbox() *Repository {
+	clone := *r
+	clone.outbox = true
+
+	return &clone
+}
+
+// WithCopyThreshold returns a copy of the repository whose BulkUpsert switches to
+// BulkUpsertFast for batches of at least rows contents. Zero or less keeps
+// BulkUpsert on batched inserts.
+func (r *Repository) WithCopyThreshold(rows int) *Repository {
+	clone := *r
+	clone.copyThreshold = rows
+
+	return &clone
+}
+
+// WithUpsertWorkers returns a copy of the repository whose BulkUpsert splits contents
+// into batches of upsertBatchSize upserted by up to workers goroutines, each batch in
+// its own transaction on its own connection. One or less keeps BulkUpsert in a single
+// transaction.
+func (r *Repository) WithUpsertWorkers(workers int) *Repository {
+	clone := *r
+	clone.upsertWorkers = workers
+
+	return &clone
+}
+
 // Search finds contents matching the given search parameters.
 func (r *Repository) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
 	params.Validate()
@@ -31,21 +71,19 @@ func (r *Repository) Search(ctx context.Context, params domain.SearchParams) (*d
 
 	// Get total count
 	var total int64
-	if err := query.WithContext(ctx).Model(&ContentModel{}).Count(&total).Error; err != nil {
-		return nil, fmt.Errorf("counting contents: %w", err)
+	if !params.SkipCount {
+		if err := query.WithContext(ctx).Model(&ContentModel{}).Count(&total).Error; err != nil {
+			return nil, fmt.Errorf("counting contents: %w", wrapTimeout(err))
+		}
 	}
 
-	// Build final query with pagination
-	finalQuery := query.WithContext(ctx).
-		Offset(params.Offset()).
-		Limit(params.Limit())
-
-	// Apply ordering (handles FTS relevance ranking safely)
-	finalQuery = r.applyOrdering(finalQuery, params)
-
-	// Execute query
-	if err := finalQuery.Find(&models).Error; err != nil {
-		return nil, fmt.Errorf("searching contents: %w", err)
+	// Execute query; uncounted searches read one more row to tell whether more follow
+	page := r.pageQuery(query.WithContext(ctx), params)
+	if params.SkipCount {
+		page = page.Limit(params.Limit() + 1)
+	}
+	if err := page.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("searching contents: %w", wrapTimeout(err))
 	}
 
 	// Convert to domain
@@ -53,20 +91,32 @@ func (r *Repository) Search(ctx context.Context, params domain.SearchParams) (*d
 	for i, m := range models {
 		contents[i] = m.ToDomain()
 	}
+	if params.SkipCount {
+		return domain.NewUncountedSearchResult(contents, params), nil
+	}
 
 	return domain.NewSearchResult(contents, total, params), nil
 }
 
+// pageQuery orders query (see applyOrdering) and restricts it to the page of params.
+func (r *Repository) pageQuery(query *gorm.DB, params domain.SearchParams) *gorm.DB {
+	query = query.
+		Offset(params.Offset()).
+		Limit(params.Limit())
+
+	return r.applyOrdering(query, params)
+}
+
 // GetByID retrieves a single content by its internal ID.
 func (r *Repository) GetByID(ctx context.Context, id string) (*domain.Content, error) {
 	var model ContentModel
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil // Not found
+			return nil, fmt.Errorf("content %s: %w", id, domain.ErrNotFound)
 		}
 
-		return nil, fmt.Errorf("getting content by id: %w", err)
+		return nil, fmt.Errorf("getting content by id: %w", wrapTimeout(err))
 	}
 
 	return model.ToDomain(), nil
@@ -80,84 +130,445 @@ func (r *Repository) GetByProviderAndExternalID(ctx context.Context, providerID,
 		First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil // Not found
+			return nil, fmt.Errorf("content %s/%s: %w", providerID, externalID, domain.ErrNotFound)
 		}
 
-		return nil, fmt.Errorf("getting content by provider and external id: %w", err)
+		return nil, fmt.Errorf("getting content by provider and external id: %w", wrapTimeout(err))
 	}
 
 	return model.ToDomain(), nil
 }
 
-// Upsert creates or updates a single content.
+// wrapTimeout marks errors caused by an exceeded context deadline with
+// domain.ErrTimeout so callers can tell slow queries apart from failed ones.
+func wrapTimeout(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", domain.ErrTimeout, err)
+	}
+
+	return err
+}
+
+// upsertReturning reads back the stored created_at so updated rows keep their
+// original creation time; inserted rows return CreatedAt == UpdatedAt
+// (see domain.NewContentEvent).
+// The stored score is read back too, since it includes the content's CTR boost.
+var upsertReturning = clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "created_at"}, {Name: "score"}}}
+
+// upsertColumns are the columns an upsert updates from provider data, besides score.
+var upsertColumns = []string{
+	"title", "type", "tags", "url", "thumbnail_url", "author",
+	"views", "likes", "duration", "duration_seconds", "listens", "reading_time", "reactions", "comments",
+	"published_at", "updated_at", "content_hash",
+}
+
+// upsertOnConflict updates an existing content from provider data. The new score
+// keeps the content's CTR boost, which only ReplaceCTRBoosts changes.
+var upsertOnConflict = clause.OnConflict{
+	Columns: []clause.Column{{Name: "provider_id"}, {Name: "external_id"}},
+	DoUpdates: append(clause.AssignmentColumns(upsertColumns), clause.Assignment{
+		Column: clause.Column{Name: "score"},
+		Value:  gorm.Expr("excluded.score + contents.ctr_boost"),
+	}),
+}
+
+// upsertBatchSize is the number of rows inserted per statement by BulkUpsert, and per
+// transaction by its upsert workers.
+const upsertBatchSize = 100
+
+// upsertTimestamp returns the current time truncated to PostgreSQL's microsecond
+// precision, so values read back compare equal to the ones written.
+func upsertTimestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// Upsert creates or updates a single content, whether it changed or not.
 func (r *Repository) Upsert(ctx context.Context, content *domain.Content) error {
 	model := FromDomain(content)
-	model.UpdatedAt = time.Now().UTC()
+	model.UpdatedAt = upsertTimestamp()
+	model.CreatedAt = model.UpdatedAt
 
-	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "provider_id"}, {Name: "external_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"title", "type", "tags",
-			"views", "likes", "duration", "reading_time", "reactions", "comments",
-			"score", "published_at", "updated_at",
-		}),
-	}).Create(model).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := touchProviderSyncs(tx, []*domain.Content{content}, model.UpdatedAt); err != nil {
+			return err
+		}
+		if err := tx.Clauses(upsertReturning, upsertOnConflict).Create(model).Error; err != nil {
+			return fmt.Errorf("upserting content: %w", wrapTimeout(err))
+		}
 
+		return r.writeUpserted(tx, []*ContentModel{model})
+	})
 	if err != nil {
-		return fmt.Errorf("upserting content: %w", err)
+		return err
 	}
 
 	// Update the domain object with database-generated fields
 	content.ID = model.ID
+	content.Score = model.Score
 	content.CreatedAt = model.CreatedAt
 	content.UpdatedAt = model.UpdatedAt
+	content.Hash = model.ContentHash
 
 	return nil
 }
 
-// BulkUpsert creates or updates multiple contents in a batch.
+// BulkUpsert creates or updates multiple contents like UpsertChanged, ignoring which
+// ones changed.
 func (r *Repository) BulkUpsert(ctx context.Context, contents []*domain.Content) error {
+	_, err := r.UpsertChanged(ctx, contents)
+
+	return err
+}
+
+// UpsertChanged creates or updates multiple contents in a batch, through
+// BulkUpsertFast for batches reaching the copy threshold (see WithCopyThreshold), or
+// in parallel transactions when upsert workers are set (see WithUpsertWorkers).
+//
+// Stored contents whose hash (see domain.Content.SourceHash) and score are unchanged
+// aren't written, so they keep their updated_at and aren't announced in the outbox;
+// the created or changed contents are returned. Every content gets its
+// database-generated fields either way.
+//
+// Returns domain.ErrLockLost without writing if ctx carries a stale fencing token
+// (see locker.FenceFromContext).
+func (r *Repository) UpsertChanged(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
 	if len(contents) == 0 {
-		return nil
+		return nil, nil
+	}
+	if r.copyThreshold > 0 && len(contents) >= r.copyThreshold {
+		return r.bulkUpsertFast(ctx, contents)
+	}
+	if r.upsertWorkers > 1 && len(contents) > upsertBatchSize {
+		return r.bulkUpsertParallel(ctx, contents)
+	}
+
+	return r.upsertBatch(ctx, contents, checkFenceAndTouch(contents))
+}
+
+// bulkUpsertParallel upserts contents in batches of upsertBatchSize, each in its own
+// transaction, run by the upsert workers. The fencing token and the sync of the
+// providers are recorded first, then the token is share-locked by each batch (see
+// shareFence).
+//
+// A failed batch doesn't stop the others: the contents of the batches that committed
+// get their database-generated fields, and the returned error joins the error of each
+// failed batch, which names the batch and its range of contents.
+func (r *Repository) bulkUpsertParallel(ctx context.Context, contents []*domain.Content) ([]*domain.Content, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return checkFenceAndTouch(contents)(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, (len(contents)+upsertBatchSize-1)/upsertBatchSize)
+	written := make([][]*domain.Content, len(errs))
+	batches := make(chan int)
+	var wg sync.WaitGroup
+	for range min(r.upsertWorkers, len(errs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range batches {
+				start := i * upsertBatchSize
+				end := min(start+upsertBatchSize, len(contents))
+				batch, err := r.upsertBatch(ctx, contents[start:end], shareFence)
+				if err != nil {
+					errs[i] = fmt.Errorf("batch %d (contents %d-%d): %w", i+1, start, end-1, err)
+				}
+				written[i] = batch
+			}
+		}()
+	}
+
+	for i := range errs {
+		batches <- i
 	}
+	close(batches)
+	wg.Wait()
 
-	now := time.Now().UTC()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(written...), nil
+}
+
+// upsertBatch upserts contents in one transaction, checked by fence, inserting
+// upsertBatchSize rows per statement, and returns those created or changed.
+func (r *Repository) upsertBatch(
+	ctx context.Context,
+	contents []*domain.Content,
+	fence func(context.Context, *gorm.DB) error,
+) ([]*domain.Content, error) {
+	now := upsertTimestamp()
 	models := FromDomainSlice(contents)
 	for _, m := range models {
+		m.CreatedAt = now
 		m.UpdatedAt = now
 	}
 
-	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "provider_id"}, {Name: "external_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"title", "type", "tags",
-			"views", "likes", "duration", "reading_time", "reactions", "comments",
-			"score", "published_at", "updated_at",
-		}),
-	}).CreateInBatches(models, 100).Error
+	var changed []*ContentModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := fence(ctx, tx); err != nil {
+			return err
+		}
+
+		unchanged, err := findUnchanged(tx, models)
+		if err != nil {
+			return err
+		}
+		changed = skipUnchanged(models, unchanged)
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err := tx.Clauses(upsertReturning, upsertOnConflict).CreateInBatches(changed, upsertBatchSize).Error; err != nil {
+			return fmt.Errorf("bulk upserting contents: %w", wrapTimeout(err))
+		}
+
+		return r.writeUpserted(tx, changed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return setUpserted(contents, models, changed), nil
+}
+
+// contentKey identifies a content by its provider and the provider's ID for it.
+type contentKey struct {
+	providerID string
+	externalID string
+}
+
+// findUnchanged returns the stored rows of the models whose hash and score didn't
+// change, upsertBatchSize keys per query. A key listed more than once is compared by
+// its last model, which an upsert would keep. Scores compare as an upsert stores
+// them: rounded like the score column, plus the content's CTR boost.
+func findUnchanged(tx *gorm.DB, models []*ContentModel) ([]mergedRow, error) {
+	last := make(map[contentKey]*ContentModel, len(models))
+	keys := make([]contentKey, 0, len(models))
+	for _, m := range models {
+		key := contentKey{m.ProviderID, m.ExternalID}
+		if _, ok := last[key]; !ok {
+			keys = append(keys, key)
+		}
+		last[key] = m
+	}
+
+	var unchanged []mergedRow
+	for chunk := range slices.Chunk(keys, upsertBatchSize) {
+		values := make([]string, len(chunk))
+		args := make([]any, 0, 4*len(chunk))
+		for i, key := range chunk {
+			m := last[key]
+			values[i] = "(?, ?, ?, ?::decimal(10,2))"
+			args = append(args, m.ProviderID, m.ExternalID, m.ContentHash, m.Score)
+		}
+
+		var rows []mergedRow
+		err := tx.Raw(`
+			SELECT c.id, c.provider_id, c.external_id, c.created_at, c.updated_at, c.score
+			FROM contents c
+			JOIN (VALUES `+strings.Join(values, ", ")+`) AS v(provider_id, external_id, content_hash, score)
+				ON c.provider_id = v.provider_id AND c.external_id = v.external_id
+			WHERE c.content_hash = v.content_hash AND c.score = v.score + c.ctr_boost
+		`, args...).Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("finding unchanged contents: %w", wrapTimeout(err))
+		}
+		unchanged = append(unchanged, rows...)
+	}
+
+	return unchanged, nil
+}
+
+// skipUnchanged sets the stored fields of the models with a row in unchanged and
+// returns the others, to be written.
+func skipUnchanged(models []*ContentModel, unchanged []mergedRow) []*ContentModel {
+	if len(unchanged) == 0 {
+		return models
+	}
+
+	byKey := make(map[contentKey]mergedRow, len(unchanged))
+	for _, row := range unchanged {
+		byKey[contentKey{row.ProviderID, row.ExternalID}] = row
+	}
+
+	changed := make([]*ContentModel, 0, len(models)-len(unchanged))
+	for _, m := range models {
+		row, ok := byKey[contentKey{m.ProviderID, m.ExternalID}]
+		if !ok {
+			changed = append(changed, m)
+
+			continue
+		}
+		row.set(m)
+	}
+
+	return changed
+}
+
+// checkFenceAndTouch returns a fence checking the fencing token of the context (see
+// checkFence), then recording the sync of the providers of contents.
+func checkFenceAndTouch(contents []*domain.Content) func(context.Context, *gorm.DB) error {
+	return func(ctx context.Context, tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		return touchProviderSyncs(tx, contents, upsertTimestamp())
+	}
+}
 
+// touchProviderSyncs records that the providers of contents synced at, for
+// LastSyncTimes: unchanged contents keep their updated_at.
+func touchProviderSyncs(tx *gorm.DB, contents []*domain.Content, at time.Time) error {
+	var syncs []ProviderSyncModel
+	seen := make(map[string]bool)
+	for _, c := range contents {
+		if !seen[c.ProviderID] {
+			seen[c.ProviderID] = true
+			syncs = append(syncs, ProviderSyncModel{ProviderID: c.ProviderID, SyncedAt: at})
+		}
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider_id"}},
+		DoUpdates: clause.Set{{
+			Column: clause.Column{Name: "synced_at"},
+			Value:  gorm.Expr("GREATEST(provider_syncs.synced_at, excluded.synced_at)"),
+		}},
+	}).Create(&syncs).Error
 	if err != nil {
-		return fmt.Errorf("bulk upserting contents: %w", err)
+		return fmt.Errorf("recording provider syncs: %w", wrapTimeout(err))
 	}
 
-	// Update domain objects with database-generated fields
+	return nil
+}
+
+// setUpserted updates the domain objects with the database-generated fields of their
+// upserted models, and returns those whose model is in written.
+func setUpserted(contents []*domain.Content, models, written []*ContentModel) []*domain.Content {
+	isWritten := make(map[*ContentModel]bool, len(written))
+	for _, m := range written {
+		isWritten[m] = true
+	}
+
+	var changed []*domain.Content
 	for i, m := range models {
 		contents[i].ID = m.ID
+		contents[i].Score = m.Score
 		contents[i].CreatedAt = m.CreatedAt
 		contents[i].UpdatedAt = m.UpdatedAt
+		contents[i].Hash = m.ContentHash
+		if isWritten[m] {
+			changed = append(changed, contents[i])
+		}
 	}
 
-	return nil
+	return changed
+}
+
+// writeUpserted stores a ContentUpserted event per provider of the upserted models in
+// the outbox, if enabled.
+func (r *Repository) writeUpserted(tx *gorm.DB, models []*ContentModel) error {
+	if !r.outbox {
+		return nil
+	}
+
+	var upserts []domain.ContentUpserted
+	byProvider := make(map[string]int) // Index of each provider's event
+	for _, m := range models {
+		i, ok := byProvider[m.ProviderID]
+		if !ok {
+			i = len(upserts)
+			byProvider[m.ProviderID] = i
+			upserts = append(upserts, domain.ContentUpserted{Provider: m.ProviderID})
+		}
+		upserts[i].Contents = append(upserts[i].Contents, m.ToDomain())
+	}
+
+	events := make([]domain.Event, len(upserts))
+	for i, e := range upserts {
+		events[i] = e
+	}
+
+	return writeOutbox(tx, events...)
 }
 
 // Delete removes a content by its internal ID.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&ContentModel{})
-	if result.Error != nil {
-		return fmt.Errorf("deleting content: %w", result.Error)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&ContentModel{})
+		if result.Error != nil {
+			return fmt.Errorf("deleting content: %w", wrapTimeout(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("content %s: %w", id, domain.ErrNotFound)
+		}
+
+		if !r.outbox {
+			return nil
+		}
+
+		return writeOutbox(tx, domain.ContentDeleted{ID: id})
+	})
+}
+
+// UpdateCuration changes the editorial flags of a content. Like ReplaceCTRBoosts, it
+// leaves updated_at alone since that tracks provider data.
+func (r *Repository) UpdateCuration(ctx context.Context, id string, patch domain.CurationPatch) (*domain.Content, error) {
+	var content *domain.Content
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Unset fields bind as NULL and keep their column value
+		result := tx.Exec(`
+			UPDATE contents SET
+				pinned = COALESCE(?::boolean, pinned),
+				blocked = COALESCE(?::boolean, blocked),
+				boost = COALESCE(?::decimal, boost)
+			WHERE id = ?
+		`, patch.Pinned, patch.Blocked, patch.Boost, id)
+		if result.Error != nil {
+			return fmt.Errorf("updating content curation: %w", wrapTimeout(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("content %s: %w", id, domain.ErrNotFound)
+		}
+
+		var err error
+		content, err = (&Repository{db: tx}).GetByID(ctx, id)
+		if err != nil || !r.outbox {
+			return err
+		}
+
+		return writeOutbox(tx, domain.ContentCurated{Content: content})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return content, nil
+}
+
+// ListCurated returns all pinned, blocked or boosted contents, pinned ones first.
+func (r *Repository) ListCurated(ctx context.Context) ([]*domain.Content, error) {
+	var models []ContentModel
+	err := r.db.WithContext(ctx).
+		Where("pinned OR blocked OR boost <> 1").
+		Order("pinned DESC, blocked ASC, boost DESC, title ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("listing curated contents: %w", wrapTimeout(err))
+	}
+
+	contents := make([]*domain.Content, len(models))
+	for i := range models {
+		contents[i] = models[i].ToDomain()
+	}
+
+	return contents, nil
 }
 
 // Count returns the total number of contents matching optional filters.
@@ -165,38 +576,340 @@ func (r *Repository) Count(ctx context.Context, params domain.SearchParams) (int
 	var count int64
 	query := r.buildSearchQuery(params)
 	if err := query.WithContext(ctx).Model(&ContentModel{}).Count(&count).Error; err != nil {
-		return 0, fmt.Errorf("counting contents: %w", err)
+		return 0, fmt.Errorf("counting contents: %w", wrapTimeout(err))
 	}
 
 	return count, nil
 }
 
+// Facets counts the contents matching the params filters per type, provider, tag and
+// duration bucket. Each facet is counted without its own filter.
+func (r *Repository) Facets(ctx context.Context, params domain.SearchParams, tagLimit int) (*domain.SearchFacets, error) {
+	facets := &domain.SearchFacets{}
+
+	byType := params
+	byType.Type = ""
+	if err := r.countFacet(r.buildSearchQuery(byType).WithContext(ctx), "type", 0, &facets.Types); err != nil {
+		return nil, fmt.Errorf("counting type facet: %w", err)
+	}
+
+	byProvider := params
+	byProvider.Provider = ""
+	if err := r.countFacet(r.buildSearchQuery(byProvider).WithContext(ctx), "provider_id", 0, &facets.Providers); err != nil {
+		return nil, fmt.Errorf("counting provider facet: %w", err)
+	}
+
+	byTag := params
+	byTag.Tag = ""
+	tags := r.buildSearchQuery(byTag).WithContext(ctx).Joins("CROSS JOIN LATERAL unnest(contents.tags) AS facet_tags(tag)")
+	if err := r.countFacet(tags, "facet_tags.tag", tagLimit, &facets.Tags); err != nil {
+		return nil, fmt.Errorf("counting tag facet: %w", err)
+	}
+
+	byDuration := params
+	byDuration.Duration = ""
+	durations := r.buildSearchQuery(byDuration).WithContext(ctx).Where("duration_bucket IS NOT NULL")
+	if err := r.countFacet(durations, "duration_bucket", 0, &facets.Durations); err != nil {
+		return nil, fmt.Errorf("counting duration facet: %w", err)
+	}
+
+	return facets, nil
+}
+
+// countFacet groups the rows of query by column, most frequent first. limit 0 means all values.
+func (r *Repository) countFacet(query *gorm.DB, column string, limit int, out *[]domain.FacetCount) error {
+	query = query.
+		Select(column + " AS value, COUNT(*) AS count").
+		Group(column).
+		Order("count DESC, value ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(out).Error; err != nil {
+		return wrapTimeout(err)
+	}
+	if *out == nil {
+		*out = []domain.FacetCount{}
+	}
+
+	return nil
+}
+
+// Iterate calls fn with successive batches of contents matching the params filters.
+// Uses keyset pagination on id, so memory stays bounded and rows inserted during
+// iteration don't shift pages the way OFFSET would.
+func (r *Repository) Iterate(
+	ctx context.Context,
+	params domain.SearchParams,
+	batchSize int,
+	fn func(batch []*domain.Content) error,
+) error {
+	lastID := ""
+
+	for {
+		query := r.buildSearchQuery(params).WithContext(ctx)
+		if lastID != "" {
+			query = query.Where("id > ?", lastID)
+		}
+
+		var models []ContentModel
+		if err := query.Order("id ASC").Limit(batchSize).Find(&models).Error; err != nil {
+			return fmt.Errorf("iterating contents: %w", wrapTimeout(err))
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		batch := make([]*domain.Content, len(models))
+		for i := range models {
+			batch[i] = models[i].ToDomain()
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+
+		if len(models) < batchSize {
+			return nil
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// ctrBoostBatchSize bounds the rows set per UPDATE statement (2 parameters each).
+const ctrBoostBatchSize = 1000
+
+// ReplaceCTRBoosts sets the CTR boost of each content in boosts (keyed by content ID)
+// and removes the boost of every other content, in one transaction. The boost is
+// added to score; updated_at is left alone since it tracks provider data.
+// Like BulkUpsert, it returns domain.ErrLockLost if ctx carries a stale fencing token.
+func (r *Repository) ReplaceCTRBoosts(ctx context.Context, boosts map[string]float64) error {
+	ids := make([]string, 0, len(boosts))
+	for id := range boosts {
+		ids = append(ids, id)
+	}
+	// Stable order keeps row locks consistent between concurrent runs
+	sort.Strings(ids)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE contents SET score = score - ctr_boost, ctr_boost = 0 WHERE ctr_boost <> 0").Error; err != nil {
+			return err
+		}
+
+		for start := 0; start < len(ids); start += ctrBoostBatchSize {
+			batch := ids[start:min(start+ctrBoostBatchSize, len(ids))]
+			values := make([]string, len(batch))
+			args := make([]any, 0, 2*len(batch))
+			for i, id := range batch {
+				values[i] = "(?::uuid, ?::decimal)"
+				args = append(args, id, boosts[id])
+			}
+
+			if err := tx.Exec(`
+				UPDATE contents SET score = contents.score + v.boost, ctr_boost = v.boost
+				FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, boost)
+				WHERE contents.id = v.id
+			`, args...).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("replacing ctr boosts: %w", wrapTimeout(err))
+	}
+
+	return nil
+}
+
+// Rescore sets the score of every content, blocked ones included, to score(content)
+// plus its CTR boost, batchSize contents at a time. Each batch is its own statement,
+// so a large table isn't locked for the whole run; like ReplaceCTRBoosts, it leaves
+// updated_at alone.
+func (r *Repository) Rescore(ctx context.Context, batchSize int, score func(c *domain.Content) float64) (int64, error) {
+	var rescored int64
+	lastID := ""
+
+	for {
+		query := r.db.WithContext(ctx).Model(&ContentModel{})
+		if lastID != "" {
+			query = query.Where("id > ?", lastID)
+		}
+
+		var models []ContentModel
+		if err := query.Order("id ASC").Limit(batchSize).Find(&models).Error; err != nil {
+			return rescored, fmt.Errorf("rescoring contents: %w", wrapTimeout(err))
+		}
+		if len(models) == 0 {
+			return rescored, nil
+		}
+
+		values := make([]string, len(models))
+		args := make([]any, 0, 2*len(models))
+		for i := range models {
+			values[i] = "(?::uuid, ?::decimal)"
+			args = append(args, models[i].ID, score(models[i].ToDomain()))
+		}
+
+		result := r.db.WithContext(ctx).Exec(`
+			UPDATE contents SET score = v.score + contents.ctr_boost
+			FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, score)
+			WHERE contents.id = v.id
+		`, args...)
+		if result.Error != nil {
+			return rescored, fmt.Errorf("rescoring contents: %w", wrapTimeout(result.Error))
+		}
+		rescored += result.RowsAffected
+
+		if len(models) < batchSize {
+			return rescored, nil
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// LastSyncTimes returns when each provider's contents were last upserted, as
+// recorded in provider_syncs: unchanged contents keep their updated_at.
+func (r *Repository) LastSyncTimes(ctx context.Context) (map[string]time.Time, error) {
+	var syncs []ProviderSyncModel
+	if err := r.db.WithContext(ctx).Find(&syncs).Error; err != nil {
+		return nil, fmt.Errorf("querying last sync times: %w", wrapTimeout(err))
+	}
+
+	times := make(map[string]time.Time, len(syncs))
+	for _, s := range syncs {
+		times[s.ProviderID] = s.SyncedAt
+	}
+
+	return times, nil
+}
+
+// CreatedPerDay returns the number of contents created before since and per UTC day
+// from since on. Blocked contents are left out, as in Count.
+func (r *Repository) CreatedPerDay(ctx context.Context, since time.Time) (int64, []domain.DailyCount, error) {
+	var before int64
+	err := r.db.WithContext(ctx).Model(&ContentModel{}).
+		Where("NOT blocked AND created_at < ?", since).
+		Count(&before).Error
+	if err != nil {
+		return 0, nil, fmt.Errorf("counting contents created before %s: %w", since.Format(time.RFC3339), wrapTimeout(err))
+	}
+
+	var daily []domain.DailyCount
+	err = r.db.WithContext(ctx).Model(&ContentModel{}).
+		Select("date_trunc('day', created_at) AS day, COUNT(*) AS count").
+		Where("NOT blocked AND created_at >= ?", since).
+		Group("day").
+		Order("day").
+		Scan(&daily).Error
+	if err != nil {
+		return 0, nil, fmt.Errorf("counting contents created per day: %w", wrapTimeout(err))
+	}
+
+	return before, daily, nil
+}
+
 // buildSearchQuery builds the WHERE clause for search.
-// When query is provided, uses PostgreSQL FTS with tsvector matching.
+// When query is provided, uses PostgreSQL FTS with tsvector matching, against the
+// tsquery joined as textQuery.
+// Contents blocked by an admin never match.
 // All parameters are safely bound using GORM's parameterized queries.
 func (r *Repository) buildSearchQuery(params domain.SearchParams) *gorm.DB {
-	query := r.db.Model(&ContentModel{})
+	query := r.db.Model(&ContentModel{}).Where("NOT blocked")
+	if params.HideUnpublished {
+		query = query.Where("published_at <= NOW()")
+	}
 
-	// Full-Text Search: Use tsvector @@ tsquery when query provided
-	// websearch_to_tsquery supports user-friendly syntax:
-	// - "word1 word2" → word1 AND word2
-	// - "word1 OR word2" → word1 OR word2
-	// - "-word" → NOT word
-	if params.Query != "" {
-		query = query.Where(
-			"search_vector @@ websearch_to_tsquery('english', ?)",
-			params.Query,
-		)
+	// Full-Text Search: Use tsvector @@ tsquery when query provided, unless a text
+	// index already matched it
+	if params.TextMatches != nil {
+		query = joinTextMatches(query, params.TextMatches)
+	} else if params.Query != "" {
+		vector, tsquery, arg := textSearch(params)
+		query = query.
+			Joins("CROSS JOIN "+tsquery+" AS text_query(tsq)", arg).
+			Where(vector + " @@ " + textQuery)
 	}
 
 	// Filter by content type
 	if params.Type != "" {
 		query = query.Where("type = ?", string(params.Type))
 	}
+	if params.Provider != "" {
+		query = query.Where("provider_id = ?", params.Provider)
+	}
+	// Containment rather than ANY() so the GIN index on tags is used
+	if params.Tag != "" {
+		query = query.Where("tags @> ARRAY[?]::text[]", params.Tag)
+	}
+	if params.Duration != "" {
+		query = query.Where("duration_bucket = ?", string(params.Duration))
+	}
 
 	return query
 }
 
+// textQuery is the tsquery of the search, joined once per statement by
+// buildSearchQuery. Ranking by ts_rank(vector, <tsquery expression>) instead would
+// parse the query again for every matching row, which adds up on broad queries
+// whatever plan the GIN index gets.
+const textQuery = "text_query.tsq"
+
+// textSearch returns the tsvector column and the tsquery expression matching
+// params.Query in its search mode, with the argument to bind to the expression.
+//
+// websearch_to_tsquery supports user-friendly syntax:
+// - "word1 word2" → word1 AND word2
+// - "word1 OR word2" → word1 OR word2
+// - "-word" → NOT word
+//
+// Prefix mode matches the unstemmed prefix_vector instead, each query word as a
+// prefix: "kube tut" → kube:* & tut:*.
+func textSearch(params domain.SearchParams) (vector, tsquery, arg string) {
+	if params.Mode == domain.SearchModePrefix {
+		return "prefix_vector", "to_tsquery('simple', ?)", prefixTSQuery(params.Query)
+	}
+
+	return "search_vector", "websearch_to_tsquery('english', ?)", params.Query
+}
+
+// joinTextMatches restricts query to the contents matched by a text index, joining
+// their relevance as match_rank. The columns are prefixed so they don't clash with
+// those of contents.
+func joinTextMatches(query *gorm.DB, matches []domain.TextMatch) *gorm.DB {
+	ids := make([]string, len(matches))
+	ranks := make([]float64, len(matches))
+	for i, m := range matches {
+		ids[i], ranks[i] = m.ContentID, m.Relevance
+	}
+
+	return query.Joins(
+		"JOIN unnest(?::uuid[], ?::float8[]) AS text_matches(match_id, match_rank) ON text_matches.match_id = contents.id",
+		pq.Array(ids), pq.Array(ranks),
+	)
+}
+
+// prefixTSQuery turns a query into a tsquery matching each of its words as a prefix.
+// Only letters and digits are kept, so the result is always valid tsquery syntax;
+// a query without any yields an empty tsquery, which matches nothing. to_tsquery
+// lowercases the words.
+func prefixTSQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
 // applyOrdering adds ORDER BY clause to the query.
 //
 // For relevance sort with a search query, uses hybrid ranking:
@@ -212,33 +925,52 @@ func (r *Repository) buildSearchQuery(params domain.SearchParams) *gorm.DB {
 // | Poor match, viral          | 0.1     | 1,000,000 | 0.1 × 6.0 = 0.6     |
 //
 // Key insight: Perfect match of new content (0.9) beats poor match of viral (0.6)
+//
+// With the text scoring strategy the popularity factor is dropped and matches are
+// ranked by ts_rank alone.
+//
+// Pinned contents come first whatever the sort, and the editorial boost multiplies
+// the rank or score (published_at sorts stay chronological).
 func (r *Repository) applyOrdering(query *gorm.DB, params domain.SearchParams) *gorm.DB {
 	direction := "DESC"
 	if params.SortOrder == domain.SortOrderAsc {
 		direction = "ASC"
 	}
 
+	// Part of each ORDER BY rather than a separate Order call: gorm drops plain
+	// order columns once an expression is set
+	const pinnedFirst = "pinned DESC, "
+
 	switch params.SortBy {
 	case domain.SortFieldRelevance:
+		if params.TextMatches != nil {
+			// Ranked by the text index instead of ts_rank
+			rank := "(match_rank * log_score_cached * boost) "
+			if params.Scoring == domain.ScoringText {
+				rank = "(match_rank * boost) "
+			}
+
+			return query.Order(pinnedFirst + rank + direction)
+		}
 		if params.Query != "" {
-			// Use gorm.Expr with parameterized query for SQL injection safety.
-			// This prevents injection from user input like "O'Reilly"
-			// Uses cached log_score_cached column for efficient ranking
-			expr := gorm.Expr(
-				"(ts_rank(search_vector, websearch_to_tsquery('english', ?)) * log_score_cached) "+direction,
-				params.Query,
-			)
-
-			return query.Clauses(clause.OrderBy{Expression: expr})
+			// Ranks with the tsquery joined by buildSearchQuery, where the user input
+			// is bound; uses cached log_score_cached column for efficient ranking
+			vector, _, _ := textSearch(params)
+			rank := "(ts_rank(" + vector + ", " + textQuery + ") * log_score_cached * boost) "
+			if params.Scoring == domain.ScoringText {
+				rank = "(ts_rank(" + vector + ", " + textQuery + ") * boost) "
+			}
+
+			return query.Order(pinnedFirst + rank + direction)
 		}
 		// Fallback to score when no query provided
-		return query.Order("score " + direction)
+		return query.Order(pinnedFirst + "score * boost " + direction)
 
 	case domain.SortFieldScore:
-		return query.Order("score " + direction)
+		return query.Order(pinnedFirst + "score * boost " + direction)
 	case domain.SortFieldPublishedAt:
-		return query.Order("published_at " + direction)
+		return query.Order(pinnedFirst + "published_at " + direction)
 	default:
-		return query.Order("score " + direction)
+		return query.Order(pinnedFirst + "score * boost " + direction)
 	}
 }
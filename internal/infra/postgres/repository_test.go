@@ -2,7 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/postgres/migrations"
+	"search-engine-service/pkg/locker"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -24,7 +29,7 @@ import (
 //
 // OR
 //   - Skip tests with: go test -short
-func setupTestDB(t *testing.T) (*gorm.DB, func()) {
+func setupTestDB(t testing.TB) (*gorm.DB, func()) {
 	t.Helper()
 
 	ctx := context.Background()
@@ -63,9 +68,16 @@ Docker Prerequisites:
 	require.NoError(t, err, "Failed to connect to test database")
 
 	// Run migrations
-	err = db.AutoMigrate(&ContentModel{})
+	err = db.AutoMigrate(&ContentModel{}, &AnalyticsEventModel{}, &LockFenceModel{}, &CollectionModel{}, &CollectionItemModel{}, &SyncRunModel{}, &SearchQueryModel{}, &SearchTermModel{}, &OutboxEventModel{}, &DeadLetterModel{}, &ProviderSyncModel{})
 	require.NoError(t, err, "Failed to run migrations")
 
+	// AutoMigrate doesn't create generated columns; searches filter on duration_bucket
+	for _, m := range migrations.Migrations() {
+		if m.ID == "024_add_duration_bucket" {
+			require.NoError(t, m.Migrate(db), "Failed to add duration_bucket")
+		}
+	}
+
 	// Cleanup function
 	cleanup := func() {
 		sqlDB, _ := db.DB()
@@ -159,6 +171,8 @@ func TestUpsert_UpdateExisting(t *testing.T) {
 	// Update content
 	content.Title = "Updated Title"
 	content.Views = 200
+	content.URL = "https://provider-a.example.com/ext_123"
+	content.SetDuration("12:30")
 	err = repo.Upsert(ctx, content)
 	require.NoError(t, err)
 
@@ -177,6 +191,8 @@ func TestUpsert_UpdateExisting(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Updated Title", model.Title)
 	assert.Equal(t, 200, model.Views)
+	assert.Equal(t, "https://provider-a.example.com/ext_123", model.URL)
+	assert.Equal(t, 750, model.DurationSeconds)
 }
 
 // TestBulkUpsert_MixedOperations verifies BulkUpsert handles mixed new and existing records
@@ -439,6 +455,75 @@ func TestBulkUpsert_EmptySlice(t *testing.T) {
 	assert.NoError(t, err, "Nil slice should not cause error")
 }
 
+// TestBulkUpsert_RejectsStaleFencingToken verifies writes under an expired lock are rejected
+func TestBulkUpsert_RejectsStaleFencingToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	staleCtx := locker.ContextWithFence(ctx, locker.Fence{Key: "sync:run:lock", Token: 1})
+	currentCtx := locker.ContextWithFence(ctx, locker.Fence{Key: "sync:run:lock", Token: 2})
+
+	// The lock's new holder writes first, then the old one finishes its slow sync
+	require.NoError(t, repo.BulkUpsert(currentCtx, []*domain.Content{createTestContent("provider_a", "ext_001")}))
+	require.NoError(t, repo.BulkUpsert(currentCtx, []*domain.Content{createTestContent("provider_a", "ext_002")}),
+		"the current holder can write repeatedly")
+
+	err := repo.BulkUpsert(staleCtx, []*domain.Content{createTestContent("provider_a", "ext_003")})
+	require.ErrorIs(t, err, domain.ErrLockLost)
+
+	var count int64
+	require.NoError(t, db.Model(&ContentModel{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count, "the stale write must not be applied")
+
+	// Writes outside a lock aren't fenced
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{createTestContent("provider_a", "ext_004")}))
+
+	// Lockers losing their counters start over above the recorded token
+	floor, err := FenceFloor(db)(ctx, "sync:run:lock")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), floor)
+	floor, err = FenceFloor(db)(ctx, "other:lock")
+	require.NoError(t, err)
+	assert.Zero(t, floor)
+}
+
+// TestBulkUpsert_FencedThroughRestartedMemoryLocker verifies fenced writes keep working
+// after a restart when the memory locker draws its tokens from the database
+func TestBulkUpsert_FencedThroughRestartedMemoryLocker(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, m := range migrations.Migrations() {
+		if m.ID == "008_create_lock_fencing_tokens" {
+			require.NoError(t, m.Migrate(db))
+		}
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	for i := range 2 {
+		// Each locker stands for a process started anew, with its counters reset
+		l := locker.NewMemoryLocker(locker.WithTokenSource(locker.SequenceTokenSource(sqlDB)))
+		err := locker.WithLock(ctx, l, "sync:run:lock", time.Minute, func(ctx context.Context) error {
+			return repo.BulkUpsert(ctx, []*domain.Content{createTestContent("provider_a", fmt.Sprint("ext_", i))})
+		})
+		require.NoError(t, err, "write %d", i+1)
+	}
+}
+
 // TestBulkUpsert_LargeBatch verifies batch processing with large datasets
 func TestBulkUpsert_LargeBatch(t *testing.T) {
 	if testing.Short() {
@@ -474,6 +559,150 @@ func TestBulkUpsert_LargeBatch(t *testing.T) {
 	}
 }
 
+// TestBulkUpsertFast_MatchesBulkUpsert verifies the COPY path writes like batched inserts
+func TestBulkUpsertFast_MatchesBulkUpsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db).WithOutbox()
+	ctx := context.Background()
+
+	existing := createTestContent("provider_a", "ext_001")
+	require.NoError(t, repo.Upsert(ctx, existing))
+	require.NoError(t, repo.ReplaceCTRBoosts(ctx, map[string]float64{existing.ID: 4}))
+
+	updated := createTestContent("provider_a", "ext_001")
+	updated.Title = "Updated Title"
+	inserted := createTestContent("provider_b", "ext_002")
+	inserted.Tags = nil
+	duplicate := createTestContent("provider_b", "ext_002")
+	duplicate.Title = "Last Title"
+	contents := []*domain.Content{updated, inserted, duplicate}
+
+	require.NoError(t, repo.BulkUpsertFast(ctx, contents))
+
+	assert.Equal(t, existing.ID, updated.ID, "updates keep their ID")
+	assert.Equal(t, existing.CreatedAt, updated.CreatedAt, "updates keep their creation time")
+	assert.InDelta(t, 79.5, updated.Score, 0.001, "updates keep their CTR boost")
+	assert.NotEmpty(t, inserted.ID)
+	assert.Equal(t, inserted.ID, duplicate.ID)
+	assert.Equal(t, inserted.UpdatedAt, inserted.CreatedAt)
+
+	stored, err := repo.GetByProviderAndExternalID(ctx, "provider_b", "ext_002")
+	require.NoError(t, err)
+	assert.Equal(t, "Last Title", stored.Title, "the last row of a key wins")
+	stored, err = repo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated Title", stored.Title)
+	assert.Equal(t, []string{"tag1", "tag2"}, stored.Tags)
+
+	var count int64
+	require.NoError(t, db.Model(&ContentModel{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+	require.NoError(t, db.Model(&OutboxEventModel{}).Where("name = ?", domain.ContentUpserted{}.EventName()).Count(&count).Error)
+	assert.Equal(t, int64(3), count, "one event for the Upsert, one per provider of the batch")
+
+	// Stale fencing tokens are rejected like on the insert path
+	require.NoError(t, repo.BulkUpsertFast(locker.ContextWithFence(ctx, locker.Fence{Key: "sync:run:lock", Token: 2}),
+		[]*domain.Content{createTestContent("provider_a", "ext_003")}))
+	err = repo.BulkUpsertFast(locker.ContextWithFence(ctx, locker.Fence{Key: "sync:run:lock", Token: 1}),
+		[]*domain.Content{createTestContent("provider_a", "ext_004")})
+	require.ErrorIs(t, err, domain.ErrLockLost)
+}
+
+// TestBulkUpsert_ParallelWorkers verifies parallel batches are upserted and fail on their own
+func TestBulkUpsert_ParallelWorkers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db).WithUpsertWorkers(3)
+	ctx := locker.ContextWithFence(context.Background(), locker.Fence{Key: "sync:run:lock", Token: 2})
+
+	contents := make([]*domain.Content, 350)
+	for i := range contents {
+		contents[i] = createTestContent("provider_a", fmt.Sprintf("ext_%d", i))
+	}
+	require.NoError(t, repo.BulkUpsert(ctx, contents))
+	for i, content := range contents {
+		assert.NotEmpty(t, content.ID, "Content %d should have ID", i)
+	}
+
+	// The second batch fails alone and is named in the error
+	for i := range contents {
+		contents[i] = createTestContent("provider_b", fmt.Sprintf("ext_%d", i))
+	}
+	contents[150].Title = strings.Repeat("x", 501)
+	err := repo.BulkUpsert(ctx, contents)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "batch 2 (contents 100-199)")
+	assert.NotContains(t, err.Error(), "batch 1")
+	assert.NotEmpty(t, contents[0].ID)
+	assert.Empty(t, contents[150].ID)
+	assert.NotEmpty(t, contents[349].ID)
+
+	var count int64
+	require.NoError(t, db.Model(&ContentModel{}).Where("provider_id = ?", "provider_b").Count(&count).Error)
+	assert.Equal(t, int64(250), count)
+
+	// A stale fencing token fails every batch
+	staleCtx := locker.ContextWithFence(context.Background(), locker.Fence{Key: "sync:run:lock", Token: 1})
+	require.ErrorIs(t, repo.BulkUpsert(staleCtx, contents), domain.ErrLockLost)
+}
+
+// BenchmarkBulkUpsert compares batched inserts, serial and parallel, with COPY for a sync's worth of rows,
+// first inserted, then updated
+func BenchmarkBulkUpsert(b *testing.B) {
+	if testing.Short() {
+		b.Skip("Skipping integration benchmark")
+	}
+
+	db, cleanup := setupTestDB(b)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	paths := []struct {
+		name   string
+		upsert func(context.Context, []*domain.Content) error
+	}{
+		{"insert", repo.BulkUpsert},
+		{"parallel", repo.WithUpsertWorkers(4).BulkUpsert},
+		{"copy", repo.BulkUpsertFast},
+	}
+	for _, rows := range []int{1000, 10000} {
+		for _, path := range paths {
+			b.Run(fmt.Sprintf("%s/%d", path.name, rows), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					require.NoError(b, db.Exec("TRUNCATE contents").Error)
+					contents := make([]*domain.Content, rows)
+					for j := range contents {
+						contents[j] = createTestContent("provider_a", fmt.Sprintf("ext_%d", j))
+					}
+					b.StartTimer()
+
+					require.NoError(b, path.upsert(ctx, contents))
+					b.StopTimer()
+					for _, c := range contents {
+						c.Title += " (updated)"
+					}
+					b.StartTimer()
+					require.NoError(b, path.upsert(ctx, contents))
+				}
+			})
+		}
+	}
+}
+
 // TestUpsert_UniqueConstraintEnforced verifies the composite unique constraint works
 func TestUpsert_UniqueConstraintEnforced(t *testing.T) {
 	if testing.Short() {
@@ -516,3 +745,993 @@ func TestUpsert_UniqueConstraintEnforced(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Different Title", model.Title)
 }
+
+// TestIterate_AllBatches verifies Iterate visits every matching row exactly once across batches
+func TestIterate_AllBatches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	contents := make([]*domain.Content, 0, 25)
+	for i := range 25 {
+		c := createTestContent("provider_a", fmt.Sprintf("ext_%d", i))
+		if i%5 == 0 {
+			c.Type = domain.ContentTypeVideo
+		}
+		contents = append(contents, c)
+	}
+	require.NoError(t, repo.BulkUpsert(ctx, contents))
+
+	seen := make(map[string]bool)
+	batches := 0
+	err := repo.Iterate(ctx, domain.SearchParams{}, 10, func(batch []*domain.Content) error {
+		batches++
+		for _, c := range batch {
+			assert.False(t, seen[c.ID], "content visited twice")
+			seen[c.ID] = true
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, 25)
+	assert.Equal(t, 3, batches)
+
+	// Type filter is applied
+	videos := 0
+	err = repo.Iterate(ctx, domain.SearchParams{Type: domain.ContentTypeVideo}, 10, func(batch []*domain.Content) error {
+		videos += len(batch)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, videos)
+}
+
+// TestLastSyncTimes_NewestUpsertPerProvider verifies the last upsert time is reported per provider
+func TestLastSyncTimes_NewestUpsertPerProvider(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	first := createTestContent("provider_a", "ext_1")
+	require.NoError(t, repo.Upsert(ctx, first))
+	second := createTestContent("provider_a", "ext_2")
+	require.NoError(t, repo.Upsert(ctx, second))
+	other := createTestContent("provider_b", "ext_1")
+	require.NoError(t, repo.Upsert(ctx, other))
+
+	times, err := repo.LastSyncTimes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, times, 2)
+	assert.True(t, second.UpdatedAt.Equal(times["provider_a"]))
+	assert.True(t, other.UpdatedAt.Equal(times["provider_b"]))
+}
+
+// TestUpsertChanged_SkipsUnchangedContents verifies both upsert paths leave contents
+// with unchanged provider data alone, while still recording the sync
+func TestUpsertChanged_SkipsUnchangedContents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for name, repo := range map[string]*Repository{
+		"insert": NewRepository(db),
+		"copy":   NewRepository(db).WithCopyThreshold(1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, db.Exec("TRUNCATE contents, provider_syncs").Error)
+
+			publishedAt := time.Now().UTC()
+			content := func(externalID string) *domain.Content {
+				c := createTestContent("provider_a", externalID)
+				c.PublishedAt = publishedAt
+
+				return c
+			}
+
+			first := []*domain.Content{content("ext_1"), content("ext_2")}
+			written, err := repo.UpsertChanged(ctx, first)
+			require.NoError(t, err)
+			assert.Len(t, written, 2, "new contents are written")
+			assert.NotEmpty(t, first[0].Hash)
+
+			same := content("ext_1")
+			changed := content("ext_2")
+			changed.Title = "Changed Title"
+			written, err = repo.UpsertChanged(ctx, []*domain.Content{same, changed})
+			require.NoError(t, err)
+			assert.Equal(t, []*domain.Content{changed}, written)
+
+			assert.Equal(t, first[0].ID, same.ID, "unchanged contents get their stored fields")
+			assert.True(t, first[0].UpdatedAt.Equal(same.UpdatedAt), "unchanged contents keep their update time")
+			assert.True(t, changed.UpdatedAt.After(first[1].UpdatedAt))
+
+			times, err := repo.LastSyncTimes(ctx)
+			require.NoError(t, err)
+			assert.False(t, times["provider_a"].Before(changed.UpdatedAt), "the sync is recorded")
+		})
+	}
+}
+
+func TestAnalyticsCreateBatch_SetsIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	content := createTestContent("provider_a", "ext_1")
+	require.NoError(t, NewRepository(db).Upsert(context.Background(), content))
+
+	repo := NewAnalyticsRepository(db)
+	occurred := time.Now().UTC().Truncate(time.Second)
+	events := []*domain.AnalyticsEvent{
+		{Type: domain.AnalyticsEventImpression, Query: "go", ContentID: content.ID, Position: 1, OccurredAt: occurred},
+		{Type: domain.AnalyticsEventClick, Query: "go", ContentID: content.ID, Position: 1, OccurredAt: occurred},
+	}
+	require.NoError(t, repo.CreateBatch(context.Background(), events))
+
+	for _, e := range events {
+		assert.NotEmpty(t, e.ID)
+		assert.False(t, e.CreatedAt.IsZero())
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&AnalyticsEventModel{}).Where("content_id = ?", content.ID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestReplaceCTRBoosts_SurvivesUpserts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	boosted := createTestContent("provider_a", "ext_1")
+	other := createTestContent("provider_a", "ext_2")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{boosted, other}))
+	updatedAt := boosted.UpdatedAt
+
+	score := func(id string) float64 {
+		var model ContentModel
+		require.NoError(t, db.First(&model, "id = ?", id).Error)
+
+		return model.Score
+	}
+
+	require.NoError(t, repo.ReplaceCTRBoosts(ctx, map[string]float64{boosted.ID: 2.5, other.ID: 1}))
+	assert.Equal(t, 78.0, score(boosted.ID))
+
+	// A sync recomputes the score from provider data and keeps the boost
+	require.NoError(t, repo.Upsert(ctx, createTestContent("provider_a", "ext_1")))
+	assert.Equal(t, 78.0, score(boosted.ID))
+
+	// Replacing drops boosts missing from the new set
+	require.NoError(t, repo.ReplaceCTRBoosts(ctx, map[string]float64{boosted.ID: 1}))
+	assert.Equal(t, 76.5, score(boosted.ID))
+	assert.Equal(t, 75.5, score(other.ID))
+
+	var model ContentModel
+	require.NoError(t, db.First(&model, "id = ?", other.ID).Error)
+	assert.True(t, model.UpdatedAt.Equal(updatedAt), "boosts don't count as syncs")
+}
+
+func TestRescore_KeepsCTRBoosts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	boosted := createTestContent("provider_a", "ext_1")
+	blocked := createTestContent("provider_a", "ext_2")
+	plain := createTestContent("provider_b", "ext_3")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{boosted, blocked, plain}))
+	require.NoError(t, repo.ReplaceCTRBoosts(ctx, map[string]float64{boosted.ID: 2.5}))
+	yes := true
+	_, err := repo.UpdateCuration(ctx, blocked.ID, domain.CurationPatch{Blocked: &yes})
+	require.NoError(t, err)
+
+	// A batch smaller than the table walks every batch
+	rescored, err := repo.Rescore(ctx, 2, func(*domain.Content) float64 { return 10 })
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rescored)
+
+	scores := map[string]float64{}
+	var models []ContentModel
+	require.NoError(t, db.Find(&models).Error)
+	for _, m := range models {
+		scores[m.ID] = m.Score
+		assert.True(t, m.UpdatedAt.Equal(boosted.UpdatedAt), "rescoring doesn't count as a sync")
+	}
+	assert.Equal(t, map[string]float64{boosted.ID: 12.5, blocked.ID: 10, plain.ID: 10}, scores)
+}
+
+func TestSearch_HonorsCuration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	top := createTestContent("provider_a", "ext_top")
+	top.Score = 90
+	low := createTestContent("provider_a", "ext_low")
+	low.Score = 10
+	hidden := createTestContent("provider_a", "ext_hidden")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{top, low, hidden}))
+
+	ids := func(params domain.SearchParams) []string {
+		result, err := repo.Search(ctx, params)
+		require.NoError(t, err)
+		ids := make([]string, len(result.Contents))
+		for i, c := range result.Contents {
+			ids[i] = c.ID
+		}
+
+		return ids
+	}
+
+	blocked := true
+	_, err := repo.UpdateCuration(ctx, hidden.ID, domain.CurationPatch{Blocked: &blocked})
+	require.NoError(t, err)
+	assert.Equal(t, []string{top.ID, low.ID}, ids(domain.DefaultSearchParams()), "blocked contents are excluded")
+
+	boost := 10.0
+	_, err = repo.UpdateCuration(ctx, low.ID, domain.CurationPatch{Boost: &boost})
+	require.NoError(t, err)
+	assert.Equal(t, []string{low.ID, top.ID}, ids(domain.DefaultSearchParams()), "boost multiplies the score")
+
+	boost, pinned := 1.0, true
+	curated, err := repo.UpdateCuration(ctx, low.ID, domain.CurationPatch{Boost: &boost})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, curated.Boost)
+	_, err = repo.UpdateCuration(ctx, top.ID, domain.CurationPatch{Pinned: &pinned})
+	require.NoError(t, err)
+	ascending := domain.DefaultSearchParams()
+	ascending.SortOrder = domain.SortOrderAsc
+	assert.Equal(t, []string{top.ID, low.ID}, ids(ascending), "pinned contents come first whatever the order")
+
+	// Syncs keep the flags
+	require.NoError(t, repo.Upsert(ctx, createTestContent("provider_a", "ext_hidden")))
+	listed, err := repo.ListCurated(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.True(t, listed[0].Pinned)
+	assert.True(t, listed[1].Blocked)
+
+	_, err = repo.UpdateCuration(ctx, "00000000-0000-0000-0000-000000000001", domain.CurationPatch{Pinned: &pinned})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSearch_HidesUnpublished(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	published := createTestContent("provider_a", "ext_published")
+	upcoming := createTestContent("provider_a", "ext_upcoming")
+	upcoming.PublishedAt = time.Now().UTC().Add(48 * time.Hour)
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{published, upcoming}))
+
+	params := domain.DefaultSearchParams()
+	result, err := repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+
+	params.HideUnpublished = true
+	result, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, published.ID, result.Contents[0].ID)
+}
+
+func TestSearch_PrefixMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// AutoMigrate doesn't create the text search columns and triggers
+	for _, m := range migrations.Migrations() {
+		if m.ID == "002_add_fts_support" || m.ID == "017_add_prefix_search" {
+			require.NoError(t, m.Migrate(db))
+		}
+	}
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	programming := createTestContent("provider_a", "ext_programming")
+	programming.Title = "Programming Kubernetes"
+	programming.Tags = []string{"devops"}
+	docker := createTestContent("provider_a", "ext_docker")
+	docker.Title = "Docker Basics"
+	docker.Tags = []string{"programming"}
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{programming, docker}))
+
+	search := func(q string) []string {
+		params := domain.DefaultSearchParams()
+		params.Query = q
+		params.Mode = domain.SearchModePrefix
+		params.SortBy = domain.SortFieldRelevance
+
+		result, err := repo.Search(ctx, params)
+		require.NoError(t, err)
+
+		ids := make([]string, len(result.Contents))
+		for i, c := range result.Contents {
+			ids[i] = c.ID
+		}
+
+		return ids
+	}
+
+	// A title match ranks above a tag match
+	assert.Equal(t, []string{programming.ID, docker.ID}, search("programm"))
+	assert.Equal(t, []string{programming.ID}, search("Prog KUB"))
+	assert.Equal(t, []string{docker.ID}, search("doc"))
+	assert.Empty(t, search("rust"))
+	assert.Empty(t, search("!?"))
+}
+
+func TestSearch_RelevancePlanUsesSearchIndex(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// AutoMigrate doesn't create the text search columns and triggers
+	for _, m := range migrations.Migrations() {
+		if m.ID == "002_add_fts_support" {
+			require.NoError(t, m.Migrate(db))
+		}
+	}
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	contents := make([]*domain.Content, 1000)
+	for i := range contents {
+		contents[i] = createTestContent("provider_a", fmt.Sprintf("ext_%d", i))
+		contents[i].Title = fmt.Sprintf("Cooking recipe %d", i)
+		if i%100 == 0 {
+			contents[i].Title = fmt.Sprintf("Kubernetes operators %d", i)
+		}
+	}
+	require.NoError(t, repo.BulkUpsert(ctx, contents))
+	require.NoError(t, db.Exec("ANALYZE contents").Error)
+
+	params := domain.DefaultSearchParams()
+	params.Query = "kubernetes operators"
+	params.SortBy = domain.SortFieldRelevance
+
+	var models []ContentModel
+	stmt := repo.pageQuery(repo.buildSearchQuery(params).Session(&gorm.Session{DryRun: true}), params).
+		Find(&models).Statement
+
+	// Sequential scans are only discouraged: the plan shows whether the index can be used
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	conn, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_, err = conn.ExecContext(ctx, "SET enable_seqscan = off")
+	require.NoError(t, err)
+
+	rows, err := conn.QueryContext(ctx, "EXPLAIN (VERBOSE) "+stmt.SQL.String(), stmt.Vars...)
+	require.NoError(t, err)
+	defer func() { _ = rows.Close() }()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		require.NoError(t, rows.Scan(&line))
+		plan = append(plan, line)
+	}
+	require.NoError(t, rows.Err())
+	explained := strings.Join(plan, "\n")
+
+	assert.Contains(t, explained, "idx_contents_search_vector", explained)
+	for _, line := range plan {
+		if strings.Contains(line, "Sort Key:") {
+			assert.Contains(t, line, "text_query.tsq", "the rank reuses the joined tsquery")
+			assert.NotContains(t, line, "websearch_to_tsquery", "the query isn't parsed per row")
+		}
+	}
+
+	// The rewritten query still ranks the matches
+	result, err := repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.Total)
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := map[string]string{
+		"golang":            "golang:*",
+		"Go  Tut":           "Go:* & Tut:*",
+		"c++ & 'x' | !y:*":  "c:* & x:* & y:*",
+		"kubernetes-operat": "kubernetes:* & operat:*",
+		"  ":                "",
+		"Çay Köln":          "Çay:* & Köln:*",
+	}
+	for q, expected := range tests {
+		assert.Equal(t, expected, prefixTSQuery(q), q)
+	}
+}
+
+func TestFacets_IgnoreOwnFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	video := createTestContent("provider_a", "ext_video")
+	video.Type = domain.ContentTypeVideo
+	video.Tags = []string{"go", "tutorial"}
+	article := createTestContent("provider_b", "ext_article")
+	article.Type = domain.ContentTypeArticle
+	article.Tags = []string{"go"}
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{video, article}))
+
+	facets, err := repo.Facets(ctx, domain.SearchParams{Provider: "provider_a"}, 10)
+	require.NoError(t, err)
+
+	// The provider facet still counts the other provider
+	assert.Equal(t, []domain.FacetCount{{Value: "provider_a", Count: 1}, {Value: "provider_b", Count: 1}}, facets.Providers)
+	assert.Equal(t, []domain.FacetCount{{Value: "video", Count: 1}}, facets.Types)
+	assert.Equal(t, []domain.FacetCount{{Value: "go", Count: 1}, {Value: "tutorial", Count: 1}}, facets.Tags)
+
+	params := domain.DefaultSearchParams()
+	params.Tag = "tutorial"
+	result, err := repo.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, video.ID, result.Contents[0].ID)
+}
+
+func TestSearch_DurationBuckets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	video := func(externalID, duration string) *domain.Content {
+		c := createTestContent("provider_a", externalID)
+		c.Type = domain.ContentTypeVideo
+		c.SetDuration(duration)
+
+		return c
+	}
+	short := video("ext_short", "3:59")
+	medium := video("ext_medium", "20:00")
+	long := video("ext_long", "1:02:03")
+	unknown := video("ext_unknown", "soon")
+	podcast := createTestContent("provider_a", "ext_podcast")
+	podcast.Type = domain.ContentTypePodcast
+	podcast.SetDuration("5:00")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{short, medium, long, unknown, podcast}))
+
+	facets, err := repo.Facets(ctx, domain.SearchParams{Duration: domain.DurationShort}, 10)
+	require.NoError(t, err)
+	// The duration facet ignores its own filter and leaves out unclassified contents
+	assert.Equal(t, []domain.FacetCount{
+		{Value: "long", Count: 1}, {Value: "medium", Count: 1}, {Value: "short", Count: 1},
+	}, facets.Durations)
+	assert.Equal(t, []domain.FacetCount{{Value: "video", Count: 1}}, facets.Types)
+
+	for bucket, want := range map[domain.DurationBucket]*domain.Content{
+		domain.DurationShort: short, domain.DurationMedium: medium, domain.DurationLong: long,
+	} {
+		params := domain.DefaultSearchParams()
+		params.Duration = bucket
+		result, err := repo.Search(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1, bucket)
+		assert.Equal(t, want.ID, result.Contents[0].ID)
+		assert.Equal(t, bucket, result.Contents[0].DurationBucket(), "the column classifies like the domain")
+	}
+}
+
+func TestCollections_KeepContentOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	contents := NewRepository(db)
+	repo := NewCollectionRepository(db)
+	ctx := context.Background()
+
+	first := createTestContent("provider_a", "ext_first")
+	second := createTestContent("provider_a", "ext_second")
+	third := createTestContent("provider_a", "ext_third")
+	require.NoError(t, contents.BulkUpsert(ctx, []*domain.Content{first, second, third}))
+
+	collection := &domain.Collection{Title: "Go Series", ContentIDs: []string{third.ID, first.ID}}
+	require.NoError(t, repo.Create(ctx, collection))
+	require.NotEmpty(t, collection.ID)
+
+	stored, err := repo.GetByID(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{third.ID, first.ID}, stored.ContentIDs)
+
+	collection.ContentIDs = []string{first.ID, second.ID, third.ID}
+	require.NoError(t, repo.Update(ctx, collection))
+
+	blocked := true
+	_, err = contents.UpdateCuration(ctx, second.ID, domain.CurationPatch{Blocked: &blocked})
+	require.NoError(t, err)
+
+	items, err := repo.Contents(ctx, collection.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 2, "blocked contents are left out")
+	assert.Equal(t, first.ID, items[0].ID)
+	assert.Equal(t, third.ID, items[1].ID)
+
+	collection.ContentIDs = []string{first.ID, "00000000-0000-0000-0000-000000000000"}
+	assert.ErrorIs(t, repo.Update(ctx, collection), domain.ErrInvalidParams)
+	stored, err = repo.GetByID(ctx, collection.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.ContentIDs, 3, "a failed update leaves the contents alone")
+
+	require.NoError(t, repo.Delete(ctx, collection.ID))
+	_, err = repo.GetByID(ctx, collection.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, collection), domain.ErrNotFound)
+}
+
+func TestAnalyticsAggregateCTR_CountsWithinWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewAnalyticsRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	contentID := "00000000-0000-0000-0000-000000000001"
+
+	event := func(typ domain.AnalyticsEventType, occurred time.Time) *domain.AnalyticsEvent {
+		return &domain.AnalyticsEvent{Type: typ, ContentID: contentID, Position: 1, OccurredAt: occurred}
+	}
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.AnalyticsEvent{
+		event(domain.AnalyticsEventImpression, now),
+		event(domain.AnalyticsEventImpression, now),
+		event(domain.AnalyticsEventClick, now),
+		event(domain.AnalyticsEventClick, now.Add(-48*time.Hour)),
+	}))
+
+	ctrs, err := repo.AggregateCTR(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ContentCTR{{ContentID: contentID, Impressions: 2, Clicks: 1}}, ctrs)
+}
+
+func TestSyncRuns_FailuresPerDayAndPruning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSyncRunRepository(db)
+	ctx := context.Background()
+
+	today := domain.TruncateDay(time.Now())
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.SyncRun{
+		{Provider: "provider_a", Count: 10, Duration: time.Second, FinishedAt: today.Add(time.Hour)},
+		{Provider: "provider_b", Error: "timeout", FinishedAt: today.Add(time.Hour)},
+		{Provider: "provider_b", Error: "timeout", FinishedAt: today.Add(2 * time.Hour)},
+		{Provider: "provider_a", Error: "timeout", FinishedAt: today.AddDate(0, 0, -100)},
+	}))
+
+	failures, err := repo.FailuresPerDay(ctx, today)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "provider_b", failures[0].Provider)
+	assert.Equal(t, int64(2), failures[0].Count)
+	assert.True(t, failures[0].Day.Equal(today))
+
+	deleted, err := repo.DeleteBefore(ctx, today.AddDate(0, 0, -90))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	runs, err := repo.ListSince(ctx, today.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, time.Second, runs[0].Duration)
+}
+
+func TestDeadLetters_CreateAndPrune(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewDeadLetterRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	letters := []*domain.DeadLetter{
+		{
+			Provider:   "provider_a",
+			ExternalID: "a-1",
+			Payload:    `{"id": "a-1", "view_count": 3}`,
+			Errors:     domain.ValidationErrors{{Field: "view_count", Tag: "unknown", Message: "view_count is not in the schema"}},
+			CreatedAt:  now,
+		},
+		{Provider: "provider_b", Payload: "<item/>", CreatedAt: now.AddDate(0, 0, -40)},
+	}
+	require.NoError(t, repo.CreateBatch(ctx, letters))
+	assert.NotEmpty(t, letters[0].ID)
+
+	var stored DeadLetterModel
+	require.NoError(t, db.First(&stored, "id = ?", letters[0].ID).Error)
+	assert.JSONEq(t, `[{"field": "view_count", "tag": "unknown", "message": "view_count is not in the schema"}]`, stored.Errors)
+
+	deleted, err := repo.DeleteBefore(ctx, now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.DeleteByExternalID(ctx, "provider_b", "a-1")
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "only the letters of the provider's item are removed")
+	deleted, err = repo.DeleteByExternalID(ctx, "provider_a", "a-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestSearchQueries_TopAndZeroResults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSearchQueryRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.SearchQuery{
+		{Query: "golang", Total: 4, Latency: 2 * time.Millisecond, OccurredAt: now.Add(-time.Hour)},
+		{Query: "golang", Total: 6, Latency: 4 * time.Millisecond, OccurredAt: now},
+		{Query: "rust", Total: 0, Latency: time.Millisecond, OccurredAt: now},
+		{Query: "elixir", Total: 0, Latency: time.Millisecond, OccurredAt: now.Add(-48 * time.Hour)},
+	}))
+
+	top, err := repo.TopQueries(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, domain.QueryStat{Query: "golang", Searches: 2, AvgResults: 5, AvgLatency: 3 * time.Millisecond, LastSearchedAt: now}, top[0])
+	assert.Equal(t, "rust", top[1].Query)
+
+	zero, err := repo.TopZeroResultQueries(ctx, now.Add(-72*time.Hour), 1)
+	require.NoError(t, err)
+	require.Len(t, zero, 1)
+	assert.Equal(t, "elixir", zero[0].Query) // Ties ordered by query
+
+	deleted, err := repo.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestSearchTerms_RebuildAndList(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// The terms are read from prefix_vector, which AutoMigrate doesn't create
+	for _, m := range migrations.Migrations() {
+		if m.ID == "017_add_prefix_search" {
+			require.NoError(t, m.Migrate(db))
+		}
+	}
+
+	repo := NewRepository(db)
+	terms := NewSearchTermRepository(db)
+	ctx := context.Background()
+
+	golang := createTestContent("provider_a", "ext_golang")
+	golang.Title = "Golang Tutorial 2024"
+	golang.Tags = []string{"golang", "go-kit"}
+	tutorial := createTestContent("provider_a", "ext_tutorial")
+	tutorial.Title = "Docker Tutorial"
+	tutorial.Tags = nil
+	blocked := createTestContent("provider_b", "ext_blocked")
+	blocked.Title = "Hidden Tutorial"
+	blocked.Tags = nil
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{golang, tutorial, blocked}))
+	yes := true
+	_, err := repo.UpdateCuration(ctx, blocked.ID, domain.CurationPatch{Blocked: &yes})
+	require.NoError(t, err)
+
+	stored, err := terms.RebuildTerms(ctx)
+	require.NoError(t, err)
+
+	// Counted once per content; numbers, hyphenated words and blocked contents left out
+	list, err := terms.ListTerms(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Term{
+		{Term: "docker", Frequency: 1},
+		{Term: "go", Frequency: 1},
+		{Term: "golang", Frequency: 1},
+		{Term: "kit", Frequency: 1},
+		{Term: "tutorial", Frequency: 2},
+	}, list)
+	assert.Equal(t, int64(len(list)), stored)
+
+	// A rebuild replaces the terms
+	_, err = repo.UpdateCuration(ctx, golang.ID, domain.CurationPatch{Blocked: &yes})
+	require.NoError(t, err)
+	_, err = terms.RebuildTerms(ctx)
+	require.NoError(t, err)
+
+	list, err = terms.ListTerms(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Term{{Term: "docker", Frequency: 1}, {Term: "tutorial", Frequency: 1}}, list)
+}
+
+func TestSearch_TextMatches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	low := createTestContent("provider_a", "ext_low")
+	high := createTestContent("provider_a", "ext_high")
+	video := createTestContent("provider_b", "ext_video")
+	video.Type = domain.ContentTypeVideo
+	other := createTestContent("provider_b", "ext_other")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{low, high, video, other}))
+
+	// The matches replace the text query: ranked by their relevance, still filtered in SQL
+	params := domain.DefaultSearchParams()
+	params.Query = "anything"
+	params.SortBy = domain.SortFieldRelevance
+	params.Scoring = domain.ScoringText
+	params.TextMatches = []domain.TextMatch{{ContentID: low.ID, Relevance: 0.2}, {ContentID: high.ID, Relevance: 0.9}, {ContentID: video.ID, Relevance: 0.5}}
+
+	result, err := repo.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 3)
+	assert.Equal(t, []string{high.ID, video.ID, low.ID}, []string{result.Contents[0].ID, result.Contents[1].ID, result.Contents[2].ID})
+
+	params.Type = domain.ContentTypeArticle
+	count, err := repo.Count(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	var iterated int
+	require.NoError(t, repo.Iterate(ctx, params, 1, func(batch []*domain.Content) error {
+		iterated += len(batch)
+
+		return nil
+	}))
+	assert.Equal(t, 2, iterated)
+
+	// No matches, no contents
+	params.TextMatches = []domain.TextMatch{}
+	result, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	assert.Empty(t, result.Contents)
+}
+
+func TestOutbox_WrittenWithChangesAndRelayed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db).WithOutbox()
+	outbox := NewOutboxRepository(db)
+	ctx := context.Background()
+
+	a1, a2, b1 := createTestContent("provider_a", "ext_1"), createTestContent("provider_a", "ext_2"), createTestContent("provider_b", "ext_1")
+	require.NoError(t, repo.BulkUpsert(ctx, []*domain.Content{a1, b1, a2}))
+	pinned := true
+	_, err := repo.UpdateCuration(ctx, a1.ID, domain.CurationPatch{Pinned: &pinned})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, b1.ID))
+
+	// Failed changes store no event
+	require.ErrorIs(t, repo.Delete(ctx, b1.ID), domain.ErrNotFound)
+
+	// A failed relay keeps the events
+	_, err = outbox.Relay(ctx, 10, func([]*domain.OutboxEvent) error { return errors.New("handler failed") })
+	require.Error(t, err)
+
+	var events []domain.Event
+	relayed, err := outbox.Relay(ctx, 10, func(stored []*domain.OutboxEvent) error {
+		for _, s := range stored {
+			e, err := s.Event()
+			require.NoError(t, err)
+			events = append(events, e)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, relayed)
+
+	// One upsert event per provider, in order
+	require.Len(t, events, 4)
+	upsertA, upsertB := events[0].(domain.ContentUpserted), events[1].(domain.ContentUpserted)
+	assert.Equal(t, "provider_a", upsertA.Provider)
+	assert.Equal(t, []string{a1.ID, a2.ID}, []string{upsertA.Contents[0].ID, upsertA.Contents[1].ID})
+	assert.Equal(t, "provider_b", upsertB.Provider)
+	assert.Equal(t, b1.ID, upsertB.Contents[0].ID)
+	assert.True(t, events[2].(domain.ContentCurated).Content.Pinned)
+	assert.Equal(t, domain.ContentDeleted{ID: b1.ID}, events[3])
+
+	// Relayed events are removed
+	relayed, err = outbox.Relay(ctx, 10, func([]*domain.OutboxEvent) error { return nil })
+	require.NoError(t, err)
+	assert.Zero(t, relayed)
+
+	// Without the outbox, changes store no event
+	require.NoError(t, NewRepository(db).Delete(ctx, a2.ID))
+	var count int64
+	require.NoError(t, db.Model(&OutboxEventModel{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestQuotaRepository_CountsPerKeyAndMonth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewQuotaRepository(db)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := repo.Increment(ctx, "partner-a", "2026-10")
+		require.NoError(t, err)
+	}
+	n, err := repo.Increment(ctx, "partner-a", "2026-10")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = repo.Increment(ctx, "partner-a", "2026-11")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "each month starts over")
+
+	counts, err := repo.Counts(ctx, "2026-10", []string{"partner-a", "partner-b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"partner-a": 3}, counts)
+}
+
+func TestRepository_WarmUpPreparesStatements(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// AutoMigrate doesn't create the text search columns and triggers
+	for _, m := range migrations.Migrations() {
+		if m.ID == "002_add_fts_support" || m.ID == "017_add_prefix_search" {
+			require.NoError(t, m.Migrate(db))
+		}
+	}
+
+	ctx := context.Background()
+	prepared, err := NewRepository(db).WarmUp(ctx, 2, true)
+	require.NoError(t, err)
+	assert.Zero(t, prepared, "statements aren't cached without PrepareStmt")
+
+	repo := NewRepository(db.Session(&gorm.Session{PrepareStmt: true}))
+	prepared, err = repo.WarmUp(ctx, 2, true)
+	require.NoError(t, err)
+	assert.Positive(t, prepared)
+
+	// Searches of the warmed up shapes reuse the cached statements
+	params := domain.DefaultSearchParams()
+	params.Query = "kubernetes"
+	params.SortBy = domain.SortFieldRelevance
+	params.HideUnpublished = true
+	_, err = repo.Search(ctx, params)
+	require.NoError(t, err)
+	after, err := repo.WarmUp(ctx, 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, prepared, after)
+}
+
+func TestAuditRepository_DeleteBefore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	require.NoError(t, db.AutoMigrate(&AuditLogModel{}))
+
+	repo := NewAuditRepository(db)
+	ctx := context.Background()
+
+	old := &domain.AuditEntry{Actor: "user-1", Method: "DELETE", Path: "/api/v1/admin/cache", Route: "/api/v1/admin/cache", Status: 204}
+	recent := &domain.AuditEntry{Actor: "user-1", Method: "POST", Path: "/api/v1/admin/sync", Route: "/api/v1/admin/sync", Status: 202}
+	require.NoError(t, repo.Create(ctx, old))
+	require.NoError(t, repo.Create(ctx, recent))
+	require.NoError(t, db.Model(&AuditLogModel{}).Where("id = ?", old.ID).Update("created_at", time.Now().AddDate(0, 0, -100)).Error)
+
+	deleted, err := repo.DeleteBefore(ctx, time.Now().AddDate(0, 0, -90))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	entries, total, err := repo.List(ctx, domain.AuditFilter{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, recent.ID, entries[0].ID)
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// SearchQueryModel is the GORM model for the search_queries table.
+type SearchQueryModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Query      string    `gorm:"type:varchar(200);not null"`
+	Total      int64     `gorm:"not null;default:0"`
+	LatencyUs  int64     `gorm:"column:latency_us;not null;default:0"`
+	OccurredAt time.Time `gorm:"not null;index:idx_search_queries_occurred_at"`
+}
+
+// TableName returns the table name for SearchQueryModel.
+func (SearchQueryModel) TableName() string {
+	return "search_queries"
+}
+
+// SearchQueryRepository implements domain.SearchQueryRepository using PostgreSQL.
+type SearchQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchQueryRepository creates a new PostgreSQL search query repository.
+func NewSearchQueryRepository(db *gorm.DB) *SearchQueryRepository {
+	return &SearchQueryRepository{db: db}
+}
+
+// CreateBatch stores searches in one statement.
+func (r *SearchQueryRepository) CreateBatch(ctx context.Context, queries []*domain.SearchQuery) error {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	models := make([]SearchQueryModel, len(queries))
+	for i, q := range queries {
+		models[i] = SearchQueryModel{
+			Query:      q.Query,
+			Total:      q.Total,
+			LatencyUs:  q.Latency.Microseconds(),
+			OccurredAt: q.OccurredAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("creating search queries: %w", wrapTimeout(err))
+	}
+
+	return nil
+}
+
+// TopQueries returns up to limit queries searched at or after since, most frequent first.
+func (r *SearchQueryRepository) TopQueries(ctx context.Context, since time.Time, limit int) ([]domain.QueryStat, error) {
+	return r.topQueries(ctx, r.db.Where("occurred_at >= ?", since), limit)
+}
+
+// TopZeroResultQueries returns up to limit queries searched at or after since that
+// matched no content, most frequent first.
+func (r *SearchQueryRepository) TopZeroResultQueries(ctx context.Context, since time.Time, limit int) ([]domain.QueryStat, error) {
+	return r.topQueries(ctx, r.db.Where("occurred_at >= ? AND total = 0", since), limit)
+}
+
+// topQueries aggregates the searches selected by scope per query.
+func (r *SearchQueryRepository) topQueries(ctx context.Context, scope *gorm.DB, limit int) ([]domain.QueryStat, error) {
+	var rows []struct {
+		Query          string
+		Searches       int64
+		AvgResults     float64
+		AvgLatencyUs   float64
+		LastSearchedAt time.Time
+	}
+
+	err := scope.WithContext(ctx).Model(&SearchQueryModel{}).
+		Select("query, COUNT(*) AS searches, AVG(total)::float8 AS avg_results, AVG(latency_us)::float8 AS avg_latency_us, MAX(occurred_at) AS last_searched_at").
+		Group("query").
+		Order("searches DESC, query").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("aggregating search queries: %w", wrapTimeout(err))
+	}
+
+	stats := make([]domain.QueryStat, len(rows))
+	for i, row := range rows {
+		stats[i] = domain.QueryStat{
+			Query:          row.Query,
+			Searches:       row.Searches,
+			AvgResults:     row.AvgResults,
+			AvgLatency:     time.Duration(row.AvgLatencyUs * float64(time.Microsecond)),
+			LastSearchedAt: row.LastSearchedAt,
+		}
+	}
+
+	return stats, nil
+}
+
+// DeleteBefore removes the searches that occurred before before.
+func (r *SearchQueryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("occurred_at < ?", before).Delete(&SearchQueryModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("deleting search queries: %w", wrapTimeout(result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
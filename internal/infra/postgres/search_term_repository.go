@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// SearchTermModel is the GORM model for the search_terms table.
+type SearchTermModel struct {
+	Term      string    `gorm:"type:varchar(100);primaryKey"`
+	Frequency int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for SearchTermModel.
+func (SearchTermModel) TableName() string {
+	return "search_terms"
+}
+
+// SearchTermRepository implements domain.SearchTermRepository using PostgreSQL.
+type SearchTermRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchTermRepository creates a new PostgreSQL search term repository.
+func NewSearchTermRepository(db *gorm.DB) *SearchTermRepository {
+	return &SearchTermRepository{db: db}
+}
+
+// RebuildTerms replaces the stored terms with the words of the titles and tags of the
+// contents that aren't blocked, in one transaction. Returns the number of terms stored.
+//
+// The words are read from prefix_vector, which holds them unstemmed, with ts_stat
+// counting the contents having each. Only words of letters and digits, with at least
+// one letter, are kept: the 'simple' parser also emits hyphenated words, URLs and
+// numbers, which are no use for suggestions.
+func (r *SearchTermRepository) RebuildTerms(ctx context.Context) (int64, error) {
+	var stored int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM search_terms").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`
+			INSERT INTO search_terms (term, frequency, updated_at)
+			SELECT word, ndoc, NOW()
+			FROM ts_stat('SELECT prefix_vector FROM contents WHERE NOT blocked AND prefix_vector IS NOT NULL')
+			WHERE char_length(word) BETWEEN 2 AND 100
+				AND word ~ '^[[:alnum:]]+$'
+				AND word ~ '[[:alpha:]]'
+		`)
+		stored = result.RowsAffected
+
+		return result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("rebuilding search terms: %w", wrapTimeout(err))
+	}
+
+	return stored, nil
+}
+
+// ListTerms returns every stored term.
+func (r *SearchTermRepository) ListTerms(ctx context.Context) ([]domain.Term, error) {
+	var models []SearchTermModel
+	if err := r.db.WithContext(ctx).Order("term").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("listing search terms: %w", wrapTimeout(err))
+	}
+
+	terms := make([]domain.Term, len(models))
+	for i, m := range models {
+		terms[i] = domain.Term{Term: m.Term, Frequency: m.Frequency}
+	}
+
+	return terms, nil
+}
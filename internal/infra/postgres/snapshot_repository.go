@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"search-engine-service/internal/domain"
+)
+
+// snapshotColumns are the contents columns a snapshot restores, IDs and the
+// columns syncs leave alone included. The search vectors are set by triggers.
+var snapshotColumns = []string{
+	"id", "provider_id", "external_id", "title", "type", "tags", "url", "thumbnail_url", "author",
+	"views", "likes", "duration", "duration_seconds", "listens", "reading_time", "reactions", "comments",
+	"score", "ctr_boost", "pinned", "blocked", "boost", "published_at", "created_at", "updated_at", "content_hash",
+}
+
+// SnapshotRepository implements domain.SnapshotRepository using PostgreSQL.
+type SnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewSnapshotRepository creates a new PostgreSQL snapshot repository.
+func NewSnapshotRepository(db *gorm.DB) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+// DumpSnapshot writes the provider sync times, then every content in ID order, from
+// a read-only repeatable read transaction, so writes made meanwhile are left out.
+func (r *SnapshotRepository) DumpSnapshot(ctx context.Context, batchSize int, w domain.SnapshotWriter) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var syncs []ProviderSyncModel
+		if err := tx.Find(&syncs).Error; err != nil {
+			return fmt.Errorf("querying provider syncs: %w", wrapTimeout(err))
+		}
+		times := make(map[string]time.Time, len(syncs))
+		for _, s := range syncs {
+			times[s.ProviderID] = s.SyncedAt
+		}
+		if err := w.WriteSyncTimes(times); err != nil {
+			return err
+		}
+
+		lastID := ""
+		for {
+			query := tx.Model(&ContentModel{})
+			if lastID != "" {
+				query = query.Where("id > ?", lastID)
+			}
+			var models []ContentModel
+			if err := query.Order("id ASC").Limit(batchSize).Find(&models).Error; err != nil {
+				return fmt.Errorf("reading contents: %w", wrapTimeout(err))
+			}
+			if len(models) == 0 {
+				return nil
+			}
+
+			batch := make([]*domain.SnapshotContent, len(models))
+			for i := range models {
+				batch[i] = &domain.SnapshotContent{Content: *models[i].ToDomain(), CTRBoost: models[i].CTRBoost}
+			}
+			if err := w.WriteContents(batch); err != nil {
+				return err
+			}
+
+			if len(models) < batchSize {
+				return nil
+			}
+			lastID = models[len(models)-1].ID
+		}
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// RestoreSnapshot copies the contents read from rd into the contents table with COPY,
+// and stores the provider sync times, in one transaction. The tables are locked
+// against writes meanwhile, so no sync can slip contents in; restoring fails with
+// domain.ErrConflict if contents has rows. No outbox events are written.
+func (r *SnapshotRepository) RestoreSnapshot(ctx context.Context, rd domain.SnapshotReader) (int64, error) {
+	var restored int64
+	err := r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		sqlConn, ok := conn.Statement.ConnPool.(*sql.Conn)
+		if !ok {
+			return fmt.Errorf("restoring snapshot: unexpected connection %T", conn.Statement.ConnPool)
+		}
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("LOCK TABLE contents, provider_syncs IN EXCLUSIVE MODE").Error; err != nil {
+				return fmt.Errorf("locking contents: %w", wrapTimeout(err))
+			}
+			var exists bool
+			if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM contents)").Scan(&exists).Error; err != nil {
+				return fmt.Errorf("checking contents: %w", wrapTimeout(err))
+			}
+			if exists {
+				return fmt.Errorf("%w: snapshots are only restored into an empty contents table", domain.ErrConflict)
+			}
+
+			times, err := rd.ReadSyncTimes()
+			if err != nil {
+				return err
+			}
+			if err := restoreSyncTimes(tx, times); err != nil {
+				return err
+			}
+
+			for {
+				batch, err := rd.ReadContents()
+				if err != nil {
+					return err
+				}
+				if len(batch) == 0 {
+					return nil
+				}
+				if err := copyRows(ctx, sqlConn, "contents", snapshotColumns, snapshotRows(batch)); err != nil {
+					return fmt.Errorf("copying contents: %w", wrapTimeout(err))
+				}
+				restored += int64(len(batch))
+			}
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
+
+// restoreSyncTimes replaces the sync times of the providers in times.
+func restoreSyncTimes(tx *gorm.DB, times map[string]time.Time) error {
+	if len(times) == 0 {
+		return nil
+	}
+
+	syncs := make([]ProviderSyncModel, 0, len(times))
+	for provider, at := range times {
+		syncs = append(syncs, ProviderSyncModel{ProviderID: provider, SyncedAt: at})
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"synced_at"}),
+	}).Create(&syncs).Error
+	if err != nil {
+		return fmt.Errorf("restoring provider syncs: %w", wrapTimeout(err))
+	}
+
+	return nil
+}
+
+// snapshotRows returns the values of snapshotColumns for each content of batch.
+func snapshotRows(batch []*domain.SnapshotContent) [][]any {
+	rows := make([][]any, len(batch))
+	for i, c := range batch {
+		rows[i] = []any{
+			c.ID, c.ProviderID, c.ExternalID, c.Title, string(c.Type), c.Tags, c.URL, c.ThumbnailURL, c.Author,
+			c.Views, c.Likes, c.Duration, c.DurationSeconds, c.Listens, c.ReadingTime, c.Reactions, c.Comments,
+			c.Score, c.CTRBoost, c.Pinned, c.Blocked, c.Boost, c.PublishedAt, c.CreatedAt, c.UpdatedAt, c.Hash,
+		}
+	}
+
+	return rows
+}
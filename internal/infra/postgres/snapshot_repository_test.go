@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+// memorySnapshot implements domain.SnapshotWriter and domain.SnapshotReader, handing
+// back what was written as a single batch.
+type memorySnapshot struct {
+	times    map[string]time.Time
+	contents []*domain.SnapshotContent
+	read     bool
+}
+
+func (m *memorySnapshot) WriteSyncTimes(times map[string]time.Time) error {
+	m.times = times
+
+	return nil
+}
+
+func (m *memorySnapshot) WriteContents(batch []*domain.SnapshotContent) error {
+	m.contents = append(m.contents, batch...)
+
+	return nil
+}
+
+func (m *memorySnapshot) ReadSyncTimes() (map[string]time.Time, error) {
+	return m.times, nil
+}
+
+func (m *memorySnapshot) ReadContents() ([]*domain.SnapshotContent, error) {
+	if m.read {
+		return nil, nil
+	}
+	m.read = true
+
+	return m.contents, nil
+}
+
+// TestSnapshotRepository_DumpAndRestore verifies that a restore brings back every
+// content as dumped, IDs, curation and CTR boosts included
+func TestSnapshotRepository_DumpAndRestore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	snapshots := NewSnapshotRepository(db)
+	ctx := context.Background()
+
+	contents := []*domain.Content{createTestContent("provider_a", "ext_1"), createTestContent("provider_a", "ext_2")}
+	require.NoError(t, repo.BulkUpsert(ctx, contents))
+	blocked := true
+	_, err := repo.UpdateCuration(ctx, contents[1].ID, domain.CurationPatch{Blocked: &blocked})
+	require.NoError(t, err)
+	require.NoError(t, repo.ReplaceCTRBoosts(ctx, map[string]float64{contents[0].ID: 3}))
+
+	dump := &memorySnapshot{}
+	require.NoError(t, snapshots.DumpSnapshot(ctx, 1, dump))
+	require.Len(t, dump.contents, 2, "blocked contents are dumped too")
+	assert.Contains(t, dump.times, "provider_a")
+
+	_, err = snapshots.RestoreSnapshot(ctx, dump)
+	require.ErrorIs(t, err, domain.ErrConflict)
+
+	require.NoError(t, db.Exec("DELETE FROM contents").Error)
+	dump.read = false
+	restored, err := snapshots.RestoreSnapshot(ctx, dump)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), restored)
+
+	again := &memorySnapshot{}
+	require.NoError(t, snapshots.DumpSnapshot(ctx, 10, again))
+	require.Len(t, again.contents, 2)
+	for i, c := range again.contents {
+		assert.Equal(t, dump.contents[i].ID, c.ID)
+		assert.Equal(t, dump.contents[i].Blocked, c.Blocked)
+		assert.Equal(t, dump.contents[i].CTRBoost, c.CTRBoost)
+		assert.Equal(t, dump.contents[i].Score, c.Score)
+	}
+}
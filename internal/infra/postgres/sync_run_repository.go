@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// SyncRunModel is the GORM model for the sync_runs table.
+type SyncRunModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Provider   string    `gorm:"type:varchar(100);not null"`
+	Count      int       `gorm:"not null;default:0"`
+	DurationMs int64     `gorm:"not null;default:0"`
+	Error      string    `gorm:"type:text;not null;default:''"`
+	FinishedAt time.Time `gorm:"not null;index:idx_sync_runs_finished_at"`
+}
+
+// TableName returns the table name for SyncRunModel.
+func (SyncRunModel) TableName() string {
+	return "sync_runs"
+}
+
+// toDomain converts SyncRunModel to domain.SyncRun.
+func (m *SyncRunModel) toDomain() *domain.SyncRun {
+	return &domain.SyncRun{
+		ID:         m.ID,
+		Provider:   m.Provider,
+		Count:      m.Count,
+		Duration:   time.Duration(m.DurationMs) * time.Millisecond,
+		Error:      m.Error,
+		FinishedAt: m.FinishedAt,
+	}
+}
+
+// SyncRunRepository implements domain.SyncRunRepository using PostgreSQL.
+type SyncRunRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncRunRepository creates a new PostgreSQL sync run repository.
+func NewSyncRunRepository(db *gorm.DB) *SyncRunRepository {
+	return &SyncRunRepository{db: db}
+}
+
+// CreateBatch stores runs in one statement and sets their IDs.
+func (r *SyncRunRepository) CreateBatch(ctx context.Context, runs []*domain.SyncRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	models := make([]SyncRunModel, len(runs))
+	for i, run := range runs {
+		models[i] = SyncRunModel{
+			Provider:   run.Provider,
+			Count:      run.Count,
+			DurationMs: run.Duration.Milliseconds(),
+			Error:      run.Error,
+			FinishedAt: run.FinishedAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("creating sync runs: %w", wrapTimeout(err))
+	}
+
+	for i, run := range runs {
+		run.ID = models[i].ID
+	}
+
+	return nil
+}
+
+// ListSince returns the runs finished at or after since, oldest first.
+func (r *SyncRunRepository) ListSince(ctx context.Context, since time.Time) ([]*domain.SyncRun, error) {
+	var models []SyncRunModel
+	err := r.db.WithContext(ctx).
+		Where("finished_at >= ?", since).
+		Order("finished_at ASC, provider ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("listing sync runs: %w", wrapTimeout(err))
+	}
+
+	runs := make([]*domain.SyncRun, len(models))
+	for i := range models {
+		runs[i] = models[i].toDomain()
+	}
+
+	return runs, nil
+}
+
+// FailuresPerDay counts the failed runs per provider and UTC day for runs finished at or after since.
+func (r *SyncRunRepository) FailuresPerDay(ctx context.Context, since time.Time) ([]domain.ProviderDailyCount, error) {
+	var counts []domain.ProviderDailyCount
+	err := r.db.WithContext(ctx).Model(&SyncRunModel{}).
+		Select("provider, date_trunc('day', finished_at) AS day, COUNT(*) AS count").
+		Where("finished_at >= ? AND error <> ''", since).
+		Group("provider, day").
+		Order("day, provider").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("counting sync failures: %w", wrapTimeout(err))
+	}
+
+	return counts, nil
+}
+
+// DeleteBefore removes the runs finished before before.
+func (r *SyncRunRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("finished_at < ?", before).Delete(&SyncRunModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("deleting sync runs: %w", wrapTimeout(result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
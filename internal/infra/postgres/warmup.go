@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// warmUpSearches are the shapes of the searches most requests make: the default
+// listing, relevance and prefix text searches, and the chronological sort. Their
+// terms don't matter, only the statements they build.
+var warmUpSearches = []domain.SearchParams{
+	{SortBy: domain.SortFieldScore, SortOrder: domain.SortOrderDesc},
+	{Query: "warm up", SortBy: domain.SortFieldRelevance, SortOrder: domain.SortOrderDesc},
+	{Query: "warm", Mode: domain.SearchModePrefix, SortBy: domain.SortFieldRelevance, SortOrder: domain.SortOrderDesc},
+	{SortBy: domain.SortFieldPublishedAt, SortOrder: domain.SortOrderDesc},
+}
+
+// warmUpTagLimit is the tag limit of the warmed up facets; it's bound, so any value
+// prepares the same statement.
+const warmUpTagLimit = 20
+
+// WarmUp runs the hot search statements (searches, their facets and content lookups)
+// once on each of conns connections, so the first requests after a start don't pay
+// for preparing and planning them. hideUnpublished must match the searches of the
+// future publish policy, whose statements differ. It returns the number of statements
+// in the prepared statement cache afterwards, 0 when the connection doesn't cache them.
+//
+// Connections are recycled after the pool's max lifetime and prepare the statements
+// again on first use; warming up only smooths the start.
+func (r *Repository) WarmUp(ctx context.Context, conns int, hideUnpublished bool) (int, error) {
+	// Concurrently, so each goroutine holds its own pooled connection
+	errs := make([]error, max(1, conns))
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = r.warmUpConn(ctx, hideUnpublished)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+
+	prepared, ok := r.db.ConnPool.(*gorm.PreparedStmtDB)
+	if !ok {
+		return 0, nil
+	}
+
+	return len(prepared.Stmts.Keys()), nil
+}
+
+// warmUpConn runs the statements of warmUpSearches one after the other.
+func (r *Repository) warmUpConn(ctx context.Context, hideUnpublished bool) error {
+	for _, params := range warmUpSearches {
+		params.HideUnpublished = hideUnpublished
+		if _, err := r.Search(ctx, params); err != nil {
+			return fmt.Errorf("warming up search: %w", err)
+		}
+		if _, err := r.Facets(ctx, params, warmUpTagLimit); err != nil {
+			return fmt.Errorf("warming up facets: %w", err)
+		}
+	}
+	// The nil UUID is never assigned to a content
+	if _, err := r.GetByID(ctx, "00000000-0000-0000-0000-000000000000"); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("warming up content lookup: %w", err)
+	}
+
+	return nil
+}
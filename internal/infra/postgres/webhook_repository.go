@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"search-engine-service/internal/domain"
+)
+
+// WebhookModel is the GORM model for the webhooks table.
+type WebhookModel struct {
+	ID         string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	URL        string         `gorm:"type:varchar(2048);not null"`
+	Secret     string         `gorm:"type:varchar(256);not null"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null"`
+	Active     bool           `gorm:"not null;default:true"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for WebhookModel.
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+// ToDomain converts WebhookModel to domain.Webhook.
+func (m *WebhookModel) ToDomain() *domain.Webhook {
+	return &domain.Webhook{
+		ID:         m.ID,
+		URL:        m.URL,
+		Secret:     m.Secret,
+		EventTypes: m.EventTypes,
+		Active:     m.Active,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// WebhookRepository implements domain.WebhookRepository using PostgreSQL.
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new PostgreSQL webhook repository.
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create stores a new webhook and sets its ID and timestamps.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	model := &WebhookModel{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: webhook.EventTypes,
+		Active:     webhook.Active,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("creating webhook: %w", wrapTimeout(err))
+	}
+
+	webhook.ID = model.ID
+	webhook.CreatedAt = model.CreatedAt
+	webhook.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+// List returns all webhooks ordered by creation time.
+func (r *WebhookRepository) List(ctx context.Context) ([]*domain.Webhook, error) {
+	return r.find(r.db.WithContext(ctx))
+}
+
+// ListActive returns webhooks that are active.
+func (r *WebhookRepository) ListActive(ctx context.Context) ([]*domain.Webhook, error) {
+	return r.find(r.db.WithContext(ctx).Where("active = ?", true))
+}
+
+// Delete removes a webhook by ID. Returns domain.ErrNotFound if it didn't exist.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&WebhookModel{})
+	if result.Error != nil {
+		return fmt.Errorf("deleting webhook: %w", wrapTimeout(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// find runs the query and converts the models to domain webhooks.
+func (r *WebhookRepository) find(query *gorm.DB) ([]*domain.Webhook, error) {
+	var models []WebhookModel
+	if err := query.Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("listing webhooks: %w", wrapTimeout(err))
+	}
+
+	webhooks := make([]*domain.Webhook, len(models))
+	for i := range models {
+		webhooks[i] = models[i].ToDomain()
+	}
+
+	return webhooks, nil
+}
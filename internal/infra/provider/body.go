@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"search-engine-service/internal/domain"
+)
+
+// acceptEncoding lists the content encodings GetBody decompresses.
+const acceptEncoding = "gzip, deflate"
+
+// ErrBodyTooLarge means a provider's response exceeded its client's MaxBodySize.
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// Body is a provider response body returned by GetBody, decompressed as it is
+// read.
+type Body struct {
+	io.ReadCloser
+	ContentType string // Content-Type of the response, e.g. to find the charset of the body
+}
+
+// decompress returns body decoded per its Content-Encoding, closing body when
+// closed. Providers sending "deflate" disagree on whether it is zlib-wrapped, so
+// both are accepted.
+func decompress(body io.ReadCloser, encoding string) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("reading gzip response: %w", err)
+		}
+
+		return &decodedBody{Reader: r, closers: []io.Closer{r, body}}, nil
+	case "deflate":
+		br := bufio.NewReader(body)
+		if header, err := br.Peek(2); err == nil && isZlibHeader(header) {
+			r, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, fmt.Errorf("reading deflate response: %w", err)
+			}
+
+			return &decodedBody{Reader: r, closers: []io.Closer{r, body}}, nil
+		}
+		r := flate.NewReader(br)
+
+		return &decodedBody{Reader: r, closers: []io.Closer{r, body}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// isZlibHeader reports whether header starts a zlib stream (RFC 1950): deflate
+// compression method and a check value making it a multiple of 31.
+func isZlibHeader(header []byte) bool {
+	return header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// decodedBody reads a decompressed body and closes the decompressor and the body.
+type decodedBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes the decompressor, then the body.
+func (b *decodedBody) Close() error {
+	var errs []error
+	for _, c := range b.closers {
+		errs = append(errs, c.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// limitedBody fails reads with ErrBodyTooLarge once more than limit bytes are
+// sent. Unlike io.LimitReader, which would end the body early, the decoder
+// reading it then reports the limit rather than a truncated document.
+type limitedBody struct {
+	io.ReadCloser
+	name      string
+	limit     int64
+	remaining int64
+}
+
+// Read reads at most the remaining bytes allowed, then checks whether the body
+// ends there.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining > 0 {
+		if int64(len(p)) > b.remaining {
+			p = p[:b.remaining]
+		}
+		n, err := b.ReadCloser.Read(p)
+		b.remaining -= int64(n)
+
+		return n, err
+	}
+
+	var probe [1]byte
+	n, err := b.ReadCloser.Read(probe[:])
+	if n > 0 {
+		return 0, fmt.Errorf("%w: %w: %s sent more than %d bytes",
+			domain.ErrProviderUnavailable, ErrBodyTooLarge, b.name, b.limit)
+	}
+
+	return 0, err
+}
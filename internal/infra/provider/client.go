@@ -2,18 +2,57 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sony/gobreaker/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/logger"
 )
 
+// headerRequestID forwards the ID of the request that triggered a call (e.g. a manual sync)
+// so providers can correlate their logs with ours.
+const headerRequestID = "X-Request-ID"
+
 // ClientConfig holds configuration for a provider client.
 type ClientConfig struct {
-	BaseURL string
-	Timeout time.Duration
-	Retry   RetryConfig
-	CB      CBConfig
+	Name     string // Provider ID stored with its contents; empty uses the client's default
+	Endpoint string // Content path; empty uses the client's default
+	BaseURL  string
+	APIKey   string // Sent as a bearer token when set
+	Timeout  time.Duration
+	Retry    RetryConfig
+	CB       CBConfig
+
+	// MaxBodySize is the largest response body read, in bytes (0 or less disables
+	// the limit), so a misbehaving provider can't exhaust memory while it's decoded.
+	MaxBodySize int64
+
+	// Strict checks each item against the client's Schema; items breaking it are
+	// handed over with their errors (see domain.SchemaCheckedItem) rather than decoded.
+	Strict bool
+
+	Recorder *Recorder // Optional recorder of the provider's responses (can be nil)
+	Replay   string    // Response recorded by a Recorder read instead of calling the provider, if set
+}
+
+// BodyOptions configures how GetBody reads a provider's response.
+type BodyOptions struct {
+	MaxSize  int64     // Largest body read once decompressed; 0 or less allows any size
+	Recorder *Recorder // Optional recorder of the response (can be nil)
+	Replay   string    // Recorded response read instead of calling the provider, if set
+}
+
+// NewBodyOptions returns the options of GetBody set by cfg.
+func NewBodyOptions(cfg ClientConfig) BodyOptions {
+	return BodyOptions{MaxSize: cfg.MaxBodySize, Recorder: cfg.Recorder, Replay: cfg.Replay}
 }
 
 // RetryConfig holds retry configuration.
@@ -31,8 +70,10 @@ type CBConfig struct {
 	FailureRatio float64
 }
 
-// NewRestyClient creates a new Resty HTTP client with retry configuration.
-func NewRestyClient(cfg ClientConfig) *resty.Client {
+// NewRestyClient creates a new Resty HTTP client with retry configuration. Every
+// request attempt is recorded in the provider metrics and logged at debug level,
+// labeled with cfg.Name.
+func NewRestyClient(cfg ClientConfig, log *zap.Logger) *resty.Client {
 	client := resty.New().
 		SetBaseURL(cfg.BaseURL).
 		SetTimeout(cfg.Timeout).
@@ -45,12 +86,132 @@ func NewRestyClient(cfg ClientConfig) *resty.Client {
 				return true
 			}
 
-			return r.StatusCode() >= 500
+			if r.StatusCode() < 500 {
+				return false
+			}
+			// Responses left unread by GetBody must be closed before the retry
+			closeBody(r)
+
+			return true
+		}).
+		OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+			if id := logger.RequestIDFromContext(r.Context()); id != "" {
+				r.SetHeader(headerRequestID, id)
+			}
+			// Runs before each attempt, ahead of creating its HTTP request
+			r.SetContext(withAttempt(r.Context(), r.Attempt))
+
+			return nil
 		})
+	client.SetTransport(&instrumentedTransport{name: cfg.Name, next: client.GetClient().Transport, logger: log})
+	if cfg.APIKey != "" {
+		client.SetAuthToken(cfg.APIKey)
+	}
 
 	return client
 }
 
+// GetBody sends req as a GET of endpoint through cb and returns the response body
+// unread, so feeds are decoded as they arrive rather than buffered whole. Failed
+// calls are wrapped with ClassifyError; error statuses fail as "<name> returned
+// status <code>". The caller closes the body.
+//
+// gzip and deflate responses are accepted and decompressed while read. Bodies
+// larger than opts.MaxSize bytes once decompressed fail with ErrBodyTooLarge:
+// right away when the Content-Length announces it, counting against cb, otherwise
+// from Read once opts.MaxSize bytes were read.
+//
+// With opts.Replay set, the recorded response is read instead and neither req nor
+// cb are used; otherwise opts.Recorder, if set, records the response as it is read.
+//
+// The client's timeout also bounds reading the body.
+func GetBody(
+	ctx context.Context,
+	name string,
+	cb *gobreaker.CircuitBreaker[*resty.Response],
+	req *resty.Request,
+	endpoint string,
+	opts BodyOptions,
+) (*Body, error) {
+	var resp *http.Response
+	if opts.Replay != "" {
+		r, err := openRecording(opts.Replay)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", domain.ErrProviderUnavailable, name, err)
+		}
+		if r.StatusCode >= http.StatusBadRequest {
+			_ = r.Body.Close()
+
+			return nil, fmt.Errorf("%w: %s: recorded response has status %d",
+				domain.ErrProviderUnavailable, name, r.StatusCode)
+		}
+		resp = r
+	} else {
+		r, err := get(ctx, name, cb, req, endpoint, opts.MaxSize)
+		if err != nil {
+			return nil, ClassifyError(err)
+		}
+		resp = r.RawResponse
+		if opts.Recorder != nil {
+			resp.Body = opts.Recorder.record(ctx, name, resp)
+		}
+	}
+
+	body, err := decompress(resp.Body, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %s: %w", domain.ErrProviderUnavailable, name, err)
+	}
+	if opts.MaxSize > 0 {
+		body = &limitedBody{ReadCloser: body, name: name, limit: opts.MaxSize, remaining: opts.MaxSize}
+	}
+
+	return &Body{ReadCloser: body, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+// get sends req as a GET of endpoint through cb, leaving the response body unread.
+func get(
+	ctx context.Context,
+	name string,
+	cb *gobreaker.CircuitBreaker[*resty.Response],
+	req *resty.Request,
+	endpoint string,
+	maxSize int64,
+) (*resty.Response, error) {
+	return cb.Execute(func() (*resty.Response, error) {
+		r, err := req.SetContext(ctx).
+			SetHeader("Accept-Encoding", acceptEncoding).
+			SetDoNotParseResponse(true).
+			Get(endpoint)
+		if err != nil {
+			closeBody(r)
+
+			return nil, CallerError(ctx, err)
+		}
+		if r.IsError() {
+			closeBody(r)
+
+			return nil, fmt.Errorf("%s returned status %d", name, r.StatusCode())
+		}
+		if maxSize > 0 && r.RawResponse.ContentLength > maxSize {
+			closeBody(r)
+
+			return nil, fmt.Errorf("%w: %s sent %d bytes, limit is %d",
+				ErrBodyTooLarge, name, r.RawResponse.ContentLength, maxSize)
+		}
+
+		return r, nil
+	})
+}
+
+// closeBody closes the body of r, if any.
+func closeBody(r *resty.Response) {
+	if r != nil && r.RawResponse != nil && r.RawResponse.Body != nil {
+		_ = r.RawResponse.Body.Close()
+	}
+}
+
 // NewCircuitBreaker creates a new circuit breaker for a provider.
 func NewCircuitBreaker[T any](name string, cfg CBConfig) *gobreaker.CircuitBreaker[T] {
 	settings := gobreaker.Settings{
@@ -63,6 +224,10 @@ func NewCircuitBreaker[T any](name string, cfg CBConfig) *gobreaker.CircuitBreak
 
 			return counts.Requests >= 3 && failureRatio >= cfg.FailureRatio
 		},
+		// Calls abandoned by their caller say nothing about the provider's health
+		IsExcluded: func(err error) bool {
+			return errors.Is(err, errCallerDone)
+		},
 		OnStateChange: func(_ string, _ gobreaker.State, _ gobreaker.State) {
 			// Log state changes - logger injected at higher level #todo
 		},
@@ -70,3 +235,30 @@ func NewCircuitBreaker[T any](name string, cfg CBConfig) *gobreaker.CircuitBreak
 
 	return gobreaker.NewCircuitBreaker[T](settings)
 }
+
+// errCallerDone marks a failed call whose caller's context had already ended.
+var errCallerDone = errors.New("caller context done")
+
+// CallerError marks err as caused by the caller when ctx is done, e.g. when the HTTP
+// request that triggered a sync ran past its deadline. Circuit breakers created by
+// NewCircuitBreaker ignore such errors, so requests timing out under load don't trip them.
+// Call it on the error returned inside the breaker's Execute.
+func CallerError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", errCallerDone, err)
+}
+
+// ClassifyError wraps a failed provider call with domain.ErrTimeout when it ran
+// out of time and with domain.ErrProviderUnavailable otherwise (including an
+// open circuit breaker), so callers don't need to know about HTTP clients.
+func ClassifyError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", domain.ErrTimeout, err)
+	}
+
+	return fmt.Errorf("%w: %w", domain.ErrProviderUnavailable, err)
+}
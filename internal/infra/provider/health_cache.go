@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"search-engine-service/internal/domain"
+)
+
+// HealthCachedProvider wraps a provider and reuses its last HealthCheck result for a TTL.
+// Concurrent checks are coalesced: callers arriving while a check is in flight wait
+// for it and share its result instead of issuing their own request.
+type HealthCachedProvider struct {
+	domain.Provider
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	lastErr   error
+	checkedAt time.Time
+}
+
+// WithHealthCache wraps p so HealthCheck results are cached for ttl. The wrapper
+// streams like p if p is a domain.ProviderStreamer.
+// Returns p unchanged when ttl is not positive.
+func WithHealthCache(p domain.Provider, ttl time.Duration) domain.Provider {
+	if ttl <= 0 {
+		return p
+	}
+
+	cached := &HealthCachedProvider{
+		Provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+	if streamer, ok := p.(domain.ProviderStreamer); ok {
+		return &healthCachedStreamer{HealthCachedProvider: cached, ProviderStreamer: streamer}
+	}
+
+	return cached
+}
+
+// healthCachedStreamer is a HealthCachedProvider of a provider streaming its feed.
+type healthCachedStreamer struct {
+	*HealthCachedProvider
+	domain.ProviderStreamer
+}
+
+// HealthCheck returns the cached result if it is younger than the TTL,
+// otherwise checks the wrapped provider.
+func (p *HealthCachedProvider) HealthCheck(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.checkedAt.IsZero() && p.now().Sub(p.checkedAt) < p.ttl {
+		return p.lastErr
+	}
+
+	err := p.Provider.HealthCheck(ctx)
+	if ctx.Err() != nil {
+		// Don't cache results of checks cut short by the caller
+		return err
+	}
+
+	p.lastErr = err
+	p.checkedAt = p.now()
+
+	return err
+}
+
+// BreakerState reports the wrapped provider's circuit breaker state, or "" if it has none.
+func (p *HealthCachedProvider) BreakerState() string {
+	if r, ok := p.Provider.(domain.BreakerStateReporter); ok {
+		return r.BreakerState()
+	}
+
+	return ""
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"search-engine-service/internal/domain"
+)
+
+type countingProvider struct {
+	domain.Provider
+	calls atomic.Int32
+	err   error
+	delay time.Duration
+}
+
+func (p *countingProvider) HealthCheck(_ context.Context) error {
+	p.calls.Add(1)
+	time.Sleep(p.delay)
+
+	return p.err
+}
+
+func TestWithHealthCache_ZeroTTLReturnsProvider(t *testing.T) {
+	p := &countingProvider{}
+
+	assert.Same(t, domain.Provider(p), WithHealthCache(p, 0))
+}
+
+func TestHealthCachedProvider_ReusesResultWithinTTL(t *testing.T) {
+	inner := &countingProvider{err: errors.New("down")}
+	cached := WithHealthCache(inner, time.Minute).(*HealthCachedProvider)
+
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	assert.EqualError(t, cached.HealthCheck(context.Background()), "down")
+	assert.EqualError(t, cached.HealthCheck(context.Background()), "down")
+	assert.Equal(t, int32(1), inner.calls.Load())
+
+	// After the TTL the provider is checked again
+	now = now.Add(time.Minute)
+	inner.err = nil
+	assert.NoError(t, cached.HealthCheck(context.Background()))
+	assert.Equal(t, int32(2), inner.calls.Load())
+}
+
+func TestHealthCachedProvider_CoalescesConcurrentChecks(t *testing.T) {
+	inner := &countingProvider{delay: 20 * time.Millisecond}
+	cached := WithHealthCache(inner, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cached.HealthCheck(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), inner.calls.Load())
+}
+
+type streamingProvider struct {
+	countingProvider
+}
+
+func (p *streamingProvider) Stream(_ context.Context, _ func(domain.ProviderItem) error) error {
+	return errors.New("streamed")
+}
+
+func TestWithHealthCache_KeepsStreaming(t *testing.T) {
+	_, ok := WithHealthCache(&countingProvider{}, time.Minute).(domain.ProviderStreamer)
+	assert.False(t, ok)
+
+	streamer, ok := WithHealthCache(&streamingProvider{}, time.Minute).(domain.ProviderStreamer)
+	assert.True(t, ok)
+	assert.EqualError(t, streamer.Stream(context.Background(), nil), "streamed")
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/logger"
+	"search-engine-service/internal/metrics"
+)
+
+type attemptKey struct{}
+
+// withAttempt returns a copy of ctx carrying the number of a request's attempt, 1
+// for the first one, for instrumentedTransport.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// instrumentedTransport records every attempt of a provider's requests, retries
+// included: latency, status and retry in the metrics right away, and once the body
+// is closed, a debug log line adding the bytes read. Resty's response middleware
+// can't do this, as it skips the unparsed responses GetBody streams.
+type instrumentedTransport struct {
+	name   string
+	next   http.RoundTripper
+	logger *zap.Logger
+}
+
+// RoundTrip sends req through the next transport and records the attempt.
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	path := req.URL.Path
+	attempt, _ := req.Context().Value(attemptKey{}).(int)
+	if attempt > 1 {
+		metrics.ProviderRetries.WithLabelValues(t.name).Inc()
+	}
+	metrics.ProviderRequestDuration.WithLabelValues(t.name, path).Observe(latency.Seconds())
+
+	log := logger.FromContext(req.Context(), t.logger)
+	if err != nil {
+		metrics.ProviderRequests.WithLabelValues(t.name, path, "error").Inc()
+		log.Debug("provider request attempt failed",
+			zap.String("provider", t.name),
+			zap.String("path", path),
+			zap.Int("attempt", attempt),
+			zap.Duration("latency", latency),
+			zap.Error(err),
+		)
+
+		return resp, err
+	}
+
+	metrics.ProviderRequests.WithLabelValues(t.name, path, strconv.Itoa(resp.StatusCode)).Inc()
+	resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+		metrics.ProviderResponseBytes.WithLabelValues(t.name).Add(float64(n))
+		log.Debug("provider request attempt",
+			zap.String("provider", t.name),
+			zap.String("path", path),
+			zap.Int("attempt", attempt),
+			zap.Int("status", resp.StatusCode),
+			zap.Duration("latency", latency),
+			zap.Duration("duration", time.Since(start)),
+			zap.Int64("bytes", n),
+		)
+	}}
+
+	return resp, nil
+}
+
+// countingBody counts the bytes read from a response body and reports them to onClose
+// when first closed.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	onClose func(n int64)
+}
+
+// Read reads from the body, counting the bytes read.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+
+	return n, err
+}
+
+// Close reports the bytes read, then closes the body.
+func (b *countingBody) Close() error {
+	if b.onClose != nil {
+		b.onClose(b.n)
+		b.onClose = nil
+	}
+
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/metrics"
+)
+
+func TestNewRestyClient_RecordsEveryAttempt(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		_, _ = w.Write([]byte(recordedFeed))
+	}))
+	t.Cleanup(srv.Close)
+
+	const name = "instrument_test"
+	attempts := func(status string) float64 {
+		return testutil.ToFloat64(metrics.ProviderRequests.WithLabelValues(name, "/feed", status))
+	}
+
+	client := NewRestyClient(ClientConfig{
+		Name:    name,
+		BaseURL: srv.URL,
+		Timeout: 5 * time.Second,
+		Retry:   RetryConfig{MaxAttempts: 1, WaitTime: time.Millisecond, MaxWaitTime: time.Millisecond},
+	}, zap.NewNop())
+	cb := NewCircuitBreaker[*resty.Response](name, CBConfig{FailureRatio: 1})
+	body, err := GetBody(context.Background(), name, cb, client.R(), "/feed", BodyOptions{})
+	require.NoError(t, err)
+	_, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+
+	assert.Equal(t, 1.0, attempts("503"), "the failed attempt is recorded")
+	assert.Equal(t, 1.0, attempts("200"), "the retry is recorded")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.ProviderRetries.WithLabelValues(name)))
+	assert.Equal(t, float64(len(recordedFeed)), testutil.ToFloat64(metrics.ProviderResponseBytes.WithLabelValues(name)),
+		"the bytes of the streamed body are counted once it's closed")
+}
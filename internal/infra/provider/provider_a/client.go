@@ -3,6 +3,7 @@ package provider_a
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
@@ -11,26 +12,46 @@ import (
 
 	"search-engine-service/internal/domain"
 	"search-engine-service/internal/infra/provider"
+	"search-engine-service/internal/logger"
 )
 
-// Endpoint is the API path for Provider A's content endpoint.
+// Name is the default provider ID of Provider A.
+const Name = "provider_a"
+
+// Endpoint is the default API path for Provider A's content endpoint.
 const Endpoint = "/api/contents"
 
 // Client implements domain.Provider for Provider A (JSON).
 type Client struct {
-	name   string
-	client *resty.Client
-	cb     *gobreaker.CircuitBreaker[*resty.Response]
-	logger *zap.Logger
+	name     string
+	endpoint string
+	client   *resty.Client
+	cb       *gobreaker.CircuitBreaker[*resty.Response]
+	logger   *zap.Logger
+
+	body   provider.BodyOptions
+	strict bool
 }
 
-// New creates a new Provider A client.
+// New creates a new Provider A client. The name and endpoint default to Name and Endpoint,
+// so other providers serving the same API can be configured under their own names.
 func New(cfg provider.ClientConfig, logger *zap.Logger) *Client {
+	if cfg.Name == "" {
+		cfg.Name = Name
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = Endpoint
+	}
+
 	return &Client{
-		name:   "provider_a",
-		client: provider.NewRestyClient(cfg),
-		cb:     provider.NewCircuitBreaker[*resty.Response]("provider_a", cfg.CB),
-		logger: logger,
+		name:     cfg.Name,
+		endpoint: cfg.Endpoint,
+		client:   provider.NewRestyClient(cfg, logger),
+		cb:       provider.NewCircuitBreaker[*resty.Response](cfg.Name, cfg.CB),
+		logger:   logger,
+
+		body:   provider.NewBodyOptions(cfg),
+		strict: cfg.Strict,
 	}
 }
 
@@ -41,51 +62,89 @@ func (c *Client) Name() string {
 
 // Fetch retrieves all content from Provider A.
 func (c *Client) Fetch(ctx context.Context) ([]*domain.Content, error) {
-	resp, err := c.cb.Execute(func() (*resty.Response, error) {
-		var result Response
-		r, err := c.client.R().
-			SetContext(ctx).
-			SetResult(&result).
-			Get(Endpoint)
-		if err != nil {
-			return nil, err
+	var contents []*domain.Content
+	err := c.Stream(ctx, func(item domain.ProviderItem) error {
+		content := item.ToDomain(c.name)
+		if errs := item.(*ContentItem).SchemaErrors(); len(errs) > 0 {
+			logger.FromContext(ctx, c.logger).Warn("skipping content breaking the schema",
+				zap.String("provider", c.name),
+				zap.String("external_id", content.ExternalID),
+				zap.Error(errs),
+			)
+
+			return nil
 		}
-		if r.IsError() {
-			return nil, fmt.Errorf("provider_a returned status %d", r.StatusCode())
+		if err := content.Validate(); err != nil {
+			logger.FromContext(ctx, c.logger).Warn("skipping invalid content",
+				zap.String("provider", c.name),
+				zap.String("external_id", content.ExternalID),
+				zap.Error(err),
+			)
+
+			return nil
 		}
+		// Calculate score
+		content.Score = domain.CalculateScore(content)
+		contents = append(contents, content)
 
-		return r, nil
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, c.logger).Info("provider fetch completed",
+		zap.String("provider", c.name),
+		zap.Int("count", len(contents)),
+	)
+
+	return contents, nil
+}
 
+// Stream decodes the contents of Provider A's response one at a time, as the
+// response arrives, and hands each *ContentItem to emit. A strict client hands over
+// the items breaking its schema with their errors, undecoded.
+func (c *Client) Stream(ctx context.Context, emit func(domain.ProviderItem) error) error {
+	body, err := provider.GetBody(ctx, c.name, c.cb, c.client.R(), c.endpoint, c.body)
 	if err != nil {
-		c.logger.Warn("provider_a fetch failed",
+		logger.FromContext(ctx, c.logger).Warn("provider fetch failed",
+			zap.String("provider", c.name),
 			zap.Error(err),
 			zap.String("state", c.cb.State().String()),
 		)
 
-		return nil, fmt.Errorf("fetching from provider_a: %w", err)
+		return fmt.Errorf("fetching from %s: %w", c.name, err)
 	}
+	defer body.Close()
 
-	// Parse response
-	result := resp.Result().(*Response)
-	contents := make([]*domain.Content, 0, len(result.Contents))
+	var emitErr error
+	err = decodeContents(json.NewDecoder(body), c.strict, func(item *ContentItem) error {
+		emitErr = emit(item)
 
-	for _, item := range result.Contents {
-		content := item.ToDomain(c.name)
-		// Calculate score
-		content.Score = domain.CalculateScore(content)
-		contents = append(contents, content)
+		return emitErr
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	if err != nil {
+		return fmt.Errorf("parsing %s JSON: %w", c.name, err)
 	}
 
-	c.logger.Info("provider_a fetch completed",
-		zap.Int("count", len(contents)),
-	)
+	return nil
+}
 
-	return contents, nil
+// BreakerState returns the state of the provider's circuit breaker.
+func (c *Client) BreakerState() string {
+	return c.cb.State().String()
 }
 
-// HealthCheck verifies the provider is accessible.
+// HealthCheck verifies the provider is accessible, or that its recorded response
+// can be read when replaying one.
 func (c *Client) HealthCheck(ctx context.Context) error {
+	if c.body.Replay != "" {
+		return provider.CheckRecording(c.body.Replay)
+	}
+
 	resp, err := c.client.R().
 		SetContext(ctx).
 		Get("/health")
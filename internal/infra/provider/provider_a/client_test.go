@@ -2,18 +2,21 @@ package provider_a
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/sony/gobreaker/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/domain"
 	"search-engine-service/internal/infra/provider"
+	"search-engine-service/internal/logger"
 )
 
 const testEndpoint = "https://provider-a.example.com/api/contents"
@@ -57,6 +60,9 @@ func mockSuccessResponse() Response {
 				},
 				PublishedAt: "2024-01-15T10:00:00Z",
 				Tags:        []string{"golang", "tutorial"},
+				URL:         "https://provider-a.example.com/videos/1",
+				Thumbnail:   "https://cdn.example.com/video-1.jpg",
+				Author:      "Gopher Academy",
 			},
 			{
 				ID:    "video-2",
@@ -101,7 +107,11 @@ func TestProviderA_Fetch_Success(t *testing.T) {
 	assert.Equal(t, 10000, contents[0].Views)
 	assert.Equal(t, 500, contents[0].Likes)
 	assert.Equal(t, "5m30s", contents[0].Duration)
+	assert.Equal(t, 330, contents[0].DurationSeconds)
 	assert.Equal(t, []string{"golang", "tutorial"}, contents[0].Tags)
+	assert.Equal(t, "https://provider-a.example.com/videos/1", contents[0].URL)
+	assert.Equal(t, "https://cdn.example.com/video-1.jpg", contents[0].ThumbnailURL)
+	assert.Equal(t, "Gopher Academy", contents[0].Author)
 	assert.Greater(t, contents[0].Score, 0.0) // Score should be calculated
 
 	// Verify second content
@@ -109,6 +119,151 @@ func TestProviderA_Fetch_Success(t *testing.T) {
 	assert.Equal(t, "Test Video 2", contents[1].Title)
 }
 
+// TestProviderA_Stream_DecodesItemByItem tests items are handed over unconverted, other fields skipped.
+func TestProviderA_Stream_DecodesItemByItem(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200,
+		`{"pagination": {"total": 3}, "contents": [{"id": "a"}, {"id": "b"}, {"id": "c"}], "extra": [1, {"x": null}]}`))
+
+	client := newTestClient()
+	var ids []string
+	err := client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		ids = append(ids, item.(*ContentItem).ID)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	// An emit error stops the stream and is returned as is
+	stop := errors.New("stop")
+	ids = nil
+	err = client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		ids = append(ids, item.(*ContentItem).ID)
+
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"a"}, ids)
+}
+
+// TestProviderA_Fetch_InvalidJSON tests handling of malformed JSON.
+func TestProviderA_Fetch_InvalidJSON(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewStringResponder(200, `{"contents": [{"id": "a"}, `))
+
+	client := newTestClient()
+	contents, err := client.Fetch(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, contents)
+	assert.Contains(t, err.Error(), "parsing provider_a JSON")
+}
+
+// TestProviderA_Fetch_BodyTooLarge tests responses over the size limit fail, whether or not
+// their Content-Length announces it.
+func TestProviderA_Fetch_BodyTooLarge(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	body := `{"contents": [{"id": "a"}, {"id": "b"}]}`
+	client := newTestClient()
+
+	client.body.MaxSize = int64(len(body))
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200, body))
+	_, err := client.Fetch(context.Background())
+	require.NoError(t, err, "a body of exactly the limit is read")
+
+	client.body.MaxSize = int64(len(body)) - 1
+	_, err = client.Fetch(context.Background())
+	require.ErrorIs(t, err, provider.ErrBodyTooLarge)
+	require.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	assert.Contains(t, err.Error(), "parsing provider_a JSON")
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewStringResponder(200, body).SetContentLength())
+	_, err = client.Fetch(context.Background())
+	require.ErrorIs(t, err, provider.ErrBodyTooLarge)
+	require.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	assert.Contains(t, err.Error(), "fetching from provider_a", "rejected before decoding")
+}
+
+// TestProviderA_Stream_StrictSchema tests a strict client hands over items breaking its schema
+// with their errors and payload, and decodes the others.
+func TestProviderA_Stream_StrictSchema(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	renamed := `{"id": "b", "title": "Renamed", "type": "video", "published_at": "2024-01-15T10:00:00Z", "metrics": {"view_count": 10}}`
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200,
+		`{"contents": [{"id": "a", "title": "Valid", "type": "video", "published_at": "2024-01-15T10:00:00Z", "metrics": {"views": 10}}, `+
+			renamed+`]}`))
+
+	client := newTestClient()
+	client.strict = true
+	var items []*ContentItem
+	err := client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		items = append(items, item.(*ContentItem))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Empty(t, items[0].SchemaErrors())
+	assert.Nil(t, items[0].Payload())
+	assert.Equal(t, 10, items[0].Metrics.Views)
+
+	require.Len(t, items[1].SchemaErrors(), 1)
+	assert.Equal(t, "metrics.view_count", items[1].SchemaErrors()[0].Field)
+	assert.JSONEq(t, renamed, string(items[1].Payload()))
+	assert.Equal(t, "b", items[1].ToDomain(Name).ExternalID)
+
+	// Fetch leaves the rejected item out
+	contents, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "a", contents[0].ExternalID)
+}
+
+// TestProviderA_Fetch_ForwardsRequestID tests the triggering request's ID is sent to the provider.
+func TestProviderA_Fetch_ForwardsRequestID(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	var requestID string
+	httpmock.RegisterResponder("GET", testEndpoint, func(req *http.Request) (*http.Response, error) {
+		requestID = req.Header.Get("X-Request-ID")
+
+		return httpmock.NewJsonResponse(200, mockSuccessResponse())
+	})
+
+	client := newTestClient()
+	_, err := client.Fetch(logger.WithRequestID(context.Background(), "req-123"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", requestID)
+}
+
+// TestProviderA_Fetch_SendsAPIKey tests a configured API key is sent as a bearer token.
+func TestProviderA_Fetch_SendsAPIKey(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	var authorization string
+	httpmock.RegisterResponder("GET", testEndpoint, func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+
+		return httpmock.NewJsonResponse(200, mockSuccessResponse())
+	})
+
+	client := New(provider.ClientConfig{BaseURL: "https://provider-a.example.com", APIKey: "key-123"}, zap.NewNop())
+	httpmock.ActivateNonDefault(client.client.GetClient())
+	_, err := client.Fetch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer key-123", authorization)
+}
+
 // TestProviderA_Fetch_EmptyResponse tests handling of empty content array.
 func TestProviderA_Fetch_EmptyResponse(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
@@ -203,6 +358,7 @@ func TestProviderA_Fetch_NetworkError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, contents)
 	assert.Contains(t, err.Error(), "fetching from provider_a")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
 }
 
 // TestProviderA_Fetch_ContextCancellation tests context cancellation handling.
@@ -225,6 +381,7 @@ func TestProviderA_Fetch_ContextCancellation(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Nil(t, contents)
+	assert.ErrorIs(t, err, domain.ErrTimeout)
 }
 
 // TestProviderA_CircuitBreaker_Opens tests that CB opens after consecutive failures.
@@ -251,10 +408,41 @@ func TestProviderA_CircuitBreaker_Opens(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "circuit breaker is open")
+	assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
 	// Should fail fast (< 100ms) without making HTTP request
 	assert.Less(t, elapsed.Milliseconds(), int64(100))
 }
 
+// TestProviderA_CircuitBreaker_IgnoresCallerDeadline tests that requests abandoned by
+// their caller don't count as provider failures.
+func TestProviderA_CircuitBreaker_IgnoresCallerDeadline(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		func(_ *http.Request) (*http.Response, error) {
+			time.Sleep(100 * time.Millisecond)
+
+			return httpmock.NewJsonResponse(200, mockSuccessResponse())
+		})
+
+	client := newTestClient()
+
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := client.Fetch(ctx)
+		cancel()
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrTimeout)
+	}
+
+	assert.Equal(t, gobreaker.StateClosed, client.cb.State())
+
+	contents, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, contents, 2)
+}
+
 // TestProviderA_Retry_ExponentialBackoff tests retry mechanism.
 func TestProviderA_Retry_ExponentialBackoff(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
@@ -330,6 +518,36 @@ func TestProviderA_Fetch_ScoreCalculation(t *testing.T) {
 	}
 }
 
+// TestProviderA_Fetch_Podcast tests podcasts get their listen count.
+func TestProviderA_Fetch_Podcast(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	resp := Response{
+		Contents: []ContentItem{
+			{
+				ID:          "podcast-1",
+				Title:       "Go Time",
+				Type:        "podcast",
+				Metrics:     Metrics{Listens: 20000, Likes: 1000, Duration: "45m"},
+				PublishedAt: "2024-01-15T10:30:00Z",
+			},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewJsonResponderOrPanic(200, resp))
+
+	client := newTestClient()
+	contents, err := client.Fetch(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, domain.ContentTypePodcast, contents[0].Type)
+	assert.Equal(t, 20000, contents[0].Listens)
+	assert.Equal(t, 1000, contents[0].Likes)
+	assert.Equal(t, "45m", contents[0].Duration)
+}
+
 // TestProviderA_Fetch_DateParsing tests published_at date parsing.
 func TestProviderA_Fetch_DateParsing(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
@@ -383,10 +601,34 @@ func TestProviderA_Fetch_InvalidDateFormat(t *testing.T) {
 	client := newTestClient()
 	contents, err := client.Fetch(context.Background())
 
-	// Should still succeed but with zero time
+	// Should still succeed, skipping the content without a publish date
+	require.NoError(t, err)
+	assert.Empty(t, contents)
+}
+
+// TestProviderA_Fetch_SkipsInvalidContent tests that contents breaking domain invariants are skipped.
+func TestProviderA_Fetch_SkipsInvalidContent(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	published := time.Now().AddDate(0, 0, -1).Format(time.RFC3339)
+	resp := Response{
+		Contents: []ContentItem{
+			{ID: "video-1", Title: "Valid", Type: "video", Metrics: Metrics{Views: 100, Likes: 10}, PublishedAt: published},
+			{ID: "video-2", Title: "Negative", Type: "video", Metrics: Metrics{Views: -5}, PublishedAt: published},
+			{ID: "", Title: "No ID", Type: "video", PublishedAt: published},
+			{ID: "live-1", Title: "Unknown Type", Type: "livestream", PublishedAt: published},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewJsonResponderOrPanic(200, resp))
+
+	client := newTestClient()
+	contents, err := client.Fetch(context.Background())
+
 	require.NoError(t, err)
 	require.Len(t, contents, 1)
-	assert.True(t, contents[0].PublishedAt.IsZero())
+	assert.Equal(t, "video-1", contents[0].ExternalID)
 }
 
 // TestProviderA_Fetch_HTTPCallCount verifies httpmock call tracking.
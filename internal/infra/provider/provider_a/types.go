@@ -1,9 +1,13 @@
 package provider_a
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/provider"
 )
 
 // Response represents the JSON response from Provider A.
@@ -20,13 +24,38 @@ type ContentItem struct {
 	Metrics     Metrics  `json:"metrics"`
 	PublishedAt string   `json:"published_at"`
 	Tags        []string `json:"tags"`
+	URL         string   `json:"url"`
+	Thumbnail   string   `json:"thumbnail_url"`
+	Author      string   `json:"author"`
+
+	schemaErrors domain.ValidationErrors // Set by strict decoding when the item breaks schema
+	payload      []byte                  // The item as sent, kept with schemaErrors
+}
+
+// schema describes ContentItem for strict decoding.
+var schema = provider.Schema{
+	{Name: "id", Kind: provider.KindString, Required: true},
+	{Name: "title", Kind: provider.KindString, Required: true},
+	{Name: "type", Kind: provider.KindString, Required: true},
+	{Name: "metrics", Kind: provider.KindObject, Fields: []provider.Field{
+		{Name: "views", Kind: provider.KindInt},
+		{Name: "likes", Kind: provider.KindInt},
+		{Name: "duration", Kind: provider.KindString},
+		{Name: "listens", Kind: provider.KindInt},
+	}},
+	{Name: "published_at", Kind: provider.KindDate, Required: true, Layout: time.RFC3339},
+	{Name: "tags", Kind: provider.KindString, List: true},
+	{Name: "url", Kind: provider.KindString},
+	{Name: "thumbnail_url", Kind: provider.KindString},
+	{Name: "author", Kind: provider.KindString},
 }
 
-// Metrics holds video metrics.
+// Metrics holds content metrics (video, podcast and image).
 type Metrics struct {
-	Views    int    `json:"views"`
-	Likes    int    `json:"likes"`
-	Duration string `json:"duration"`
+	Views    int    `json:"views"`    // Video, image
+	Likes    int    `json:"likes"`    // Video, podcast, image
+	Duration string `json:"duration"` // Video, podcast
+	Listens  int    `json:"listens"`  // Podcast
 }
 
 // Pagination holds pagination info.
@@ -40,15 +69,125 @@ type Pagination struct {
 func (c *ContentItem) ToDomain(providerID string) *domain.Content {
 	publishedAt, _ := time.Parse(time.RFC3339, c.PublishedAt)
 
-	return &domain.Content{
-		ProviderID:  providerID,
-		ExternalID:  c.ID,
-		Title:       c.Title,
-		Type:        domain.ContentType(c.Type),
-		Tags:        c.Tags,
-		Views:       c.Metrics.Views,
-		Likes:       c.Metrics.Likes,
-		Duration:    c.Metrics.Duration,
-		PublishedAt: publishedAt,
+	content := &domain.Content{
+		ProviderID:   providerID,
+		ExternalID:   c.ID,
+		Title:        c.Title,
+		Type:         domain.ContentType(c.Type),
+		Tags:         c.Tags,
+		URL:          c.URL,
+		ThumbnailURL: c.Thumbnail,
+		Author:       c.Author,
+		Views:        c.Metrics.Views,
+		Likes:        c.Metrics.Likes,
+		Listens:      c.Metrics.Listens,
+		PublishedAt:  publishedAt,
+	}
+	content.SetDuration(c.Metrics.Duration)
+
+	return content
+}
+
+// SchemaErrors implements domain.SchemaCheckedItem.
+func (c *ContentItem) SchemaErrors() domain.ValidationErrors {
+	return c.schemaErrors
+}
+
+// Payload implements domain.SchemaCheckedItem.
+func (c *ContentItem) Payload() []byte {
+	return c.payload
+}
+
+// decodeContents decodes a Response from dec one content at a time, handing each
+// item of its contents array to fn instead of holding them all. Other fields are
+// skipped. When strict, items breaking schema are handed over with their errors and
+// payload, and only their ID decoded.
+func decodeContents(dec *json.Decoder, strict bool, fn func(item *ContentItem) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key != "contents" {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue // "contents": null
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("contents: expected array, got %v", tok)
+		}
+		for dec.More() {
+			item, err := decodeItem(dec, strict)
+			if err != nil {
+				return err
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
 	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeItem decodes the next content of dec, checked against schema when strict.
+func decodeItem(dec *json.Decoder, strict bool) (*ContentItem, error) {
+	item := &ContentItem{}
+	if !strict {
+		return item, dec.Decode(item)
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	tree := json.NewDecoder(bytes.NewReader(raw))
+	tree.UseNumber()
+	var value any
+	if err := tree.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if errs := schema.Check(value); len(errs) > 0 {
+		if obj, ok := value.(map[string]any); ok {
+			item.ID, _ = obj["id"].(string)
+		}
+		item.schemaErrors = errs
+		item.payload = raw
+
+		return item, nil
+	}
+
+	return item, json.Unmarshal(raw, item)
+}
+
+// expectDelim reads the next token of dec, which must be delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+
+	return nil
 }
@@ -3,7 +3,6 @@ package provider_b
 
 import (
 	"context"
-	"encoding/xml"
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
@@ -12,26 +11,46 @@ import (
 
 	"search-engine-service/internal/domain"
 	"search-engine-service/internal/infra/provider"
+	"search-engine-service/internal/logger"
 )
 
-// Endpoint is the API path for Provider B's content endpoint.
+// Name is the default provider ID of Provider B.
+const Name = "provider_b"
+
+// Endpoint is the default API path for Provider B's content endpoint.
 const Endpoint = "/feed"
 
 // Client implements domain.Provider for Provider B (XML).
 type Client struct {
-	name   string
-	client *resty.Client
-	cb     *gobreaker.CircuitBreaker[*resty.Response]
-	logger *zap.Logger
+	name     string
+	endpoint string
+	client   *resty.Client
+	cb       *gobreaker.CircuitBreaker[*resty.Response]
+	logger   *zap.Logger
+
+	body   provider.BodyOptions
+	strict bool
 }
 
-// New creates a new Provider B client.
+// New creates a new Provider B client. The name and endpoint default to Name and Endpoint,
+// so other providers serving the same API can be configured under their own names.
 func New(cfg provider.ClientConfig, logger *zap.Logger) *Client {
+	if cfg.Name == "" {
+		cfg.Name = Name
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = Endpoint
+	}
+
 	return &Client{
-		name:   "provider_b",
-		client: provider.NewRestyClient(cfg),
-		cb:     provider.NewCircuitBreaker[*resty.Response]("provider_b", cfg.CB),
-		logger: logger,
+		name:     cfg.Name,
+		endpoint: cfg.Endpoint,
+		client:   provider.NewRestyClient(cfg, logger),
+		cb:       provider.NewCircuitBreaker[*resty.Response](cfg.Name, cfg.CB),
+		logger:   logger,
+
+		body:   provider.NewBodyOptions(cfg),
+		strict: cfg.Strict,
 	}
 }
 
@@ -42,54 +61,95 @@ func (c *Client) Name() string {
 
 // Fetch retrieves all content from Provider B.
 func (c *Client) Fetch(ctx context.Context) ([]*domain.Content, error) {
-	resp, err := c.cb.Execute(func() (*resty.Response, error) {
-		r, err := c.client.R().
-			SetContext(ctx).
-			SetHeader("Accept", "application/xml").
-			Get(Endpoint)
-		if err != nil {
-			return nil, err
+	var contents []*domain.Content
+	err := c.Stream(ctx, func(item domain.ProviderItem) error {
+		content := item.ToDomain(c.name)
+		if errs := item.(*Item).SchemaErrors(); len(errs) > 0 {
+			logger.FromContext(ctx, c.logger).Warn("skipping content breaking the schema",
+				zap.String("provider", c.name),
+				zap.String("external_id", content.ExternalID),
+				zap.Error(errs),
+			)
+
+			return nil
 		}
-		if r.IsError() {
-			return nil, fmt.Errorf("provider_b returned status %d", r.StatusCode())
+		if err := content.Validate(); err != nil {
+			logger.FromContext(ctx, c.logger).Warn("skipping invalid content",
+				zap.String("provider", c.name),
+				zap.String("external_id", content.ExternalID),
+				zap.Error(err),
+			)
+
+			return nil
 		}
+		// Calculate score
+		content.Score = domain.CalculateScore(content)
+		contents = append(contents, content)
 
-		return r, nil
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, c.logger).Info("provider fetch completed",
+		zap.String("provider", c.name),
+		zap.Int("count", len(contents)),
+	)
+
+	return contents, nil
+}
 
+// Stream decodes the items of Provider B's feed one at a time, as the response
+// arrives, and hands each *Item to emit. A strict client hands over the items
+// breaking its schema with their errors, undecoded.
+func (c *Client) Stream(ctx context.Context, emit func(domain.ProviderItem) error) error {
+	req := c.client.R().SetHeader("Accept", "application/xml")
+	body, err := provider.GetBody(ctx, c.name, c.cb, req, c.endpoint, c.body)
 	if err != nil {
-		c.logger.Warn("provider_b fetch failed",
+		logger.FromContext(ctx, c.logger).Warn("provider fetch failed",
+			zap.String("provider", c.name),
 			zap.Error(err),
 			zap.String("state", c.cb.State().String()),
 		)
 
-		return nil, fmt.Errorf("fetching from provider_b: %w", err)
+		return fmt.Errorf("fetching from %s: %w", c.name, err)
 	}
+	defer body.Close()
 
-	// Parse XML response
-	var feed Feed
-	if err := xml.Unmarshal(resp.Body(), &feed); err != nil {
-		return nil, fmt.Errorf("parsing provider_b XML: %w", err)
+	dec, err := newDecoder(body, body.ContentType)
+	if err != nil {
+		return fmt.Errorf("parsing %s XML: %w", c.name, err)
 	}
 
-	contents := make([]*domain.Content, 0, len(feed.Items.Items))
+	var emitErr error
+	err = decodeItems(dec, c.strict, func(item *Item) error {
+		emitErr = emit(item)
 
-	for _, item := range feed.Items.Items {
-		content := item.ToDomain(c.name)
-		// Calculate score
-		content.Score = domain.CalculateScore(content)
-		contents = append(contents, content)
+		return emitErr
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	if err != nil {
+		return fmt.Errorf("parsing %s XML: %w", c.name, err)
 	}
 
-	c.logger.Info("provider_b fetch completed",
-		zap.Int("count", len(contents)),
-	)
+	return nil
+}
 
-	return contents, nil
+// BreakerState returns the state of the provider's circuit breaker.
+func (c *Client) BreakerState() string {
+	return c.cb.State().String()
 }
 
-// HealthCheck verifies the provider is accessible.
+// HealthCheck verifies the provider is accessible, or that its recorded response
+// can be read when replaying one.
 func (c *Client) HealthCheck(ctx context.Context) error {
+	if c.body.Replay != "" {
+		return provider.CheckRecording(c.body.Replay)
+	}
+
 	resp, err := c.client.R().
 		SetContext(ctx).
 		Get("/health")
@@ -1,9 +1,16 @@
 package provider_b
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -11,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/text/encoding/charmap"
 
 	"search-engine-service/internal/domain"
 	"search-engine-service/internal/infra/provider"
@@ -61,6 +69,9 @@ func mockSuccessXMLResponse() string {
 				<category>technology</category>
 				<category>golang</category>
 			</categories>
+			<link>https://provider-b.example.com/articles/1</link>
+			<thumbnail>https://cdn.example.com/article-1.png</thumbnail>
+			<author>Jane Doe</author>
 		</item>
 		<item>
 			<id>video-1</id>
@@ -108,6 +119,9 @@ func TestProviderB_Fetch_Success(t *testing.T) {
 	assert.Equal(t, 150, contents[0].Reactions)
 	assert.Equal(t, 25, contents[0].Comments)
 	assert.Equal(t, []string{"technology", "golang"}, contents[0].Tags)
+	assert.Equal(t, "https://provider-b.example.com/articles/1", contents[0].URL)
+	assert.Equal(t, "https://cdn.example.com/article-1.png", contents[0].ThumbnailURL)
+	assert.Equal(t, "Jane Doe", contents[0].Author)
 	assert.Greater(t, contents[0].Score, 0.0) // Score should be calculated
 
 	// Verify second content (video)
@@ -117,6 +131,54 @@ func TestProviderB_Fetch_Success(t *testing.T) {
 	assert.Equal(t, 10000, contents[1].Views)
 	assert.Equal(t, 500, contents[1].Likes)
 	assert.Equal(t, "5m30s", contents[1].Duration)
+	assert.Equal(t, 330, contents[1].DurationSeconds)
+}
+
+// TestProviderB_Stream_DecodesItemByItem tests items are handed over unconverted, other elements skipped.
+func TestProviderB_Stream_DecodesItemByItem(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200,
+		`<feed><meta><item><id>meta</id></item></meta><items><item><id>a</id></item><item><id>b</id></item></items></feed>`))
+
+	client := newTestClient()
+	var ids []string
+	err := client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		ids = append(ids, item.(*Item).ID)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids)
+
+	// An emit error stops the stream and is returned as is
+	stop := errors.New("stop")
+	err = client.Stream(context.Background(), func(domain.ProviderItem) error { return stop })
+	require.ErrorIs(t, err, stop)
+
+	// Truncated and foreign documents fail
+	for _, body := range []string{`<feed><items><item><id>a</id></item>`, `<rss></rss>`} {
+		httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200, body))
+		err = client.Stream(context.Background(), func(domain.ProviderItem) error { return nil })
+		assert.ErrorContains(t, err, "parsing provider_b XML", body)
+	}
+}
+
+// TestProviderB_Fetch_ConfiguredNameAndEndpoint tests another provider serving the same API.
+func TestProviderB_Fetch_ConfiguredNameAndEndpoint(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://news.example.com/rss",
+		httpmock.NewStringResponder(200, mockSuccessXMLResponse()))
+
+	client := New(provider.ClientConfig{Name: "partner_news", Endpoint: "/rss", BaseURL: "https://news.example.com"}, zap.NewNop())
+	httpmock.ActivateNonDefault(client.client.GetClient())
+	contents, err := client.Fetch(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	assert.Equal(t, "partner_news", client.Name())
+	assert.Equal(t, "partner_news", contents[0].ProviderID)
 }
 
 // TestProviderB_Fetch_EmptyResponse tests handling of empty XML.
@@ -143,6 +205,110 @@ func TestProviderB_Fetch_EmptyResponse(t *testing.T) {
 	assert.Empty(t, contents)
 }
 
+// TestProviderB_Fetch_EncodedFeed tests compressed feeds and feeds in other charsets are
+// decoded from the response's headers, served by a real server so the transport doesn't
+// get in between.
+func TestProviderB_Fetch_EncodedFeed(t *testing.T) {
+	const headline = "Şişli'de Çay Keyfi"
+	feed := func(declared string) string {
+		return `<?xml version="1.0" encoding="` + declared + `"?>
+<feed><items><item>
+	<id>tr-1</id><headline>` + headline + `</headline><type>article</type>
+	<stats><reading_time>3</reading_time></stats>
+	<publication_date>2024-01-15</publication_date>
+</item></items></feed>`
+	}
+	latin5 := func(s string) []byte {
+		b, err := charmap.ISO8859_9.NewEncoder().Bytes([]byte(s))
+		require.NoError(t, err)
+
+		return b
+	}
+	compress := func(encoding string, body []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		default:
+			fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+			require.NoError(t, err)
+			w = fw
+		}
+		_, err := w.Write(body)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name            string
+		contentType     string
+		contentEncoding string
+		body            []byte
+	}{
+		{"gzip", "application/xml; charset=utf-8", "gzip", compress("gzip", []byte(feed("UTF-8")))},
+		{"zlib deflate", "application/xml", "deflate", compress("deflate", []byte(feed("UTF-8")))},
+		{"raw deflate", "application/xml", "deflate", compress("raw", []byte(feed("UTF-8")))},
+		{"charset of the Content-Type", "application/xml; charset=ISO-8859-9", "", latin5(feed("ISO-8859-9"))},
+		{"Content-Type over declaration", "text/xml; charset=iso-8859-9", "gzip",
+			compress("gzip", latin5(feed("UTF-8")))},
+		{"charset of the declaration", "application/xml", "", latin5(feed("ISO-8859-9"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "gzip, deflate", r.Header.Get("Accept-Encoding"))
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tt.contentEncoding)
+				}
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := New(provider.ClientConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, zap.NewNop())
+			contents, err := client.Fetch(context.Background())
+
+			require.NoError(t, err)
+			require.Len(t, contents, 1)
+			assert.Equal(t, headline, contents[0].Title)
+		})
+	}
+
+	t.Run("unsupported charset", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/xml; charset=x-unknown")
+			_, _ = w.Write([]byte(feed("UTF-8")))
+		}))
+		defer server.Close()
+
+		client := New(provider.ClientConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, zap.NewNop())
+		_, err := client.Fetch(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported charset "x-unknown"`)
+	})
+
+	t.Run("size limit applies decompressed", func(t *testing.T) {
+		body := compress("gzip", bytes.Repeat([]byte(" "), 1<<20))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(body)
+		}))
+		defer server.Close()
+
+		client := New(provider.ClientConfig{BaseURL: server.URL, Timeout: 5 * time.Second, MaxBodySize: 64 << 10}, zap.NewNop())
+		_, err := client.Fetch(context.Background())
+
+		require.ErrorIs(t, err, provider.ErrBodyTooLarge)
+	})
+}
+
 // TestProviderB_Fetch_HTTPError_4xx tests client error handling (4xx).
 func TestProviderB_Fetch_HTTPError_4xx(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
@@ -216,6 +382,81 @@ func TestProviderB_Fetch_InvalidXML(t *testing.T) {
 	assert.Contains(t, err.Error(), "parsing provider_b XML")
 }
 
+// TestProviderB_Fetch_BodyTooLarge tests an XML feed over the size limit fails mid-stream.
+func TestProviderB_Fetch_BodyTooLarge(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewStringResponder(200, mockSuccessXMLResponse()))
+
+	client := newTestClient()
+	client.body.MaxSize = 256
+	contents, err := client.Fetch(context.Background())
+
+	require.ErrorIs(t, err, provider.ErrBodyTooLarge)
+	require.ErrorIs(t, err, domain.ErrProviderUnavailable)
+	assert.Nil(t, contents)
+}
+
+// TestProviderB_Stream_StrictSchema tests a strict client hands over items breaking its schema
+// with their errors and payload, and decodes the others.
+func TestProviderB_Stream_StrictSchema(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200, `<feed><items>
+		<item><id>a</id><headline>Valid</headline><type>video</type><stats><views>10</views></stats>
+			<publication_date>2024-01-15</publication_date><categories><category>go</category></categories></item>
+		<item><id>b</id><headline>Renamed</headline><type>video</type><stats><view_count>10</view_count><likes>x</likes></stats>
+			<publication_date>2024-01-15</publication_date></item>
+	</items></feed>`))
+
+	client := newTestClient()
+	client.strict = true
+	var items []*Item
+	err := client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		items = append(items, item.(*Item))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Empty(t, items[0].SchemaErrors())
+	assert.Equal(t, 10, items[0].Stats.Views)
+	assert.Equal(t, []string{"go"}, items[0].Categories.Category)
+
+	var fields []string
+	for _, e := range items[1].SchemaErrors() {
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{"stats.likes", "stats.view_count"}, fields)
+	assert.Contains(t, string(items[1].Payload()), "<view_count>10</view_count>")
+	assert.Equal(t, "b", items[1].ToDomain(Name).ExternalID)
+
+	// Fetch leaves the rejected item out
+	contents, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "a", contents[0].ExternalID)
+}
+
+// TestProviderB_Stream_StrictSchemaAcceptsFeed tests the mock's feed conforms to the schema.
+func TestProviderB_Stream_StrictSchemaAcceptsFeed(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint,
+		httpmock.NewStringResponder(200, mockSuccessXMLResponse()))
+
+	client := newTestClient()
+	client.strict = true
+	err := client.Stream(context.Background(), func(item domain.ProviderItem) error {
+		assert.Empty(t, item.(*Item).SchemaErrors())
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 // TestProviderB_Fetch_NetworkError tests network error handling.
 func TestProviderB_Fetch_NetworkError(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
@@ -415,10 +656,9 @@ func TestProviderB_Fetch_InvalidDateFormat(t *testing.T) {
 	client := newTestClient()
 	contents, err := client.Fetch(context.Background())
 
-	// Should still succeed but with zero time
+	// Should still succeed, skipping the content without a publish date
 	require.NoError(t, err)
-	require.Len(t, contents, 1)
-	assert.True(t, contents[0].PublishedAt.IsZero())
+	assert.Empty(t, contents)
 }
 
 // TestProviderB_Fetch_MixedContentTypes tests parsing both article and video types.
@@ -452,6 +692,59 @@ func TestProviderB_Fetch_MixedContentTypes(t *testing.T) {
 	assert.Equal(t, 0, videoContent.Reactions)   // Article field should be empty
 }
 
+// TestProviderB_Fetch_PodcastAndImage tests podcast and image items get their metrics.
+func TestProviderB_Fetch_PodcastAndImage(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", testEndpoint, httpmock.NewStringResponder(200, `<?xml version="1.0" encoding="UTF-8"?>
+<feed>
+	<items>
+		<item>
+			<id>podcast-1</id>
+			<headline>Go Time</headline>
+			<type>podcast</type>
+			<stats>
+				<listens>20000</listens>
+				<likes>1000</likes>
+				<duration>45m</duration>
+				<views>99</views>
+			</stats>
+			<publication_date>2024-01-15</publication_date>
+		</item>
+		<item>
+			<id>image-1</id>
+			<headline>Gopher Sketch</headline>
+			<type>image</type>
+			<stats>
+				<views>5000</views>
+				<likes>500</likes>
+			</stats>
+			<publication_date>2024-01-16</publication_date>
+		</item>
+	</items>
+</feed>`))
+
+	client := newTestClient()
+	contents, err := client.Fetch(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+
+	podcast := contents[0]
+	assert.Equal(t, domain.ContentTypePodcast, podcast.Type)
+	assert.Equal(t, 20000, podcast.Listens)
+	assert.Equal(t, 1000, podcast.Likes)
+	assert.Equal(t, "45m", podcast.Duration)
+	assert.Equal(t, 2700, podcast.DurationSeconds)
+	assert.Equal(t, 0, podcast.Views, "views aren't a podcast metric")
+
+	image := contents[1]
+	assert.Equal(t, domain.ContentTypeImage, image.Type)
+	assert.Equal(t, 5000, image.Views)
+	assert.Equal(t, 500, image.Likes)
+	assert.Greater(t, image.Score, 0.0)
+}
+
 // TestProviderB_Fetch_HTTPCallCount verifies httpmock call tracking.
 func TestProviderB_Fetch_HTTPCallCount(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
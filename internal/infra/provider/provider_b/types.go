@@ -1,10 +1,20 @@
 package provider_b
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
 	"time"
 
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+
 	"search-engine-service/internal/domain"
+	"search-engine-service/internal/infra/provider"
 )
 
 // Feed represents the XML response from Provider B.
@@ -27,15 +37,47 @@ type Item struct {
 	Stats           Stats      `xml:"stats"`
 	PublicationDate string     `xml:"publication_date"`
 	Categories      Categories `xml:"categories"`
+	Link            string     `xml:"link"`
+	Thumbnail       string     `xml:"thumbnail"`
+	Author          string     `xml:"author"`
+
+	schemaErrors domain.ValidationErrors // Set by strict decoding when the item breaks schema
+	payload      []byte                  // The item as sent, kept with schemaErrors
+}
+
+// schema describes Item for strict decoding.
+var schema = provider.Schema{
+	{Name: "id", Kind: provider.KindString, Required: true},
+	{Name: "headline", Kind: provider.KindString, Required: true},
+	{Name: "type", Kind: provider.KindString, Required: true},
+	{Name: "stats", Kind: provider.KindObject, Fields: []provider.Field{
+		{Name: "views", Kind: provider.KindInt},
+		{Name: "likes", Kind: provider.KindInt},
+		{Name: "duration", Kind: provider.KindString},
+		{Name: "listens", Kind: provider.KindInt},
+		{Name: "reading_time", Kind: provider.KindInt},
+		{Name: "reactions", Kind: provider.KindInt},
+		{Name: "comments", Kind: provider.KindInt},
+	}},
+	{Name: "publication_date", Kind: provider.KindDate, Required: true, Layout: "2006-01-02"},
+	{Name: "categories", Kind: provider.KindObject, Fields: []provider.Field{
+		{Name: "category", Kind: provider.KindString, List: true},
+	}},
+	{Name: "link", Kind: provider.KindString},
+	{Name: "thumbnail", Kind: provider.KindString},
+	{Name: "author", Kind: provider.KindString},
 }
 
 // Stats holds content metrics (varies by type).
 type Stats struct {
-	// Video stats
+	// Video stats (views and likes also for images, likes and duration for podcasts)
 	Views    int    `xml:"views"`
 	Likes    int    `xml:"likes"`
 	Duration string `xml:"duration"`
 
+	// Podcast stats
+	Listens int `xml:"listens"`
+
 	// Article stats
 	ReadingTime int `xml:"reading_time"`
 	Reactions   int `xml:"reactions"`
@@ -60,12 +102,15 @@ func (i *Item) ToDomain(providerID string) *domain.Content {
 	publishedAt, _ := time.Parse("2006-01-02", i.PublicationDate)
 
 	content := &domain.Content{
-		ProviderID:  providerID,
-		ExternalID:  i.ID,
-		Title:       i.Headline,
-		Type:        domain.ContentType(i.Type),
-		Tags:        i.Categories.Category,
-		PublishedAt: publishedAt,
+		ProviderID:   providerID,
+		ExternalID:   i.ID,
+		Title:        i.Headline,
+		Type:         domain.ContentType(i.Type),
+		Tags:         i.Categories.Category,
+		URL:          i.Link,
+		ThumbnailURL: i.Thumbnail,
+		Author:       i.Author,
+		PublishedAt:  publishedAt,
 	}
 
 	// Set type-specific metrics
@@ -73,12 +118,199 @@ func (i *Item) ToDomain(providerID string) *domain.Content {
 	case "video":
 		content.Views = i.Stats.Views
 		content.Likes = i.Stats.Likes
-		content.Duration = i.Stats.Duration
+		content.SetDuration(i.Stats.Duration)
 	case "article":
 		content.ReadingTime = i.Stats.ReadingTime
 		content.Reactions = i.Stats.Reactions
 		content.Comments = i.Stats.Comments
+	case "podcast":
+		content.Listens = i.Stats.Listens
+		content.Likes = i.Stats.Likes
+		content.SetDuration(i.Stats.Duration)
+	case "image":
+		content.Views = i.Stats.Views
+		content.Likes = i.Stats.Likes
 	}
 
 	return content
 }
+
+// SchemaErrors implements domain.SchemaCheckedItem.
+func (i *Item) SchemaErrors() domain.ValidationErrors {
+	return i.schemaErrors
+}
+
+// Payload implements domain.SchemaCheckedItem.
+func (i *Item) Payload() []byte {
+	return i.payload
+}
+
+// node is an XML element decoded without a schema.
+type node struct {
+	XMLName xml.Name
+	Inner   []byte `xml:",innerxml"`
+	Text    string `xml:",chardata"`
+	Nodes   []node `xml:",any"`
+}
+
+// tree converts the element n to the value Schema.Check validates, as if it were
+// JSON: elements with child elements become objects, the elements of List fields
+// lists, and the text of other elements strings, or numbers for KindInt fields.
+// Child elements unknown to fields are converted too, so they are reported.
+func (n *node) tree(f provider.Field) any {
+	if len(n.Nodes) == 0 && (f.Kind != provider.KindObject || strings.TrimSpace(n.Text) != "") {
+		if f.Kind == provider.KindInt {
+			return json.Number(strings.TrimSpace(n.Text))
+		}
+
+		return n.Text
+	}
+
+	fields := make(map[string]provider.Field, len(f.Fields))
+	for _, child := range f.Fields {
+		fields[child.Name] = child
+	}
+	obj := make(map[string]any, len(n.Nodes))
+	for i := range n.Nodes {
+		child := &n.Nodes[i]
+		name := child.XMLName.Local
+		childField, ok := fields[name]
+		if !ok {
+			childField = provider.Field{Name: name, Kind: provider.KindObject}
+		}
+		value := child.tree(childField)
+
+		switch prev, seen := obj[name]; {
+		case childField.List && !seen:
+			obj[name] = []any{value}
+		case childField.List, seen:
+			// Repeated elements other than lists' make a list too, reported as such
+			list, isList := prev.([]any)
+			if !isList {
+				list = []any{prev}
+			}
+			obj[name] = append(list, value)
+		default:
+			obj[name] = value
+		}
+	}
+
+	return obj
+}
+
+// decodeItem decodes the item element start from dec, checked against schema when
+// strict.
+func decodeItem(dec *xml.Decoder, start *xml.StartElement, strict bool) (*Item, error) {
+	item := &Item{}
+	if !strict {
+		return item, dec.DecodeElement(item, start)
+	}
+
+	var n node
+	if err := dec.DecodeElement(&n, start); err != nil {
+		return nil, err
+	}
+	payload := append(append([]byte("<item>"), n.Inner...), "</item>"...)
+
+	if errs := schema.Check(n.tree(provider.Field{Kind: provider.KindObject, Fields: schema})); len(errs) > 0 {
+		for _, child := range n.Nodes {
+			if child.XMLName.Local == "id" && len(child.Nodes) == 0 {
+				item.ID = child.Text
+			}
+		}
+		item.schemaErrors = errs
+		item.payload = payload
+
+		return item, nil
+	}
+
+	return item, xml.Unmarshal(payload, item)
+}
+
+// newDecoder returns a decoder of an XML body converting it to UTF-8 from the
+// charset named by contentType or, without one, by the document's declaration.
+// The Content-Type takes precedence (RFC 7303), so a body converted per its
+// header isn't converted again per its declaration.
+func newDecoder(body io.Reader, contentType string) (*xml.Decoder, error) {
+	var charset string
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		charset = params["charset"]
+	}
+	if charset != "" {
+		r, err := utf8Reader(body, charset)
+		if err != nil {
+			return nil, err
+		}
+		body = r
+	}
+
+	dec := xml.NewDecoder(body)
+	dec.CharsetReader = func(declared string, input io.Reader) (io.Reader, error) {
+		if charset != "" {
+			return input, nil
+		}
+
+		return utf8Reader(input, declared)
+	}
+
+	return dec, nil
+}
+
+// utf8Reader returns r converted to UTF-8 from the named charset, resolved by the
+// WHATWG encoding labels (e.g. ISO-8859-9 decodes as windows-1254, its superset).
+func utf8Reader(r io.Reader, charset string) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	if enc == unicode.UTF8 {
+		return r, nil
+	}
+
+	return enc.NewDecoder().Reader(r), nil
+}
+
+// decodeItems decodes a Feed from dec one item at a time, handing each
+// <feed><items><item> to fn instead of holding them all. Other elements are skipped.
+// When strict, items breaking schema are handed over with their errors and payload,
+// and only their ID decoded.
+func decodeItems(dec *xml.Decoder, strict bool, fn func(item *Item) error) error {
+	var path []string // Names of the open elements
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if len(path) == 0 {
+				return errors.New("no <feed> element")
+			}
+
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(path) == 0 && t.Name.Local != "feed" {
+				return fmt.Errorf("expected element type <feed> but have <%s>", t.Name.Local)
+			}
+			if len(path) == 2 && path[1] == "items" && t.Name.Local == "item" {
+				item, err := decodeItem(dec, &t, strict)
+				if err != nil {
+					return err
+				}
+				if err := fn(item); err != nil {
+					return err
+				}
+
+				continue
+			}
+			path = append(path, t.Name.Local)
+		case xml.EndElement:
+			path = path[:len(path)-1]
+			if len(path) == 0 {
+				return nil // </feed>
+			}
+		}
+	}
+}
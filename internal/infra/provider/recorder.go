@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/logger"
+)
+
+// recordingExt is the file extension of recorded responses.
+const recordingExt = ".http"
+
+// recordingLayout names recordings by the time they started, sorting oldest first.
+const recordingLayout = "20060102T150405.000000000Z"
+
+// Recorder writes provider responses to disk as they are read, so the sync that
+// read them can be replayed (see ClientConfig.Replay), e.g. to find out why scores
+// changed. Each response goes to <dir>/<provider>/<time>.http and is kept as
+// received: status line, headers, and the body before decompression, in the
+// format read by http.ReadResponse.
+type Recorder struct {
+	dir    string
+	keep   int
+	now    func() time.Time
+	logger *zap.Logger
+}
+
+// NewRecorder creates a Recorder writing under dir and keeping the latest keep
+// recordings of each provider (0 or less keeps them all).
+func NewRecorder(dir string, keep int, logger *zap.Logger) *Recorder {
+	return &Recorder{dir: dir, keep: keep, now: time.Now, logger: logger}
+}
+
+// record returns the body of resp, copying what's read of it to a new recording.
+// Recording failures are logged rather than returned, so they don't fail syncs.
+// A body left unread, e.g. by a failed sync, leaves a truncated recording.
+func (r *Recorder) record(ctx context.Context, name string, resp *http.Response) io.ReadCloser {
+	log := logger.FromContext(ctx, r.logger).With(zap.String("provider", name))
+
+	dir := filepath.Join(r.dir, name)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		log.Warn("failed to record provider response", zap.Error(err))
+
+		return resp.Body
+	}
+	path := filepath.Join(dir, r.now().UTC().Format(recordingLayout)+recordingExt)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		log.Warn("failed to record provider response", zap.Error(err))
+
+		return resp.Body
+	}
+	if err := writeResponseHead(f, resp); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		log.Warn("failed to record provider response", zap.Error(err))
+
+		return resp.Body
+	}
+
+	log.Info("recording provider response", zap.String("path", path))
+	r.prune(log, dir)
+
+	return &recordedBody{ReadCloser: resp.Body, file: f, logger: log}
+}
+
+// writeResponseHead writes the status line and headers of resp to w. The body is
+// written as is, up to the end of the file, so its framing headers are left out.
+func writeResponseHead(w io.Writer, resp *http.Response) error {
+	if _, err := fmt.Fprintf(w, "HTTP/%d.%d %s\r\n", resp.ProtoMajor, resp.ProtoMinor, resp.Status); err != nil {
+		return err
+	}
+	header := resp.Header.Clone()
+	header.Del("Content-Length")
+	header.Del("Transfer-Encoding")
+	if err := header.Write(w); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\r\n")
+
+	return err
+}
+
+// prune removes the oldest recordings in dir beyond the latest keep.
+func (r *Recorder) prune(log *zap.Logger, dir string) {
+	if r.keep <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Warn("failed to prune recorded responses", zap.Error(err))
+
+		return
+	}
+
+	var recordings []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), recordingExt) {
+			recordings = append(recordings, e.Name())
+		}
+	}
+	slices.Sort(recordings)
+	for _, old := range recordings[:max(len(recordings)-r.keep, 0)] {
+		if err := os.Remove(filepath.Join(dir, old)); err != nil {
+			log.Warn("failed to prune recorded responses", zap.Error(err))
+		}
+	}
+}
+
+// recordedBody copies what's read of a response body to its recording. It stops
+// recording, keeping the response readable, if the recording can't be written.
+type recordedBody struct {
+	io.ReadCloser
+	file   *os.File // Nil once recording stopped
+	logger *zap.Logger
+}
+
+// Read reads from the body and writes what was read to the recording.
+func (b *recordedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 && b.file != nil {
+		if _, werr := b.file.Write(p[:n]); werr != nil {
+			b.logger.Warn("failed to record provider response", zap.Error(werr))
+			_ = b.file.Close()
+			b.file = nil
+		}
+	}
+
+	return n, err
+}
+
+// Close closes the recording, then the body.
+func (b *recordedBody) Close() error {
+	if b.file != nil {
+		if err := b.file.Close(); err != nil {
+			b.logger.Warn("failed to record provider response", zap.Error(err))
+		}
+		b.file = nil
+	}
+
+	return b.ReadCloser.Close()
+}
+
+// openRecording returns the response recorded at path by a Recorder. Closing its
+// body closes the file.
+func openRecording(path string) (*http.Response, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening recorded response: %w", err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(f), nil)
+	if err != nil {
+		_ = f.Close()
+
+		return nil, fmt.Errorf("reading recorded response %s: %w", path, err)
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{resp.Body, f}
+
+	return resp, nil
+}
+
+// CheckRecording verifies the recorded response at path can be replayed, in place
+// of the health check of a provider replaying it.
+func CheckRecording(path string) error {
+	resp, err := openRecording(path)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+const recordedFeed = `{"contents":[{"id":"v1","title":"Go"}]}`
+
+// newGzipServer serves recordedFeed gzipped, counting the requests it gets.
+func newGzipServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte(recordedFeed))
+	require.NoError(t, w.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gz.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// readBody reads the body GetBody returns for opts from the client of baseURL.
+func readBody(t *testing.T, baseURL string, opts BodyOptions) (string, *Body) {
+	t.Helper()
+
+	client := NewRestyClient(ClientConfig{BaseURL: baseURL, Timeout: 5 * time.Second}, zap.NewNop())
+	cb := NewCircuitBreaker[*resty.Response]("test", CBConfig{FailureRatio: 1})
+	body, err := GetBody(context.Background(), "test", cb, client.R(), "/feed", opts)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+
+	return string(data), body
+}
+
+func TestRecorder_RecordsResponsesForReplays(t *testing.T) {
+	var requests int
+	srv := newGzipServer(t, &requests)
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, 0, zap.NewNop())
+	recorder.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	data, _ := readBody(t, srv.URL, BodyOptions{Recorder: recorder})
+	assert.Equal(t, recordedFeed, data, "recording doesn't change what's read")
+
+	path := filepath.Join(dir, "test", "20260301T120000.000000000Z.http")
+	require.FileExists(t, path)
+
+	data, body := readBody(t, "http://127.0.0.1:1", BodyOptions{Replay: path})
+	assert.Equal(t, recordedFeed, data, "the replay is decompressed like the response")
+	assert.Equal(t, "application/json; charset=utf-8", body.ContentType)
+	assert.Equal(t, 1, requests, "replays don't call the provider")
+
+	require.NoError(t, CheckRecording(path))
+}
+
+func TestRecorder_KeepsLatestRecordings(t *testing.T) {
+	var requests int
+	srv := newGzipServer(t, &requests)
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, 2, zap.NewNop())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	for range 3 {
+		readBody(t, srv.URL, BodyOptions{Recorder: recorder})
+		now = now.Add(time.Minute)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "test"))
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"20260301T120100.000000000Z.http", "20260301T120200.000000000Z.http"}, names)
+}
+
+func TestGetBody_ReplayFailures(t *testing.T) {
+	dir := t.TempDir()
+	failed := filepath.Join(dir, "failed.http")
+	require.NoError(t, os.WriteFile(failed, []byte("HTTP/1.1 503 Service Unavailable\r\n\r\n"), 0o600))
+
+	cb := NewCircuitBreaker[*resty.Response]("test", CBConfig{FailureRatio: 1})
+	for name, path := range map[string]string{
+		"missing file": filepath.Join(dir, "missing.http"),
+		"error status": failed,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := GetBody(context.Background(), "test", cb, resty.New().R(), "/feed", BodyOptions{Replay: path})
+			assert.ErrorIs(t, err, domain.ErrProviderUnavailable)
+		})
+	}
+	assert.Error(t, CheckRecording(filepath.Join(dir, "missing.http")))
+}
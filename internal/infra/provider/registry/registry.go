@@ -1,6 +1,10 @@
 package registry
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+
 	"search-engine-service/internal/config"
 	"search-engine-service/internal/domain"
 	"search-engine-service/internal/infra/provider"
@@ -10,59 +14,85 @@ import (
 	"go.uber.org/zap"
 )
 
+// clients creates a provider client by provider type. Supporting a new provider API
+// takes a client package (request and mapping to domain.Content) and an entry here;
+// providers serving an existing API only need configuration.
+var clients = map[string]func(cfg provider.ClientConfig, logger *zap.Logger) domain.Provider{
+	"provider_a": func(cfg provider.ClientConfig, logger *zap.Logger) domain.Provider {
+		return provider_a.New(cfg, logger)
+	},
+	"provider_b": func(cfg provider.ClientConfig, logger *zap.Logger) domain.Provider {
+		return provider_b.New(cfg, logger)
+	},
+}
+
 // NewProviders creates all configured provider clients.
 // This is a factory function that centralizes provider initialization
 // while maintaining dependency injection principles.
 //
 // Parameters:
-//   - cfg: Provider configuration containing endpoints, timeouts, retry, and circuit breaker settings
+//   - cfg: Settings shared by all providers, e.g. where their responses are recorded
+//   - endpoints: Provider configurations by name, with endpoints, timeouts, retry, and circuit breaker settings
 //   - logger: Zap logger instance for structured logging
 //
-// Returns a slice of domain.Provider instances ready for use in services.
-func NewProviders(cfg config.ProviderConfig, logger *zap.Logger) []domain.Provider {
-	providers := make([]domain.Provider, 0, 2)
+// Returns a slice of domain.Provider instances ready for use in services, sorted by
+// name, or an error if a provider has an unknown type.
+func NewProviders(cfg config.ProviderConfig, endpoints map[string]config.ProviderEndpoint, logger *zap.Logger) ([]domain.Provider, error) {
+	providers := make([]domain.Provider, 0, len(endpoints))
 
-	// Provider A
-	providerA := provider_a.New(
-		provider.ClientConfig{
-			BaseURL: cfg.A.BaseURL,
-			Timeout: cfg.A.Timeout,
-			Retry: provider.RetryConfig{
-				MaxAttempts: cfg.A.Retry.MaxAttempts,
-				WaitTime:    cfg.A.Retry.WaitTime,
-				MaxWaitTime: cfg.A.Retry.MaxWaitTime,
-			},
-			CB: provider.CBConfig{
-				MaxRequests:  cfg.A.CB.MaxRequests,
-				Interval:     cfg.A.CB.Interval,
-				Timeout:      cfg.A.CB.Timeout,
-				FailureRatio: cfg.A.CB.FailureRatio,
-			},
-		},
-		logger,
-	)
-	providers = append(providers, providerA)
+	var recorder *provider.Recorder
+	if cfg.RecordDir != "" {
+		recorder = provider.NewRecorder(cfg.RecordDir, cfg.RecordKeep, logger)
+	}
 
-	// Provider B
-	providerB := provider_b.New(
-		provider.ClientConfig{
-			BaseURL: cfg.B.BaseURL,
-			Timeout: cfg.B.Timeout,
-			Retry: provider.RetryConfig{
-				MaxAttempts: cfg.B.Retry.MaxAttempts,
-				WaitTime:    cfg.B.Retry.WaitTime,
-				MaxWaitTime: cfg.B.Retry.MaxWaitTime,
-			},
-			CB: provider.CBConfig{
-				MaxRequests:  cfg.B.CB.MaxRequests,
-				Interval:     cfg.B.CB.Interval,
-				Timeout:      cfg.B.CB.Timeout,
-				FailureRatio: cfg.B.CB.FailureRatio,
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		e := endpoints[name]
+		if e.Disabled {
+			logger.Info("provider disabled", zap.String("provider", name))
+
+			continue
+		}
+
+		newClient, ok := clients[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("provider %s: unknown type %q (want one of %v)",
+				name, e.Type, slices.Sorted(maps.Keys(clients)))
+		}
+
+		// Replays aren't recorded again
+		providerRecorder := recorder
+		if e.Replay != "" {
+			logger.Info("provider replaying a recorded response", zap.String("provider", name), zap.String("path", e.Replay))
+			providerRecorder = nil
+		}
+
+		client := newClient(
+			provider.ClientConfig{
+				Name:     name,
+				Endpoint: e.Endpoint,
+				BaseURL:  e.BaseURL,
+				APIKey:   e.APIKey,
+				Timeout:  e.Timeout,
+				Retry: provider.RetryConfig{
+					MaxAttempts: e.Retry.MaxAttempts,
+					WaitTime:    e.Retry.WaitTime,
+					MaxWaitTime: e.Retry.MaxWaitTime,
+				},
+				CB: provider.CBConfig{
+					MaxRequests:  e.CB.MaxRequests,
+					Interval:     e.CB.Interval,
+					Timeout:      e.CB.Timeout,
+					FailureRatio: e.CB.FailureRatio,
+				},
+				MaxBodySize: e.MaxBodySize,
+				Strict:      e.Strict,
+				Recorder:    providerRecorder,
+				Replay:      e.Replay,
 			},
-		},
-		logger,
-	)
-	providers = append(providers, providerB)
+			logger,
+		)
+		providers = append(providers, provider.WithHealthCache(client, cfg.HealthCacheTTL))
+	}
 
-	return providers
+	return providers, nil
 }
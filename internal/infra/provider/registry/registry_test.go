@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/config"
+)
+
+func TestNewProviders(t *testing.T) {
+	providers, err := NewProviders(config.ProviderConfig{}, map[string]config.ProviderEndpoint{
+		"provider_b":   {Type: "provider_b", BaseURL: "http://localhost:8082"},
+		"provider_a":   {Type: "provider_a", BaseURL: "http://localhost:8081"},
+		"partner_news": {Type: "provider_b", BaseURL: "https://news.example.com", Endpoint: "/rss"},
+		"retired":      {Type: "provider_a", Disabled: true},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name())
+	}
+	assert.Equal(t, []string{"partner_news", "provider_a", "provider_b"}, names)
+}
+
+func TestNewProviders_UnknownType(t *testing.T) {
+	_, err := NewProviders(config.ProviderConfig{}, map[string]config.ProviderEndpoint{
+		"provider_c": {Type: "graphql"},
+	}, zap.NewNop())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider_c")
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"search-engine-service/internal/domain"
+)
+
+// Kind is the type of the values of a schema field.
+type Kind int
+
+// Kinds of schema fields.
+const (
+	KindString Kind = iota
+	KindInt         // An integer, decoded as a json.Number
+	KindDate        // A string in the field's Layout
+	KindObject      // Fields nested in the field
+)
+
+// Field is a field of a provider item's schema.
+type Field struct {
+	Name     string
+	Kind     Kind
+	Required bool    // Missing, null and empty values are errors
+	List     bool    // A list of values (repeated elements in XML), each checked
+	Layout   string  // Time layout of KindDate values
+	Fields   []Field // Of KindObject
+}
+
+// Schema lists the fields of a provider item, the way an XSD or a JSON Schema would.
+// Strict clients (ClientConfig.Strict) check each item against it before decoding
+// it: fields missing from the schema are errors too, so a field renamed upstream is
+// reported rather than read as a zero value.
+type Schema []Field
+
+// Check validates item against s. item is decoded the way encoding/json decodes into
+// an any with UseNumber: objects are map[string]any, lists []any, integers
+// json.Number. Returns an error per offending field, named by its path (e.g.
+// metrics.views or tags[2]), or nil if item conforms.
+func (s Schema) Check(item any) domain.ValidationErrors {
+	var errs domain.ValidationErrors
+	checkObject(&errs, "", s, item)
+
+	return errs
+}
+
+// checkObject checks the fields of the object v at path.
+func checkObject(errs *domain.ValidationErrors, path string, fields []Field, v any) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		addSchemaError(errs, path, "type", v, "must be an object")
+
+		return
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.Name] = true
+		checkField(errs, fieldPath(path, f.Name), f, obj[f.Name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		if !known[name] {
+			addSchemaError(errs, fieldPath(path, name), "unknown", nil, "is not in the schema")
+		}
+	}
+}
+
+// checkField checks the value v of the field f at path; nil when the field is missing.
+func checkField(errs *domain.ValidationErrors, path string, f Field, v any) {
+	if v == nil || v == "" {
+		if f.Required {
+			addSchemaError(errs, path, "required", nil, "is required")
+		}
+
+		return
+	}
+	if !f.List {
+		checkValue(errs, path, f, v)
+
+		return
+	}
+
+	list, ok := v.([]any)
+	if !ok {
+		addSchemaError(errs, path, "type", v, "must be a list")
+
+		return
+	}
+	if f.Required && len(list) == 0 {
+		addSchemaError(errs, path, "required", nil, "is required")
+	}
+	for i, item := range list {
+		checkValue(errs, fmt.Sprintf("%s[%d]", path, i), f, item)
+	}
+}
+
+// checkValue checks a single value of the field f.
+func checkValue(errs *domain.ValidationErrors, path string, f Field, v any) {
+	switch f.Kind {
+	case KindString:
+		if _, ok := v.(string); !ok {
+			addSchemaError(errs, path, "type", v, "must be a string")
+		}
+	case KindInt:
+		n, ok := v.(json.Number)
+		if !ok {
+			addSchemaError(errs, path, "type", v, "must be an integer")
+
+			return
+		}
+		if _, err := n.Int64(); err != nil {
+			addSchemaError(errs, path, "type", v, "must be an integer")
+		}
+	case KindDate:
+		s, ok := v.(string)
+		if !ok {
+			addSchemaError(errs, path, "type", v, "must be a string")
+
+			return
+		}
+		if _, err := time.Parse(f.Layout, s); err != nil {
+			addSchemaError(errs, path, "format", v, "must be a date like %s", f.Layout)
+		}
+	case KindObject:
+		checkObject(errs, path, f.Fields, v)
+	}
+}
+
+// addSchemaError appends an error of the field at path to errs.
+func addSchemaError(errs *domain.ValidationErrors, path, tag string, value any, format string, args ...any) {
+	if path == "" {
+		path = "item"
+	}
+	e := domain.ValidationError{Field: path, Tag: tag, Message: path + " " + fmt.Sprintf(format, args...)}
+	if value != nil {
+		e.Value = fmt.Sprint(value)
+	}
+	*errs = append(*errs, e)
+}
+
+// fieldPath returns the path of the field name of the object at path.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
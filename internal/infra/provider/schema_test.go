@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	{Name: "id", Kind: KindString, Required: true},
+	{Name: "published", Kind: KindDate, Required: true, Layout: "2006-01-02"},
+	{Name: "tags", Kind: KindString, List: true},
+	{Name: "metrics", Kind: KindObject, Fields: []Field{
+		{Name: "views", Kind: KindInt},
+	}},
+}
+
+func decodeTree(t *testing.T, doc string) any {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader([]byte(doc)))
+	dec.UseNumber()
+	var v any
+	require.NoError(t, dec.Decode(&v))
+
+	return v
+}
+
+func TestSchema_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		fields []string // Offending fields, in order
+		tags   []string
+	}{
+		{
+			name: "conforming item",
+			doc:  `{"id": "a", "published": "2024-01-15", "tags": ["go"], "metrics": {"views": 3}}`,
+		},
+		{
+			name: "optional fields may be missing or null",
+			doc:  `{"id": "a", "published": "2024-01-15", "tags": null}`,
+		},
+		{
+			name:   "renamed field",
+			doc:    `{"id": "a", "published": "2024-01-15", "metrics": {"view_count": 3}}`,
+			fields: []string{"metrics.view_count"},
+			tags:   []string{"unknown"},
+		},
+		{
+			name:   "missing and empty required fields",
+			doc:    `{"id": ""}`,
+			fields: []string{"id", "published"},
+			tags:   []string{"required", "required"},
+		},
+		{
+			name:   "wrong types and formats",
+			doc:    `{"id": 1, "published": "15/01/2024", "tags": ["go", 2], "metrics": {"views": 1.5}}`,
+			fields: []string{"id", "published", "tags[1]", "metrics.views"},
+			tags:   []string{"type", "format", "type", "type"},
+		},
+		{
+			name:   "not an object",
+			doc:    `["a"]`,
+			fields: []string{"item"},
+			tags:   []string{"type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := testSchema.Check(decodeTree(t, tt.doc))
+
+			var fields, tags []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+				tags = append(tags, e.Tag)
+			}
+			assert.Equal(t, tt.fields, fields)
+			assert.Equal(t, tt.tags, tags)
+		})
+	}
+}
+
+func TestSchema_CheckMessages(t *testing.T) {
+	errs := testSchema.Check(decodeTree(t, `{"id": "a", "published": "soon", "title": "x"}`))
+
+	require.Len(t, errs, 2)
+	assert.Equal(t, "published must be a date like 2006-01-02", errs[0].Message)
+	assert.Equal(t, "soon", errs[0].Value)
+	assert.Equal(t, "title is not in the schema", errs[1].Message)
+	assert.EqualError(t, errs, "published must be a date like 2006-01-02; title is not in the schema")
+}
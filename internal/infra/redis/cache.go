@@ -1,28 +1,42 @@
 package redis
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+
+	"search-engine-service/internal/logger"
 )
 
+// compressedMagic prefixes values stored gzip-compressed.
+// Values without this header are returned as-is, so entries written before
+// compression was enabled (or below the threshold) remain readable.
+var compressedMagic = []byte{0x00, 'g', 'z', 0x01}
+
 // Cache implements the domain.Cache interface using Redis.
 // It provides key-value storage with TTL support and prefix-based namespacing.
 type Cache struct {
-	client    *redis.Client
-	logger    *zap.Logger
-	keyPrefix string
+	client               *redis.Client
+	logger               *zap.Logger
+	keyPrefix            string
+	compressionThreshold int // Values at least this large are gzip-compressed; 0 disables
 }
 
 // NewCache creates a new Redis cache instance.
 // keyPrefix is used to namespace all keys and prevent collisions with other applications.
-func NewCache(client *redis.Client, logger *zap.Logger, keyPrefix string) *Cache {
+// compressionThreshold is the minimum value size in bytes that gets compressed (0 disables compression).
+func NewCache(client *redis.Client, logger *zap.Logger, keyPrefix string, compressionThreshold int) *Cache {
 	return &Cache{
-		client:    client,
-		logger:    logger,
-		keyPrefix: keyPrefix,
+		client:               client,
+		logger:               logger,
+		keyPrefix:            keyPrefix,
+		compressionThreshold: compressionThreshold,
 	}
 }
 
@@ -37,7 +51,7 @@ func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
 		return nil, nil
 	}
 	if err != nil {
-		c.logger.Error("cache get failed",
+		logger.FromContext(ctx, c.logger).Error("cache get failed",
 			zap.String("key", key),
 			zap.Error(err),
 		)
@@ -45,11 +59,25 @@ func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
 		return nil, err
 	}
 
-	c.logger.Debug("cache hit",
+	logger.FromContext(ctx, c.logger).Debug("cache hit",
 		zap.String("key", key),
 		zap.Int("bytes", len(data)),
 	)
 
+	if bytes.HasPrefix(data, compressedMagic) {
+		decoded, err := decompress(data[len(compressedMagic):])
+		if err != nil {
+			logger.FromContext(ctx, c.logger).Error("cache decompress failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+
+			return nil, err
+		}
+
+		return decoded, nil
+	}
+
 	return data, nil
 }
 
@@ -58,9 +86,26 @@ func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
 func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	fullKey := c.buildKey(key)
 
+	if c.compressionThreshold > 0 && len(value) >= c.compressionThreshold {
+		compressed, err := compress(value)
+		if err != nil {
+			logger.FromContext(ctx, c.logger).Warn("cache compress failed, storing uncompressed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		} else {
+			logger.FromContext(ctx, c.logger).Debug("cache value compressed",
+				zap.String("key", key),
+				zap.Int("raw_bytes", len(value)),
+				zap.Int("compressed_bytes", len(compressed)),
+			)
+			value = compressed
+		}
+	}
+
 	err := c.client.Set(ctx, fullKey, value, ttl).Err()
 	if err != nil {
-		c.logger.Error("cache set failed",
+		logger.FromContext(ctx, c.logger).Error("cache set failed",
 			zap.String("key", key),
 			zap.Int("bytes", len(value)),
 			zap.Duration("ttl", ttl),
@@ -70,7 +115,7 @@ func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Dura
 		return err
 	}
 
-	c.logger.Debug("cache set",
+	logger.FromContext(ctx, c.logger).Debug("cache set",
 		zap.String("key", key),
 		zap.Int("bytes", len(value)),
 		zap.Duration("ttl", ttl),
@@ -86,7 +131,7 @@ func (c *Cache) Delete(ctx context.Context, key string) error {
 
 	err := c.client.Del(ctx, fullKey).Err()
 	if err != nil {
-		c.logger.Error("cache delete failed",
+		logger.FromContext(ctx, c.logger).Error("cache delete failed",
 			zap.String("key", key),
 			zap.Error(err),
 		)
@@ -94,7 +139,7 @@ func (c *Cache) Delete(ctx context.Context, key string) error {
 		return err
 	}
 
-	c.logger.Debug("cache delete",
+	logger.FromContext(ctx, c.logger).Debug("cache delete",
 		zap.String("key", key),
 	)
 
@@ -115,7 +160,7 @@ func (c *Cache) Clear(ctx context.Context) error {
 	}
 
 	if err := iter.Err(); err != nil {
-		c.logger.Error("cache clear scan failed",
+		logger.FromContext(ctx, c.logger).Error("cache clear scan failed",
 			zap.String("pattern", pattern),
 			zap.Error(err),
 		)
@@ -127,7 +172,7 @@ func (c *Cache) Clear(ctx context.Context) error {
 	if len(keys) > 0 {
 		err := c.client.Del(ctx, keys...).Err()
 		if err != nil {
-			c.logger.Error("cache clear delete failed",
+			logger.FromContext(ctx, c.logger).Error("cache clear delete failed",
 				zap.Int("key_count", len(keys)),
 				zap.Error(err),
 			)
@@ -135,11 +180,11 @@ func (c *Cache) Clear(ctx context.Context) error {
 			return err
 		}
 
-		c.logger.Info("cache cleared",
+		logger.FromContext(ctx, c.logger).Info("cache cleared",
 			zap.Int("key_count", len(keys)),
 		)
 	} else {
-		c.logger.Debug("cache clear: no keys found",
+		logger.FromContext(ctx, c.logger).Debug("cache clear: no keys found",
 			zap.String("pattern", pattern),
 		)
 	}
@@ -151,3 +196,35 @@ func (c *Cache) Clear(ctx context.Context) error {
 func (c *Cache) buildKey(key string) string {
 	return c.keyPrefix + ":" + key
 }
+
+// compress gzip-compresses value and prepends the compressed magic header.
+func compress(value []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(compressedMagic)
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(value); err != nil {
+		return nil, fmt.Errorf("gzip write: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("gzip close: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decompress inflates a gzip payload (without the magic header).
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("gzip read: %w", err)
+	}
+
+	return decoded, nil
+}
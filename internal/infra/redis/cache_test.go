@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupTestCache(t *testing.T, compressionThreshold int) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewCache(client, zap.NewNop(), "test", compressionThreshold), mr
+}
+
+func TestCache_SetGet_BelowThreshold(t *testing.T) {
+	cache, mr := setupTestCache(t, 1024)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "k", []byte(`{"small":true}`), time.Minute))
+
+	raw, err := mr.Get("test:k")
+	require.NoError(t, err)
+	assert.Equal(t, `{"small":true}`, raw, "small values should be stored uncompressed")
+
+	data, err := cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"small":true}`), data)
+}
+
+func TestCache_SetGet_Compressed(t *testing.T) {
+	cache, mr := setupTestCache(t, 64)
+	ctx := context.Background()
+
+	value := bytes.Repeat([]byte(`{"title":"golang tutorial"},`), 100)
+	require.NoError(t, cache.Set(ctx, "k", value, time.Minute))
+
+	raw, err := mr.Get("test:k")
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix([]byte(raw), compressedMagic), "large values should carry the magic header")
+	assert.Less(t, len(raw), len(value))
+
+	data, err := cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, value, data)
+}
+
+func TestCache_Get_LegacyUncompressedValue(t *testing.T) {
+	cache, mr := setupTestCache(t, 64)
+	ctx := context.Background()
+
+	// Written by a version without compression support
+	legacy := string(bytes.Repeat([]byte("x"), 200))
+	require.NoError(t, mr.Set("test:k", legacy))
+
+	data, err := cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(legacy), data)
+}
+
+func TestCache_Get_Missing(t *testing.T) {
+	cache, _ := setupTestCache(t, 0)
+
+	data, err := cache.Get(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// subscriberBuffer is the per-subscriber channel size. Events for subscribers
+// that fall further behind are dropped rather than blocking the fan-out.
+const subscriberBuffer = 64
+
+// EventBus implements domain.ContentEventBus using Redis pub/sub.
+// A single Redis subscription per instance is fanned out to local subscribers,
+// so the number of Redis connections doesn't grow with connected clients.
+type EventBus struct {
+	client  *redis.Client
+	logger  *zap.Logger
+	channel string
+
+	mu          sync.Mutex
+	subscribers map[chan domain.ContentEvent]struct{}
+	pubsub      *redis.PubSub
+	closed      bool
+}
+
+// NewEventBus creates a new Redis-backed event bus.
+// keyPrefix namespaces the pub/sub channel like cache keys.
+func NewEventBus(client *redis.Client, logger *zap.Logger, keyPrefix string) *EventBus {
+	return &EventBus{
+		client:      client,
+		logger:      logger,
+		channel:     keyPrefix + ":events:content",
+		subscribers: make(map[chan domain.ContentEvent]struct{}),
+	}
+}
+
+// Publish broadcasts events on the Redis channel.
+func (b *EventBus) Publish(ctx context.Context, events []domain.ContentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, e := range events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("marshaling content event: %w", err)
+			}
+			pipe.Publish(ctx, b.channel, data)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("publishing content events: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe registers a local subscriber. The Redis subscription is started on first use.
+func (b *EventBus) Subscribe(ctx context.Context) (<-chan domain.ContentEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, fmt.Errorf("event bus closed")
+	}
+
+	if b.pubsub == nil {
+		pubsub := b.client.Subscribe(context.Background(), b.channel)
+		// Wait for confirmation so events published right after Subscribe returns aren't missed
+		if _, err := pubsub.Receive(ctx); err != nil {
+			_ = pubsub.Close()
+
+			return nil, fmt.Errorf("subscribing to content events: %w", err)
+		}
+		b.pubsub = pubsub
+		go b.fanOut(pubsub.Channel())
+	}
+
+	ch := make(chan domain.ContentEvent, subscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+
+	return ch, nil
+}
+
+// Close stops the Redis subscription and closes all subscriber channels.
+func (b *EventBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+
+	if b.pubsub != nil {
+		return b.pubsub.Close()
+	}
+
+	return nil
+}
+
+// fanOut delivers messages from the Redis subscription to local subscribers.
+func (b *EventBus) fanOut(messages <-chan *redis.Message) {
+	for msg := range messages {
+		var event domain.ContentEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			b.logger.Warn("invalid content event payload", zap.Error(err))
+
+			continue
+		}
+
+		b.mu.Lock()
+		for ch := range b.subscribers {
+			select {
+			case ch <- event:
+			default:
+				b.logger.Debug("dropping content event for slow subscriber")
+			}
+		}
+		b.mu.Unlock()
+	}
+}
+
+// unsubscribe removes and closes a subscriber channel.
+func (b *EventBus) unsubscribe(ch chan domain.ContentEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,97 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+func setupTestEventBus(t *testing.T) *EventBus {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	bus := NewEventBus(client, zap.NewNop(), "test")
+	t.Cleanup(func() { _ = bus.Close() })
+
+	return bus
+}
+
+func receive(t *testing.T, ch <-chan domain.ContentEvent) domain.ContentEvent {
+	t.Helper()
+
+	select {
+	case e, ok := <-ch:
+		require.True(t, ok, "channel closed")
+
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+
+		return domain.ContentEvent{}
+	}
+}
+
+func TestEventBus_PublishFansOutToSubscribers(t *testing.T) {
+	bus := setupTestEventBus(t)
+	ctx := context.Background()
+
+	first, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+	second, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+
+	event := domain.ContentEvent{
+		Type:    domain.EventContentCreated,
+		Content: &domain.Content{ID: "id-1", Title: "Go Generics", Type: domain.ContentTypeArticle},
+	}
+	require.NoError(t, bus.Publish(ctx, []domain.ContentEvent{event}))
+
+	for _, ch := range []<-chan domain.ContentEvent{first, second} {
+		got := receive(t, ch)
+		assert.Equal(t, domain.EventContentCreated, got.Type)
+		assert.Equal(t, "id-1", got.Content.ID)
+	}
+}
+
+func TestEventBus_SubscriptionClosedWithContext(t *testing.T) {
+	bus := setupTestEventBus(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := bus.Subscribe(ctx)
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription not closed")
+	}
+}
+
+func TestEventBus_CloseEndsSubscriptions(t *testing.T) {
+	bus := setupTestEventBus(t)
+
+	ch, err := bus.Subscribe(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Close())
+
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	_, err = bus.Subscribe(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"search-engine-service/internal/domain"
+)
+
+// IdempotencyStore implements domain.IdempotencyStore using Redis.
+// Records live outside the cache namespace so cache clears don't drop them.
+type IdempotencyStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewIdempotencyStore creates a new Redis idempotency store.
+func NewIdempotencyStore(client *redis.Client, keyPrefix string) *IdempotencyStore {
+	return &IdempotencyStore{
+		client:    client,
+		keyPrefix: keyPrefix + ":idempotency:",
+	}
+}
+
+// Begin claims key with an in-progress record using SET NX.
+func (s *IdempotencyStore) Begin(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl time.Duration,
+) (*domain.IdempotencyRecord, bool, error) {
+	pending, err := json.Marshal(domain.IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, fmt.Errorf("marshaling idempotency record: %w", err)
+	}
+
+	fullKey := s.keyPrefix + key
+
+	acquired, err := s.client.SetNX(ctx, fullKey, pending, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("claiming idempotency key: %w", err)
+	}
+	if acquired {
+		return nil, true, nil
+	}
+
+	data, err := s.client.Get(ctx, fullKey).Bytes()
+	if err == redis.Nil {
+		// Expired or released between SETNX and GET; the caller may retry
+		return nil, false, fmt.Errorf("idempotency key vanished while reading")
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading idempotency record: %w", err)
+	}
+
+	var record domain.IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false, fmt.Errorf("decoding idempotency record: %w", err)
+	}
+
+	return &record, false, nil
+}
+
+// Complete overwrites the claim with the final response.
+func (s *IdempotencyStore) Complete(ctx context.Context, key string, record *domain.IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshaling idempotency record: %w", err)
+	}
+
+	if err := s.client.Set(ctx, s.keyPrefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("storing idempotency record: %w", err)
+	}
+
+	return nil
+}
+
+// Release deletes the claim.
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("releasing idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestIdempotencyStore_Lifecycle(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewIdempotencyStore(client, "test")
+	ctx := context.Background()
+
+	_, acquired, err := store.Begin(ctx, "k", "fp", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	assert.True(t, mr.Exists("test:idempotency:k"))
+
+	record, acquired, err := store.Begin(ctx, "k", "fp", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.False(t, record.Completed)
+
+	require.NoError(t, store.Complete(ctx, "k", &domain.IdempotencyRecord{
+		Fingerprint: "fp", Completed: true, Status: 200, Body: []byte(`{"ok":true}`),
+	}, time.Hour))
+
+	record, _, err = store.Begin(ctx, "k", "fp", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, record.Completed)
+	assert.Equal(t, []byte(`{"ok":true}`), record.Body)
+
+	require.NoError(t, store.Release(ctx, "k"))
+	_, acquired, err = store.Begin(ctx, "k", "fp", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// quotaRetention is how long monthly request counts are kept, so usage can be
+// reported for the past year.
+const quotaRetention = 400 * 24 * time.Hour
+
+// QuotaStore implements domain.QuotaStore using Redis, a counter per API key and
+// month. Counters live outside the cache namespace so cache clears don't drop them.
+type QuotaStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewQuotaStore creates a new Redis quota store.
+func NewQuotaStore(client *redis.Client, keyPrefix string) *QuotaStore {
+	return &QuotaStore{
+		client:    client,
+		keyPrefix: keyPrefix + ":quota:",
+	}
+}
+
+// Increment counts a request with INCR, renewing the counter's expiry.
+func (s *QuotaStore) Increment(ctx context.Context, name, month string) (int64, error) {
+	key := s.key(name, month)
+
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, quotaRetention)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("counting quota request: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// Counts reads the counters of names with MGET.
+func (s *QuotaStore) Counts(ctx context.Context, month string, names []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(names))
+	if len(names) == 0 {
+		return counts, nil
+	}
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = s.key(name, month)
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading quota counts: %w", err)
+	}
+
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("reading quota count of %s: %w", names[i], err)
+		}
+		counts[names[i]] = n
+	}
+
+	return counts, nil
+}
+
+// key returns the counter of the API key name in month.
+func (s *QuotaStore) key(name, month string) string {
+	return s.keyPrefix + month + ":" + name
+}
@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaStore_CountsPerKeyAndMonth(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewQuotaStore(client, "test")
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := store.Increment(ctx, "partner-a", "2026-10")
+		require.NoError(t, err)
+	}
+	n, err := store.Increment(ctx, "partner-a", "2026-10")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = store.Increment(ctx, "partner-a", "2026-11")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "each month starts over")
+	assert.Positive(t, mr.TTL("test:quota:2026-10:partner-a"))
+
+	counts, err := store.Counts(ctx, "2026-10", []string{"partner-a", "partner-b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"partner-a": 3}, counts)
+}
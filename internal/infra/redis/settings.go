@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"search-engine-service/internal/domain"
+)
+
+// SettingsStore implements domain.SettingsStore using Redis.
+// Settings live outside the cache namespace so cache clears don't reset them.
+type SettingsStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewSettingsStore creates a new Redis settings store.
+func NewSettingsStore(client *redis.Client, keyPrefix string) *SettingsStore {
+	return &SettingsStore{
+		client: client,
+		key:    keyPrefix + ":settings",
+	}
+}
+
+// Get returns the stored settings, or nil if none were saved.
+func (s *SettingsStore) Get(ctx context.Context) (*domain.RuntimeSettings, error) {
+	data, err := s.client.Get(ctx, s.key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading settings: %w", err)
+	}
+
+	var settings domain.RuntimeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("decoding settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// Save stores settings without expiration.
+func (s *SettingsStore) Save(ctx context.Context, settings *domain.RuntimeSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("marshaling settings: %w", err)
+	}
+
+	if err := s.client.Set(ctx, s.key, data, 0).Err(); err != nil {
+		return fmt.Errorf("storing settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestSettingsStore_SaveAndGet(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewSettingsStore(client, "test")
+	ctx := context.Background()
+
+	settings, err := store.Get(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, settings, "nothing saved yet")
+
+	saved := &domain.RuntimeSettings{
+		CacheEnabled:    false,
+		CacheSearchTTL:  5 * time.Minute,
+		ScoringStrategy: domain.ScoringText,
+		UpdatedAt:       time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(ctx, saved))
+	assert.True(t, mr.Exists("test:settings"))
+	assert.Zero(t, mr.TTL("test:settings"), "settings must not expire")
+
+	settings, err = store.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, saved, settings)
+}
@@ -0,0 +1,104 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"search-engine-service/internal/domain"
+)
+
+// FileStore implements domain.SnapshotStore with a directory, e.g. on a volume or a
+// mounted bucket.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a new FileStore keeping snapshots in dir, created if missing.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating snapshot directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// Put writes r to a temporary file renamed to name once complete, so a failed
+// snapshot never shows up.
+func (s *FileStore) Put(ctx context.Context, name string, r io.Reader) (err error) {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: r}); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("storing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Get opens the snapshot file name.
+func (s *FileStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot %s: %w", name, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening snapshot: %w", err)
+	}
+
+	return f, nil
+}
+
+// List returns the snapshot files of the directory, newest first. Temporary files of
+// snapshots being written are left out.
+func (s *FileStore) List(_ context.Context) ([]domain.SnapshotInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	var snapshots []domain.SnapshotInfo
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("listing snapshots: %w", err)
+		}
+		snapshots = append(snapshots, domain.SnapshotInfo{Name: e.Name(), Size: info.Size(), ModifiedAt: info.ModTime().UTC()})
+	}
+	sortNewestFirst(snapshots)
+
+	return snapshots, nil
+}
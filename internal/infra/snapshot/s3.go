@@ -0,0 +1,326 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"search-engine-service/internal/domain"
+)
+
+// emptySHA256 is the hex SHA-256 of an empty payload.
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config locates the bucket of an S3Store.
+type S3Config struct {
+	Bucket   string
+	Prefix   string // Prepended to snapshot names to form object keys, e.g. snapshots/
+	Region   string // Defaults to AWS_REGION, then AWS_DEFAULT_REGION
+	Endpoint string // Defaults to https://s3.<region>.amazonaws.com; set for S3-compatible stores (e.g. MinIO)
+}
+
+// S3Store implements domain.SnapshotStore with an S3 bucket, or one of an S3-compatible
+// object store, addressed path-style. Requests are signed with the static credentials of
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, as secret://aws
+// references are.
+type S3Store struct {
+	cfg    S3Config
+	creds  awsCredentials
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Store creates a new S3Store for the bucket of cfg.
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("snapshot bucket is not set")
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_REGION")
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("snapshot bucket region is not set (or AWS_REGION)")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	creds := awsCredentials{
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.accessKeyID == "" || creds.secretAccessKey == "" {
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for the snapshot bucket")
+	}
+
+	// Snapshots take a while to transfer, so only the connection and headers are
+	// bounded; the context bounds the rest
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = time.Minute
+
+	return &S3Store{cfg: cfg, creds: creds, client: &http.Client{Transport: transport}, now: time.Now}, nil
+}
+
+// Put spools r to a temporary file, since S3 needs the length of an object up front,
+// then uploads it in a single request; objects are limited to 5 GB.
+func (s *S3Store) Put(ctx context.Context, name string, r io.Reader) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "snapshot-*")
+	if err != nil {
+		return fmt.Errorf("spooling snapshot: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return fmt.Errorf("spooling snapshot: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("spooling snapshot: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(name), io.NopCloser(tmp))
+	if err != nil {
+		return fmt.Errorf("uploading snapshot: %w", err)
+	}
+	req.ContentLength = size
+	resp, err := s.do(req, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return fmt.Errorf("uploading snapshot: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// Get downloads the object of the snapshot name.
+func (s *S3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(name), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("downloading snapshot: %w", err)
+	}
+	resp, err := s.do(req, emptySHA256)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("snapshot %s: %w", name, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("downloading snapshot: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+// List returns the objects under the prefix, newest first.
+func (s *S3Store) List(ctx context.Context) ([]domain.SnapshotInfo, error) {
+	var snapshots []domain.SnapshotInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.cfg.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.bucketURL()+"?"+query.Encode(), http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("listing snapshots: %w", err)
+		}
+		resp, err := s.do(req, emptySHA256)
+		if err != nil {
+			return nil, fmt.Errorf("listing snapshots: %w", err)
+		}
+
+		var page struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listing snapshots: decoding response: %w", err)
+		}
+
+		for _, o := range page.Contents {
+			name := strings.TrimPrefix(o.Key, s.cfg.Prefix)
+			if checkName(name) != nil {
+				// In a "subdirectory" of the prefix
+				continue
+			}
+			snapshots = append(snapshots, domain.SnapshotInfo{Name: name, Size: o.Size, ModifiedAt: o.LastModified.UTC()})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+	sortNewestFirst(snapshots)
+
+	return snapshots, nil
+}
+
+// bucketURL returns the path-style URL of the bucket.
+func (s *S3Store) bucketURL() string {
+	return s.cfg.Endpoint + "/" + awsEscape(s.cfg.Bucket, false)
+}
+
+// objectURL returns the URL of the object of the snapshot name.
+func (s *S3Store) objectURL(name string) string {
+	return s.bucketURL() + "/" + awsEscape(s.cfg.Prefix+name, true)
+}
+
+// do signs and sends req, whose payload has the hex SHA-256 payloadHash. Responses
+// other than 200 are closed and returned as errors, 404 as domain.ErrNotFound.
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signS3Request(req, payloadHash, s.creds, s.cfg.Region, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&s3Err)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrNotFound
+	}
+
+	return nil, fmt.Errorf("unexpected status %d: %s %s", resp.StatusCode, s3Err.Code, s3Err.Message)
+}
+
+// awsCredentials are static AWS credentials.
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// signS3Request adds an AWS Signature Version 4 Authorization header to req for S3,
+// whose payload has the hex SHA-256 payloadHash. The path of req must be escaped
+// with awsEscape.
+func signS3Request(req *http.Request, payloadHash string, creds awsCredentials, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	// Headers are signed in lexical order of their lower-cased names
+	headers := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if creds.sessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		value := req.Header.Get(h)
+		if h == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(h + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(canonicalHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery returns query with its parameters sorted and escaped for signing.
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, v := range values {
+			params = append(params, awsEscape(key, false)+"="+awsEscape(v, false))
+		}
+	}
+	slices.Sort(params)
+
+	return strings.Join(params, "&")
+}
+
+// awsEscape percent-encodes every byte of s but the unreserved characters, and '/'
+// if keepSlash is set, as Signature Version 4 requires.
+func awsEscape(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,65 @@
+// Package snapshot provides the stores keeping content snapshots.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"search-engine-service/internal/config"
+	"search-engine-service/internal/domain"
+)
+
+// NewStore creates the snapshot store of cfg.
+func NewStore(cfg config.SnapshotConfig) (domain.SnapshotStore, error) {
+	switch cfg.Store {
+	case config.SnapshotStoreFile:
+		return NewFileStore(cfg.Dir)
+	case config.SnapshotStoreS3:
+		return NewS3Store(S3Config{
+			Bucket:   cfg.S3.Bucket,
+			Prefix:   cfg.S3.Prefix,
+			Region:   cfg.S3.Region,
+			Endpoint: cfg.S3.Endpoint,
+		})
+	default:
+		return nil, fmt.Errorf("unknown snapshot.store %q", cfg.Store)
+	}
+}
+
+// checkName rejects snapshot names that could leave the store's directory or prefix.
+func checkName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%w: invalid snapshot name %q", domain.ErrInvalidParams, name)
+	}
+
+	return nil
+}
+
+// sortNewestFirst sorts snapshots by modification time, newest first, then by name.
+func sortNewestFirst(snapshots []domain.SnapshotInfo) {
+	slices.SortFunc(snapshots, func(a, b domain.SnapshotInfo) int {
+		if c := b.ModifiedAt.Compare(a.ModifiedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(b.Name, a.Name)
+	})
+}
+
+// contextReader stops reading r once ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read reads from r unless ctx is done.
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
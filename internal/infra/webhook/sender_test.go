@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestSender_SendSignsPayload(t *testing.T) {
+	var (
+		gotBody    []byte
+		gotHeaders http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(time.Second)
+	hook := &domain.Webhook{URL: srv.URL, Secret: "s3cret"}
+	event := domain.WebhookEvent{ID: "evt-1", Type: domain.EventSyncCompleted, Data: map[string]int{"count": 3}}
+
+	require.NoError(t, sender.Send(context.Background(), hook, event))
+
+	assert.Equal(t, "evt-1", gotHeaders.Get(HeaderEventID))
+	assert.Equal(t, domain.EventSyncCompleted, gotHeaders.Get(HeaderEventType))
+	assert.JSONEq(t, `{"id":"evt-1","type":"sync.completed","occurred_at":"0001-01-01T00:00:00Z","data":{"count":3}}`, string(gotBody))
+
+	timestamp, err := strconv.ParseInt(gotHeaders.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("s3cret", timestamp, gotBody), gotHeaders.Get(HeaderSignature))
+}
+
+func TestSender_SendNon2xxIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewSender(time.Second).Send(context.Background(), &domain.Webhook{URL: srv.URL}, domain.WebhookEvent{})
+	assert.ErrorContains(t, err, "status 502")
+}
+
+func TestSign(t *testing.T) {
+	sig := Sign("secret", 1700000000, []byte(`{}`))
+
+	assert.Equal(t, sig, Sign("secret", 1700000000, []byte(`{}`)))
+	assert.NotEqual(t, sig, Sign("other", 1700000000, []byte(`{}`)))
+	assert.NotEqual(t, sig, Sign("secret", 1700000001, []byte(`{}`)))
+	assert.Len(t, sig, len("sha256=")+64)
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/pkg/locker"
+)
+
+// CTRScheduler periodically refreshes the CTR boosts of content scores, using a
+// distributed lock so only one instance runs each refresh.
+type CTRScheduler struct {
+	ctrService *service.CTRService
+	interval   time.Duration
+	timeout    time.Duration
+	logger     *zap.Logger
+	locker     locker.DistributedLocker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// CTRSchedulerConfig holds CTR scheduler configuration.
+type CTRSchedulerConfig struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// NewCTRScheduler creates a new CTRScheduler with distributed locking support.
+func NewCTRScheduler(
+	ctrSvc *service.CTRService,
+	cfg CTRSchedulerConfig,
+	logger *zap.Logger,
+	locker locker.DistributedLocker,
+) *CTRScheduler {
+	return &CTRScheduler{
+		ctrService: ctrSvc,
+		interval:   cfg.Interval,
+		timeout:    cfg.Timeout,
+		logger:     logger,
+		locker:     locker,
+	}
+}
+
+// Start begins the background refresh job. The first refresh runs immediately.
+func (s *CTRScheduler) Start() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.logger.Info("starting ctr scheduler", zap.Duration("interval", s.interval))
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler.
+func (s *CTRScheduler) Stop() {
+	s.logger.Info("stopping ctr scheduler")
+	s.cancel()
+	s.wg.Wait()
+	s.logger.Info("ctr scheduler stopped")
+}
+
+// run is the main loop of the scheduler.
+func (s *CTRScheduler) run() {
+	defer s.wg.Done()
+
+	s.executeRefresh()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.executeRefresh()
+		}
+	}
+}
+
+// executeRefresh refreshes the CTR boosts with distributed locking and timeout.
+// Like executeSync, the lock is held for the interval on success and released on failure.
+func (s *CTRScheduler) executeRefresh() {
+	const lockKey = "ctr:scheduler:lock"
+
+	err := locker.WithLock(s.ctx, s.locker, lockKey, s.interval, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+
+		_, err := s.ctrService.Refresh(ctx)
+
+		return err
+	}, locker.HoldOnSuccess())
+
+	switch {
+	case errors.Is(err, locker.ErrLockHeld):
+		s.logger.Debug("another instance is refreshing ctr boosts, skipping execution")
+	case err != nil:
+		s.logger.Warn("ctr refresh failed, lock released for retry", zap.Error(err))
+	}
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/pkg/locker"
+)
+
+// DictionaryScheduler periodically rebuilds the term dictionary, using a distributed
+// lock so only one instance rebuilds it each interval. The other instances load the
+// rebuilt dictionary into memory instead.
+type DictionaryScheduler struct {
+	dictionary *service.DictionaryService
+	interval   time.Duration
+	timeout    time.Duration
+	logger     *zap.Logger
+	locker     locker.DistributedLocker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// DictionarySchedulerConfig holds dictionary scheduler configuration.
+type DictionarySchedulerConfig struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// NewDictionaryScheduler creates a new DictionaryScheduler with distributed locking support.
+func NewDictionaryScheduler(
+	dictionarySvc *service.DictionaryService,
+	cfg DictionarySchedulerConfig,
+	logger *zap.Logger,
+	locker locker.DistributedLocker,
+) *DictionaryScheduler {
+	return &DictionaryScheduler{
+		dictionary: dictionarySvc,
+		interval:   cfg.Interval,
+		timeout:    cfg.Timeout,
+		logger:     logger,
+		locker:     locker,
+	}
+}
+
+// Start begins the background rebuild job. The first rebuild, or load, runs immediately.
+func (s *DictionaryScheduler) Start() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.logger.Info("starting dictionary scheduler", zap.Duration("interval", s.interval))
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler.
+func (s *DictionaryScheduler) Stop() {
+	s.logger.Info("stopping dictionary scheduler")
+	s.cancel()
+	s.wg.Wait()
+	s.logger.Info("dictionary scheduler stopped")
+}
+
+// run is the main loop of the scheduler.
+func (s *DictionaryScheduler) run() {
+	defer s.wg.Done()
+
+	s.executeRebuild()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.executeRebuild()
+		}
+	}
+}
+
+// executeRebuild rebuilds the dictionary with distributed locking and timeout.
+// Like executeSync, the lock is held for the interval on success and released on failure.
+// When another instance holds the lock, the stored dictionary is loaded instead, so
+// every instance catches up with the last rebuild within an interval.
+func (s *DictionaryScheduler) executeRebuild() {
+	const lockKey = "dictionary:scheduler:lock"
+
+	err := locker.WithLock(s.ctx, s.locker, lockKey, s.interval, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+
+		_, err := s.dictionary.Rebuild(ctx)
+
+		return err
+	}, locker.HoldOnSuccess())
+
+	switch {
+	case errors.Is(err, locker.ErrLockHeld):
+		s.logger.Debug("another instance is rebuilding the term dictionary, loading it")
+		s.load()
+	case err != nil:
+		s.logger.Warn("term dictionary rebuild failed, lock released for retry", zap.Error(err))
+	}
+}
+
+// load loads the stored dictionary, logging failures.
+func (s *DictionaryScheduler) load() {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	if err := s.dictionary.Load(ctx); err != nil {
+		s.logger.Warn("term dictionary load failed", zap.Error(err))
+	}
+}
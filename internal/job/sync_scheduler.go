@@ -3,12 +3,16 @@ package job
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/metrics"
 	"search-engine-service/pkg/locker"
 )
 
@@ -16,14 +20,25 @@ import (
 // to ensure only one instance executes sync jobs at a time.
 type SyncScheduler struct {
 	syncService *service.SyncService
-	interval    time.Duration
 	timeout     time.Duration
+	events      domain.EventPublisher
 	logger      *zap.Logger
 	locker      locker.DistributedLocker
 
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	// Consecutive failed syncs per provider, only touched by the run goroutine
+	failureAlertThreshold int
+	failures              map[string]int
+
+	mu              sync.Mutex // Guards interval
+	interval        time.Duration
+	intervalChanged chan struct{}
+
+	// ctx ends the loop; syncCtx, the running sync, once Stop stops waiting for it
+	ctx        context.Context
+	cancel     context.CancelFunc
+	syncCtx    context.Context
+	cancelSync context.CancelCauseFunc
+	wg         sync.WaitGroup
 }
 
 // SyncConfig holds sync scheduler configuration.
@@ -31,6 +46,11 @@ type SyncConfig struct {
 	Interval  time.Duration
 	Timeout   time.Duration
 	OnStartup bool
+
+	// FailureAlertThreshold is the number of consecutive failed syncs of a provider
+	// after which an alert is logged at ERROR, which also reports it to Sentry, and
+	// domain.ProviderDown is published (0 disables).
+	FailureAlertThreshold int
 }
 
 // NewSyncScheduler creates a new SyncScheduler with distributed locking support.
@@ -38,29 +58,60 @@ type SyncConfig struct {
 // Parameters:
 //   - syncSvc: Service handling the actual sync operations
 //   - cfg: Sync configuration including interval and timeout
+//   - events: Optional publisher of ProviderDown events (can be nil)
 //   - logger: Structured logger for operational visibility
 //   - locker: Distributed locker for cross-instance coordination
 func NewSyncScheduler(
 	syncSvc *service.SyncService,
 	cfg SyncConfig,
+	events domain.EventPublisher,
 	logger *zap.Logger,
 	locker locker.DistributedLocker,
 ) *SyncScheduler {
 	return &SyncScheduler{
 		syncService: syncSvc,
-		interval:    cfg.Interval,
 		timeout:     cfg.Timeout,
+		events:      events,
 		logger:      logger,
 		locker:      locker,
+
+		failureAlertThreshold: cfg.FailureAlertThreshold,
+		failures:              make(map[string]int),
+
+		interval:        cfg.Interval,
+		intervalChanged: make(chan struct{}, 1),
 	}
 }
 
+// SetInterval changes the time between syncs, e.g. after a configuration reload.
+// The next sync runs one new interval from now; the cooldown of a completed sync
+// keeps the TTL it was locked with.
+func (s *SyncScheduler) SetInterval(interval time.Duration) {
+	s.mu.Lock()
+	s.interval = interval
+	s.mu.Unlock()
+
+	select {
+	case s.intervalChanged <- struct{}{}:
+	default: // A change is already pending; it reads the latest interval
+	}
+}
+
+// currentInterval returns the time between syncs.
+func (s *SyncScheduler) currentInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.interval
+}
+
 // Start begins the background sync job.
 func (s *SyncScheduler) Start(runOnStartup bool) {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.syncCtx, s.cancelSync = context.WithCancelCause(context.Background())
 
 	s.logger.Info("starting sync scheduler",
-		zap.Duration("interval", s.interval),
+		zap.Duration("interval", s.currentInterval()),
 		zap.Bool("run_on_startup", runOnStartup),
 	)
 
@@ -68,11 +119,30 @@ func (s *SyncScheduler) Start(runOnStartup bool) {
 	go s.run(runOnStartup)
 }
 
-// Stop gracefully stops the scheduler.
-func (s *SyncScheduler) Stop() {
+// Stop gracefully stops the scheduler: no sync starts anymore, and a running one
+// is waited for until ctx is done, then interrupted with domain.ErrInterrupted.
+// An interrupted sync keeps the contents stored so far, reports them in its
+// results, and releases the sync lock so another instance can sync right away.
+func (s *SyncScheduler) Stop(ctx context.Context) {
 	s.logger.Info("stopping sync scheduler")
 	s.cancel()
-	s.wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("sync still running at shutdown, interrupting it")
+		s.cancelSync(domain.ErrInterrupted)
+		// Interrupted stages return right away; the lock is released on the way out
+		<-done
+	}
+	s.cancelSync(nil)
+
 	s.logger.Info("sync scheduler stopped")
 }
 
@@ -85,14 +155,22 @@ func (s *SyncScheduler) run(runOnStartup bool) {
 		s.executeSync()
 	}
 
-	ticker := time.NewTicker(s.interval)
+	ticker := time.NewTicker(s.currentInterval())
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
+		case <-s.intervalChanged:
+			interval := s.currentInterval()
+			ticker.Reset(interval)
+			s.logger.Info("sync interval changed", zap.Duration("interval", interval))
 		case <-ticker.C:
+			// Both may be ready; a stopped scheduler starts no sync
+			if s.ctx.Err() != nil {
+				return
+			}
 			s.executeSync()
 		}
 	}
@@ -100,65 +178,118 @@ func (s *SyncScheduler) run(runOnStartup bool) {
 
 // executeSync performs a sync operation with distributed locking and timeout.
 //
-// Locking behavior:
+// Locking behavior (see locker.WithLock):
 //   - Lock TTL = interval duration (cooldown model, not timeout)
+//   - Lockers implementing locker.Renewer extend the lock while the sync runs
 //   - Success: Lock held for full interval to prevent duplicate syncs
 //   - Failure: Lock released immediately to allow retry by another instance
+//   - Interrupted by Stop: Lock released, so another instance syncs without waiting
+//     for the cooldown, and the interrupted providers don't count as failing
 func (s *SyncScheduler) executeSync() {
 	const lockKey = "sync:scheduler:lock"
 
-	// Try to acquire lock with interval-based TTL (cooldown model)
-	acquired, err := s.locker.Acquire(s.ctx, lockKey, s.interval)
-	if err != nil {
-		s.logger.Error("failed to acquire distributed lock", zap.Error(err))
-
-		return
-	}
-	if !acquired {
-		s.logger.Debug("another instance is running sync, skipping execution")
-
-		return
-	}
-
-	// Lock acquired - run sync with timeout
-	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
-	defer cancel()
-
-	results := s.syncService.SyncAll(ctx)
-
-	// Analyze results
+	interval := s.currentInterval()
 	totalSynced := 0
-	totalErrors := 0
-	hasError := false
+	err := locker.WithLock(s.syncCtx, s.locker, lockKey, interval, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
 
-	for _, r := range results {
-		if r.Error != nil {
-			totalErrors++
-			hasError = true
-			s.logger.Warn("provider sync failed",
-				zap.String("provider", r.Provider),
-				zap.Error(r.Error),
-			)
-		} else {
-			totalSynced += r.Count
+		results, err := s.syncService.SyncAll(ctx)
+		if err != nil {
+			// The sync didn't run (e.g. a manual sync kept the run lock)
+			return err
 		}
-	}
+		s.trackFailures(context.WithoutCancel(ctx), results)
+		if errors.Is(context.Cause(s.syncCtx), domain.ErrInterrupted) {
+			for _, r := range results {
+				totalSynced += r.Count
+			}
 
-	// Handle success vs error scenarios
-	if hasError {
-		// Release lock immediately on error (allow immediate retry)
-		if err := s.locker.Release(s.ctx, lockKey); err != nil {
-			s.logger.Error("failed to release lock after sync error", zap.Error(err))
+			return domain.ErrInterrupted
+		}
+
+		failed := 0
+		for _, r := range results {
+			if r.Error != nil {
+				failed++
+				s.logger.Warn("provider sync failed",
+					zap.String("provider", r.Provider),
+					zap.String("error_kind", string(r.ErrorKind)),
+					zap.Int("stored", r.Count),
+					zap.Error(r.Error),
+				)
+			} else {
+				totalSynced += r.Count
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d providers failed", failed, len(results))
 		}
+
+		return nil
+	}, locker.HoldOnSuccess())
+
+	switch {
+	case errors.Is(err, locker.ErrLockHeld):
+		s.logger.Debug("another instance is running sync, skipping execution")
+	case errors.Is(err, domain.ErrInterrupted):
+		s.logger.Warn("sync interrupted by shutdown, lock released",
+			zap.Int("total_synced", totalSynced),
+		)
+	case err != nil:
 		s.logger.Info("sync completed with errors, lock released for retry",
 			zap.Int("total_synced", totalSynced),
-			zap.Int("providers_failed", totalErrors),
+			zap.Error(err),
 		)
-	} else {
+	default:
 		// Lock will expire naturally after interval (cooldown period)
 		s.logger.Info("sync completed successfully, lock held for cooldown",
 			zap.Int("total_synced", totalSynced),
-			zap.Duration("cooldown", s.interval),
+			zap.Duration("cooldown", interval),
 		)
 	}
 }
+
+// trackFailures counts consecutive failed syncs per provider and raises an alert
+// (ERROR log and ProviderDown event) once a provider reaches the failure threshold. Counts are kept per instance:
+// syncs run elsewhere while another instance holds the lock aren't seen here.
+// Syncs interrupted by shutdown neither fail nor recover.
+func (s *SyncScheduler) trackFailures(ctx context.Context, results []service.SyncResult) {
+	for _, r := range results {
+		if errors.Is(r.Error, domain.ErrInterrupted) {
+			continue
+		}
+		if r.Error == nil {
+			if s.failureAlertThreshold > 0 && s.failures[r.Provider] >= s.failureAlertThreshold {
+				s.logger.Info("provider sync recovered",
+					zap.String("provider", r.Provider),
+					zap.Int("failed_syncs", s.failures[r.Provider]),
+				)
+			}
+			s.failures[r.Provider] = 0
+			metrics.SyncConsecutiveFailures.WithLabelValues(r.Provider).Set(0)
+
+			continue
+		}
+
+		s.failures[r.Provider]++
+		failures := s.failures[r.Provider]
+		metrics.SyncConsecutiveFailures.WithLabelValues(r.Provider).Set(float64(failures))
+
+		// Alert once per outage; the gauge keeps counting
+		if s.failureAlertThreshold > 0 && failures == s.failureAlertThreshold {
+			s.logger.Error("provider sync failing repeatedly",
+				zap.String("provider", r.Provider),
+				zap.Int("consecutive_failures", failures),
+				zap.Error(r.Error),
+			)
+			if s.events != nil {
+				s.events.Publish(ctx, domain.ProviderDown{
+					Provider:            r.Provider,
+					ConsecutiveFailures: failures,
+					Error:               r.Error,
+				})
+			}
+		}
+	}
+}
@@ -1,4 +1,158 @@
 package job
 
-// Tests for SyncScheduler will be added here
-// TODO: Add tests using testify
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/metrics"
+	"search-engine-service/pkg/locker"
+)
+
+// recordingPublisher is a domain.EventPublisher keeping published events.
+type recordingPublisher struct {
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event domain.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestSyncScheduler_AlertsOnceAfterConsecutiveFailures(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	events := &recordingPublisher{}
+	s := NewSyncScheduler(nil, SyncConfig{FailureAlertThreshold: 2}, events, zap.New(core), nil)
+	ctx := context.Background()
+
+	upstreamDown := errors.New("upstream down")
+	failed := []service.SyncResult{{Provider: "alert_test", Error: upstreamDown}}
+	for range 3 {
+		s.trackFailures(ctx, failed)
+	}
+
+	require.Len(t, events.events, 1)
+	assert.Equal(t, domain.ProviderDown{Provider: "alert_test", ConsecutiveFailures: 2, Error: upstreamDown}, events.events[0])
+	assert.Equal(t, 3.0, testutil.ToFloat64(metrics.SyncConsecutiveFailures.WithLabelValues("alert_test")))
+	assert.Equal(t, 1, logs.FilterLevelExact(zapcore.ErrorLevel).FilterMessage("provider sync failing repeatedly").Len())
+
+	s.trackFailures(ctx, []service.SyncResult{{Provider: "alert_test"}})
+
+	assert.Zero(t, testutil.ToFloat64(metrics.SyncConsecutiveFailures.WithLabelValues("alert_test")))
+	assert.Equal(t, 1, logs.FilterMessage("provider sync recovered").Len())
+
+	// A new outage alerts again
+	s.trackFailures(ctx, failed)
+	s.trackFailures(ctx, failed)
+	assert.Len(t, events.events, 2)
+}
+
+func TestSyncScheduler_ZeroThresholdDisablesAlerts(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	events := &recordingPublisher{}
+	s := NewSyncScheduler(nil, SyncConfig{}, events, zap.New(core), nil)
+
+	for range 5 {
+		s.trackFailures(context.Background(), []service.SyncResult{{Provider: "no_alert_test", Error: errors.New("upstream down")}})
+	}
+
+	assert.Zero(t, logs.Len())
+	assert.Empty(t, events.events)
+	assert.Equal(t, 5.0, testutil.ToFloat64(metrics.SyncConsecutiveFailures.WithLabelValues("no_alert_test")))
+}
+
+// blockingProvider is a domain.Provider whose fetches wait for release, or for their
+// context to end.
+type blockingProvider struct {
+	name    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingProvider) Name() string { return p.name }
+
+func (p *blockingProvider) Fetch(ctx context.Context) ([]*domain.Content, error) {
+	close(p.started)
+	select {
+	case <-p.release:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *blockingProvider) HealthCheck(context.Context) error { return nil }
+
+// startBlockedSync starts a scheduler whose first sync waits for provider.release.
+func startBlockedSync(t *testing.T, provider *blockingProvider, events domain.EventPublisher, l locker.DistributedLocker) *SyncScheduler {
+	t.Helper()
+
+	syncSvc := service.NewSyncService(nil, []domain.Provider{provider}, zap.NewNop(), service.WithEvents(events))
+	s := NewSyncScheduler(syncSvc, SyncConfig{Interval: time.Hour, Timeout: time.Minute, FailureAlertThreshold: 1},
+		nil, zap.NewNop(), l)
+	s.Start(true)
+	<-provider.started
+
+	return s
+}
+
+func TestSyncScheduler_StopWaitsForRunningSync(t *testing.T) {
+	provider := &blockingProvider{name: "stop_wait_test", started: make(chan struct{}), release: make(chan struct{})}
+	l := locker.NewMemoryLocker()
+	s := startBlockedSync(t, provider, nil, l)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop(context.Background())
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned before the running sync finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(provider.release)
+	<-stopped
+
+	acquired, err := l.Acquire(context.Background(), "sync:scheduler:lock", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "a completed sync keeps the lock for the cooldown")
+}
+
+func TestSyncScheduler_StopInterruptsSyncAfterWait(t *testing.T) {
+	provider := &blockingProvider{name: "stop_interrupt_test", started: make(chan struct{}), release: make(chan struct{})}
+	events := &recordingPublisher{}
+	l := locker.NewMemoryLocker()
+	s := startBlockedSync(t, provider, events, l)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.Stop(ctx)
+
+	acquired, err := l.Acquire(context.Background(), "sync:scheduler:lock", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "an interrupted sync releases the lock")
+
+	var completed []domain.SyncCompleted
+	for _, e := range events.events {
+		if c, ok := e.(domain.SyncCompleted); ok {
+			completed = append(completed, c)
+		}
+	}
+	require.Len(t, completed, 1, "the interrupted sync is recorded")
+	assert.ErrorIs(t, completed[0].Results[0].Error, domain.ErrInterrupted)
+	assert.ErrorIs(t, completed[0].Results[0].Error, context.Canceled)
+	assert.Zero(t, testutil.ToFloat64(metrics.SyncConsecutiveFailures.WithLabelValues("stop_interrupt_test")),
+		"an interrupted sync isn't a failure")
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// contextKey namespaces the request identifiers stored in a context.
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceIDKey
+)
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+
+	return id
+}
+
+// WithTraceID returns a copy of ctx carrying the distributed trace ID.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceIDFromContext returns the distributed trace ID carried by ctx, or "".
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+
+	return id
+}
+
+// FromContext returns l with the request_id and trace_id fields carried by ctx, so log lines
+// from handlers, services and repositories can be correlated with the request that caused them.
+// l is returned unchanged when ctx carries neither.
+func FromContext(ctx context.Context, l *zap.Logger) *zap.Logger {
+	fields := make([]zap.Field, 0, 2)
+	if id := RequestIDFromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if id := TraceIDFromContext(ctx); id != "" {
+		fields = append(fields, zap.String("trace_id", id))
+	}
+	if len(fields) == 0 {
+		return l
+	}
+
+	return l.With(fields...)
+}
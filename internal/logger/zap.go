@@ -28,6 +28,7 @@ type SentryConfig struct {
 // Logger wraps zap.Logger with Sentry integration.
 type Logger struct {
 	*zap.Logger
+	level         zap.AtomicLevel // Shared with child loggers
 	sentryEnabled bool
 }
 
@@ -47,10 +48,11 @@ func New(cfg Config, sentryCfg SentryConfig) (*Logger, error) {
 	}
 
 	// Parse log level
-	level, err := zapcore.ParseLevel(cfg.Level)
+	parsed, err := zapcore.ParseLevel(cfg.Level)
 	if err != nil {
-		level = zapcore.InfoLevel
+		parsed = zapcore.InfoLevel
 	}
+	level := zap.NewAtomicLevelAt(parsed)
 
 	// Create encoder config
 	encoderConfig := zapcore.EncoderConfig{
@@ -103,10 +105,28 @@ func New(cfg Config, sentryCfg SentryConfig) (*Logger, error) {
 
 	return &Logger{
 		Logger:        zapLogger,
+		level:         level,
 		sentryEnabled: sentryCfg.Enabled,
 	}, nil
 }
 
+// SetLevel changes the minimum level logged by this logger and every logger derived
+// from it, including the Sentry hook.
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(parsed)
+
+	return nil
+}
+
+// Level returns the minimum level currently logged.
+func (l *Logger) Level() zapcore.Level {
+	return l.level.Level()
+}
+
 // Sync flushes any buffered log entries and Sentry events.
 func (l *Logger) Sync() error {
 	if l.sentryEnabled {
@@ -120,6 +140,7 @@ func (l *Logger) Sync() error {
 func (l *Logger) With(fields ...zap.Field) *Logger {
 	return &Logger{
 		Logger:        l.Logger.With(fields...),
+		level:         l.level,
 		sentryEnabled: l.sentryEnabled,
 	}
 }
@@ -130,7 +151,7 @@ type sentryCore struct {
 	fields []zapcore.Field
 }
 
-func newSentryCore(level zapcore.Level) *sentryCore {
+func newSentryCore(level zapcore.LevelEnabler) *sentryCore {
 	return &sentryCore{
 		LevelEnabler: level,
 		fields:       make([]zapcore.Field, 0),
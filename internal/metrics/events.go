@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+
+	"search-engine-service/internal/domain"
+)
+
+// RecordEvent is an event bus handler updating the metrics derived from domain events.
+func RecordEvent(_ context.Context, event domain.Event) {
+	switch e := event.(type) {
+	case domain.SyncCompleted:
+		for _, r := range e.Results {
+			for _, stage := range r.Stages {
+				SyncStageItems.WithLabelValues(r.Provider, stage.Name).Add(float64(stage.Items))
+				SyncStageSeconds.WithLabelValues(r.Provider, stage.Name, "busy").Add(stage.Busy.Seconds())
+				SyncStageSeconds.WithLabelValues(r.Provider, stage.Name, "blocked").Add(stage.Blocked.Seconds())
+			}
+			if r.Error != nil {
+				SyncRuns.WithLabelValues(r.Provider, "error").Inc()
+				SyncFailures.WithLabelValues(r.Provider, string(r.ErrorKind)).Inc()
+
+				continue
+			}
+			SyncRuns.WithLabelValues(r.Provider, "ok").Inc()
+			SyncedContents.WithLabelValues(r.Provider).Add(float64(r.Count))
+		}
+	case domain.ItemsRejected:
+		SyncRejectedItems.WithLabelValues(e.Provider).Add(float64(len(e.Letters)))
+	case domain.ProviderDown:
+		SyncFailureAlerts.WithLabelValues(e.Provider).Inc()
+	case domain.ShadowSearchCompared:
+		switch {
+		case e.Skipped:
+			ShadowSearches.WithLabelValues(e.Variant, "skipped").Inc()
+		case e.Error != nil:
+			ShadowSearches.WithLabelValues(e.Variant, "failed").Inc()
+		default:
+			ShadowSearches.WithLabelValues(e.Variant, "compared").Inc()
+			ShadowOverlap.WithLabelValues(e.Variant).Observe(e.Overlap)
+			if e.Correlated {
+				ShadowRankCorrelation.WithLabelValues(e.Variant).Observe(e.RankCorrelation)
+			}
+		}
+	}
+}
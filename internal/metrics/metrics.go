@@ -0,0 +1,266 @@
+// Package metrics defines the Prometheus collectors exported by the service.
+// Collectors are registered on the default registry and served at GET /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "search_engine"
+
+// CacheOperations counts cache operations by operation (get, set, delete, clear)
+// and result (hit, miss, ok, error).
+var CacheOperations = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "operations_total",
+		Help:      "Cache operations by operation and result.",
+	},
+	[]string{"operation", "result"},
+)
+
+// HTTPRequests counts handled requests by method, route (the registered pattern,
+// e.g. /api/v1/contents/:id) and status code.
+var HTTPRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	},
+	[]string{"method", "route", "status"},
+)
+
+// HTTPRequestDuration observes request handling time by method and route.
+// Streamed responses (export, event stream) are measured until their handler returns.
+var HTTPRequestDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request handling time by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"method", "route"},
+)
+
+// SyncConsecutiveFailures reports how many syncs of a provider failed in a row on
+// this instance (0 after a successful sync).
+var SyncConsecutiveFailures = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "consecutive_failures",
+		Help:      "Consecutive failed syncs by provider.",
+	},
+	[]string{"provider"},
+)
+
+// SyncFailureAlerts counts the alerts raised when a provider's consecutive failed
+// syncs reach the configured threshold.
+var SyncFailureAlerts = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "failure_alerts_total",
+		Help:      "Alerts raised for providers failing repeatedly, by provider.",
+	},
+	[]string{"provider"},
+)
+
+// ReadinessFailures counts readiness probes answered as not ready, by reason:
+// draining (shutting down) or database (the ping failed).
+var ReadinessFailures = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "health",
+		Name:      "readiness_failures_total",
+		Help:      "Readiness probes failed, by reason.",
+	},
+	[]string{"reason"},
+)
+
+// EventsPublished counts domain events published on the in-process event bus by event name.
+var EventsPublished = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "Domain events published in-process by event name.",
+	},
+	[]string{"event"},
+)
+
+// WebhookDeliveriesDropped counts webhook deliveries given up without being attempted,
+// by reason: queue_full (the delivery queue was full) or shutdown (still queued when
+// the instance stopped).
+var WebhookDeliveriesDropped = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "deliveries_dropped_total",
+		Help:      "Webhook deliveries dropped without being attempted, by reason.",
+	},
+	[]string{"reason"},
+)
+
+// SyncRuns counts provider syncs by provider and result (ok, error).
+var SyncRuns = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "runs_total",
+		Help:      "Provider syncs by provider and result.",
+	},
+	[]string{"provider", "result"},
+)
+
+// SyncFailures counts failed provider syncs by provider and kind (see
+// domain.SyncErrorKind): timeout, interrupted, provider or database.
+var SyncFailures = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "failures_total",
+		Help:      "Failed provider syncs by provider and kind.",
+	},
+	[]string{"provider", "kind"},
+)
+
+// SyncRejectedItems counts the provider items rejected by strict clients for breaking
+// the provider's schema, by provider.
+var SyncRejectedItems = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "rejected_items_total",
+		Help:      "Provider items breaking the provider's schema, kept as dead letters.",
+	},
+	[]string{"provider"},
+)
+
+// SyncedContents counts contents upserted by successful syncs, by provider.
+var SyncedContents = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "contents_total",
+		Help:      "Contents upserted by sync, by provider.",
+	},
+	[]string{"provider"},
+)
+
+// SyncStageItems counts the items each stage of streamed syncs passed on (contents
+// stored for upsert), by provider and stage.
+var SyncStageItems = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "stage_items_total",
+		Help:      "Items passed on by each stage of streamed syncs, by provider and stage.",
+	},
+	[]string{"provider", "stage"},
+)
+
+// SyncStageSeconds adds up the time the stages of streamed syncs spent working or
+// blocked on the next stage, by provider, stage and state (busy, blocked). A stage
+// mostly blocked waits for a slower stage after it.
+var SyncStageSeconds = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "stage_seconds_total",
+		Help:      "Time spent by the stages of streamed syncs, by provider, stage and state.",
+	},
+	[]string{"provider", "stage", "state"},
+)
+
+// ProviderRequests counts HTTP requests sent to providers, each retry attempt on its
+// own, by provider, path and status code ("error" when no response arrived).
+var ProviderRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "requests_total",
+		Help:      "Provider HTTP request attempts by provider, path and status code.",
+	},
+	[]string{"provider", "path", "status"},
+)
+
+// ProviderRequestDuration observes how long provider request attempts took until the
+// response headers arrived (or the attempt failed), by provider and path.
+var ProviderRequestDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "request_duration_seconds",
+		Help:      "Provider HTTP request attempt latency until response headers, by provider and path.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"provider", "path"},
+)
+
+// ProviderRetries counts provider request attempts that retried a failed one, by provider.
+var ProviderRetries = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "retries_total",
+		Help:      "Provider HTTP request retries by provider.",
+	},
+	[]string{"provider"},
+)
+
+// ProviderResponseBytes counts the response body bytes read from providers as
+// received, i.e. before decompression, by provider.
+var ProviderResponseBytes = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "provider",
+		Name:      "response_bytes_total",
+		Help:      "Provider response body bytes read, before decompression, by provider.",
+	},
+	[]string{"provider"},
+)
+
+// ShadowSearches counts shadow searches (see service.WithShadowTraffic) by variant,
+// the ranking and backend they ran with (e.g. text/bleve), and result (compared,
+// failed, or skipped while too many were running).
+var ShadowSearches = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "shadow",
+		Name:      "searches_total",
+		Help:      "Shadow searches by variant and result.",
+	},
+	[]string{"variant", "result"},
+)
+
+// ShadowOverlap observes the overlap at k of shadow searches with the served results:
+// the share of the top results found by both, by variant.
+var ShadowOverlap = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "shadow",
+		Name:      "overlap",
+		Help:      "Share of the top results shadow searches have in common with the served ones, by variant.",
+		Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+	},
+	[]string{"variant"},
+)
+
+// ShadowRankCorrelation observes the Kendall rank correlation between the served and
+// shadow ranks of the top results both found, by variant. Comparisons with fewer
+// than two results in common aren't observed.
+var ShadowRankCorrelation = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "shadow",
+		Name:      "rank_correlation",
+		Help:      "Kendall rank correlation of the top results shadow and served searches have in common, by variant.",
+		Buckets:   prometheus.LinearBuckets(-0.8, 0.2, 10),
+	},
+	[]string{"variant"},
+)
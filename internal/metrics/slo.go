@@ -0,0 +1,221 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SLI names.
+const (
+	SLIAvailability = "availability" // Requests not answered with a 5xx status
+	SLILatency      = "latency"      // Requests handled within the objective's threshold
+)
+
+// sloBucketWidth is the resolution of the burn-rate windows.
+const sloBucketWidth = 10 * time.Second
+
+// SLOObjective is a service level objective for a set of routes.
+type SLOObjective struct {
+	Name   string
+	Routes []string // Route prefixes (e.g. /api/v1/contents) counted towards the objective
+
+	Availability  float64       // Target share of good requests for the availability SLI (0 disables it)
+	Latency       time.Duration // Threshold of the latency SLI (0 disables it)
+	LatencyTarget float64       // Target share of requests faster than Latency
+}
+
+// matches reports whether route belongs to the objective.
+func (o *SLOObjective) matches(route string) bool {
+	for _, prefix := range o.Routes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SLORecorder counts good and bad requests per objective and SLI and reports how
+// fast each objective's error budget is burning over a set of windows.
+// It is a prometheus.Collector; register it to export:
+//
+//   - search_engine_slo_requests_total{slo,sli,result}: good/bad request counts
+//   - search_engine_slo_objective{slo,sli}: the target share of good requests
+//   - search_engine_slo_burn_rate{slo,sli,window}: bad share over the window divided by
+//     the error budget (1 - objective); 1 spends the budget exactly over the SLO period
+//
+// Burn rates are computed per instance. Alerts spanning replicas should aggregate
+// slo_requests_total instead.
+type SLORecorder struct {
+	objectives []SLOObjective
+	windows    []time.Duration
+	now        func() time.Time
+
+	mu     sync.Mutex
+	series map[sloKey]*sloSeries
+
+	requestsDesc  *prometheus.Desc
+	objectiveDesc *prometheus.Desc
+	burnRateDesc  *prometheus.Desc
+}
+
+// sloKey identifies the series of one SLI of one objective.
+type sloKey struct {
+	slo string
+	sli string
+}
+
+// sloSeries holds the counts of one SLI: totals since start and a ring of
+// sloBucketWidth buckets covering the longest window.
+type sloSeries struct {
+	target    float64
+	good, bad uint64
+	buckets   []sloBucket
+}
+
+type sloBucket struct {
+	index     int64 // Bucket number since the Unix epoch
+	good, bad uint64
+}
+
+// NewSLORecorder creates a new SLORecorder for objectives, reporting burn rates over windows.
+func NewSLORecorder(objectives []SLOObjective, windows []time.Duration) *SLORecorder {
+	var longest time.Duration
+	for _, w := range windows {
+		longest = max(longest, w)
+	}
+	size := int(longest/sloBucketWidth) + 1
+
+	r := &SLORecorder{
+		objectives: objectives,
+		windows:    windows,
+		now:        time.Now,
+		series:     make(map[sloKey]*sloSeries),
+		requestsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "slo", "requests_total"),
+			"Requests counted towards an SLO by SLI and result (good, bad).",
+			[]string{"slo", "sli", "result"}, nil,
+		),
+		objectiveDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "slo", "objective"),
+			"Target share of good requests by SLO and SLI.",
+			[]string{"slo", "sli"}, nil,
+		),
+		burnRateDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "slo", "burn_rate"),
+			"Error budget burn rate by SLO, SLI and window (1 = budget spent exactly over the SLO period).",
+			[]string{"slo", "sli", "window"}, nil,
+		),
+	}
+
+	for _, o := range objectives {
+		if o.Availability > 0 {
+			r.series[sloKey{o.Name, SLIAvailability}] = &sloSeries{target: o.Availability, buckets: make([]sloBucket, size)}
+		}
+		if o.Latency > 0 && o.LatencyTarget > 0 {
+			r.series[sloKey{o.Name, SLILatency}] = &sloSeries{target: o.LatencyTarget, buckets: make([]sloBucket, size)}
+		}
+	}
+
+	return r
+}
+
+// Observe counts a handled request towards the first objective whose routes match route.
+func (r *SLORecorder) Observe(route string, status int, duration time.Duration) {
+	for i := range r.objectives {
+		o := &r.objectives[i]
+		if !o.matches(route) {
+			continue
+		}
+
+		index := r.now().UnixNano() / int64(sloBucketWidth)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if s := r.series[sloKey{o.Name, SLIAvailability}]; s != nil {
+			s.add(index, status < 500)
+		}
+		if s := r.series[sloKey{o.Name, SLILatency}]; s != nil {
+			s.add(index, duration <= o.Latency)
+		}
+
+		return
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (r *SLORecorder) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.requestsDesc
+	ch <- r.objectiveDesc
+	ch <- r.burnRateDesc
+}
+
+// Collect implements prometheus.Collector.
+func (r *SLORecorder) Collect(ch chan<- prometheus.Metric) {
+	index := r.now().UnixNano() / int64(sloBucketWidth)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, s := range r.series {
+		ch <- prometheus.MustNewConstMetric(r.requestsDesc, prometheus.CounterValue, float64(s.good), key.slo, key.sli, "good")
+		ch <- prometheus.MustNewConstMetric(r.requestsDesc, prometheus.CounterValue, float64(s.bad), key.slo, key.sli, "bad")
+		ch <- prometheus.MustNewConstMetric(r.objectiveDesc, prometheus.GaugeValue, s.target, key.slo, key.sli)
+
+		for _, w := range r.windows {
+			ch <- prometheus.MustNewConstMetric(r.burnRateDesc, prometheus.GaugeValue,
+				s.burnRate(index, int64(w/sloBucketWidth)), key.slo, key.sli, windowLabel(w))
+		}
+	}
+}
+
+// add counts a request in the bucket at index, recycling the slot of an expired bucket.
+func (s *sloSeries) add(index int64, good bool) {
+	b := &s.buckets[index%int64(len(s.buckets))]
+	if b.index != index {
+		*b = sloBucket{index: index}
+	}
+
+	if good {
+		b.good++
+		s.good++
+	} else {
+		b.bad++
+		s.bad++
+	}
+}
+
+// burnRate returns the bad share of the last n buckets up to index divided by the error budget.
+func (s *sloSeries) burnRate(index, n int64) float64 {
+	var good, bad uint64
+	for _, b := range s.buckets {
+		if b.index > index-n && b.index <= index {
+			good += b.good
+			bad += b.bad
+		}
+	}
+
+	budget := 1 - s.target
+	if good+bad == 0 || budget <= 0 {
+		return 0
+	}
+
+	return float64(bad) / float64(good+bad) / budget
+}
+
+// windowLabel formats d the way Prometheus writes range durations (5m, 1h).
+func windowLabel(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherSLO registers r on a fresh registry and returns every sample keyed by
+// metric name and sorted label values, e.g. "search_engine_slo_burn_rate{availability,search,5m}".
+func gatherSLO(t *testing.T, r *SLORecorder) map[string]float64 {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(r))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	samples := make(map[string]float64)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			values := make([]string, 0, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				values = append(values, l.GetValue())
+			}
+			sort.Strings(values)
+
+			key := mf.GetName() + "{" + strings.Join(values, ",") + "}"
+			if m.GetCounter() != nil {
+				samples[key] = m.GetCounter().GetValue()
+			} else {
+				samples[key] = m.GetGauge().GetValue()
+			}
+		}
+	}
+
+	return samples
+}
+
+func newTestSLORecorder(now *time.Time) *SLORecorder {
+	r := NewSLORecorder([]SLOObjective{
+		{Name: "search", Routes: []string{"/api/v1/contents"}, Availability: 0.99, Latency: 100 * time.Millisecond, LatencyTarget: 0.9},
+		{Name: "all", Routes: []string{"/"}, Availability: 0.9},
+	}, []time.Duration{5 * time.Minute, time.Hour})
+	r.now = func() time.Time { return *now }
+
+	return r
+}
+
+func TestSLORecorder_BurnRate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestSLORecorder(&now)
+
+	// 2 of 100 requests fail and 20 are slow: twice the availability budget, twice the latency budget
+	for i := 0; i < 100; i++ {
+		status, duration := 200, 10*time.Millisecond
+		if i < 2 {
+			status = 503
+		}
+		if i < 20 {
+			duration = time.Second
+		}
+		r.Observe("/api/v1/contents/:id", status, duration)
+	}
+
+	samples := gatherSLO(t, r)
+
+	assert.InDelta(t, 2, samples["search_engine_slo_burn_rate{5m,availability,search}"], 1e-9)
+	assert.InDelta(t, 2, samples["search_engine_slo_burn_rate{1h,availability,search}"], 1e-9)
+	assert.InDelta(t, 2, samples["search_engine_slo_burn_rate{1h,latency,search}"], 1e-9)
+	assert.Equal(t, 98.0, samples["search_engine_slo_requests_total{availability,good,search}"])
+	assert.Equal(t, 2.0, samples["search_engine_slo_requests_total{availability,bad,search}"])
+	assert.Equal(t, 0.99, samples["search_engine_slo_objective{availability,search}"])
+}
+
+func TestSLORecorder_WindowsExpire(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestSLORecorder(&now)
+
+	r.Observe("/api/v1/contents", 500, time.Millisecond)
+	now = now.Add(10 * time.Minute)
+	r.Observe("/api/v1/contents", 200, time.Millisecond)
+
+	samples := gatherSLO(t, r)
+
+	// The failure left the 5m window but is still within the hour
+	assert.Zero(t, samples["search_engine_slo_burn_rate{5m,availability,search}"])
+	assert.InDelta(t, 50, samples["search_engine_slo_burn_rate{1h,availability,search}"], 1e-9)
+	assert.Equal(t, 1.0, samples["search_engine_slo_requests_total{availability,bad,search}"])
+}
+
+func TestSLORecorder_CountsFirstMatchingObjectiveOnly(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestSLORecorder(&now)
+
+	r.Observe("/api/v1/contents", 500, time.Millisecond)
+	r.Observe("/api/v1/admin/sync", 500, time.Millisecond)
+
+	samples := gatherSLO(t, r)
+
+	assert.Equal(t, 1.0, samples["search_engine_slo_requests_total{availability,bad,search}"])
+	assert.Equal(t, 1.0, samples["search_engine_slo_requests_total{all,availability,bad}"])
+	_, hasLatency := samples["search_engine_slo_objective{all,latency}"]
+	assert.False(t, hasLatency, "objectives without a latency threshold have no latency SLI")
+}
+
+func TestWindowLabel(t *testing.T) {
+	assert.Equal(t, "5m", windowLabel(5*time.Minute))
+	assert.Equal(t, "6h", windowLabel(6*time.Hour))
+	assert.Equal(t, "90m", windowLabel(90*time.Minute))
+	assert.Equal(t, "30s", windowLabel(30*time.Second))
+}
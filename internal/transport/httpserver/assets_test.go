@@ -0,0 +1,43 @@
+package httpserver
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/template/html/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardAssets_EmbeddedByDefault(t *testing.T) {
+	// Outside debug mode, or without a directory, the templates directory is ignored
+	for _, tt := range []struct {
+		debug bool
+		dir   string
+	}{{false, ""}, {false, t.TempDir()}, {true, ""}} {
+		assets, fromDisk := dashboardAssets(tt.debug, tt.dir)
+		assert.False(t, fromDisk)
+
+		_, err := fs.Stat(assets, "static/js/app.js")
+		require.NoError(t, err)
+
+		engine := html.NewFileSystem(http.FS(subFS(assets, "templates")), ".html")
+		require.NoError(t, engine.Load())
+	}
+}
+
+func TestDashboardAssets_FromDiskInDebug(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "static"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "static", "edited.css"), []byte("body {}"), 0o600))
+
+	assets, fromDisk := dashboardAssets(true, dir)
+	assert.True(t, fromDisk)
+
+	data, err := fs.ReadFile(subFS(assets, "static"), "edited.css")
+	require.NoError(t, err)
+	assert.Equal(t, "body {}", string(data))
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"search-engine-service/internal/domain"
+)
+
+// Export formats supported by GET /api/v1/contents/export.
+const (
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatCSV    = "csv"
+)
+
+// csvHeader lists the exported CSV columns in order.
+var csvHeader = []string{
+	"id", "provider_id", "external_id", "title", "type", "tags", "url", "thumbnail_url", "author",
+	"views", "likes", "duration", "duration_seconds", "listens", "reading_time", "reactions", "comments",
+	"score", "published_at", "created_at", "updated_at",
+}
+
+// ContentEncoder writes contents in an export format.
+type ContentEncoder interface {
+	// Encode writes a batch of contents.
+	Encode(batch []*domain.Content) error
+	// Flush writes any buffered data.
+	Flush() error
+}
+
+// NewContentEncoder returns an encoder for format writing to w.
+// Unknown formats fall back to NDJSON. The CSV header is written immediately.
+func NewContentEncoder(format string, w io.Writer) (ContentEncoder, error) {
+	if format == ExportFormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, err
+		}
+
+		return &csvEncoder{w: cw}, nil
+	}
+
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+}
+
+// ExportContentType returns the MIME type for an export format.
+func ExportContentType(format string) string {
+	if format == ExportFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+
+	return "application/x-ndjson"
+}
+
+// ndjsonEncoder writes one ContentResponse JSON object per line.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(batch []*domain.Content) error {
+	for _, c := range batch {
+		if err := e.enc.Encode(FromDomainContent(c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *ndjsonEncoder) Flush() error { return nil }
+
+// csvEncoder writes one row per content. Tags are joined with ';'.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) Encode(batch []*domain.Content) error {
+	for _, c := range batch {
+		r := FromDomainContent(c)
+		record := []string{
+			r.ID, r.ProviderID, r.ExternalID, r.Title, r.Type, strings.Join(r.Tags, ";"), r.URL, r.ThumbnailURL, r.Author,
+			strconv.Itoa(r.Views), strconv.Itoa(r.Likes), r.Duration, strconv.Itoa(r.DurationSeconds), strconv.Itoa(r.Listens),
+			strconv.Itoa(r.ReadingTime), strconv.Itoa(r.Reactions), strconv.Itoa(r.Comments),
+			strconv.FormatFloat(r.Score, 'f', 2, 64), r.PublishedAt, r.CreatedAt, r.UpdatedAt,
+		}
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+
+	return e.w.Error()
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func exportFixture() []*domain.Content {
+	published := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	return []*domain.Content{
+		{ID: "a1", ProviderID: "provider_b", ExternalID: "x", Title: `Clean "Architecture", Go`,
+			Type: domain.ContentTypeArticle, Tags: []string{"go", "design"}, ReadingTime: 8, Score: 12.5,
+			URL: "https://example.com/a1", Author: "Jane Doe",
+			PublishedAt: published, CreatedAt: published, UpdatedAt: published},
+		{ID: "v1", ProviderID: "provider_a", ExternalID: "y", Title: "REST APIs",
+			Type: domain.ContentTypeVideo, Views: 100, Duration: "10m", DurationSeconds: 600,
+			PublishedAt: published, CreatedAt: published, UpdatedAt: published},
+	}
+}
+
+func TestContentEncoder_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewContentEncoder(ExportFormatNDJSON, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Encode(exportFixture()))
+	require.NoError(t, enc.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first ContentResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "a1", first.ID)
+	assert.Equal(t, []string{"go", "design"}, first.Tags)
+}
+
+func TestContentEncoder_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewContentEncoder(ExportFormatCSV, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Encode(exportFixture()))
+	require.NoError(t, enc.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "id,provider_id,external_id,title,type,tags,"))
+	assert.Equal(t,
+		`a1,provider_b,x,"Clean ""Architecture"", Go",article,go;design,https://example.com/a1,,Jane Doe,0,0,,0,0,8,0,0,12.50,2024-03-14T00:00:00Z,2024-03-14T00:00:00Z,2024-03-14T00:00:00Z`,
+		lines[1],
+	)
+	assert.Contains(t, lines[2], ",100,0,10:00,600,0,", "duration is formatted, with seconds alongside")
+}
+
+func TestExportContentType(t *testing.T) {
+	assert.Equal(t, "text/csv; charset=utf-8", ExportContentType(ExportFormatCSV))
+	assert.Equal(t, "application/x-ndjson", ExportContentType(ExportFormatNDJSON))
+	assert.Equal(t, "application/x-ndjson", ExportContentType(""))
+}
@@ -1,12 +1,22 @@
 // Package dto provides Data Transfer Objects for HTTP requests and responses.
 package dto
 
-import "search-engine-service/internal/domain"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"search-engine-service/internal/domain"
+)
 
 // SearchRequest represents the query parameters for searching contents.
 type SearchRequest struct {
 	Query     string `query:"q" validate:"max=200"`
-	Type      string `query:"type" validate:"omitempty,oneof=video article"`
+	Mode      string `query:"mode" validate:"omitempty,oneof=websearch prefix"`
+	Type      string `query:"type" validate:"omitempty,oneof=video article podcast image"`
+	Provider  string `query:"provider" validate:"max=100"`
+	Tag       string `query:"tag" validate:"max=100"`
+	Duration  string `query:"duration" validate:"omitempty,oneof=short medium long"`
 	SortBy    string `query:"sort_by" validate:"omitempty,oneof=relevance score published_at"`
 	SortOrder string `query:"sort_order" validate:"omitempty,oneof=asc desc"`
 	Page      int    `query:"page" validate:"omitempty,min=1"`
@@ -21,6 +31,13 @@ func (r *SearchRequest) ToSearchParams() domain.SearchParams {
 
 	params.Query = r.Query
 	params.Type = domain.ContentType(r.Type)
+	params.Provider = r.Provider
+	params.Tag = r.Tag
+	params.Duration = domain.DurationBucket(r.Duration)
+
+	if r.Mode != "" {
+		params.Mode = domain.SearchMode(r.Mode)
+	}
 
 	if r.SortBy != "" {
 		params.SortBy = domain.SortField(r.SortBy)
@@ -42,7 +59,289 @@ func (r *SearchRequest) ToSearchParams() domain.SearchParams {
 	return params
 }
 
+// FacetsRequest represents the query parameters for search facets.
+type FacetsRequest struct {
+	Query    string `query:"q" validate:"max=200"`
+	Mode     string `query:"mode" validate:"omitempty,oneof=websearch prefix"`
+	Type     string `query:"type" validate:"omitempty,oneof=video article podcast image"`
+	Provider string `query:"provider" validate:"max=100"`
+	Tag      string `query:"tag" validate:"max=100"`
+	Duration string `query:"duration" validate:"omitempty,oneof=short medium long"`
+}
+
+// ToSearchParams converts FacetsRequest to domain.SearchParams (filters only).
+func (r *FacetsRequest) ToSearchParams() domain.SearchParams {
+	return domain.SearchParams{
+		Query:    r.Query,
+		Mode:     domain.SearchMode(r.Mode),
+		Type:     domain.ContentType(r.Type),
+		Provider: r.Provider,
+		Tag:      r.Tag,
+		Duration: domain.DurationBucket(r.Duration),
+	}
+}
+
+// ExportRequest represents the query parameters for exporting contents.
+type ExportRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=ndjson csv"`
+	Query  string `query:"q" validate:"max=200"`
+	Type   string `query:"type" validate:"omitempty,oneof=video article podcast image"`
+}
+
+// ToSearchParams converts ExportRequest to domain.SearchParams (filters only).
+func (r *ExportRequest) ToSearchParams() domain.SearchParams {
+	return domain.SearchParams{
+		Query: r.Query,
+		Type:  domain.ContentType(r.Type),
+	}
+}
+
+// StreamRequest represents the query parameters for the content event stream.
+type StreamRequest struct {
+	Type string `query:"type" validate:"omitempty,oneof=video article podcast image"`
+	Tags string `query:"tags" validate:"max=500"` // Comma-separated; matches any
+}
+
+// ToEventFilter converts StreamRequest to domain.ContentEventFilter.
+func (r *StreamRequest) ToEventFilter() domain.ContentEventFilter {
+	filter := domain.ContentEventFilter{Type: domain.ContentType(r.Type)}
+
+	for _, tag := range strings.Split(r.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	return filter
+}
+
 // SyncRequest represents the request body for manual sync.
 type SyncRequest struct {
 	Provider string `json:"provider" validate:"omitempty,max=50"`
 }
+
+// UpdateProviderRequest represents the request body for switching a provider's syncs on or off.
+type UpdateProviderRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// CreateWebhookRequest represents the request body for registering a webhook.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,startswith=http,max=2048"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=256"` // Generated when empty
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=content.created content.updated sync.completed"`
+}
+
+// UpdateSettingsRequest represents the request body for changing runtime settings.
+// Omitted fields are left unchanged.
+type UpdateSettingsRequest struct {
+	CacheEnabled    *bool   `json:"cache_enabled"`
+	CacheSearchTTL  *string `json:"cache_search_ttl" validate:"omitempty,max=20"` // Go duration, e.g. "5m"
+	ScoringStrategy *string `json:"scoring_strategy" validate:"omitempty,oneof=hybrid text"`
+	SearchBackend   *string `json:"search_backend" validate:"omitempty,oneof=postgres bleve"`
+}
+
+// ToSettingsPatch converts UpdateSettingsRequest to domain.SettingsPatch.
+func (r *UpdateSettingsRequest) ToSettingsPatch() (domain.SettingsPatch, error) {
+	patch := domain.SettingsPatch{CacheEnabled: r.CacheEnabled}
+
+	if r.CacheSearchTTL != nil {
+		ttl, err := time.ParseDuration(*r.CacheSearchTTL)
+		if err != nil {
+			return domain.SettingsPatch{}, fmt.Errorf("cache_search_ttl: %w", err)
+		}
+		patch.CacheSearchTTL = &ttl
+	}
+	if r.ScoringStrategy != nil {
+		strategy := domain.ScoringStrategy(*r.ScoringStrategy)
+		patch.ScoringStrategy = &strategy
+	}
+	if r.SearchBackend != nil {
+		backend := domain.SearchBackend(*r.SearchBackend)
+		patch.SearchBackend = &backend
+	}
+
+	return patch, nil
+}
+
+// CollectionRequest represents the request body for creating or replacing a collection.
+type CollectionRequest struct {
+	Title       string   `json:"title" validate:"required,max=500"`
+	Description string   `json:"description" validate:"max=5000"`
+	ContentIDs  []string `json:"content_ids" validate:"max=500,unique,dive,uuid"` // In collection order
+}
+
+// UpdateCurationRequest represents the request body for changing a content's editorial
+// flags. Omitted fields are left unchanged.
+type UpdateCurationRequest struct {
+	Pinned  *bool    `json:"pinned"`
+	Blocked *bool    `json:"blocked"`
+	Boost   *float64 `json:"boost" validate:"omitempty,gte=0.1,lte=10"` // Ranking multiplier, 1 is neutral
+}
+
+// ToCurationPatch converts UpdateCurationRequest to domain.CurationPatch.
+func (r *UpdateCurationRequest) ToCurationPatch() domain.CurationPatch {
+	return domain.CurationPatch{
+		Pinned:  r.Pinned,
+		Blocked: r.Blocked,
+		Boost:   r.Boost,
+	}
+}
+
+// PurgeRequest represents the query parameters identifying a provider item to purge.
+type PurgeRequest struct {
+	Provider   string `query:"provider" validate:"required,max=100"`
+	ExternalID string `query:"external_id" validate:"required,max=255"`
+}
+
+// defaultAuditPageSize is the audit log page size when page_size is omitted.
+const defaultAuditPageSize = 50
+
+// AuditLogRequest represents the query parameters for listing audit log entries.
+type AuditLogRequest struct {
+	Actor    string `query:"actor" validate:"max=255"`
+	Method   string `query:"method" validate:"omitempty,oneof=GET POST PUT PATCH DELETE"`
+	From     string `query:"from" validate:"omitempty,max=64"` // RFC 3339, inclusive
+	To       string `query:"to" validate:"omitempty,max=64"`   // RFC 3339, exclusive
+	Page     int    `query:"page" validate:"omitempty,min=1"`
+	PageSize int    `query:"page_size" validate:"omitempty,min=1,max=200"`
+}
+
+// ToAuditFilter converts AuditLogRequest to domain.AuditFilter.
+func (r *AuditLogRequest) ToAuditFilter() (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		Actor:    r.Actor,
+		Method:   r.Method,
+		Page:     1,
+		PageSize: defaultAuditPageSize,
+	}
+
+	if r.From != "" {
+		from, err := time.Parse(time.RFC3339, r.From)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("from: %w", err)
+		}
+		filter.From = from
+	}
+	if r.To != "" {
+		to, err := time.Parse(time.RFC3339, r.To)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("to: %w", err)
+		}
+		filter.To = to
+	}
+	if r.Page > 0 {
+		filter.Page = r.Page
+	}
+	if r.PageSize > 0 {
+		filter.PageSize = r.PageSize
+	}
+
+	return filter, nil
+}
+
+// defaultChartDays is the charted period when days is omitted.
+const defaultChartDays = 30
+
+// ChartRequest represents the query parameters of the dashboard chart endpoints.
+// Sync runs are kept for 90 days, so longer periods would chart nothing more.
+type ChartRequest struct {
+	Days int `query:"days" validate:"omitempty,min=1,max=90"`
+}
+
+// ChartDays returns the number of UTC days to chart, today included.
+func (r *ChartRequest) ChartDays() int {
+	if r.Days > 0 {
+		return r.Days
+	}
+
+	return defaultChartDays
+}
+
+// AnalyticsEventsRequest represents the request body for reporting search result impressions and clicks
+// in batches of up to 100 events.
+type AnalyticsEventsRequest struct {
+	Events []AnalyticsEventRequest `json:"events" validate:"required,min=1,max=100,dive"`
+}
+
+// AnalyticsEventRequest represents a single reported impression or click.
+type AnalyticsEventRequest struct {
+	Type       string    `json:"type" validate:"required,oneof=impression click"`
+	Query      string    `json:"query" validate:"max=200"`
+	ContentID  string    `json:"content_id" validate:"required,uuid"`
+	Position   int       `json:"position" validate:"required,min=1,max=1000"` // 1-based rank within the results
+	SessionID  string    `json:"session_id" validate:"max=64"`
+	OccurredAt time.Time `json:"occurred_at"` // RFC 3339; defaults to the time of receipt
+}
+
+// ToAnalyticsEvents converts AnalyticsEventsRequest to domain.AnalyticsEvent values
+// reported by the request with requestID.
+func (r *AnalyticsEventsRequest) ToAnalyticsEvents(requestID string) []*domain.AnalyticsEvent {
+	events := make([]*domain.AnalyticsEvent, len(r.Events))
+	for i, e := range r.Events {
+		events[i] = &domain.AnalyticsEvent{
+			Type:       domain.AnalyticsEventType(e.Type),
+			Query:      e.Query,
+			ContentID:  e.ContentID,
+			Position:   e.Position,
+			SessionID:  e.SessionID,
+			RequestID:  requestID,
+			OccurredAt: e.OccurredAt,
+		}
+	}
+
+	return events
+}
+
+// Defaults of the query analytics report.
+const (
+	defaultSearchAnalyticsDays  = 7
+	defaultSearchAnalyticsLimit = 20
+)
+
+// SearchAnalyticsRequest represents the query parameters of the query analytics report.
+type SearchAnalyticsRequest struct {
+	Days  int `query:"days" validate:"omitempty,min=1,max=90"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ReportDays returns the number of days reported, counting back from now.
+func (r *SearchAnalyticsRequest) ReportDays() int {
+	if r.Days > 0 {
+		return r.Days
+	}
+
+	return defaultSearchAnalyticsDays
+}
+
+// ReportLimit returns the number of queries per list.
+func (r *SearchAnalyticsRequest) ReportLimit() int {
+	if r.Limit > 0 {
+		return r.Limit
+	}
+
+	return defaultSearchAnalyticsLimit
+}
+
+// QuotaReportRequest represents the query parameters of the API key usage report.
+type QuotaReportRequest struct {
+	Month string `query:"month" validate:"omitempty,len=7"` // YYYY-MM, the current month when empty
+}
+
+// defaultSuggestLimit is the number of suggestions returned by default.
+const defaultSuggestLimit = 5
+
+// SuggestRequest represents the query parameters of autocomplete suggestions.
+type SuggestRequest struct {
+	Query string `query:"q" validate:"required,max=200"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// SuggestLimit returns the number of suggestions to return.
+func (r *SuggestRequest) SuggestLimit() int {
+	if r.Limit > 0 {
+		return r.Limit
+	}
+
+	return defaultSuggestLimit
+}
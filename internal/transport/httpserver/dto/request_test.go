@@ -2,6 +2,7 @@ package dto
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -97,10 +98,24 @@ func TestSearchRequest_Validation_Invalid(t *testing.T) {
 		},
 		{
 			name:         "invalid type",
-			req:          SearchRequest{Type: "podcast", Page: 1, PageSize: 1},
+			req:          SearchRequest{Type: "audiobook", Page: 1, PageSize: 1},
 			expectField:  "Type",
 			expectTag:    "oneof",
-			expectErrMsg: "must be one of: video article",
+			expectErrMsg: "must be one of: video article podcast image",
+		},
+		{
+			name:         "invalid mode",
+			req:          SearchRequest{Mode: "fuzzy", Page: 1, PageSize: 1},
+			expectField:  "Mode",
+			expectTag:    "oneof",
+			expectErrMsg: "must be one of: websearch prefix",
+		},
+		{
+			name:         "invalid duration",
+			req:          SearchRequest{Duration: "epic", Page: 1, PageSize: 1},
+			expectField:  "Duration",
+			expectTag:    "oneof",
+			expectErrMsg: "must be one of: short medium long",
 		},
 		{
 			name:         "invalid sort field",
@@ -207,7 +222,11 @@ func TestSearchRequest_ToSearchParams(t *testing.T) {
 			name: "full request converts correctly",
 			req: SearchRequest{
 				Query:     "golang",
+				Mode:      "prefix",
 				Type:      "video",
+				Provider:  "provider_a",
+				Tag:       "tutorial",
+				Duration:  "long",
 				SortBy:    "published_at",
 				SortOrder: "asc",
 				Page:      3,
@@ -215,7 +234,11 @@ func TestSearchRequest_ToSearchParams(t *testing.T) {
 			},
 			expected: domain.SearchParams{
 				Query:     "golang",
+				Mode:      domain.SearchModePrefix,
 				Type:      domain.ContentTypeVideo,
+				Provider:  "provider_a",
+				Tag:       "tutorial",
+				Duration:  domain.DurationLong,
 				SortBy:    domain.SortFieldPublishedAt,
 				SortOrder: domain.SortOrderAsc,
 				Page:      3,
@@ -254,6 +277,9 @@ func TestSearchRequest_ToSearchParams(t *testing.T) {
 
 			assert.Equal(t, tt.expected.Query, result.Query)
 			assert.Equal(t, tt.expected.Type, result.Type)
+			assert.Equal(t, tt.expected.Provider, result.Provider)
+			assert.Equal(t, tt.expected.Tag, result.Tag)
+			assert.Equal(t, tt.expected.Duration, result.Duration)
 			assert.Equal(t, tt.expected.SortBy, result.SortBy)
 			assert.Equal(t, tt.expected.SortOrder, result.SortOrder)
 			assert.Equal(t, tt.expected.Page, result.Page)
@@ -340,8 +366,8 @@ func TestValidationErrors_Error(t *testing.T) {
 func TestSearchRequest_Validation_ContentTypes(t *testing.T) {
 	v := newTestValidator()
 
-	validTypes := []string{"", "video", "article"}
-	invalidTypes := []string{"text", "podcast", "image", "VIDEO", "Article"}
+	validTypes := []string{"", "video", "article", "podcast", "image"}
+	invalidTypes := []string{"text", "audiobook", "VIDEO", "Article", "Podcast"}
 
 	for _, contentType := range validTypes {
 		t.Run("valid_"+contentType, func(t *testing.T) {
@@ -413,3 +439,111 @@ func TestSearchRequest_Validation_SortOrders(t *testing.T) {
 		})
 	}
 }
+
+// TestAuditLogRequest_ToAuditFilter tests defaults and time parsing of audit log filters.
+func TestAuditLogRequest_ToAuditFilter(t *testing.T) {
+	filter, err := (&AuditLogRequest{}).ToAuditFilter()
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditFilter{Page: 1, PageSize: defaultAuditPageSize}, filter)
+
+	req := AuditLogRequest{
+		Actor:    "user-1",
+		Method:   "DELETE",
+		From:     "2026-10-01T00:00:00Z",
+		To:       "2026-10-02T00:00:00Z",
+		Page:     3,
+		PageSize: 20,
+	}
+	filter, err = req.ToAuditFilter()
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", filter.Actor)
+	assert.Equal(t, "DELETE", filter.Method)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), filter.From)
+	assert.Equal(t, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), filter.To)
+	assert.Equal(t, 3, filter.Page)
+	assert.Equal(t, 20, filter.PageSize)
+
+	_, err = (&AuditLogRequest{From: "yesterday"}).ToAuditFilter()
+	assert.Error(t, err)
+}
+
+func TestAnalyticsEventsRequest_Validation(t *testing.T) {
+	v := newTestValidator()
+	valid := AnalyticsEventRequest{
+		Type:      "click",
+		Query:     "golang",
+		ContentID: "9b2f4c1e-0d3a-4f6b-8c7d-2e1f0a9b8c7d",
+		Position:  3,
+	}
+
+	assert.NoError(t, v.Validate(&AnalyticsEventsRequest{Events: []AnalyticsEventRequest{valid}}))
+	assert.Error(t, v.Validate(&AnalyticsEventsRequest{}), "empty batch")
+
+	invalid := []func(e *AnalyticsEventRequest){
+		func(e *AnalyticsEventRequest) { e.Type = "view" },
+		func(e *AnalyticsEventRequest) { e.ContentID = "42" },
+		func(e *AnalyticsEventRequest) { e.Position = 0 },
+		func(e *AnalyticsEventRequest) { e.Position = 1001 },
+	}
+	for _, mutate := range invalid {
+		e := valid
+		mutate(&e)
+		assert.Error(t, v.Validate(&AnalyticsEventsRequest{Events: []AnalyticsEventRequest{e}}), "%+v", e)
+	}
+
+	tooMany := make([]AnalyticsEventRequest, 101)
+	for i := range tooMany {
+		tooMany[i] = valid
+	}
+	assert.Error(t, v.Validate(&AnalyticsEventsRequest{Events: tooMany}))
+}
+
+func TestUpdateCurationRequest_Validation(t *testing.T) {
+	v := newTestValidator()
+	boost := func(b float64) *float64 { return &b }
+
+	assert.NoError(t, v.Validate(&UpdateCurationRequest{}))
+	assert.NoError(t, v.Validate(&UpdateCurationRequest{Boost: boost(0.1)}))
+	assert.NoError(t, v.Validate(&UpdateCurationRequest{Boost: boost(10)}))
+	assert.Error(t, v.Validate(&UpdateCurationRequest{Boost: boost(0)}))
+	assert.Error(t, v.Validate(&UpdateCurationRequest{Boost: boost(-1)}))
+	assert.Error(t, v.Validate(&UpdateCurationRequest{Boost: boost(10.5)}))
+
+	pinned := true
+	patch := (&UpdateCurationRequest{Pinned: &pinned}).ToCurationPatch()
+	assert.Equal(t, &pinned, patch.Pinned)
+	assert.Nil(t, patch.Blocked)
+	assert.Nil(t, patch.Boost)
+}
+
+func TestCollectionRequest_Validation(t *testing.T) {
+	v := newTestValidator()
+	id1 := "3f2b8c4e-1d5a-4e8b-9c7f-2a6d1e0b5c93"
+	id2 := "7a1c9e2f-4b6d-4f8a-8e3c-5d2b7f1a9c04"
+
+	assert.NoError(t, v.Validate(&CollectionRequest{Title: "Go Series"}))
+	assert.NoError(t, v.Validate(&CollectionRequest{Title: "Go Series", ContentIDs: []string{id1, id2}}))
+	assert.Error(t, v.Validate(&CollectionRequest{ContentIDs: []string{id1}}), "title is required")
+	assert.Error(t, v.Validate(&CollectionRequest{Title: "Go Series", ContentIDs: []string{id1, id1}}), "contents are unique")
+	assert.Error(t, v.Validate(&CollectionRequest{Title: "Go Series", ContentIDs: []string{"c-1"}}), "contents are IDs")
+}
+
+func TestAnalyticsEventsRequest_ToAnalyticsEvents(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	req := AnalyticsEventsRequest{Events: []AnalyticsEventRequest{
+		{Type: "impression", Query: "go", ContentID: "c-1", Position: 1, SessionID: "s-1", OccurredAt: at},
+	}}
+
+	events := req.ToAnalyticsEvents("req-1")
+
+	require.Len(t, events, 1)
+	assert.Equal(t, &domain.AnalyticsEvent{
+		Type:       domain.AnalyticsEventImpression,
+		Query:      "go",
+		ContentID:  "c-1",
+		Position:   1,
+		SessionID:  "s-1",
+		RequestID:  "req-1",
+		OccurredAt: at,
+	}, events[0])
+}
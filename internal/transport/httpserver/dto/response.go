@@ -1,72 +1,155 @@
 package dto
 
 import (
+	"encoding/xml"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"search-engine-service/internal/app/service"
 	"search-engine-service/internal/domain"
+	"search-engine-service/pkg/locker"
 )
 
 // ContentResponse represents a single content item in the response.
 type ContentResponse struct {
-	ID         string   `json:"id"`
-	ProviderID string   `json:"provider_id"`
-	ExternalID string   `json:"external_id"`
-	Title      string   `json:"title"`
-	Type       string   `json:"type"`
-	Tags       []string `json:"tags,omitempty"`
+	XMLName    xml.Name `json:"-" xml:"content"`
+	ID         string   `json:"id" xml:"id"`
+	ProviderID string   `json:"provider_id" xml:"provider_id"`
+	ExternalID string   `json:"external_id" xml:"external_id"`
+	Title      string   `json:"title" xml:"title"`
+	Type       string   `json:"type" xml:"type"`
+	Tags       []string `json:"tags,omitempty" xml:"tags>tag,omitempty"`
+
+	// Source
+	URL          string `json:"url,omitempty" xml:"url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty" xml:"thumbnail_url,omitempty"`
+	Author       string `json:"author,omitempty" xml:"author,omitempty"`
 
 	// Metrics
-	Views       int    `json:"views,omitempty"`
-	Likes       int    `json:"likes,omitempty"`
-	Duration    string `json:"duration,omitempty"`
-	ReadingTime int    `json:"reading_time,omitempty"`
-	Reactions   int    `json:"reactions,omitempty"`
-	Comments    int    `json:"comments,omitempty"`
+	Views           int    `json:"views,omitempty" xml:"views,omitempty"`
+	Likes           int    `json:"likes,omitempty" xml:"likes,omitempty"`
+	Duration        string `json:"duration,omitempty" xml:"duration,omitempty"` // Clock notation, e.g. "15:30"
+	DurationSeconds int    `json:"duration_seconds,omitempty" xml:"duration_seconds,omitempty"`
+	DurationBucket  string `json:"duration_bucket,omitempty" xml:"duration_bucket,omitempty"` // Videos: short, medium or long
+	Listens         int    `json:"listens,omitempty" xml:"listens,omitempty"`
+	ReadingTime     int    `json:"reading_time,omitempty" xml:"reading_time,omitempty"`
+	Reactions       int    `json:"reactions,omitempty" xml:"reactions,omitempty"`
+	Comments        int    `json:"comments,omitempty" xml:"comments,omitempty"`
 
 	// Score
-	Score float64 `json:"score"`
+	Score float64 `json:"score" xml:"score"`
 
 	// Timestamps
-	PublishedAt string `json:"published_at"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	PublishedAt string `json:"published_at" xml:"published_at"`
+	CreatedAt   string `json:"created_at" xml:"created_at"`
+	UpdatedAt   string `json:"updated_at" xml:"updated_at"`
 }
 
 // FromDomainContent converts domain.Content to ContentResponse.
 func FromDomainContent(c *domain.Content) ContentResponse {
 	return ContentResponse{
-		ID:          c.ID,
-		ProviderID:  c.ProviderID,
-		ExternalID:  c.ExternalID,
-		Title:       c.Title,
-		Type:        string(c.Type),
-		Tags:        c.Tags,
-		Views:       c.Views,
-		Likes:       c.Likes,
-		Duration:    c.Duration,
-		ReadingTime: c.ReadingTime,
-		Reactions:   c.Reactions,
-		Comments:    c.Comments,
-		Score:       c.Score,
-		PublishedAt: c.PublishedAt.Format(time.RFC3339),
-		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
+		ID:              c.ID,
+		ProviderID:      c.ProviderID,
+		ExternalID:      c.ExternalID,
+		Title:           c.Title,
+		Type:            string(c.Type),
+		Tags:            c.Tags,
+		URL:             c.URL,
+		ThumbnailURL:    c.ThumbnailURL,
+		Author:          c.Author,
+		Views:           c.Views,
+		Likes:           c.Likes,
+		Duration:        c.FormattedDuration(),
+		DurationSeconds: c.DurationSeconds,
+		DurationBucket:  string(c.DurationBucket()),
+		Listens:         c.Listens,
+		ReadingTime:     c.ReadingTime,
+		Reactions:       c.Reactions,
+		Comments:        c.Comments,
+		Score:           c.Score,
+		PublishedAt:     c.PublishedAt.Format(time.RFC3339),
+		CreatedAt:       c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ContentEventResponse represents a content event pushed to stream clients.
+type ContentEventResponse struct {
+	Type       string          `json:"type"`
+	Content    ContentResponse `json:"content"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// FromContentEvent converts domain.ContentEvent to ContentEventResponse.
+func FromContentEvent(e domain.ContentEvent) ContentEventResponse {
+	return ContentEventResponse{
+		Type:       string(e.Type),
+		Content:    FromDomainContent(e.Content),
+		OccurredAt: e.OccurredAt,
 	}
 }
 
 // SearchResponse represents the search results response.
 type SearchResponse struct {
-	Contents   []ContentResponse `json:"contents"`
-	Pagination PaginationMeta    `json:"pagination"`
+	XMLName    xml.Name          `json:"-" xml:"search_response"`
+	Contents   []ContentResponse `json:"contents" xml:"contents>content"`
+	Pagination PaginationMeta    `json:"pagination" xml:"pagination"`
+	Links      PaginationLinks   `json:"links" xml:"links"`
+
+	// Degraded is set when the database was too slow: the results may be outdated, or
+	// uncounted with pagination.total a lower bound
+	Degraded bool `json:"degraded,omitempty" xml:"degraded,omitempty"`
 }
 
 // PaginationMeta holds pagination metadata.
 type PaginationMeta struct {
-	Total      int64 `json:"total"`
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	TotalPages int   `json:"total_pages"`
+	Total      int64 `json:"total" xml:"total"`
+	Page       int   `json:"page" xml:"page"`
+	PageSize   int   `json:"page_size" xml:"page_size"`
+	TotalPages int   `json:"total_pages" xml:"total_pages"`
+}
+
+// PaginationLinks holds URLs of neighbouring result pages, relative to the server root.
+// Next and Prev are omitted on the last and first page.
+type PaginationLinks struct {
+	Self  string `json:"self" xml:"self"`
+	First string `json:"first" xml:"first"`
+	Prev  string `json:"prev,omitempty" xml:"prev,omitempty"`
+	Next  string `json:"next,omitempty" xml:"next,omitempty"`
+	Last  string `json:"last" xml:"last"`
+}
+
+// NewPaginationLinks builds the page links for a search on path, keeping every query
+// parameter of the request except page. An empty result still links to page 1 as first and last.
+func NewPaginationLinks(path string, query url.Values, meta PaginationMeta) PaginationLinks {
+	pageURL := func(page int) string {
+		q := make(url.Values, len(query)+1)
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+
+		return path + "?" + q.Encode()
+	}
+
+	last := max(meta.TotalPages, 1)
+	links := PaginationLinks{
+		Self:  pageURL(meta.Page),
+		First: pageURL(1),
+		Last:  pageURL(last),
+	}
+	if meta.Page > 1 {
+		// A page past the end links back to the last one
+		links.Prev = pageURL(min(meta.Page-1, last))
+	}
+	if meta.Page < meta.TotalPages {
+		links.Next = pageURL(meta.Page + 1)
+	}
+
+	return links
 }
 
 // FromSearchResult converts domain.SearchResult to SearchResponse.
@@ -84,15 +167,60 @@ func FromSearchResult(result *domain.SearchResult) SearchResponse {
 			PageSize:   result.PageSize,
 			TotalPages: result.TotalPages,
 		},
+		Degraded: result.Degraded,
 	}
 }
 
+// FacetsResponse represents the number of matching contents per facet value.
+type FacetsResponse struct {
+	XMLName   xml.Name        `json:"-" xml:"facets"`
+	Types     []FacetResponse `json:"types" xml:"types>facet"`
+	Providers []FacetResponse `json:"providers" xml:"providers>facet"`
+	Tags      []FacetResponse `json:"tags" xml:"tags>facet"`
+	Durations []FacetResponse `json:"durations" xml:"durations>facet"`
+}
+
+// FacetResponse represents a facet value and its number of matching contents.
+type FacetResponse struct {
+	Value string `json:"value" xml:"value"`
+	Count int64  `json:"count" xml:"count"`
+}
+
+// FromSearchFacets converts domain.SearchFacets to FacetsResponse.
+func FromSearchFacets(f *domain.SearchFacets) FacetsResponse {
+	return FacetsResponse{
+		Types:     fromFacetCounts(f.Types),
+		Providers: fromFacetCounts(f.Providers),
+		Tags:      fromFacetCounts(f.Tags),
+		Durations: fromFacetCounts(f.Durations),
+	}
+}
+
+// fromFacetCounts converts facet counts, keeping an empty facet as an empty list.
+func fromFacetCounts(counts []domain.FacetCount) []FacetResponse {
+	facets := make([]FacetResponse, len(counts))
+	for i, c := range counts {
+		facets[i] = FacetResponse{Value: c.Value, Count: c.Count}
+	}
+
+	return facets
+}
+
+// ProviderResponse represents whether a provider is synced with the others.
+type ProviderResponse struct {
+	Provider string `json:"provider"`
+	Enabled  bool   `json:"enabled"`
+}
+
 // SyncResultResponse represents the response for a sync operation.
 type SyncResultResponse struct {
-	Provider string `json:"provider"`
-	Count    int    `json:"count"`
-	Duration string `json:"duration"`
-	Error    string `json:"error,omitempty"`
+	Provider  string `json:"provider"`
+	Count     int    `json:"count"`
+	Changed   int    `json:"changed"`            // Contents of count created or changed
+	Rejected  int    `json:"rejected,omitempty"` // Items breaking the provider's schema, kept as dead letters
+	Duration  string `json:"duration"`
+	Error     string `json:"error,omitempty"`
+	ErrorKind string `json:"error_kind,omitempty"` // timeout, interrupted, provider or database
 }
 
 // SyncResponse represents the response for sync all operation.
@@ -127,14 +255,420 @@ func FromSyncResults(results []service.SyncResult) SyncResponse {
 		resp.Results[i] = SyncResultResponse{
 			Provider: r.Provider,
 			Count:    r.Count,
+			Changed:  r.Changed,
+			Rejected: r.Rejected,
 			Duration: r.Duration.String(),
 			Error:    errMsg,
+
+			ErrorKind: string(r.ErrorKind),
+		}
+	}
+
+	return resp
+}
+
+// CacheStatsResponse represents cache usage counters.
+type CacheStatsResponse struct {
+	Enabled bool              `json:"enabled"`
+	Stats   domain.CacheStats `json:"stats"`
+}
+
+// WebhookResponse represents a webhook subscription.
+// Secret is only populated in the response to the create request.
+type WebhookResponse struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FromDomainWebhook converts domain.Webhook to WebhookResponse without the secret.
+func FromDomainWebhook(w *domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// CollectionResponse represents a collection with the IDs of its contents.
+type CollectionResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	ContentIDs  []string  `json:"content_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FromDomainCollection converts domain.Collection to CollectionResponse.
+func FromDomainCollection(c *domain.Collection) CollectionResponse {
+	return CollectionResponse{
+		ID:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		ContentIDs:  c.ContentIDs,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// CollectionContentsResponse represents a collection with its contents, in order.
+type CollectionContentsResponse struct {
+	XMLName     xml.Name          `json:"-" xml:"collection"`
+	ID          string            `json:"id" xml:"id"`
+	Title       string            `json:"title" xml:"title"`
+	Description string            `json:"description,omitempty" xml:"description,omitempty"`
+	Contents    []ContentResponse `json:"contents" xml:"contents>content"`
+	CreatedAt   string            `json:"created_at" xml:"created_at"`
+	UpdatedAt   string            `json:"updated_at" xml:"updated_at"`
+}
+
+// FromCollectionContents converts a collection and its contents to CollectionContentsResponse.
+func FromCollectionContents(c *domain.Collection, contents []*domain.Content) CollectionContentsResponse {
+	resp := CollectionContentsResponse{
+		ID:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		Contents:    make([]ContentResponse, len(contents)),
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, content := range contents {
+		resp.Contents[i] = FromDomainContent(content)
+	}
+
+	return resp
+}
+
+// SettingsResponse represents the runtime settings.
+type SettingsResponse struct {
+	CacheEnabled    bool       `json:"cache_enabled"`
+	CacheSearchTTL  string     `json:"cache_search_ttl"`
+	ScoringStrategy string     `json:"scoring_strategy"`
+	SearchBackend   string     `json:"search_backend"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"` // Omitted until first changed
+
+	ProviderSyncs map[string]bool `json:"provider_syncs,omitempty"` // Providers switched through the admin API
+}
+
+// FromRuntimeSettings converts domain.RuntimeSettings to SettingsResponse.
+func FromRuntimeSettings(s domain.RuntimeSettings) SettingsResponse {
+	resp := SettingsResponse{
+		CacheEnabled:    s.CacheEnabled,
+		CacheSearchTTL:  s.CacheSearchTTL.String(),
+		ScoringStrategy: string(s.ScoringStrategy),
+		SearchBackend:   string(s.SearchBackend),
+		ProviderSyncs:   s.ProviderSyncs,
+	}
+	if !s.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &s.UpdatedAt
+	}
+
+	return resp
+}
+
+// ReindexResponse represents the progress of the last search index rebuild.
+type ReindexResponse struct {
+	State      string     `json:"state"`
+	Indexed    int64      `json:"indexed"`
+	Total      int64      `json:"total"`
+	Progress   float64    `json:"progress"`              // Percentage of contents indexed
+	StartedAt  *time.Time `json:"started_at,omitempty"`  // Omitted until the first rebuild
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // Omitted while running
+	Error      string     `json:"error,omitempty"`
+}
+
+// FromReindexStatus converts domain.ReindexStatus to ReindexResponse.
+func FromReindexStatus(s domain.ReindexStatus) ReindexResponse {
+	resp := ReindexResponse{
+		State:    string(s.State),
+		Indexed:  s.Indexed,
+		Total:    s.Total,
+		Progress: math.Round(s.Progress()*10) / 10,
+		Error:    s.Error,
+	}
+	if !s.StartedAt.IsZero() {
+		resp.StartedAt = &s.StartedAt
+	}
+	if !s.FinishedAt.IsZero() {
+		resp.FinishedAt = &s.FinishedAt
+	}
+
+	return resp
+}
+
+// PurgeResponse represents what purging a provider item removed.
+type PurgeResponse struct {
+	Provider    string `json:"provider"`
+	ExternalID  string `json:"external_id"`
+	ContentID   string `json:"content_id,omitempty"` // Omitted when no content of the item was stored
+	DeadLetters int64  `json:"dead_letters"`         // Rejected payloads of the item removed
+}
+
+// FromPurgeResult converts the result of purging the item externalID of provider to
+// PurgeResponse.
+func FromPurgeResult(provider, externalID string, r service.PurgeResult) PurgeResponse {
+	return PurgeResponse{
+		Provider:    provider,
+		ExternalID:  externalID,
+		ContentID:   r.ContentID,
+		DeadLetters: r.DeadLetters,
+	}
+}
+
+// QuotaUsageResponse represents the use of an API key's monthly request quota.
+type QuotaUsageResponse struct {
+	Name      string    `json:"name"`
+	Limit     int64     `json:"limit"`     // 0 for unlimited
+	Requests  int64     `json:"requests"`  // Rejected ones included
+	Rejected  int64     `json:"rejected"`  // Over the quota
+	Remaining int64     `json:"remaining"` // 0 for unlimited
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// QuotaReportResponse represents the use of the quotas of every API key in a month.
+type QuotaReportResponse struct {
+	Month string               `json:"month"` // YYYY-MM
+	Keys  []QuotaUsageResponse `json:"keys"`
+}
+
+// FromQuotaUsage converts the use of the quotas of month to QuotaReportResponse.
+func FromQuotaUsage(month string, usage []domain.QuotaUsage) QuotaReportResponse {
+	keys := make([]QuotaUsageResponse, len(usage))
+	for i, u := range usage {
+		keys[i] = QuotaUsageResponse{
+			Name:      u.Name,
+			Limit:     u.Limit,
+			Requests:  u.Requests,
+			Rejected:  u.Rejected(),
+			Remaining: u.Remaining(),
+			ResetAt:   u.ResetAt,
+		}
+	}
+
+	return QuotaReportResponse{Month: month, Keys: keys}
+}
+
+// SnapshotResponse represents a stored content snapshot.
+type SnapshotResponse struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"` // In bytes, compressed
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// FromSnapshotInfos converts stored snapshots to SnapshotResponses.
+func FromSnapshotInfos(snapshots []domain.SnapshotInfo) []SnapshotResponse {
+	resp := make([]SnapshotResponse, len(snapshots))
+	for i, s := range snapshots {
+		resp[i] = SnapshotResponse{Name: s.Name, Size: s.Size, ModifiedAt: s.ModifiedAt}
+	}
+
+	return resp
+}
+
+// SnapshotJobResponse represents the progress of the last snapshot creation or restore.
+type SnapshotJobResponse struct {
+	Operation  string     `json:"operation,omitempty"` // Omitted until the first job
+	State      string     `json:"state"`
+	Name       string     `json:"name,omitempty"`
+	Contents   int64      `json:"contents"`              // Contents written or restored so far
+	StartedAt  *time.Time `json:"started_at,omitempty"`  // Omitted until the first job
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // Omitted while running
+	Error      string     `json:"error,omitempty"`
+}
+
+// FromSnapshotStatus converts domain.SnapshotStatus to SnapshotJobResponse.
+func FromSnapshotStatus(s domain.SnapshotStatus) SnapshotJobResponse {
+	resp := SnapshotJobResponse{
+		Operation: string(s.Operation),
+		State:     string(s.State),
+		Name:      s.Name,
+		Contents:  s.Contents,
+		Error:     s.Error,
+	}
+	if !s.StartedAt.IsZero() {
+		resp.StartedAt = &s.StartedAt
+	}
+	if !s.FinishedAt.IsZero() {
+		resp.FinishedAt = &s.FinishedAt
+	}
+
+	return resp
+}
+
+// CuratedContentResponse represents a content along with its editorial flags.
+type CuratedContentResponse struct {
+	ContentResponse
+	Pinned  bool    `json:"pinned"`
+	Blocked bool    `json:"blocked"`
+	Boost   float64 `json:"boost"`
+}
+
+// FromCuratedContent converts domain.Content to CuratedContentResponse.
+func FromCuratedContent(c *domain.Content) CuratedContentResponse {
+	return CuratedContentResponse{
+		ContentResponse: FromDomainContent(c),
+		Pinned:          c.Pinned,
+		Blocked:         c.Blocked,
+		Boost:           c.Boost,
+	}
+}
+
+// CuratedContentsResponse represents the list of curated contents.
+type CuratedContentsResponse struct {
+	Contents []CuratedContentResponse `json:"contents"`
+}
+
+// FromCuratedContents converts curated contents to CuratedContentsResponse.
+func FromCuratedContents(contents []*domain.Content) CuratedContentsResponse {
+	resp := CuratedContentsResponse{Contents: make([]CuratedContentResponse, len(contents))}
+	for i, c := range contents {
+		resp.Contents[i] = FromCuratedContent(c)
+	}
+
+	return resp
+}
+
+// LockResponse represents a held distributed lock.
+type LockResponse struct {
+	Key          string     `json:"key"`
+	Holder       string     `json:"holder,omitempty"`      // Omitted when the backend didn't record it
+	AcquiredAt   *time.Time `json:"acquired_at,omitempty"` // Omitted when the backend didn't record it
+	TTL          string     `json:"ttl"`                   // Time left until the lock expires unless renewed
+	FencingToken int64      `json:"fencing_token,omitempty"`
+}
+
+// FromLockInfos converts held locks to LockResponses.
+func FromLockInfos(locks []locker.LockInfo) []LockResponse {
+	resp := make([]LockResponse, len(locks))
+	for i, l := range locks {
+		resp[i] = LockResponse{
+			Key:          l.Key,
+			Holder:       l.Holder,
+			TTL:          l.TTL.Round(time.Millisecond).String(),
+			FencingToken: l.Token,
+		}
+		if !l.AcquiredAt.IsZero() {
+			resp[i].AcquiredAt = &l.AcquiredAt
 		}
 	}
 
 	return resp
 }
 
+// AuditLogResponse represents a page of audit log entries, newest first.
+type AuditLogResponse struct {
+	Entries    []*domain.AuditEntry `json:"entries"`
+	Pagination PaginationMeta       `json:"pagination"`
+}
+
+// FromAuditEntries converts a page of audit entries matching filter to AuditLogResponse.
+func FromAuditEntries(entries []*domain.AuditEntry, total int64, filter domain.AuditFilter) AuditLogResponse {
+	totalPages := int(total) / filter.PageSize
+	if int(total)%filter.PageSize > 0 {
+		totalPages++
+	}
+
+	return AuditLogResponse{
+		Entries: entries,
+		Pagination: PaginationMeta{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+// chartDayLayout formats the days of chart points.
+const chartDayLayout = "2006-01-02"
+
+// ContentGrowthResponse represents the number of contents at the end of each day.
+type ContentGrowthResponse struct {
+	Points []DailyCountResponse `json:"points"`
+}
+
+// DailyCountResponse represents a count for a UTC day.
+type DailyCountResponse struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+}
+
+// FromDailyCounts converts daily counts to ContentGrowthResponse.
+func FromDailyCounts(points []domain.DailyCount) ContentGrowthResponse {
+	resp := ContentGrowthResponse{Points: make([]DailyCountResponse, len(points))}
+	for i, p := range points {
+		resp.Points[i] = DailyCountResponse{Day: p.Day.Format(chartDayLayout), Count: p.Count}
+	}
+
+	return resp
+}
+
+// SyncRunsResponse represents the provider syncs of a period, oldest first.
+type SyncRunsResponse struct {
+	Runs []SyncRunResponse `json:"runs"`
+}
+
+// SyncRunResponse represents a recorded provider sync.
+type SyncRunResponse struct {
+	Provider   string `json:"provider"`
+	Count      int    `json:"count"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+	FinishedAt string `json:"finished_at"`
+}
+
+// FromSyncRuns converts sync runs to SyncRunsResponse.
+func FromSyncRuns(runs []*domain.SyncRun) SyncRunsResponse {
+	resp := SyncRunsResponse{Runs: make([]SyncRunResponse, len(runs))}
+	for i, r := range runs {
+		resp.Runs[i] = SyncRunResponse{
+			Provider:   r.Provider,
+			Count:      r.Count,
+			DurationMs: r.Duration.Milliseconds(),
+			Error:      r.Error,
+			FinishedAt: r.FinishedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	return resp
+}
+
+// SyncFailuresResponse represents the failed syncs per provider on each day.
+type SyncFailuresResponse struct {
+	Days      []string                   `json:"days"`
+	Providers []ProviderFailuresResponse `json:"providers"`
+}
+
+// ProviderFailuresResponse represents the failed syncs of a provider, one count per day.
+type ProviderFailuresResponse struct {
+	Provider string  `json:"provider"`
+	Failures []int64 `json:"failures"`
+}
+
+// FromSyncFailures converts daily failure series to SyncFailuresResponse.
+func FromSyncFailures(days []time.Time, series []domain.ProviderSeries) SyncFailuresResponse {
+	resp := SyncFailuresResponse{
+		Days:      make([]string, len(days)),
+		Providers: make([]ProviderFailuresResponse, len(series)),
+	}
+	for i, d := range days {
+		resp.Days[i] = d.Format(chartDayLayout)
+	}
+	for i, s := range series {
+		resp.Providers[i] = ProviderFailuresResponse{Provider: s.Provider, Failures: s.Counts}
+	}
+
+	return resp
+}
+
 // HealthResponse represents health check response.
 type HealthResponse struct {
 	Status    string            `json:"status"`
@@ -142,11 +676,170 @@ type HealthResponse struct {
 	Timestamp string            `json:"timestamp"`
 }
 
+// FromProviderHealth converts provider health results to HealthResponse.
+// Status is "ok" when every provider is healthy and "degraded" otherwise.
+func FromProviderHealth(results []service.ProviderHealth) HealthResponse {
+	resp := HealthResponse{
+		Status:    "ok",
+		Checks:    make(map[string]string, len(results)),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	for _, r := range results {
+		if r.Error != nil {
+			resp.Checks[r.Provider] = r.Error.Error()
+			resp.Status = "degraded"
+
+			continue
+		}
+		resp.Checks[r.Provider] = "ok"
+	}
+
+	return resp
+}
+
+// HealthDetailsResponse represents the per-dependency health report.
+// Error messages are left out since the endpoint is public; they are logged instead.
+type HealthDetailsResponse struct {
+	Status       string                            `json:"status"`
+	Dependencies map[string]DependencyStatus       `json:"dependencies"`
+	Providers    map[string]ProviderStatusResponse `json:"providers"`
+	Timestamp    string                            `json:"timestamp"`
+}
+
+// DependencyStatus represents the health of a single dependency.
+type DependencyStatus struct {
+	Status    string  `json:"status"` // "up" or "down"
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// ProviderStatusResponse represents a provider's breaker state and sync freshness.
+type ProviderStatusResponse struct {
+	BreakerState       string     `json:"breaker_state,omitempty"`
+	LastSyncAt         *time.Time `json:"last_sync_at,omitempty"` // Omitted until the provider's first sync
+	LastSyncAgeSeconds *int64     `json:"last_sync_age_seconds,omitempty"`
+}
+
+// FromHealthReport converts service.HealthReport to HealthDetailsResponse.
+func FromHealthReport(report service.HealthReport) HealthDetailsResponse {
+	resp := HealthDetailsResponse{
+		Status:       report.Status,
+		Dependencies: make(map[string]DependencyStatus, len(report.Dependencies)),
+		Providers:    make(map[string]ProviderStatusResponse, len(report.Providers)),
+		Timestamp:    report.CheckedAt.Format(time.RFC3339),
+	}
+
+	for _, d := range report.Dependencies {
+		status := "up"
+		if d.Error != nil {
+			status = "down"
+		}
+		resp.Dependencies[d.Name] = DependencyStatus{
+			Status:    status,
+			LatencyMs: float64(d.Latency.Microseconds()) / 1000,
+		}
+	}
+
+	for _, p := range report.Providers {
+		status := ProviderStatusResponse{BreakerState: p.BreakerState}
+		if !p.LastSyncAt.IsZero() {
+			lastSync := p.LastSyncAt.UTC()
+			age := int64(report.CheckedAt.Sub(lastSync).Seconds())
+			status.LastSyncAt = &lastSync
+			status.LastSyncAgeSeconds = &age
+		}
+		resp.Providers[p.Provider] = status
+	}
+
+	return resp
+}
+
+// AnalyticsEventsResponse acknowledges stored analytics events.
+type AnalyticsEventsResponse struct {
+	Accepted int `json:"accepted"`
+}
+
+// SearchAnalyticsResponse represents the most frequent queries of a period.
+type SearchAnalyticsResponse struct {
+	Since             string              `json:"since"`
+	Hashed            bool                `json:"hashed"` // Queries are SHA-256 hashes of the normalized queries
+	TopQueries        []QueryStatResponse `json:"top_queries"`
+	ZeroResultQueries []QueryStatResponse `json:"zero_result_queries"`
+}
+
+// QueryStatResponse represents the searches for a query.
+type QueryStatResponse struct {
+	Query          string  `json:"query"`
+	Searches       int64   `json:"searches"`
+	AvgResults     float64 `json:"avg_results"`
+	AvgLatencyMs   float64 `json:"avg_latency_ms"`
+	LastSearchedAt string  `json:"last_searched_at"`
+}
+
+// FromSearchAnalytics converts domain.SearchAnalytics to SearchAnalyticsResponse.
+func FromSearchAnalytics(a *domain.SearchAnalytics) SearchAnalyticsResponse {
+	return SearchAnalyticsResponse{
+		Since:             a.Since.UTC().Format(time.RFC3339),
+		Hashed:            a.Hashed,
+		TopQueries:        fromQueryStats(a.Top),
+		ZeroResultQueries: fromQueryStats(a.ZeroResults),
+	}
+}
+
+// fromQueryStats converts query stats to responses, rounding averages to 2 decimals.
+func fromQueryStats(stats []domain.QueryStat) []QueryStatResponse {
+	round := func(f float64) float64 { return math.Round(f*100) / 100 }
+
+	resp := make([]QueryStatResponse, len(stats))
+	for i, s := range stats {
+		resp[i] = QueryStatResponse{
+			Query:          s.Query,
+			Searches:       s.Searches,
+			AvgResults:     round(s.AvgResults),
+			AvgLatencyMs:   round(float64(s.AvgLatency) / float64(time.Millisecond)),
+			LastSearchedAt: s.LastSearchedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	return resp
+}
+
 // ErrorResponse represents an error response.
+// Details are omitted from XML since they can hold arbitrary values.
 type ErrorResponse struct {
-	Error   string      `json:"error"`
-	Code    string      `json:"code,omitempty"`
-	Details interface{} `json:"details,omitempty"`
+	XMLName xml.Name    `json:"-" xml:"error_response"`
+	Error   string      `json:"error" xml:"error"`
+	Code    string      `json:"code,omitempty" xml:"code,omitempty"`
+	Details interface{} `json:"details,omitempty" xml:"-"`
+}
+
+// MIMEProblemJSON is the media type of RFC 9457 problem details.
+const MIMEProblemJSON = "application/problem+json"
+
+// ProblemResponse represents an RFC 9457 problem details error response (API v2).
+// Code and Errors are extension members carrying ErrorResponse's Code and Details.
+type ProblemResponse struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	Code     string      `json:"code,omitempty"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
+
+// NewProblemResponse converts an error response with the given status into problem details
+// for the request path instance.
+func NewProblemResponse(status int, resp ErrorResponse, instance string) ProblemResponse {
+	return ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   resp.Error,
+		Instance: instance,
+		Code:     resp.Code,
+		Errors:   resp.Details,
+	}
 }
 
 // StatsResponse represents dashboard stats.
@@ -155,3 +848,11 @@ type StatsResponse struct {
 	ByType        map[string]int64 `json:"by_type"`
 	ByProvider    map[string]int64 `json:"by_provider"`
 }
+
+// SuggestResponse represents the autocomplete suggestions and spelling correction of a query.
+type SuggestResponse struct {
+	XMLName     xml.Name `json:"-" xml:"suggest"`
+	Query       string   `json:"query" xml:"query"`
+	Suggestions []string `json:"suggestions" xml:"suggestions>suggestion"`
+	DidYouMean  string   `json:"did_you_mean,omitempty" xml:"did_you_mean,omitempty"` // Query with its misspelled words corrected
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchResponse_XML(t *testing.T) {
+	resp := SearchResponse{
+		Contents: []ContentResponse{
+			{ID: "c1", Title: "Go Basics", Type: "video", Tags: []string{"go", "tutorial"}, Views: 10},
+		},
+		Pagination: PaginationMeta{Total: 1, Page: 1, PageSize: 5, TotalPages: 1},
+	}
+
+	data, err := xml.Marshal(resp)
+	require.NoError(t, err)
+
+	body := string(data)
+	assert.Contains(t, body, "<search_response><contents><content><id>c1</id>")
+	assert.Contains(t, body, "<tags><tag>go</tag><tag>tutorial</tag></tags>")
+	assert.Contains(t, body, "<pagination><total>1</total><page>1</page>")
+	assert.NotContains(t, body, "<likes>", "omitempty metrics are dropped")
+
+	var decoded SearchResponse
+	require.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, resp.Contents[0].Tags, decoded.Contents[0].Tags)
+}
+
+func TestErrorResponse_XMLOmitsDetails(t *testing.T) {
+	data, err := xml.Marshal(ErrorResponse{Error: "not found", Code: "NOT_FOUND", Details: map[string]string{"id": "x"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "<error_response><error>not found</error><code>NOT_FOUND</code></error_response>", string(data))
+}
+
+func TestContentResponse_JSONUnchanged(t *testing.T) {
+	data, err := json.Marshal(ContentResponse{ID: "c1"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "XMLName")
+}
+
+func TestNewPaginationLinks(t *testing.T) {
+	query := url.Values{"q": {"go lang"}, "page": {"2"}, "page_size": {"10"}}
+
+	links := NewPaginationLinks("/api/v2/contents", query, PaginationMeta{Total: 25, Page: 2, PageSize: 10, TotalPages: 3})
+
+	assert.Equal(t, "/api/v2/contents?page=2&page_size=10&q=go+lang", links.Self)
+	assert.Equal(t, "/api/v2/contents?page=1&page_size=10&q=go+lang", links.First)
+	assert.Equal(t, "/api/v2/contents?page=1&page_size=10&q=go+lang", links.Prev)
+	assert.Equal(t, "/api/v2/contents?page=3&page_size=10&q=go+lang", links.Next)
+	assert.Equal(t, "/api/v2/contents?page=3&page_size=10&q=go+lang", links.Last)
+	assert.Equal(t, []string{"2"}, query["page"], "request query must not be modified")
+}
+
+func TestNewPaginationLinks_Edges(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     PaginationMeta
+		wantPrev string
+		wantNext string
+		wantLast string
+	}{
+		{"first page", PaginationMeta{Page: 1, TotalPages: 3}, "", "/c?page=2", "/c?page=3"},
+		{"last page", PaginationMeta{Page: 3, TotalPages: 3}, "/c?page=2", "", "/c?page=3"},
+		{"past the end", PaginationMeta{Page: 7, TotalPages: 3}, "/c?page=3", "", "/c?page=3"},
+		{"no results", PaginationMeta{Page: 1, TotalPages: 0}, "", "", "/c?page=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := NewPaginationLinks("/c", url.Values{}, tt.meta)
+
+			assert.Equal(t, tt.wantPrev, links.Prev)
+			assert.Equal(t, tt.wantNext, links.Next)
+			assert.Equal(t, tt.wantLast, links.Last)
+		})
+	}
+}
@@ -1,73 +1,126 @@
 package handler
 
 import (
+	"context"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
 	"search-engine-service/internal/transport/httpserver/dto"
 	"search-engine-service/internal/validator"
 )
 
 // AdminHandler handles admin-related HTTP requests.
 type AdminHandler struct {
-	syncService *service.SyncService
-	validator   *validator.Validator
-	logger      *zap.Logger
+	syncService   service.SyncUseCase
+	searchService service.SearchUseCase
+	cacheStats    domain.CacheStatsReporter // Optional (nil when caching is disabled)
+	validator     *validator.Validator
+	logger        *zap.Logger
 }
 
 // NewAdminHandler creates a new AdminHandler.
-func NewAdminHandler(syncSvc *service.SyncService, v *validator.Validator, logger *zap.Logger) *AdminHandler {
+// cacheStats is optional and can be nil when caching is disabled.
+func NewAdminHandler(
+	syncSvc service.SyncUseCase,
+	searchSvc service.SearchUseCase,
+	cacheStats domain.CacheStatsReporter,
+	v *validator.Validator,
+	logger *zap.Logger,
+) *AdminHandler {
 	return &AdminHandler{
-		syncService: syncSvc,
-		validator:   v,
-		logger:      logger,
+		syncService:   syncSvc,
+		searchService: searchSvc,
+		cacheStats:    cacheStats,
+		validator:     v,
+		logger:        logger,
 	}
 }
 
 // SyncAll handles POST /api/v1/admin/sync
+// With force=true the sync doesn't queue behind a running one (see service.ForceSync).
 func (h *AdminHandler) SyncAll(c *fiber.Ctx) error {
-	h.logger.Info("manual sync triggered")
+	force := c.QueryBool("force")
+	applog.FromContext(c.UserContext(), h.logger).Info("manual sync triggered", zap.Bool("force", force))
 
-	results := h.syncService.SyncAll(c.Context())
+	results, err := h.syncService.SyncAll(syncContext(c, force))
+	if err != nil {
+		return err
+	}
 
 	return c.JSON(dto.FromSyncResults(results))
 }
 
 // SyncProvider handles POST /api/v1/admin/sync/:provider
+// With force=true the sync doesn't queue behind a running one (see service.ForceSync).
 func (h *AdminHandler) SyncProvider(c *fiber.Ctx) error {
 	providerName := c.Params("provider")
-	if providerName == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "provider name is required",
-			Code:  "MISSING_PROVIDER",
-		})
-	}
+	force := c.QueryBool("force")
+	applog.FromContext(c.UserContext(), h.logger).Info("manual provider sync triggered",
+		zap.String("provider", providerName),
+		zap.Bool("force", force),
+	)
 
-	h.logger.Info("manual provider sync triggered", zap.String("provider", providerName))
-
-	result, err := h.syncService.SyncProvider(c.Context(), providerName)
+	result, err := h.syncService.SyncProvider(syncContext(c, force), providerName)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-			Code:  "SYNC_FAILED",
-		})
-	}
-
-	if result == nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error: "provider not found",
-			Code:  "PROVIDER_NOT_FOUND",
-		})
+		return err
 	}
 
 	return c.JSON(dto.SyncResultResponse{
 		Provider: result.Provider,
 		Count:    result.Count,
+		Changed:  result.Changed,
 		Duration: result.Duration.String(),
 	})
 }
 
+// DeleteContent handles DELETE /api/v1/admin/contents/:id
+func (h *AdminHandler) DeleteContent(c *fiber.Ctx) error {
+	id := c.Params("id")
+	applog.FromContext(c.UserContext(), h.logger).Info("content delete triggered", zap.String("id", id))
+
+	if err := h.syncService.DeleteContent(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListCurated handles GET /api/v1/admin/contents/curated
+func (h *AdminHandler) ListCurated(c *fiber.Ctx) error {
+	contents, err := h.syncService.ListCurated(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromCuratedContents(contents))
+}
+
+// CurateContent handles PATCH /api/v1/admin/contents/:id
+func (h *AdminHandler) CurateContent(c *fiber.Ctx) error {
+	var req dto.UpdateCurationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	id := c.Params("id")
+	applog.FromContext(c.UserContext(), h.logger).Info("content curation triggered", zap.String("id", id))
+
+	content, err := h.syncService.CurateContent(c.UserContext(), id, req.ToCurationPatch())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromCuratedContent(content))
+}
+
 // GetProviders handles GET /api/v1/admin/providers
 func (h *AdminHandler) GetProviders(c *fiber.Ctx) error {
 	providers := h.syncService.GetProviderNames()
@@ -76,3 +129,67 @@ func (h *AdminHandler) GetProviders(c *fiber.Ctx) error {
 		"providers": providers,
 	})
 }
+
+// UpdateProvider handles PATCH /api/v1/admin/providers/:provider
+// Switches the provider's scheduled syncs on or off on every instance; its contents are kept.
+func (h *AdminHandler) UpdateProvider(c *fiber.Ctx) error {
+	var req dto.UpdateProviderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	provider := c.Params("provider")
+	applog.FromContext(c.UserContext(), h.logger).Info("provider sync switch triggered",
+		zap.String("provider", provider),
+		zap.Bool("enabled", *req.Enabled),
+	)
+
+	if err := h.syncService.SetProviderEnabled(c.UserContext(), provider, *req.Enabled); err != nil {
+		return err
+	}
+
+	return c.JSON(dto.ProviderResponse{Provider: provider, Enabled: *req.Enabled})
+}
+
+// GetProvidersHealth handles GET /api/v1/admin/providers/health
+func (h *AdminHandler) GetProvidersHealth(c *fiber.Ctx) error {
+	results := h.syncService.CheckProviders(c.UserContext())
+
+	return c.JSON(dto.FromProviderHealth(results))
+}
+
+// GetCacheStats handles GET /api/v1/admin/cache/stats
+func (h *AdminHandler) GetCacheStats(c *fiber.Ctx) error {
+	if h.cacheStats == nil {
+		return c.JSON(dto.CacheStatsResponse{Enabled: false})
+	}
+
+	return c.JSON(dto.CacheStatsResponse{
+		Enabled: true,
+		Stats:   h.cacheStats.Stats(),
+	})
+}
+
+// ClearCache handles DELETE /api/v1/admin/cache
+func (h *AdminHandler) ClearCache(c *fiber.Ctx) error {
+	applog.FromContext(c.UserContext(), h.logger).Info("cache clear triggered")
+
+	if err := h.searchService.ClearCache(c.UserContext()); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// syncContext returns the context of a manual sync, forced if requested.
+func syncContext(c *fiber.Ctx, force bool) context.Context {
+	if force {
+		return service.ForceSync(c.UserContext())
+	}
+
+	return c.UserContext()
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/app/service/mocks"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+func TestAdminHandler_SyncProvider(t *testing.T) {
+	syncSvc := mocks.NewSyncUseCase(t)
+	syncSvc.On("SyncProvider", mock.Anything, "provider_a").
+		Return(&service.SyncResult{Provider: "provider_a", Count: 3, Changed: 1, Duration: time.Second}, nil)
+	syncSvc.On("SyncProvider", mock.Anything, "provider_x").Return(nil, domain.ErrNotFound)
+
+	h := NewAdminHandler(syncSvc, mocks.NewSearchUseCase(t), nil, validator.New(), zap.NewNop())
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Post("/sync/:provider", h.SyncProvider)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/sync/provider_a", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dto.SyncResultResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, dto.SyncResultResponse{Provider: "provider_a", Count: 3, Changed: 1, Duration: "1s"}, body)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/sync/provider_x", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestAdminHandler_UpdateProvider(t *testing.T) {
+	syncSvc := mocks.NewSyncUseCase(t)
+	syncSvc.On("SetProviderEnabled", mock.Anything, "provider_a", false).Return(nil)
+
+	h := NewAdminHandler(syncSvc, mocks.NewSearchUseCase(t), nil, validator.New(), zap.NewNop())
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Patch("/providers/:provider", h.UpdateProvider)
+	patch := func(body string) *http.Response {
+		req := httptest.NewRequest("PATCH", "/providers/provider_a", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	resp := patch(`{"enabled": false}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dto.ProviderResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, dto.ProviderResponse{Provider: "provider_a", Enabled: false}, body)
+
+	assert.Equal(t, fiber.StatusBadRequest, patch(`{}`).StatusCode, "enabled is required")
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// AnalyticsHandler handles search analytics ingestion.
+type AnalyticsHandler struct {
+	service   *service.AnalyticsService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler.
+func NewAnalyticsHandler(svc *service.AnalyticsService, v *validator.Validator, logger *zap.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Record handles POST /api/v1/analytics/events
+func (h *AnalyticsHandler) Record(c *fiber.Ctx) error {
+	var req dto.AnalyticsEventsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	events := req.ToAnalyticsEvents(c.GetRespHeader(fiber.HeaderXRequestID))
+	if err := h.service.Record(c.UserContext(), events); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.AnalyticsEventsResponse{Accepted: len(events)})
+}
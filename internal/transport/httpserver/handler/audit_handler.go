@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// AuditHandler handles audit log queries.
+type AuditHandler struct {
+	service   *service.AuditService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewAuditHandler creates a new AuditHandler.
+func NewAuditHandler(svc *service.AuditService, v *validator.Validator, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// List handles GET /api/v1/admin/audit-logs
+func (h *AuditHandler) List(c *fiber.Ctx) error {
+	var req dto.AuditLogRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	filter, err := req.ToAuditFilter()
+	if err != nil {
+		return invalidParams(err)
+	}
+
+	entries, total, err := h.service.List(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromAuditEntries(entries, total, filter))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// CollectionHandler handles collection requests: the public view of a collection
+// and its management by admins.
+type CollectionHandler struct {
+	service   *service.CollectionService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewCollectionHandler creates a new CollectionHandler.
+func NewCollectionHandler(svc *service.CollectionService, v *validator.Validator, logger *zap.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Get handles GET /api/v1/collections/:id
+// The collection's contents are returned in collection order.
+func (h *CollectionHandler) Get(c *fiber.Ctx) error {
+	collection, contents, err := h.service.GetWithContents(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return respond(c, fiber.StatusOK, dto.FromCollectionContents(collection, contents))
+}
+
+// Create handles POST /api/v1/admin/collections
+func (h *CollectionHandler) Create(c *fiber.Ctx) error {
+	req, err := h.parse(c)
+	if err != nil {
+		return err
+	}
+
+	collection, err := h.service.Create(c.UserContext(), req.Title, req.Description, req.ContentIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.FromDomainCollection(collection))
+}
+
+// List handles GET /api/v1/admin/collections
+func (h *CollectionHandler) List(c *fiber.Ctx) error {
+	collections, err := h.service.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dto.CollectionResponse, len(collections))
+	for i, collection := range collections {
+		resp[i] = dto.FromDomainCollection(collection)
+	}
+
+	return c.JSON(fiber.Map{
+		"collections": resp,
+	})
+}
+
+// Update handles PUT /api/v1/admin/collections/:id
+func (h *CollectionHandler) Update(c *fiber.Ctx) error {
+	req, err := h.parse(c)
+	if err != nil {
+		return err
+	}
+
+	collection, err := h.service.Update(c.UserContext(), c.Params("id"), req.Title, req.Description, req.ContentIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromDomainCollection(collection))
+}
+
+// Delete handles DELETE /api/v1/admin/collections/:id
+func (h *CollectionHandler) Delete(c *fiber.Ctx) error {
+	if err := h.service.Delete(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// parse reads and validates the collection in the request body.
+func (h *CollectionHandler) parse(c *fiber.Ctx) (*dto.CollectionRequest, error) {
+	var req dto.CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
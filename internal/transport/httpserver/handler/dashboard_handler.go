@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
@@ -9,26 +11,73 @@ import (
 
 // DashboardHandler handles dashboard-related HTTP requests.
 type DashboardHandler struct {
-	searchService *service.SearchService
+	searchService service.SearchUseCase
+	syncService   service.SyncUseCase
 	logger        *zap.Logger
 }
 
 // NewDashboardHandler creates a new DashboardHandler.
-func NewDashboardHandler(svc *service.SearchService, logger *zap.Logger) *DashboardHandler {
+func NewDashboardHandler(searchSvc service.SearchUseCase, syncSvc service.SyncUseCase, logger *zap.Logger) *DashboardHandler {
 	return &DashboardHandler{
-		searchService: svc,
+		searchService: searchSvc,
+		syncService:   syncSvc,
 		logger:        logger,
 	}
 }
 
+// dashboardState is the dashboard data kept current by live updates: the content
+// count and the providers panel. It's embedded in the page as JSON and pushed over
+// the dashboard WebSocket when it changes.
+type dashboardState struct {
+	ContentCount int64               `json:"content_count"`
+	Providers    []dashboardProvider `json:"providers"`
+}
+
+// dashboardProvider is a row of the dashboard's providers panel.
+type dashboardProvider struct {
+	Name         string `json:"name"`
+	Enabled      bool   `json:"enabled"`       // False while left out of scheduled syncs
+	BreakerState string `json:"breaker_state"` // Empty when the provider has no breaker
+	LastSyncAt   string `json:"last_sync_at"`  // Last upsert by any instance, empty if never
+	LastRunAt    string `json:"last_run_at"`   // Latest sync run by this instance, empty if none
+	LastCount    int    `json:"last_count"`
+	LastDuration string `json:"last_duration"`
+	LastError    string `json:"last_error"`
+}
+
 // Render handles GET /dashboard
 // Renders the dashboard HTML page using Fiber's template engine.
 func (h *DashboardHandler) Render(c *fiber.Ctx) error {
 	// Get content count for stats
-	count, _ := h.searchService.Count(c.Context())
+	count, _ := h.searchService.Count(c.UserContext())
 
 	return c.Render("pages/dashboard", fiber.Map{
-		"Title":        "Search Engine Dashboard",
-		"ContentCount": count,
+		"Title": "Search Engine Dashboard",
+		"State": dashboardState{
+			ContentCount: count,
+			Providers:    dashboardProviders(h.syncService.ProviderStatuses(c.UserContext())),
+		},
 	}, "layouts/base")
 }
+
+// dashboardProviders builds the providers panel from the providers' sync statuses.
+func dashboardProviders(statuses []service.ProviderSyncStatus) []dashboardProvider {
+	rows := make([]dashboardProvider, len(statuses))
+	for i, s := range statuses {
+		row := dashboardProvider{Name: s.Provider, Enabled: s.Enabled, BreakerState: s.BreakerState}
+		if !s.LastSyncAt.IsZero() {
+			row.LastSyncAt = s.LastSyncAt.UTC().Format(time.RFC3339)
+		}
+		if s.LastRun != nil {
+			row.LastRunAt = s.LastRunAt.Format(time.RFC3339)
+			row.LastCount = s.LastRun.Count
+			row.LastDuration = s.LastRun.Duration.Round(time.Millisecond).String()
+			if s.LastRun.Error != nil {
+				row.LastError = s.LastRun.Error.Error()
+			}
+		}
+		rows[i] = row
+	}
+
+	return rows
+}
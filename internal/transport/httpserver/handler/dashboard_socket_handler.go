@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// dashboardWriteTimeout bounds writing a message to a dashboard session, so a stalled
+// client is dropped instead of holding its subscription.
+const dashboardWriteTimeout = 10 * time.Second
+
+// dashboardMessage is a live update sent to the dashboard over its WebSocket.
+type dashboardMessage struct {
+	Type      service.DashboardUpdateType `json:"type"`
+	Providers []string                    `json:"providers,omitempty"` // sync.started
+	Results   []dto.SyncResultResponse    `json:"results,omitempty"`   // sync.progress, sync.completed
+	Summary   *dto.SyncSummary            `json:"summary,omitempty"`   // sync.completed
+	Done      int                         `json:"done,omitempty"`      // sync.progress
+	Total     int                         `json:"total,omitempty"`     // sync.progress
+	State     *dashboardState             `json:"state,omitempty"`     // stats
+}
+
+// DashboardSocketHandler pushes live updates to dashboard sessions over WebSockets.
+type DashboardSocketHandler struct {
+	notifier *service.DashboardNotifier
+	upgrade  fiber.Handler
+	logger   *zap.Logger
+}
+
+// NewDashboardSocketHandler creates a new DashboardSocketHandler.
+func NewDashboardSocketHandler(notifier *service.DashboardNotifier, logger *zap.Logger) *DashboardSocketHandler {
+	h := &DashboardSocketHandler{
+		notifier: notifier,
+		logger:   logger,
+	}
+	h.upgrade = websocket.New(h.stream)
+
+	return h
+}
+
+// Serve handles GET /dashboard/ws
+// Upgrades the connection and streams sync progress and stats updates until the
+// client disconnects.
+func (h *DashboardSocketHandler) Serve(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	return h.upgrade(c)
+}
+
+// stream writes updates to conn until the client or the notifier goes away.
+func (h *DashboardSocketHandler) stream(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := h.notifier.Subscribe(ctx)
+
+	// The dashboard never sends messages, but reading is needed to process control
+	// frames and notice the client closing the connection. The connection is released
+	// once stream returns, so the reader is stopped by closing it and waited for.
+	reading := make(chan struct{})
+	defer func() {
+		_ = conn.Close()
+		<-reading
+	}()
+	go func() {
+		defer close(reading)
+		defer cancel()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(dashboardWriteTimeout))
+
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(dashboardWriteTimeout))
+			err = conn.WriteJSON(toDashboardMessage(update))
+		case <-heartbeat.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(dashboardWriteTimeout))
+		}
+
+		if err != nil {
+			h.logger.Debug("dashboard socket closed", zap.Error(err))
+
+			return
+		}
+	}
+}
+
+// toDashboardMessage maps a notifier update to its wire format.
+func toDashboardMessage(update service.DashboardUpdate) dashboardMessage {
+	msg := dashboardMessage{
+		Type:      update.Type,
+		Providers: update.Providers,
+		Done:      update.Done,
+		Total:     update.Total,
+	}
+
+	switch update.Type {
+	case service.DashboardSyncProgress:
+		msg.Results = dto.FromSyncResults(update.Results).Results
+	case service.DashboardSyncCompleted:
+		resp := dto.FromSyncResults(update.Results)
+		msg.Results, msg.Summary = resp.Results, &resp.Summary
+	case service.DashboardStats:
+		msg.State = &dashboardState{
+			ContentCount: update.ContentCount,
+			Providers:    dashboardProviders(update.Statuses),
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/transport/httpserver/middleware"
+	"search-engine-service/internal/validator"
+)
+
+// statusCodes names the error codes used for plain fiber errors (e.g. unknown routes).
+var statusCodes = map[int]string{
+	fiber.StatusBadRequest:            "INVALID_PARAMS",
+	fiber.StatusNotFound:              "NOT_FOUND",
+	fiber.StatusMethodNotAllowed:      "METHOD_NOT_ALLOWED",
+	fiber.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+	fiber.StatusServiceUnavailable:    "SERVICE_UNAVAILABLE",
+	fiber.StatusGatewayTimeout:        "TIMEOUT",
+}
+
+// invalidParams marks a request parsing or validation failure as domain.ErrInvalidParams.
+func invalidParams(err error) error {
+	return fmt.Errorf("%w: %w", domain.ErrInvalidParams, err)
+}
+
+// mapError translates an error returned by a handler into an HTTP status and response body.
+// Messages of server-side failures are not exposed to clients.
+func mapError(err error) (int, dto.ErrorResponse) {
+	var validationErrs validator.ValidationErrors
+	var fiberErr *fiber.Error
+
+	switch {
+	case errors.As(err, &validationErrs):
+		return fiber.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation failed",
+			Code:    "VALIDATION_ERROR",
+			Details: validationErrs,
+		}
+	case errors.Is(err, domain.ErrInvalidParams):
+		return fiber.StatusBadRequest, dto.ErrorResponse{Error: err.Error(), Code: "INVALID_PARAMS"}
+	case errors.Is(err, domain.ErrNotFound):
+		return fiber.StatusNotFound, dto.ErrorResponse{Error: err.Error(), Code: "NOT_FOUND"}
+	case errors.Is(err, domain.ErrBusy):
+		return fiber.StatusConflict, dto.ErrorResponse{Error: err.Error(), Code: "BUSY"}
+	case errors.Is(err, domain.ErrConflict):
+		return fiber.StatusConflict, dto.ErrorResponse{Error: err.Error(), Code: "CONFLICT"}
+	case errors.Is(err, domain.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return fiber.StatusGatewayTimeout, dto.ErrorResponse{Error: "operation timed out", Code: "TIMEOUT"}
+	case errors.Is(err, domain.ErrProviderUnavailable):
+		return fiber.StatusServiceUnavailable, dto.ErrorResponse{Error: err.Error(), Code: "SERVICE_UNAVAILABLE"}
+	case errors.As(err, &fiberErr):
+		code, ok := statusCodes[fiberErr.Code]
+		if !ok {
+			code = "UNHANDLED_ERROR"
+		}
+
+		return fiberErr.Code, dto.ErrorResponse{Error: fiberErr.Message, Code: code}
+	default:
+		return fiber.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"}
+	}
+}
+
+// ErrorHandler returns the Fiber error handler mapping errors returned by handlers
+// (domain sentinel errors, validation errors, fiber errors) to HTTP responses.
+// 404s are logged at DEBUG level (expected client behavior), 4xx at WARN, 5xx at ERROR.
+// API v2 requests get RFC 9457 problem details; other requests get an ErrorResponse
+// negotiated as JSON or XML.
+func ErrorHandler(logger *zap.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		status, resp := mapError(err)
+		log := applog.FromContext(c.UserContext(), logger)
+
+		// Log based on status code - 404s are common and not server errors
+		switch {
+		case status == fiber.StatusNotFound:
+			log.Debug("resource not found",
+				zap.String("path", c.Path()),
+				zap.String("method", c.Method()),
+			)
+		case status >= 500:
+			log.Error("server error",
+				zap.Error(err),
+				zap.Int("status", status),
+				zap.String("path", c.Path()),
+			)
+		default:
+			log.Warn("client error",
+				zap.Error(err),
+				zap.Int("status", status),
+				zap.String("path", c.Path()),
+			)
+		}
+
+		if middleware.APIVersionFromContext(c) >= 2 {
+			return middleware.WriteError(c, status, resp)
+		}
+
+		return respond(c, status, resp)
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/transport/httpserver/middleware"
+	"search-engine-service/internal/validator"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", fmt.Errorf("content x: %w", domain.ErrNotFound), fiber.StatusNotFound, "NOT_FOUND"},
+		{"invalid params", invalidParams(errors.New("bad page")), fiber.StatusBadRequest, "INVALID_PARAMS"},
+		{"validation", validator.ValidationErrors{{Field: "page", Message: "page is invalid"}}, fiber.StatusBadRequest, "VALIDATION_ERROR"},
+		{"provider unavailable", fmt.Errorf("fetching: %w", domain.ErrProviderUnavailable), fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE"},
+		{"timeout", fmt.Errorf("%w: %w", domain.ErrTimeout, context.DeadlineExceeded), fiber.StatusGatewayTimeout, "TIMEOUT"},
+		{"bare deadline", context.DeadlineExceeded, fiber.StatusGatewayTimeout, "TIMEOUT"},
+		{"fiber error", fiber.ErrMethodNotAllowed, fiber.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED"},
+		{"unknown fiber status", fiber.ErrTeapot, fiber.StatusTeapot, "UNHANDLED_ERROR"},
+		{"internal", errors.New("pq: connection reset"), fiber.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := mapError(tt.err)
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
+
+func TestMapError_HidesInternalMessages(t *testing.T) {
+	_, resp := mapError(errors.New("pq: password authentication failed"))
+
+	assert.Equal(t, "internal server error", resp.Error)
+}
+
+func TestErrorHandler_WritesErrorResponse(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Get("/", func(_ *fiber.Ctx) error {
+		return fmt.Errorf("webhook w1: %w", domain.ErrNotFound)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+
+	var got dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "NOT_FOUND", got.Code)
+	assert.Equal(t, "webhook w1: not found", got.Error)
+}
+
+func TestErrorHandler_WritesProblemDetailsOnV2(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Get("/api/v2/contents", middleware.APIVersion(2), func(_ *fiber.Ctx) error {
+		return validator.ValidationErrors{{Field: "page", Message: "page is invalid"}}
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v2/contents", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+
+	var got dto.ProblemResponse
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, dto.MIMEProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, "about:blank", got.Type)
+	assert.Equal(t, "Bad Request", got.Title)
+	assert.Equal(t, fiber.StatusBadRequest, got.Status)
+	assert.Equal(t, "validation failed", got.Detail)
+	assert.Equal(t, "/api/v2/contents", got.Instance)
+	assert.Equal(t, "VALIDATION_ERROR", got.Code)
+	assert.NotEmpty(t, got.Errors)
+}
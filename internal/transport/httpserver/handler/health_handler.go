@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// HealthHandler handles detailed health requests.
+type HealthHandler struct {
+	service *service.HealthService
+	logger  *zap.Logger
+}
+
+// NewHealthHandler creates a new HealthHandler.
+func NewHealthHandler(svc *service.HealthService, logger *zap.Logger) *HealthHandler {
+	return &HealthHandler{
+		service: svc,
+		logger:  logger,
+	}
+}
+
+// Details handles GET /healthz/details
+// Responds 503 when a critical dependency is down, 200 otherwise (including "degraded").
+func (h *HealthHandler) Details(c *fiber.Ctx) error {
+	report := h.service.Check(c.UserContext())
+
+	status := fiber.StatusOK
+	if report.Status == service.HealthDown {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(status).JSON(dto.FromHealthReport(report))
+}
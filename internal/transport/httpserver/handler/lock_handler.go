@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// LockHandler handles distributed lock inspection requests.
+type LockHandler struct {
+	service *service.LockService
+	logger  *zap.Logger
+}
+
+// NewLockHandler creates a new LockHandler.
+func NewLockHandler(svc *service.LockService, logger *zap.Logger) *LockHandler {
+	return &LockHandler{
+		service: svc,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/admin/locks
+func (h *LockHandler) List(c *fiber.Ctx) error {
+	locks, err := h.service.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"locks": dto.FromLockInfos(locks),
+	})
+}
+
+// Release handles DELETE /api/v1/admin/locks/:key
+func (h *LockHandler) Release(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(c.Params("key"))
+	if err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.service.ForceRelease(c.UserContext(), key); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// respond writes v as XML when the client prefers it via the Accept header
+// and as JSON otherwise, including when no Accept header is sent.
+func respond(c *fiber.Ctx, status int, v interface{}) error {
+	c.Vary(fiber.HeaderAccept)
+	c.Status(status)
+
+	switch c.Accepts(fiber.MIMEApplicationJSON, fiber.MIMEApplicationXML, fiber.MIMETextXML) {
+	case fiber.MIMEApplicationXML, fiber.MIMETextXML:
+		return c.XML(v)
+	default:
+		return c.JSON(v)
+	}
+}
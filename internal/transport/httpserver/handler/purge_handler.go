@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/transport/httpserver/middleware"
+	"search-engine-service/internal/validator"
+)
+
+// PurgeHandler handles removal requests of provider items.
+type PurgeHandler struct {
+	service   *service.PurgeService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewPurgeHandler creates a new PurgeHandler.
+func NewPurgeHandler(svc *service.PurgeService, v *validator.Validator, logger *zap.Logger) *PurgeHandler {
+	return &PurgeHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Purge handles DELETE /api/v1/admin/contents/by-external-id
+func (h *PurgeHandler) Purge(c *fiber.Ctx) error {
+	var req dto.PurgeRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	applog.FromContext(c.UserContext(), h.logger).Info("content purge triggered",
+		zap.String("provider", req.Provider),
+		zap.String("external_id", req.ExternalID),
+	)
+
+	result, err := h.service.Purge(c.UserContext(), req.Provider, req.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	resp := dto.FromPurgeResult(req.Provider, req.ExternalID, result)
+	middleware.SetAuditResult(c, resp)
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// QueryAnalyticsHandler serves the reports of executed search queries.
+type QueryAnalyticsHandler struct {
+	service   *service.QueryAnalyticsService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewQueryAnalyticsHandler creates a new QueryAnalyticsHandler.
+func NewQueryAnalyticsHandler(svc *service.QueryAnalyticsService, v *validator.Validator, logger *zap.Logger) *QueryAnalyticsHandler {
+	return &QueryAnalyticsHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Report handles GET /api/v1/admin/search-analytics
+func (h *QueryAnalyticsHandler) Report(c *fiber.Ctx) error {
+	var req dto.SearchAnalyticsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	report, err := h.service.Report(c.UserContext(), req.ReportDays(), req.ReportLimit())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromSearchAnalytics(report))
+}
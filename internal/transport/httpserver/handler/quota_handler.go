@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// QuotaHandler reports the use of the request quotas of API keys.
+type QuotaHandler struct {
+	service   *service.QuotaService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewQuotaHandler creates a new QuotaHandler.
+func NewQuotaHandler(svc *service.QuotaService, v *validator.Validator, logger *zap.Logger) *QuotaHandler {
+	return &QuotaHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Usage handles GET /api/v1/admin/quotas
+func (h *QuotaHandler) Usage(c *fiber.Ctx) error {
+	var req dto.QuotaReportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	month, usage, err := h.service.Usage(c.UserContext(), req.Month)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromQuotaUsage(month, usage))
+}
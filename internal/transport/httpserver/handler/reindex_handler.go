@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// ReindexHandler handles search index rebuild requests.
+type ReindexHandler struct {
+	service *service.ReindexService
+	logger  *zap.Logger
+}
+
+// NewReindexHandler creates a new ReindexHandler.
+func NewReindexHandler(svc *service.ReindexService, logger *zap.Logger) *ReindexHandler {
+	return &ReindexHandler{
+		service: svc,
+		logger:  logger,
+	}
+}
+
+// Start handles POST /api/v1/admin/reindex
+func (h *ReindexHandler) Start(c *fiber.Ctx) error {
+	status, err := h.service.Start()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.FromReindexStatus(status))
+}
+
+// Status handles GET /api/v1/admin/reindex
+func (h *ReindexHandler) Status(c *fiber.Ctx) error {
+	return c.JSON(dto.FromReindexStatus(h.service.Status()))
+}
@@ -2,23 +2,32 @@
 package handler
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
 	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/transport/httpserver/middleware"
 	"search-engine-service/internal/validator"
 )
 
 // SearchHandler handles search-related HTTP requests.
 type SearchHandler struct {
-	service   *service.SearchService
+	service   service.SearchUseCase
 	validator *validator.Validator
 	logger    *zap.Logger
 }
 
 // NewSearchHandler creates a new SearchHandler.
-func NewSearchHandler(svc *service.SearchService, v *validator.Validator, logger *zap.Logger) *SearchHandler {
+func NewSearchHandler(svc service.SearchUseCase, v *validator.Validator, logger *zap.Logger) *SearchHandler {
 	return &SearchHandler{
 		service:   svc,
 		validator: v,
@@ -27,63 +36,141 @@ func NewSearchHandler(svc *service.SearchService, v *validator.Validator, logger
 }
 
 // Search handles GET /api/v1/contents
+// Responds with XML when the client sends Accept: application/xml.
 func (h *SearchHandler) Search(c *fiber.Ctx) error {
 	var req dto.SearchRequest
 	if err := c.QueryParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "invalid query parameters",
-			Code:  "INVALID_PARAMS",
-		})
+		return invalidParams(err)
 	}
 
 	if err := h.validator.Validate(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "validation failed",
-			Code:    "VALIDATION_ERROR",
-			Details: err,
-		})
+		return err
 	}
 
 	params := req.ToSearchParams()
-	result, err := h.service.Search(c.Context(), params)
+	if middleware.APIVersionFromContext(c) >= 2 {
+		// v2 rejects the params v1 has the service correct
+		if err := params.ValidateStrict(); err != nil {
+			return err
+		}
+	}
+	params.BypassCache = middleware.CacheRefreshGranted(c)
+	result, err := h.service.Search(c.UserContext(), params)
 	if err != nil {
-		h.logger.Error("search failed", zap.Error(err))
+		return err
+	}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: "search failed",
-			Code:  "INTERNAL_ERROR",
-		})
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return invalidParams(err)
+	}
+
+	resp := dto.FromSearchResult(result)
+	resp.Links = dto.NewPaginationLinks(c.Path(), query, resp.Pagination)
+
+	return respond(c, fiber.StatusOK, resp)
+}
+
+// Facets handles GET /api/v1/contents/facets
+// Counts the contents matching the search query and filters per type, provider and tag.
+func (h *SearchHandler) Facets(c *fiber.Ctx) error {
+	var req dto.FacetsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
 	}
 
-	return c.JSON(dto.FromSearchResult(result))
+	facets, err := h.service.Facets(c.UserContext(), req.ToSearchParams())
+	if err != nil {
+		return err
+	}
+
+	return respond(c, fiber.StatusOK, dto.FromSearchFacets(facets))
 }
 
 // GetByID handles GET /api/v1/contents/:id
+// Sets the content's ETag, answering 304 when the request's If-None-Match has it.
 func (h *SearchHandler) GetByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "id is required",
-			Code:  "MISSING_ID",
-		})
+	content, err := h.service.GetByID(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
 	}
 
-	content, err := h.service.GetByID(c.Context(), id)
-	if err != nil {
-		h.logger.Error("get by id failed", zap.String("id", id), zap.Error(err))
+	if etag := content.ETag(); etag != "" {
+		c.Set(fiber.HeaderETag, etag)
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			c.Vary(fiber.HeaderAccept)
 
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: "failed to get content",
-			Code:  "INTERNAL_ERROR",
-		})
+			return c.SendStatus(fiber.StatusNotModified)
+		}
 	}
 
-	if content == nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error: "content not found",
-			Code:  "NOT_FOUND",
-		})
+	return respond(c, fiber.StatusOK, dto.FromDomainContent(content))
+}
+
+// etagMatches reports whether the If-None-Match header value lists etag, compared
+// weakly (RFC 9110, section 13.1.2).
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
 	}
 
-	return c.JSON(dto.FromDomainContent(content))
+	return false
+}
+
+// Export handles GET /api/v1/contents/export
+// The full filtered result set is streamed with chunked transfer encoding.
+func (h *SearchHandler) Export(c *fiber.Ctx) error {
+	var req dto.ExportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = dto.ExportFormatNDJSON
+	}
+	params := req.ToSearchParams()
+
+	c.Set(fiber.HeaderContentType, dto.ExportContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="contents.%s"`, format))
+
+	// The stream outlives the handler, so it keeps the request context's values (e.g. the
+	// request ID) but not its cancellation
+	ctx := context.WithoutCancel(c.UserContext())
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// Headers are already sent, so failures can only be logged and the stream cut short
+		enc, err := dto.NewContentEncoder(format, w)
+		if err != nil {
+			return
+		}
+
+		err = h.service.Export(ctx, params, func(batch []*domain.Content) error {
+			if err := enc.Encode(batch); err != nil {
+				return err
+			}
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+
+			// A flush error means the client went away
+			return w.Flush()
+		})
+		if err != nil {
+			applog.FromContext(ctx, h.logger).Warn("export stream aborted", zap.String("format", format), zap.Error(err))
+		}
+	})
+
+	return nil
 }
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service/mocks"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/validator"
+)
+
+func TestSearchHandler_GetByID(t *testing.T) {
+	content := &domain.Content{ID: "c1", Title: "Go", Hash: "abc", Score: 42}
+	svc := mocks.NewSearchUseCase(t)
+	svc.On("GetByID", mock.Anything, "c1").Return(content, nil)
+	svc.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrNotFound)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Get("/contents/:id", NewSearchHandler(svc, validator.New(), zap.NewNop()).GetByID)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/contents/c1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, content.ETag(), resp.Header.Get(fiber.HeaderETag))
+
+	req := httptest.NewRequest("GET", "/contents/c1", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, content.ETag())
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/contents/missing", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `W/"abc-0000002a"`
+
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{``, false},
+		{etag, true},
+		{`"abc-0000002a"`, true},
+		{`"other", W/"abc-0000002a"`, true},
+		{`"abc-00000000"`, false},
+		{`*`, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, etagMatches(tt.ifNoneMatch, etag), "If-None-Match: %s", tt.ifNoneMatch)
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// SettingsHandler handles runtime settings requests.
+type SettingsHandler struct {
+	service   *service.SettingsService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewSettingsHandler creates a new SettingsHandler.
+func NewSettingsHandler(svc *service.SettingsService, v *validator.Validator, logger *zap.Logger) *SettingsHandler {
+	return &SettingsHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Get handles GET /api/v1/admin/settings
+func (h *SettingsHandler) Get(c *fiber.Ctx) error {
+	return c.JSON(dto.FromRuntimeSettings(h.service.Current()))
+}
+
+// Update handles PATCH /api/v1/admin/settings
+func (h *SettingsHandler) Update(c *fiber.Ctx) error {
+	var req dto.UpdateSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	patch, err := req.ToSettingsPatch()
+	if err != nil {
+		return invalidParams(err)
+	}
+
+	settings, err := h.service.Update(c.UserContext(), patch)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromRuntimeSettings(settings))
+}
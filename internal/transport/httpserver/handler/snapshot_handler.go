@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// SnapshotHandler handles content snapshot requests.
+type SnapshotHandler struct {
+	service *service.SnapshotService
+	logger  *zap.Logger
+}
+
+// NewSnapshotHandler creates a new SnapshotHandler.
+func NewSnapshotHandler(svc *service.SnapshotService, logger *zap.Logger) *SnapshotHandler {
+	return &SnapshotHandler{
+		service: svc,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/admin/snapshots
+func (h *SnapshotHandler) List(c *fiber.Ctx) error {
+	snapshots, err := h.service.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"snapshots": dto.FromSnapshotInfos(snapshots),
+	})
+}
+
+// Create handles POST /api/v1/admin/snapshots
+func (h *SnapshotHandler) Create(c *fiber.Ctx) error {
+	status, err := h.service.StartCreate()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.FromSnapshotStatus(status))
+}
+
+// Restore handles POST /api/v1/admin/snapshots/:name/restore
+func (h *SnapshotHandler) Restore(c *fiber.Ctx) error {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return invalidParams(err)
+	}
+
+	status, err := h.service.StartRestore(c.UserContext(), name)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.FromSnapshotStatus(status))
+}
+
+// Status handles GET /api/v1/admin/snapshots/status
+func (h *SnapshotHandler) Status(c *fiber.Ctx) error {
+	return c.JSON(dto.FromSnapshotStatus(h.service.Status()))
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// streamHeartbeat is how often a comment line is sent to keep idle connections
+// open through proxies and to detect disconnected clients.
+const streamHeartbeat = 15 * time.Second
+
+// StreamHandler serves content change events as Server-Sent Events.
+type StreamHandler struct {
+	events    domain.ContentEventBus
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewStreamHandler creates a new StreamHandler.
+func NewStreamHandler(events domain.ContentEventBus, v *validator.Validator, logger *zap.Logger) *StreamHandler {
+	return &StreamHandler{
+		events:    events,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Stream handles GET /api/v1/contents/stream
+func (h *StreamHandler) Stream(c *fiber.Ctx) error {
+	var req dto.StreamRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	filter := req.ToEventFilter()
+
+	// The stream outlives the handler, so it keeps the request context's values (e.g. the
+	// request ID) but not its cancellation
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.UserContext()))
+	events, err := h.events.Subscribe(ctx)
+	if err != nil {
+		cancel()
+		applog.FromContext(ctx, h.logger).Error("content stream subscribe failed", zap.Error(err))
+
+		return fiber.NewError(fiber.StatusServiceUnavailable, "event stream unavailable")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Disable nginx response buffering
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		h.writeEvents(w, events, filter)
+	})
+
+	return nil
+}
+
+// writeEvents writes matching events until the subscription ends or the client disconnects.
+func (h *StreamHandler) writeEvents(w *bufio.Writer, events <-chan domain.ContentEvent, filter domain.ContentEventFilter) {
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	// Initial comment flushes headers so clients see the connection open immediately
+	if _, err := w.WriteString(": connected\n\n"); err != nil || w.Flush() != nil {
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+
+			data, err := json.Marshal(dto.FromContentEvent(event))
+			if err != nil {
+				h.logger.Warn("failed to marshal content event", zap.Error(err))
+
+				continue
+			}
+
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		case <-heartbeat.C:
+			_, _ = w.WriteString(": ping\n\n")
+		}
+
+		// A flush error means the client went away
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
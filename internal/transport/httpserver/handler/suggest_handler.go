@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// SuggestHandler serves autocomplete suggestions and spelling corrections from the
+// term dictionary.
+type SuggestHandler struct {
+	service   *service.DictionaryService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewSuggestHandler creates a new SuggestHandler.
+func NewSuggestHandler(svc *service.DictionaryService, v *validator.Validator, logger *zap.Logger) *SuggestHandler {
+	return &SuggestHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Suggest handles GET /api/v1/contents/suggest
+// Completes the last word of the query and corrects its misspelled words.
+func (h *SuggestHandler) Suggest(c *fiber.Ctx) error {
+	var req dto.SuggestRequest
+	if err := c.QueryParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	suggestions := h.service.Suggest(req.Query, req.SuggestLimit())
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return respond(c, fiber.StatusOK, dto.SuggestResponse{
+		Query:       req.Query,
+		Suggestions: suggestions,
+		DidYouMean:  h.service.DidYouMean(req.Query),
+	})
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// SyncHistoryHandler serves the time series charted on the dashboard.
+type SyncHistoryHandler struct {
+	service   *service.SyncHistoryService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewSyncHistoryHandler creates a new SyncHistoryHandler.
+func NewSyncHistoryHandler(svc *service.SyncHistoryService, v *validator.Validator, logger *zap.Logger) *SyncHistoryHandler {
+	return &SyncHistoryHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// ContentGrowth handles GET /api/v1/admin/metrics/ui/content-growth
+func (h *SyncHistoryHandler) ContentGrowth(c *fiber.Ctx) error {
+	days, err := h.chartDays(c)
+	if err != nil {
+		return err
+	}
+
+	points, err := h.service.ContentGrowth(c.UserContext(), days)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromDailyCounts(points))
+}
+
+// SyncRuns handles GET /api/v1/admin/metrics/ui/sync-runs
+func (h *SyncHistoryHandler) SyncRuns(c *fiber.Ctx) error {
+	days, err := h.chartDays(c)
+	if err != nil {
+		return err
+	}
+
+	runs, err := h.service.SyncRuns(c.UserContext(), days)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromSyncRuns(runs))
+}
+
+// SyncFailures handles GET /api/v1/admin/metrics/ui/sync-failures
+func (h *SyncHistoryHandler) SyncFailures(c *fiber.Ctx) error {
+	days, err := h.chartDays(c)
+	if err != nil {
+		return err
+	}
+
+	dayList, series, err := h.service.SyncFailures(c.UserContext(), days)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.FromSyncFailures(dayList, series))
+}
+
+// chartDays parses and validates the charted period of a request.
+func (h *SyncHistoryHandler) chartDays(c *fiber.Ctx) (int, error) {
+	var req dto.ChartRequest
+	if err := c.QueryParser(&req); err != nil {
+		return 0, invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return 0, err
+	}
+
+	return req.ChartDays(), nil
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/transport/httpserver/dto"
+	"search-engine-service/internal/validator"
+)
+
+// WebhookHandler handles webhook subscription management requests.
+type WebhookHandler struct {
+	service   *service.WebhookService
+	validator *validator.Validator
+	logger    *zap.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(svc *service.WebhookService, v *validator.Validator, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service:   svc,
+		validator: v,
+		logger:    logger,
+	}
+}
+
+// Create handles POST /api/v1/admin/webhooks
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidParams(err)
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		return err
+	}
+
+	webhook, err := h.service.Create(c.UserContext(), req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	resp := dto.FromDomainWebhook(webhook)
+	resp.Secret = webhook.Secret // Shown once so the receiver can verify signatures
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// List handles GET /api/v1/admin/webhooks
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	webhooks, err := h.service.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dto.WebhookResponse, len(webhooks))
+	for i, w := range webhooks {
+		resp[i] = dto.FromDomainWebhook(w)
+	}
+
+	return c.JSON(fiber.Map{
+		"webhooks": resp,
+	})
+}
+
+// Delete handles DELETE /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	if err := h.service.Delete(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
+)
+
+const (
+	// auditWriteTimeout bounds storing a single audit entry.
+	auditWriteTimeout = 3 * time.Second
+
+	// maxAuditBodySize is the largest request body copied into an audit entry.
+	maxAuditBodySize = 4 << 10
+
+	auditRedacted     = "[REDACTED]"
+	auditBodyOmitted  = "[OMITTED]"
+	auditAnonymous    = "anonymous"
+	auditSubjectClaim = "sub"
+
+	// auditResultLocalKey is the fiber.Ctx locals key holding the result set by SetAuditResult.
+	auditResultLocalKey = "audit_result"
+
+	// maxAuditedAnonymousRejects caps the anonymous calls rejected by auth recorded per
+	// auditRejectWindow, so unauthenticated clients can't flood the audit log.
+	maxAuditedAnonymousRejects = 60
+	auditRejectWindow          = time.Minute
+)
+
+// auditSensitiveKeys are substrings of parameter names whose values are never stored.
+var auditSensitiveKeys = []string{"secret", "password", "token"}
+
+// AuditRecorder stores audit entries.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+// Audit returns a middleware recording every request it wraps: the caller, route, parameters
+// (with secrets redacted), response status and duration.
+//
+// Register it before auth so rejected calls are recorded too. Only the first
+// maxAuditedAnonymousRejects anonymous calls rejected with 401 or 403 are recorded per
+// auditRejectWindow; the next one recorded counts those skipped under the "suppressed"
+// parameter. Errors returned by later handlers are rendered here so the recorded status
+// is the one sent. Recording failures are logged and don't affect the response.
+func Audit(recorder AuditRecorder, logger *zap.Logger) fiber.Handler {
+	rejects := &rejectSampler{}
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if err := c.Next(); err != nil {
+			if herr := c.App().Config().ErrorHandler(c, err); herr != nil {
+				return herr
+			}
+		}
+
+		actor, status := auditActor(c), c.Response().StatusCode()
+		var suppressed int
+		if actor == auditAnonymous && (status == fiber.StatusUnauthorized || status == fiber.StatusForbidden) {
+			var record bool
+			if record, suppressed = rejects.sample(time.Now()); !record {
+				return nil
+			}
+		}
+
+		entry := &domain.AuditEntry{
+			Actor:      actor,
+			Method:     c.Method(),
+			Path:       c.Path(),
+			Route:      c.Route().Path,
+			Params:     auditParams(c),
+			Status:     status,
+			DurationMs: time.Since(start).Milliseconds(),
+			IP:         c.IP(),
+			RequestID:  c.GetRespHeader(fiber.HeaderXRequestID),
+		}
+
+		if suppressed > 0 {
+			entry.Params["suppressed"] = suppressed
+		}
+
+		// Recorded even if the client has gone away
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), auditWriteTimeout)
+		defer cancel()
+
+		if err := recorder.Record(ctx, entry); err != nil {
+			applog.FromContext(ctx, logger).Warn("failed to record audit entry",
+				zap.Error(err),
+				zap.String("method", entry.Method),
+				zap.String("path", entry.Path),
+				zap.String("actor", entry.Actor),
+			)
+		}
+
+		return nil
+	}
+}
+
+// rejectSampler counts the rejected anonymous calls of the current window.
+type rejectSampler struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	recorded    int
+	suppressed  int // Skipped since the last recorded call
+}
+
+// sample reports whether a rejected call made at now is recorded and, if so, how
+// many were skipped since the last one that was.
+func (s *rejectSampler) sample(now time.Time) (record bool, suppressed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= auditRejectWindow {
+		s.windowStart, s.recorded = now, 0
+	}
+	if s.recorded >= maxAuditedAnonymousRejects {
+		s.suppressed++
+
+		return false, 0
+	}
+	s.recorded++
+	suppressed, s.suppressed = s.suppressed, 0
+
+	return true, suppressed
+}
+
+// SetAuditResult adds result to the audit entry of the request, under the "result"
+// parameter, for calls whose outcome the response status doesn't tell, like what a
+// purge removed. Does nothing when the audit log is disabled.
+func SetAuditResult(c *fiber.Ctx, result any) {
+	c.Locals(auditResultLocalKey, result)
+}
+
+// auditActor returns the JWT subject, or "anonymous" when the request wasn't authenticated.
+func auditActor(c *fiber.Ctx) string {
+	if sub, ok := ClaimsFromContext(c)[auditSubjectClaim].(string); ok && sub != "" {
+		return sub
+	}
+
+	return auditAnonymous
+}
+
+// auditParams collects the route, query and JSON body parameters of a request, and
+// the result set by SetAuditResult.
+// Bodies that are too large or not JSON are replaced with a marker.
+func auditParams(c *fiber.Ctx) map[string]any {
+	params := make(map[string]any)
+
+	if route := c.AllParams(); len(route) > 0 {
+		path := make(map[string]any, len(route))
+		for k, v := range route {
+			path[k] = v
+		}
+		params["path"] = path
+	}
+
+	query := make(map[string]any)
+	for k, v := range c.Queries() {
+		query[k] = v
+	}
+	if len(query) > 0 {
+		params["query"] = redact(query)
+	}
+
+	if body := c.Body(); len(body) > 0 {
+		var decoded any
+		if len(body) > maxAuditBodySize || json.Unmarshal(body, &decoded) != nil {
+			params["body"] = auditBodyOmitted
+		} else {
+			params["body"] = redact(decoded)
+		}
+	}
+
+	if result := c.Locals(auditResultLocalKey); result != nil {
+		params["result"] = result
+	}
+
+	return params
+}
+
+// redact replaces values of sensitive keys in decoded JSON, recursively.
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, inner := range v {
+			if isSensitiveKey(k) {
+				v[k] = auditRedacted
+			} else {
+				v[k] = redact(inner)
+			}
+		}
+
+		return v
+	case []any:
+		for i, inner := range v {
+			v[i] = redact(inner)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey reports whether a parameter name suggests a secret value.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range auditSensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// memoryAuditRecorder is an in-memory AuditRecorder for tests.
+type memoryAuditRecorder struct {
+	mu      sync.Mutex
+	entries []*domain.AuditEntry
+	err     error
+}
+
+func (r *memoryAuditRecorder) Record(_ context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+
+	return r.err
+}
+
+func newAuditTestApp(t *testing.T, recorder AuditRecorder) *fiber.App {
+	t.Helper()
+
+	auth, err := NewJWTAuth(context.Background(), JWTConfig{Secret: testSecret}, zap.NewNop())
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.Status(fiber.StatusNotFound).SendString(err.Error())
+		},
+	})
+	admin := app.Group("/admin", Audit(recorder, zap.NewNop()), auth.Authenticate(), auth.RequireRole("admin"))
+	admin.Post("/webhooks/:id", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).SendString("created")
+	})
+	admin.Delete("/webhooks/:id", func(*fiber.Ctx) error {
+		return errors.New("webhook not found")
+	})
+
+	return app
+}
+
+func TestAudit_RecordsCall(t *testing.T) {
+	recorder := &memoryAuditRecorder{}
+	app := newAuditTestApp(t, recorder)
+
+	body := `{"url":"https://example.com","secret":"s3cr3t-value","nested":{"api_token":"t"}}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc?dry_run=true&access_token=t", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signHMAC(t, validClaims()))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	require.Len(t, recorder.entries, 1)
+	entry := recorder.entries[0]
+	assert.Equal(t, "user-1", entry.Actor)
+	assert.Equal(t, http.MethodPost, entry.Method)
+	assert.Equal(t, "/admin/webhooks/abc", entry.Path)
+	assert.Equal(t, "/admin/webhooks/:id", entry.Route)
+	assert.Equal(t, fiber.StatusCreated, entry.Status)
+	assert.Equal(t, map[string]any{
+		"path":  map[string]any{"id": "abc"},
+		"query": map[string]any{"dry_run": "true", "access_token": auditRedacted},
+		"body": map[string]any{
+			"url":    "https://example.com",
+			"secret": auditRedacted,
+			"nested": map[string]any{"api_token": auditRedacted},
+		},
+	}, entry.Params)
+}
+
+func TestAudit_RecordsResult(t *testing.T) {
+	recorder := &memoryAuditRecorder{}
+	app := fiber.New()
+	app.Delete("/admin/contents", Audit(recorder, zap.NewNop()), func(c *fiber.Ctx) error {
+		SetAuditResult(c, map[string]any{"content_id": "id-1"})
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	_, err := app.Test(httptest.NewRequest(http.MethodDelete, "/admin/contents?external_id=a-1", nil))
+	require.NoError(t, err)
+
+	require.Len(t, recorder.entries, 1)
+	assert.Equal(t, map[string]any{
+		"query":  map[string]any{"external_id": "a-1"},
+		"result": map[string]any{"content_id": "id-1"},
+	}, recorder.entries[0].Params)
+}
+
+func TestAudit_RecordsRejectedCall(t *testing.T) {
+	recorder := &memoryAuditRecorder{}
+	app := newAuditTestApp(t, recorder)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	require.Len(t, recorder.entries, 1)
+	assert.Equal(t, auditAnonymous, recorder.entries[0].Actor)
+	assert.Equal(t, fiber.StatusUnauthorized, recorder.entries[0].Status)
+}
+
+func TestAudit_CapsRejectedAnonymousCalls(t *testing.T) {
+	recorder := &memoryAuditRecorder{}
+	app := newAuditTestApp(t, recorder)
+
+	for range maxAuditedAnonymousRejects + 10 {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, "skipped calls are still rejected")
+	}
+	require.Len(t, recorder.entries, maxAuditedAnonymousRejects)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signHMAC(t, validClaims()))
+	_, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Len(t, recorder.entries, maxAuditedAnonymousRejects+1, "authenticated calls are always recorded")
+}
+
+func TestRejectSampler_CountsSuppressedCalls(t *testing.T) {
+	s := &rejectSampler{}
+	now := time.Now()
+
+	for range maxAuditedAnonymousRejects {
+		record, suppressed := s.sample(now)
+		require.True(t, record)
+		require.Zero(t, suppressed)
+	}
+	for range 3 {
+		record, _ := s.sample(now.Add(time.Second))
+		assert.False(t, record)
+	}
+
+	record, suppressed := s.sample(now.Add(auditRejectWindow))
+	assert.True(t, record, "a new window records again")
+	assert.Equal(t, 3, suppressed)
+}
+
+func TestAudit_RecordsHandlerErrorStatus(t *testing.T) {
+	recorder := &memoryAuditRecorder{}
+	app := newAuditTestApp(t, recorder)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/webhooks/abc", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signHMAC(t, validClaims()))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	require.Len(t, recorder.entries, 1)
+	assert.Equal(t, fiber.StatusNotFound, recorder.entries[0].Status)
+}
+
+func TestAudit_OmitsLargeOrNonJSONBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"too large", `{"q":"` + strings.Repeat("a", maxAuditBodySize) + `"}`},
+		{"not json", "provider=a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &memoryAuditRecorder{}
+			app := newAuditTestApp(t, recorder)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signHMAC(t, validClaims()))
+
+			_, err := app.Test(req)
+			require.NoError(t, err)
+
+			require.Len(t, recorder.entries, 1)
+			assert.Equal(t, auditBodyOmitted, recorder.entries[0].Params["body"])
+		})
+	}
+}
+
+func TestAudit_RecorderFailureKeepsResponse(t *testing.T) {
+	recorder := &memoryAuditRecorder{err: errors.New("database down")}
+	app := newAuditTestApp(t, recorder)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/abc", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+signHMAC(t, validClaims()))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
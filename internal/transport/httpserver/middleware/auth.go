@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// claimsLocalKey is the fiber.Ctx locals key holding the validated token claims.
+const claimsLocalKey = "auth_claims"
+
+// JWTConfig holds JWT validation settings.
+// Exactly one of Secret (HMAC) or JWKSURL (RSA/ECDSA) must be set.
+type JWTConfig struct {
+	Secret          string
+	JWKSURL         string
+	JWKSRefresh     time.Duration
+	Issuer          string
+	Audience        string
+	RolesClaim      string
+	ClockSkewLeeway time.Duration
+}
+
+// JWTAuth validates bearer tokens and enforces role claims.
+type JWTAuth struct {
+	keyfunc    jwt.Keyfunc
+	parser     *jwt.Parser
+	rolesClaim string
+	logger     *zap.Logger
+}
+
+// NewJWTAuth creates a JWT validator.
+// When JWKSURL is set, the key set is fetched immediately so misconfiguration fails fast.
+func NewJWTAuth(ctx context.Context, cfg JWTConfig, logger *zap.Logger) (*JWTAuth, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.ClockSkewLeeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	var keyfunc jwt.Keyfunc
+	switch {
+	case cfg.Secret != "" && cfg.JWKSURL != "":
+		return nil, errors.New("jwt: secret and jwks_url are mutually exclusive")
+	case cfg.Secret != "":
+		secret := []byte(cfg.Secret)
+		keyfunc = func(*jwt.Token) (interface{}, error) { return secret, nil }
+		opts = append(opts, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	case cfg.JWKSURL != "":
+		jwks, err := newJWKSCache(ctx, cfg.JWKSURL, cfg.JWKSRefresh)
+		if err != nil {
+			return nil, err
+		}
+		keyfunc = jwks.Keyfunc
+		opts = append(opts, jwt.WithValidMethods([]string{
+			"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512",
+		}))
+	default:
+		return nil, errors.New("jwt: either secret or jwks_url is required")
+	}
+
+	rolesClaim := cfg.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = "roles"
+	}
+
+	return &JWTAuth{
+		keyfunc:    keyfunc,
+		parser:     jwt.NewParser(opts...),
+		rolesClaim: rolesClaim,
+		logger:     logger,
+	}, nil
+}
+
+// Authenticate returns a middleware that requires a valid bearer token.
+// The validated claims are stored in the request locals for RequireRole.
+func (a *JWTAuth) Authenticate() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			return unauthorized(c, "missing bearer token")
+		}
+
+		claims, err := a.parse(token)
+		if err != nil {
+			applog.FromContext(c.UserContext(), a.logger).Debug("jwt validation failed", zap.Error(err), zap.String("path", c.Path()))
+
+			return unauthorized(c, "invalid token")
+		}
+
+		c.Locals(claimsLocalKey, claims)
+
+		return c.Next()
+	}
+}
+
+// Authenticated reports whether the request carries a valid bearer token, for routes
+// open to everyone that give authenticated callers more. It doesn't store the claims.
+func (a *JWTAuth) Authenticated(c *fiber.Ctx) bool {
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+
+	_, err := a.parse(token)
+
+	return err == nil
+}
+
+// parse validates token and returns its claims.
+func (a *JWTAuth) parse(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.keyfunc); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// RequireRole returns a middleware that requires the authenticated token to carry role.
+// It must run after Authenticate.
+func (a *JWTAuth) RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals(claimsLocalKey).(jwt.MapClaims)
+		if !ok {
+			return unauthorized(c, "missing bearer token")
+		}
+
+		if !slices.Contains(rolesFromClaim(claims[a.rolesClaim]), role) {
+			return WriteError(c, fiber.StatusForbidden, dto.ErrorResponse{
+				Error: "insufficient role",
+				Code:  "FORBIDDEN",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// ClaimsFromContext returns the validated token claims, or nil for unauthenticated requests.
+func ClaimsFromContext(c *fiber.Ctx) jwt.MapClaims {
+	claims, _ := c.Locals(claimsLocalKey).(jwt.MapClaims)
+
+	return claims
+}
+
+// rolesFromClaim accepts roles as a JSON array or a space-separated string.
+func rolesFromClaim(v interface{}) []string {
+	switch roles := v.(type) {
+	case string:
+		return strings.Fields(roles)
+	case []interface{}:
+		out := make([]string, 0, len(roles))
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				out = append(out, s)
+			}
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
+
+// unauthorized writes a 401 response with a bearer challenge.
+func unauthorized(c *fiber.Ctx, msg string) error {
+	c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+
+	return WriteError(c, fiber.StatusUnauthorized, dto.ErrorResponse{
+		Error: msg,
+		Code:  "UNAUTHORIZED",
+	})
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSecret = "test-secret"
+
+func newTestApp(t *testing.T, auth *JWTAuth) *fiber.App {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/public", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	admin := app.Group("/admin", auth.Authenticate(), auth.RequireRole("admin"))
+	admin.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(ClaimsFromContext(c)["sub"].(string))
+	})
+
+	return app
+}
+
+func signHMAC(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	return token
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com",
+		"aud":   "search-engine",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin"},
+	}
+}
+
+func doRequest(t *testing.T, app *fiber.App, path, token string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestNewJWTAuth_ConfigValidation(t *testing.T) {
+	_, err := NewJWTAuth(context.Background(), JWTConfig{}, zap.NewNop())
+	assert.Error(t, err)
+
+	_, err = NewJWTAuth(context.Background(), JWTConfig{Secret: "s", JWKSURL: "http://x"}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestJWTAuth_HMAC(t *testing.T) {
+	auth, err := NewJWTAuth(context.Background(), JWTConfig{
+		Secret:   testSecret,
+		Issuer:   "https://issuer.example.com",
+		Audience: "search-engine",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	app := newTestApp(t, auth)
+
+	tests := []struct {
+		name       string
+		token      func() string
+		wantStatus int
+	}{
+		{
+			name:       "valid admin token",
+			token:      func() string { return signHMAC(t, validClaims()) },
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "roles as space-separated string",
+			token:      func() string { c := validClaims(); c["roles"] = "reader admin"; return signHMAC(t, c) },
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "missing token",
+			token:      func() string { return "" },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "malformed token",
+			token:      func() string { return "not-a-jwt" },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "wrong signature",
+			token: func() string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("other"))
+
+				return token
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "expired",
+			token:      func() string { c := validClaims(); c["exp"] = time.Now().Add(-time.Hour).Unix(); return signHMAC(t, c) },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "missing exp",
+			token:      func() string { c := validClaims(); delete(c, "exp"); return signHMAC(t, c) },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "wrong issuer",
+			token:      func() string { c := validClaims(); c["iss"] = "https://evil.example.com"; return signHMAC(t, c) },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "wrong audience",
+			token:      func() string { c := validClaims(); c["aud"] = "other-service"; return signHMAC(t, c) },
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "missing admin role",
+			token:      func() string { c := validClaims(); c["roles"] = []string{"reader"}; return signHMAC(t, c) },
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name: "alg none rejected",
+			token: func() string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+				return token
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, app, "/admin/", tt.token())
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestJWTAuth_PublicRoutesUnaffected(t *testing.T) {
+	auth, err := NewJWTAuth(context.Background(), JWTConfig{Secret: testSecret}, zap.NewNop())
+	require.NoError(t, err)
+
+	resp := doRequest(t, newTestApp(t, auth), "/public", "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestJWTAuth_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	defer srv.Close()
+
+	auth, err := NewJWTAuth(context.Background(), JWTConfig{
+		JWKSURL:     srv.URL,
+		JWKSRefresh: time.Hour,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	app := newTestApp(t, auth)
+
+	sign := func(kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims())
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	assert.Equal(t, fiber.StatusOK, doRequest(t, app, "/admin/", sign("key-1")).StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, doRequest(t, app, "/admin/", sign("unknown")).StatusCode)
+
+	// HMAC tokens must not be accepted when keys come from JWKS
+	assert.Equal(t, fiber.StatusUnauthorized, doRequest(t, app, "/admin/", signHMAC(t, validClaims())).StatusCode)
+}
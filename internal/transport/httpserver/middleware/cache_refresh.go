@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderRefresh asks for a search to be read from the database when set to true,
+// like Cache-Control: no-cache.
+const HeaderRefresh = "X-Refresh"
+
+// cacheRefreshLocalKey is the fiber.Ctx locals key set when a refresh was granted.
+const cacheRefreshLocalKey = "cache_refresh"
+
+// CacheRefresh returns a middleware letting callers authenticated by auth force a
+// cached route to read the database and re-populate the cache, by sending
+// Cache-Control: no-cache or X-Refresh: true. Without auth (nil) nobody may, since
+// anyone could otherwise send every search to the database.
+//
+// Refreshes asked for by other callers are ignored rather than rejected, since
+// browsers send no-cache on reloads.
+func CacheRefresh(auth *JWTAuth) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if auth != nil && refreshRequested(c) && auth.Authenticated(c) {
+			c.Locals(cacheRefreshLocalKey, true)
+		}
+
+		return c.Next()
+	}
+}
+
+// CacheRefreshGranted reports whether CacheRefresh let the request bypass the cache.
+func CacheRefreshGranted(c *fiber.Ctx) bool {
+	granted, _ := c.Locals(cacheRefreshLocalKey).(bool)
+
+	return granted
+}
+
+// refreshRequested reports whether the request asks to bypass the cache.
+func refreshRequested(c *fiber.Ctx) bool {
+	if strings.EqualFold(c.Get(HeaderRefresh), "true") {
+		return true
+	}
+
+	for _, directive := range strings.Split(c.Get(fiber.HeaderCacheControl), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+
+	return false
+}
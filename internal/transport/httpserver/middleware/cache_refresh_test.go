@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCacheRefresh_OnlyForAuthenticatedCallers(t *testing.T) {
+	auth, err := NewJWTAuth(context.Background(), JWTConfig{Secret: testSecret}, zap.NewNop())
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Get("/contents", CacheRefresh(auth), func(c *fiber.Ctx) error {
+		return c.SendString(strconv.FormatBool(CacheRefreshGranted(c)))
+	})
+	granted := func(headers map[string]string) bool {
+		req := httptest.NewRequest(http.MethodGet, "/contents", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode, "refreshes are never rejected")
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(body) == "true"
+	}
+	bearer := "Bearer " + signHMAC(t, validClaims())
+
+	assert.True(t, granted(map[string]string{fiber.HeaderCacheControl: "max-age=0, No-Cache", fiber.HeaderAuthorization: bearer}))
+	assert.True(t, granted(map[string]string{HeaderRefresh: "true", fiber.HeaderAuthorization: bearer}))
+	assert.False(t, granted(map[string]string{fiber.HeaderAuthorization: bearer}), "without asking")
+	assert.False(t, granted(map[string]string{HeaderRefresh: "true"}), "without a token")
+	assert.False(t, granted(map[string]string{HeaderRefresh: "true", fiber.HeaderAuthorization: "Bearer invalid"}))
+}
+
+func TestCacheRefresh_IgnoredWithoutAuth(t *testing.T) {
+	app := fiber.New()
+	app.Get("/contents", CacheRefresh(nil), func(c *fiber.Ctx) error {
+		return c.SendString(strconv.FormatBool(CacheRefreshGranted(c)))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/contents", nil)
+	req.Header.Set(HeaderRefresh, "true")
+	req.Header.Set(fiber.HeaderCacheControl, "no-cache")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "false", string(body), "anonymous callers must not bypass the cache")
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// CompressLevel trades compression ratio for CPU time.
+type CompressLevel int
+
+// Compression levels, applied to gzip, deflate and Brotli alike.
+const (
+	CompressLevelDefault CompressLevel = iota
+	CompressLevelBestSpeed
+	CompressLevelBestCompression
+)
+
+// ParseCompressLevel parses a level name: "default", "best_speed" or "best_compression".
+// An empty name is the default level.
+func ParseCompressLevel(name string) (CompressLevel, error) {
+	switch name {
+	case "", "default":
+		return CompressLevelDefault, nil
+	case "best_speed":
+		return CompressLevelBestSpeed, nil
+	case "best_compression":
+		return CompressLevelBestCompression, nil
+	default:
+		return 0, fmt.Errorf("unknown compression level %q", name)
+	}
+}
+
+// CompressConfig configures response compression.
+type CompressConfig struct {
+	Level   CompressLevel
+	MinSize int  // Smaller bodies are sent as is; streamed bodies have no known size and are always compressed
+	Brotli  bool // Offer br to clients accepting it; gzip and deflate are offered otherwise
+
+	// Next skips compression for a request when it returns true
+	Next func(c *fiber.Ctx) bool
+}
+
+// compressedContentTypes are formats that are already compressed; compressing them
+// again costs CPU without making them smaller.
+var compressedContentTypes = [][]byte{
+	[]byte("application/gzip"),
+	[]byte("application/x-gzip"),
+	[]byte("application/zip"),
+	[]byte("application/zstd"),
+	[]byte("application/x-bzip2"),
+	[]byte("application/x-xz"),
+	[]byte("application/x-7z-compressed"),
+}
+
+// Compress returns a middleware compressing responses with the best encoding the
+// client accepts (br, gzip, deflate). Responses that already carry a Content-Encoding,
+// have an already-compressed content type or are smaller than MinSize are left as is.
+func Compress(cfg CompressConfig) fiber.Handler {
+	gzipLevel, brotliLevel := compressLevels(cfg.Level)
+
+	noop := func(*fasthttp.RequestCtx) {}
+	compressor := fasthttp.CompressHandlerLevel(noop, gzipLevel)
+	if cfg.Brotli {
+		compressor = fasthttp.CompressHandlerBrotliLevel(noop, brotliLevel, gzipLevel)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if isCompressedContentType(resp.Header.ContentType()) {
+			return nil
+		}
+		// Reading the body of a streamed response would consume the stream
+		if !resp.IsBodyStream() && len(resp.Body()) < cfg.MinSize {
+			return nil
+		}
+
+		compressor(c.Context())
+
+		return nil
+	}
+}
+
+// compressLevels maps level to the gzip/deflate and Brotli levels of fasthttp.
+func compressLevels(level CompressLevel) (gzipLevel, brotliLevel int) {
+	switch level {
+	case CompressLevelBestSpeed:
+		return fasthttp.CompressBestSpeed, fasthttp.CompressBrotliBestSpeed
+	case CompressLevelBestCompression:
+		return fasthttp.CompressBestCompression, fasthttp.CompressBrotliBestCompression
+	default:
+		return fasthttp.CompressDefaultCompression, fasthttp.CompressBrotliDefaultCompression
+	}
+}
+
+// isCompressedContentType reports whether contentType is an already-compressed format.
+func isCompressedContentType(contentType []byte) bool {
+	for _, ct := range compressedContentTypes {
+		if bytes.HasPrefix(contentType, ct) {
+			return true
+		}
+	}
+
+	return false
+}
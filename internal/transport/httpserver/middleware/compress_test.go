@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var compressBody = strings.Repeat("compressible ", 200)
+
+func newCompressApp(cfg CompressConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(Compress(cfg))
+	app.Get("/text", func(c *fiber.Ctx) error {
+		return c.SendString(compressBody)
+	})
+	app.Get("/small", func(c *fiber.Ctx) error {
+		return c.SendString(compressBody[:300])
+	})
+	app.Get("/archive", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/gzip")
+
+		return c.SendString(compressBody)
+	})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString(compressBody[:100])
+		})
+
+		return nil
+	})
+
+	return app
+}
+
+func compressRequest(t *testing.T, app *fiber.App, path, acceptEncoding string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, acceptEncoding)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestCompress_PrefersBrotliWhenEnabled(t *testing.T) {
+	app := newCompressApp(CompressConfig{Brotli: true})
+
+	resp := compressRequest(t, app, "/text", "gzip, br")
+
+	assert.Equal(t, "br", resp.Header.Get(fiber.HeaderContentEncoding))
+	assert.Equal(t, fiber.HeaderAcceptEncoding, resp.Header.Get(fiber.HeaderVary))
+}
+
+func TestCompress_FallsBackToGzipWithoutBrotli(t *testing.T) {
+	app := newCompressApp(CompressConfig{Level: CompressLevelBestSpeed})
+
+	resp := compressRequest(t, app, "/text", "gzip, br")
+	require.Equal(t, "gzip", resp.Header.Get(fiber.HeaderContentEncoding))
+
+	zr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, compressBody, string(body))
+}
+
+func TestCompress_SkipsBodiesBelowMinSize(t *testing.T) {
+	app := newCompressApp(CompressConfig{MinSize: 1024})
+
+	small := compressRequest(t, app, "/small", "gzip")
+	large := compressRequest(t, app, "/text", "gzip")
+
+	assert.Empty(t, small.Header.Get(fiber.HeaderContentEncoding))
+	assert.Equal(t, "gzip", large.Header.Get(fiber.HeaderContentEncoding))
+}
+
+func TestCompress_SkipsAlreadyCompressedContentTypes(t *testing.T) {
+	app := newCompressApp(CompressConfig{Brotli: true})
+
+	resp := compressRequest(t, app, "/archive", "gzip, br")
+
+	assert.Empty(t, resp.Header.Get(fiber.HeaderContentEncoding))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, compressBody, string(body))
+}
+
+func TestCompress_CompressesStreamsRegardlessOfMinSize(t *testing.T) {
+	app := newCompressApp(CompressConfig{MinSize: 1024})
+
+	resp := compressRequest(t, app, "/stream", "gzip")
+
+	assert.Equal(t, "gzip", resp.Header.Get(fiber.HeaderContentEncoding))
+}
+
+func TestCompress_HonorsNext(t *testing.T) {
+	app := newCompressApp(CompressConfig{
+		Next: func(c *fiber.Ctx) bool { return c.Path() == "/text" },
+	})
+
+	resp := compressRequest(t, app, "/text", "gzip")
+
+	assert.Empty(t, resp.Header.Get(fiber.HeaderContentEncoding))
+}
+
+func TestParseCompressLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    CompressLevel
+		wantErr bool
+	}{
+		{name: "", want: CompressLevelDefault},
+		{name: "default", want: CompressLevelDefault},
+		{name: "best_speed", want: CompressLevelBestSpeed},
+		{name: "best_compression", want: CompressLevelBestCompression},
+		{name: "fastest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCompressLevel(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,17 +1,31 @@
 package middleware
 
 import (
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-// CORS returns a middleware that handles CORS.
-func CORS() fiber.Handler {
+// CORSConfig is the cross-origin policy for browser clients.
+type CORSConfig struct {
+	AllowOrigins     []string // "*" allows any origin (not combinable with AllowCredentials)
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string // Response headers readable by scripts
+	AllowCredentials bool
+	MaxAge           time.Duration // How long preflight results may be cached
+}
+
+// CORS returns a middleware that handles CORS according to cfg.
+func CORS(cfg CORSConfig) fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins:     "*",
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
-		AllowCredentials: false,
-		MaxAge:           86400,
+		AllowOrigins:     strings.Join(cfg.AllowOrigins, ","),
+		AllowMethods:     strings.Join(cfg.AllowMethods, ","),
+		AllowHeaders:     strings.Join(cfg.AllowHeaders, ","),
+		ExposeHeaders:    strings.Join(cfg.ExposeHeaders, ","),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
 	})
 }
@@ -2,9 +2,17 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/healthcheck"
 	"gorm.io/gorm"
+
+	"search-engine-service/internal/metrics"
 )
 
 // NewHealthCheck creates a Fiber healthcheck middleware with Kubernetes-style endpoints.
@@ -13,8 +21,20 @@ import (
 //   - GET /livez  - Liveness probe (app is running)
 //   - GET /readyz - Readiness probe (app is ready to serve, DB connected)
 //
+// Readiness fails once draining is set, so load balancers stop routing new
+// requests to an instance that is shutting down. The database ping is reused for
+// cacheTTL (0 pings on every probe), so frequent probes from the kubelet and load
+// balancers don't each reach Postgres; draining is always seen right away.
+//
 // This middleware should be registered BEFORE other routes.
-func NewHealthCheck(db *gorm.DB) fiber.Handler {
+func NewHealthCheck(db *gorm.DB, draining *atomic.Bool, cacheTTL time.Duration) fiber.Handler {
+	return newHealthCheck(pingDB(db), draining, cacheTTL)
+}
+
+// newHealthCheck creates the healthcheck middleware, ready while ping succeeds.
+func newHealthCheck(ping func(ctx context.Context) error, draining *atomic.Bool, cacheTTL time.Duration) fiber.Handler {
+	ready := &readinessCheck{ping: ping, ttl: cacheTTL, now: time.Now}
+
 	return healthcheck.New(healthcheck.Config{
 		// Liveness probe - is the application running?
 		LivenessEndpoint: "/livez",
@@ -24,16 +44,63 @@ func NewHealthCheck(db *gorm.DB) fiber.Handler {
 
 		// Readiness probe - is the application ready to serve traffic?
 		ReadinessEndpoint: "/readyz",
-		ReadinessProbe: func(_ *fiber.Ctx) bool {
-			if db == nil {
+		ReadinessProbe: func(c *fiber.Ctx) bool {
+			if draining.Load() {
+				metrics.ReadinessFailures.WithLabelValues("draining").Inc()
+
 				return false
 			}
-			sqlDB, err := db.DB()
-			if err != nil {
+			if err := ready.check(c.UserContext()); err != nil {
+				metrics.ReadinessFailures.WithLabelValues("database").Inc()
+
 				return false
 			}
 
-			return sqlDB.Ping() == nil
+			return true
 		},
 	})
 }
+
+// pingDB returns a function pinging the database of db.
+func pingDB(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("no database")
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// readinessCheck reuses the last ping result for a TTL. Concurrent probes arriving
+// while a ping is in flight wait for it and share its result.
+type readinessCheck struct {
+	ping func(ctx context.Context) error
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	lastErr   error
+	checkedAt time.Time
+}
+
+// check returns the cached ping result if it is younger than the TTL, otherwise
+// pings again.
+func (r *readinessCheck) check(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checkedAt.IsZero() && r.now().Sub(r.checkedAt) < r.ttl {
+		return r.lastErr
+	}
+
+	err := r.ping(ctx)
+	r.lastErr = err
+	r.checkedAt = r.now()
+
+	return err
+}
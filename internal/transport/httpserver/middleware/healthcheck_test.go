@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/metrics"
+)
+
+func TestHealthCheck_CachesDatabasePing(t *testing.T) {
+	var pings atomic.Int32
+	var pingErr error
+	ping := func(context.Context) error {
+		pings.Add(1)
+
+		return pingErr
+	}
+	var draining atomic.Bool
+	app := fiber.New()
+	app.Use(newHealthCheck(ping, &draining, time.Hour))
+
+	probe := func() int {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		require.NoError(t, err)
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, probe())
+	pingErr = errors.New("connection refused")
+	assert.Equal(t, fiber.StatusOK, probe(), "the cached ping is reused")
+	assert.Equal(t, int32(1), pings.Load())
+
+	// Draining isn't cached
+	failures := testutil.ToFloat64(metrics.ReadinessFailures.WithLabelValues("draining"))
+	draining.Store(true)
+	assert.Equal(t, fiber.StatusServiceUnavailable, probe())
+	assert.Equal(t, failures+1, testutil.ToFloat64(metrics.ReadinessFailures.WithLabelValues("draining")))
+}
+
+func TestReadinessCheck_PingsAgainAfterTTL(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	pings := 0
+	r := &readinessCheck{
+		ping: func(context.Context) error {
+			pings++
+
+			return errors.New("connection refused")
+		},
+		ttl: time.Second,
+		now: func() time.Time { return now },
+	}
+
+	require.Error(t, r.check(context.Background()))
+	require.Error(t, r.check(context.Background()))
+	assert.Equal(t, 1, pings, "failures are cached too")
+
+	now = now.Add(time.Second)
+	require.Error(t, r.check(context.Background()))
+	assert.Equal(t, 2, pings)
+}
+
+func TestReadinessCheck_ZeroTTLPingsEveryProbe(t *testing.T) {
+	pings := 0
+	r := &readinessCheck{
+		ping: func(context.Context) error {
+			pings++
+
+			return nil
+		},
+		now: time.Now,
+	}
+
+	for range 3 {
+		require.NoError(t, r.check(context.Background()))
+	}
+	assert.Equal(t, 3, pings)
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// Idempotency headers.
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+
+	// idempotencyStoreTimeout bounds each call to the idempotency store.
+	idempotencyStoreTimeout = 3 * time.Second
+)
+
+// Idempotency returns a middleware that makes POST, PATCH and DELETE requests carrying an
+// Idempotency-Key header safe to retry.
+//
+//   - First request: runs normally; 2xx-4xx responses, including errors returned by
+//     later handlers, are stored for ttl.
+//   - Retry with the same key and body: the stored response is replayed.
+//   - Retry while the first is still running: 409 Conflict.
+//   - Same key with a different method, path or body: 422 Unprocessable Entity.
+//
+// Keys are scoped per JWT subject when auth is enabled. Store errors fail open. Errors
+// returned by later handlers are rendered here so the stored response is the one sent.
+func Idempotency(store domain.IdempotencyStore, ttl time.Duration, logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" || !isMutatingMethod(c.Method()) {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return WriteError(c, fiber.StatusBadRequest, dto.ErrorResponse{
+				Error: "idempotency key too long",
+				Code:  "VALIDATION_ERROR",
+			})
+		}
+
+		if sub, ok := ClaimsFromContext(c)["sub"].(string); ok {
+			key = sub + ":" + key
+		}
+		fingerprint := requestFingerprint(c)
+
+		beginCtx, cancel := context.WithTimeout(c.UserContext(), idempotencyStoreTimeout)
+		record, acquired, err := store.Begin(beginCtx, key, fingerprint, ttl)
+		cancel()
+		if err != nil {
+			applog.FromContext(c.UserContext(), logger).Warn("idempotency store unavailable, processing request without it", zap.Error(err))
+
+			return c.Next()
+		}
+
+		if !acquired {
+			return replay(c, record, fingerprint)
+		}
+
+		if err := c.Next(); err != nil {
+			if herr := c.App().Config().ErrorHandler(c, err); herr != nil {
+				release(c, store, key, logger)
+
+				return herr
+			}
+		}
+
+		// Server errors aren't stored so the client can retry them
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			release(c, store, key, logger)
+
+			return nil
+		}
+
+		completed := &domain.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		// Stored even if the client has gone away, so its retry is replayed
+		ctx, cancel := storeContext(c)
+		defer cancel()
+
+		if err := store.Complete(ctx, key, completed, ttl); err != nil {
+			applog.FromContext(ctx, logger).Warn("failed to store idempotent response", zap.Error(err))
+		}
+
+		return nil
+	}
+}
+
+// replay answers a repeated request from its stored record.
+func replay(c *fiber.Ctx, record *domain.IdempotencyRecord, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return WriteError(c, fiber.StatusUnprocessableEntity, dto.ErrorResponse{
+			Error: "idempotency key was used for a different request",
+			Code:  "IDEMPOTENCY_KEY_REUSED",
+		})
+	}
+
+	if !record.Completed {
+		return WriteError(c, fiber.StatusConflict, dto.ErrorResponse{
+			Error: "a request with this idempotency key is still in progress",
+			Code:  "IDEMPOTENCY_IN_PROGRESS",
+		})
+	}
+
+	c.Set(HeaderIdempotentReplayed, "true")
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+
+	return c.Status(record.Status).Send(record.Body)
+}
+
+// release frees the key after a failed request; errors only delay retries until the TTL.
+func release(c *fiber.Ctx, store domain.IdempotencyStore, key string, logger *zap.Logger) {
+	ctx, cancel := storeContext(c)
+	defer cancel()
+
+	if err := store.Release(ctx, key); err != nil {
+		applog.FromContext(ctx, logger).Warn("failed to release idempotency key", zap.Error(err))
+	}
+}
+
+// storeContext bounds a store call finishing a request, which outlives the client.
+func storeContext(c *fiber.Ctx) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(c.UserContext()), idempotencyStoreTimeout)
+}
+
+// requestFingerprint hashes method, path and body to detect key reuse for different requests.
+func requestFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Path()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isMutatingMethod reports whether requests with method change state.
+func isMutatingMethod(method string) bool {
+	return method == fiber.MethodPost || method == fiber.MethodPatch || method == fiber.MethodDelete
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// memoryIdempotencyStore is an in-memory domain.IdempotencyStore for tests.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Begin(
+	_ context.Context, key, fingerprint string, _ time.Duration,
+) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.records[key]; ok {
+		return r, false, nil
+	}
+	s.records[key] = &domain.IdempotencyRecord{Fingerprint: fingerprint}
+
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, r *domain.IdempotencyRecord, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = r
+
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+
+	return nil
+}
+
+func newIdempotencyTestApp(store domain.IdempotencyStore, status *int, calls *int) *fiber.App {
+	app := fiber.New()
+	app.Use(Idempotency(store, time.Hour, zap.NewNop()))
+	app.Post("/sync", func(c *fiber.Ctx) error {
+		*calls++
+
+		return c.Status(*status).JSON(fiber.Map{"call": *calls})
+	})
+
+	return app
+}
+
+func postWithKey(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(resp.Body)
+
+	return resp, string(data)
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	status, calls := fiber.StatusOK, 0
+	app := newIdempotencyTestApp(newMemoryIdempotencyStore(), &status, &calls)
+
+	first, firstBody := postWithKey(t, app, "key-1", `{}`)
+	second, secondBody := postWithKey(t, app, "key-1", `{}`)
+
+	assert.Equal(t, 1, calls, "handler must run once")
+	assert.Equal(t, fiber.StatusOK, second.StatusCode)
+	assert.Equal(t, firstBody, secondBody)
+	assert.Empty(t, first.Header.Get(HeaderIdempotentReplayed))
+	assert.Equal(t, "true", second.Header.Get(HeaderIdempotentReplayed))
+	assert.Equal(t, fiber.MIMEApplicationJSON, second.Header.Get(fiber.HeaderContentType))
+}
+
+func TestIdempotency_WithoutKeyAlwaysRuns(t *testing.T) {
+	status, calls := fiber.StatusOK, 0
+	app := newIdempotencyTestApp(newMemoryIdempotencyStore(), &status, &calls)
+
+	postWithKey(t, app, "", `{}`)
+	postWithKey(t, app, "", `{}`)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	status, calls := fiber.StatusOK, 0
+	app := newIdempotencyTestApp(newMemoryIdempotencyStore(), &status, &calls)
+
+	postWithKey(t, app, "key-1", `{"provider":"a"}`)
+	resp, _ := postWithKey(t, app, "key-1", `{"provider":"b"}`)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	status, calls := fiber.StatusOK, 0
+	app := newIdempotencyTestApp(store, &status, &calls)
+
+	// Simulate a first request still running on another instance
+	req := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(`{}`))
+	fp := func() string {
+		var got string
+		probe := fiber.New()
+		probe.Post("/sync", func(c *fiber.Ctx) error { got = requestFingerprint(c); return nil })
+		_, _ = probe.Test(req)
+
+		return got
+	}()
+	_, _, _ = store.Begin(context.Background(), "key-1", fp, time.Hour)
+
+	resp, _ := postWithKey(t, app, "key-1", `{}`)
+
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	status, calls := fiber.StatusInternalServerError, 0
+	app := newIdempotencyTestApp(newMemoryIdempotencyStore(), &status, &calls)
+
+	postWithKey(t, app, "key-1", `{}`)
+	status = fiber.StatusOK
+	resp, _ := postWithKey(t, app, "key-1", `{}`)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls, "retry after a 5xx must run the handler again")
+}
+
+func TestIdempotency_ReplaysHandlerErrors(t *testing.T) {
+	calls := 0
+	app := fiber.New()
+	app.Use(Idempotency(newMemoryIdempotencyStore(), time.Hour, zap.NewNop()))
+	app.Post("/sync", func(*fiber.Ctx) error {
+		calls++
+
+		return fiber.NewError(fiber.StatusNotFound, "unknown provider")
+	})
+
+	first, firstBody := postWithKey(t, app, "key-1", `{"provider":"x"}`)
+	second, secondBody := postWithKey(t, app, "key-1", `{"provider":"x"}`)
+
+	assert.Equal(t, 1, calls, "a 4xx is stored, not run again")
+	assert.Equal(t, fiber.StatusNotFound, first.StatusCode)
+	assert.Equal(t, fiber.StatusNotFound, second.StatusCode)
+	assert.Equal(t, firstBody, secondBody)
+	assert.Equal(t, "true", second.Header.Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotency_HandlerServerErrorsAreNotStored(t *testing.T) {
+	calls := 0
+	app := fiber.New()
+	app.Use(Idempotency(newMemoryIdempotencyStore(), time.Hour, zap.NewNop()))
+	app.Post("/sync", func(*fiber.Ctx) error {
+		calls++
+
+		return fiber.ErrServiceUnavailable
+	})
+
+	postWithKey(t, app, "key-1", `{}`)
+	resp, _ := postWithKey(t, app, "key-1", `{}`)
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"search-engine-service/internal/domain"
+)
+
+// Timeout returns a middleware that attaches a deadline to the request's user
+// context (c.UserContext), so handlers passing it down cancel slow database and
+// provider calls instead of piling up goroutines. A handler error returned after
+// the deadline passed is reported as domain.ErrTimeout (504).
+// A non-positive timeout disables the middleware.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !errors.Is(err, domain.ErrTimeout) {
+			return fmt.Errorf("%w: %w", domain.ErrTimeout, err)
+		}
+
+		return err
+	}
+}
+
+// BodyLimit returns a middleware rejecting request bodies larger than limit bytes
+// with 413. It tightens the server-wide body limit for a route group.
+// A non-positive limit disables the middleware.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		if len(c.Body()) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+)
+
+func TestTimeout_CancelsUserContext(t *testing.T) {
+	var handlerErr error
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			handlerErr = err
+
+			return c.SendStatus(fiber.StatusGatewayTimeout)
+		},
+	})
+	app.Get("/", Timeout(20*time.Millisecond), func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+
+		return c.UserContext().Err()
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+	assert.ErrorIs(t, handlerErr, domain.ErrTimeout)
+	assert.ErrorIs(t, handlerErr, context.DeadlineExceeded)
+}
+
+func TestTimeout_PassesThroughBeforeDeadline(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", Timeout(time.Second), func(c *fiber.Ctx) error {
+		_, hasDeadline := c.UserContext().Deadline()
+		assert.True(t, hasDeadline)
+
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestTimeout_KeepsErrorsBeforeDeadline(t *testing.T) {
+	var handlerErr error
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			handlerErr = err
+
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Get("/", Timeout(time.Second), func(_ *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+
+	_, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	assert.NotErrorIs(t, handlerErr, domain.ErrTimeout)
+}
+
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New()
+	app.Post("/", BodyLimit(8), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	small, err := app.Test(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("tiny")))
+	require.NoError(t, err)
+	large, err := app.Test(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("far too large")))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNoContent, small.StatusCode)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, large.StatusCode)
+}
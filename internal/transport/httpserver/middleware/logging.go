@@ -6,9 +6,12 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
+
+	applog "search-engine-service/internal/logger"
 )
 
 // Logger returns a middleware that logs HTTP requests.
+// Lines carry the request_id and trace_id set by RequestContext.
 func Logger(logger *zap.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
@@ -33,12 +36,13 @@ func Logger(logger *zap.Logger) fiber.Handler {
 			fields = append(fields, zap.Error(err))
 		}
 
+		log := applog.FromContext(c.UserContext(), logger)
 		if status >= 500 {
-			logger.Error("request failed", fields...)
+			log.Error("request failed", fields...)
 		} else if status >= 400 {
-			logger.Warn("request error", fields...)
+			log.Warn("request error", fields...)
 		} else {
-			logger.Debug("request completed", fields...)
+			log.Debug("request completed", fields...)
 		}
 
 		return err
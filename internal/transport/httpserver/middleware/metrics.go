@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"search-engine-service/internal/metrics"
+)
+
+// routeUnmatched labels requests that matched no route, keeping arbitrary paths out of label values.
+const routeUnmatched = "unmatched"
+
+// Metrics returns a middleware recording request counts and latencies by route
+// and counting requests towards the SLOs of slo (nil records no SLOs).
+// Handler errors are rendered here so the recorded status is the one sent.
+func Metrics(slo *metrics.SLORecorder) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		route := ""
+		if err := c.Next(); err != nil {
+			// Fiber reports requests matching no route as a *fiber.Error; handlers use domain errors
+			var fe *fiber.Error
+			if errors.As(err, &fe) && fe.Code == fiber.StatusNotFound {
+				route = routeUnmatched
+			}
+			if herr := c.App().Config().ErrorHandler(c, err); herr != nil {
+				return herr
+			}
+		}
+		if route == "" {
+			route = c.Route().Path
+		}
+
+		duration := time.Since(start)
+		status := c.Response().StatusCode()
+
+		metrics.HTTPRequests.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Method(), route).Observe(duration.Seconds())
+		if slo != nil {
+			slo.Observe(route, status, duration)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/metrics"
+)
+
+func TestMetrics_LabelsRequestsByRoute(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.SendStatus(fiber.StatusServiceUnavailable)
+		},
+	})
+	app.Use(Metrics(nil))
+	app.Get("/metrics-test/items/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "broken" {
+			return domain.ErrTimeout
+		}
+
+		return c.SendString("ok")
+	})
+
+	requests := func(route, status string) float64 {
+		return testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, route, status))
+	}
+	okBefore := requests("/metrics-test/items/:id", "200")
+	failedBefore := requests("/metrics-test/items/:id", "503")
+	unmatchedBefore := requests(routeUnmatched, "503")
+
+	for _, path := range []string{"/metrics-test/items/1", "/metrics-test/items/2", "/metrics-test/items/broken", "/metrics-test/nope"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, 2.0, requests("/metrics-test/items/:id", "200")-okBefore)
+	assert.Equal(t, 1.0, requests("/metrics-test/items/:id", "503")-failedBefore, "errors are recorded with the rendered status")
+	assert.Equal(t, 1.0, requests(routeUnmatched, "503")-unmatchedBefore, "unknown paths share one label")
+}
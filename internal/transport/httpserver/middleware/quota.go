@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+	applog "search-engine-service/internal/logger"
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// Quota headers. Reset is the Unix time the quota starts over.
+const (
+	HeaderAPIKey         = "X-API-Key"
+	HeaderQuotaLimit     = "X-Quota-Limit"
+	HeaderQuotaRemaining = "X-Quota-Remaining"
+	HeaderQuotaReset     = "X-Quota-Reset"
+)
+
+// QuotaMeter identifies API keys and counts their requests.
+type QuotaMeter interface {
+	Lookup(key string) (name string, ok bool)
+	Consume(ctx context.Context, name string) (domain.QuotaUsage, error)
+}
+
+// Quota returns a middleware counting requests carrying an X-API-Key header towards
+// the monthly quota of the key, answering 429 Too Many Requests once it's used up.
+// Responses of keys with a limit carry the X-Quota-* headers.
+//
+// Unknown keys are rejected. Requests without a key are rejected when requireKey is
+// set, and let through uncounted otherwise. Store errors fail open.
+func Quota(meter QuotaMeter, requireKey bool, logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderAPIKey)
+		if key == "" {
+			if requireKey {
+				return invalidAPIKey(c, "missing API key")
+			}
+
+			return c.Next()
+		}
+
+		name, ok := meter.Lookup(key)
+		if !ok {
+			return invalidAPIKey(c, "invalid API key")
+		}
+
+		usage, err := meter.Consume(c.UserContext(), name)
+		if err != nil {
+			applog.FromContext(c.UserContext(), logger).Warn("quota store unavailable, processing request without it",
+				zap.Error(err),
+				zap.String("api_key", name),
+			)
+
+			return c.Next()
+		}
+
+		if usage.Limit > 0 {
+			c.Set(HeaderQuotaLimit, strconv.FormatInt(usage.Limit, 10))
+			c.Set(HeaderQuotaRemaining, strconv.FormatInt(usage.Remaining(), 10))
+			c.Set(HeaderQuotaReset, strconv.FormatInt(usage.ResetAt.Unix(), 10))
+		}
+
+		if usage.Exceeded() {
+			retryAfter := max(1, int64(time.Until(usage.ResetAt).Seconds()))
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+
+			return WriteError(c, fiber.StatusTooManyRequests, dto.ErrorResponse{
+				Error: "monthly request quota exceeded",
+				Code:  "QUOTA_EXCEEDED",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// invalidAPIKey writes a 401 response for a missing or unknown API key.
+func invalidAPIKey(c *fiber.Ctx, msg string) error {
+	return WriteError(c, fiber.StatusUnauthorized, dto.ErrorResponse{
+		Error: msg,
+		Code:  "UNAUTHORIZED",
+	})
+}
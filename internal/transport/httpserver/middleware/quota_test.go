@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/domain"
+)
+
+// fakeQuotaMeter allows the key "key-a" of partner-a a limit of requests.
+type fakeQuotaMeter struct {
+	limit    int64
+	requests int64
+	err      error
+}
+
+func (m *fakeQuotaMeter) Lookup(key string) (string, bool) {
+	return "partner-a", key == "key-a"
+}
+
+func (m *fakeQuotaMeter) Consume(_ context.Context, name string) (domain.QuotaUsage, error) {
+	if m.err != nil {
+		return domain.QuotaUsage{}, m.err
+	}
+	m.requests++
+
+	return domain.QuotaUsage{Name: name, Limit: m.limit, Requests: m.requests, ResetAt: time.Now().Add(time.Hour)}, nil
+}
+
+func quotaRequest(t *testing.T, app *fiber.App, key string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/contents", nil)
+	if key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func newQuotaTestApp(meter QuotaMeter, requireKey bool) *fiber.App {
+	app := fiber.New()
+	app.Use(Quota(meter, requireKey, zap.NewNop()))
+	app.Get("/contents", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	return app
+}
+
+func TestQuota_RejectsRequestsOverLimit(t *testing.T) {
+	app := newQuotaTestApp(&fakeQuotaMeter{limit: 2}, false)
+
+	first := quotaRequest(t, app, "key-a")
+	assert.Equal(t, fiber.StatusOK, first.StatusCode)
+	assert.Equal(t, "2", first.Header.Get(HeaderQuotaLimit))
+	assert.Equal(t, "1", first.Header.Get(HeaderQuotaRemaining))
+	assert.NotEmpty(t, first.Header.Get(HeaderQuotaReset))
+
+	quotaRequest(t, app, "key-a")
+	over := quotaRequest(t, app, "key-a")
+	assert.Equal(t, fiber.StatusTooManyRequests, over.StatusCode)
+	assert.Equal(t, "0", over.Header.Get(HeaderQuotaRemaining))
+	assert.NotEmpty(t, over.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestQuota_Keys(t *testing.T) {
+	open := newQuotaTestApp(&fakeQuotaMeter{limit: 1}, false)
+	resp := quotaRequest(t, open, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode, "requests without a key aren't counted")
+	assert.Empty(t, resp.Header.Get(HeaderQuotaLimit))
+	assert.Equal(t, fiber.StatusUnauthorized, quotaRequest(t, open, "key-b").StatusCode)
+
+	required := newQuotaTestApp(&fakeQuotaMeter{limit: 1}, true)
+	assert.Equal(t, fiber.StatusUnauthorized, quotaRequest(t, required, "").StatusCode)
+}
+
+func TestQuota_StoreErrorsFailOpen(t *testing.T) {
+	app := newQuotaTestApp(&fakeQuotaMeter{limit: 1, err: errors.New("redis down")}, false)
+
+	assert.Equal(t, fiber.StatusOK, quotaRequest(t, app, "key-a").StatusCode)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
+	applog "search-engine-service/internal/logger"
 	"search-engine-service/internal/transport/httpserver/dto"
 )
 
@@ -14,13 +15,13 @@ func Recover(logger *zap.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.Error("panic recovered",
+				applog.FromContext(c.UserContext(), logger).Error("panic recovered",
 					zap.Any("error", r),
 					zap.String("stack", string(debug.Stack())),
 					zap.String("path", c.Path()),
 				)
 
-				err = c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				err = WriteError(c, fiber.StatusInternalServerError, dto.ErrorResponse{
 					Error: "internal server error",
 					Code:  "PANIC",
 				})
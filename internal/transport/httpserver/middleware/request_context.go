@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	applog "search-engine-service/internal/logger"
+)
+
+// HeaderTraceparent carries the W3C Trace Context of the caller.
+const HeaderTraceparent = "traceparent"
+
+// RequestContext returns a middleware storing the request ID (set by the requestid middleware,
+// which must run first) and the caller's trace ID in the request's user context.
+// Everything logging through logger.FromContext then tags its lines with both.
+func RequestContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if id := c.GetRespHeader(fiber.HeaderXRequestID); id != "" {
+			ctx = applog.WithRequestID(ctx, id)
+		}
+		if id := traceIDFromTraceparent(c.Get(HeaderTraceparent)); id != "" {
+			ctx = applog.WithTraceID(ctx, id)
+		}
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// traceIDFromTraceparent returns the trace ID of a traceparent header
+// ("version-traceid-parentid-flags"), or "" when the header is missing or malformed.
+func traceIDFromTraceparent(header string) string {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || !isLowerHex(parts[1]) {
+		return ""
+	}
+	if strings.Trim(parts[1], "0") == "" {
+		return "" // All zeros is invalid
+	}
+
+	return parts[1]
+}
+
+// isLowerHex reports whether s consists of lowercase hex digits only.
+func isLowerHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
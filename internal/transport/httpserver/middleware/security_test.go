@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityApp(cfg SecurityHeadersConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(SecurityHeaders(cfg))
+	app.Get("/page", func(c *fiber.Ctx) error {
+		c.Type("html")
+
+		return c.SendString("<html></html>")
+	})
+	app.Get("/api", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"ok": true})
+	})
+
+	return app
+}
+
+func TestSecurityHeaders_SetsBaselineHeaders(t *testing.T) {
+	app := newSecurityApp(SecurityHeadersConfig{})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+	assert.Equal(t, "strict-origin-when-cross-origin", resp.Header.Get(fiber.HeaderReferrerPolicy))
+}
+
+func TestSecurityHeaders_HSTSOnlyOverHTTPS(t *testing.T) {
+	app := newSecurityApp(SecurityHeadersConfig{HSTSMaxAge: 24 * time.Hour, HSTSIncludeSubdomains: true})
+
+	plain, err := app.Test(httptest.NewRequest(http.MethodGet, "/api", nil))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.Header.Set(fiber.HeaderXForwardedProto, "https")
+	forwarded, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Empty(t, plain.Header.Get(fiber.HeaderStrictTransportSecurity))
+	assert.Equal(t, "max-age=86400; includeSubDomains", forwarded.Header.Get(fiber.HeaderStrictTransportSecurity))
+}
+
+func TestSecurityHeaders_CSPOnlyOnHTML(t *testing.T) {
+	const policy = "default-src 'self'"
+	app := newSecurityApp(SecurityHeadersConfig{ContentSecurityPolicy: policy})
+
+	page, err := app.Test(httptest.NewRequest(http.MethodGet, "/page", nil))
+	require.NoError(t, err)
+	api, err := app.Test(httptest.NewRequest(http.MethodGet, "/api", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, policy, page.Header.Get(fiber.HeaderContentSecurityPolicy))
+	assert.Empty(t, api.Header.Get(fiber.HeaderContentSecurityPolicy))
+}
+
+func TestCORS_AllowsConfiguredOriginsOnly(t *testing.T) {
+	app := fiber.New()
+	app.Use(CORS(CORSConfig{
+		AllowOrigins:  []string{"https://app.example.com"},
+		AllowMethods:  []string{"GET", "PATCH"},
+		AllowHeaders:  []string{"Authorization", HeaderIdempotencyKey},
+		ExposeHeaders: []string{fiber.HeaderXRequestID},
+		MaxAge:        time.Hour,
+	}))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	preflight := func(origin string) *http.Response {
+		req := httptest.NewRequest(http.MethodOptions, "/", nil)
+		req.Header.Set(fiber.HeaderOrigin, origin)
+		req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodPatch)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	allowed := preflight("https://app.example.com")
+	assert.Equal(t, "https://app.example.com", allowed.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,PATCH", allowed.Header.Get(fiber.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "Authorization,Idempotency-Key", allowed.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "3600", allowed.Header.Get(fiber.HeaderAccessControlMaxAge))
+
+	denied := preflight("https://evil.example.com")
+	assert.Empty(t, denied.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+// Deprecation headers (RFC 9745, RFC 8594).
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+const apiVersionLocalKey = "api_version"
+
+// APIVersion returns a middleware tagging requests with the API version of their route group.
+// The version decides the error response format (see WriteError).
+func APIVersion(version int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(apiVersionLocalKey, version)
+
+		return c.Next()
+	}
+}
+
+// APIVersionFromContext returns the API version set by APIVersion, or 1 outside versioned routes.
+func APIVersionFromContext(c *fiber.Ctx) int {
+	version, ok := c.Locals(apiVersionLocalKey).(int)
+	if !ok {
+		return 1
+	}
+
+	return version
+}
+
+// DeprecationConfig describes the announced retirement of an API version.
+type DeprecationConfig struct {
+	Date      time.Time // When the version was deprecated (zero omits the Deprecation header)
+	Sunset    time.Time // When the version stops being served (zero omits the Sunset header)
+	Successor string    // Path of the replacing version, advertised as a successor-version link
+}
+
+// Deprecation returns a middleware announcing the deprecation of a route group through the
+// Deprecation, Sunset and Link (rel="successor-version") response headers.
+// Headers are set before the handler runs so error responses carry them too.
+func Deprecation(cfg DeprecationConfig) fiber.Handler {
+	var deprecation, sunset, link string
+	if !cfg.Date.IsZero() {
+		deprecation = fmt.Sprintf("@%d", cfg.Date.Unix())
+	}
+	if !cfg.Sunset.IsZero() {
+		sunset = cfg.Sunset.UTC().Format(http.TimeFormat)
+	}
+	if cfg.Successor != "" {
+		link = fmt.Sprintf(`<%s>; rel="successor-version"`, cfg.Successor)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if deprecation != "" {
+			c.Set(HeaderDeprecation, deprecation)
+		}
+		if sunset != "" {
+			c.Set(HeaderSunset, sunset)
+		}
+		if link != "" {
+			c.Append(fiber.HeaderLink, link)
+		}
+
+		return c.Next()
+	}
+}
+
+// WriteError writes an error response in the format of the request's API version:
+// RFC 9457 problem details from v2 on, the plain ErrorResponse before.
+func WriteError(c *fiber.Ctx, status int, resp dto.ErrorResponse) error {
+	c.Status(status)
+
+	if APIVersionFromContext(c) >= 2 {
+		return c.JSON(dto.NewProblemResponse(status, resp, c.Path()), dto.MIMEProblemJSON)
+	}
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search-engine-service/internal/transport/httpserver/dto"
+)
+
+func TestDeprecation_SetsHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Use(Deprecation(DeprecationConfig{
+		Date:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC),
+		Successor: "/api/v2",
+	}))
+	app.Get("/", func(_ *fiber.Ctx) error { return fiber.ErrNotFound })
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	// Error responses are announced too
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "@1790812800", resp.Header.Get(HeaderDeprecation))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", resp.Header.Get(HeaderSunset))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, resp.Header.Get(fiber.HeaderLink))
+}
+
+func TestDeprecation_OmitsUnsetHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Use(Deprecation(DeprecationConfig{}))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.Header.Get(HeaderDeprecation))
+	assert.Empty(t, resp.Header.Get(HeaderSunset))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderLink))
+}
+
+func TestWriteError_FormatPerVersion(t *testing.T) {
+	app := fiber.New()
+	app.Get("/v1", APIVersion(1), func(c *fiber.Ctx) error {
+		return WriteError(c, fiber.StatusConflict, dto.ErrorResponse{Error: "busy", Code: "IDEMPOTENCY_IN_PROGRESS"})
+	})
+	app.Get("/v2", APIVersion(2), func(c *fiber.Ctx) error {
+		return WriteError(c, fiber.StatusConflict, dto.ErrorResponse{Error: "busy", Code: "IDEMPOTENCY_IN_PROGRESS"})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/v1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get(fiber.HeaderContentType))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/v2", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+
+	var problem dto.ProblemResponse
+	require.NoError(t, json.Unmarshal(body, &problem))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, dto.MIMEProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, "Conflict", problem.Title)
+	assert.Equal(t, "busy", problem.Detail)
+	assert.Equal(t, "/v2", problem.Instance)
+	assert.Equal(t, "IDEMPOTENCY_IN_PROGRESS", problem.Code)
+}
@@ -0,0 +1,218 @@
+package httpserver
+
+import (
+	"time"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/domain"
+	"search-engine-service/internal/metrics"
+	"search-engine-service/internal/transport/httpserver/middleware"
+)
+
+// Option configures a Server. The routes of a capability are only registered when
+// its option is given with a non-nil service.
+type Option func(*serverOptions)
+
+// serverOptions holds what the options of NewServer set.
+type serverOptions struct {
+	auth      *middleware.JWTAuth
+	adminRole string
+
+	idempotency    domain.IdempotencyStore
+	idempotencyTTL time.Duration
+
+	quotas          *service.QuotaService
+	quotaRequireKey bool
+
+	cacheStats  domain.CacheStatsReporter
+	slo         *metrics.SLORecorder
+	assetsDir   string
+	tls         *TLSConfig
+	compression *middleware.CompressConfig
+
+	events            domain.ContentEventBus
+	dashboardNotifier *service.DashboardNotifier
+	health            *service.HealthService
+	webhooks          *service.WebhookService
+	collections       *service.CollectionService
+	syncHistory       *service.SyncHistoryService
+	settings          *service.SettingsService
+	locks             *service.LockService
+	audit             *service.AuditService
+	analytics         *service.AnalyticsService
+	queryAnalytics    *service.QueryAnalyticsService
+	dictionary        *service.DictionaryService
+	reindex           *service.ReindexService
+	snapshots         *service.SnapshotService
+	purge             *service.PurgeService
+}
+
+// WithAuth protects the admin routes with auth, letting through the callers with
+// adminRole. Without it, they are open.
+func WithAuth(auth *middleware.JWTAuth, adminRole string) Option {
+	return func(o *serverOptions) {
+		o.auth = auth
+		o.adminRole = adminRole
+	}
+}
+
+// WithIdempotency stores the responses of admin mutations sent with an
+// Idempotency-Key in store for ttl, replaying them to retries.
+func WithIdempotency(store domain.IdempotencyStore, ttl time.Duration) Option {
+	return func(o *serverOptions) {
+		o.idempotency = store
+		o.idempotencyTTL = ttl
+	}
+}
+
+// WithQuotas counts the requests of API keys to the public routes towards their
+// monthly quotas in quotas, and reports their use to admins. With requireKey, public
+// requests without an API key are rejected.
+func WithQuotas(quotas *service.QuotaService, requireKey bool) Option {
+	return func(o *serverOptions) {
+		o.quotas = quotas
+		o.quotaRequireKey = requireKey
+	}
+}
+
+// WithCache reports the cache usage counters of stats on the admin cache stats route.
+func WithCache(stats domain.CacheStatsReporter) Option {
+	return func(o *serverOptions) {
+		o.cacheStats = stats
+	}
+}
+
+// WithMetrics counts requests towards the service level objectives of slo, on top
+// of the request metrics always recorded.
+func WithMetrics(slo *metrics.SLORecorder) Option {
+	return func(o *serverOptions) {
+		o.slo = slo
+	}
+}
+
+// WithTemplates serves the dashboard templates and static files from dir in debug
+// mode, so edits show up without a rebuild. Otherwise the embedded ones are served.
+func WithTemplates(dir string) Option {
+	return func(o *serverOptions) {
+		o.assetsDir = dir
+	}
+}
+
+// WithTLS serves HTTPS instead of plain HTTP.
+func WithTLS(tls *TLSConfig) Option {
+	return func(o *serverOptions) {
+		o.tls = tls
+	}
+}
+
+// WithCompression compresses responses.
+func WithCompression(compression *middleware.CompressConfig) Option {
+	return func(o *serverOptions) {
+		o.compression = compression
+	}
+}
+
+// WithEventStream streams the content changes of events to Server-Sent Events clients.
+func WithEventStream(events domain.ContentEventBus) Option {
+	return func(o *serverOptions) {
+		o.events = events
+	}
+}
+
+// WithDashboardUpdates pushes the updates of notifier to the dashboard over its WebSocket.
+func WithDashboardUpdates(notifier *service.DashboardNotifier) Option {
+	return func(o *serverOptions) {
+		o.dashboardNotifier = notifier
+	}
+}
+
+// WithHealthDetails reports the health of each dependency of health.
+func WithHealthDetails(health *service.HealthService) Option {
+	return func(o *serverOptions) {
+		o.health = health
+	}
+}
+
+// WithWebhooks lets admins manage the webhooks of webhooks.
+func WithWebhooks(webhooks *service.WebhookService) Option {
+	return func(o *serverOptions) {
+		o.webhooks = webhooks
+	}
+}
+
+// WithCollections serves the collections of collections, managed by admins.
+func WithCollections(collections *service.CollectionService) Option {
+	return func(o *serverOptions) {
+		o.collections = collections
+	}
+}
+
+// WithSyncHistory serves the time series charted on the dashboard from history.
+func WithSyncHistory(history *service.SyncHistoryService) Option {
+	return func(o *serverOptions) {
+		o.syncHistory = history
+	}
+}
+
+// WithSettings lets admins change the runtime settings of settings.
+func WithSettings(settings *service.SettingsService) Option {
+	return func(o *serverOptions) {
+		o.settings = settings
+	}
+}
+
+// WithLocks lets operators list and release the locks of locks.
+func WithLocks(locks *service.LockService) Option {
+	return func(o *serverOptions) {
+		o.locks = locks
+	}
+}
+
+// WithAudit records admin API calls, rejected ones included, in audit and serves them.
+func WithAudit(audit *service.AuditService) Option {
+	return func(o *serverOptions) {
+		o.audit = audit
+	}
+}
+
+// WithAnalytics records the analytics events reported by search clients in analytics.
+func WithAnalytics(analytics *service.AnalyticsService) Option {
+	return func(o *serverOptions) {
+		o.analytics = analytics
+	}
+}
+
+// WithQueryAnalytics serves the search analytics report of queries.
+func WithQueryAnalytics(queries *service.QueryAnalyticsService) Option {
+	return func(o *serverOptions) {
+		o.queryAnalytics = queries
+	}
+}
+
+// WithSuggestions serves autocomplete suggestions from dictionary.
+func WithSuggestions(dictionary *service.DictionaryService) Option {
+	return func(o *serverOptions) {
+		o.dictionary = dictionary
+	}
+}
+
+// WithReindex lets admins start and follow the reindexes of reindex.
+func WithReindex(reindex *service.ReindexService) Option {
+	return func(o *serverOptions) {
+		o.reindex = reindex
+	}
+}
+
+// WithPurge lets admins purge provider items with purge.
+func WithPurge(purge *service.PurgeService) Option {
+	return func(o *serverOptions) {
+		o.purge = purge
+	}
+}
+
+// WithSnapshots lets admins create content snapshots and restore them with snapshots.
+func WithSnapshots(snapshots *service.SnapshotService) Option {
+	return func(o *serverOptions) {
+		o.snapshots = snapshots
+	}
+}
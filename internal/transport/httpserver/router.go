@@ -2,12 +2,21 @@
 package httpserver
 
 import (
+	"crypto/tls"
 	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/compress"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/template/html/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
@@ -15,33 +24,78 @@ import (
 	"search-engine-service/internal/transport/httpserver/handler"
 	"search-engine-service/internal/transport/httpserver/middleware"
 	"search-engine-service/internal/validator"
+	"search-engine-service/web"
 )
 
-// ServerConfig holds server configuration.
+// ServerConfig holds server configuration. Capabilities are enabled by the options
+// of NewServer instead.
 type ServerConfig struct {
 	Port      int
 	BodyLimit int
 	Debug     bool
+
+	// Per route group limits; sync gets its own since it runs much longer than other admin calls
+	SearchLimits RouteLimits
+	AdminLimits  RouteLimits
+	SyncLimits   RouteLimits
+
+	CORS            middleware.CORSConfig
+	SecurityHeaders middleware.SecurityHeadersConfig
+
+	// V1Deprecation is announced on every /api/v1 response
+	V1Deprecation middleware.DeprecationConfig
+
+	// ReadinessCacheTTL reuses the database ping of /readyz for this long (0 disables)
+	ReadinessCacheTTL time.Duration
 }
 
+// RouteLimits bounds handling time and body size for a route group.
+// Zero values disable the corresponding limit.
+type RouteLimits struct {
+	Timeout   time.Duration
+	BodyLimit int
+}
+
+// limited returns the timeout and body-size middleware for limits followed by h.
+func limited(limits RouteLimits, h fiber.Handler) []fiber.Handler {
+	return []fiber.Handler{middleware.Timeout(limits.Timeout), middleware.BodyLimit(limits.BodyLimit), h}
+}
+
+// contentStreamSuffix ends the Server-Sent Events endpoint for content changes of every API version.
+const contentStreamSuffix = "/contents/stream"
+
+// dashboardSocketPath is the WebSocket endpoint pushing live updates to the dashboard.
+const dashboardSocketPath = "/dashboard/ws"
+
 // Server wraps Fiber app with handlers.
 type Server struct {
 	App    *fiber.App
 	Logger *zap.Logger
+
+	tls      *TLSConfig
+	draining atomic.Bool
 }
 
-// NewServer creates a new HTTP server with all routes configured.
+// NewServer creates a new HTTP server with the search, dashboard and admin routes of
+// searchSvc and syncSvc, and those of the capabilities enabled by opts.
 func NewServer(
 	cfg ServerConfig,
-	searchSvc *service.SearchService,
-	syncSvc *service.SyncService,
+	searchSvc service.SearchUseCase,
+	syncSvc service.SyncUseCase,
 	db *gorm.DB,
 	v *validator.Validator,
 	logger *zap.Logger,
+	opts ...Option,
 ) *Server {
+	var o serverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Template engine for dashboard
-	engine := html.New("./web/templates", ".html")
-	if cfg.Debug {
+	assets, fromDisk := dashboardAssets(cfg.Debug, o.assetsDir)
+	engine := html.NewFileSystem(http.FS(subFS(assets, "templates")), ".html")
+	if fromDisk {
 		engine.Reload(true)
 	}
 
@@ -49,117 +103,315 @@ func NewServer(
 	app := fiber.New(fiber.Config{
 		AppName:      "search-engine-service",
 		BodyLimit:    cfg.BodyLimit,
-		ErrorHandler: errorHandler(logger),
+		ErrorHandler: handler.ErrorHandler(logger),
 		Views:        engine,
 	})
 
+	server := &Server{
+		App:    app,
+		Logger: logger,
+		tls:    o.tls,
+	}
+
 	// Health check middleware MUST be registered BEFORE other middleware
 	// for Kubernetes probes to work even during high load
-	app.Use(middleware.NewHealthCheck(db))
+	app.Use(middleware.NewHealthCheck(db, &server.draining, cfg.ReadinessCacheTTL))
 
 	// Global middleware
 	app.Use(requestid.New())
+	app.Use(middleware.RequestContext())
+	app.Use(middleware.Metrics(o.slo))
 	app.Use(middleware.Recover(logger))
 	app.Use(middleware.Logger(logger))
-	app.Use(middleware.CORS())
-	app.Use(compress.New())
+	app.Use(middleware.SecurityHeaders(cfg.SecurityHeaders))
+	app.Use(middleware.CORS(cfg.CORS))
+	if o.compression != nil {
+		compression := *o.compression
+		// Compressing the event stream would buffer events until the connection closes;
+		// the dashboard socket takes over the connection after the upgrade
+		compression.Next = func(c *fiber.Ctx) bool {
+			return strings.HasSuffix(c.Path(), contentStreamSuffix) || c.Path() == dashboardSocketPath
+		}
+		app.Use(middleware.Compress(compression))
+	}
 
 	// Static files
-	app.Static("/static", "./web/static")
+	app.Use("/static", filesystem.New(filesystem.Config{Root: http.FS(subFS(assets, "static"))}))
 
-	// Create handlers
-	searchHandler := handler.NewSearchHandler(searchSvc, v, logger)
-	adminHandler := handler.NewAdminHandler(syncSvc, v, logger)
-	dashboardHandler := handler.NewDashboardHandler(searchSvc, logger)
+	// Create handlers, leaving out those of disabled capabilities
+	h := routeHandlers{
+		search:    handler.NewSearchHandler(searchSvc, v, logger),
+		admin:     handler.NewAdminHandler(syncSvc, searchSvc, o.cacheStats, v, logger),
+		dashboard: handler.NewDashboardHandler(searchSvc, syncSvc, logger),
+	}
+	if o.dashboardNotifier != nil {
+		h.dashboardSocket = handler.NewDashboardSocketHandler(o.dashboardNotifier, logger)
+	}
+	if o.events != nil {
+		h.stream = handler.NewStreamHandler(o.events, v, logger)
+	}
+	if o.webhooks != nil {
+		h.webhook = handler.NewWebhookHandler(o.webhooks, v, logger)
+	}
+	if o.collections != nil {
+		h.collection = handler.NewCollectionHandler(o.collections, v, logger)
+	}
+	if o.syncHistory != nil {
+		h.syncHistory = handler.NewSyncHistoryHandler(o.syncHistory, v, logger)
+	}
+	if o.settings != nil {
+		h.settings = handler.NewSettingsHandler(o.settings, v, logger)
+	}
+	if o.health != nil {
+		h.health = handler.NewHealthHandler(o.health, logger)
+	}
+	if o.audit != nil {
+		h.audit = handler.NewAuditHandler(o.audit, v, logger)
+	}
+	if o.analytics != nil {
+		h.analytics = handler.NewAnalyticsHandler(o.analytics, v, logger)
+	}
+	if o.queryAnalytics != nil {
+		h.queryAnalytics = handler.NewQueryAnalyticsHandler(o.queryAnalytics, v, logger)
+	}
+	if o.dictionary != nil {
+		h.suggest = handler.NewSuggestHandler(o.dictionary, v, logger)
+	}
+	if o.reindex != nil {
+		h.reindex = handler.NewReindexHandler(o.reindex, logger)
+	}
+	if o.purge != nil {
+		h.purge = handler.NewPurgeHandler(o.purge, v, logger)
+	}
+	if o.snapshots != nil {
+		h.snapshot = handler.NewSnapshotHandler(o.snapshots, logger)
+	}
+	if o.locks != nil {
+		h.lock = handler.NewLockHandler(o.locks, logger)
+	}
+	if o.quotas != nil {
+		h.quota = handler.NewQuotaHandler(o.quotas, v, logger)
+	}
 
 	// Register routes
-	registerRoutes(app, searchHandler, adminHandler, dashboardHandler)
+	registerRoutes(app, cfg, &o, &h, logger)
 
-	return &Server{
-		App:    app,
-		Logger: logger,
-	}
+	return server
+}
+
+// routeHandlers holds the handlers of the server's routes. Those of capabilities
+// that weren't enabled are nil.
+type routeHandlers struct {
+	search          *handler.SearchHandler
+	admin           *handler.AdminHandler
+	dashboard       *handler.DashboardHandler
+	dashboardSocket *handler.DashboardSocketHandler
+	stream          *handler.StreamHandler
+	webhook         *handler.WebhookHandler
+	collection      *handler.CollectionHandler
+	syncHistory     *handler.SyncHistoryHandler
+	settings        *handler.SettingsHandler
+	health          *handler.HealthHandler
+	audit           *handler.AuditHandler
+	analytics       *handler.AnalyticsHandler
+	queryAnalytics  *handler.QueryAnalyticsHandler
+	suggest         *handler.SuggestHandler
+	reindex         *handler.ReindexHandler
+	snapshot        *handler.SnapshotHandler
+	purge           *handler.PurgeHandler
+	lock            *handler.LockHandler
+	quota           *handler.QuotaHandler
 }
 
 // registerRoutes sets up all API routes.
-func registerRoutes(
-	app *fiber.App,
-	searchHandler *handler.SearchHandler,
-	adminHandler *handler.AdminHandler,
-	dashboardHandler *handler.DashboardHandler,
-) {
-	// Health checks are handled by middleware (/livez, /readyz)
+func registerRoutes(app *fiber.App, cfg ServerConfig, o *serverOptions, h *routeHandlers, logger *zap.Logger) {
+	// Probes are handled by middleware (/livez, /readyz); this one reports each dependency
+	if h.health != nil {
+		app.Get("/healthz/details", h.health.Details)
+	}
+
+	// Prometheus metrics
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	// Dashboard (HTML)
-	app.Get("/dashboard", dashboardHandler.Render)
+	app.Get("/dashboard", limited(cfg.SearchLimits, h.dashboard.Render)...)
+	if h.dashboardSocket != nil {
+		// Outlives the handler, so it isn't bound by the search timeout
+		app.Get(dashboardSocketPath, h.dashboardSocket.Serve)
+	}
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.Redirect("/dashboard")
 	})
 
-	// API v1 routes
-	v1 := app.Group("/api/v1")
+	// API versions share handlers until a breaking change needs a version-specific one.
+	// v2 answers errors with RFC 9457 problem details; v1 keeps the legacy error body
+	// and announces its deprecation.
+	v1 := app.Group("/api/v1", middleware.APIVersion(1), middleware.Deprecation(cfg.V1Deprecation))
+	registerAPIRoutes(v1, cfg, o, h, logger)
+
+	v2 := app.Group("/api/v2", middleware.APIVersion(2))
+	registerAPIRoutes(v2, cfg, o, h, logger)
+}
+
+// registerAPIRoutes sets up the content and admin routes of an API version group.
+func registerAPIRoutes(api fiber.Router, cfg ServerConfig, o *serverOptions, h *routeHandlers, logger *zap.Logger) {
+	// Partners call the public routes with API keys; admin routes are behind auth instead
+	if o.quotas != nil {
+		quota := middleware.Quota(o.quotas, o.quotaRequireKey, logger)
+		for _, prefix := range []string{"/contents", "/collections", "/analytics"} {
+			api.Use(prefix, quota)
+		}
+	}
 
 	// Contents
-	contents := v1.Group("/contents")
-	contents.Get("/", searchHandler.Search)
-	contents.Get("/:id", searchHandler.GetByID)
+	contents := api.Group("/contents")
+	// Authenticated callers may force a search to bypass the cache
+	contents.Get("/", append([]fiber.Handler{middleware.CacheRefresh(o.auth)}, limited(cfg.SearchLimits, h.search.Search)...)...)
+	// Registered before /:id so "facets", "suggest", "stream" and "export" aren't taken as IDs.
+	// The latter two stream past the handler, so they aren't bound by the search timeout.
+	contents.Get("/facets", limited(cfg.SearchLimits, h.search.Facets)...)
+	if h.suggest != nil {
+		contents.Get("/suggest", limited(cfg.SearchLimits, h.suggest.Suggest)...)
+	}
+	if h.stream != nil {
+		contents.Get("/stream", h.stream.Stream)
+	}
+	contents.Get("/export", h.search.Export)
+	contents.Get("/:id", limited(cfg.SearchLimits, h.search.GetByID)...)
+
+	// Collections, ordered groups of contents managed by admins
+	if h.collection != nil {
+		api.Get("/collections/:id", limited(cfg.SearchLimits, h.collection.Get)...)
+	}
+
+	// Analytics, reported by search clients like the dashboard
+	if h.analytics != nil {
+		api.Post("/analytics/events", limited(cfg.SearchLimits, h.analytics.Record)...)
+	}
 
 	// Admin routes
-	admin := v1.Group("/admin")
-	admin.Post("/sync", adminHandler.SyncAll)
-	admin.Post("/sync/:provider", adminHandler.SyncProvider)
-	admin.Get("/providers", adminHandler.GetProviders)
+	admin := api.Group("/admin")
+	if o.audit != nil {
+		// Before auth so rejected calls are recorded too, those of anonymous callers capped
+		admin.Use(middleware.Audit(o.audit, logger))
+	}
+	if o.auth != nil {
+		admin.Use(o.auth.Authenticate(), o.auth.RequireRole(o.adminRole))
+	}
+	if o.idempotency != nil {
+		// After auth so keys are scoped per subject
+		admin.Use(middleware.Idempotency(o.idempotency, o.idempotencyTTL, logger))
+	}
+	admin.Post("/sync", limited(cfg.SyncLimits, h.admin.SyncAll)...)
+	admin.Post("/sync/:provider", limited(cfg.SyncLimits, h.admin.SyncProvider)...)
+	admin.Get("/providers", limited(cfg.AdminLimits, h.admin.GetProviders)...)
+	admin.Get("/providers/health", limited(cfg.AdminLimits, h.admin.GetProvidersHealth)...)
+	admin.Patch("/providers/:provider", limited(cfg.AdminLimits, h.admin.UpdateProvider)...)
+	admin.Get("/contents/curated", limited(cfg.AdminLimits, h.admin.ListCurated)...)
+	admin.Patch("/contents/:id", limited(cfg.AdminLimits, h.admin.CurateContent)...)
+	if h.purge != nil {
+		// Before /contents/:id, which would match it
+		admin.Delete("/contents/by-external-id", limited(cfg.AdminLimits, h.purge.Purge)...)
+	}
+	admin.Delete("/contents/:id", limited(cfg.AdminLimits, h.admin.DeleteContent)...)
+	admin.Get("/cache/stats", limited(cfg.AdminLimits, h.admin.GetCacheStats)...)
+	admin.Delete("/cache", limited(cfg.AdminLimits, h.admin.ClearCache)...)
+	if h.webhook != nil {
+		admin.Post("/webhooks", limited(cfg.AdminLimits, h.webhook.Create)...)
+		admin.Get("/webhooks", limited(cfg.AdminLimits, h.webhook.List)...)
+		admin.Delete("/webhooks/:id", limited(cfg.AdminLimits, h.webhook.Delete)...)
+	}
+	if h.collection != nil {
+		admin.Post("/collections", limited(cfg.AdminLimits, h.collection.Create)...)
+		admin.Get("/collections", limited(cfg.AdminLimits, h.collection.List)...)
+		admin.Put("/collections/:id", limited(cfg.AdminLimits, h.collection.Update)...)
+		admin.Delete("/collections/:id", limited(cfg.AdminLimits, h.collection.Delete)...)
+	}
+	if h.syncHistory != nil {
+		// Time series charted on the dashboard
+		admin.Get("/metrics/ui/content-growth", limited(cfg.AdminLimits, h.syncHistory.ContentGrowth)...)
+		admin.Get("/metrics/ui/sync-runs", limited(cfg.AdminLimits, h.syncHistory.SyncRuns)...)
+		admin.Get("/metrics/ui/sync-failures", limited(cfg.AdminLimits, h.syncHistory.SyncFailures)...)
+	}
+	if h.settings != nil {
+		admin.Get("/settings", limited(cfg.AdminLimits, h.settings.Get)...)
+		admin.Patch("/settings", limited(cfg.AdminLimits, h.settings.Update)...)
+	}
+	if h.audit != nil {
+		admin.Get("/audit-logs", limited(cfg.AdminLimits, h.audit.List)...)
+	}
+	if h.queryAnalytics != nil {
+		admin.Get("/search-analytics", limited(cfg.AdminLimits, h.queryAnalytics.Report)...)
+	}
+	if h.reindex != nil {
+		admin.Post("/reindex", limited(cfg.AdminLimits, h.reindex.Start)...)
+		admin.Get("/reindex", limited(cfg.AdminLimits, h.reindex.Status)...)
+	}
+	if h.snapshot != nil {
+		admin.Get("/snapshots", limited(cfg.AdminLimits, h.snapshot.List)...)
+		admin.Post("/snapshots", limited(cfg.AdminLimits, h.snapshot.Create)...)
+		admin.Get("/snapshots/status", limited(cfg.AdminLimits, h.snapshot.Status)...)
+		admin.Post("/snapshots/:name/restore", limited(cfg.AdminLimits, h.snapshot.Restore)...)
+	}
+	if h.lock != nil {
+		admin.Get("/locks", limited(cfg.AdminLimits, h.lock.List)...)
+		admin.Delete("/locks/:key", limited(cfg.AdminLimits, h.lock.Release)...)
+	}
+	if h.quota != nil {
+		admin.Get("/quotas", limited(cfg.AdminLimits, h.quota.Usage)...)
+	}
 }
 
-// errorHandler returns a custom error handler that logs based on HTTP status code.
-// 404s are logged at DEBUG level (expected client behavior), 4xx at WARN, 5xx at ERROR.
-func errorHandler(logger *zap.Logger) fiber.ErrorHandler {
-	return func(c *fiber.Ctx, err error) error {
-		code := fiber.StatusInternalServerError
-
-		if e, ok := err.(*fiber.Error); ok {
-			code = e.Code
-		}
+// dashboardAssets returns the dashboard's templates and static files: the copies
+// embedded in the binary, or assetsDir in debug mode so edits show up without a
+// rebuild. fromDisk reports the latter.
+func dashboardAssets(debug bool, assetsDir string) (assets fs.FS, fromDisk bool) {
+	if debug && assetsDir != "" {
+		return os.DirFS(assetsDir), true
+	}
 
-		// Log based on status code - 404s are common and not server errors
-		switch {
-		case code == fiber.StatusNotFound:
-			logger.Debug("resource not found",
-				zap.String("path", c.Path()),
-				zap.String("method", c.Method()),
-			)
-		case code >= 500:
-			logger.Error("server error",
-				zap.Error(err),
-				zap.Int("status", code),
-				zap.String("path", c.Path()),
-			)
-		case code >= 400:
-			logger.Warn("client error",
-				zap.Error(err),
-				zap.Int("status", code),
-				zap.String("path", c.Path()),
-			)
-		default:
-			logger.Error("unhandled error",
-				zap.Error(err),
-				zap.Int("status", code),
-				zap.String("path", c.Path()),
-			)
-		}
+	return web.Assets, false
+}
 
-		return c.Status(code).JSON(fiber.Map{
-			"error": err.Error(),
-			"code":  "UNHANDLED_ERROR",
-		})
+// subFS returns the dir subtree of assets.
+func subFS(assets fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(assets, dir)
+	if err != nil {
+		// Only fails for invalid paths, which dir is not
+		panic(err)
 	}
+
+	return sub
 }
 
-// Start starts the HTTP server.
+// Start starts the HTTP server, serving HTTPS when WithTLS was given.
 func (s *Server) Start(port int) error {
-	s.Logger.Info("starting HTTP server", zap.Int("port", port))
+	addr := fmt.Sprintf(":%d", port)
+	if s.tls == nil {
+		s.Logger.Info("starting HTTP server", zap.Int("port", port))
+
+		return s.App.Listen(addr)
+	}
+
+	tlsConfig, err := s.tls.build()
+	if err != nil {
+		return err
+	}
+	ln, err := tls.Listen("tcp", addr, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+
+	s.Logger.Info("starting HTTPS server", zap.Int("port", port), zap.Bool("autocert", s.tls.CertFile == ""))
+
+	return s.App.Listener(ln)
+}
 
-	return s.App.Listen(fmt.Sprintf(":%d", port))
+// StartDraining makes /readyz fail while the server keeps serving requests,
+// giving load balancers time to stop routing traffic here before shutdown.
+func (s *Server) StartDraining() {
+	s.Logger.Info("draining HTTP server")
+	s.draining.Store(true)
 }
 
 // Shutdown gracefully shuts down the server.
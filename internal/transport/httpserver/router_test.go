@@ -0,0 +1,55 @@
+package httpserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/internal/app/service"
+	"search-engine-service/internal/app/service/mocks"
+	"search-engine-service/internal/transport/httpserver/middleware"
+	"search-engine-service/internal/validator"
+)
+
+func TestNewServer_RegistersEnabledCapabilities(t *testing.T) {
+	newServer := func(opts ...Option) *Server {
+		syncSvc := mocks.NewSyncUseCase(t)
+		syncSvc.On("GetProviderNames").Return([]string{"provider_a"}).Maybe()
+
+		return NewServer(ServerConfig{}, mocks.NewSearchUseCase(t), syncSvc, nil, validator.New(), zap.NewNop(), opts...)
+	}
+	status := func(server *Server, path string) int {
+		resp, err := server.App.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+
+		return resp.StatusCode
+	}
+
+	server := newServer()
+	assert.Equal(t, fiber.StatusOK, status(server, "/api/v1/admin/providers"), "admin routes are open without auth")
+	assert.Equal(t, fiber.StatusNotFound, status(server, "/api/v1/admin/webhooks"), "routes of disabled capabilities")
+	assert.Equal(t, fiber.StatusNotFound, status(server, "/healthz/details"))
+
+	auth, err := middleware.NewJWTAuth(context.Background(), middleware.JWTConfig{Secret: "secret"}, zap.NewNop())
+	require.NoError(t, err)
+	server = newServer(WithAuth(auth, "admin"))
+	assert.Equal(t, fiber.StatusUnauthorized, status(server, "/api/v1/admin/providers"))
+
+	// Routed to the purge, not to the deletion of the content with ID by-external-id
+	server = newServer(WithPurge(service.NewPurgeService(nil, nil, nil, nil, zap.NewNop())))
+	resp, err := server.App.Test(httptest.NewRequest("DELETE", "/api/v1/admin/contents/by-external-id?provider=provider_a", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, "external_id is required")
+
+	quotas, err := service.NewQuotaService(nil, []service.QuotaKey{{Name: "partner-a", Key: "key-a", Limit: 10}}, zap.NewNop())
+	require.NoError(t, err)
+	server = newServer(WithQuotas(quotas, true))
+	assert.Equal(t, fiber.StatusUnauthorized, status(server, "/api/v2/contents"), "public routes need an API key")
+	assert.Equal(t, fiber.StatusOK, status(server, "/api/v1/admin/providers"), "admin routes aren't metered")
+	assert.Equal(t, fiber.StatusBadRequest, status(server, "/api/v1/admin/quotas?month=2026-1x"))
+}
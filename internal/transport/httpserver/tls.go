@@ -0,0 +1,63 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig enables HTTPS on the server, either from certificate files or with
+// certificates obtained from an ACME CA (Let's Encrypt) for AutocertHosts.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+
+	// Used when CertFile is empty. Certificates are obtained through the TLS-ALPN-01
+	// challenge, so the server must be reachable on port 443 under each host.
+	AutocertHosts    []string
+	AutocertCacheDir string // Keeps certificates across restarts (empty keeps them in memory only)
+	AutocertEmail    string // Contact for expiry and revocation notices
+}
+
+// errNoCertificate is returned when TLS is enabled without a certificate source.
+var errNoCertificate = errors.New("tls needs cert_file and key_file or autocert hosts")
+
+// build returns the crypto/tls configuration for t.
+// Fiber (fasthttp) only speaks HTTP/1.1, so h2 is never offered through ALPN;
+// HTTP/2 clients negotiate HTTP/1.1 instead.
+func (t *TLSConfig) build() (*tls.Config, error) {
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading tls certificate: %w", err)
+		}
+
+		return &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+			NextProtos:   []string{"http/1.1"},
+		}, nil
+	}
+
+	if len(t.AutocertHosts) == 0 {
+		return nil, errNoCertificate
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(t.AutocertHosts...),
+		Email:      t.AutocertEmail,
+	}
+	if t.AutocertCacheDir != "" {
+		manager.Cache = autocert.DirCache(t.AutocertCacheDir)
+	}
+
+	cfg := manager.TLSConfig()
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.NextProtos = []string{"http/1.1", acme.ALPNProto}
+
+	return cfg, nil
+}
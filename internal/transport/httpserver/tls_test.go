@@ -0,0 +1,77 @@
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+)
+
+// writeSelfSignedCert writes a certificate and key for localhost to dir.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestTLSConfig_LoadsCertificateFiles(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+
+	cfg, err := (&TLSConfig{CertFile: certFile, KeyFile: keyFile}).build()
+	require.NoError(t, err)
+
+	assert.Len(t, cfg.Certificates, 1)
+	assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	assert.Equal(t, []string{"http/1.1"}, cfg.NextProtos)
+}
+
+func TestTLSConfig_MissingCertificateFile(t *testing.T) {
+	_, err := (&TLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"}).build()
+
+	assert.ErrorContains(t, err, "loading tls certificate")
+}
+
+func TestTLSConfig_AutocertDoesNotOfferHTTP2(t *testing.T) {
+	cfg, err := (&TLSConfig{AutocertHosts: []string{"search.example.com"}}).build()
+	require.NoError(t, err)
+
+	assert.NotNil(t, cfg.GetCertificate)
+	assert.Equal(t, []string{"http/1.1", acme.ALPNProto}, cfg.NextProtos)
+}
+
+func TestTLSConfig_RequiresCertificateSource(t *testing.T) {
+	_, err := (&TLSConfig{}).build()
+
+	assert.ErrorIs(t, err, errNoCertificate)
+}
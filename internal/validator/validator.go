@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+
+	"search-engine-service/internal/domain"
 )
 
 // Validator wraps the go-playground validator with custom configuration.
@@ -14,32 +16,12 @@ type Validator struct {
 	v *validator.Validate
 }
 
-// ValidationError represents a single field validation error.
-type ValidationError struct {
-	Field   string `json:"field"`
-	Tag     string `json:"tag"`
-	Value   string `json:"value,omitempty"`
-	Message string `json:"message"`
-}
+// ValidationError represents a single field validation error. Request validation
+// and domain invariants (e.g. domain.Content.Validate) report the same errors.
+type ValidationError = domain.ValidationError
 
 // ValidationErrors is a collection of validation errors.
-type ValidationErrors []ValidationError
-
-// Error implements the error interface.
-func (ve ValidationErrors) Error() string {
-	if len(ve) == 0 {
-		return ""
-	}
-	var sb strings.Builder
-	for i, e := range ve {
-		if i > 0 {
-			sb.WriteString("; ")
-		}
-		sb.WriteString(e.Message)
-	}
-
-	return sb.String()
-}
+type ValidationErrors = domain.ValidationErrors
 
 // New creates a new Validator instance with custom tag name and validations.
 func New() *Validator {
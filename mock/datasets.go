@@ -0,0 +1,15 @@
+// Package mock holds the datasets served by the mock providers, embedded so the
+// admin CLI can seed a database without running the mock servers.
+package mock
+
+import _ "embed"
+
+// ProviderA is the JSON payload served by the Provider A mock.
+//
+//go:embed provider_a/data.json
+var ProviderA []byte
+
+// ProviderB is the XML payload served by the Provider B mock.
+//
+//go:embed provider_b/data.xml
+var ProviderB []byte
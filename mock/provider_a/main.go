@@ -2,28 +2,41 @@ package main
 
 import (
 	_ "embed"
+	"encoding/json"
 	"log"
+	"maps"
 	"net/http"
+	"strings"
 	"time"
 )
 
 //go:embed data.json
 var jsonData []byte
 
-func main() {
-	http.HandleFunc("/api/contents", func(w http.ResponseWriter, r *http.Request) {
-		// Simulate network latency (50-200ms)
-		time.Sleep(time.Duration(50+time.Now().UnixNano()%150) * time.Millisecond)
+// feed is the response of /api/contents. Contents are kept generic so they're
+// served back as loaded.
+type feed struct {
+	Contents   []map[string]any `json:"contents"`
+	Pagination pagination       `json:"pagination"`
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("X-Provider", "provider-a")
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write(jsonData); err != nil {
-			log.Printf("[Provider A] Write error: %v", err)
-		}
+type pagination struct {
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
 
-		log.Printf("[Provider A] %s %s - 200 OK", r.Method, r.URL.Path)
-	})
+func main() {
+	var base feed
+	if err := json.Unmarshal(jsonData, &base); err != nil {
+		log.Fatalf("[Provider A] Invalid data.json: %v", err)
+	}
+	defaults, err := scenarioFromEnv()
+	if err != nil {
+		log.Fatalf("[Provider A] Invalid scenario: %v", err)
+	}
+
+	http.HandleFunc("/api/contents", contentsHandler(base.Contents, defaults))
 
 	http.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -41,3 +54,116 @@ func main() {
 	}
 	log.Fatal(server.ListenAndServe())
 }
+
+// contentsHandler serves the base contents, shaped and disrupted by the scenario of the
+// request: defaults overridden by its query parameters.
+func contentsHandler(base []map[string]any, defaults scenario) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sc, err := defaults.withQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			log.Printf("[Provider A] %s %s - 400 %v", r.Method, r.URL.Path, err)
+
+			return
+		}
+
+		// Simulate network latency (50-200ms)
+		time.Sleep(time.Duration(50+time.Now().UnixNano()%150)*time.Millisecond + sc.Delay)
+
+		if status, fail := sc.failure(); fail {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":"injected failure"}`))
+			log.Printf("[Provider A] %s %s - %d (injected)", r.Method, r.URL.Path, status)
+
+			return
+		}
+
+		body, err := json.Marshal(contents(base, sc))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Provider", "provider-a")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(sc.corrupt(body)); err != nil {
+			log.Printf("[Provider A] Write error: %v", err)
+		}
+
+		log.Printf("[Provider A] %s %s - 200 OK", r.Method, r.URL.Path)
+	}
+}
+
+// contents builds the page of the dataset requested by sc.
+func contents(base []map[string]any, sc scenario) feed {
+	var page []map[string]any
+	var total, pageNum, perPage int
+	if sc.Generate > 0 {
+		var start, end int
+		start, end, pageNum, perPage = pageBounds(sc.Generate, sc.Page, sc.PerPage)
+		for i := start; i < end; i++ {
+			page = append(page, toContent(sc.generate(i)))
+		}
+		total = sc.Generate
+	} else {
+		items := dataset(base, sc.Count, func(item map[string]any, suffix string) map[string]any {
+			item = maps.Clone(item)
+			item["id"] = item["id"].(string) + suffix
+
+			return item
+		})
+		page, pageNum, perPage = paginate(items, sc.Page, sc.PerPage)
+		total = len(items)
+	}
+
+	resp := feed{
+		Contents:   make([]map[string]any, len(page)),
+		Pagination: pagination{Total: total, Page: pageNum, PerPage: perPage},
+	}
+	for i, item := range page {
+		if sc.Malformed == malformedInvalid && i%2 == 1 {
+			item = maps.Clone(item)
+			item["id"] = ""
+			metrics, _ := item["metrics"].(map[string]any)
+			metrics = maps.Clone(metrics)
+			if metrics == nil {
+				metrics = map[string]any{}
+			}
+			metrics["views"] = -1
+			item["metrics"] = metrics
+		}
+		resp.Contents[i] = item
+	}
+
+	return resp
+}
+
+// toContent maps a generated item onto a content of the feed.
+func toContent(g generated) map[string]any {
+	metrics := map[string]any{}
+	switch g.Type {
+	case "video":
+		metrics["views"], metrics["likes"], metrics["duration"] = g.Views, g.Likes, clock(g.DurationSeconds)
+	case "podcast":
+		metrics["listens"], metrics["likes"], metrics["duration"] = g.Listens, g.Likes, clock(g.DurationSeconds)
+	case "image":
+		metrics["views"], metrics["likes"] = g.Views, g.Likes
+	}
+
+	slug := strings.ToLower(strings.ReplaceAll(g.Title, " ", "-"))
+
+	return map[string]any{
+		"id":            g.ID,
+		"title":         g.Title,
+		"type":          g.Type,
+		"metrics":       metrics,
+		"published_at":  g.PublishedAt.Format(time.RFC3339),
+		"tags":          g.Tags,
+		"url":           "https://provider-a.example.com/" + g.Type + "s/" + slug,
+		"thumbnail_url": "https://provider-a.example.com/" + g.Type + "s/" + slug + ".jpg",
+		"author":        g.Author,
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Mocks build on their own, so tests stick to the standard library.
+
+func TestScenario_WithQuery(t *testing.T) {
+	defaults := scenario{ErrorRate: 0.5, Delay: time.Second}
+
+	sc, err := defaults.withQuery(url.Values{"status": {"429"}, "delay": {"10ms"}, "count": {"20"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := scenario{ErrorRate: 0.5, Status: 429, Delay: 10 * time.Millisecond, Count: 20}
+	if sc != want {
+		t.Errorf("expected %+v, got %+v", want, sc)
+	}
+
+	for _, query := range []url.Values{
+		{"error_rate": {"2"}},
+		{"status": {"42"}},
+		{"delay": {"soon"}},
+		{"malformed": {"garbled"}},
+		{"per_page": {"-1"}},
+	} {
+		if _, err := defaults.withQuery(query); err == nil {
+			t.Errorf("expected an error for %v", query)
+		}
+	}
+}
+
+func TestScenario_FailureSpreadsErrorRate(t *testing.T) {
+	requests.Store(0)
+
+	sc := scenario{ErrorRate: 0.25, Status: http.StatusServiceUnavailable}
+	var failed []int
+	for n := 1; n <= 8; n++ {
+		if status, fail := sc.failure(); fail {
+			if status != http.StatusServiceUnavailable {
+				t.Errorf("expected status 503, got %d", status)
+			}
+			failed = append(failed, n)
+		}
+	}
+	if len(failed) != 2 || failed[0] != 4 || failed[1] != 8 {
+		t.Errorf("expected requests 4 and 8 to fail, got %v", failed)
+	}
+
+	// A status alone fails every request
+	if _, fail := (scenario{Status: http.StatusTooManyRequests}).failure(); !fail {
+		t.Error("expected the request to fail")
+	}
+	if _, fail := (scenario{}).failure(); fail {
+		t.Error("expected the request to succeed")
+	}
+}
+
+func TestContents_CountPaginationAndInvalidItems(t *testing.T) {
+	base := []map[string]any{
+		{"id": "v1", "metrics": map[string]any{"views": 10}},
+		{"id": "v2", "metrics": map[string]any{"views": 20}},
+	}
+
+	resp := contents(base, scenario{Count: 5, Page: 2, PerPage: 2, Malformed: malformedInvalid})
+
+	if resp.Pagination != (pagination{Total: 5, Page: 2, PerPage: 2}) {
+		t.Errorf("unexpected pagination %+v", resp.Pagination)
+	}
+	if len(resp.Contents) != 2 {
+		t.Fatalf("expected 2 contents, got %d", len(resp.Contents))
+	}
+	if resp.Contents[0]["id"] != "v1-1" {
+		t.Errorf("expected the copy v1-1 first, got %v", resp.Contents[0]["id"])
+	}
+	if resp.Contents[1]["id"] != "" || resp.Contents[1]["metrics"].(map[string]any)["views"] != -1 {
+		t.Errorf("expected the second content to be invalid, got %v", resp.Contents[1])
+	}
+	if base[1]["id"] != "v2" || base[1]["metrics"].(map[string]any)["views"] != 20 {
+		t.Errorf("expected the base contents to be left alone, got %v", base[1])
+	}
+}
+
+func TestScenario_GenerateIsSeeded(t *testing.T) {
+	sc := scenario{Generate: 100, Seed: 7, Days: 30}
+
+	for i := range 100 {
+		a, b := sc.generate(i), sc.generate(i)
+		if a.Title != b.Title || a.Type != b.Type || a.Views != b.Views || !a.PublishedAt.Equal(b.PublishedAt) {
+			t.Fatalf("expected item %d to be the same for the same seed: %+v, %+v", i, a, b)
+		}
+
+		age := time.Since(a.PublishedAt)
+		if age <= 0 || age > 31*24*time.Hour {
+			t.Errorf("expected item %d to be published within 30 days, got %v", i, a.PublishedAt)
+		}
+		if a.Views < 0 || a.Listens < 0 || a.Likes < 0 || a.Reactions < 0 || a.Comments < 0 {
+			t.Errorf("expected item %d to have no negative metrics: %+v", i, a)
+		}
+	}
+
+	other := scenario{Generate: 100, Seed: 8, Days: 30}
+	if sc.generate(0).Title == other.generate(0).Title && sc.generate(1).Title == other.generate(1).Title &&
+		sc.generate(2).Title == other.generate(2).Title {
+		t.Error("expected another seed to generate other items")
+	}
+}
+
+func TestScenario_GenerateFollowsTypeWeights(t *testing.T) {
+	weights, err := parseTypeWeights("podcast=1,image=3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sc := scenario{Generate: 1000, Types: weights}
+
+	counts := map[string]int{}
+	for i := range 1000 {
+		counts[sc.generate(i).Type]++
+	}
+	if counts["video"] != 0 || counts["article"] != 0 {
+		t.Errorf("expected only podcasts and images, got %v", counts)
+	}
+	if counts["image"] < 2*counts["podcast"] {
+		t.Errorf("expected about 3 images per podcast, got %v", counts)
+	}
+
+	for _, v := range []string{"video=0", "movie=1", "video=-1", "video"} {
+		if _, err := parseTypeWeights(v); err == nil {
+			t.Errorf("expected an error for %q", v)
+		}
+	}
+}
+
+func TestContents_GeneratesOnlyThePage(t *testing.T) {
+	resp := contents(nil, scenario{Generate: 250_000, Page: 3, PerPage: 10})
+
+	if resp.Pagination != (pagination{Total: 250_000, Page: 3, PerPage: 10}) {
+		t.Errorf("unexpected pagination %+v", resp.Pagination)
+	}
+	if len(resp.Contents) != 10 || resp.Contents[0]["id"] != "gen-20" {
+		t.Errorf("expected items gen-20 to gen-29, got %d starting with %v", len(resp.Contents), resp.Contents[0]["id"])
+	}
+}
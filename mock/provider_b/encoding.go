@@ -0,0 +1,140 @@
+package main
+
+// Response encodings of the Provider B mock, so the service's decompression and
+// charset conversion can be tested against it. Provider B alone sends XML, whose
+// charset can differ from UTF-8, so unlike scenarios these are not copied in both
+// mocks.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Content encodings and charsets the mock can send.
+const (
+	contentEncodingGzip    = "gzip"
+	contentEncodingDeflate = "deflate" // zlib-wrapped, as most servers send it
+	charsetLatin5          = "iso-8859-9"
+)
+
+// latin5 maps the Turkish letters of ISO-8859-9 onto their bytes; it is Latin-1
+// (the first 256 code points) otherwise.
+var latin5 = map[rune]byte{'Ğ': 0xD0, 'İ': 0xDD, 'Ş': 0xDE, 'ğ': 0xF0, 'ı': 0xFD, 'ş': 0xFE}
+
+// encoding controls how the feed is sent. Like scenarios, defaults come from
+// MOCK_CONTENT_ENCODING and MOCK_CHARSET, overridden per request by the
+// content_encoding and charset query parameters.
+type encoding struct {
+	ContentEncoding string // gzip or deflate compresses the body
+	Charset         string // iso-8859-9 sends the body in Latin-5 instead of UTF-8
+}
+
+// encodingFromEnv reads the default encoding from the environment.
+func encodingFromEnv() (encoding, error) {
+	return encoding{}.with(func(name string) string {
+		return os.Getenv("MOCK_" + strings.ToUpper(name))
+	})
+}
+
+// withQuery returns e overridden by the encoding parameters of query.
+func (e encoding) withQuery(query url.Values) (encoding, error) {
+	return e.with(query.Get)
+}
+
+// with returns e overridden by the non-empty values of lookup.
+func (e encoding) with(lookup func(name string) string) (encoding, error) {
+	var errs []error
+	if v := strings.ToLower(lookup("content_encoding")); v != "" {
+		if v != contentEncodingGzip && v != contentEncodingDeflate {
+			errs = append(errs, fmt.Errorf("content_encoding: must be %s or %s", contentEncodingGzip, contentEncodingDeflate))
+		}
+		e.ContentEncoding = v
+	}
+	if v := strings.ToLower(lookup("charset")); v != "" {
+		if v != charsetLatin5 && v != "utf-8" {
+			errs = append(errs, fmt.Errorf("charset: must be utf-8 or %s", charsetLatin5))
+		}
+		e.Charset = v
+	}
+
+	return e, errors.Join(errs...)
+}
+
+// contentType returns the Content-Type of the feed.
+func (e encoding) contentType() string {
+	if e.Charset == charsetLatin5 {
+		return "application/xml; charset=ISO-8859-9"
+	}
+
+	return "application/xml; charset=utf-8"
+}
+
+// declaration returns the XML declaration of the feed.
+func (e encoding) declaration() string {
+	if e.Charset == charsetLatin5 {
+		return `<?xml version="1.0" encoding="ISO-8859-9"?>` + "\n"
+	}
+
+	return `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+}
+
+// encode converts doc, in UTF-8, to the charset of e, then compresses it.
+func (e encoding) encode(doc []byte) ([]byte, error) {
+	if e.Charset == charsetLatin5 {
+		doc = toLatin5(doc)
+	}
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch e.ContentEncoding {
+	case contentEncodingGzip:
+		w = gzip.NewWriter(&buf)
+	case contentEncodingDeflate:
+		w = zlib.NewWriter(&buf)
+	default:
+		return doc, nil
+	}
+	if _, err := w.Write(doc); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// toLatin5 converts UTF-8 text to ISO-8859-9, replacing the characters it lacks
+// (and invalid UTF-8) with '?'.
+func toLatin5(text []byte) []byte {
+	replaced := make(map[byte]bool, len(latin5)) // Latin-1 characters Latin-5 has replaced
+	for _, b := range latin5 {
+		replaced[b] = true
+	}
+
+	out := make([]byte, 0, len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+
+		b, ok := latin5[r]
+		switch {
+		case ok:
+			out = append(out, b)
+		case r < 0x100 && r != utf8.RuneError && !replaced[byte(r)]:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+
+	return out
+}
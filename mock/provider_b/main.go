@@ -2,28 +2,67 @@ package main
 
 import (
 	_ "embed"
+	"encoding/xml"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
 //go:embed data.xml
 var xmlData []byte
 
-func main() {
-	http.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
-		// Simulate network latency (100-300ms)
-		time.Sleep(time.Duration(100+time.Now().UnixNano()%200) * time.Millisecond)
+// feed is the response of /feed.
+type feed struct {
+	XMLName xml.Name `xml:"feed"`
+	Items   []item   `xml:"items>item"`
+	Meta    meta     `xml:"meta"`
+}
 
-		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
-		w.Header().Set("X-Provider", "provider-b")
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write(xmlData); err != nil {
-			log.Printf("[Provider B] Write error: %v", err)
-		}
+type item struct {
+	ID              string   `xml:"id"`
+	Headline        string   `xml:"headline"`
+	Type            string   `xml:"type"`
+	Stats           stats    `xml:"stats"`
+	Link            string   `xml:"link"`
+	Thumbnail       string   `xml:"thumbnail"`
+	Author          string   `xml:"author"`
+	PublicationDate string   `xml:"publication_date"`
+	Categories      []string `xml:"categories>category"`
+}
 
-		log.Printf("[Provider B] %s %s - 200 OK", r.Method, r.URL.Path)
-	})
+type stats struct {
+	Views       int    `xml:"views,omitempty"`
+	Likes       int    `xml:"likes,omitempty"`
+	Duration    string `xml:"duration,omitempty"`
+	Listens     int    `xml:"listens,omitempty"`
+	ReadingTime int    `xml:"reading_time,omitempty"`
+	Reactions   int    `xml:"reactions,omitempty"`
+	Comments    int    `xml:"comments,omitempty"`
+}
+
+type meta struct {
+	TotalCount   int `xml:"total_count"`
+	CurrentPage  int `xml:"current_page"`
+	ItemsPerPage int `xml:"items_per_page"`
+}
+
+func main() {
+	var base feed
+	if err := xml.Unmarshal(xmlData, &base); err != nil {
+		log.Fatalf("[Provider B] Invalid data.xml: %v", err)
+	}
+	defaults, err := scenarioFromEnv()
+	if err != nil {
+		log.Fatalf("[Provider B] Invalid scenario: %v", err)
+	}
+	defaultEncoding, err := encodingFromEnv()
+	if err != nil {
+		log.Fatalf("[Provider B] Invalid encoding: %v", err)
+	}
+
+	http.HandleFunc("/feed", feedHandler(base.Items, defaults, defaultEncoding))
 
 	http.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -41,3 +80,120 @@ func main() {
 	}
 	log.Fatal(server.ListenAndServe())
 }
+
+// feedHandler serves the base contents, shaped and disrupted by the scenario of the
+// request and sent in its encoding: defaults overridden by its query parameters.
+func feedHandler(base []item, defaults scenario, defaultEncoding encoding) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sc, scErr := defaults.withQuery(r.URL.Query())
+		enc, encErr := defaultEncoding.withQuery(r.URL.Query())
+		if err := errors.Join(scErr, encErr); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			log.Printf("[Provider B] %s %s - 400 %v", r.Method, r.URL.Path, err)
+
+			return
+		}
+
+		// Simulate network latency (100-300ms)
+		time.Sleep(time.Duration(100+time.Now().UnixNano()%200)*time.Millisecond + sc.Delay)
+
+		if status, fail := sc.failure(); fail {
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`<error>injected failure</error>`))
+			log.Printf("[Provider B] %s %s - %d (injected)", r.Method, r.URL.Path, status)
+
+			return
+		}
+
+		body, err := xml.MarshalIndent(items(base, sc), "", "    ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		body, err = enc.encode(sc.corrupt(append([]byte(enc.declaration()), body...)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", enc.contentType())
+		if enc.ContentEncoding != "" {
+			w.Header().Set("Content-Encoding", enc.ContentEncoding)
+		}
+		w.Header().Set("X-Provider", "provider-b")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			log.Printf("[Provider B] Write error: %v", err)
+		}
+
+		log.Printf("[Provider B] %s %s - 200 OK", r.Method, r.URL.Path)
+	}
+}
+
+// items builds the page of the dataset requested by sc.
+func items(base []item, sc scenario) feed {
+	var page []item
+	var total, pageNum, perPage int
+	if sc.Generate > 0 {
+		var start, end int
+		start, end, pageNum, perPage = pageBounds(sc.Generate, sc.Page, sc.PerPage)
+		for i := start; i < end; i++ {
+			page = append(page, toItem(sc.generate(i)))
+		}
+		total = sc.Generate
+	} else {
+		all := dataset(base, sc.Count, func(it item, suffix string) item {
+			it.ID += suffix
+
+			return it
+		})
+		page, pageNum, perPage = paginate(all, sc.Page, sc.PerPage)
+		total = len(all)
+	}
+
+	resp := feed{
+		Items: make([]item, len(page)),
+		Meta:  meta{TotalCount: total, CurrentPage: pageNum, ItemsPerPage: perPage},
+	}
+	for i, it := range page {
+		if sc.Malformed == malformedInvalid && i%2 == 1 {
+			it.ID = ""
+			it.Stats.Views = -1
+		}
+		resp.Items[i] = it
+	}
+
+	return resp
+}
+
+// toItem maps a generated item onto an item of the feed.
+func toItem(g generated) item {
+	slug := strings.ToLower(strings.ReplaceAll(g.Title, " ", "-"))
+	it := item{
+		ID:              g.ID,
+		Headline:        g.Title,
+		Type:            g.Type,
+		Link:            "https://provider-b.example.com/" + g.Type + "s/" + slug,
+		Thumbnail:       "https://provider-b.example.com/" + g.Type + "s/" + slug + ".jpg",
+		Author:          g.Author,
+		PublicationDate: g.PublishedAt.Format("2006-01-02"),
+		Categories:      g.Tags,
+		Stats: stats{
+			Views:       g.Views,
+			Likes:       g.Likes,
+			Listens:     g.Listens,
+			ReadingTime: g.ReadingTime,
+			Reactions:   g.Reactions,
+			Comments:    g.Comments,
+		},
+	}
+	if g.DurationSeconds > 0 {
+		it.Stats.Duration = clock(g.DurationSeconds)
+	}
+
+	return it
+}
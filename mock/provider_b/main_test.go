@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Mocks build on their own, so tests stick to the standard library.
+
+func TestFeedHandler_Scenarios(t *testing.T) {
+	var base feed
+	if err := xml.Unmarshal(xmlData, &base); err != nil {
+		t.Fatalf("invalid data.xml: %v", err)
+	}
+	handler := feedHandler(base.Items, scenario{}, encoding{})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/feed?"+query, nil))
+
+		return rec
+	}
+
+	t.Run("pages of a larger dataset", func(t *testing.T) {
+		rec := get("count=12&page=3&per_page=5")
+
+		var resp feed
+		if err := xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid feed: %v", err)
+		}
+		if resp.Meta != (meta{TotalCount: 12, CurrentPage: 3, ItemsPerPage: 5}) {
+			t.Errorf("unexpected meta %+v", resp.Meta)
+		}
+		if len(resp.Items) != 2 || resp.Items[0].ID != base.Items[0].ID+"-2" {
+			t.Errorf("expected the last 2 items, starting with the third copy, got %+v", resp.Items)
+		}
+	})
+
+	t.Run("injected status", func(t *testing.T) {
+		if rec := get("status=503"); rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("expected 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("truncated payload", func(t *testing.T) {
+		var resp feed
+		if err := xml.Unmarshal(get("malformed=truncated").Body.Bytes(), &resp); err == nil {
+			t.Error("expected the payload not to parse")
+		}
+	})
+
+	t.Run("invalid scenario", func(t *testing.T) {
+		if rec := get("error_rate=abc"); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestFeedHandler_Encoding(t *testing.T) {
+	base := []item{{ID: "tr-1", Headline: "Şişli'de Çay Ğ İ ı €", Type: "article"}}
+	handler := feedHandler(base, scenario{}, encoding{})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/feed?content_encoding=gzip&charset=iso-8859-9", nil))
+
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected a gzip response, got %q", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/xml; charset=ISO-8859-9" {
+		t.Errorf("unexpected Content-Type %q", got)
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("invalid gzip: %v", err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("invalid gzip: %v", err)
+	}
+
+	if !bytes.HasPrefix(body, []byte(`<?xml version="1.0" encoding="ISO-8859-9"?>`)) {
+		t.Errorf("expected a Latin-5 declaration, got %q", body[:min(len(body), 50)])
+	}
+	// Ş i ş l i ' d e, Ç a y, Ğ, İ, ı; € is missing from Latin-5
+	if want := []byte("\xDEi\xFEli&#39;de \xC7ay \xD0 \xDD \xFD ?"); !bytes.Contains(body, want) {
+		t.Errorf("expected the headline in Latin-5 %q, got %q", want, body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/feed?charset=koi8-r", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown charset, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+// Fault injection and generated datasets for the mock providers, so integration and
+// chaos tests can drive the service's retry and circuit breaker paths, and load tests
+// can run against large corpora. Each mock builds on its own (see its Dockerfile), so
+// this file is copied in both rather than shared.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Malformed payload kinds.
+const (
+	malformedTruncated = "truncated" // The body is cut in half, so it doesn't parse
+	malformedInvalid   = "invalid"   // Every second item has an empty ID and negative metrics
+)
+
+// scenario controls how a request is answered. Defaults come from the MOCK_*
+// environment variables; each is overridden per request by the query parameter
+// named like the variable without the prefix, in lower case (MOCK_ERROR_RATE is
+// error_rate).
+type scenario struct {
+	ErrorRate float64       // Fraction of requests failing with Status, spread evenly (0-1)
+	Status    int           // Status code of failures; set alone, every request fails with it
+	Delay     time.Duration // Latency added to every response
+	Malformed string        // Payload corruption: truncated or invalid
+	Count     int           // Dataset size, repeating the base items with new IDs (0 keeps them)
+	Page      int           // 1-based page of the dataset
+	PerPage   int           // Items per page (0 returns every item)
+
+	// Generator: random items replace the embedded ones
+	Generate int         // Items to generate (0 serves the embedded items)
+	Seed     uint64      // Items are derived from the seed, so the same seed serves the same items
+	Types    typeWeights // Relative frequency of each type (all zero picks them evenly)
+	Days     int         // Publish dates are spread over this many days before today (0 is 365)
+	Views    int         // Median views and listens; most items get fewer, a few many more (0 is 5000)
+}
+
+// maxGenerate bounds the generated dataset.
+const maxGenerate = 1_000_000
+
+// contentTypes are the types of generated items, in the order of typeWeights.
+var contentTypes = [...]string{"video", "article", "podcast", "image"}
+
+// typeWeights holds a weight per content type, parsed from e.g. "video=3,article=1".
+type typeWeights [len(contentTypes)]int
+
+// requests numbers the requests answered, spreading failures deterministically.
+var requests atomic.Int64
+
+// scenarioFromEnv reads the default scenario from the environment.
+func scenarioFromEnv() (scenario, error) {
+	return scenario{}.with(func(name string) string {
+		return os.Getenv("MOCK_" + strings.ToUpper(name))
+	})
+}
+
+// withQuery returns s overridden by the scenario parameters of query.
+func (s scenario) withQuery(query url.Values) (scenario, error) {
+	return s.with(query.Get)
+}
+
+// with returns s overridden by the non-empty values of lookup.
+func (s scenario) with(lookup func(name string) string) (scenario, error) {
+	var errs []error
+	parse := func(name string, set func(string) error) {
+		if v := lookup(name); v != "" {
+			if err := set(v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+	nonNegative := func(dst *int) func(string) error {
+		return func(v string) error {
+			n, err := strconv.Atoi(v)
+			if err == nil && n < 0 {
+				err = errors.New("must not be negative")
+			}
+			*dst = n
+
+			return err
+		}
+	}
+
+	parse("error_rate", func(v string) error {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err == nil && (rate < 0 || rate > 1) {
+			err = errors.New("must be between 0 and 1")
+		}
+		s.ErrorRate = rate
+
+		return err
+	})
+	parse("status", func(v string) error {
+		status, err := strconv.Atoi(v)
+		if err == nil && (status < 100 || status > 599) {
+			err = errors.New("must be an HTTP status code")
+		}
+		s.Status = status
+
+		return err
+	})
+	parse("delay", func(v string) (err error) {
+		s.Delay, err = time.ParseDuration(v)
+
+		return err
+	})
+	parse("malformed", func(v string) error {
+		if v != malformedTruncated && v != malformedInvalid {
+			return fmt.Errorf("must be %s or %s", malformedTruncated, malformedInvalid)
+		}
+		s.Malformed = v
+
+		return nil
+	})
+	parse("count", nonNegative(&s.Count))
+	parse("page", nonNegative(&s.Page))
+	parse("per_page", nonNegative(&s.PerPage))
+	parse("generate", func(v string) error {
+		err := nonNegative(&s.Generate)(v)
+		if err == nil && s.Generate > maxGenerate {
+			err = fmt.Errorf("must be at most %d", maxGenerate)
+		}
+
+		return err
+	})
+	parse("seed", func(v string) (err error) {
+		s.Seed, err = strconv.ParseUint(v, 10, 64)
+
+		return err
+	})
+	parse("types", func(v string) (err error) {
+		s.Types, err = parseTypeWeights(v)
+
+		return err
+	})
+	parse("days", nonNegative(&s.Days))
+	parse("views", nonNegative(&s.Views))
+
+	return s, errors.Join(errs...)
+}
+
+// failure reports whether this request must fail, and with which status code.
+func (s scenario) failure() (int, bool) {
+	rate := s.ErrorRate
+	if rate == 0 && s.Status != 0 {
+		rate = 1
+	}
+	status := s.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	// The n-th request fails when it takes the failures so far past n × rate
+	n := float64(requests.Add(1))
+	fail := math.Floor(n*rate) > math.Floor((n-1)*rate)
+
+	return status, fail
+}
+
+// corrupt applies the truncated payload kind to body.
+func (s scenario) corrupt(body []byte) []byte {
+	if s.Malformed == malformedTruncated {
+		return body[:len(body)/2]
+	}
+
+	return body
+}
+
+// dataset repeats base up to count items, giving copies new IDs with clone.
+func dataset[T any](base []T, count int, clone func(item T, suffix string) T) []T {
+	if count == 0 || len(base) == 0 {
+		return base
+	}
+
+	items := make([]T, count)
+	for i := range items {
+		item := base[i%len(base)]
+		if copyNum := i / len(base); copyNum > 0 {
+			item = clone(item, "-"+strconv.Itoa(copyNum))
+		}
+		items[i] = item
+	}
+
+	return items
+}
+
+// pageBounds returns the index range of the page of total items, and the page number
+// and size it used.
+func pageBounds(total, page, perPage int) (start, end, pageNum, size int) {
+	if perPage == 0 {
+		perPage = total
+	}
+	if page == 0 {
+		page = 1
+	}
+
+	start = min((page-1)*perPage, total)
+	end = min(start+perPage, total)
+
+	return start, end, page, perPage
+}
+
+// paginate returns the page of items, and the page number and size it used.
+func paginate[T any](items []T, page, perPage int) (pageItems []T, pageNum, size int) {
+	start, end, pageNum, size := pageBounds(len(items), page, perPage)
+
+	return items[start:end], pageNum, size
+}
+
+// parseTypeWeights parses comma-separated type=weight pairs; unlisted types get 0.
+func parseTypeWeights(v string) (typeWeights, error) {
+	var weights typeWeights
+	total := 0
+	for pair := range strings.SplitSeq(v, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		i := slices.Index(contentTypes[:], name)
+		weight, err := strconv.Atoi(value)
+		if i < 0 || err != nil || weight < 0 {
+			return typeWeights{}, fmt.Errorf("%q is not a type=weight pair of %s", pair, strings.Join(contentTypes[:], ", "))
+		}
+		weights[i] = weight
+		total += weight
+	}
+	if total == 0 {
+		return typeWeights{}, errors.New("at least one weight must be positive")
+	}
+
+	return weights, nil
+}
+
+// generated is a random item, mapped by each mock onto its payload.
+type generated struct {
+	ID              string
+	Title           string
+	Type            string
+	Author          string
+	Tags            []string
+	PublishedAt     time.Time
+	DurationSeconds int // Video, podcast
+	Views           int // Video, image
+	Listens         int // Podcast
+	Likes           int // Video, podcast, image
+	ReadingTime     int // Article, in minutes
+	Reactions       int // Article
+	Comments        int // Article
+}
+
+// Words generated titles, tags and authors are made of.
+var (
+	generatedTopics     = []string{"Go", "Kubernetes", "Docker", "PostgreSQL", "Redis", "Linux", "Rust", "Security", "Testing", "Observability"}
+	generatedAdjectives = []string{"Practical", "Advanced", "Modern", "Hands-on", "Essential", "Scalable", "Effective"}
+	generatedNouns      = []string{"Tutorial", "Patterns", "Deep Dive", "Guide", "Tips", "Workshop", "Case Study"}
+	generatedAuthors    = []string{"Gopher Academy", "Jane Doe", "Alex Kim", "Go Time", "Cloud Native Weekly"}
+)
+
+// generate returns the i-th generated item. Each item is derived from the seed and
+// its index alone, so pages are generated without the rest of the dataset.
+func (s scenario) generate(i int) generated {
+	rng := rand.New(rand.NewPCG(s.Seed, uint64(i)))
+
+	days := s.Days
+	if days == 0 {
+		days = 365
+	}
+	median := s.Views
+	if median == 0 {
+		median = 5000
+	}
+	// Log-normal: the median is typical, a long tail of items is far more popular
+	popularity := func() int {
+		return int(float64(median) * math.Exp(1.5*rng.NormFloat64()))
+	}
+
+	// Published before today's start, so items don't change during the day
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	age := time.Duration(1 + rng.Int64N(int64(days)*int64(24*time.Hour)))
+
+	topic := generatedTopics[rng.IntN(len(generatedTopics))]
+	item := generated{
+		ID: "gen-" + strconv.Itoa(i),
+		Title: fmt.Sprintf("%s %s %s", generatedAdjectives[rng.IntN(len(generatedAdjectives))], topic,
+			generatedNouns[rng.IntN(len(generatedNouns))]),
+		Type:        s.pickType(rng),
+		Author:      generatedAuthors[rng.IntN(len(generatedAuthors))],
+		Tags:        []string{strings.ToLower(topic), strings.ToLower(generatedTopics[rng.IntN(len(generatedTopics))])},
+		PublishedAt: today.Add(-age),
+	}
+	if item.Tags[0] == item.Tags[1] {
+		item.Tags = item.Tags[:1]
+	}
+
+	switch item.Type {
+	case "video":
+		item.Views = popularity()
+		item.Likes = item.Views * (1 + rng.IntN(10)) / 100
+		item.DurationSeconds = 60 + rng.IntN(60*60)
+	case "article":
+		item.ReadingTime = 2 + rng.IntN(28)
+		item.Reactions = popularity() / 20
+		item.Comments = item.Reactions * rng.IntN(20) / 100
+	case "podcast":
+		item.Listens = popularity()
+		item.Likes = item.Listens * (1 + rng.IntN(10)) / 100
+		item.DurationSeconds = 10*60 + rng.IntN(110*60)
+	case "image":
+		item.Views = popularity()
+		item.Likes = item.Views * (1 + rng.IntN(10)) / 100
+	}
+
+	return item
+}
+
+// pickType picks a content type by the scenario's weights.
+func (s scenario) pickType(rng *rand.Rand) string {
+	weights := s.Types
+	if weights == (typeWeights{}) {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return contentTypes[i]
+		}
+		n -= w
+	}
+
+	return contentTypes[0]
+}
+
+// clock formats seconds as the providers' mm:ss duration.
+func clock(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,229 @@
+// Package leader provides continuous leader election on top of the locker package,
+// so always-on background work runs on exactly one of many service instances.
+//
+// Unlike per-tick locking (see locker.WithLock), an elected leader keeps its
+// leadership between runs, renewing it until it's lost or the elector stops.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"search-engine-service/pkg/locker"
+)
+
+// Locker is a distributed locker whose locks can be extended, which the election
+// needs to find out whether leadership is still held. All lockers in pkg/locker qualify.
+type Locker interface {
+	locker.DistributedLocker
+	locker.Renewer
+}
+
+// Config holds election settings.
+type Config struct {
+	Key           string        // Lock key of the election; electors with the same key compete
+	TTL           time.Duration // Leadership lapses this long after the last renewal, e.g. after a crash
+	RenewInterval time.Duration // Leader renewal period; defaults to TTL/3
+	RetryInterval time.Duration // Period in which followers try to take over; defaults to TTL/3
+}
+
+// Callbacks are called as this instance gains and loses leadership.
+type Callbacks struct {
+	// OnStartedLeading runs the leader's work when this instance is elected. ctx is
+	// cancelled when leadership is lost or the elector stops; the function should
+	// return then. It carries the lock's fencing token if the locker issues them
+	// (see locker.FenceFromContext). Returning early gives up leadership.
+	OnStartedLeading func(ctx context.Context)
+
+	// OnStoppedLeading is called after leadership ended and OnStartedLeading returned.
+	// Optional.
+	OnStoppedLeading func()
+}
+
+// Elector campaigns for leadership until stopped.
+type Elector struct {
+	locker    Locker
+	cfg       Config
+	callbacks Callbacks
+	logger    *zap.Logger
+	leading   atomic.Bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new Elector. Call Start to begin campaigning.
+func New(l Locker, cfg Config, callbacks Callbacks, logger *zap.Logger) (*Elector, error) {
+	if cfg.Key == "" {
+		return nil, errors.New("leader election: key is required")
+	}
+	if cfg.TTL <= 0 {
+		return nil, fmt.Errorf("leader election %s: ttl must be positive", cfg.Key)
+	}
+	if callbacks.OnStartedLeading == nil {
+		return nil, fmt.Errorf("leader election %s: OnStartedLeading is required", cfg.Key)
+	}
+	if cfg.RenewInterval <= 0 {
+		cfg.RenewInterval = cfg.TTL / 3
+	}
+	if cfg.RenewInterval >= cfg.TTL {
+		return nil, fmt.Errorf("leader election %s: renew interval must be shorter than the ttl", cfg.Key)
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = cfg.TTL / 3
+	}
+
+	return &Elector{
+		locker:    l,
+		cfg:       cfg,
+		callbacks: callbacks,
+		logger:    logger.With(zap.String("election", cfg.Key)),
+	}, nil
+}
+
+// Start begins campaigning in the background. The first attempt runs immediately.
+func (e *Elector) Start() {
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+
+	e.logger.Info("starting leader election", zap.Duration("ttl", e.cfg.TTL))
+
+	e.wg.Add(1)
+	go e.run()
+}
+
+// Stop ends the election. A leader cancels its work, waits for OnStartedLeading to
+// return and releases leadership, so another instance can take over at once.
+func (e *Elector) Stop() {
+	e.logger.Info("stopping leader election")
+	e.cancel()
+	e.wg.Wait()
+	e.logger.Info("leader election stopped")
+}
+
+// IsLeader reports whether this instance currently holds leadership.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// run campaigns until the elector stops, leading whenever elected.
+func (e *Elector) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.cfg.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		if e.campaign() {
+			e.lead()
+			ticker.Reset(e.cfg.RetryInterval)
+		}
+
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign makes one attempt to acquire leadership.
+func (e *Elector) campaign() bool {
+	acquired, err := e.locker.Acquire(e.ctx, e.cfg.Key, e.cfg.TTL)
+	if err != nil {
+		if e.ctx.Err() == nil {
+			e.logger.Warn("leader election attempt failed", zap.Error(err))
+		}
+
+		return false
+	}
+
+	return acquired
+}
+
+// lead runs OnStartedLeading and renews leadership until it's lost, the work
+// returns or the elector stops, then releases leadership.
+func (e *Elector) lead() {
+	e.leading.Store(true)
+	e.logger.Info("elected leader", zap.String("instance", locker.InstanceID()))
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	if f, ok := e.locker.(locker.Fencer); ok {
+		if fence, ok := locker.FenceOf(f, e.cfg.Key); ok {
+			ctx = locker.ContextWithFence(ctx, fence)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.callbacks.OnStartedLeading(ctx)
+	}()
+
+	held := e.renew(ctx, done)
+
+	e.leading.Store(false)
+	cancel()
+	<-done
+
+	if held {
+		releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(e.ctx), e.cfg.RenewInterval)
+		defer cancelRelease()
+		if err := e.locker.Release(releaseCtx, e.cfg.Key); err != nil {
+			e.logger.Warn("failed to release leadership, it lapses after the ttl", zap.Error(err))
+		}
+	}
+
+	if e.callbacks.OnStoppedLeading != nil {
+		e.callbacks.OnStoppedLeading()
+	}
+	e.logger.Info("stopped leading")
+}
+
+// renew extends leadership every RenewInterval until it's lost, done is closed or
+// ctx is done. When renewals fail, leadership is given up before it could lapse and
+// be taken over while this instance still believes it leads. Returns false if the
+// lock is known to be lost, so it mustn't be released.
+func (e *Elector) renew(ctx context.Context, done <-chan struct{}) bool {
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+
+	deadline := e.cfg.TTL - e.cfg.RenewInterval
+	renewed := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-done:
+			e.logger.Info("leader work returned, giving up leadership")
+
+			return true
+		case <-ticker.C:
+		}
+
+		ok, err := e.locker.Extend(ctx, e.cfg.Key)
+		switch {
+		case ctx.Err() != nil:
+			return true
+		case err != nil && time.Since(renewed) < deadline:
+			e.logger.Warn("leadership renewal failed, retrying", zap.Error(err))
+		case err != nil:
+			e.logger.Warn("leadership renewal failed, stepping down", zap.Error(err))
+
+			return true
+		case !ok:
+			e.logger.Warn("leadership lost")
+
+			return false
+		default:
+			renewed = time.Now()
+		}
+	}
+}
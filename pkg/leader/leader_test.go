@@ -0,0 +1,148 @@
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"search-engine-service/pkg/locker"
+)
+
+const testElectionKey = "test:leader"
+
+var testConfig = Config{
+	Key:           testElectionKey,
+	TTL:           300 * time.Millisecond,
+	RenewInterval: 50 * time.Millisecond,
+	RetryInterval: 20 * time.Millisecond,
+}
+
+// testElector records the leadership changes of an Elector.
+type testElector struct {
+	*Elector
+	started atomic.Int32
+	stopped atomic.Int32
+}
+
+func newTestElector(t *testing.T, l Locker) *testElector {
+	t.Helper()
+
+	te := &testElector{}
+	e, err := New(l, testConfig, Callbacks{
+		OnStartedLeading: func(ctx context.Context) {
+			te.started.Add(1)
+			<-ctx.Done()
+		},
+		OnStoppedLeading: func() { te.stopped.Add(1) },
+	}, zap.NewNop())
+	require.NoError(t, err)
+	te.Elector = e
+
+	return te
+}
+
+func TestElector_SingleLeaderAndFailover(t *testing.T) {
+	l := locker.NewMemoryLocker()
+	first := newTestElector(t, l)
+	second := newTestElector(t, l)
+
+	first.Start()
+	require.Eventually(t, first.IsLeader, time.Second, 10*time.Millisecond)
+	second.Start()
+	defer second.Stop()
+
+	// Renewals keep the first elector in charge past the TTL
+	time.Sleep(2 * testConfig.TTL)
+	assert.True(t, first.IsLeader())
+	assert.False(t, second.IsLeader())
+	assert.Equal(t, int32(1), first.started.Load())
+	assert.Zero(t, second.started.Load())
+
+	first.Stop()
+	assert.False(t, first.IsLeader())
+	assert.Equal(t, int32(1), first.stopped.Load(), "OnStoppedLeading runs when stepping down")
+
+	require.Eventually(t, second.IsLeader, time.Second, 10*time.Millisecond, "leadership is released on stop")
+	assert.Equal(t, int32(1), second.started.Load())
+}
+
+func TestElector_LostLeadershipCancelsWork(t *testing.T) {
+	l := locker.NewMemoryLocker()
+	e := newTestElector(t, l)
+
+	e.Start()
+	defer e.Stop()
+	require.Eventually(t, e.IsLeader, time.Second, 10*time.Millisecond)
+
+	released, err := l.ForceRelease(context.Background(), testElectionKey)
+	require.NoError(t, err)
+	require.True(t, released)
+
+	require.Eventually(t, func() bool { return e.stopped.Load() == 1 }, time.Second, 10*time.Millisecond,
+		"the next renewal notices the loss")
+	require.Eventually(t, func() bool { return e.started.Load() == 2 }, time.Second, 10*time.Millisecond,
+		"the free lock is taken again")
+}
+
+func TestElector_PassesFencingToken(t *testing.T) {
+	fences := make(chan locker.Fence, 1)
+	e, err := New(locker.NewMemoryLocker(), testConfig, Callbacks{
+		OnStartedLeading: func(ctx context.Context) {
+			if fence, ok := locker.FenceFromContext(ctx); ok {
+				fences <- fence
+			}
+			<-ctx.Done()
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	e.Start()
+	defer e.Stop()
+
+	select {
+	case fence := <-fences:
+		assert.Equal(t, locker.Fence{Key: "memory:" + testElectionKey, Token: 1}, fence)
+	case <-time.After(time.Second):
+		t.Fatal("leader work didn't get a fencing token")
+	}
+}
+
+func TestElector_WorkReturningGivesUpLeadership(t *testing.T) {
+	var runs atomic.Int32
+	e, err := New(locker.NewMemoryLocker(), testConfig, Callbacks{
+		OnStartedLeading: func(context.Context) { runs.Add(1) },
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	e.Start()
+	defer e.Stop()
+
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 10*time.Millisecond,
+		"leadership is released and won again")
+}
+
+func TestNew_ValidatesConfig(t *testing.T) {
+	run := Callbacks{OnStartedLeading: func(context.Context) {}}
+
+	_, err := New(locker.NewMemoryLocker(), Config{TTL: time.Second}, run, zap.NewNop())
+	assert.Error(t, err, "missing key")
+
+	_, err = New(locker.NewMemoryLocker(), Config{Key: testElectionKey}, run, zap.NewNop())
+	assert.Error(t, err, "missing ttl")
+
+	_, err = New(locker.NewMemoryLocker(), Config{Key: testElectionKey, TTL: time.Second, RenewInterval: time.Second}, run, zap.NewNop())
+	assert.Error(t, err, "renew interval not shorter than the ttl")
+
+	_, err = New(locker.NewMemoryLocker(), Config{Key: testElectionKey, TTL: time.Second}, Callbacks{}, zap.NewNop())
+	assert.Error(t, err, "missing OnStartedLeading")
+
+	e, err := New(locker.NewMemoryLocker(), Config{Key: testElectionKey, TTL: 3 * time.Second}, run, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, e.cfg.RenewInterval)
+	assert.Equal(t, time.Second, e.cfg.RetryInterval)
+}
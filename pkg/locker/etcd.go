@@ -0,0 +1,302 @@
+package locker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// EtcdLocker implements DistributedLocker using etcd leases. A lock is a key under
+// locks/ attached to a lease with the lock's TTL, created only if the key doesn't exist;
+// revoking or expiring the lease deletes it. The key's value records the holder.
+// Lease TTLs have a granularity of one second.
+//
+// It also implements Renewer (lease keep-alive), Fencer and Inspector. The fencing token
+// is the etcd revision that created the key, which increases with every write to the cluster.
+type EtcdLocker struct {
+	client *clientv3.Client
+	logger *zap.Logger
+	leases map[string]etcdLease
+	mu     sync.Mutex
+}
+
+// etcdKeyPrefix namespaces lock keys in etcd.
+const etcdKeyPrefix = "locks/"
+
+// etcdHolder is the value of a lock key.
+type etcdHolder struct {
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// etcdLease is a lock held by this instance.
+type etcdLease struct {
+	id       clientv3.LeaseID
+	revision int64
+}
+
+// NewEtcdLocker creates a new etcd-based distributed locker.
+func NewEtcdLocker(client *clientv3.Client, logger *zap.Logger) *EtcdLocker {
+	return &EtcdLocker{
+		client: client,
+		logger: logger,
+		leases: make(map[string]etcdLease),
+	}
+}
+
+// Acquire attempts to create the lock key under a new lease without blocking.
+// Returns true if the lock was acquired, false if another instance holds it.
+func (e *EtcdLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	lease, err := e.client.Grant(ctx, leaseSeconds(ttl))
+	if err != nil {
+		return false, fmt.Errorf("acquire lock %s: grant lease: %w", key, err)
+	}
+
+	holder, err := json.Marshal(etcdHolder{Holder: instanceID, AcquiredAt: time.Now().UTC()})
+	if err != nil {
+		return false, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+
+	resp, err := e.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(etcdKeyPrefix+key), "=", 0)).
+		Then(clientv3.OpPut(etcdKeyPrefix+key, string(holder), clientv3.WithLease(lease.ID))).
+		Commit()
+	if err != nil || !resp.Succeeded {
+		// The lease would otherwise linger until its TTL
+		if _, revokeErr := e.client.Revoke(context.WithoutCancel(ctx), lease.ID); revokeErr != nil {
+			e.logger.Warn("failed to revoke unused lease", zap.String("key", key), zap.Error(revokeErr))
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+	if !resp.Succeeded {
+		e.logger.Debug("lock already held by another instance",
+			zap.String("key", key),
+		)
+
+		return false, nil
+	}
+
+	e.mu.Lock()
+	e.leases[key] = etcdLease{id: lease.ID, revision: resp.Header.Revision}
+	e.mu.Unlock()
+
+	e.logger.Debug("lock acquired",
+		zap.String("key", key),
+		zap.Duration("ttl", ttl),
+		zap.Int64("fencing_token", resp.Header.Revision),
+	)
+
+	return true, nil
+}
+
+// AcquireWait is like Acquire, but while another instance holds the lock it retries
+// with backoff for up to maxWait. Returns false if the lock wasn't obtained in time.
+func (e *EtcdLocker) AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error) {
+	return acquireWait(ctx, key, maxWait, func(ctx context.Context) (bool, error) {
+		return e.Acquire(ctx, key, ttl)
+	})
+}
+
+// Release revokes the lock's lease if this instance holds it; otherwise it's a no-op.
+func (e *EtcdLocker) Release(ctx context.Context, key string) error {
+	e.mu.Lock()
+	lease, exists := e.leases[key]
+	if exists {
+		delete(e.leases, key)
+	}
+	e.mu.Unlock()
+
+	if !exists {
+		e.logger.Debug("no lease found for key, lock not owned by this instance",
+			zap.String("key", key),
+		)
+
+		return nil
+	}
+
+	if _, err := e.client.Revoke(ctx, lease.id); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			e.logger.Debug("lock already expired",
+				zap.String("key", key),
+			)
+
+			return nil
+		}
+
+		return fmt.Errorf("release lock %s: %w", key, err)
+	}
+
+	e.logger.Debug("lock released",
+		zap.String("key", key),
+	)
+
+	return nil
+}
+
+// Renew refreshes the lock's lease to its full TTL every interval, until stop is
+// called or ctx is done. Renewal ends on the first failure, e.g. an expired lease.
+func (e *EtcdLocker) Renew(ctx context.Context, key string, interval time.Duration) (stop func()) {
+	e.mu.Lock()
+	lease, exists := e.leases[key]
+	e.mu.Unlock()
+
+	if !exists {
+		e.logger.Debug("no lease found for key, nothing to renew",
+			zap.String("key", key),
+		)
+
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			resp, err := e.client.KeepAliveOnce(ctx, lease.id)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				e.logger.Warn("lock renewal failed, lock may be acquired by another instance",
+					zap.String("key", key),
+					zap.Error(err),
+				)
+
+				return
+			}
+
+			e.logger.Debug("lock renewed",
+				zap.String("key", key),
+				zap.Int64("ttl_seconds", resp.TTL),
+			)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Extend refreshes the lock's lease to its full TTL once. Returns false if this
+// instance doesn't hold the lock or its lease is gone (expired or revoked).
+func (e *EtcdLocker) Extend(ctx context.Context, key string) (bool, error) {
+	e.mu.Lock()
+	lease, exists := e.leases[key]
+	e.mu.Unlock()
+
+	if !exists {
+		return false, nil
+	}
+
+	if _, err := e.client.KeepAliveOnce(ctx, lease.id); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("extend lock %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// FenceScope returns "etcd". Revisions keep increasing as long as the cluster keeps
+// its data.
+func (e *EtcdLocker) FenceScope() string {
+	return "etcd"
+}
+
+// FencingToken returns the revision that created the lock key when this instance
+// acquired it. Returns false if this instance doesn't hold the lock.
+func (e *EtcdLocker) FencingToken(key string) (int64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	lease, ok := e.leases[key]
+
+	return lease.revision, ok
+}
+
+// Locks returns the locks held by any instance, sorted by key.
+func (e *EtcdLocker) Locks(ctx context.Context) ([]LockInfo, error) {
+	resp, err := e.client.Get(ctx, etcdKeyPrefix, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+
+	locks := make([]LockInfo, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		info := LockInfo{
+			Key:   strings.TrimPrefix(string(kv.Key), etcdKeyPrefix),
+			Token: kv.CreateRevision,
+		}
+
+		var holder etcdHolder
+		if err := json.Unmarshal(kv.Value, &holder); err == nil {
+			info.Holder = holder.Holder
+			info.AcquiredAt = holder.AcquiredAt
+		}
+
+		lease, err := e.client.TimeToLive(ctx, clientv3.LeaseID(kv.Lease))
+		if err != nil {
+			return nil, fmt.Errorf("list locks: lease of %s: %w", info.Key, err)
+		}
+		if lease.TTL <= 0 {
+			continue // Expired since the Get
+		}
+		info.TTL = time.Duration(lease.TTL) * time.Second
+
+		locks = append(locks, info)
+	}
+
+	return locks, nil
+}
+
+// ForceRelease revokes the lease of the lock identified by key whoever holds it.
+// Returns false if the lock wasn't held.
+func (e *EtcdLocker) ForceRelease(ctx context.Context, key string) (bool, error) {
+	resp, err := e.client.Get(ctx, etcdKeyPrefix+key)
+	if err != nil {
+		return false, fmt.Errorf("force release lock %s: %w", key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return false, nil
+	}
+
+	if _, err := e.client.Revoke(ctx, clientv3.LeaseID(resp.Kvs[0].Lease)); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("force release lock %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// leaseSeconds converts ttl to a lease TTL, rounding up to whole seconds.
+func leaseSeconds(ttl time.Duration) int64 {
+	return max(int64((ttl+time.Second-1)/time.Second), 1)
+}
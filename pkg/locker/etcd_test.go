@@ -0,0 +1,161 @@
+package locker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// setupTestEtcd starts a single-node etcd container. Requires Docker; skip with go test -short.
+func setupTestEtcd(t *testing.T) *clientv3.Client {
+	t.Helper()
+
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "quay.io/coreos/etcd:v3.6.8",
+			ExposedPorts: []string{"2379/tcp"},
+			Cmd: []string{
+				"etcd",
+				"--listen-client-urls=http://0.0.0.0:2379",
+				"--advertise-client-urls=http://0.0.0.0:2379",
+			},
+			WaitingFor: wait.ForListeningPort("2379/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err, "Failed to start etcd container")
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	endpoint, err := container.PortEndpoint(ctx, "2379/tcp", "")
+	require.NoError(t, err)
+
+	client, err := clientv3.New(clientv3.Config{Endpoints: []string{endpoint}, DialTimeout: 5 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestEtcdLocker(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	client := setupTestEtcd(t)
+	locker1 := NewEtcdLocker(client, zap.NewNop())
+	locker2 := NewEtcdLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	t.Run("excludes other instances", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the lock is held by locker1")
+
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the lock is free after release")
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+	})
+
+	t.Run("issues increasing fencing tokens", func(t *testing.T) {
+		var tokens []int64
+		for _, l := range []*EtcdLocker{locker1, locker2} {
+			acquired, err := l.Acquire(ctx, testLockKey, 5*time.Second)
+			require.NoError(t, err)
+			require.True(t, acquired)
+
+			token, ok := l.FencingToken(testLockKey)
+			require.True(t, ok)
+			tokens = append(tokens, token)
+			require.NoError(t, l.Release(ctx, testLockKey))
+		}
+
+		assert.Greater(t, tokens[1], tokens[0])
+	})
+
+	t.Run("release after expiry is a no-op", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		assert.Eventually(t, func() bool {
+			acquired, err := locker2.Acquire(ctx, testLockKey, 5*time.Second)
+
+			return err == nil && acquired
+		}, 10*time.Second, 200*time.Millisecond)
+
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+		acquired, err = locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.False(t, acquired, "releasing an expired lock must not free locker2's")
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+	})
+
+	t.Run("lists and force-releases locks", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		token, _ := locker1.FencingToken(testLockKey)
+
+		locks, err := locker2.Locks(ctx)
+		require.NoError(t, err)
+		require.Len(t, locks, 1)
+		assert.Equal(t, testLockKey, locks[0].Key)
+		assert.Equal(t, InstanceID(), locks[0].Holder)
+		assert.Equal(t, token, locks[0].Token)
+		assert.Positive(t, locks[0].TTL)
+
+		released, err := locker2.ForceRelease(ctx, testLockKey)
+		require.NoError(t, err)
+		assert.True(t, released)
+
+		locks, err = locker2.Locks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, locks)
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the lock is free after a forced release")
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+	})
+
+	t.Run("extension notices a revoked lease", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		ok, err := locker1.Extend(ctx, testLockKey)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		_, err = locker2.ForceRelease(ctx, testLockKey)
+		require.NoError(t, err)
+
+		ok, err = locker1.Extend(ctx, testLockKey)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+	})
+}
+
+func TestLeaseSeconds(t *testing.T) {
+	assert.Equal(t, int64(1), leaseSeconds(0))
+	assert.Equal(t, int64(1), leaseSeconds(200*time.Millisecond))
+	assert.Equal(t, int64(30), leaseSeconds(30*time.Second))
+	assert.Equal(t, int64(31), leaseSeconds(30*time.Second+time.Millisecond))
+}
@@ -4,9 +4,18 @@ package locker
 
 import (
 	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
 	"time"
 )
 
+// Delay bounds between AcquireWait attempts.
+const (
+	acquireWaitMinDelay = 50 * time.Millisecond
+	acquireWaitMaxDelay = time.Second
+)
+
 // DistributedLocker provides distributed lock capabilities across multiple instances.
 // Implementations must be safe for concurrent use.
 //
@@ -33,8 +42,157 @@ type DistributedLocker interface {
 	// - For cooldown/rate limiting: use the desired cooldown period
 	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
 
+	// AcquireWait is like Acquire, but while another instance holds the lock it retries
+	// with backoff for up to maxWait. Returns false if the lock wasn't obtained in time.
+	AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error)
+
 	// Release releases the lock identified by key.
 	// Returns an error if the lock doesn't exist or the release fails.
 	// Safe to call even if this instance doesn't own the lock (no-op).
 	Release(ctx context.Context, key string) error
 }
+
+// Renewer is implemented by lockers that can keep a held lock alive while an
+// operation outlives its TTL, so another instance can't acquire it mid-operation.
+//
+// Typical usage:
+//
+//	if r, ok := locker.(Renewer); ok {
+//	    stop := r.Renew(ctx, "my-lock", ttl/3)
+//	    defer stop()
+//	}
+type Renewer interface {
+	// Renew extends the lock identified by key to its full TTL every interval,
+	// until the returned stop function is called or ctx is done. Renewal ends
+	// early if the lock was lost. Renewing a lock this instance doesn't hold is a no-op.
+	// stop waits for a renewal in progress, so the lock can be released right after it.
+	Renew(ctx context.Context, key string, interval time.Duration) (stop func())
+
+	// Extend extends the lock identified by key to its full TTL once. Returns false if
+	// this instance doesn't hold the lock (anymore), e.g. because it expired or was
+	// force-released, and an error if that can't be determined.
+	Extend(ctx context.Context, key string) (bool, error)
+}
+
+// Inspector is implemented by lockers that can list the locks held by any instance
+// and release them by force, e.g. when a stuck holder keeps renewing a lock.
+type Inspector interface {
+	// Locks returns the locks currently held, sorted by key.
+	Locks(ctx context.Context) ([]LockInfo, error)
+
+	// ForceRelease releases the lock identified by key whoever holds it.
+	// Returns false if the lock wasn't held. The former holder finds out when
+	// renewing or writing with its fencing token.
+	ForceRelease(ctx context.Context, key string) (bool, error)
+}
+
+// LockInfo describes a held lock.
+type LockInfo struct {
+	Key        string
+	Holder     string // Instance that acquired the lock (see InstanceID)
+	AcquiredAt time.Time
+	TTL        time.Duration // Remaining until the lock expires unless renewed
+	Token      int64         // Fencing token (0 if unknown)
+}
+
+// instanceID identifies this process as a lock holder.
+var instanceID = func() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}()
+
+// InstanceID returns the holder ID recorded with locks this process acquires:
+// the hostname (the pod name on Kubernetes) and process ID.
+func InstanceID() string {
+	return instanceID
+}
+
+// Fencer is implemented by lockers that issue fencing tokens. Every acquisition of a key
+// gets a token greater than the ones before it, so a store can reject writes from a
+// holder whose lock expired and was taken over while it was still working.
+//
+// WithLock passes the token to the protected operation; see FenceFromContext.
+type Fencer interface {
+	// FencingToken returns the token issued when this instance acquired the lock
+	// identified by key. Returns false if this instance doesn't hold the lock.
+	FencingToken(key string) (int64, bool)
+
+	// FenceScope names the source of the tokens, e.g. the backend. Tokens of different
+	// sources are unrelated, so stores keep the fences of each scope apart.
+	FenceScope() string
+}
+
+// Fence is the fencing token of one acquisition of a lock. Key is the lock key
+// prefixed with the scope of the token ("<scope>:<lock key>"), so switching lock
+// backends starts new fences rather than comparing unrelated tokens.
+type Fence struct {
+	Key   string
+	Token int64
+}
+
+// FenceOf returns the fence of the lock identified by key as held by f. Returns false
+// if f doesn't hold the lock.
+func FenceOf(f Fencer, key string) (Fence, bool) {
+	token, ok := f.FencingToken(key)
+	if !ok {
+		return Fence{}, false
+	}
+
+	return Fence{Key: f.FenceScope() + ":" + key, Token: token}, true
+}
+
+// TokenFloor returns the highest token a store has accepted with the fence identified
+// by fenceKey (see Fence), 0 if none. Lockers whose token counters can be lost use it
+// to issue tokens above it again (see WithTokenFloor).
+type TokenFloor func(ctx context.Context, fenceKey string) (int64, error)
+
+type fenceKey struct{}
+
+// ContextWithFence returns a copy of ctx carrying fence.
+func ContextWithFence(ctx context.Context, fence Fence) context.Context {
+	return context.WithValue(ctx, fenceKey{}, fence)
+}
+
+// FenceFromContext returns the fence of the innermost lock held by WithLock for ctx.
+// Returns false outside WithLock or if the locker doesn't issue fencing tokens.
+func FenceFromContext(ctx context.Context) (Fence, bool) {
+	fence, ok := ctx.Value(fenceKey{}).(Fence)
+
+	return fence, ok
+}
+
+// acquireWait calls acquire until it obtains the lock, fails, or maxWait or ctx run out.
+// The delay between attempts doubles up to acquireWaitMaxDelay, with jitter so waiting
+// instances don't retry in lockstep. Shared by the DistributedLocker implementations.
+func acquireWait(ctx context.Context, key string, maxWait time.Duration, acquire func(context.Context) (bool, error)) (bool, error) {
+	deadline := time.Now().Add(maxWait)
+	delay := acquireWaitMinDelay
+
+	for {
+		acquired, err := acquire(ctx)
+		if err != nil || acquired {
+			return acquired, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+
+		wait := min(delay/2+rand.N(delay/2+1), remaining)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return false, fmt.Errorf("wait for lock %s: %w", key, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay = min(2*delay, acquireWaitMaxDelay)
+	}
+}
@@ -0,0 +1,252 @@
+package locker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryLocker implements DistributedLocker within a single process, for running
+// without Redis (development, single-instance deployments, tests). It provides no
+// exclusion between instances; run only one instance of the service with it.
+//
+// Locks expire after their TTL like Redis locks, so the scheduler's cooldown model
+// behaves the same. It also implements Renewer, Fencer and Inspector. Fencing tokens
+// come from an in-process counter that restarts with the process, unless a durable
+// source is set with WithTokenSource; stores keeping the highest token they have seen
+// (lock_fences) need one, or they reject every write after a restart.
+type MemoryLocker struct {
+	mu        sync.Mutex
+	locks     map[string]*memoryLock
+	tokens    map[string]int64
+	nextToken TokenSource // nil counts in tokens
+	now       func() time.Time
+}
+
+// TokenSource issues fencing tokens, each greater than the ones before it, for the
+// acquisition of the lock identified by key.
+type TokenSource func(ctx context.Context, key string) (int64, error)
+
+// MemoryOption configures a MemoryLocker.
+type MemoryOption func(*MemoryLocker)
+
+// WithTokenSource makes the locker issue the fencing tokens of next, e.g. the
+// lock_fencing_tokens sequence (see SequenceTokenSource), so they keep increasing
+// across restarts.
+func WithTokenSource(next TokenSource) MemoryOption {
+	return func(m *MemoryLocker) {
+		m.nextToken = next
+	}
+}
+
+// memoryLock is a held lock.
+type memoryLock struct {
+	token      int64
+	ttl        time.Duration
+	acquiredAt time.Time
+	expiresAt  time.Time
+}
+
+// NewMemoryLocker creates a new process-local locker.
+func NewMemoryLocker(opts ...MemoryOption) *MemoryLocker {
+	m := &MemoryLocker{
+		locks:  make(map[string]*memoryLock),
+		tokens: make(map[string]int64),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Acquire takes the lock for key unless it's held and not yet expired.
+func (m *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.heldLocked(key) != nil {
+		return false, nil
+	}
+
+	token, err := m.issueToken(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("issue fencing token for lock %s: %w", key, err)
+	}
+
+	now := m.now()
+	m.locks[key] = &memoryLock{
+		token:      token,
+		ttl:        ttl,
+		acquiredAt: now,
+		expiresAt:  now.Add(ttl),
+	}
+
+	return true, nil
+}
+
+// AcquireWait is like Acquire, but while the lock is held it retries with backoff
+// for up to maxWait. Returns false if the lock wasn't obtained in time.
+func (m *MemoryLocker) AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error) {
+	return acquireWait(ctx, key, maxWait, func(ctx context.Context) (bool, error) {
+		return m.Acquire(ctx, key, ttl)
+	})
+}
+
+// Release releases the lock identified by key. Releasing a free lock is a no-op.
+func (m *MemoryLocker) Release(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.locks, key)
+
+	return nil
+}
+
+// Renew extends the lock identified by key to its full TTL every interval, until
+// stop is called or ctx is done. Renewal ends once the lock expired or was released.
+func (m *MemoryLocker) Renew(ctx context.Context, key string, interval time.Duration) (stop func()) {
+	m.mu.Lock()
+	lock := m.heldLocked(key)
+	m.mu.Unlock()
+
+	if lock == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			m.mu.Lock()
+			held := m.heldLocked(key) == lock
+			if held {
+				lock.expiresAt = m.now().Add(lock.ttl)
+			}
+			m.mu.Unlock()
+
+			if !held {
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Extend extends the lock identified by key to its full TTL once. Returns false if
+// the lock isn't held.
+func (m *MemoryLocker) Extend(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock := m.heldLocked(key)
+	if lock == nil {
+		return false, nil
+	}
+	lock.expiresAt = m.now().Add(lock.ttl)
+
+	return true, nil
+}
+
+// FenceScope returns "memory".
+func (m *MemoryLocker) FenceScope() string {
+	return "memory"
+}
+
+// FencingToken returns the token issued when the lock identified by key was acquired.
+// Returns false if the lock isn't held.
+func (m *MemoryLocker) FencingToken(key string) (int64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock := m.heldLocked(key)
+	if lock == nil {
+		return 0, false
+	}
+
+	return lock.token, true
+}
+
+// Locks returns the unexpired locks, sorted by key. They are all held by this instance.
+func (m *MemoryLocker) Locks(_ context.Context) ([]LockInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	locks := make([]LockInfo, 0, len(m.locks))
+	for key := range m.locks {
+		lock := m.heldLocked(key)
+		if lock == nil {
+			continue
+		}
+		locks = append(locks, LockInfo{
+			Key:        key,
+			Holder:     instanceID,
+			AcquiredAt: lock.acquiredAt,
+			TTL:        lock.expiresAt.Sub(now),
+			Token:      lock.token,
+		})
+	}
+	slices.SortFunc(locks, func(a, b LockInfo) int { return strings.Compare(a.Key, b.Key) })
+
+	return locks, nil
+}
+
+// ForceRelease releases the lock identified by key. Returns false if it wasn't held.
+func (m *MemoryLocker) ForceRelease(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.heldLocked(key) == nil {
+		return false, nil
+	}
+	delete(m.locks, key)
+
+	return true, nil
+}
+
+// issueToken returns the next fencing token of key. Callers must hold m.mu.
+func (m *MemoryLocker) issueToken(ctx context.Context, key string) (int64, error) {
+	if m.nextToken != nil {
+		return m.nextToken(ctx, key)
+	}
+	m.tokens[key]++
+
+	return m.tokens[key], nil
+}
+
+// heldLocked returns the unexpired lock for key, dropping an expired one.
+// Callers must hold m.mu.
+func (m *MemoryLocker) heldLocked(key string) *memoryLock {
+	lock, ok := m.locks[key]
+	if !ok {
+		return nil
+	}
+	if !m.now().Before(lock.expiresAt) {
+		delete(m.locks, key)
+
+		return nil
+	}
+
+	return lock
+}
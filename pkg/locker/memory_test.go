@@ -0,0 +1,207 @@
+package locker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemoryLocker returns a MemoryLocker reading the time from now.
+func newTestMemoryLocker(now *time.Time) *MemoryLocker {
+	l := NewMemoryLocker()
+	l.now = func() time.Time { return *now }
+
+	return l
+}
+
+func TestMemoryLocker_AcquireRelease(t *testing.T) {
+	l := NewMemoryLocker()
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "the lock is held")
+
+	require.NoError(t, l.Release(ctx, testLockKey))
+	require.NoError(t, l.Release(ctx, testLockKey), "releasing a free lock is a no-op")
+
+	acquired, err = l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the lock is free after release")
+}
+
+func TestMemoryLocker_Expires(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newTestMemoryLocker(&now)
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	now = now.Add(time.Minute)
+
+	_, ok := l.FencingToken(testLockKey)
+	assert.False(t, ok, "an expired lock has no token")
+	acquired, err = l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the lock is free after its ttl (cooldown)")
+}
+
+func TestMemoryLocker_FencingTokensIncrease(t *testing.T) {
+	l := NewMemoryLocker()
+	ctx := context.Background()
+
+	var tokens []int64
+	for range 2 {
+		acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		token, ok := l.FencingToken(testLockKey)
+		require.True(t, ok)
+		tokens = append(tokens, token)
+		require.NoError(t, l.Release(ctx, testLockKey))
+	}
+
+	assert.Greater(t, tokens[1], tokens[0])
+}
+
+func TestMemoryLocker_TokenSourceOutlivesRestarts(t *testing.T) {
+	var issued int64 // Kept by the durable source across restarts
+	source := func(context.Context, string) (int64, error) {
+		issued++
+
+		return issued, nil
+	}
+	ctx := context.Background()
+
+	var tokens []int64
+	for range 2 {
+		l := NewMemoryLocker(WithTokenSource(source)) // A restarted process
+		acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		token, ok := l.FencingToken(testLockKey)
+		require.True(t, ok)
+		tokens = append(tokens, token)
+	}
+
+	assert.Greater(t, tokens[1], tokens[0])
+
+	failing := NewMemoryLocker(WithTokenSource(func(context.Context, string) (int64, error) {
+		return 0, errors.New("database down")
+	}))
+	_, err := failing.Acquire(ctx, testLockKey, time.Minute)
+	require.Error(t, err)
+	_, ok := failing.FencingToken(testLockKey)
+	assert.False(t, ok, "no lock is taken without a token")
+}
+
+func TestMemoryLocker_AcquireWait(t *testing.T) {
+	l := NewMemoryLocker()
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = l.Release(ctx, testLockKey)
+	}()
+
+	acquired, err = l.AcquireWait(ctx, testLockKey, time.Minute, 5*time.Second)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the lock is obtained once released")
+}
+
+func TestMemoryLocker_Renew(t *testing.T) {
+	l := NewMemoryLocker()
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, testLockKey, 200*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	stop := l.Renew(ctx, testLockKey, 50*time.Millisecond)
+	time.Sleep(400 * time.Millisecond)
+
+	acquired, err = l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "the renewed lock is still held")
+
+	stop()
+	require.NoError(t, l.Release(ctx, testLockKey))
+}
+
+func TestMemoryLocker_Extend(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newTestMemoryLocker(&now)
+	ctx := context.Background()
+
+	acquired, err := l.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	now = now.Add(50 * time.Second)
+	ok, err := l.Extend(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	now = now.Add(50 * time.Second)
+	_, held := l.FencingToken(testLockKey)
+	assert.True(t, held, "the ttl starts over on extension")
+
+	now = now.Add(10 * time.Second)
+	ok, err = l.Extend(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.False(t, ok, "an expired lock can't be extended")
+}
+
+func TestMemoryLocker_LocksAndForceRelease(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newTestMemoryLocker(&now)
+	ctx := context.Background()
+
+	for _, key := range []string{"b:lock", "a:lock"} {
+		acquired, err := l.Acquire(ctx, key, time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+	}
+	now = now.Add(10 * time.Second)
+
+	locks, err := l.Locks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	assert.Equal(t, LockInfo{
+		Key:        "a:lock",
+		Holder:     InstanceID(),
+		AcquiredAt: time.Unix(1_700_000_000, 0),
+		TTL:        50 * time.Second,
+		Token:      1,
+	}, locks[0])
+	assert.Equal(t, "b:lock", locks[1].Key)
+
+	released, err := l.ForceRelease(ctx, "a:lock")
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	released, err = l.ForceRelease(ctx, "a:lock")
+	require.NoError(t, err)
+	assert.False(t, released, "a free lock isn't released")
+
+	locks, err = l.Locks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "b:lock", locks[0].Key)
+}
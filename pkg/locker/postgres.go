@@ -0,0 +1,381 @@
+package locker
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// releaseTimeout bounds the release of a lock whose TTL ran out or that is force-released.
+const releaseTimeout = 5 * time.Second
+
+// PostgresLocker implements DistributedLocker using PostgreSQL session-level
+// advisory locks, for deployments without Redis.
+//
+// Each held lock pins one connection of db, since advisory locks belong to the
+// session that took them; size the pool accordingly. Advisory locks don't expire,
+// so the TTL is enforced by this instance releasing the lock when it runs out. If
+// the instance dies, the connection closes and PostgreSQL releases the lock at once.
+//
+// It also implements Renewer, Fencer and Inspector. Fencing tokens come from the
+// lock_fencing_tokens sequence and holders are recorded in the lock_holders table,
+// which must exist (see the migrations).
+type PostgresLocker struct {
+	db     *sql.DB
+	logger *zap.Logger
+	held   map[string]*advisoryLock
+	mu     sync.Mutex
+}
+
+// SequenceTokenSource returns a TokenSource drawing from the lock_fencing_tokens
+// sequence of db, as the PostgreSQL backend does, so a MemoryLocker's tokens survive
+// restarts (see WithTokenSource).
+func SequenceTokenSource(db *sql.DB) TokenSource {
+	return func(ctx context.Context, _ string) (int64, error) {
+		var token int64
+		err := db.QueryRowContext(ctx, "SELECT nextval('lock_fencing_tokens')").Scan(&token)
+
+		return token, err
+	}
+}
+
+// advisoryLock is a lock held by this instance.
+type advisoryLock struct {
+	conn     *sql.Conn
+	token    int64
+	ttl      time.Duration
+	expiry   *time.Timer
+	renewing bool // Renew stopped expiry until it ends
+}
+
+// NewPostgresLocker creates a new advisory-lock based distributed locker.
+func NewPostgresLocker(db *sql.DB, logger *zap.Logger) *PostgresLocker {
+	return &PostgresLocker{
+		db:     db,
+		logger: logger,
+		held:   make(map[string]*advisoryLock),
+	}
+}
+
+// Acquire attempts to take the advisory lock for key without blocking.
+// Returns true if the lock was acquired, false if another session holds it.
+// The lock is released after ttl.
+//
+// Every attempt runs on its own connection. Held locks keep theirs out of the pool,
+// so a lock can't be reentered through a session that already holds it.
+func (p *PostgresLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtextextended($1, 0))", key).Scan(&acquired); err != nil {
+		discard(conn)
+
+		return false, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		p.logger.Debug("lock already held by another instance",
+			zap.String("key", key),
+		)
+
+		return false, nil
+	}
+
+	lock := &advisoryLock{conn: conn, ttl: ttl}
+	if err := conn.QueryRowContext(ctx, "SELECT nextval('lock_fencing_tokens')").Scan(&lock.token); err != nil {
+		// Closing the session releases the lock
+		discard(conn)
+
+		return false, fmt.Errorf("issue fencing token for lock %s: %w", key, err)
+	}
+
+	// The holder record is informational; the lock stands without it
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO lock_holders (lock_key, holder, token, pid, acquired_at, expires_at)
+		VALUES ($1, $2, $3, pg_backend_pid(), now(), now() + $4 * interval '1 millisecond')
+		ON CONFLICT (lock_key) DO UPDATE SET holder = excluded.holder, token = excluded.token, pid = excluded.pid,
+			acquired_at = excluded.acquired_at, expires_at = excluded.expires_at
+	`, key, instanceID, lock.token, ttl.Milliseconds()); err != nil {
+		p.logger.Warn("failed to record lock holder", zap.String("key", key), zap.Error(err))
+	}
+
+	p.mu.Lock()
+	p.held[key] = lock
+	lock.expiry = time.AfterFunc(ttl, func() { p.expire(key, lock) })
+	p.mu.Unlock()
+
+	p.logger.Debug("lock acquired",
+		zap.String("key", key),
+		zap.Duration("ttl", ttl),
+		zap.Int64("fencing_token", lock.token),
+	)
+
+	return true, nil
+}
+
+// AcquireWait is like Acquire, but while another instance holds the lock it retries
+// with backoff for up to maxWait. Returns false if the lock wasn't obtained in time.
+func (p *PostgresLocker) AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error) {
+	return acquireWait(ctx, key, maxWait, func(ctx context.Context) (bool, error) {
+		return p.Acquire(ctx, key, ttl)
+	})
+}
+
+// Release releases the lock if this instance holds it; otherwise it's a no-op.
+func (p *PostgresLocker) Release(ctx context.Context, key string) error {
+	p.mu.Lock()
+	lock, exists := p.held[key]
+	if exists {
+		delete(p.held, key)
+		lock.expiry.Stop()
+	}
+	p.mu.Unlock()
+
+	if !exists {
+		p.logger.Debug("lock not owned by this instance or already expired",
+			zap.String("key", key),
+		)
+
+		return nil
+	}
+
+	return p.unlock(ctx, key, lock)
+}
+
+// Renew keeps the lock identified by key from expiring until stop is called or ctx
+// is done; its TTL then starts over. The lock lives as long as its session; only the
+// recorded expiry is moved forward every interval.
+func (p *PostgresLocker) Renew(ctx context.Context, key string, interval time.Duration) (stop func()) {
+	p.mu.Lock()
+	lock, exists := p.held[key]
+	if exists {
+		lock.expiry.Stop()
+		lock.renewing = true
+	}
+	p.mu.Unlock()
+
+	if !exists {
+		p.logger.Debug("no lock found for key, nothing to renew",
+			zap.String("key", key),
+		)
+
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+	renewal:
+		for {
+			select {
+			case <-ctx.Done():
+				break renewal
+			case <-ticker.C:
+			}
+
+			p.renewHolder(ctx, key, lock)
+		}
+
+		p.mu.Lock()
+		lock.renewing = false
+		if p.held[key] == lock {
+			lock.expiry.Reset(lock.ttl)
+		}
+		p.mu.Unlock()
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Extend restarts the TTL of the lock identified by key once, after checking that its
+// session still holds it. Returns false if this instance doesn't hold the lock, e.g.
+// because it expired or its session was terminated by ForceRelease.
+func (p *PostgresLocker) Extend(ctx context.Context, key string) (bool, error) {
+	p.mu.Lock()
+	lock, exists := p.held[key]
+	p.mu.Unlock()
+
+	if !exists {
+		return false, nil
+	}
+
+	var held bool
+	if err := lock.conn.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pg_locks l WHERE l.pid = pg_backend_pid() AND `+heldAdvisoryLock+`)
+	`, key).Scan(&held); err != nil {
+		return false, fmt.Errorf("extend lock %s: %w", key, err)
+	}
+
+	p.mu.Lock()
+	if p.held[key] != lock || (!lock.renewing && !lock.expiry.Stop()) {
+		held = false // Expired meanwhile
+	}
+	if held && !lock.renewing {
+		lock.expiry.Reset(lock.ttl)
+	}
+	p.mu.Unlock()
+
+	if !held {
+		return false, nil
+	}
+	p.renewHolder(ctx, key, lock)
+
+	return true, nil
+}
+
+// renewHolder moves the recorded expiry of lock forward to its full TTL.
+func (p *PostgresLocker) renewHolder(ctx context.Context, key string, lock *advisoryLock) {
+	if _, err := p.db.ExecContext(ctx, `
+		UPDATE lock_holders SET expires_at = now() + $3 * interval '1 millisecond'
+		WHERE lock_key = $1 AND token = $2
+	`, key, lock.token, lock.ttl.Milliseconds()); err != nil && ctx.Err() == nil {
+		p.logger.Warn("failed to renew lock holder", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// FenceScope returns "postgres".
+func (p *PostgresLocker) FenceScope() string {
+	return "postgres"
+}
+
+// FencingToken returns the fencing token issued when this instance acquired the lock
+// identified by key. Returns false if this instance doesn't hold the lock.
+func (p *PostgresLocker) FencingToken(key string) (int64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lock, ok := p.held[key]
+	if !ok {
+		return 0, false
+	}
+
+	return lock.token, true
+}
+
+// heldAdvisoryLock matches the pg_locks row of the advisory lock for the key in $1.
+// A bigint key is shown split into classid (high half) and objid (low half).
+const heldAdvisoryLock = `
+	l.locktype = 'advisory' AND l.granted AND l.objsubid = 1
+	AND l.classid::bigint = (hashtextextended($1, 0) >> 32) & 4294967295
+	AND l.objid::bigint = hashtextextended($1, 0) & 4294967295
+`
+
+// Locks returns the locks held by any instance, sorted by key.
+func (p *PostgresLocker) Locks(ctx context.Context) ([]LockInfo, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT h.lock_key, h.holder, h.token, h.acquired_at,
+			(EXTRACT(EPOCH FROM GREATEST(h.expires_at - now(), interval '0')) * 1000000)::bigint
+		FROM lock_holders h
+		WHERE EXISTS (
+			SELECT 1 FROM pg_locks l
+			WHERE l.pid = h.pid AND `+strings.ReplaceAll(heldAdvisoryLock, "$1", "h.lock_key")+`
+		)
+		ORDER BY h.lock_key
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var locks []LockInfo
+	for rows.Next() {
+		var info LockInfo
+		var ttlMicros int64
+		if err := rows.Scan(&info.Key, &info.Holder, &info.Token, &info.AcquiredAt, &ttlMicros); err != nil {
+			return nil, fmt.Errorf("list locks: %w", err)
+		}
+		info.TTL = time.Duration(ttlMicros) * time.Microsecond
+		locks = append(locks, info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+
+	return locks, nil
+}
+
+// ForceRelease terminates the database session holding the lock identified by key,
+// which releases it whoever holds it, and waits up to releaseTimeout for the session
+// to end. Returns false if the lock wasn't held.
+func (p *PostgresLocker) ForceRelease(ctx context.Context, key string) (bool, error) {
+	var terminated int
+	if err := p.db.QueryRowContext(ctx, `
+		SELECT count(*) FILTER (WHERE pg_terminate_backend(l.pid, $2)) FROM pg_locks l WHERE `+heldAdvisoryLock,
+		key, releaseTimeout.Milliseconds(),
+	).Scan(&terminated); err != nil {
+		return false, fmt.Errorf("force release lock %s: %w", key, err)
+	}
+
+	if _, err := p.db.ExecContext(ctx, "DELETE FROM lock_holders WHERE lock_key = $1", key); err != nil {
+		return false, fmt.Errorf("force release lock %s: %w", key, err)
+	}
+
+	return terminated > 0, nil
+}
+
+// expire releases lock once its TTL ran out, unless it was released in the meantime.
+func (p *PostgresLocker) expire(key string, lock *advisoryLock) {
+	p.mu.Lock()
+	if p.held[key] != lock {
+		p.mu.Unlock()
+
+		return
+	}
+	delete(p.held, key)
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := p.unlock(ctx, key, lock); err != nil {
+		p.logger.Warn("failed to release expired lock", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// unlock releases the advisory lock and returns its connection to the pool.
+func (p *PostgresLocker) unlock(ctx context.Context, key string, lock *advisoryLock) error {
+	if _, err := lock.conn.ExecContext(ctx, "DELETE FROM lock_holders WHERE lock_key = $1 AND token = $2", key, lock.token); err != nil {
+		p.logger.Warn("failed to clear lock holder", zap.String("key", key), zap.Error(err))
+	}
+
+	var released bool
+	if err := lock.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock(hashtextextended($1, 0))", key).Scan(&released); err != nil {
+		// The session may still hold the lock; closing it is the only safe option
+		discard(lock.conn)
+
+		return fmt.Errorf("release lock %s: %w", key, err)
+	}
+	_ = lock.conn.Close()
+
+	p.logger.Debug("lock released",
+		zap.String("key", key),
+		zap.Bool("held", released),
+	)
+
+	return nil
+}
+
+// discard closes the connection instead of returning it to the pool, ending its
+// session and with it any advisory lock it holds.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -0,0 +1,182 @@
+package locker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // database/sql driver "pgx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	postgresContainer "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.uber.org/zap"
+)
+
+// setupTestPostgres starts a PostgreSQL container with the fencing token sequence.
+// Requires Docker; skip with go test -short.
+func setupTestPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+
+	ctx := context.Background()
+	pgContainer, err := postgresContainer.Run(ctx,
+		"postgres:16-alpine",
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	require.NoError(t, err, "Failed to start PostgreSQL container")
+	t.Cleanup(func() { _ = pgContainer.Terminate(ctx) })
+
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("pgx", connStr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.ExecContext(ctx, `
+		CREATE SEQUENCE lock_fencing_tokens;
+		CREATE TABLE lock_holders (
+			lock_key VARCHAR(255) PRIMARY KEY,
+			holder VARCHAR(255) NOT NULL,
+			token BIGINT NOT NULL,
+			pid INTEGER NOT NULL,
+			acquired_at TIMESTAMPTZ NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresLocker(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestPostgres(t)
+	locker1 := NewPostgresLocker(db, zap.NewNop())
+	locker2 := NewPostgresLocker(db, zap.NewNop())
+	ctx := context.Background()
+
+	t.Run("excludes other instances", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the lock is held by locker1")
+
+		acquired, err = locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the lock can't be reentered")
+
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the lock is free after release")
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 200*time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		assert.Eventually(t, func() bool {
+			acquired, err := locker2.Acquire(ctx, testLockKey, 5*time.Second)
+
+			return err == nil && acquired
+		}, 5*time.Second, 50*time.Millisecond)
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+	})
+
+	t.Run("renewal keeps the lock past its ttl", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 200*time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		stop := locker1.Renew(ctx, testLockKey, 100*time.Millisecond)
+		time.Sleep(500 * time.Millisecond)
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the renewed lock is still held")
+
+		stop()
+		require.NoError(t, locker1.Release(ctx, testLockKey))
+	})
+
+	t.Run("issues increasing fencing tokens", func(t *testing.T) {
+		var tokens []int64
+		for _, l := range []*PostgresLocker{locker1, locker2} {
+			acquired, err := l.Acquire(ctx, testLockKey, 5*time.Second)
+			require.NoError(t, err)
+			require.True(t, acquired)
+
+			token, ok := l.FencingToken(testLockKey)
+			require.True(t, ok)
+			tokens = append(tokens, token)
+			require.NoError(t, l.Release(ctx, testLockKey))
+		}
+
+		assert.Greater(t, tokens[1], tokens[0])
+	})
+
+	t.Run("lists and force-releases locks", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		token, _ := locker1.FencingToken(testLockKey)
+
+		locks, err := locker2.Locks(ctx)
+		require.NoError(t, err)
+		require.Len(t, locks, 1)
+		assert.Equal(t, testLockKey, locks[0].Key)
+		assert.Equal(t, InstanceID(), locks[0].Holder)
+		assert.Equal(t, token, locks[0].Token)
+		assert.Positive(t, locks[0].TTL)
+
+		released, err := locker2.ForceRelease(ctx, testLockKey)
+		require.NoError(t, err)
+		assert.True(t, released)
+
+		locks, err = locker2.Locks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, locks)
+
+		acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the lock is free after a forced release")
+		require.NoError(t, locker2.Release(ctx, testLockKey))
+		// locker1's session was terminated, so its release fails, but it doesn't hold the lock anymore
+		_ = locker1.Release(ctx, testLockKey)
+		_, ok := locker1.FencingToken(testLockKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("extension notices a terminated session", func(t *testing.T) {
+		acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		ok, err := locker1.Extend(ctx, testLockKey)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		_, err = locker2.ForceRelease(ctx, testLockKey)
+		require.NoError(t, err)
+
+		_, err = locker1.Extend(ctx, testLockKey)
+		assert.Error(t, err, "the session is gone")
+		_ = locker1.Release(ctx, testLockKey)
+	})
+}
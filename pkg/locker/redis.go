@@ -2,7 +2,10 @@ package locker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,13 +19,35 @@ import (
 // RedisLocker implements DistributedLocker using the Redsync library.
 // Redsync implements the Redlock algorithm for distributed mutual exclusion,
 // providing production-ready distributed locking with proper failure handling.
+//
+// It also implements Renewer, Fencer and Inspector. Fencing tokens come from a Redis
+// counter per lock key (<key>:fence), incremented on every acquisition. Holder metadata
+// is kept in a hash (<key>:holder) expiring with the lock, and lock keys are indexed
+// in a set (locker:keys) so they can be listed.
+//
+// The counters are lost when Redis is flushed or restarts without persistence; with
+// WithTokenFloor, a counter starting over is lifted above the highest token the store
+// accepted, so fenced writes aren't all rejected afterwards.
 type RedisLocker struct {
+	client  *redis.Client
 	rs      *redsync.Redsync
 	logger  *zap.Logger
+	floor   TokenFloor // nil leaves restarted counters as they are
 	mutexes map[string]*redsync.Mutex
+	tokens  map[string]int64
 	mu      sync.Mutex
 }
 
+// RedisOption configures a RedisLocker.
+type RedisOption func(*RedisLocker)
+
+// WithTokenFloor lifts fencing token counters starting over above floor.
+func WithTokenFloor(floor TokenFloor) RedisOption {
+	return func(r *RedisLocker) {
+		r.floor = floor
+	}
+}
+
 // NewRedisLocker creates a new Redis-based distributed locker using Redsync.
 //
 // Redsync implements the Redlock algorithm as described in Redis documentation:
@@ -33,15 +58,22 @@ type RedisLocker struct {
 // - Automatic expiration to prevent deadlocks
 // - Protection against clock drift and network issues
 // - Battle-tested reliability (used by Sourcegraph, Google, etc.)
-func NewRedisLocker(client *redis.Client, logger *zap.Logger) *RedisLocker {
+func NewRedisLocker(client *redis.Client, logger *zap.Logger, opts ...RedisOption) *RedisLocker {
 	pool := goredis.NewPool(client)
 	rs := redsync.New(pool)
 
-	return &RedisLocker{
+	r := &RedisLocker{
+		client:  client,
 		rs:      rs,
 		logger:  logger,
 		mutexes: make(map[string]*redsync.Mutex),
+		tokens:  make(map[string]int64),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
 // Acquire attempts to acquire a distributed lock using the Redlock algorithm.
@@ -54,6 +86,7 @@ func NewRedisLocker(client *redis.Client, logger *zap.Logger) *RedisLocker {
 // - Uses Redsync's NewMutex with expiry and tries=1 (non-blocking)
 // - Returns false (not error) when lock is already held
 // - Stores mutex reference for proper release
+// - Issues the next fencing token of key (see FencingToken)
 // - Safe for concurrent use across multiple instances
 func (r *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
 	// Create a mutex with the specified TTL and single try (non-blocking)
@@ -81,19 +114,54 @@ func (r *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration
 		return false, fmt.Errorf("acquire lock %s: %w", key, err)
 	}
 
+	token, err := r.issueToken(ctx, key)
+	if err != nil {
+		// A lock without a token can't protect fenced writes; give it back
+		if _, unlockErr := mutex.UnlockContext(context.WithoutCancel(ctx)); unlockErr != nil {
+			r.logger.Warn("failed to release lock after fencing token error",
+				zap.String("key", key),
+				zap.Error(unlockErr),
+			)
+		}
+
+		return false, fmt.Errorf("issue fencing token for lock %s: %w", key, err)
+	}
+
 	// Store mutex for later release
 	r.mu.Lock()
 	r.mutexes[key] = mutex
+	r.tokens[key] = token
 	r.mu.Unlock()
 
+	// Metadata is informational; the lock stands without it
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, holderKey(key), "holder", instanceID, "acquired_at", time.Now().UTC().Format(time.RFC3339Nano), "token", token)
+		pipe.PExpireAt(ctx, holderKey(key), mutex.Until())
+		pipe.SAdd(ctx, lockIndexKey, key)
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Warn("failed to record lock holder", zap.String("key", key), zap.Error(err))
+	}
+
 	r.logger.Debug("lock acquired",
 		zap.String("key", key),
 		zap.Duration("ttl", ttl),
+		zap.Int64("fencing_token", token),
 	)
 
 	return true, nil
 }
 
+// AcquireWait is like Acquire, but while another instance holds the lock it retries
+// with backoff for up to maxWait. Returns false if the lock wasn't obtained in time.
+func (r *RedisLocker) AcquireWait(ctx context.Context, key string, ttl, maxWait time.Duration) (bool, error) {
+	return acquireWait(ctx, key, maxWait, func(ctx context.Context) (bool, error) {
+		return r.Acquire(ctx, key, ttl)
+	})
+}
+
 // Release releases the lock if and only if this instance owns it.
 //
 // Redsync handles token verification internally, ensuring that:
@@ -105,6 +173,7 @@ func (r *RedisLocker) Release(ctx context.Context, key string) error {
 	mutex, exists := r.mutexes[key]
 	if exists {
 		delete(r.mutexes, key)
+		delete(r.tokens, key)
 	}
 	r.mu.Unlock()
 
@@ -123,6 +192,10 @@ func (r *RedisLocker) Release(ctx context.Context, key string) error {
 	}
 
 	if ok {
+		// Only the holder's metadata; a new holder's would be written after it
+		if err := r.client.Del(ctx, holderKey(key)).Err(); err != nil {
+			r.logger.Warn("failed to clear lock holder", zap.String("key", key), zap.Error(err))
+		}
 		r.logger.Debug("lock released",
 			zap.String("key", key),
 		)
@@ -134,3 +207,218 @@ func (r *RedisLocker) Release(ctx context.Context, key string) error {
 
 	return nil
 }
+
+// FenceScope returns "redis".
+func (r *RedisLocker) FenceScope() string {
+	return "redis"
+}
+
+// FencingToken returns the fencing token issued when this instance acquired the lock
+// identified by key. Returns false if this instance doesn't hold the lock.
+func (r *RedisLocker) FencingToken(key string) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[key]
+
+	return token, ok
+}
+
+// Locks returns the locks held by any instance, sorted by key. Keys of expired
+// locks are dropped from the index along the way.
+func (r *RedisLocker) Locks(ctx context.Context) ([]LockInfo, error) {
+	keys, err := r.client.SMembers(ctx, lockIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+	sort.Strings(keys)
+
+	ttls := make([]*redis.DurationCmd, len(keys))
+	holders := make([]*redis.MapStringStringCmd, len(keys))
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			ttls[i] = pipe.PTTL(ctx, key)
+			holders[i] = pipe.HGetAll(ctx, holderKey(key))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+
+	locks := make([]LockInfo, 0, len(keys))
+	var expired []any
+	for i, key := range keys {
+		ttl := ttls[i].Val()
+		if ttl < 0 {
+			// -2: the lock is gone; -1 can't happen for redsync keys
+			expired = append(expired, key)
+
+			continue
+		}
+
+		info := LockInfo{Key: key, TTL: ttl}
+		holder := holders[i].Val()
+		info.Holder = holder["holder"]
+		info.AcquiredAt, _ = time.Parse(time.RFC3339Nano, holder["acquired_at"])
+		info.Token, _ = strconv.ParseInt(holder["token"], 10, 64)
+		locks = append(locks, info)
+	}
+
+	if len(expired) > 0 {
+		if err := r.client.SRem(ctx, lockIndexKey, expired...).Err(); err != nil {
+			r.logger.Warn("failed to prune lock index", zap.Error(err))
+		}
+	}
+
+	return locks, nil
+}
+
+// ForceRelease deletes the lock identified by key whoever holds it.
+// Returns false if the lock wasn't held.
+func (r *RedisLocker) ForceRelease(ctx context.Context, key string) (bool, error) {
+	var deleted *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, key)
+		pipe.Del(ctx, holderKey(key))
+		pipe.SRem(ctx, lockIndexKey, key)
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("force release lock %s: %w", key, err)
+	}
+
+	return deleted.Val() > 0, nil
+}
+
+// lockIndexKey is the Redis set of lock keys that may be held.
+const lockIndexKey = "locker:keys"
+
+// holderKey returns the Redis key of the holder metadata of a lock.
+func holderKey(key string) string {
+	return key + ":holder"
+}
+
+// issueToken increments the fencing token counter of key. A counter starting over
+// (new, or lost with the Redis data) is lifted above the token floor.
+func (r *RedisLocker) issueToken(ctx context.Context, key string) (int64, error) {
+	token, err := r.client.Incr(ctx, fenceCounterKey(key)).Result()
+	if err != nil || token != 1 || r.floor == nil {
+		return token, err
+	}
+
+	mark, err := r.floor(ctx, r.FenceScope()+":"+key)
+	if err != nil {
+		return 0, fmt.Errorf("reading token floor: %w", err)
+	}
+	if mark < token {
+		return token, nil
+	}
+
+	r.logger.Warn("fencing token counter started over, lifting it above the stored token",
+		zap.String("key", key),
+		zap.Int64("stored_token", mark),
+	)
+
+	return r.client.IncrBy(ctx, fenceCounterKey(key), mark).Result()
+}
+
+// fenceCounterKey returns the Redis key of the fencing token counter of a lock.
+// The counter has no TTL; tokens must keep increasing across lock expirations.
+func fenceCounterKey(key string) string {
+	return key + ":fence"
+}
+
+// Renew extends the lock identified by key to its full TTL every interval, until
+// stop is called or ctx is done. Uses Redsync's Extend, which only succeeds while
+// this instance still owns the lock; renewal ends on the first failure.
+func (r *RedisLocker) Renew(ctx context.Context, key string, interval time.Duration) (stop func()) {
+	r.mu.Lock()
+	mutex, exists := r.mutexes[key]
+	r.mu.Unlock()
+
+	if !exists {
+		r.logger.Debug("no mutex found for key, nothing to renew",
+			zap.String("key", key),
+		)
+
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			ok, err := r.extend(ctx, key, mutex)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil || !ok {
+				r.logger.Warn("lock renewal failed, lock may be acquired by another instance",
+					zap.String("key", key),
+					zap.Error(err),
+				)
+
+				return
+			}
+
+			r.logger.Debug("lock renewed",
+				zap.String("key", key),
+				zap.Time("until", mutex.Until()),
+			)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Extend extends the lock identified by key to its full TTL once. Returns false if
+// this instance doesn't hold the lock or it expired.
+func (r *RedisLocker) Extend(ctx context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	mutex, exists := r.mutexes[key]
+	r.mu.Unlock()
+
+	if !exists {
+		return false, nil
+	}
+
+	return r.extend(ctx, key, mutex)
+}
+
+// extend extends mutex and the holder record of key. Only failures to reach Redis
+// are errors; otherwise a failed extension means the lock is held elsewhere or gone.
+func (r *RedisLocker) extend(ctx context.Context, key string, mutex *redsync.Mutex) (bool, error) {
+	ok, err := mutex.ExtendContext(ctx)
+	if !ok {
+		var redisErr *redsync.RedisError
+		if errors.As(err, &redisErr) {
+			return false, fmt.Errorf("extend lock %s: %w", key, err)
+		}
+
+		return false, nil
+	}
+
+	if err := r.client.PExpireAt(ctx, holderKey(key), mutex.Until()).Err(); err != nil && ctx.Err() == nil {
+		r.logger.Warn("failed to renew lock holder", zap.String("key", key), zap.Error(err))
+	}
+
+	return true, nil
+}
@@ -174,3 +174,257 @@ func TestRedisLocker_ContextCancellation(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, acquired)
 }
+
+func TestRedisLocker_Renew_ExtendsUntilStopped(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+	ttl := time.Second
+
+	acquired, err := locker.Acquire(ctx, testLockKey, ttl)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// The operation has almost used up the TTL
+	mr.FastForward(900 * time.Millisecond)
+
+	stop := locker.Renew(ctx, testLockKey, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return mr.TTL(testLockKey) > 500*time.Millisecond
+	}, time.Second, 5*time.Millisecond, "renewal should restore the full TTL")
+	stop()
+
+	// Without renewal the lock expires
+	mr.FastForward(2 * ttl)
+	assert.False(t, mr.Exists(testLockKey))
+}
+
+func TestRedisLocker_Renew_NotOwned(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+
+	stop := locker.Renew(context.Background(), testLockKey, 10*time.Millisecond)
+	stop()
+	stop() // Stopping twice is harmless
+}
+
+func TestRedisLocker_AcquireWait_QueuesBehindHolder(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	waiter := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := holder.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = holder.Release(ctx, testLockKey)
+	}()
+
+	acquired, err = waiter.AcquireWait(ctx, testLockKey, time.Minute, 5*time.Second)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the waiter should get the lock once it's released")
+}
+
+func TestRedisLocker_AcquireWait_GivesUpAfterMaxWait(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	waiter := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := holder.Acquire(ctx, testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	start := time.Now()
+	acquired, err = waiter.AcquireWait(ctx, testLockKey, time.Minute, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRedisLocker_AcquireWait_ContextCancelled(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	waiter := NewRedisLocker(client, zap.NewNop())
+
+	acquired, err := holder.Acquire(context.Background(), testLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	acquired, err = waiter.AcquireWait(ctx, testLockKey, time.Minute, time.Minute)
+	assert.Error(t, err)
+	assert.False(t, acquired)
+}
+
+func TestRedisLocker_TokenFloorLiftsCounterStartingOver(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	var floorKey string
+	locker := NewRedisLocker(client, zap.NewNop(), WithTokenFloor(func(_ context.Context, fenceKey string) (int64, error) {
+		floorKey = fenceKey
+
+		return 41, nil // Accepted by the store before Redis lost its data
+	}))
+	ctx := context.Background()
+
+	acquire := func() int64 {
+		t.Helper()
+
+		acquired, err := locker.Acquire(ctx, testLockKey, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		token, ok := locker.FencingToken(testLockKey)
+		require.True(t, ok)
+		require.NoError(t, locker.Release(ctx, testLockKey))
+
+		return token
+	}
+
+	assert.Equal(t, int64(42), acquire())
+	assert.Equal(t, "redis:"+testLockKey, floorKey)
+	assert.Equal(t, int64(43), acquire(), "the lifted counter goes on from there")
+
+	mr.FlushAll()
+	assert.Equal(t, int64(42), acquire(), "a flushed counter is lifted again")
+}
+
+func TestRedisLocker_FencingTokensIncrease(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker1 := NewRedisLocker(client, zap.NewNop())
+	locker2 := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := locker1.Acquire(ctx, testLockKey, 5*time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	first, ok := locker1.FencingToken(testLockKey)
+	require.True(t, ok)
+
+	_, ok = locker2.FencingToken(testLockKey)
+	assert.False(t, ok, "only the holder has a token")
+
+	require.NoError(t, locker1.Release(ctx, testLockKey))
+	_, ok = locker1.FencingToken(testLockKey)
+	assert.False(t, ok, "the token is dropped on release")
+
+	acquired, err = locker2.Acquire(ctx, testLockKey, 5*time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	second, ok := locker2.FencingToken(testLockKey)
+	require.True(t, ok)
+
+	assert.Greater(t, second, first, "a later acquisition gets a greater token")
+}
+
+func TestRedisLocker_LocksListsHolders(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker1 := NewRedisLocker(client, zap.NewNop())
+	locker2 := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	for _, key := range []string{"b:lock", "a:lock"} {
+		acquired, err := locker1.Acquire(ctx, key, 5*time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+	}
+
+	locks, err := locker2.Locks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 2, "locks held by other instances are listed")
+	assert.Equal(t, "a:lock", locks[0].Key)
+	assert.Equal(t, "b:lock", locks[1].Key)
+	token, _ := locker1.FencingToken("a:lock")
+	assert.Equal(t, token, locks[0].Token)
+	assert.Equal(t, InstanceID(), locks[0].Holder)
+	assert.WithinDuration(t, time.Now(), locks[0].AcquiredAt, 5*time.Second)
+	assert.Positive(t, locks[0].TTL)
+	assert.LessOrEqual(t, locks[0].TTL, 5*time.Second)
+
+	require.NoError(t, locker1.Release(ctx, "a:lock"))
+
+	locks, err = locker2.Locks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 1, "released locks are dropped")
+	assert.Equal(t, "b:lock", locks[0].Key)
+}
+
+func TestRedisLocker_ForceRelease(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	operator := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := holder.Acquire(ctx, testLockKey, 5*time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	released, err := operator.ForceRelease(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	locks, err := operator.Locks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+
+	acquired, err = operator.Acquire(ctx, testLockKey, 5*time.Second)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the lock is free after a forced release")
+	require.NoError(t, operator.Release(ctx, testLockKey))
+
+	released, err = operator.ForceRelease(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.False(t, released, "a free lock isn't released")
+}
+
+func TestRedisLocker_Extend(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	operator := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	ok, err := holder.Extend(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.False(t, ok, "a lock that isn't held can't be extended")
+
+	acquired, err := holder.Acquire(ctx, testLockKey, time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	ok, err = holder.Extend(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = operator.ForceRelease(ctx, testLockKey)
+	require.NoError(t, err)
+
+	ok, err = holder.Extend(ctx, testLockKey)
+	require.NoError(t, err)
+	assert.False(t, ok, "a force-released lock is lost")
+}
@@ -0,0 +1,104 @@
+package locker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLockHeld is returned by WithLock when another instance holds the lock.
+var ErrLockHeld = errors.New("lock held by another instance")
+
+// Option configures WithLock.
+type Option func(*lockOptions)
+
+type lockOptions struct {
+	maxWait       time.Duration
+	holdOnSuccess bool
+}
+
+// Wait makes WithLock wait up to maxWait for the lock (see DistributedLocker.AcquireWait)
+// instead of failing right away.
+func Wait(maxWait time.Duration) Option {
+	return func(o *lockOptions) {
+		o.maxWait = maxWait
+	}
+}
+
+// HoldOnSuccess keeps the lock until its TTL expires when fn succeeds (cooldown model),
+// so no instance repeats the operation within the TTL. It's still released when fn fails.
+func HoldOnSuccess() Option {
+	return func(o *lockOptions) {
+		o.holdOnSuccess = true
+	}
+}
+
+// WithLock runs fn while holding the lock identified by key, and releases the lock
+// afterwards, including when fn panics. Returns ErrLockHeld (wrapped) without running
+// fn if another instance holds the lock, otherwise fn's error.
+// Lockers implementing Renewer keep the lock alive while fn runs longer than ttl, and
+// lockers implementing Fencer pass the lock's fencing token to fn (see FenceFromContext).
+//
+// Typical usage:
+//
+//	err := locker.WithLock(ctx, l, "my-lock", time.Minute, func(ctx context.Context) error {
+//	    return doWork(ctx)
+//	})
+//	if errors.Is(err, locker.ErrLockHeld) {
+//	    return nil // Another instance is doing the work
+//	}
+func WithLock(
+	ctx context.Context,
+	l DistributedLocker,
+	key string,
+	ttl time.Duration,
+	fn func(ctx context.Context) error,
+	opts ...Option,
+) (err error) {
+	var o lockOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var acquired bool
+	if o.maxWait > 0 {
+		acquired, err = l.AcquireWait(ctx, key, ttl, o.maxWait)
+	} else {
+		acquired, err = l.Acquire(ctx, key, ttl)
+	}
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return fmt.Errorf("%w: %s", ErrLockHeld, key)
+	}
+
+	stopRenewal := func() {}
+	if renewer, ok := l.(Renewer); ok && ttl > 0 {
+		stopRenewal = renewer.Renew(ctx, key, ttl/3)
+	}
+
+	fnCtx := ctx
+	if fencer, ok := l.(Fencer); ok {
+		if fence, ok := FenceOf(fencer, key); ok {
+			fnCtx = ContextWithFence(ctx, fence)
+		}
+	}
+
+	panicked := true
+	defer func() {
+		stopRenewal()
+		if panicked || err != nil || !o.holdOnSuccess {
+			// ctx may be done by now; the lock must be released regardless
+			if releaseErr := l.Release(context.WithoutCancel(ctx), key); releaseErr != nil && !panicked {
+				err = errors.Join(err, releaseErr)
+			}
+		}
+	}()
+
+	err = fn(fnCtx)
+	panicked = false
+
+	return err
+}
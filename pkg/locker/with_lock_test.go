@@ -0,0 +1,139 @@
+package locker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWithLock_ReleasesAfterSuccess(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	ran := false
+	err := WithLock(ctx, locker, testLockKey, 5*time.Second, func(ctx context.Context) error {
+		ran = true
+		exists, err := client.Exists(ctx, testLockKey).Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), exists, "lock should be held while fn runs")
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	exists, err := client.Exists(ctx, testLockKey).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists, "lock should be released after fn returns")
+}
+
+func TestWithLock_HoldOnSuccessKeepsLock(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	err := WithLock(ctx, locker, testLockKey, 5*time.Second, func(context.Context) error {
+		return nil
+	}, HoldOnSuccess())
+	require.NoError(t, err)
+
+	exists, err := client.Exists(ctx, testLockKey).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), exists, "lock should be held for the cooldown")
+}
+
+func TestWithLock_ReleasesOnError(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	err := WithLock(ctx, locker, testLockKey, 5*time.Second, func(context.Context) error {
+		return errFailed
+	}, HoldOnSuccess())
+	require.ErrorIs(t, err, errFailed)
+
+	exists, err := client.Exists(ctx, testLockKey).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists, "lock should be released so another instance can retry")
+}
+
+func TestWithLock_ReleasesOnPanic(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = WithLock(ctx, locker, testLockKey, 5*time.Second, func(context.Context) error {
+			panic("boom")
+		}, HoldOnSuccess())
+	})
+
+	exists, err := client.Exists(ctx, testLockKey).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists, "lock should be released when fn panics")
+}
+
+func TestWithLock_LockHeld(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	holder := NewRedisLocker(client, zap.NewNop())
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	acquired, err := holder.Acquire(ctx, testLockKey, 5*time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	ran := false
+	err = WithLock(ctx, locker, testLockKey, 5*time.Second, func(context.Context) error {
+		ran = true
+
+		return nil
+	}, Wait(100*time.Millisecond))
+
+	assert.ErrorIs(t, err, ErrLockHeld)
+	assert.False(t, ran, "fn must not run without the lock")
+}
+
+func TestWithLock_PassesFencingToken(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	locker := NewRedisLocker(client, zap.NewNop())
+	ctx := context.Background()
+
+	var fences []Fence
+	for range 2 {
+		err := WithLock(ctx, locker, testLockKey, 5*time.Second, func(ctx context.Context) error {
+			fence, ok := FenceFromContext(ctx)
+			require.True(t, ok)
+			fences = append(fences, fence)
+
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, fences, 2)
+	assert.Equal(t, "redis:"+testLockKey, fences[0].Key, "fences are scoped by backend")
+	assert.Greater(t, fences[1].Token, fences[0].Token)
+
+	_, ok := FenceFromContext(ctx)
+	assert.False(t, ok, "the caller's context is left alone")
+}
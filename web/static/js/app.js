@@ -1,10 +1,26 @@
 /**
  * Search Engine Dashboard - Vue.js 3 Application
  * 
- * Provides real-time search, filtering, sorting, and pagination
- * for content visualization.
+ * Provides real-time search with faceted filtering, highlighting,
+ * sorting, and pagination for content visualization, editorial
+ * curation of contents, per-provider syncs, and history charts. Sync
+ * progress, the content count and the providers panel are kept current
+ * over the dashboard socket.
  */
 
+// Chart.js instances by chart name. Kept out of the reactive data since
+// Vue proxies break Chart.js internals.
+const charts = {};
+
+// Colors of chart series, assigned in order
+const chartColors = ['#3b82f6', '#8b5cf6', '#10b981', '#f59e0b', '#db2777', '#0891b2'];
+
+// Delay before reconnecting a closed dashboard socket
+const liveReconnectDelay = 5000;
+
+// Content count and providers rendered into the page by the server
+const initialState = JSON.parse(document.getElementById('dashboard-state')?.textContent || '{}');
+
 const app = Vue.createApp({
     // Use custom delimiters to avoid conflict with Go templates
     delimiters: ['${', '}'],
@@ -13,12 +29,30 @@ const app = Vue.createApp({
         return {
             // Content data
             contents: [],
+            contentCount: initialState.content_count || 0,
+
+            // Providers panel
+            providers: initialState.providers || [],
+
+            // Editorial curation: pinned, blocked and boosted contents
+            curated: [],
 
             // Search & Filter state
             query: '',
+            searchMode: 'prefix',
             sortBy: '',
             sortOrder: 'desc',
             typeFilter: 'all',
+            providerFilter: 'all',
+            tagFilter: 'all',
+            durationFilter: 'all',
+
+            // Matching contents per type, provider, tag and duration, backing the filters
+            facets: { types: [], providers: [], tags: [], durations: [] },
+
+            // History charts
+            chartDays: 30,
+            chartsAvailable: false,
 
             // Pagination state
             page: 1,
@@ -26,9 +60,15 @@ const app = Vue.createApp({
             total: 0,
             totalPages: 0,
 
+            // Live updates: the dashboard socket and the running sync, if any
+            socket: null,
+            syncProgress: null,
+
             // UI state
             loading: false,
             syncing: false,
+            syncingProvider: null,
+            curating: false,
             error: null,
 
             // Debounce timer
@@ -51,6 +91,22 @@ const app = Vue.createApp({
          */
         hasPrevPage() {
             return this.page > 1;
+        },
+
+        /**
+         * Editorial flags of curated contents by content ID.
+         * @returns {Object}
+         */
+        curationById() {
+            return Object.fromEntries(this.curated.map(c => [c.id, c]));
+        },
+
+        /**
+         * Lowercased search terms to highlight in the results.
+         * @returns {string[]}
+         */
+        queryTerms() {
+            return this.query.trim().toLowerCase().split(/\s+/).filter(Boolean);
         }
     },
 
@@ -58,22 +114,17 @@ const app = Vue.createApp({
         /**
          * Fetches contents from the API with current filters and pagination.
          * Updates the contents list and pagination metadata.
+         * @param {boolean} [force=false] - Read the database instead of the cache
          */
-        async fetchContents() {
+        async fetchContents(force = false) {
             this.loading = true;
             this.error = null;
 
             try {
                 // Build query params
-                const params = new URLSearchParams({
-                    page: this.page.toString(),
-                    page_size: this.pageSize.toString()
-                });
-
-                // Add search query if present
-                if (this.query.trim()) {
-                    params.set('q', this.query.trim());
-                }
+                const params = this.filterParams();
+                params.set('page', this.page.toString());
+                params.set('page_size', this.pageSize.toString());
 
                 // Add sort parameter
                 if (this.sortBy) {
@@ -85,12 +136,9 @@ const app = Vue.createApp({
                     params.set('sort_order', this.sortOrder);
                 }
 
-                // Add type filter if not 'all'
-                if (this.typeFilter && this.typeFilter !== 'all') {
-                    params.set('type', this.typeFilter);
-                }
-
-                const response = await fetch(`/api/v1/contents?${params}`);
+                // Honored for authenticated callers only, or when auth is disabled
+                const headers = force ? { 'X-Refresh': 'true' } : {};
+                const response = await fetch(`/api/v2/contents?${params}`, { headers });
 
                 if (!response.ok) {
                     throw new Error(`HTTP ${response.status}: ${response.statusText}`);
@@ -113,16 +161,113 @@ const app = Vue.createApp({
             }
         },
 
+        /**
+         * Builds the query params shared by searches and facets: the search
+         * query with its mode and the filters not set to 'all'.
+         * @returns {URLSearchParams}
+         */
+        filterParams() {
+            const params = new URLSearchParams();
+
+            if (this.query.trim()) {
+                params.set('q', this.query.trim());
+                params.set('mode', this.searchMode);
+            }
+
+            const filters = {
+                type: this.typeFilter,
+                provider: this.providerFilter,
+                tag: this.tagFilter,
+                duration: this.durationFilter
+            };
+            for (const [name, value] of Object.entries(filters)) {
+                if (value && value !== 'all') {
+                    params.set(name, value);
+                }
+            }
+
+            return params;
+        },
+
+        /**
+         * Fetches the number of matching contents per type, provider, tag and duration.
+         * Failures only leave the filters with their previous options.
+         */
+        async fetchFacets() {
+            try {
+                const response = await fetch(`/api/v2/contents/facets?${this.filterParams()}`);
+
+                if (!response.ok) {
+                    throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+                }
+
+                this.facets = await response.json();
+            } catch (err) {
+                console.error('Failed to fetch facets:', err);
+            }
+        },
+
+        /**
+         * Returns the options of a facet filter. The selected value is kept even
+         * when nothing matches it anymore, so the select doesn't go blank.
+         * @param {Array<{value: string, count: number}>} counts - Facet counts
+         * @param {string} selected - Selected filter value
+         * @returns {Array<{value: string, count: number}>}
+         */
+        facetOptions(counts, selected) {
+            const options = counts || [];
+            if (selected !== 'all' && !options.some(o => o.value === selected)) {
+                return [...options, { value: selected, count: 0 }];
+            }
+
+            return options;
+        },
+
+        /**
+         * Restarts the search from the first page and refreshes the facets,
+         * after the query or a filter changed.
+         */
+        refresh() {
+            this.page = 1;
+            this.fetchContents();
+            this.fetchFacets();
+        },
+
+        /**
+         * Re-reads the current page from the database, re-populating the cache,
+         * e.g. to see curation or sync changes before the cached results expire.
+         */
+        reload() {
+            this.fetchContents(true);
+            this.fetchFacets();
+        },
+
         /**
          * Debounced fetch to avoid excessive API calls during typing.
          * Waits 300ms after the last keystroke before fetching.
          */
         debouncedFetch() {
             clearTimeout(this.debounceTimer);
-            this.debounceTimer = setTimeout(() => {
-                this.page = 1; // Reset to first page on new search
-                this.fetchContents();
-            }, 300);
+            this.debounceTimer = setTimeout(() => this.refresh(), 300);
+        },
+
+        /**
+         * Splits text into segments, flagging those matching a search term so
+         * they can be highlighted without rendering HTML.
+         * @param {string} text - Text to highlight
+         * @returns {Array<{text: string, match: boolean}>}
+         */
+        highlight(text) {
+            if (!text || this.queryTerms.length === 0) {
+                return [{ text: text || '', match: false }];
+            }
+
+            const escaped = this.queryTerms.map(t => t.replace(/[.*+?^${}()|[\]\\]/g, '\\$&'));
+            const pattern = new RegExp(`(${escaped.join('|')})`, 'gi');
+
+            return text.split(pattern)
+                .filter(Boolean)
+                .map(part => ({ text: part, match: this.queryTerms.includes(part.toLowerCase()) }));
         },
 
         /**
@@ -175,12 +320,316 @@ const app = Vue.createApp({
         },
 
         /**
-         * Triggers manual sync from all providers.
+         * Formats an ISO date string to a localized date and time.
+         * @param {string} dateStr - ISO date string
+         * @returns {string} Formatted date and time
+         */
+        formatDateTime(dateStr) {
+            if (!dateStr) return '-';
+
+            try {
+                const date = new Date(dateStr);
+                return date.toLocaleString('tr-TR', {
+                    year: 'numeric',
+                    month: 'short',
+                    day: 'numeric',
+                    hour: '2-digit',
+                    minute: '2-digit'
+                });
+            } catch {
+                return dateStr;
+            }
+        },
+
+        /**
+         * Returns the badge icon for a content type.
+         * @param {string} type - Content type
+         * @returns {string} Emoji icon
+         */
+        typeIcon(type) {
+            const icons = { video: '🎬', podcast: '🎙️', image: '🖼️' };
+            return icons[type] || '📄';
+        },
+
+        /**
+         * Returns the editorial flags of a content; uncurated contents get the defaults.
+         * @param {Object} content - Content from the search results
+         * @returns {{pinned: boolean, blocked: boolean, boost: number}}
+         */
+        curation(content) {
+            return this.curationById[content.id] || { pinned: false, blocked: false, boost: 1 };
+        },
+
+        /**
+         * Fetches the curated contents. Failures (e.g. admin routes requiring auth)
+         * only hide the curation state.
+         */
+        async fetchCurated() {
+            try {
+                const response = await fetch('/api/v2/admin/contents/curated');
+
+                if (!response.ok) {
+                    throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+                }
+
+                const data = await response.json();
+                this.curated = data.contents || [];
+            } catch (err) {
+                console.error('Failed to fetch curated contents:', err);
+                this.curated = [];
+            }
+        },
+
+        /**
+         * Changes the editorial flags of a content, then refreshes the results
+         * since its position or visibility changed.
+         * @param {string} id - Content ID
+         * @param {Object} patch - Flags to change: pinned, blocked and/or boost
+         */
+        async curate(id, patch) {
+            this.curating = true;
+            try {
+                const response = await fetch(`/api/v2/admin/contents/${id}`, {
+                    method: 'PATCH',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify(patch)
+                });
+
+                if (!response.ok) {
+                    throw new Error(`Curation failed: ${response.statusText}`);
+                }
+
+                await Promise.all([this.fetchCurated(), this.fetchContents()]);
+            } catch (err) {
+                console.error('Curation failed:', err);
+                alert('Curation failed: ' + err.message);
+            } finally {
+                this.curating = false;
+            }
+        },
+
+        /**
+         * Sets the boost of a content from the boost input.
+         * @param {string} id - Content ID
+         * @param {Event} event - Change event of the input
+         */
+        setBoost(id, event) {
+            const boost = parseFloat(event.target.value);
+            if (Number.isNaN(boost) || boost < 0.1 || boost > 10) {
+                alert('Boost must be between 0.1 and 10');
+                return;
+            }
+
+            this.curate(id, { boost });
+        },
+
+        /**
+         * Fetches the JSON of an admin chart endpoint for the selected period.
+         * @param {string} name - Chart endpoint under /api/v2/admin/metrics/ui
+         * @returns {Promise<Object>}
+         */
+        async fetchChartData(name) {
+            const response = await fetch(`/api/v2/admin/metrics/ui/${name}?days=${this.chartDays}`);
+
+            if (!response.ok) {
+                throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+            }
+
+            return response.json();
+        },
+
+        /**
+         * Fetches the history series and draws the charts. Failures (e.g. admin
+         * routes requiring auth, or Chart.js not loading) hide the charts.
+         */
+        async fetchCharts() {
+            if (typeof Chart === 'undefined') {
+                this.chartsAvailable = false;
+                return;
+            }
+
+            try {
+                const [growth, runs, failures] = await Promise.all([
+                    this.fetchChartData('content-growth'),
+                    this.fetchChartData('sync-runs'),
+                    this.fetchChartData('sync-failures')
+                ]);
+
+                this.chartsAvailable = true;
+                await this.$nextTick();
+
+                this.drawChart('growth', this.$refs.growthChart, {
+                    type: 'line',
+                    data: {
+                        labels: growth.points.map(p => p.day),
+                        datasets: [{
+                            label: 'Contents',
+                            data: growth.points.map(p => p.count),
+                            borderColor: chartColors[0],
+                            fill: false
+                        }]
+                    }
+                });
+
+                // One series per provider; runs are placed on a time axis in milliseconds
+                const providers = [...new Set(runs.runs.map(r => r.provider))];
+                this.drawChart('runs', this.$refs.runsChart, {
+                    type: 'line',
+                    data: {
+                        datasets: providers.map((provider, i) => ({
+                            label: provider,
+                            data: runs.runs
+                                .filter(r => r.provider === provider)
+                                .map(r => ({ x: Date.parse(r.finished_at), y: r.count })),
+                            borderColor: chartColors[i % chartColors.length],
+                            showLine: true
+                        }))
+                    },
+                    options: {
+                        scales: {
+                            x: {
+                                type: 'linear',
+                                ticks: { callback: value => this.formatDate(new Date(value).toISOString()) }
+                            }
+                        }
+                    }
+                });
+
+                this.drawChart('failures', this.$refs.failuresChart, {
+                    type: 'bar',
+                    data: {
+                        labels: failures.days,
+                        datasets: failures.providers.map((p, i) => ({
+                            label: p.provider,
+                            data: p.failures,
+                            backgroundColor: chartColors[i % chartColors.length]
+                        }))
+                    },
+                    options: {
+                        scales: { x: { stacked: true }, y: { stacked: true, ticks: { precision: 0 } } }
+                    }
+                });
+            } catch (err) {
+                console.error('Failed to fetch history charts:', err);
+                this.chartsAvailable = false;
+            }
+        },
+
+        /**
+         * Draws a chart on a canvas, replacing the one drawn there before.
+         * @param {string} name - Chart name
+         * @param {HTMLCanvasElement} canvas - Canvas to draw on
+         * @param {Object} config - Chart.js configuration
+         */
+        drawChart(name, canvas, config) {
+            if (charts[name]) {
+                charts[name].destroy();
+            }
+
+            config.options = {
+                responsive: true,
+                maintainAspectRatio: false,
+                plugins: { legend: { display: config.data.datasets.length > 1 } },
+                ...config.options
+            };
+            charts[name] = new Chart(canvas, config);
+        },
+
+        /**
+         * Opens the dashboard socket, reconnecting whenever it closes.
+         */
+        connectLive() {
+            const scheme = window.location.protocol === 'https:' ? 'wss' : 'ws';
+            const socket = new WebSocket(`${scheme}://${window.location.host}/dashboard/ws`);
+
+            socket.onmessage = event => this.handleLiveUpdate(JSON.parse(event.data));
+            socket.onclose = () => {
+                this.socket = null;
+                this.syncProgress = null;
+                setTimeout(() => this.connectLive(), liveReconnectDelay);
+            };
+            socket.onopen = () => {
+                this.socket = socket;
+            };
+        },
+
+        /**
+         * Applies an update pushed over the dashboard socket.
+         * @param {Object} update - Update with a type of sync.started, sync.progress,
+         *   sync.completed or stats
+         */
+        handleLiveUpdate(update) {
+            switch (update.type) {
+                case 'sync.started':
+                    this.syncProgress = { providers: update.providers || [], done: 0, total: 0 };
+                    break;
+                case 'sync.progress':
+                    if (this.syncProgress) {
+                        this.syncProgress.done = update.done;
+                        this.syncProgress.total = update.total;
+                    }
+                    break;
+                case 'sync.completed':
+                    // Synced contents change results, filters and history
+                    this.syncProgress = null;
+                    this.fetchContents();
+                    this.fetchFacets();
+                    this.fetchCharts();
+                    break;
+                case 'stats':
+                    this.contentCount = update.state.content_count;
+                    this.providers = update.state.providers || [];
+                    break;
+            }
+        },
+
+        /**
+         * Whether the dashboard socket is open, so sync results will be pushed.
+         * @returns {boolean}
+         */
+        isLive() {
+            return this.socket !== null && this.socket.readyState === WebSocket.OPEN;
+        },
+
+        /**
+         * Triggers a manual sync of a single provider. Its result reaches the
+         * providers panel over the dashboard socket, or by reloading the page
+         * when the socket isn't open.
+         * @param {string} name - Provider name
+         */
+        async syncProvider(name) {
+            this.syncingProvider = name;
+            try {
+                const response = await fetch(`/api/v2/admin/sync/${encodeURIComponent(name)}`, {
+                    method: 'POST'
+                });
+
+                if (!response.ok) {
+                    throw new Error(`Sync failed: ${response.statusText}`);
+                }
+
+                const data = await response.json();
+                console.log('Provider sync completed:', data);
+
+                if (!this.isLive()) {
+                    window.location.reload();
+                }
+            } catch (err) {
+                console.error('Provider sync failed:', err);
+                alert('Sync of ' + name + ' failed: ' + err.message);
+            } finally {
+                this.syncingProvider = null;
+            }
+        },
+
+        /**
+         * Triggers manual sync from all providers. Like syncProvider, falls back
+         * to reloading the page when the dashboard socket isn't open.
          */
         async syncProviders() {
             this.syncing = true;
             try {
-                const response = await fetch('/api/v1/admin/sync', {
+                const response = await fetch('/api/v2/admin/sync', {
                     method: 'POST'
                 });
 
@@ -191,11 +640,9 @@ const app = Vue.createApp({
                 const data = await response.json();
                 console.log('Sync completed:', data);
 
-                // Refresh content list after sync
-                await this.fetchContents();
-
-                // Reload page to update total count in header
-                window.location.reload();
+                if (!this.isLive()) {
+                    window.location.reload();
+                }
             } catch (err) {
                 console.error('Sync failed:', err);
                 alert('Sync failed: ' + err.message);
@@ -223,19 +670,51 @@ const app = Vue.createApp({
         },
 
         /**
-         * When type filter changes, reset to page 1 and fetch.
+         * When the search mode changes, search again if there is a query.
+         */
+        searchMode() {
+            if (this.query.trim()) {
+                this.refresh();
+            }
+        },
+
+        /**
+         * When a filter changes, reset to page 1 and fetch with new facets.
          */
         typeFilter() {
-            this.page = 1;
-            this.fetchContents();
+            this.refresh();
+        },
+
+        providerFilter() {
+            this.refresh();
+        },
+
+        tagFilter() {
+            this.refresh();
+        },
+
+        durationFilter() {
+            this.refresh();
+        },
+
+        /**
+         * When the charted period changes, redraw the history charts.
+         */
+        chartDays() {
+            this.fetchCharts();
         }
     },
 
     /**
-     * Lifecycle hook - fetch contents on mount.
+     * Lifecycle hook - fetch contents and facets on mount, and start
+     * receiving live updates.
      */
     mounted() {
         this.fetchContents();
+        this.fetchFacets();
+        this.fetchCurated();
+        this.fetchCharts();
+        this.connectLive();
     }
 });
 
@@ -0,0 +1,10 @@
+// Package web holds the dashboard's templates and static files, embedded in the
+// binary so it doesn't depend on the working directory.
+package web
+
+import "embed"
+
+// Assets holds the templates and static directories.
+//
+//go:embed templates static
+var Assets embed.FS